@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/config"
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+const apiKeyUsage = "usage: main apikey create [--admin] <label> | list | revoke <id> | role <id> <user|admin>"
+
+// cfg.RepositoryのデータベースでAPIキーを発行・一覧表示・失効させ、権限を変更する。
+// 発行したキーそのものは保存しないため、createの出力でのみ確認できる。--adminを指定しない限り、キーは一般の権限で発行する
+func runAPIKey(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(apiKeyUsage)
+	}
+	if cfg.Repository == "memory" {
+		return errors.New("REPOSITORY=memory does not persist API keys; a development key is issued at server startup")
+	}
+
+	switch args[0] {
+	case "create":
+		if len(args) < 2 {
+			return errors.New(apiKeyUsage)
+		}
+	case "list":
+		if len(args) > 1 {
+			return errors.New(apiKeyUsage)
+		}
+	case "revoke":
+		if len(args) != 2 {
+			return errors.New(apiKeyUsage)
+		}
+	case "role":
+		if len(args) != 3 {
+			return errors.New(apiKeyUsage)
+		}
+	default:
+		return errors.New(apiKeyUsage)
+	}
+
+	handler, dialect, err := databaseInfra.NewHandler(ctx, cfg.Repository, cfg.DatabaseDSN(), databaseInfra.ConnectConfig{Attempts: cfg.DBConnectAttempts, Interval: cfg.DBConnectInterval})
+	if err != nil {
+		return err
+	}
+	defer handler.Close()
+
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(&itemDatabase.APIKeyRepository{SqlHandler: handler, Dialect: dialect})
+
+	switch args[0] {
+	case "create":
+		role, labelArgs := entity.UserRoleUser, args[1:]
+		if labelArgs[0] == "--admin" {
+			role, labelArgs = entity.UserRoleAdmin, labelArgs[1:]
+		}
+		// ラベルは空白を含めて指定できるよう、残りの引数をつなげる
+		apiKey, key, err := apiKeyUsecase.CreateAPIKey(ctx, strings.Join(labelArgs, " "), role)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created API key %d (%s, %s)\n", apiKey.ID, apiKey.Label, apiKey.Role)
+		fmt.Println(key)
+		fmt.Fprintln(os.Stderr, "Store the key now; it cannot be shown again.")
+		return nil
+	case "list":
+		keys, err := apiKeyUsecase.ListAPIKeys(ctx)
+		if err != nil {
+			return err
+		}
+		return printAPIKeys(keys)
+	}
+
+	id, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil || id <= 0 {
+		return fmt.Errorf("id must be a positive integer: %s", args[1])
+	}
+	switch args[0] {
+	case "role":
+		role, err := entity.ParseUserRole(args[2])
+		if err != nil {
+			return err
+		}
+		apiKey, err := apiKeyUsecase.SetAPIKeyRole(ctx, id, role)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Changed the role of API key %d (%s) to %s\n", apiKey.ID, apiKey.Label, apiKey.Role)
+		return nil
+	default:
+		apiKey, err := apiKeyUsecase.RevokeAPIKey(ctx, id)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Revoked API key %d (%s)\n", apiKey.ID, apiKey.Label)
+		return nil
+	}
+}
+
+func printAPIKeys(keys []*entity.APIKey) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tLABEL\tROLE\tCREATED AT\tREVOKED AT")
+	for _, k := range keys {
+		revokedAt := "-"
+		if k.RevokedAt != nil {
+			revokedAt = k.RevokedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", k.ID, k.Label, k.Role, k.CreatedAt.Format("2006-01-02 15:04:05"), revokedAt)
+	}
+	return w.Flush()
+}
@@ -2,17 +2,75 @@ package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
 
+	"Aicon-assignment/internal/infrastructure/config"
+	"Aicon-assignment/internal/infrastructure/logging"
 	"Aicon-assignment/internal/infrastructure/server"
+	"Aicon-assignment/internal/infrastructure/tracing"
 )
 
 func main() {
 	ctx := context.Background()
 
-	server := server.NewServer()
+	// 不足している・不正な環境変数はまとめて出力して終了する
+	cfg, err := config.Load()
+	if err != nil {
+		var invalid *config.ValidationError
+		if errors.As(err, &invalid) {
+			slog.Error("Invalid configuration", "problems", invalid.Problems)
+		} else {
+			slog.Error("Failed to load configuration", "error", err)
+		}
+		os.Exit(1)
+	}
+
+	// リクエストの処理中のログにトレースのIDとリクエストの属性を付ける。log.Printfの出力も同じハンドラーで出力する。
+	// レベルは実行中に/admin/log-levelで変更できる
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(cfg.LogLevel)
+	slog.SetDefault(slog.New(tracing.NewLogHandler(logging.NewHandler(os.Stderr, cfg.LogFormat, logLevel))))
+
+	// 起動時の設定。パスワードなどの秘密の値は伏せる
+	var summary strings.Builder
+	if err := config.Print(&summary, cfg, true); err == nil {
+		slog.Info("Loaded configuration", "config", summary.String())
+	}
+
+	// main migrate up | down [steps] | status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, cfg, os.Args[2:]); err != nil {
+			slog.Error("Migration failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// main apikey create <label> | list | revoke <id>
+	if len(os.Args) > 1 && os.Args[1] == "apikey" {
+		if err := runAPIKey(ctx, cfg, os.Args[2:]); err != nil {
+			slog.Error("API key command failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// main user list | passwd <email>
+	if len(os.Args) > 1 && os.Args[1] == "user" {
+		if err := runUser(ctx, cfg, os.Args[2:]); err != nil {
+			slog.Error("User command failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	server := server.NewServer(cfg, logLevel)
 
 	if err := server.Run(ctx); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+		slog.Error("Failed to start server", "error", err)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"Aicon-assignment/internal/infrastructure/config"
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/infrastructure/migration"
+)
+
+const migrateUsage = "usage: main migrate up | down [steps] | status"
+
+// cfg.Repositoryのデータベースにマイグレーションを適用・取り消し、または適用状況を表示する
+func runMigrate(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	if cfg.Repository == "memory" {
+		return errors.New("REPOSITORY=memory has no migrations")
+	}
+
+	steps := 1
+	switch args[0] {
+	case "up", "status":
+		if len(args) > 1 {
+			return errors.New(migrateUsage)
+		}
+	case "down":
+		if len(args) > 2 {
+			return errors.New(migrateUsage)
+		}
+		if len(args) == 2 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("steps must be a positive integer: %s", args[1])
+			}
+			steps = n
+		}
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	handler, _, err := databaseInfra.NewHandler(ctx, cfg.Repository, cfg.DatabaseDSN(), databaseInfra.ConnectConfig{Attempts: cfg.DBConnectAttempts, Interval: cfg.DBConnectInterval})
+	if err != nil {
+		return err
+	}
+	defer handler.Close()
+
+	migrator, err := migration.New(handler, cfg.Repository)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		printMigrations("Applied", applied)
+		if err == nil && len(applied) == 0 {
+			fmt.Println("No pending migrations")
+		}
+		return err
+	case "down":
+		rolledBack, err := migrator.Down(ctx, steps)
+		printMigrations("Rolled back", rolledBack)
+		if err == nil && len(rolledBack) == 0 {
+			fmt.Println("No applied migrations")
+		}
+		return err
+	default:
+		return printStatus(ctx, migrator)
+	}
+}
+
+func printMigrations(action string, migrations []migration.Migration) {
+	for _, m := range migrations {
+		fmt.Printf("%s %04d_%s\n", action, m.Version, m.Name)
+	}
+}
+
+func printStatus(ctx context.Context, migrator *migration.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: %d pending\n", migrator.Backend(), migration.Pending(statuses))
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/config"
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+const userUsage = "usage: main user list | passwd <email> | role <email> <user|admin>"
+
+// cfg.Repositoryのデータベースのユーザーを一覧表示し、パスワードと権限を設定する。
+// passwdのパスワードはコマンドの履歴に残らないよう、標準入力の1行目から読み込む
+func runUser(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(userUsage)
+	}
+	if cfg.Repository == "memory" {
+		return errors.New("REPOSITORY=memory does not persist users; register with POST /auth/register instead")
+	}
+
+	switch args[0] {
+	case "list":
+		if len(args) > 1 {
+			return errors.New(userUsage)
+		}
+	case "passwd":
+		if len(args) != 2 {
+			return errors.New(userUsage)
+		}
+	case "role":
+		if len(args) != 3 {
+			return errors.New(userUsage)
+		}
+	default:
+		return errors.New(userUsage)
+	}
+
+	handler, dialect, err := databaseInfra.NewHandler(ctx, cfg.Repository, cfg.DatabaseDSN(), databaseInfra.ConnectConfig{Attempts: cfg.DBConnectAttempts, Interval: cfg.DBConnectInterval})
+	if err != nil {
+		return err
+	}
+	defer handler.Close()
+
+	// トークンは発行しないため、署名の鍵は使わない
+	authUsecase := usecase.NewAuthUsecase(&itemDatabase.UserRepository{SqlHandler: handler, Dialect: dialect}, nil, usecase.AuthConfig{})
+
+	if args[0] == "list" {
+		users, err := authUsecase.ListUsers(ctx)
+		if err != nil {
+			return err
+		}
+		return printUsers(users)
+	}
+
+	if args[0] == "role" {
+		role, err := entity.ParseUserRole(args[2])
+		if err != nil {
+			return err
+		}
+		user, err := authUsecase.SetRole(ctx, args[1], role)
+		if err != nil {
+			return err
+		}
+		// 発行済みのアクセストークンには、期限が切れて再発行するまで反映されない
+		fmt.Printf("Changed the role of user %d (%s) to %s\n", user.ID, user.Email, user.Role)
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, "Enter the new password:")
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == "" {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	user, err := authUsecase.SetPassword(ctx, args[1], strings.TrimRight(password, "\r\n"))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Updated the password of user %d (%s)\n", user.ID, user.Email)
+	return nil
+}
+
+func printUsers(users []*entity.User) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tEMAIL\tROLE\tPASSWORD\tCREATED AT")
+	for _, u := range users {
+		// パスワードのない既定のユーザーは、passwdで設定するまでログインできない
+		password := "set"
+		if u.PasswordHash == "" {
+			password = "-"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", u.ID, u.Email, u.Role, password, u.CreatedAt.Format("2006-01-02 15:04:05"))
+	}
+	return w.Flush()
+}
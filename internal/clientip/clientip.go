@@ -0,0 +1,17 @@
+// リクエストの送信元のIPアドレスをctxで受け渡す。HTTPに依存しないため、ユースケースやリポジトリからも参照できる
+package clientip
+
+import "context"
+
+type contextKey struct{}
+
+// ipを送信元のIPアドレスとして持つctxを返す
+func NewContext(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, contextKey{}, ip)
+}
+
+// ctxの送信元のIPアドレス。リクエストの処理中でない場合は空文字列
+func From(ctx context.Context) string {
+	ip, _ := ctx.Value(contextKey{}).(string)
+	return ip
+}
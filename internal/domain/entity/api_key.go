@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// APIキーのラベルの最大文字数
+const MaxAPIKeyLabelLength = 100
+
+// 発行するAPIキーの接頭辞。ログや設定ファイルに紛れ込んだキーを見つけやすくする
+const APIKeyPrefix = "aicon_"
+
+// APIキーのランダムな部分のバイト数
+const apiKeyRandomBytes = 32
+
+// APIを呼び出すクライアントの認証に使うキー。キーそのものは発行時に一度だけ返し、SHA-256のハッシュ値のみを保存する。
+// Labelはキーの用途や持ち主を表し、ログと監査に記録する。RevokedAtが設定されたキーは認証に使えない。
+// Roleはユーザーと同じ権限で、UserRoleUserのキーは既定のユーザー（DefaultUserID）のアイテムのみを扱う
+type APIKey struct {
+	ID        int64      `json:"id"`
+	Label     string     `json:"label"`
+	Role      UserRole   `json:"role"`
+	KeyHash   string     `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+// 新しいAPIキーを作成し、キーそのものとともに返す。キーは保存しないため、呼び出し元で一度だけ利用者に伝える
+func NewAPIKey(label string, role UserRole) (*APIKey, string, error) {
+	apiKey := &APIKey{
+		Label:     strings.TrimSpace(label),
+		Role:      role,
+		CreatedAt: Now(),
+	}
+	if err := apiKey.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	b := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	apiKey.KeyHash = HashAPIKey(key)
+
+	return apiKey, key, nil
+}
+
+// APIキーを保存・照合するハッシュ値（SHA-256の16進数）
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// 失効済みかどうか
+func (k *APIKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// APIKeyフィールドのバリデーション
+func (k *APIKey) Validate() error {
+	var errs domainErrors.ValidationErrors
+
+	if k.Label == "" {
+		errs.Append(domainErrors.Required("label"))
+	} else if utf8.RuneCountInString(k.Label) > MaxAPIKeyLabelLength {
+		errs.Append(domainErrors.TooLong("label", MaxAPIKeyLabelLength))
+	}
+	if !k.Role.Valid() {
+		errs.Append(domainErrors.OneOf("role", []string{string(UserRoleUser), string(UserRoleAdmin)}))
+	}
+
+	return errs.Err()
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	apiKey, key, err := NewAPIKey(" batch ", UserRoleUser)
+	require.NoError(t, err)
+
+	assert.Equal(t, "batch", apiKey.Label)
+	assert.Equal(t, UserRoleUser, apiKey.Role)
+	assert.True(t, strings.HasPrefix(key, APIKeyPrefix))
+	// キーそのものではなくハッシュ値を保持する
+	assert.Equal(t, HashAPIKey(key), apiKey.KeyHash)
+	assert.NotContains(t, apiKey.KeyHash, key)
+	assert.Len(t, apiKey.KeyHash, 64)
+	assert.False(t, apiKey.Revoked())
+
+	// 発行するたびに異なるキーになる
+	_, other, err := NewAPIKey("batch", UserRoleAdmin)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestNewAPIKey_InvalidLabel(t *testing.T) {
+	for _, label := range []string{"  ", strings.Repeat("a", MaxAPIKeyLabelLength+1)} {
+		_, _, err := NewAPIKey(label, UserRoleUser)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	}
+}
+
+func TestNewAPIKey_InvalidRole(t *testing.T) {
+	for _, role := range []UserRole{"", "owner"} {
+		_, _, err := NewAPIKey("batch", role)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	}
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 監査ログに記録する操作
+const (
+	AuditActionCreate     = "create"
+	AuditActionUpdate     = "update"
+	AuditActionDelete     = "delete"
+	AuditActionImport     = "import"
+	AuditActionRestore    = "restore"
+	AuditActionHardDelete = "hard_delete"
+)
+
+var ValidAuditActions = []string{AuditActionCreate, AuditActionUpdate, AuditActionDelete, AuditActionImport, AuditActionRestore, AuditActionHardDelete}
+
+// 認証していない呼び出し（認証を無効にした場合）の操作者
+const AuditActorAnonymous = "anonymous"
+
+// サーバー内のバックグラウンドの処理（principal.System）の操作者
+const AuditActorSystem = "system"
+
+// ユーザーの操作者の表記。「user:<ユーザーのID>」
+func AuditActorUser(userID int64) string {
+	return "user:" + strconv.FormatInt(userID, 10)
+}
+
+// APIキーの操作者の表記。「api_key:<キーのラベル>」
+func AuditActorAPIKey(label string) string {
+	return "api_key:" + label
+}
+
+// アイテムの変更の監査ログ。誰が（Actor）どこから（SourceIP）いつどのアイテムを操作したかを記録する
+type AuditLog struct {
+	ID        int64     `json:"id"`
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	ItemID    int64     `json:"item_id"`
+	SourceIP  string    `json:"source_ip"` // 送信元が分からない場合（バックグラウンドの処理など）は空文字列
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// 監査ログの絞り込み条件。空の項目では絞り込まない。Fromの時刻を含み、Toの時刻を含まない
+type AuditLogFilter struct {
+	Actor  string
+	Action string
+	From   *time.Time
+	To     *time.Time
+}
+
+// 絞り込み条件のバリデーション
+func (f AuditLogFilter) Validate() error {
+	var errs []string
+
+	if f.Action != "" && !contains(ValidAuditActions, f.Action) {
+		errs = append(errs, "action must be one of: "+strings.Join(ValidAuditActions, ", "))
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		errs = append(errs, "from must be before to")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// createdAtの監査ログが絞り込み条件の期間に含まれるかどうか
+func (f AuditLogFilter) InRange(createdAt time.Time) bool {
+	if f.From != nil && createdAt.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && !createdAt.Before(*f.To) {
+		return false
+	}
+	return true
+}
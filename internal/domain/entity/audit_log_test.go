@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditActor(t *testing.T) {
+	assert.Equal(t, "user:2", AuditActorUser(2))
+	assert.Equal(t, "api_key:ci", AuditActorAPIKey("ci"))
+}
+
+func TestAuditLogFilter_Validate(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, AuditLogFilter{}.Validate())
+	assert.NoError(t, AuditLogFilter{Action: AuditActionImport, From: &jan, To: &feb}.Validate())
+	assert.EqualError(t, AuditLogFilter{Action: "read"}.Validate(), "action must be one of: create, update, delete, import, restore, hard_delete")
+	assert.EqualError(t, AuditLogFilter{From: &feb, To: &jan}.Validate(), "from must be before to")
+	assert.Error(t, AuditLogFilter{From: &jan, To: &jan}.Validate())
+}
+
+func TestAuditLogFilter_InRange(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	filter := AuditLogFilter{From: &jan, To: &feb}
+
+	assert.True(t, filter.InRange(jan))
+	assert.True(t, filter.InRange(feb.Add(-time.Second)))
+	assert.False(t, filter.InRange(feb))
+	assert.False(t, filter.InRange(jan.Add(-time.Second)))
+	assert.True(t, AuditLogFilter{}.InRange(jan))
+}
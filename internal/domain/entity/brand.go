@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ブランド名・別名の最大文字数。アイテムのbrandにそのまま保存できるよう、アイテムのブランドと同じ上限とする
+const MaxBrandNameLength = 100
+
+// 1つのブランドに登録できる別名の上限
+const MaxBrandAliases = 20
+
+// アイテムの登録・更新時のブランドの検証モード
+const (
+	BrandValidationOff    = "off"    // 検証しない
+	BrandValidationSoft   = "soft"   // 未登録のブランドでも保存し、警告を返す
+	BrandValidationStrict = "strict" // 未登録のブランドは422とする
+)
+
+// 正式なブランド名と別名（表記ゆれや略称）。名前と別名は大文字小文字を区別せず、すべてのブランドを通じて一意
+type Brand struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Aliases   []string  `json:"aliases"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewBrand(name string, aliases []string) (*Brand, error) {
+	brand := &Brand{
+		Name:      NormalizeBrandName(name),
+		CreatedAt: Now(),
+		UpdatedAt: Now(),
+	}
+	brand.Aliases = NormalizeBrandAliases(brand.Name, aliases)
+
+	if err := brand.Validate(); err != nil {
+		return nil, err
+	}
+
+	return brand, nil
+}
+
+// ブランドフィールドのバリデーション
+func (b *Brand) Validate() error {
+	var errs domainErrors.ValidationErrors
+
+	if b.Name == "" {
+		errs.Append(domainErrors.Required("name"))
+	} else if utf8.RuneCountInString(b.Name) > MaxBrandNameLength {
+		errs.Append(domainErrors.TooLong("name", MaxBrandNameLength))
+	}
+
+	if len(b.Aliases) > MaxBrandAliases {
+		errs.Append(domainErrors.NewRuleError("aliases", domainErrors.CodeTooMany, fmt.Sprintf("a brand can have at most %d aliases", MaxBrandAliases), map[string]interface{}{"max": MaxBrandAliases}))
+	}
+	for _, alias := range b.Aliases {
+		if utf8.RuneCountInString(alias) > MaxBrandNameLength {
+			errs.Append(domainErrors.NewRuleError("aliases", domainErrors.CodeTooLong, fmt.Sprintf("each alias must be %d characters or less", MaxBrandNameLength), map[string]interface{}{"max": MaxBrandNameLength}))
+			break
+		}
+	}
+
+	return errs.Err()
+}
+
+// アイテムのブランド名と同じく正規化し、空の別名と、名前や他の別名と大文字小文字のみが異なる別名を取り除いて名前順に並べる
+func NormalizeBrandAliases(name string, aliases []string) []string {
+	seen := map[string]bool{strings.ToLower(name): true}
+	normalized := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		alias = NormalizeBrandName(alias)
+		key := strings.ToLower(alias)
+		if alias == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, alias)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestNewBrand(t *testing.T) {
+	tests := []struct {
+		name            string
+		brandName       string
+		aliases         []string
+		expectedAliases []string
+		expectedErr     string
+	}{
+		{
+			name:            "正常系: 別名を正規化して登録",
+			brandName:       " ROLEX ",
+			aliases:         []string{" ロレックス", "rolex", "", "Rolex Watch", "ロレックス"},
+			expectedAliases: []string{"Rolex Watch", "ロレックス"},
+		},
+		{
+			name:            "正常系: 別名なし",
+			brandName:       "HERMÈS",
+			expectedAliases: []string{},
+		},
+		{
+			name:            "正常系: 日本語100文字の名前と別名",
+			brandName:       strings.Repeat("ロ", MaxBrandNameLength),
+			aliases:         []string{strings.Repeat("レ", MaxBrandNameLength)},
+			expectedAliases: []string{strings.Repeat("レ", MaxBrandNameLength)},
+		},
+		{
+			name:        "異常系: 名前が空",
+			brandName:   " ",
+			expectedErr: "name is required",
+		},
+		{
+			name:        "異常系: 名前が長すぎる",
+			brandName:   strings.Repeat("ロ", MaxBrandNameLength+1),
+			expectedErr: "name must be 100 characters or less",
+		},
+		{
+			name:        "異常系: 別名が長すぎる",
+			brandName:   "ROLEX",
+			aliases:     []string{strings.Repeat("レ", MaxBrandNameLength+1)},
+			expectedErr: "each alias must be 100 characters or less",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			brand, err := NewBrand(tt.brandName, tt.aliases)
+
+			if tt.expectedErr != "" {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, brand)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, strings.TrimSpace(tt.brandName), brand.Name)
+			assert.Equal(t, tt.expectedAliases, brand.Aliases)
+		})
+	}
+}
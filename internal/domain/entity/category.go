@@ -0,0 +1,142 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// カテゴリー名（日本語・英語）とスラッグの最大文字数
+const MaxCategoryNameLength = 50
+
+// スラッグは英小文字・数字をハイフンでつないだもの（例: watch, fine-jewelry）
+var categorySlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Nameはアイテムに保存する日本語の表示名。Slugは変更されない英字の識別子で、NameEnは英語の表示名
+type Category struct {
+	ID        int64     `json:"id"`
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	NameEn    string    `json:"name_en"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// slugは小文字に揃える
+func NewCategory(name, slug, nameEn string) (*Category, error) {
+	category := &Category{
+		Slug:      strings.ToLower(strings.TrimSpace(slug)),
+		Name:      strings.TrimSpace(name),
+		NameEn:    strings.TrimSpace(nameEn),
+		CreatedAt: Now(),
+		UpdatedAt: Now(),
+	}
+
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// カテゴリーフィールドのバリデーション
+func (c *Category) Validate() error {
+	if err := ValidateCategoryName(c.Name); err != nil {
+		return err
+	}
+	if c.Slug == "" {
+		return errors.New("slug is required")
+	}
+	if len(c.Slug) > MaxCategoryNameLength || !categorySlugPattern.MatchString(c.Slug) {
+		return errors.New("slug must be 50 characters or less of lowercase letters, digits and hyphens")
+	}
+	if c.NameEn == "" {
+		return errors.New("name_en is required")
+	}
+	if utf8.RuneCountInString(c.NameEn) > MaxCategoryNameLength {
+		return errors.New("name_en must be 50 characters or less")
+	}
+
+	return nil
+}
+
+// 日本語の表示名の検証。名前の変更時にも使う
+func ValidateCategoryName(name string) error {
+	if name == "" {
+		return errors.New("name is required")
+	}
+	if utf8.RuneCountInString(name) > MaxCategoryNameLength {
+		return errors.New("name must be 50 characters or less")
+	}
+
+	return nil
+}
+
+// アイテムのカテゴリーの検証に使う、登録済みカテゴリーの参照
+type CategoryLookup interface {
+	// スラッグ（大文字小文字を区別しない）か日本語の表示名に一致するカテゴリーを返す
+	Resolve(value string) (*Category, bool)
+	Contains(name string) bool
+	Names() []string
+}
+
+// 登録済みカテゴリーの集合。データベースから取得した一覧から作成する
+type CategorySet struct {
+	categories []*Category
+	byName     map[string]*Category
+	bySlug     map[string]*Category
+}
+
+// 表示名のみのカテゴリーの集合を作成する
+func NewCategorySet(names ...string) *CategorySet {
+	categories := make([]*Category, len(names))
+	for i, name := range names {
+		categories[i] = &Category{Name: name}
+	}
+	return NewCategorySetFrom(categories)
+}
+
+// カテゴリー一覧から集合を作成する。順序は一覧の順を保つ
+func NewCategorySetFrom(categories []*Category) *CategorySet {
+	set := &CategorySet{
+		categories: make([]*Category, 0, len(categories)),
+		byName:     make(map[string]*Category, len(categories)),
+		bySlug:     make(map[string]*Category, len(categories)),
+	}
+	for _, category := range categories {
+		if _, ok := set.byName[category.Name]; ok {
+			continue
+		}
+		set.byName[category.Name] = category
+		if category.Slug != "" {
+			set.bySlug[category.Slug] = category
+		}
+		set.categories = append(set.categories, category)
+	}
+	return set
+}
+
+func (s *CategorySet) Resolve(value string) (*Category, bool) {
+	if category, ok := s.byName[value]; ok {
+		return category, true
+	}
+	category, ok := s.bySlug[strings.ToLower(value)]
+	return category, ok
+}
+
+// nameがスラッグか表示名として登録済みかどうか
+func (s *CategorySet) Contains(name string) bool {
+	_, ok := s.Resolve(name)
+	return ok
+}
+
+// 日本語の表示名の一覧
+func (s *CategorySet) Names() []string {
+	names := make([]string, len(s.categories))
+	for i, category := range s.categories {
+		names[i] = category.Name
+	}
+	return names
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCategory(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		slug        string
+		nameEn      string
+		expected    string
+		expectedErr string
+	}{
+		{name: "正常系: 前後の空白を除去", input: " アクセサリー ", slug: "accessory", nameEn: "Accessory", expected: "アクセサリー"},
+		{name: "正常系: 50文字", input: strings.Repeat("あ", 50), slug: "long", nameEn: "Long", expected: strings.Repeat("あ", 50)},
+		{name: "正常系: ハイフンでつないだスラッグ", input: "高級ジュエリー", slug: "fine-jewelry", nameEn: "Fine Jewelry", expected: "高級ジュエリー"},
+		{name: "異常系: 空文字", input: "  ", slug: "accessory", nameEn: "Accessory", expectedErr: "name is required"},
+		{name: "異常系: 51文字", input: strings.Repeat("あ", 51), slug: "long", nameEn: "Long", expectedErr: "name must be 50 characters or less"},
+		{name: "異常系: スラッグが空", input: "アクセサリー", nameEn: "Accessory", expectedErr: "slug is required"},
+		{name: "異常系: スラッグに使えない文字", input: "アクセサリー", slug: "acc_essory", nameEn: "Accessory", expectedErr: "slug must be 50 characters or less of lowercase letters, digits and hyphens"},
+		{name: "異常系: 英語名が空", input: "アクセサリー", slug: "accessory", expectedErr: "name_en is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, err := NewCategory(tt.input, tt.slug, tt.nameEn)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, category)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, category.Name)
+		})
+	}
+}
+
+func TestCategorySet(t *testing.T) {
+	set := NewCategorySetFrom([]*Category{
+		{ID: 1, Name: "時計"},
+		{ID: 2, Name: "バッグ"},
+		{ID: 3, Name: "時計"},
+	})
+
+	assert.True(t, set.Contains("時計"))
+	assert.True(t, set.Contains("バッグ"))
+	assert.False(t, set.Contains("アクセサリー"))
+	assert.Equal(t, []string{"時計", "バッグ"}, set.Names())
+}
+
+func TestCategorySet_Resolve(t *testing.T) {
+	set := NewCategorySetFrom([]*Category{
+		{ID: 1, Slug: "watch", Name: "時計", NameEn: "Watch"},
+		{ID: 2, Slug: "bag", Name: "バッグ", NameEn: "Bag"},
+	})
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "正常系: 表示名", value: "時計", expected: "時計"},
+		{name: "正常系: スラッグ", value: "bag", expected: "バッグ"},
+		{name: "正常系: 大文字のスラッグ", value: "WATCH", expected: "時計"},
+		{name: "異常系: 未登録", value: "shoes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, ok := set.Resolve(tt.value)
+
+			if tt.expected == "" {
+				assert.False(t, ok)
+				assert.False(t, set.Contains(tt.value))
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, category.Name)
+			assert.True(t, set.Contains(tt.value))
+		})
+	}
+}
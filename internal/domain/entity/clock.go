@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// 現在時刻の取得元。テストでは固定の時刻を返す実装に差し替える
+type Clock interface {
+	Now() time.Time
+}
+
+// 実際の現在時刻を返すClock
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// 作成・更新日時の記録と「今日」の判定に使うClock
+var clock Clock = SystemClock{}
+
+// 使うClockを差し替え、元に戻す関数を返す。テストではt.Cleanupに渡す
+func SetClock(c Clock) (restore func()) {
+	previous := clock
+	clock = c
+	return func() {
+		clock = previous
+	}
+}
+
+// 現在時刻。ドメインの外でもエンティティと同じ時刻を使う場合に呼ぶ
+func Now() time.Time {
+	return clock.Now()
+}
@@ -0,0 +1,18 @@
+package entity
+
+// アイテムの状態
+const (
+	ConditionNew    = "新品"
+	ConditionUnused = "未使用"
+	ConditionUsedA  = "中古A"
+	ConditionUsedB  = "中古B"
+	ConditionUsedC  = "中古C"
+)
+
+// 指定できる状態。集計ではこの順に並べる
+var ValidConditions = []string{ConditionNew, ConditionUnused, ConditionUsedA, ConditionUsedB, ConditionUsedC}
+
+// 状態のバリデーション
+func IsValidCondition(condition string) bool {
+	return contains(ValidConditions, condition)
+}
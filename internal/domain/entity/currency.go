@@ -0,0 +1,28 @@
+package entity
+
+import "strings"
+
+// 通貨が指定されなかった場合に使う通貨
+const DefaultCurrency = "JPY"
+
+// 購入価格に指定できる通貨（ISO 4217）
+var SupportedCurrencies = []string{"JPY", "USD", "EUR", "GBP", "CHF"}
+
+// 通貨コードを大文字に正規化する。空の場合はデフォルトの通貨を返す
+func NormalizeCurrency(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return DefaultCurrency
+	}
+	return currency
+}
+
+// 通貨コードのバリデーション
+func IsSupportedCurrency(currency string) bool {
+	for _, supported := range SupportedCurrencies {
+		if currency == supported {
+			return true
+		}
+	}
+	return false
+}
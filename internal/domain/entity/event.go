@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+// アイテムの変更の種類
+type EventType string
+
+const (
+	EventItemCreated     EventType = "item.created"
+	EventItemUpdated     EventType = "item.updated"
+	EventItemDeleted     EventType = "item.deleted" // 論理削除
+	EventItemRestored    EventType = "item.restored"
+	EventItemHardDeleted EventType = "item.hard_deleted"
+)
+
+// 変更前後のアイテム。登録ではBeforeが、物理削除ではAfterがnil
+type ItemChange struct {
+	Before *Item
+	After  *Item
+}
+
+// 保存したアイテムの変更。ItemChangeのスナップショットを持つ
+type Event struct {
+	Type       EventType
+	ItemID     int64
+	OccurredAt time.Time
+	ItemChange
+}
+
+func NewItemEvent(eventType EventType, itemID int64, change ItemChange) Event {
+	return Event{
+		Type:       eventType,
+		ItemID:     itemID,
+		OccurredAt: Now(),
+		ItemChange: change,
+	}
+}
+
+// イベントのアイテムの最新の内容。物理削除では削除前の内容
+func (e Event) Item() *Item {
+	if e.After != nil {
+		return e.After
+	}
+	return e.Before
+}
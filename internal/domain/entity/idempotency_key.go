@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// 冪等キーの有効期間。この期間内の同じキーでの登録は最初のレスポンスを返す
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// 冪等キーの最大文字数
+const MaxIdempotencyKeyLength = 255
+
+// アイテム登録に使われた冪等キー。リクエスト内容のハッシュと作成したアイテムのIDを保持する。
+// キーはユーザー（作成したアイテムの所有者）ごとに一意で、ほかのユーザーは同じキーを別に使える
+type IdempotencyKey struct {
+	UserID      int64
+	Key         string
+	RequestHash string
+	ItemID      int64
+	CreatedAt   time.Time
+}
@@ -0,0 +1,68 @@
+package entity
+
+import "time"
+
+// CSVインポートのジョブの状態
+const (
+	ImportJobStatusQueued  = "queued"  // 処理待ち
+	ImportJobStatusRunning = "running" // 処理中
+	ImportJobStatusDone    = "done"    // 完了。行ごとのエラーはErrorsに記録する
+	ImportJobStatusFailed  = "failed"  // 失敗。何も登録しておらず、理由はErrorに記録する
+)
+
+// 終了したジョブを保持する期間。これより前に終了したジョブは定期的に削除する
+const ImportJobRetention = 7 * 24 * time.Hour
+
+// 記録するエラーの最大文字数（import_jobs.errorの長さ）
+const MaxImportJobErrorLength = 1000
+
+// CSVの行ごとのエラー
+type ImportRowError struct {
+	Row     int    `json:"row"` // ファイル上の行番号（ヘッダー行が1）
+	Message string `json:"message"`
+}
+
+// 非同期で実行するCSVインポートのジョブ。受け付けた時点ではqueuedで、ワーカーが処理を始めるとrunningになり、
+// doneまたはfailedで終了する。RowsProcessedは解析済みのデータ行の件数で、処理中も一定の行数ごとに更新する
+type ImportJob struct {
+	ID            int64            `json:"id"`
+	UserID        int64            `json:"-"` // ジョブを登録したユーザーのID。登録時に呼び出し元のユーザーを設定する
+	Status        string           `json:"status"`
+	BestEffort    bool             `json:"best_effort"`
+	DryRun        bool             `json:"dry_run"`
+	RowsProcessed int              `json:"rows_processed"`
+	Succeeded     int              `json:"succeeded"` // ドライランの場合は登録される予定の件数
+	Failed        int              `json:"failed"`
+	Errors        []ImportRowError `json:"errors"`
+	Error         string           `json:"error,omitempty"` // ジョブが失敗した理由
+	CreatedAt     time.Time        `json:"created_at"`
+	StartedAt     *time.Time       `json:"started_at"`
+	FinishedAt    *time.Time       `json:"finished_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+func NewImportJob(bestEffort, dryRun bool) *ImportJob {
+	return &ImportJob{
+		Status:     ImportJobStatusQueued,
+		BestEffort: bestEffort,
+		DryRun:     dryRun,
+		Errors:     []ImportRowError{},
+		CreatedAt:  Now(),
+		UpdatedAt:  Now(),
+	}
+}
+
+// 終了した（doneまたはfailed）かどうか
+func (j *ImportJob) Finished() bool {
+	return j.Status == ImportJobStatusDone || j.Status == ImportJobStatusFailed
+}
+
+// ジョブを失敗として終了する。理由は記録できる長さに切り詰める
+func (j *ImportJob) Fail(reason string) {
+	runes := []rune(reason)
+	if len(runes) > MaxImportJobErrorLength {
+		reason = string(runes[:MaxImportJobErrorLength])
+	}
+	j.Status = ImportJobStatusFailed
+	j.Error = reason
+}
@@ -1,147 +1,469 @@
 package entity
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
 )
 
+// 作成直後のアイテムのバージョン
+const InitialItemVersion int64 = 1
+
+// 名前の最大文字数。ブランドの最大文字数はMaxBrandNameLength
+const MaxItemNameLength = 100
+
+// シリアル番号の最大文字数
+const MaxSerialNumberLength = 64
+
+// メモの最大文字数
+const MaxNotesLength = 2000
+
+// 購入店舗名の最大文字数
+const MaxPurchaseLocationLength = 100
+
+// 購入価格の上限のデフォルト値
+const DefaultMaxPurchasePrice int64 = 1_000_000_000
+
+// 購入価格の上限。桁の打ち間違いを防ぐためのもので、起動時に設定から変更できる
+var MaxPurchasePrice = DefaultMaxPurchasePrice
+
 type Item struct {
-	ID            int64     `json:"id"`
-	Name          string    `json:"name"`
-	Category      string    `json:"category"`
-	Brand         string    `json:"brand"`
-	PurchasePrice int       `json:"purchase_price"`
-	PurchaseDate  string    `json:"purchase_date"` // YYYY-MM-DD 形式
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID               int64         `json:"id"`
+	UserID           int64         `json:"user_id"` // 所有するユーザーのID。登録時に呼び出し元のユーザーを設定する
+	Name             string        `json:"name"`
+	Category         string        `json:"category"`      // カテゴリーの日本語の表示名。スラッグで指定された場合も表示名で保存する
+	CategorySlug     string        `json:"category_slug"` // カテゴリーのスラッグ。カテゴリーが削除されている場合は空
+	Brand            string        `json:"brand"`
+	PurchasePrice    int64         `json:"purchase_price"`
+	Currency         string        `json:"currency"`                // 購入価格の通貨（ISO 4217）
+	PurchaseDate     PurchaseDate  `json:"purchase_date"`           // YYYY-MM-DD 形式
+	SerialNumber     *string       `json:"serial_number"`           // シリアル番号。未設定の場合はnil
+	Condition        *string       `json:"condition"`               // 状態（ValidConditionsのいずれか）。未設定の場合はnil
+	Notes            string        `json:"notes"`                   // 入手経緯や修理歴、保管場所などの自由記述のメモ
+	PurchaseLocation *string       `json:"purchase_location"`       // 購入した店舗名。NormalizePurchaseLocationで正規化した値で、未設定の場合はnil
+	Status           string        `json:"status"`                  // 所有状況。ChangeStatusまたはMarkSoldでのみ変更する
+	SellingPrice     *int64        `json:"selling_price,omitempty"` // 売却価格（Currencyの通貨単位）。MarkSoldで売却した場合のみ設定される
+	SoldDate         *PurchaseDate `json:"sold_date,omitempty"`     // 売却日（YYYY-MM-DD 形式）
+	Version          int64         `json:"version"`                 // 楽観的ロック用のバージョン。更新のたびに1ずつ増える
+	CreatedAt        time.Time     `json:"created_at"`
+	UpdatedAt        time.Time     `json:"updated_at"`
+	DeletedAt        *time.Time    `json:"deleted_at,omitempty"` // 論理削除された日時。削除されていなければnil
+	Tags             []string      `json:"tags"`                 // 名前順のタグ。NormalizeTagsで正規化した値
+	Images           []*ItemImage  `json:"images,omitempty"`     // 表示順の画像。単一アイテムの取得時のみ設定される
 }
 
-// カテゴリー定義
-var ValidCategories = []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
+// ポインタのフィールド、タグと画像の一覧も含めてアイテムを複製する。変更前のスナップショットを残すために使う
+func (i *Item) Clone() *Item {
+	clone := *i
+	clone.SerialNumber = cloneOptional(i.SerialNumber)
+	clone.Condition = cloneOptional(i.Condition)
+	clone.PurchaseLocation = cloneOptional(i.PurchaseLocation)
+	clone.SellingPrice = cloneOptional(i.SellingPrice)
+	clone.SoldDate = cloneOptional(i.SoldDate)
+	clone.DeletedAt = cloneOptional(i.DeletedAt)
+	if i.Tags != nil {
+		clone.Tags = append([]string{}, i.Tags...)
+	}
+	if i.Images != nil {
+		clone.Images = append([]*ItemImage{}, i.Images...)
+	}
+	return &clone
+}
 
-func NewItem(name, category, brand string, purchasePrice int, purchaseDate string) (*Item, error) {
+func cloneOptional[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+// NewItemで作成するアイテムの値。Name・Category・Brand・PurchaseDateが必須で、ほかは省略できる
+type NewItemInput struct {
+	Name             string
+	Category         string // カテゴリーのスラッグか日本語名
+	Brand            string
+	PurchasePrice    int64
+	Currency         string // 空の場合はJPY
+	PurchaseDate     PurchaseDate
+	SerialNumber     string // 空の場合は未設定
+	Condition        string // 空の場合は未設定
+	Notes            string
+	PurchaseLocation string // 空の場合は未設定
+	Tags             []string
+	Categories       CategoryLookup // 登録済みのカテゴリー
+}
+
+// 入力を正規化してアイテムを作成する。エラーがある場合はdomainErrors.ValidationErrorsを返す
+func NewItem(input NewItemInput) (*Item, error) {
 	item := &Item{
-		Name:          strings.TrimSpace(name),
-		Category:      strings.TrimSpace(category),
-		Brand:         strings.TrimSpace(brand),
-		PurchasePrice: purchasePrice,
-		PurchaseDate:  strings.TrimSpace(purchaseDate),
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		Name:             NormalizeText(input.Name),
+		Category:         NormalizeText(input.Category),
+		Brand:            NormalizeBrandName(input.Brand),
+		PurchasePrice:    input.PurchasePrice,
+		Currency:         NormalizeCurrency(input.Currency),
+		PurchaseDate:     input.PurchaseDate,
+		SerialNumber:     normalizeOptional(input.SerialNumber),
+		Condition:        normalizeOptional(input.Condition),
+		Notes:            strings.TrimSpace(input.Notes),
+		PurchaseLocation: NormalizePurchaseLocation(input.PurchaseLocation),
+		Tags:             NormalizeTags(input.Tags),
+		Status:           ItemStatusOwned,
+		Version:          InitialItemVersion,
+		CreatedAt:        Now(),
+		UpdatedAt:        Now(),
 	}
 
-	if err := item.Validate(); err != nil {
+	item.resolveCategory(input.Categories)
+
+	if err := item.Validate(input.Categories); err != nil {
 		return nil, err
 	}
 
 	return item, nil
 }
 
-// アイテムフィールドのバリデーション
-func (i *Item) Validate() error {
-	var errs []string
+// 引数を列挙してアイテムを作成する。
+//
+// Deprecated: NewItemInputを渡してNewItemを呼ぶ。次のリリースで削除する
+func NewItemFromArgs(name, category, brand string, purchasePrice int64, currency string, purchaseDate PurchaseDate, serialNumber, condition, notes, purchaseLocation string, tags []string, categories CategoryLookup) (*Item, error) {
+	return NewItem(NewItemInput{
+		Name:             name,
+		Category:         category,
+		Brand:            brand,
+		PurchasePrice:    purchasePrice,
+		Currency:         currency,
+		PurchaseDate:     purchaseDate,
+		SerialNumber:     serialNumber,
+		Condition:        condition,
+		Notes:            notes,
+		PurchaseLocation: purchaseLocation,
+		Tags:             tags,
+		Categories:       categories,
+	})
+}
+
+// アイテムフィールドのバリデーション。エラーがある場合はdomainErrors.ValidationErrorsを返す
+func (i *Item) Validate(categories CategoryLookup) error {
+	var errs domainErrors.ValidationErrors
 
 	if i.Name == "" {
-		errs = append(errs, "name is required")
-	} else if len(i.Name) > 100 {
-		errs = append(errs, "name must be 100 characters or less")
+		errs.Append(domainErrors.Required("name"))
+	} else if utf8.RuneCountInString(i.Name) > MaxItemNameLength {
+		errs.Append(domainErrors.TooLong("name", MaxItemNameLength))
 	}
 
 	if i.Category == "" {
-		errs = append(errs, "category is required")
-	} else if !isValidCategory(i.Category) {
-		errs = append(errs, "category must be one of: 時計, バッグ, ジュエリー, 靴, その他")
+		errs.Append(domainErrors.Required("category"))
+	} else if !isValidCategory(i.Category, categories) {
+		errs.Append(categoryError(categories))
 	}
 
 	if i.Brand == "" {
-		errs = append(errs, "brand is required")
-	} else if len(i.Brand) > 100 {
-		errs = append(errs, "brand must be 100 characters or less")
+		errs.Append(domainErrors.Required("brand"))
+	} else if utf8.RuneCountInString(i.Brand) > MaxBrandNameLength {
+		errs.Append(domainErrors.TooLong("brand", MaxBrandNameLength))
 	}
 
 	if i.PurchasePrice < 0 {
-		errs = append(errs, "purchase_price must be 0 or greater")
+		errs.Append(domainErrors.TooSmall("purchase_price", 0))
+	} else if i.PurchasePrice > MaxPurchasePrice {
+		errs.Append(domainErrors.TooLarge("purchase_price", MaxPurchasePrice))
 	}
 
-	if i.PurchaseDate == "" {
-		errs = append(errs, "purchase_date is required")
-	} else if !isValidDateFormat(i.PurchaseDate) {
-		errs = append(errs, "purchase_date must be in YYYY-MM-DD format")
+	if !IsSupportedCurrency(i.Currency) {
+		errs.Append(domainErrors.OneOf("currency", SupportedCurrencies))
 	}
 
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, ", "))
+	if i.PurchaseDate.IsZero() {
+		errs.Append(domainErrors.Required("purchase_date"))
+	} else if i.PurchaseDate.After(Today()) {
+		errs.Append(futureDateError("purchase_date"))
 	}
 
-	return nil
+	if i.SerialNumber != nil && utf8.RuneCountInString(*i.SerialNumber) > MaxSerialNumberLength {
+		errs.Append(domainErrors.TooLong("serial_number", MaxSerialNumberLength))
+	}
+
+	if i.Condition != nil && !IsValidCondition(*i.Condition) {
+		errs.Append(domainErrors.OneOf("condition", ValidConditions))
+	}
+
+	if utf8.RuneCountInString(i.Notes) > MaxNotesLength {
+		errs.Append(domainErrors.TooLong("notes", MaxNotesLength))
+	}
+
+	if i.PurchaseLocation != nil && utf8.RuneCountInString(*i.PurchaseLocation) > MaxPurchaseLocationLength {
+		errs.Append(domainErrors.TooLong("purchase_location", MaxPurchaseLocationLength))
+	}
+
+	validateTags(i.Tags, &errs)
+
+	return errs.Err()
 }
 
-// アイテムフィールドのアップデート
-func (i *Item) Update(name, category, brand string, purchasePrice int, purchaseDate string) error {
-	i.Name = strings.TrimSpace(name)
-	i.Category = strings.TrimSpace(category)
-	i.Brand = strings.TrimSpace(brand)
+// アイテムフィールドのアップデート。versionはクライアントが取得した時点のバージョン
+func (i *Item) Update(version int64, name, category, brand string, purchasePrice int64, currency string, purchaseDate PurchaseDate, serialNumber, condition, notes, purchaseLocation string, tags []string, categories CategoryLookup) error {
+	if err := i.CheckVersion(version); err != nil {
+		return err
+	}
+
+	i.Name = NormalizeText(name)
+	i.Category = NormalizeText(category)
+	i.Brand = NormalizeBrandName(brand)
 	i.PurchasePrice = purchasePrice
-	i.PurchaseDate = strings.TrimSpace(purchaseDate)
-	i.UpdatedAt = time.Now()
+	i.Currency = NormalizeCurrency(currency)
+	i.PurchaseDate = purchaseDate
+	i.SerialNumber = normalizeOptional(serialNumber)
+	i.Condition = normalizeOptional(condition)
+	i.Notes = strings.TrimSpace(notes)
+	i.PurchaseLocation = NormalizePurchaseLocation(purchaseLocation)
+	i.Tags = NormalizeTags(tags)
+	i.UpdatedAt = Now()
+	i.resolveCategory(categories)
 
-	return i.Validate()
+	return i.Validate(categories)
 }
 
-// 更新関数: name, brand, purchase_price のみ
-func (i *Item) UpdatePartial(name *string, brand *string, purchasePrice *int) error {
+// 部分更新。nilのフィールドは変更しない。versionはクライアントが取得した時点のバージョン。
+// currencyとpurchaseDateは空文字を省略と区別し、バリデーションエラーとする。serialNumber、condition、notes、purchaseLocationの空文字は未設定に戻す。
+// tagsは指定した内容で置き換え、空の場合はすべてのタグを外す
+func (i *Item) UpdatePartial(version int64, name, category, brand *string, purchasePrice *int64, currency, purchaseDate, serialNumber, condition, notes, purchaseLocation *string, tags *[]string, categories CategoryLookup) error {
+	if err := i.CheckVersion(version); err != nil {
+		return err
+	}
+
+	var errs domainErrors.ValidationErrors
+
 	// 指定されたフィールドのみ更新
 	if name != nil {
-		i.Name = strings.TrimSpace(*name)
+		i.Name = NormalizeText(*name)
+	}
+	if category != nil {
+		i.Category = NormalizeText(*category)
+		i.resolveCategory(categories)
 	}
 	if brand != nil {
-		i.Brand = strings.TrimSpace(*brand)
+		i.Brand = NormalizeBrandName(*brand)
 	}
 	if purchasePrice != nil {
 		i.PurchasePrice = *purchasePrice
 	}
-	
-	// purchase_dateが RFC3339形式の場合、YYYY-MM-DD形式に正規化
-	if parsedDate, err := time.Parse(time.RFC3339, i.PurchaseDate); err == nil {
-		i.PurchaseDate = parsedDate.Format("2006-01-02")
+	if currency != nil {
+		if strings.TrimSpace(*currency) == "" {
+			errs.Append(domainErrors.Empty("currency"))
+		} else {
+			i.Currency = NormalizeCurrency(*currency)
+		}
+	}
+	if purchaseDate != nil {
+		if strings.TrimSpace(*purchaseDate) == "" {
+			errs.Append(domainErrors.Empty("purchase_date"))
+		} else if d, err := ParsePurchaseDate(*purchaseDate); err != nil {
+			errs.AddError("purchase_date", err)
+		} else {
+			i.PurchaseDate = d
+		}
+	}
+	if serialNumber != nil {
+		i.SerialNumber = normalizeOptional(*serialNumber)
+	}
+	if condition != nil {
+		i.Condition = normalizeOptional(*condition)
+	}
+	if notes != nil {
+		i.Notes = strings.TrimSpace(*notes)
+	}
+	if purchaseLocation != nil {
+		i.PurchaseLocation = NormalizePurchaseLocation(*purchaseLocation)
+	}
+	if tags != nil {
+		i.Tags = NormalizeTags(*tags)
 	}
-	
+
 	// updated_atは常に更新
-	i.UpdatedAt = time.Now()
+	i.UpdatedAt = Now()
 
 	// 更新後の全フィールドをバリデーション
-	return i.Validate()
+	var validationErrs domainErrors.ValidationErrors
+	if errors.As(i.Validate(categories), &validationErrs) {
+		errs = append(errs, validationErrs...)
+	}
+	return errs.Err()
 }
 
-// カテゴリーのバリデーション
-func isValidCategory(category string) bool {
-	for _, valid := range ValidCategories {
-		if category == valid {
-			return true
-		}
+// 所有状況の変更。変更できない遷移の場合はStatusTransitionErrorを返す
+func (i *Item) ChangeStatus(status string) error {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if status == "" {
+		return domainErrors.NewValidationErrors(domainErrors.Required("status"))
+	}
+	if !IsValidItemStatus(status) {
+		return domainErrors.NewValidationErrors(domainErrors.OneOf("status", ValidItemStatuses))
+	}
+	if !CanTransitionItemStatus(i.Status, status) {
+		return domainErrors.NewStatusTransitionError(i.Status, status)
+	}
+
+	i.Status = status
+	i.UpdatedAt = Now()
+	return nil
+}
+
+// 売却済みにして売却価格と売却日を記録する。出品中のアイテムのみ売却でき、
+// それ以外の場合はStatusTransitionErrorを返す
+func (i *Item) MarkSold(sellingPrice int64, soldDate PurchaseDate) error {
+	if !CanTransitionItemStatus(i.Status, ItemStatusSold) {
+		return domainErrors.NewStatusTransitionError(i.Status, ItemStatusSold)
+	}
+
+	var errs domainErrors.ValidationErrors
+
+	if sellingPrice < 0 {
+		errs.Append(domainErrors.TooSmall("selling_price", 0))
+	} else if sellingPrice > MaxPurchasePrice {
+		errs.Append(domainErrors.TooLarge("selling_price", MaxPurchasePrice))
 	}
-	return false
+
+	if soldDate.IsZero() {
+		errs.Append(domainErrors.Required("sold_date"))
+	} else if soldDate.Before(i.PurchaseDate) {
+		errs.Append(domainErrors.NewRuleError("sold_date", domainErrors.CodeBeforePurchaseDate, "sold_date must be on or after purchase_date", nil))
+	} else if soldDate.After(Today()) {
+		errs.Append(futureDateError("sold_date"))
+	}
+
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	i.Status = ItemStatusSold
+	i.SellingPrice = &sellingPrice
+	i.SoldDate = &soldDate
+	i.UpdatedAt = Now()
+	return nil
 }
 
-// デート形式のバリデーション
-func isValidDateFormat(dateStr string) bool {
-	// YYYY-MM-DD形式
-	if _, err := time.Parse("2006-01-02", dateStr); err == nil {
-		return true
+// 購入価格をCurrencyの通貨の金額として返す
+func (i *Item) PurchaseMoney() Money {
+	return moneyOf(i.PurchasePrice, i.Currency)
+}
+
+// 売却価格をCurrencyの通貨の金額として返す。売却価格が記録されていない場合はnil
+func (i *Item) SellingMoney() *Money {
+	if i.SellingPrice == nil {
+		return nil
+	}
+	selling := moneyOf(*i.SellingPrice, i.Currency)
+	return &selling
+}
+
+// 売却による利益（売却価格 - 購入価格、Currencyの通貨単位）。売却価格が記録されていない場合はnil
+func (i *Item) Profit() *int64 {
+	selling := i.SellingMoney()
+	if selling == nil {
+		return nil
+	}
+	// どちらもMaxPurchasePrice以下のため、同じ通貨どうしの引き算は失敗しない
+	profit, err := selling.Sub(i.PurchaseMoney())
+	if err != nil {
+		return nil
 	}
-	// RFC3339形式（データベースから取得した場合）
-	if _, err := time.Parse(time.RFC3339, dateStr); err == nil {
-		return true
+	amount := profit.Amount()
+	return &amount
+}
+
+// 計算で求めるprofitを含めてJSONに変換する。タグがない場合もtagsは空の配列とする
+func (i Item) MarshalJSON() ([]byte, error) {
+	type item Item
+	if i.Tags == nil {
+		i.Tags = []string{}
 	}
-	// その他のISO 8601形式もサポート
-	if _, err := time.Parse("2006-01-02T15:04:05Z07:00", dateStr); err == nil {
-		return true
+	return json.Marshal(struct {
+		item
+		Profit *int64 `json:"profit,omitempty"`
+	}{item(i), i.Profit()})
+}
+
+// クライアントが持っているバージョンが最新かどうかを確認する。
+// 古い場合は現在のバージョンを含むVersionConflictErrorを返す
+func (i *Item) CheckVersion(version int64) error {
+	if version != i.Version {
+		return domainErrors.NewVersionConflictError(i.Version)
 	}
-	return false
+	return nil
+}
+
+// 名前・ブランド・購入日が同じかどうか。名前とブランドは正規化して比較する。
+// 両方にシリアル番号があり、それが異なる場合は別のアイテムとみなす
+func (i *Item) IsDuplicateOf(other *Item) bool {
+	if i.SerialNumber != nil && other.SerialNumber != nil && !strings.EqualFold(*i.SerialNumber, *other.SerialNumber) {
+		return false
+	}
+	return i.PurchaseDate.Equal(other.PurchaseDate) &&
+		normalizeForDuplicateCheck(i.Name) == normalizeForDuplicateCheck(other.Name) &&
+		normalizeForDuplicateCheck(i.Brand) == normalizeForDuplicateCheck(other.Brand)
+}
+
+// 重複の判定用に、空白を正規化して小文字にそろえる
+func normalizeForDuplicateCheck(s string) string {
+	return strings.ToLower(NormalizeText(s))
+}
+
+// 店舗名の表記ゆれをそろえる。前後の空白を除き、全角スペースを含む連続した空白を半角スペース1つにする。空の場合はnilとする
+func NormalizePurchaseLocation(s string) *string {
+	return normalizeOptional(NormalizeText(s))
+}
+
+// 前後の空白を除き、空の場合はnilとする
+func normalizeOptional(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// スラッグか表示名で指定されたカテゴリーを表示名に揃え、スラッグを設定する。未登録の場合はそのままにする
+func (i *Item) resolveCategory(categories CategoryLookup) {
+	if categories == nil {
+		return
+	}
+	if category, ok := categories.Resolve(i.Category); ok {
+		i.Category = category.Name
+		i.CategorySlug = category.Slug
+	}
+}
+
+// カテゴリーのバリデーション。登録済みのカテゴリーのみ有効とする
+func isValidCategory(category string, categories CategoryLookup) bool {
+	return categories != nil && categories.Contains(category)
+}
+
+func categoryErrorMessage(categories CategoryLookup) string {
+	return categoryError(categories).Message
+}
+
+func categoryError(categories CategoryLookup) domainErrors.FieldError {
+	if categories == nil {
+		return domainErrors.NewRuleError("category", domainErrors.CodeNotRegistered, "category must be one of the registered categories", nil)
+	}
+	return domainErrors.OneOf("category", categories.Names())
+}
+
+func futureDateError(field string) domainErrors.FieldError {
+	return domainErrors.NewRuleError(field, domainErrors.CodeFutureDate, field+" must not be in the future", nil)
+}
+
+func purchaseLocationLengthErrorMessage() string {
+	return fmt.Sprintf("purchase_location must be %d characters or less", MaxPurchaseLocationLength)
 }
 
-// カテゴリーの取得
-func GetValidCategories() []string {
-	return ValidCategories
+func conditionErrorMessage() string {
+	return "condition must be one of: " + strings.Join(ValidConditions, ", ")
 }
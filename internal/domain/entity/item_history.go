@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// 履歴に記録する操作
+const (
+	HistoryActionUpdate     = "update"
+	HistoryActionDelete     = "delete"
+	HistoryActionRestore    = "restore"
+	HistoryActionHardDelete = "hard_delete"
+)
+
+// アイテムの変更履歴。変更前後のスナップショットをJSONで保持する
+type ItemHistory struct {
+	ID        int64           `json:"id"`
+	ItemID    int64           `json:"item_id"`
+	Action    string          `json:"action"`
+	Before    json.RawMessage `json:"before"` // 変更前のアイテム
+	After     json.RawMessage `json:"after"`  // 変更後のアイテム。物理削除の場合はnull
+	CreatedAt time.Time       `json:"created_at"`
+}
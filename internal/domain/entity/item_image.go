@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// 1アイテムに登録できる画像の上限
+const MaxImagesPerItem = 10
+
+// アイテムの画像。DisplayOrderの昇順で表示する
+type ItemImage struct {
+	ID           int64     `json:"id"`
+	ItemID       int64     `json:"item_id"`
+	URL          string    `json:"url"`
+	DisplayOrder int       `json:"display_order"`
+	CreatedAt    time.Time `json:"created_at"`
+}
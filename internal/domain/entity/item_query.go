@@ -0,0 +1,172 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 一覧取得時のページネーション指定。Afterを指定した場合は、Offsetの代わりにAfterの位置より後のアイテムを返す
+type Pagination struct {
+	Limit  int
+	Offset int
+	After  *ItemCursor
+}
+
+// キーセットページネーションの位置。並び替えの項目の値がValue、IDがIDのアイテムを表す。
+// Valueの型は並び替えの項目ごとに、購入価格はint64、購入日はPurchaseDate、名前はstring、登録日時はtime.Time
+type ItemCursor struct {
+	Value interface{}
+	ID    int64
+}
+
+// 並び替えに指定できる項目
+const (
+	SortByPurchasePrice = "purchase_price"
+	SortByPurchaseDate  = "purchase_date"
+	SortByName          = "name"
+	SortByCreatedAt     = "created_at"
+)
+
+var ValidSortFields = []string{SortByPurchasePrice, SortByPurchaseDate, SortByName, SortByCreatedAt}
+
+// 並び順
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// 一覧取得時の並び替え指定
+type ItemSort struct {
+	Field string
+	Order string
+}
+
+// 並び替えの既定値: 未指定の場合はcreated_atの降順、項目のみ指定の場合は昇順。
+// どの並び替えでも、リポジトリは項目が同値のアイテムを同じ向きのidで並べて順序を確定させる
+func (s ItemSort) WithDefaults() ItemSort {
+	if s.Field == "" {
+		return ItemSort{Field: SortByCreatedAt, Order: SortOrderDesc}
+	}
+	if s.Order == "" {
+		s.Order = SortOrderAsc
+	}
+	return s
+}
+
+// itemの位置を表すカーソル
+func (s ItemSort) CursorOf(item *Item) ItemCursor {
+	switch s.WithDefaults().Field {
+	case SortByPurchasePrice:
+		return ItemCursor{Value: item.PurchasePrice, ID: item.ID}
+	case SortByPurchaseDate:
+		return ItemCursor{Value: item.PurchaseDate, ID: item.ID}
+	case SortByName:
+		return ItemCursor{Value: item.Name, ID: item.ID}
+	default:
+		return ItemCursor{Value: item.CreatedAt, ID: item.ID}
+	}
+}
+
+// 並び替え指定のバリデーション
+func (s ItemSort) Validate() error {
+	var errs []string
+
+	if s.Field != "" && !contains(ValidSortFields, s.Field) {
+		errs = append(errs, "sort must be one of: "+strings.Join(ValidSortFields, ", "))
+	}
+	if s.Order != "" && s.Order != SortOrderAsc && s.Order != SortOrderDesc {
+		errs = append(errs, "order must be one of: asc, desc")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+func contains(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// 一覧取得時の絞り込み条件。空のフィールドは条件に含めない
+type ItemFilter struct {
+	Category  string
+	Condition string
+	Status    string
+	Brand     string // 部分一致（大文字小文字を区別しない）
+	Keyword   string // 名前またはブランドの部分一致（大文字小文字を区別しない）
+	MinPrice  *int64 // 購入価格の下限（境界値を含む）
+	MaxPrice  *int64 // 購入価格の上限（境界値を含む）
+
+	// 指定したタグがすべて付いたアイテムのみ（完全一致）
+	Tags []string
+
+	// 購入店舗名の完全一致。NormalizePurchaseLocationで正規化した値を指定する
+	PurchaseLocation string
+
+	// 購入日の範囲（境界値を含む）。片方のみの指定も可能
+	PurchasedFrom *time.Time
+	PurchasedTo   *time.Time
+
+	// trueの場合は論理削除されたアイテムも含める
+	IncludeDeleted bool
+
+	// 所有者のユーザーID。すべてのユーザーのアイテムを扱える管理者のみ指定できる
+	OwnerID *int64
+}
+
+// 絞り込み条件のバリデーション。categoriesには登録済みのカテゴリーを渡す
+func (f ItemFilter) Validate(categories CategoryLookup) error {
+	var errs []string
+
+	if f.Category != "" && !isValidCategory(f.Category, categories) {
+		errs = append(errs, categoryErrorMessage(categories))
+	}
+
+	if f.Condition != "" && !IsValidCondition(f.Condition) {
+		errs = append(errs, conditionErrorMessage())
+	}
+
+	if f.Status != "" && !IsValidItemStatus(f.Status) {
+		errs = append(errs, itemStatusErrorMessage())
+	}
+
+	if utf8.RuneCountInString(f.PurchaseLocation) > MaxPurchaseLocationLength {
+		errs = append(errs, purchaseLocationLengthErrorMessage())
+	}
+
+	for _, tag := range f.Tags {
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			errs = append(errs, tagLengthErrorMessage())
+			break
+		}
+	}
+
+	if f.MinPrice != nil && *f.MinPrice < 0 {
+		errs = append(errs, "min_price must be 0 or greater")
+	}
+	if f.MaxPrice != nil && *f.MaxPrice < 0 {
+		errs = append(errs, "max_price must be 0 or greater")
+	}
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice {
+		errs = append(errs, "min_price must be less than or equal to max_price")
+	}
+
+	if f.PurchasedFrom != nil && f.PurchasedTo != nil && f.PurchasedFrom.After(*f.PurchasedTo) {
+		errs = append(errs, "purchased_from must be on or before purchased_to")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
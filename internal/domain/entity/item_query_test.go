@@ -0,0 +1,152 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItemSort_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sort    ItemSort
+		wantErr bool
+	}{
+		{"正常系: 未指定", ItemSort{}, false},
+		{"正常系: 購入価格の降順", ItemSort{Field: SortByPurchasePrice, Order: SortOrderDesc}, false},
+		{"正常系: 名前のみ指定", ItemSort{Field: SortByName}, false},
+		{"異常系: 無効な並び替え項目", ItemSort{Field: "brand"}, true},
+		{"異常系: 無効な並び順", ItemSort{Field: SortByName, Order: "random"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sort.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestItemSort_WithDefaults(t *testing.T) {
+	assert.Equal(t, ItemSort{Field: SortByCreatedAt, Order: SortOrderDesc}, ItemSort{}.WithDefaults())
+	assert.Equal(t, ItemSort{Field: SortByName, Order: SortOrderAsc}, ItemSort{Field: SortByName}.WithDefaults())
+	assert.Equal(t, ItemSort{Field: SortByName, Order: SortOrderDesc}, ItemSort{Field: SortByName, Order: SortOrderDesc}.WithDefaults())
+}
+
+func TestItemFilter_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      ItemFilter
+		wantErr     bool
+		expectedErr string
+	}{
+		{
+			name:    "正常系: 条件なし",
+			filter:  ItemFilter{},
+			wantErr: false,
+		},
+		{
+			name:    "正常系: 有効なカテゴリー",
+			filter:  ItemFilter{Category: "時計"},
+			wantErr: false,
+		},
+		{
+			name:        "異常系: 無効なカテゴリー",
+			filter:      ItemFilter{Category: "衣服"},
+			wantErr:     true,
+			expectedErr: "category must be one of: 時計, バッグ, ジュエリー, 靴, その他",
+		},
+		{
+			name:    "正常系: 有効な状態",
+			filter:  ItemFilter{Condition: "中古A"},
+			wantErr: false,
+		},
+		{
+			name:        "異常系: 無効な状態",
+			filter:      ItemFilter{Condition: "ジャンク"},
+			wantErr:     true,
+			expectedErr: "condition must be one of: 新品, 未使用, 中古A, 中古B, 中古C",
+		},
+		{
+			name:        "異常系: 無効な所有状況",
+			filter:      ItemFilter{Status: "lost"},
+			wantErr:     true,
+			expectedErr: "status must be one of: owned, listed, sold",
+		},
+		{
+			name:    "正常系: 複数のタグ",
+			filter:  ItemFilter{Tags: []string{"限定品", "プレゼント"}},
+			wantErr: false,
+		},
+		{
+			name:        "異常系: 長すぎるタグ",
+			filter:      ItemFilter{Tags: []string{strings.Repeat("あ", MaxTagLength+1)}},
+			wantErr:     true,
+			expectedErr: "each tag must be 30 characters or less",
+		},
+		{
+			name:        "異常系: 長すぎる購入店舗",
+			filter:      ItemFilter{PurchaseLocation: strings.Repeat("店", MaxPurchaseLocationLength+1)},
+			wantErr:     true,
+			expectedErr: "purchase_location must be 100 characters or less",
+		},
+		{
+			name:    "正常系: 価格の下限と上限が同じ",
+			filter:  ItemFilter{MinPrice: int64Ptr(100000), MaxPrice: int64Ptr(100000)},
+			wantErr: false,
+		},
+		{
+			name:        "異常系: 負の下限価格",
+			filter:      ItemFilter{MinPrice: int64Ptr(-1)},
+			wantErr:     true,
+			expectedErr: "min_price must be 0 or greater",
+		},
+		{
+			name:        "異常系: 下限価格が上限価格より大きい",
+			filter:      ItemFilter{MinPrice: int64Ptr(500000), MaxPrice: int64Ptr(100000)},
+			wantErr:     true,
+			expectedErr: "min_price must be less than or equal to max_price",
+		},
+		{
+			name: "正常系: 購入日の範囲が同日",
+			filter: ItemFilter{
+				PurchasedFrom: timePtr(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+				PurchasedTo:   timePtr(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 購入日の下限が上限より後",
+			filter: ItemFilter{
+				PurchasedFrom: timePtr(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+				PurchasedTo:   timePtr(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)),
+			},
+			wantErr:     true,
+			expectedErr: "purchased_from must be on or before purchased_to",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate(testCategories)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "strings"
+
+// アイテムの所有状況
+const (
+	ItemStatusOwned  = "owned"  // 所有中
+	ItemStatusListed = "listed" // 出品中
+	ItemStatusSold   = "sold"   // 売却済み
+)
+
+var ValidItemStatuses = []string{ItemStatusOwned, ItemStatusListed, ItemStatusSold}
+
+// 変更できる所有状況の遷移。売却済みからはどこにも遷移できない
+var itemStatusTransitions = map[string][]string{
+	ItemStatusOwned:  {ItemStatusListed},
+	ItemStatusListed: {ItemStatusSold, ItemStatusOwned},
+}
+
+// 所有状況のバリデーション
+func IsValidItemStatus(status string) bool {
+	return contains(ValidItemStatuses, status)
+}
+
+// fromからtoへ変更できるかどうか
+func CanTransitionItemStatus(from, to string) bool {
+	return contains(itemStatusTransitions[from], to)
+}
+
+func itemStatusErrorMessage() string {
+	return "status must be one of: " + strings.Join(ValidItemStatuses, ", ")
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewItem_Status(t *testing.T) {
+	item, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-15"), Categories: testCategories})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ItemStatusOwned, item.Status)
+}
+
+func TestItem_ChangeStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     string
+		requested   string
+		expected    string
+		expectedErr error
+	}{
+		{name: "正常系: 所有中から出品中", current: ItemStatusOwned, requested: "listed", expected: ItemStatusListed},
+		{name: "正常系: 出品中から売却済み", current: ItemStatusListed, requested: "sold", expected: ItemStatusSold},
+		{name: "正常系: 出品を取り下げて所有中に戻す", current: ItemStatusListed, requested: "owned", expected: ItemStatusOwned},
+		{name: "正常系: 大文字と前後の空白を無視する", current: ItemStatusOwned, requested: " LISTED ", expected: ItemStatusListed},
+		{name: "異常系: 所有中から直接売却済み", current: ItemStatusOwned, requested: "sold", expectedErr: domainErrors.NewStatusTransitionError("owned", "sold")},
+		{name: "異常系: 売却済みから出品中", current: ItemStatusSold, requested: "listed", expectedErr: domainErrors.NewStatusTransitionError("sold", "listed")},
+		{name: "異常系: 売却済みから所有中", current: ItemStatusSold, requested: "owned", expectedErr: domainErrors.NewStatusTransitionError("sold", "owned")},
+		{name: "異常系: 同じ所有状況", current: ItemStatusOwned, requested: "owned", expectedErr: domainErrors.NewStatusTransitionError("owned", "owned")},
+		{name: "異常系: 未指定", current: ItemStatusOwned, requested: "", expectedErr: domainErrors.NewValidationErrors(domainErrors.Required("status"))},
+		{name: "異常系: 無効な所有状況", current: ItemStatusOwned, requested: "lost", expectedErr: domainErrors.NewValidationErrors(domainErrors.OneOf("status", ValidItemStatuses))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &Item{Status: tt.current}
+
+			err := item.ChangeStatus(tt.requested)
+
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				assert.Equal(t, tt.current, item.Status)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, item.Status)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package entity
+
+// カテゴリーごとのアイテム集計。金額は基準通貨に換算した値で、アイテムが0件のカテゴリーも0で含める。
+// 売却済みのアイテムは含めない
+type CategoryStats struct {
+	Category     string  `json:"category"`
+	Count        int     `json:"count"`
+	TotalPrice   int64   `json:"total_price"`
+	AveragePrice float64 `json:"average_price"`
+	// 状態ごとの内訳。ValidConditionsの順に0件の状態も含め、状態が未設定のアイテムは含めない
+	Conditions []*ConditionStats `json:"conditions"`
+}
+
+// カテゴリー内の状態ごとの件数と購入価格の合計（基準通貨に換算した値）
+type ConditionStats struct {
+	Condition  string `json:"condition"`
+	Count      int    `json:"count"`
+	TotalPrice int64  `json:"total_price"`
+}
+
+// 売却済みのアイテムの件数と購入価格の合計（基準通貨に換算した値）
+type SoldStats struct {
+	Count      int   `json:"count"`
+	TotalPrice int64 `json:"total_price"`
+}
+
+// カテゴリー・通貨・状態・所有状況ごとの件数と購入価格の合計。
+// アイテムが0件のカテゴリーはCurrencyとStatusが空になり、状態が未設定のアイテムはConditionが空になる
+type CategoryCurrencyTotal struct {
+	Category   string
+	Currency   string
+	Condition  string
+	Status     string
+	Count      int
+	TotalPrice int64
+}
+
+// 売却価格を記録した売却済みのアイテムの、通貨ごとの件数と売却価格・購入価格の合計
+type ProfitCurrencyTotal struct {
+	Currency      string
+	Count         int
+	SellingPrice  int64
+	PurchasePrice int64
+}
+
+// 購入店舗ごと・通貨ごとのアイテムの件数と購入価格の合計。PurchaseLocationがnilの行は店舗が未設定のアイテム
+type LocationCurrencyTotal struct {
+	PurchaseLocation *string
+	Currency         string
+	Count            int
+	TotalPrice       int64
+}
+
+// 絞り込み条件に一致するアイテムの、カテゴリーごと・通貨ごとの件数と購入価格の合計、最新の購入日
+type CategoryCurrencyStats struct {
+	Category           string
+	Currency           string
+	Count              int
+	TotalPrice         int64
+	LatestPurchaseDate PurchaseDate
+}
+
+// ブランドごと・通貨ごとのアイテムの件数と購入価格の合計。
+// BrandKeyは大文字小文字をまとめるための小文字にしたブランド名で、Brandはグループ内のブランド名の1つ
+type BrandCurrencyTotal struct {
+	BrandKey   string
+	Brand      string
+	Currency   string
+	Count      int
+	TotalPrice int64
+}
+
+// 購入価格の比較に使うアイテムのID・名前・購入価格
+type ItemPrice struct {
+	BrandKey      string // ブランドごとに取得した場合のみ設定する
+	ID            int64
+	Name          string
+	PurchasePrice int64
+	Currency      string
+}
+
+// 購入日の期間ごと・通貨ごとのアイテムの件数と購入価格の合計。Periodは月単位の場合はYYYY-MM形式、年単位の場合はYYYY形式
+type PeriodCurrencyTotal struct {
+	Period     string
+	Currency   string
+	Count      int
+	TotalPrice int64
+}
+
+func (t *CategoryCurrencyTotal) Total() Money {
+	return moneyOf(t.TotalPrice, t.Currency)
+}
+
+func (t *ProfitCurrencyTotal) SellingTotal() Money {
+	return moneyOf(t.SellingPrice, t.Currency)
+}
+
+func (t *ProfitCurrencyTotal) PurchaseTotal() Money {
+	return moneyOf(t.PurchasePrice, t.Currency)
+}
+
+func (t *LocationCurrencyTotal) Total() Money {
+	return moneyOf(t.TotalPrice, t.Currency)
+}
+
+func (s *CategoryCurrencyStats) Total() Money {
+	return moneyOf(s.TotalPrice, s.Currency)
+}
+
+func (t *BrandCurrencyTotal) Total() Money {
+	return moneyOf(t.TotalPrice, t.Currency)
+}
+
+func (p *ItemPrice) Price() Money {
+	return moneyOf(p.PurchasePrice, p.Currency)
+}
+
+func (t *PeriodCurrencyTotal) Total() Money {
+	return moneyOf(t.TotalPrice, t.Currency)
+}
+
+// ValidConditionsの順に、0件の状態の内訳を作成する
+func NewConditionStatsList() []*ConditionStats {
+	stats := make([]*ConditionStats, len(ValidConditions))
+	for i, condition := range ValidConditions {
+		stats[i] = &ConditionStats{Condition: condition}
+	}
+	return stats
+}
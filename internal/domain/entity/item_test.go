@@ -1,21 +1,30 @@
 package entity
 
 import (
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/testutil"
 )
 
+// テストで使う登録済みカテゴリー
+var testCategories = NewCategorySet("時計", "バッグ", "ジュエリー", "靴", "その他")
+
 func TestNewItem(t *testing.T) {
 	tests := []struct {
 		name          string
 		itemName      string
 		category      string
 		brand         string
-		purchasePrice int
-		purchaseDate  string
+		purchasePrice int64
+		purchaseDate  PurchaseDate
 		wantErr       bool
 		expectedErr   string
 	}{
@@ -25,7 +34,7 @@ func TestNewItem(t *testing.T) {
 			category:      "時計",
 			brand:         "ROLEX",
 			purchasePrice: 1500000,
-			purchaseDate:  "2023-01-15",
+			purchaseDate:  MustParsePurchaseDate("2023-01-15"),
 			wantErr:       false,
 		},
 		{
@@ -34,17 +43,17 @@ func TestNewItem(t *testing.T) {
 			category:      "時計",
 			brand:         "ROLEX",
 			purchasePrice: 1500000,
-			purchaseDate:  "2023-01-15",
+			purchaseDate:  MustParsePurchaseDate("2023-01-15"),
 			wantErr:       true,
 			expectedErr:   "name is required",
 		},
 		{
 			name:          "異常系: 名前が100文字超過",
-			itemName:      "ロレックス デイトナ 16520 18K イエローゴールド ブラック文字盤 自動巻き クロノグラフ メンズ 腕時計 1988年製 ヴィンテージ 希少 コレクション アイテム",
+			itemName:      strings.Repeat("時", 101),
 			category:      "時計",
 			brand:         "ROLEX",
 			purchasePrice: 1500000,
-			purchaseDate:  "2023-01-15",
+			purchaseDate:  MustParsePurchaseDate("2023-01-15"),
 			wantErr:       true,
 			expectedErr:   "name must be 100 characters or less",
 		},
@@ -54,7 +63,7 @@ func TestNewItem(t *testing.T) {
 			category:      "",
 			brand:         "ROLEX",
 			purchasePrice: 1500000,
-			purchaseDate:  "2023-01-15",
+			purchaseDate:  MustParsePurchaseDate("2023-01-15"),
 			wantErr:       true,
 			expectedErr:   "category is required",
 		},
@@ -64,7 +73,7 @@ func TestNewItem(t *testing.T) {
 			category:      "無効なカテゴリー",
 			brand:         "ROLEX",
 			purchasePrice: 1500000,
-			purchaseDate:  "2023-01-15",
+			purchaseDate:  MustParsePurchaseDate("2023-01-15"),
 			wantErr:       true,
 			expectedErr:   "category must be one of: 時計, バッグ, ジュエリー, 靴, その他",
 		},
@@ -74,7 +83,7 @@ func TestNewItem(t *testing.T) {
 			category:      "時計",
 			brand:         "",
 			purchasePrice: 1500000,
-			purchaseDate:  "2023-01-15",
+			purchaseDate:  MustParsePurchaseDate("2023-01-15"),
 			wantErr:       true,
 			expectedErr:   "brand is required",
 		},
@@ -82,9 +91,9 @@ func TestNewItem(t *testing.T) {
 			name:          "異常系: ブランドが100文字超過",
 			itemName:      "ロレックス デイトナ",
 			category:      "時計",
-			brand:         "ROLEX SA Geneva Switzerland Official Authorized Dealer Store Premium Collection Limited Edition Special",
+			brand:         strings.Repeat("ロ", 101),
 			purchasePrice: 1500000,
-			purchaseDate:  "2023-01-15",
+			purchaseDate:  MustParsePurchaseDate("2023-01-15"),
 			wantErr:       true,
 			expectedErr:   "brand must be 100 characters or less",
 		},
@@ -94,29 +103,38 @@ func TestNewItem(t *testing.T) {
 			category:      "時計",
 			brand:         "ROLEX",
 			purchasePrice: -1,
-			purchaseDate:  "2023-01-15",
+			purchaseDate:  MustParsePurchaseDate("2023-01-15"),
 			wantErr:       true,
 			expectedErr:   "purchase_price must be 0 or greater",
 		},
 		{
-			name:          "異常系: 購入日が空",
+			name:          "正常系: 購入価格が上限ちょうど",
 			itemName:      "ロレックス デイトナ",
 			category:      "時計",
 			brand:         "ROLEX",
-			purchasePrice: 1500000,
-			purchaseDate:  "",
+			purchasePrice: DefaultMaxPurchasePrice,
+			purchaseDate:  MustParsePurchaseDate("2023-01-15"),
+			wantErr:       false,
+		},
+		{
+			name:          "異常系: 購入価格が上限を超える",
+			itemName:      "ロレックス デイトナ",
+			category:      "時計",
+			brand:         "ROLEX",
+			purchasePrice: DefaultMaxPurchasePrice + 1,
+			purchaseDate:  MustParsePurchaseDate("2023-01-15"),
 			wantErr:       true,
-			expectedErr:   "purchase_date is required",
+			expectedErr:   "purchase_price must be 1000000000 or less",
 		},
 		{
-			name:          "異常系: 無効な日付形式",
+			name:          "異常系: 購入日が空",
 			itemName:      "ロレックス デイトナ",
 			category:      "時計",
 			brand:         "ROLEX",
 			purchasePrice: 1500000,
-			purchaseDate:  "2023/01/15",
+			purchaseDate:  PurchaseDate{},
 			wantErr:       true,
-			expectedErr:   "purchase_date must be in YYYY-MM-DD format",
+			expectedErr:   "purchase_date is required",
 		},
 		{
 			name:          "正常系: 購入価格が0",
@@ -124,14 +142,14 @@ func TestNewItem(t *testing.T) {
 			category:      "その他",
 			brand:         "不明",
 			purchasePrice: 0,
-			purchaseDate:  "2023-01-15",
+			purchaseDate:  MustParsePurchaseDate("2023-01-15"),
 			wantErr:       false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			item, err := NewItem(tt.itemName, tt.category, tt.brand, tt.purchasePrice, tt.purchaseDate)
+			item, err := NewItem(NewItemInput{Name: tt.itemName, Category: tt.category, Brand: tt.brand, PurchasePrice: tt.purchasePrice, PurchaseDate: tt.purchaseDate, Categories: testCategories})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -159,7 +177,7 @@ func TestNewItem(t *testing.T) {
 
 func TestItem_Update(t *testing.T) {
 	// 初期アイテムを作成
-	item, err := NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+	item, err := NewItem(NewItemInput{Name: "初期アイテム", Category: "時計", Brand: "初期ブランド", PurchasePrice: 100000, Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
 	require.NoError(t, err)
 
 	originalUpdatedAt := item.UpdatedAt
@@ -170,8 +188,8 @@ func TestItem_Update(t *testing.T) {
 		newName     string
 		newCategory string
 		newBrand    string
-		newPrice    int
-		newDate     string
+		newPrice    int64
+		newDate     PurchaseDate
 		wantErr     bool
 		expectedErr string
 	}{
@@ -181,7 +199,7 @@ func TestItem_Update(t *testing.T) {
 			newCategory: "バッグ",
 			newBrand:    "更新されたブランド",
 			newPrice:    200000,
-			newDate:     "2023-12-31",
+			newDate:     MustParsePurchaseDate("2023-12-31"),
 			wantErr:     false,
 		},
 		{
@@ -190,7 +208,7 @@ func TestItem_Update(t *testing.T) {
 			newCategory: "無効なカテゴリー",
 			newBrand:    "更新されたブランド",
 			newPrice:    200000,
-			newDate:     "2023-12-31",
+			newDate:     MustParsePurchaseDate("2023-12-31"),
 			wantErr:     true,
 			expectedErr: "category must be one of: 時計, バッグ, ジュエリー, 靴, その他",
 		},
@@ -200,7 +218,7 @@ func TestItem_Update(t *testing.T) {
 			newCategory: "バッグ",
 			newBrand:    "更新されたブランド",
 			newPrice:    -1,
-			newDate:     "2023-12-31",
+			newDate:     MustParsePurchaseDate("2023-12-31"),
 			wantErr:     true,
 			expectedErr: "purchase_price must be 0 or greater",
 		},
@@ -208,7 +226,7 @@ func TestItem_Update(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := item.Update(tt.newName, tt.newCategory, tt.newBrand, tt.newPrice, tt.newDate)
+			err := item.Update(item.Version, tt.newName, tt.newCategory, tt.newBrand, tt.newPrice, "", tt.newDate, "", "", "", "", nil, testCategories)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -245,7 +263,8 @@ func TestItem_Validate(t *testing.T) {
 				Category:      "時計",
 				Brand:         "ROLEX",
 				PurchasePrice: 1500000,
-				PurchaseDate:  "2023-01-15",
+				Currency:      "JPY",
+				PurchaseDate:  MustParsePurchaseDate("2023-01-15"),
 			},
 			wantErr: false,
 		},
@@ -256,16 +275,16 @@ func TestItem_Validate(t *testing.T) {
 				Category:      "",
 				Brand:         "",
 				PurchasePrice: -1,
-				PurchaseDate:  "",
+				PurchaseDate:  PurchaseDate{},
 			},
 			wantErr:     true,
-			expectedErr: "name is required, category is required, brand is required, purchase_price must be 0 or greater, purchase_date is required",
+			expectedErr: "name is required, category is required, brand is required, purchase_price must be 0 or greater, currency must be one of: JPY, USD, EUR, GBP, CHF, purchase_date is required",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.item.Validate()
+			err := tt.item.Validate(testCategories)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -296,13 +315,13 @@ func TestIsValidCategory(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := isValidCategory(tt.category)
+			got := isValidCategory(tt.category, testCategories)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
 
-func TestIsValidDateFormat(t *testing.T) {
+func TestParsePurchaseDate_Formats(t *testing.T) {
 	tests := []struct {
 		name    string
 		dateStr string
@@ -311,7 +330,8 @@ func TestIsValidDateFormat(t *testing.T) {
 		{"有効な日付: 2023-01-15", "2023-01-15", true},
 		{"有効な日付: 2023-12-31", "2023-12-31", true},
 		{"有効な日付: 2024-02-29", "2024-02-29", true}, // うるう年
-		{"無効な日付: 2023/01/15", "2023/01/15", false},
+		{"有効な日付: 2023/01/15", "2023/01/15", true},
+		{"無効な日付: 2023/1/15", "2023/1/15", false},
 		{"無効な日付: 2023-1-15", "2023-1-15", false},
 		{"無効な日付: 15-01-2023", "15-01-2023", false},
 		{"無効な日付: 2023-13-01", "2023-13-01", false},
@@ -322,18 +342,510 @@ func TestIsValidDateFormat(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := isValidDateFormat(tt.dateStr)
-			assert.Equal(t, tt.want, got)
+			_, err := ParsePurchaseDate(tt.dateStr)
+			assert.Equal(t, tt.want, err == nil)
+		})
+	}
+}
+
+func TestParsePurchaseDate(t *testing.T) {
+	tests := []struct {
+		name        string
+		dateStr     string
+		want        string
+		expectedErr string
+	}{
+		{"YYYY-MM-DD形式", "2023-01-15", "2023-01-15", ""},
+		{"RFC3339形式", "2023-01-15T10:00:00Z", "2023-01-15", ""},
+		{"RFC3339形式はその時差での日付", "2023-01-15T23:30:00+09:00", "2023-01-15", ""},
+		{"前後の空白", " 2023-01-15 ", "2023-01-15", ""},
+		{"うるう日", "2024-02-29", "2024-02-29", ""},
+		{"YYYY/MM/DD形式", "2023/01/15", "2023-01-15", ""},
+		{"無効な形式", "2023.01.15", "", "purchase_date must be in YYYY-MM-DD format"},
+		{"YYYY/MM/DD形式の存在しない日付", "2023/02/30", "", "purchase_date 2023/02/30 is not a valid calendar date"},
+		{"空文字", "", "", "purchase_date must be in YYYY-MM-DD format"},
+		{"存在しない日付", "2023-02-30", "", "purchase_date 2023-02-30 is not a valid calendar date"},
+		{"うるう年でない2月29日", "2023-02-29", "", "purchase_date 2023-02-29 is not a valid calendar date"},
+		{"存在しない月", "2023-13-01", "", "purchase_date 2023-13-01 is not a valid calendar date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePurchaseDate(tt.dateStr)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestNewItem_RegisteredCategories(t *testing.T) {
+	categories := NewCategorySet("時計", "アクセサリー")
+
+	item, err := NewItem(NewItemInput{Name: "ネックレス", Category: "アクセサリー", Brand: "ブランド", PurchasePrice: 10000, Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-01"), Categories: categories})
+	require.NoError(t, err)
+	assert.Equal(t, "アクセサリー", item.Category)
+
+	_, err = NewItem(NewItemInput{Name: "ネックレス", Category: "バッグ", Brand: "ブランド", PurchasePrice: 10000, Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-01"), Categories: categories})
+	assert.EqualError(t, err, "category must be one of: 時計, アクセサリー")
+}
+
+func TestNewItem_CategorySlug(t *testing.T) {
+	categories := NewCategorySetFrom([]*Category{
+		{ID: 1, Slug: "watch", Name: "時計", NameEn: "Watch"},
+		{ID: 2, Slug: "bag", Name: "バッグ", NameEn: "Bag"},
+	})
+
+	t.Run("正常系: スラッグで指定した場合は表示名で保存する", func(t *testing.T) {
+		item, err := NewItem(NewItemInput{Name: "デイトナ", Category: " Watch ", Brand: "ROLEX", PurchasePrice: 10000, Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-01"), Categories: categories})
+		require.NoError(t, err)
+		assert.Equal(t, "時計", item.Category)
+		assert.Equal(t, "watch", item.CategorySlug)
+	})
+
+	t.Run("正常系: 部分更新でスラッグを指定", func(t *testing.T) {
+		item, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 10000, Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-01"), Categories: categories})
+		require.NoError(t, err)
+		assert.Equal(t, "watch", item.CategorySlug)
+
+		category := "bag"
+		require.NoError(t, item.UpdatePartial(item.Version, nil, &category, nil, nil, nil, nil, nil, nil, nil, nil, nil, categories))
+		assert.Equal(t, "バッグ", item.Category)
+		assert.Equal(t, "bag", item.CategorySlug)
+	})
+
+	t.Run("異常系: 未登録のスラッグ", func(t *testing.T) {
+		_, err := NewItem(NewItemInput{Name: "デイトナ", Category: "shoes", Brand: "ROLEX", PurchasePrice: 10000, Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-01"), Categories: categories})
+		assert.EqualError(t, err, "category must be one of: 時計, バッグ")
+	})
+}
+
+func TestNewItem_Currency(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		want     string
+		wantErr  bool
+	}{
+		{"正常系: 未指定の場合はJPY", "", "JPY", false},
+		{"正常系: 小文字は大文字に正規化", "eur", "EUR", false},
+		{"正常系: USD", "USD", "USD", false},
+		{"異常系: 未対応の通貨", "XYZ", "", true},
+		{"異常系: ISO 4217の形式ではない", "DOLLAR", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := NewItem(NewItemInput{Name: "時計", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: tt.currency, PurchaseDate: MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
+
+			if tt.wantErr {
+				assert.EqualError(t, err, "currency must be one of: JPY, USD, EUR, GBP, CHF")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, item.Currency)
 		})
 	}
 }
 
-func TestGetValidCategories(t *testing.T) {
-	categories := GetValidCategories()
-	expected := []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
+func TestItem_UpdatePartial_Currency(t *testing.T) {
+	item, err := NewItem(NewItemInput{Name: "時計", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, PurchaseDate: MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
+	require.NoError(t, err)
+
+	usd := "usd"
+	require.NoError(t, item.UpdatePartial(item.Version, nil, nil, nil, nil, &usd, nil, nil, nil, nil, nil, nil, testCategories))
+	assert.Equal(t, "USD", item.Currency)
+
+	unknown := "ABC"
+	assert.Error(t, item.UpdatePartial(item.Version, nil, nil, nil, nil, &unknown, nil, nil, nil, nil, nil, nil, testCategories))
+}
+
+func TestNewItem_MaxPurchasePrice(t *testing.T) {
+	original := MaxPurchasePrice
+	t.Cleanup(func() { MaxPurchasePrice = original })
+
+	// 32bitのintに収まらない価格も上限を引き上げれば登録できる
+	MaxPurchasePrice = 10_000_000_000
+	item, err := NewItem(NewItemInput{Name: "時計", Category: "時計", Brand: "ROLEX", PurchasePrice: 5_000_000_000, PurchaseDate: MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5_000_000_000), item.PurchasePrice)
+
+	MaxPurchasePrice = 1000
+	_, err = NewItem(NewItemInput{Name: "時計", Category: "時計", Brand: "ROLEX", PurchasePrice: 1001, PurchaseDate: MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
+	assert.EqualError(t, err, "purchase_price must be 1000 or less")
+}
+
+func TestItem_Validate_FieldErrors(t *testing.T) {
+	item := &Item{Name: "", Category: "時計", Brand: "ROLEX", PurchasePrice: -1, Currency: "XYZ", PurchaseDate: MustParsePurchaseDate("2023-01-15")}
+
+	err := item.Validate(testCategories)
+
+	var validationErrs domainErrors.ValidationErrors
+	require.True(t, errors.As(err, &validationErrs))
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	assert.Equal(t, domainErrors.ValidationErrors{
+		{Field: "name", Code: domainErrors.CodeRequired, Message: "name is required"},
+		{Field: "purchase_price", Code: domainErrors.CodeTooSmall, Message: "purchase_price must be 0 or greater", Params: map[string]interface{}{"min": int64(0)}},
+		{Field: "currency", Code: domainErrors.CodeOneOf, Message: "currency must be one of: JPY, USD, EUR, GBP, CHF", Params: map[string]interface{}{"values": SupportedCurrencies}},
+	}, validationErrs)
+}
+
+func TestItem_Validate_FuturePurchaseDate(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	beforeMidnight := time.Date(2023, 1, 15, 14, 59, 59, 0, time.UTC) // 日本時間 2023-01-15 23:59:59
+	afterMidnight := time.Date(2023, 1, 15, 15, 0, 0, 0, time.UTC)    // 日本時間 2023-01-16 00:00:00
+
+	tests := []struct {
+		name         string
+		current      time.Time
+		loc          *time.Location
+		purchaseDate string
+		wantErr      bool
+	}{
+		{"正常系: 今日の日付", beforeMidnight, jst, "2023-01-15", false},
+		{"異常系: 日付が変わる直前の翌日", beforeMidnight, jst, "2023-01-16", true},
+		{"正常系: 日付が変わった直後の今日", afterMidnight, jst, "2023-01-16", false},
+		{"異常系: UTCではまだ翌日が未来", afterMidnight, time.UTC, "2023-01-16", true},
+		{"異常系: 遠い未来", afterMidnight, jst, "2099-01-01", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setNow(t, tt.current, tt.loc)
+			purchaseDate := MustParsePurchaseDate(tt.purchaseDate)
+
+			// 登録・全体更新・部分更新のいずれでも同じように検証される
+			_, err := NewItem(NewItemInput{Name: "時計", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, PurchaseDate: purchaseDate, Categories: testCategories})
+			if tt.wantErr {
+				assert.EqualError(t, err, "purchase_date must not be in the future")
+			} else {
+				assert.NoError(t, err)
+			}
+
+			existing := &Item{Name: "時計", Category: "時計", Brand: "ROLEX", Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-01")}
+			err = existing.Update(existing.Version, "時計", "時計", "ROLEX", 1000, "", purchaseDate, "", "", "", "", nil, testCategories)
+			assert.Equal(t, tt.wantErr, err != nil)
 
-	assert.Equal(t, expected, categories)
-	assert.Len(t, categories, 5)
+			existing = &Item{Name: "時計", Category: "時計", Brand: "ROLEX", Currency: "JPY", PurchaseDate: purchaseDate}
+			err = existing.UpdatePartial(existing.Version, nil, nil, nil, int64Ptr(2000), nil, nil, nil, nil, nil, nil, nil, testCategories)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestItem_UpdatePartial_CategoryAndPurchaseDate(t *testing.T) {
+	tests := []struct {
+		name             string
+		category         *string
+		purchaseDate     *string
+		wantCategory     string
+		wantPurchaseDate string
+		wantErrors       domainErrors.ValidationErrors
+	}{
+		{
+			name:             "正常系: categoryのみ更新",
+			category:         stringPtr(" バッグ "),
+			wantCategory:     "バッグ",
+			wantPurchaseDate: "2023-01-01",
+		},
+		{
+			name:             "正常系: purchase_dateはYYYY-MM-DDに正規化",
+			purchaseDate:     stringPtr("2023-02-20T15:04:05+09:00"),
+			wantCategory:     "時計",
+			wantPurchaseDate: "2023-02-20",
+		},
+		{
+			name:             "正常系: YYYY/MM/DD形式のpurchase_dateも正規化",
+			purchaseDate:     stringPtr("2023/02/20"),
+			wantCategory:     "時計",
+			wantPurchaseDate: "2023-02-20",
+		},
+		{
+			name:       "異常系: 登録されていないcategory",
+			category:   stringPtr("家電"),
+			wantErrors: domainErrors.NewValidationErrors(domainErrors.OneOf("category", testCategories.Names())),
+		},
+		{
+			name:       "異常系: 空文字のcategory",
+			category:   stringPtr(""),
+			wantErrors: domainErrors.NewValidationErrors(domainErrors.Required("category")),
+		},
+		{
+			name:         "異常系: 空文字のpurchase_date",
+			purchaseDate: stringPtr(""),
+			wantErrors:   domainErrors.NewValidationErrors(domainErrors.Empty("purchase_date")),
+		},
+		{
+			name:         "異常系: 不正な形式のpurchase_date",
+			purchaseDate: stringPtr("2023.01.01"),
+			wantErrors:   domainErrors.NewValidationErrors(domainErrors.NewRuleError("purchase_date", domainErrors.CodeInvalidFormat, "purchase_date must be in YYYY-MM-DD format", map[string]interface{}{"format": "YYYY-MM-DD"})),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := NewItem(NewItemInput{Name: "時計", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
+			require.NoError(t, err)
+
+			err = item.UpdatePartial(item.Version, nil, tt.category, nil, nil, nil, tt.purchaseDate, nil, nil, nil, nil, nil, testCategories)
+
+			if tt.wantErrors != nil {
+				var got domainErrors.ValidationErrors
+				require.ErrorAs(t, err, &got)
+				assert.Equal(t, tt.wantErrors, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCategory, item.Category)
+			assert.Equal(t, tt.wantPurchaseDate, item.PurchaseDate.String())
+		})
+	}
+}
+
+func TestItem_UpdatePartial_EmptyCurrency(t *testing.T) {
+	item, err := NewItem(NewItemInput{Name: "時計", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "USD", PurchaseDate: MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
+	require.NoError(t, err)
+
+	// 空文字は省略とは区別し、デフォルトの通貨に戻さない
+	err = item.UpdatePartial(item.Version, nil, nil, nil, nil, stringPtr(""), nil, nil, nil, nil, nil, nil, testCategories)
+
+	assert.EqualError(t, err, "currency cannot be empty")
+	assert.Equal(t, "USD", item.Currency)
+}
+
+func TestItem_VersionConflict(t *testing.T) {
+	item, err := NewItem(NewItemInput{Name: "時計", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
+	require.NoError(t, err)
+	assert.Equal(t, InitialItemVersion, item.Version)
+
+	item.Version = 3
+	originalUpdatedAt := item.UpdatedAt
+
+	// 古いバージョンでの更新は何も変更せずに現在のバージョンを返す
+	err = item.UpdatePartial(2, stringPtr("別の名前"), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, testCategories)
+	var conflictErr *domainErrors.VersionConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, int64(3), conflictErr.CurrentVersion)
+	assert.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+	assert.True(t, domainErrors.IsConflictError(err))
+	assert.Equal(t, "時計", item.Name)
+	assert.Equal(t, originalUpdatedAt, item.UpdatedAt)
+
+	err = item.Update(4, "別の名前", "時計", "ROLEX", 1000, "JPY", MustParsePurchaseDate("2023-01-01"), "", "", "", "", nil, testCategories)
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, "時計", item.Name)
+
+	require.NoError(t, item.UpdatePartial(3, stringPtr("別の名前"), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, testCategories))
+	assert.Equal(t, "別の名前", item.Name)
+}
+
+func TestItem_SerialNumber(t *testing.T) {
+	date := MustParsePurchaseDate("2023-01-15")
+
+	t.Run("正常系: 前後の空白を除いて保存する", func(t *testing.T) {
+		item, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, SerialNumber: "  SN-001 ", Categories: testCategories})
+		require.NoError(t, err)
+		require.NotNil(t, item.SerialNumber)
+		assert.Equal(t, "SN-001", *item.SerialNumber)
+	})
+
+	t.Run("正常系: 空の場合は未設定", func(t *testing.T) {
+		item, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, SerialNumber: " ", Categories: testCategories})
+		require.NoError(t, err)
+		assert.Nil(t, item.SerialNumber)
+	})
+
+	t.Run("異常系: 64文字を超える", func(t *testing.T) {
+		_, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, SerialNumber: strings.Repeat("A", MaxSerialNumberLength+1), Categories: testCategories})
+		assert.Equal(t, domainErrors.NewValidationErrors(domainErrors.TooLong("serial_number", 64)), err)
+	})
+
+	t.Run("正常系: 部分更新で空文字を指定すると削除する", func(t *testing.T) {
+		item, _ := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, SerialNumber: "SN-001", Categories: testCategories})
+		require.NoError(t, item.UpdatePartial(item.Version, nil, nil, nil, nil, nil, nil, stringPtr(""), nil, nil, nil, nil, testCategories))
+		assert.Nil(t, item.SerialNumber)
+	})
+}
+
+func TestItem_Notes(t *testing.T) {
+	date := MustParsePurchaseDate("2023-01-15")
+
+	t.Run("正常系: 2000文字まで登録できる", func(t *testing.T) {
+		item, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, Notes: strings.Repeat("あ", MaxNotesLength), Categories: testCategories})
+		require.NoError(t, err)
+		assert.Equal(t, MaxNotesLength, len([]rune(item.Notes)))
+	})
+
+	t.Run("異常系: 2000文字を超える", func(t *testing.T) {
+		_, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, Notes: strings.Repeat("あ", MaxNotesLength+1), Categories: testCategories})
+		assert.Equal(t, domainErrors.NewValidationErrors(domainErrors.TooLong("notes", 2000)), err)
+	})
+
+	t.Run("正常系: 部分更新では省略すると変更せず、空文字で削除する", func(t *testing.T) {
+		item, _ := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, Notes: "金庫に保管\n2024年にオーバーホール", Categories: testCategories})
+
+		require.NoError(t, item.UpdatePartial(item.Version, stringPtr("デイトナ2"), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, testCategories))
+		assert.Equal(t, "金庫に保管\n2024年にオーバーホール", item.Notes)
+
+		require.NoError(t, item.UpdatePartial(item.Version, nil, nil, nil, nil, nil, nil, nil, nil, stringPtr(""), nil, nil, testCategories))
+		assert.Empty(t, item.Notes)
+	})
+}
+
+func TestItem_Condition(t *testing.T) {
+	date := MustParsePurchaseDate("2023-01-15")
+
+	t.Run("正常系: 省略した場合は未設定", func(t *testing.T) {
+		item, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, Condition: " ", Categories: testCategories})
+		require.NoError(t, err)
+		assert.Nil(t, item.Condition)
+	})
+
+	t.Run("正常系: 有効な状態を登録できる", func(t *testing.T) {
+		item, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, Condition: " 中古A ", Categories: testCategories})
+		require.NoError(t, err)
+		assert.Equal(t, stringPtr("中古A"), item.Condition)
+	})
+
+	t.Run("異常系: 無効な状態は指定できる値を含むエラー", func(t *testing.T) {
+		_, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, Condition: "ジャンク", Categories: testCategories})
+		assert.Equal(t, domainErrors.NewValidationErrors(domainErrors.OneOf("condition", []string{"新品", "未使用", "中古A", "中古B", "中古C"})), err)
+	})
+
+	t.Run("正常系: 部分更新では省略すると変更せず、空文字で未設定に戻す", func(t *testing.T) {
+		item, _ := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, Condition: "新品", Categories: testCategories})
+
+		require.NoError(t, item.UpdatePartial(item.Version, stringPtr("デイトナ2"), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, testCategories))
+		assert.Equal(t, stringPtr("新品"), item.Condition)
+
+		require.NoError(t, item.UpdatePartial(item.Version, nil, nil, nil, nil, nil, nil, nil, stringPtr("中古B"), nil, nil, nil, testCategories))
+		assert.Equal(t, stringPtr("中古B"), item.Condition)
+
+		require.NoError(t, item.UpdatePartial(item.Version, nil, nil, nil, nil, nil, nil, nil, stringPtr(""), nil, nil, nil, testCategories))
+		assert.Nil(t, item.Condition)
+	})
+}
+
+func TestItem_PurchaseLocation(t *testing.T) {
+	date := MustParsePurchaseDate("2023-01-15")
+
+	t.Run("正常系: 全角を含む空白をまとめて正規化", func(t *testing.T) {
+		item, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, PurchaseLocation: " 銀座　 本店 ", Categories: testCategories})
+		require.NoError(t, err)
+		assert.Equal(t, stringPtr("銀座 本店"), item.PurchaseLocation)
+	})
+
+	t.Run("正常系: 空白のみの場合は未設定", func(t *testing.T) {
+		item, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, PurchaseLocation: "　 ", Categories: testCategories})
+		require.NoError(t, err)
+		assert.Nil(t, item.PurchaseLocation)
+	})
+
+	t.Run("異常系: 100文字を超える", func(t *testing.T) {
+		_, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, PurchaseLocation: strings.Repeat("店", 101), Categories: testCategories})
+		assert.Equal(t, domainErrors.NewValidationErrors(domainErrors.TooLong("purchase_location", 100)), err)
+	})
+
+	t.Run("正常系: 部分更新では省略すると変更せず、空文字で未設定に戻す", func(t *testing.T) {
+		item, _ := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, PurchaseLocation: "銀座本店", Categories: testCategories})
+
+		require.NoError(t, item.UpdatePartial(item.Version, stringPtr("デイトナ2"), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, testCategories))
+		assert.Equal(t, stringPtr("銀座本店"), item.PurchaseLocation)
+
+		require.NoError(t, item.UpdatePartial(item.Version, nil, nil, nil, nil, nil, nil, nil, nil, nil, stringPtr(""), nil, testCategories))
+		assert.Nil(t, item.PurchaseLocation)
+	})
+}
+
+func TestItem_MarkSold(t *testing.T) {
+	listedItem := func() *Item {
+		item, _ := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-15"), Categories: testCategories})
+		item.Status = ItemStatusListed
+		return item
+	}
+
+	t.Run("正常系: 売却価格と売却日を記録して利益を計算する", func(t *testing.T) {
+		item := listedItem()
+
+		require.NoError(t, item.MarkSold(1200000, MustParsePurchaseDate("2023-01-15")))
+		assert.Equal(t, ItemStatusSold, item.Status)
+		assert.Equal(t, int64Ptr(1200000), item.SellingPrice)
+		assert.Equal(t, "2023-01-15", item.SoldDate.String())
+		assert.Equal(t, int64Ptr(200000), item.Profit())
+	})
+
+	t.Run("正常系: 購入価格より安く売却した場合は負の利益", func(t *testing.T) {
+		item := listedItem()
+
+		require.NoError(t, item.MarkSold(0, MustParsePurchaseDate("2023-06-01")))
+		assert.Equal(t, int64Ptr(-1000000), item.Profit())
+	})
+
+	t.Run("異常系: 売却日が購入日より前、売却価格が負", func(t *testing.T) {
+		item := listedItem()
+
+		err := item.MarkSold(-1, MustParsePurchaseDate("2023-01-14"))
+
+		var errs domainErrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Equal(t, "selling_price", errs[0].Field)
+		assert.Equal(t, "sold_date must be on or after purchase_date", errs[1].Message)
+		assert.Equal(t, ItemStatusListed, item.Status)
+		assert.Nil(t, item.SellingPrice)
+	})
+
+	t.Run("異常系: 売却済みのアイテム", func(t *testing.T) {
+		item := listedItem()
+		require.NoError(t, item.MarkSold(1200000, MustParsePurchaseDate("2023-06-01")))
+
+		err := item.MarkSold(1300000, MustParsePurchaseDate("2023-07-01"))
+
+		assert.Equal(t, domainErrors.NewStatusTransitionError("sold", "sold"), err)
+		assert.Equal(t, int64Ptr(1200000), item.SellingPrice)
+	})
+
+	t.Run("正常系: 売却していないアイテムのJSONにはprofitを含めない", func(t *testing.T) {
+		data, err := json.Marshal(listedItem())
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "profit")
+
+		sold := listedItem()
+		require.NoError(t, sold.MarkSold(1200000, MustParsePurchaseDate("2023-06-01")))
+		data, err = json.Marshal(sold)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"selling_price":1200000,"sold_date":"2023-06-01"`)
+		assert.Contains(t, string(data), `"profit":200000`)
+	})
+}
+
+func TestItem_IsDuplicateOf(t *testing.T) {
+	date := MustParsePurchaseDate("2023-01-15")
+	existing := &Item{Name: "デイトナ", Brand: "Rolex ", PurchaseDate: date, SerialNumber: stringPtr("SN-001")}
+
+	tests := []struct {
+		name     string
+		item     *Item
+		expected bool
+	}{
+		{"正常系: 大文字小文字と前後の空白を無視する", &Item{Name: "デイトナ", Brand: "rolex", PurchaseDate: date}, true},
+		{"正常系: 全角スペースを無視する", &Item{Name: "\u3000デイトナ\u3000", Brand: "ROLEX", PurchaseDate: date}, true},
+		{"正常系: 名前が異なる", &Item{Name: "サブマリーナ", Brand: "ROLEX", PurchaseDate: date}, false},
+		{"正常系: ブランドが異なる", &Item{Name: "デイトナ", Brand: "OMEGA", PurchaseDate: date}, false},
+		{"正常系: シリアル番号が異なる", &Item{Name: "デイトナ", Brand: "ROLEX", PurchaseDate: date, SerialNumber: stringPtr("SN-002")}, false},
+		{"正常系: 購入日が異なる", &Item{Name: "デイトナ", Brand: "ROLEX", PurchaseDate: MustParsePurchaseDate("2023-01-16")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.item.IsDuplicateOf(existing))
+		})
+	}
 }
 
 func TestItem_UpdatePartial(t *testing.T) {
@@ -341,7 +853,7 @@ func TestItem_UpdatePartial(t *testing.T) {
 		name            string
 		inputName       *string
 		inputBrand      *string
-		inputPrice      *int
+		inputPrice      *int64
 		wantErr         bool
 		expectedErr     string
 	}{
@@ -357,14 +869,14 @@ func TestItem_UpdatePartial(t *testing.T) {
 		},
 		{
 			name:       "正常系: purchase_priceのみ更新",
-			inputPrice: intPtr(250000),
+			inputPrice: int64Ptr(250000),
 			wantErr:    false,
 		},
 		{
 			name:       "正常系: 全フィールド更新",
 			inputName:  stringPtr("全て更新"),
 			inputBrand: stringPtr("全て更新ブランド"),
-			inputPrice: intPtr(300000),
+			inputPrice: int64Ptr(300000),
 			wantErr:    false,
 		},
 		{
@@ -379,7 +891,7 @@ func TestItem_UpdatePartial(t *testing.T) {
 		},
 		{
 			name:        "異常系: 100文字超過のname",
-			inputName:   stringPtr("ロレックス デイトナ 16520 18K イエローゴールド ブラック文字盤 自動巻き クロノグラフ メンズ 腕時計 1988年製 ヴィンテージ 希少 コレクション アイテム"),
+			inputName:   stringPtr(strings.Repeat("時", 101)),
 			wantErr:     true,
 			expectedErr: "name must be 100 characters or less",
 		},
@@ -391,19 +903,19 @@ func TestItem_UpdatePartial(t *testing.T) {
 		},
 		{
 			name:        "異常系: 100文字超過のbrand",
-			inputBrand:  stringPtr("ROLEX SA Geneva Switzerland Official Authorized Dealer Store Premium Collection Limited Edition Special"),
+			inputBrand:  stringPtr(strings.Repeat("ロ", 101)),
 			wantErr:     true,
 			expectedErr: "brand must be 100 characters or less",
 		},
 		{
 			name:        "異常系: 負のpurchase_price",
-			inputPrice:  intPtr(-1),
+			inputPrice:  int64Ptr(-1),
 			wantErr:     true,
 			expectedErr: "purchase_price must be 0 or greater",
 		},
 		{
 			name:       "正常系: purchase_priceが0",
-			inputPrice: intPtr(0),
+			inputPrice: int64Ptr(0),
 			wantErr:    false,
 		},
 	}
@@ -411,7 +923,7 @@ func TestItem_UpdatePartial(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// 各テストケースで新しいアイテムを作成
-			item, err := NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+			item, err := NewItem(NewItemInput{Name: "初期アイテム", Category: "時計", Brand: "初期ブランド", PurchasePrice: 100000, Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
 			require.NoError(t, err)
 
 			originalUpdatedAt := item.UpdatedAt
@@ -421,7 +933,7 @@ func TestItem_UpdatePartial(t *testing.T) {
 
 			time.Sleep(1 * time.Millisecond) // UpdatedAt の変更を確認するため
 
-			err = item.UpdatePartial(tt.inputName, tt.inputBrand, tt.inputPrice)
+			err = item.UpdatePartial(item.Version, tt.inputName, nil, tt.inputBrand, tt.inputPrice, nil, nil, nil, nil, nil, nil, nil, testCategories)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -447,7 +959,7 @@ func TestItem_UpdatePartial(t *testing.T) {
 			if tt.inputPrice != nil {
 				assert.Equal(t, *tt.inputPrice, item.PurchasePrice)
 			} else {
-				assert.Equal(t, 100000, item.PurchasePrice) // 元の値のまま
+				assert.Equal(t, int64(100000), item.PurchasePrice) // 元の値のまま
 			}
 
 			// UpdatedAt が更新されているかチェック
@@ -466,6 +978,152 @@ func stringPtr(s string) *string {
 	return &s
 }
 
-func intPtr(i int) *int {
+func int64Ptr(i int64) *int64 {
 	return &i
 }
+
+func TestNewItem_NormalizesText(t *testing.T) {
+	date := MustParsePurchaseDate("2023-01-15")
+
+	t.Run("正常系: 全角スペースを除き、ブランドの全角英数字を半角にする", func(t *testing.T) {
+		item, err := NewItem(NewItemInput{Name: "　マトラッセ　　チェーンバッグ ", Category: "バッグ", Brand: "　ＣＨＡＮＥＬ　", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, Tags: []string{"　限定　"}, Categories: testCategories})
+		require.NoError(t, err)
+		assert.Equal(t, "マトラッセ チェーンバッグ", item.Name)
+		assert.Equal(t, "CHANEL", item.Brand)
+		assert.Equal(t, []string{"限定"}, item.Tags)
+	})
+
+	t.Run("異常系: 全角スペースのみの名前", func(t *testing.T) {
+		_, err := NewItem(NewItemInput{Name: "　", Category: "バッグ", Brand: "シャネル", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, Categories: testCategories})
+		assert.Equal(t, domainErrors.NewValidationErrors(domainErrors.Required("name")), err)
+	})
+
+	t.Run("正常系: 部分更新でも正規化する", func(t *testing.T) {
+		item, _ := NewItem(NewItemInput{Name: "マトラッセ", Category: "バッグ", Brand: "CHANEL", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, Categories: testCategories})
+
+		require.NoError(t, item.UpdatePartial(item.Version, stringPtr("　ボーイシャネル　"), nil, stringPtr("ＣＨＡＮＥＬ"), nil, nil, nil, nil, nil, nil, nil, nil, testCategories))
+		assert.Equal(t, "ボーイシャネル", item.Name)
+		assert.Equal(t, "CHANEL", item.Brand)
+	})
+}
+
+func TestItem_Validate_MultiByteLength(t *testing.T) {
+	date := MustParsePurchaseDate("2023-01-15")
+
+	tests := []struct {
+		name        string
+		itemName    string
+		brand       string
+		expectedErr error
+	}{
+		{"正常系: 日本語100文字の名前とブランド", strings.Repeat("時", 100), strings.Repeat("ロ", 100), nil},
+		{"正常系: 絵文字を含む100文字", strings.Repeat("⌚", 99) + "a", strings.Repeat("💎", 100), nil},
+		{"異常系: 日本語101文字の名前", strings.Repeat("時", 101), "ROLEX", domainErrors.NewValidationErrors(domainErrors.TooLong("name", MaxItemNameLength))},
+		{"異常系: 日本語101文字のブランド", "デイトナ", strings.Repeat("ロ", 101), domainErrors.NewValidationErrors(domainErrors.TooLong("brand", MaxBrandNameLength))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewItem(NewItemInput{Name: tt.itemName, Category: "時計", Brand: tt.brand, PurchasePrice: 1000, Currency: "JPY", PurchaseDate: date, Categories: testCategories})
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestItem_Timestamps(t *testing.T) {
+	created := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	clock := testutil.NewFixedClock(created)
+	t.Cleanup(SetClock(clock))
+
+	item, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-15"), Categories: testCategories})
+	require.NoError(t, err)
+	assert.Equal(t, created, item.CreatedAt)
+	assert.Equal(t, created, item.UpdatedAt)
+
+	clock.Advance(time.Hour)
+	require.NoError(t, item.Update(item.Version, "デイトナ", "時計", "ROLEX", 2000, "JPY", item.PurchaseDate, "", "", "", "", nil, testCategories))
+	assert.Equal(t, created, item.CreatedAt)
+	assert.Equal(t, created.Add(time.Hour), item.UpdatedAt)
+
+	clock.Advance(time.Hour)
+	require.NoError(t, item.UpdatePartial(item.Version, nil, nil, nil, int64Ptr(3000), nil, nil, nil, nil, nil, nil, nil, testCategories))
+	assert.Equal(t, created.Add(2*time.Hour), item.UpdatedAt)
+}
+
+func TestNewItem_Input(t *testing.T) {
+	date := MustParsePurchaseDate("2023-01-15")
+
+	t.Run("正常系: 必須フィールドのみ", func(t *testing.T) {
+		item, err := NewItem(NewItemInput{
+			Name:         "デイトナ",
+			Category:     "時計",
+			Brand:        "ROLEX",
+			PurchaseDate: date,
+			Categories:   testCategories,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "JPY", item.Currency)
+		assert.Equal(t, int64(0), item.PurchasePrice)
+		assert.Nil(t, item.SerialNumber)
+		assert.Nil(t, item.Condition)
+		assert.Nil(t, item.PurchaseLocation)
+		assert.Nil(t, item.Tags)
+		assert.Equal(t, ItemStatusOwned, item.Status)
+	})
+
+	t.Run("正常系: すべての任意フィールドを指定", func(t *testing.T) {
+		item, err := NewItem(NewItemInput{
+			Name:             "デイトナ",
+			Category:         "時計",
+			Brand:            "ROLEX",
+			PurchasePrice:    10000,
+			Currency:         "usd",
+			PurchaseDate:     date,
+			SerialNumber:     " SN-001 ",
+			Condition:        "中古A",
+			Notes:            "正規店で購入",
+			PurchaseLocation: "銀座　本店",
+			Tags:             []string{"限定", "プレゼント"},
+			Categories:       testCategories,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(10000), item.PurchasePrice)
+		assert.Equal(t, "USD", item.Currency)
+		assert.Equal(t, stringPtr("SN-001"), item.SerialNumber)
+		assert.Equal(t, stringPtr("中古A"), item.Condition)
+		assert.Equal(t, "正規店で購入", item.Notes)
+		assert.Equal(t, stringPtr("銀座 本店"), item.PurchaseLocation)
+		assert.Equal(t, []string{"プレゼント", "限定"}, item.Tags)
+	})
+
+	t.Run("異常系: 必須フィールドの省略", func(t *testing.T) {
+		_, err := NewItem(NewItemInput{Categories: testCategories})
+		assert.EqualError(t, err, "name is required, category is required, brand is required, purchase_date is required")
+	})
+
+	t.Run("正常系: 非推奨の引数版も同じアイテムを作成する", func(t *testing.T) {
+		item, err := NewItemFromArgs("デイトナ", "時計", "ROLEX", 1000, "", date, "", "", "", "", nil, testCategories)
+		require.NoError(t, err)
+		assert.Equal(t, "デイトナ", item.Name)
+		assert.Equal(t, "JPY", item.Currency)
+	})
+}
+
+func TestItem_Clone(t *testing.T) {
+	serialNumber := "SN-001"
+	price := int64(1500000)
+	item := &Item{ID: 1, Name: "デイトナ", SerialNumber: &serialNumber, SellingPrice: &price, Tags: []string{"限定"}}
+
+	clone := item.Clone()
+	*clone.SerialNumber = "SN-002"
+	*clone.SellingPrice = 0
+	clone.Tags[0] = "ギフト"
+	clone.Name = "サブマリーナ"
+
+	// 複製を変更しても元のアイテムは変わらない
+	assert.Equal(t, "SN-001", *item.SerialNumber)
+	assert.Equal(t, int64(1500000), *item.SellingPrice)
+	assert.Equal(t, []string{"限定"}, item.Tags)
+	assert.Equal(t, "デイトナ", item.Name)
+	assert.Nil(t, clone.Condition)
+}
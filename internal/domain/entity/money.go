@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 金額と通貨の組。金額は通貨の最小単位ではなく、購入価格と同じ通貨単位の整数で持つ。
+// 通貨の異なる金額どうしは計算できない
+type Money struct {
+	amount   int64
+	currency string
+}
+
+// 0以上の金額を作る。currencyは正規化し、空の場合はデフォルトの通貨とする
+func NewMoney(amount int64, currency string) (Money, error) {
+	currency = NormalizeCurrency(currency)
+	if amount < 0 {
+		return Money{}, fmt.Errorf("%w: amount must be 0 or greater", domainErrors.ErrInvalidInput)
+	}
+	if !IsSupportedCurrency(currency) {
+		return Money{}, fmt.Errorf("%w: currency %s is not supported", domainErrors.ErrInvalidInput, currency)
+	}
+	return Money{amount: amount, currency: currency}, nil
+}
+
+// NewMoneyと同じだが、作れない場合はpanicする。テストや固定値の定義に使う
+func MustNewMoney(amount int64, currency string) Money {
+	m, err := NewMoney(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// 指定した通貨の0円（0通貨単位）。合計の初期値に使う
+func ZeroMoney(currency string) Money {
+	return moneyOf(0, currency)
+}
+
+// 保存済みの値から金額を作る。集計結果のように検証済みの値や、利益のように負になりうる値に使う
+func moneyOf(amount int64, currency string) Money {
+	return Money{amount: amount, currency: NormalizeCurrency(currency)}
+}
+
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+func (m Money) Currency() string {
+	if m.currency == "" {
+		return DefaultCurrency
+	}
+	return m.currency
+}
+
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// 同じ通貨の金額を足す。通貨が異なる場合はErrCurrencyMismatch、int64の範囲を超える場合はErrAmountOverflowを返す
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.checkCurrency(other); err != nil {
+		return Money{}, err
+	}
+	if (other.amount > 0 && m.amount > math.MaxInt64-other.amount) || (other.amount < 0 && m.amount < math.MinInt64-other.amount) {
+		return Money{}, domainErrors.ErrAmountOverflow
+	}
+	return Money{amount: m.amount + other.amount, currency: m.Currency()}, nil
+}
+
+// 同じ通貨の金額を引く。結果は負になりうる。エラーはAddと同じ
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.checkCurrency(other); err != nil {
+		return Money{}, err
+	}
+	if (other.amount < 0 && m.amount > math.MaxInt64+other.amount) || (other.amount > 0 && m.amount < math.MinInt64+other.amount) {
+		return Money{}, domainErrors.ErrAmountOverflow
+	}
+	return Money{amount: m.amount - other.amount, currency: m.Currency()}, nil
+}
+
+// rate（1通貨単位あたりのcurrencyの金額）で換算する。端数は四捨五入する
+func (m Money) Convert(currency string, rate float64) Money {
+	return Money{amount: int64(math.Round(float64(m.amount) * rate)), currency: NormalizeCurrency(currency)}
+}
+
+func (m Money) checkCurrency(other Money) error {
+	if m.Currency() != other.Currency() {
+		return fmt.Errorf("%w: %s and %s", domainErrors.ErrCurrencyMismatch, m.Currency(), other.Currency())
+	}
+	return nil
+}
+
+// 既存のpurchase_priceなどと同じく、金額のみを整数で出力する。通貨は別のフィールドで返す
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.amount)
+}
+
+// 整数の金額を読み込む。通貨は変更せず、未設定の場合はデフォルトの通貨とする
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var amount int64
+	if err := json.Unmarshal(data, &amount); err != nil {
+		return fmt.Errorf("amount must be an integer")
+	}
+	*m = moneyOf(amount, m.currency)
+	return nil
+}
+
+// 金額の列を読み込む。通貨は別の列に保存しているため、Scanでは変更しない
+func (m *Money) Scan(src interface{}) error {
+	var amount int64
+	switch v := src.(type) {
+	case int64:
+		amount = v
+	case []byte:
+		if _, err := fmt.Sscan(string(v), &amount); err != nil {
+			return fmt.Errorf("cannot scan %q into Money: %w", v, err)
+		}
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+	*m = moneyOf(amount, m.currency)
+	return nil
+}
+
+// 金額の列に整数で保存する
+func (m Money) Value() (driver.Value, error) {
+	return m.amount, nil
+}
@@ -0,0 +1,147 @@
+package entity
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestNewMoney(t *testing.T) {
+	tests := []struct {
+		name             string
+		amount           int64
+		currency         string
+		expectedCurrency string
+		expectedErr      string
+	}{
+		{name: "正常系: 通貨を正規化する", amount: 1000, currency: " usd ", expectedCurrency: "USD"},
+		{name: "正常系: 通貨が空の場合はJPY", amount: 0, currency: "", expectedCurrency: "JPY"},
+		{name: "異常系: 負の金額", amount: -1, currency: "JPY", expectedErr: "invalid input: amount must be 0 or greater"},
+		{name: "異常系: 対応していない通貨", amount: 1000, currency: "KRW", expectedErr: "invalid input: currency KRW is not supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMoney(tt.amount, tt.currency)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.amount, m.Amount())
+			assert.Equal(t, tt.expectedCurrency, m.Currency())
+		})
+	}
+}
+
+func TestMoney_Add(t *testing.T) {
+	tests := []struct {
+		name        string
+		a           Money
+		b           Money
+		expected    int64
+		expectedErr error
+	}{
+		{name: "正常系: 同じ通貨", a: MustNewMoney(1000, "JPY"), b: MustNewMoney(500, "JPY"), expected: 1500},
+		{name: "正常系: ゼロ値はJPYとして扱う", a: Money{}, b: MustNewMoney(500, "JPY"), expected: 500},
+		{name: "異常系: 通貨が異なる", a: MustNewMoney(1000, "JPY"), b: MustNewMoney(10, "USD"), expectedErr: domainErrors.ErrCurrencyMismatch},
+		{name: "異常系: 上限を超える", a: MustNewMoney(math.MaxInt64, "JPY"), b: MustNewMoney(1, "JPY"), expectedErr: domainErrors.ErrAmountOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum, err := tt.a.Add(tt.b)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.ErrorIs(t, err, domainErrors.ErrValidation)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sum.Amount())
+		})
+	}
+}
+
+func TestMoney_Sub(t *testing.T) {
+	tests := []struct {
+		name        string
+		a           Money
+		b           Money
+		expected    int64
+		expectedErr error
+	}{
+		{name: "正常系: 結果が正", a: MustNewMoney(1500, "USD"), b: MustNewMoney(1000, "USD"), expected: 500},
+		{name: "正常系: 結果は負になりうる", a: MustNewMoney(1000, "USD"), b: MustNewMoney(1500, "USD"), expected: -500},
+		{name: "異常系: 通貨が異なる", a: MustNewMoney(1000, "EUR"), b: MustNewMoney(10, "USD"), expectedErr: domainErrors.ErrCurrencyMismatch},
+		{name: "異常系: 下限を超える", a: moneyOf(math.MinInt64, "JPY"), b: MustNewMoney(1, "JPY"), expectedErr: domainErrors.ErrAmountOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := tt.a.Sub(tt.b)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, diff.Amount())
+			assert.Equal(t, tt.a.Currency(), diff.Currency())
+		})
+	}
+}
+
+func TestMoney_Convert(t *testing.T) {
+	converted := MustNewMoney(333, "USD").Convert("JPY", 149.5)
+
+	assert.Equal(t, int64(49784), converted.Amount()) // 49783.5を四捨五入
+	assert.Equal(t, "JPY", converted.Currency())
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		PurchasePrice Money `json:"purchase_price"`
+	}{MustNewMoney(128000, "JPY")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"purchase_price": 128000}`, string(data))
+
+	m := ZeroMoney("USD")
+	require.NoError(t, json.Unmarshal([]byte(`2500`), &m))
+	assert.Equal(t, MustNewMoney(2500, "USD"), m)
+
+	assert.EqualError(t, json.Unmarshal([]byte(`"2500"`), &m), "amount must be an integer")
+}
+
+func TestMoney_ScanValue(t *testing.T) {
+	m := ZeroMoney("EUR")
+	require.NoError(t, m.Scan(int64(1200)))
+	assert.Equal(t, MustNewMoney(1200, "EUR"), m)
+
+	require.NoError(t, m.Scan([]byte("3400")))
+	assert.Equal(t, int64(3400), m.Amount())
+
+	assert.Error(t, m.Scan("abc"))
+
+	value, err := m.Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3400), value)
+}
+
+func TestItem_PurchaseMoney(t *testing.T) {
+	item := &Item{PurchasePrice: 1000, Currency: "USD"}
+	assert.Equal(t, MustNewMoney(1000, "USD"), item.PurchaseMoney())
+	assert.Nil(t, item.SellingMoney())
+
+	sellingPrice := int64(1200)
+	item.SellingPrice = &sellingPrice
+	assert.Equal(t, MustNewMoney(1200, "USD"), *item.SellingMoney())
+	assert.Equal(t, int64(200), *item.Profit())
+}
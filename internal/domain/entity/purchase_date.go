@@ -0,0 +1,185 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 購入日の出力形式
+const purchaseDateLayout = "2006-01-02"
+
+// 「今日」を判定するタイムゾーンのデフォルト値
+const DefaultPurchaseDateTimezone = "Asia/Tokyo"
+
+// 「今日」を判定するタイムゾーン。起動時に設定から変更できる
+var PurchaseDateLocation = loadDefaultPurchaseDateLocation()
+
+// tzdataがない環境でも動くよう、読み込めない場合は日本標準時の固定オフセットを使う
+func loadDefaultPurchaseDateLocation() *time.Location {
+	loc, err := time.LoadLocation(DefaultPurchaseDateTimezone)
+	if err != nil {
+		return time.FixedZone("JST", 9*60*60)
+	}
+	return loc
+}
+
+// 入力として受け付ける形式。RFC3339の場合は日付部分のみを使い、いずれの形式でもYYYY-MM-DD形式にそろえて保存する
+var purchaseDateInputLayouts = []string{purchaseDateLayout, time.RFC3339, "2006/01/02"}
+
+// YYYY-MM-DDまたはYYYY/MM/DDの形をしているかどうか。存在しない日付と形式の誤りを区別するために使う
+var datePattern = regexp.MustCompile(`^\d{4}[-/]\d{2}[-/]\d{2}`)
+
+// 購入日。時刻を持たない日付として扱い、JSONとデータベースにはYYYY-MM-DD形式で保存する
+type PurchaseDate struct {
+	t time.Time
+}
+
+// 時刻を切り捨てて購入日を作る。年月日はtのタイムゾーンで解釈する
+func NewPurchaseDate(t time.Time) PurchaseDate {
+	if t.IsZero() {
+		return PurchaseDate{}
+	}
+	year, month, day := t.Date()
+	return PurchaseDate{t: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// PurchaseDateLocationでの今日の日付
+func Today() PurchaseDate {
+	return NewPurchaseDate(Now().In(PurchaseDateLocation))
+}
+
+// YYYY-MM-DD形式、RFC3339形式またはYYYY/MM/DD形式の文字列を購入日に変換する
+func ParsePurchaseDate(s string) (PurchaseDate, error) {
+	return parseDate("purchase_date", s)
+}
+
+// ParsePurchaseDateと同じ形式の文字列を売却日に変換する
+func ParseSoldDate(s string) (PurchaseDate, error) {
+	return parseDate("sold_date", s)
+}
+
+// fieldはエラーメッセージに使うフィールド名。変換できない場合はdomainErrors.ValidationErrorsを返す
+func parseDate(field, s string) (PurchaseDate, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range purchaseDateInputLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return NewPurchaseDate(t), nil
+		}
+	}
+
+	if datePattern.MatchString(s) {
+		return PurchaseDate{}, domainErrors.NewValidationErrors(domainErrors.NewRuleError(field, domainErrors.CodeInvalidDate, fmt.Sprintf("%s %s is not a valid calendar date", field, s), map[string]interface{}{"value": s}))
+	}
+	return PurchaseDate{}, domainErrors.NewValidationErrors(domainErrors.NewRuleError(field, domainErrors.CodeInvalidFormat, field+" must be in YYYY-MM-DD format", map[string]interface{}{"format": "YYYY-MM-DD"}))
+}
+
+// ParsePurchaseDateと同じだが、変換できない場合はpanicする。テストや固定値の定義に使う
+func MustParsePurchaseDate(s string) PurchaseDate {
+	d, err := ParsePurchaseDate(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// UTCの0時0分の時刻として返す
+func (d PurchaseDate) Time() time.Time {
+	return d.t
+}
+
+func (d PurchaseDate) IsZero() bool {
+	return d.t.IsZero()
+}
+
+func (d PurchaseDate) Before(other PurchaseDate) bool {
+	return d.t.Before(other.t)
+}
+
+func (d PurchaseDate) After(other PurchaseDate) bool {
+	return d.t.After(other.t)
+}
+
+func (d PurchaseDate) Equal(other PurchaseDate) bool {
+	return d.t.Equal(other.t)
+}
+
+// YYYY-MM-DD形式で返す。ゼロ値の場合は空文字
+func (d PurchaseDate) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.t.Format(purchaseDateLayout)
+}
+
+func (d PurchaseDate) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// nullと空文字はゼロ値として扱う。必須チェックはItem.Validateで行う
+func (d *PurchaseDate) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*d = PurchaseDate{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("purchase_date must be a string")
+	}
+	if strings.TrimSpace(s) == "" {
+		*d = PurchaseDate{}
+		return nil
+	}
+
+	parsed, err := ParsePurchaseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// DATE型の値を読み込む。ドライバの設定によりtime.Timeまたは文字列で渡される
+func (d *PurchaseDate) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = PurchaseDate{}
+		return nil
+	case time.Time:
+		*d = NewPurchaseDate(v)
+		return nil
+	case []byte:
+		return d.scanString(string(v))
+	case string:
+		return d.scanString(v)
+	default:
+		return fmt.Errorf("cannot scan %T into PurchaseDate", src)
+	}
+}
+
+func (d *PurchaseDate) scanString(s string) error {
+	parsed, err := ParsePurchaseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// DATE型の列にYYYY-MM-DD形式で保存する。ゼロ値はNULL
+func (d PurchaseDate) Value() (driver.Value, error) {
+	if d.IsZero() {
+		return nil, nil
+	}
+	return d.String(), nil
+}
@@ -0,0 +1,137 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/testutil"
+)
+
+func TestPurchaseDate_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(MustParsePurchaseDate("2023-01-15T10:00:00Z"))
+	require.NoError(t, err)
+	assert.Equal(t, `"2023-01-15"`, string(data))
+
+	data, err = json.Marshal(PurchaseDate{})
+	require.NoError(t, err)
+	assert.Equal(t, `null`, string(data))
+}
+
+func TestPurchaseDate_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    string
+		wantErr bool
+	}{
+		{"正常系: YYYY-MM-DD形式", `"2023-01-15"`, "2023-01-15", false},
+		{"正常系: RFC3339形式", `"2023-01-15T10:00:00Z"`, "2023-01-15", false},
+		{"正常系: nullはゼロ値", `null`, "", false},
+		{"正常系: 空文字はゼロ値", `""`, "", false},
+		{"異常系: 存在しない日付", `"2023-02-30"`, "", true},
+		{"異常系: 文字列ではない", `20230115`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d PurchaseDate
+			err := json.Unmarshal([]byte(tt.json), &d)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, d.String())
+		})
+	}
+}
+
+func TestPurchaseDate_Scan(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    string
+		wantErr bool
+	}{
+		{"正常系: time.Time", time.Date(2023, 1, 15, 0, 0, 0, 0, jst), "2023-01-15", false},
+		{"正常系: バイト列", []byte("2023-01-15"), "2023-01-15", false},
+		{"正常系: 文字列", "2023-01-15", "2023-01-15", false},
+		{"正常系: NULL", nil, "", false},
+		{"異常系: 未対応の型", 20230115, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d PurchaseDate
+			err := d.Scan(tt.src)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, d.String())
+		})
+	}
+}
+
+func TestPurchaseDate_Value(t *testing.T) {
+	v, err := MustParsePurchaseDate("2023-01-15").Value()
+	require.NoError(t, err)
+	assert.Equal(t, "2023-01-15", v)
+
+	v, err = PurchaseDate{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestPurchaseDate_Compare(t *testing.T) {
+	earlier := MustParsePurchaseDate("2023-01-15")
+	later := MustParsePurchaseDate("2023-01-16T00:30:00+09:00")
+
+	assert.True(t, earlier.Before(later))
+	assert.True(t, later.After(earlier))
+	assert.False(t, earlier.After(earlier))
+}
+
+// 現在時刻とタイムゾーンをテストの間だけ差し替える
+func setNow(t *testing.T, current time.Time, loc *time.Location) {
+	t.Helper()
+	originalLoc := PurchaseDateLocation
+	t.Cleanup(func() {
+		PurchaseDateLocation = originalLoc
+	})
+	t.Cleanup(SetClock(testutil.NewFixedClock(current)))
+	PurchaseDateLocation = loc
+}
+
+func TestToday(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name    string
+		current time.Time
+		loc     *time.Location
+		want    string
+	}{
+		{"日本時間で日付が変わる直前", time.Date(2023, 1, 15, 14, 59, 59, 0, time.UTC), jst, "2023-01-15"},
+		{"日本時間で日付が変わった直後", time.Date(2023, 1, 15, 15, 0, 0, 0, time.UTC), jst, "2023-01-16"},
+		{"UTCではまだ前日", time.Date(2023, 1, 15, 15, 0, 0, 0, time.UTC), time.UTC, "2023-01-15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setNow(t, tt.current, tt.loc)
+			assert.Equal(t, tt.want, Today().String())
+		})
+	}
+}
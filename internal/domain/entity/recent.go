@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 最近のアイテムの期間の既定値と上限
+const (
+	DefaultRecentWindow = 7 * 24 * time.Hour
+	MaxRecentWindow     = 90 * 24 * time.Hour
+)
+
+// 最近のアイテムに含めた理由。期間内に登録したアイテムはcreated、期間より前に登録して期間内に更新したアイテムはupdated
+const (
+	RecentEventCreated = "created"
+	RecentEventUpdated = "updated"
+)
+
+// 期間の単位
+var recentWindowUnits = map[byte]time.Duration{
+	'd': 24 * time.Hour,
+	'h': time.Hour,
+}
+
+// 「7d」「12h」のように、1以上の整数と単位（日数はd、時間数はh）で指定した期間を解析する。
+// 空の場合は既定値（7日）とし、上限（90日）を超える場合はエラーを返す
+func ParseRecentWindow(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return DefaultRecentWindow, nil
+	}
+
+	invalid := errors.New("window must be a positive integer followed by d (days) or h (hours), e.g. 7d or 12h")
+	unit, ok := recentWindowUnits[s[len(s)-1]]
+	if !ok {
+		return 0, invalid
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n < 1 {
+		return 0, invalid
+	}
+	// 掛け算のオーバーフローを避けるため、単位ごとの上限の数と比べる
+	if n > int(MaxRecentWindow/unit) {
+		return 0, errors.New("window must be 90d or less")
+	}
+
+	return time.Duration(n) * unit, nil
+}
+
+// sinceから後に登録または更新したアイテムを最近のアイテムに含めた理由
+func RecentEventOf(item *Item, since time.Time) string {
+	if item.CreatedAt.Before(since) {
+		return RecentEventUpdated
+	}
+	return RecentEventCreated
+}
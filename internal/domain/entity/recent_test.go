@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecentWindow(t *testing.T) {
+	tests := []struct {
+		name        string
+		window      string
+		expected    time.Duration
+		expectedErr string
+	}{
+		{name: "正常系: 未指定の場合は7日", window: "", expected: 7 * 24 * time.Hour},
+		{name: "正常系: 日数", window: "7d", expected: 7 * 24 * time.Hour},
+		{name: "正常系: 時間数", window: "12h", expected: 12 * time.Hour},
+		{name: "正常系: 大文字と前後の空白", window: " 3D ", expected: 3 * 24 * time.Hour},
+		{name: "正常系: 上限の90日", window: "90d", expected: 90 * 24 * time.Hour},
+		{name: "正常系: 上限の90日を時間数で指定", window: "2160h", expected: 90 * 24 * time.Hour},
+		{name: "異常系: 上限を超える日数", window: "91d", expectedErr: "window must be 90d or less"},
+		{name: "異常系: 上限を超える時間数", window: "2161h", expectedErr: "window must be 90d or less"},
+		{name: "異常系: オーバーフローする日数", window: "999999999999999999d", expectedErr: "window must be 90d or less"},
+		{name: "異常系: 単位がない", window: "7", expectedErr: "window must be a positive integer"},
+		{name: "異常系: 対応していない単位", window: "2w", expectedErr: "window must be a positive integer"},
+		{name: "異常系: 0", window: "0d", expectedErr: "window must be a positive integer"},
+		{name: "異常系: 負の数", window: "-1d", expectedErr: "window must be a positive integer"},
+		{name: "異常系: 小数", window: "1.5d", expectedErr: "window must be a positive integer"},
+		{name: "異常系: 単位のみ", window: "d", expectedErr: "window must be a positive integer"},
+		{name: "異常系: Goの期間の形式", window: "1h30m", expectedErr: "window must be a positive integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window, err := ParseRecentWindow(tt.window)
+
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, window)
+		})
+	}
+}
+
+func TestRecentEventOf(t *testing.T) {
+	since := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, RecentEventCreated, RecentEventOf(&Item{CreatedAt: since}, since))
+	assert.Equal(t, RecentEventCreated, RecentEventOf(&Item{CreatedAt: since.Add(time.Hour), UpdatedAt: since.Add(2 * time.Hour)}, since))
+	assert.Equal(t, RecentEventUpdated, RecentEventOf(&Item{CreatedAt: since.Add(-time.Second), UpdatedAt: since.Add(time.Hour)}, since))
+}
@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 支出レポートの集計単位
+const (
+	SpendGranularityMonth = "month"
+	SpendGranularityYear  = "year"
+)
+
+var ValidSpendGranularities = []string{SpendGranularityMonth, SpendGranularityYear}
+
+// 支出レポートで指定できる期間の上限（年）
+const MaxSpendRangeYears = 10
+
+// 集計単位ごとの期間の形式と、エラーメッセージでの表記
+var (
+	spendPeriodLayouts = map[string]string{
+		SpendGranularityMonth: "2006-01",
+		SpendGranularityYear:  "2006",
+	}
+	spendPeriodFormats = map[string]string{
+		SpendGranularityMonth: "YYYY-MM",
+		SpendGranularityYear:  "YYYY",
+	}
+)
+
+// 支出レポートの集計期間。FromとToはそれぞれ最初と最後の期間の初日で、Toの期間も含む
+type SpendRange struct {
+	Granularity string
+	From        time.Time
+	To          time.Time
+}
+
+// 集計単位と期間の文字列から集計期間を作成する。granularityが空の場合はmonthとする。
+// fromとtoは月単位の場合はYYYY-MM形式、年単位の場合はYYYY形式
+func ParseSpendRange(granularity, from, to string) (SpendRange, error) {
+	if granularity == "" {
+		granularity = SpendGranularityMonth
+	}
+	layout, ok := spendPeriodLayouts[granularity]
+	if !ok {
+		return SpendRange{}, errors.New("granularity must be one of: " + strings.Join(ValidSpendGranularities, ", "))
+	}
+
+	format := spendPeriodFormats[granularity]
+	var errs []string
+	fromTime, err := time.Parse(layout, from)
+	if err != nil {
+		errs = append(errs, "from must be in "+format+" format")
+	}
+	toTime, err := time.Parse(layout, to)
+	if err != nil {
+		errs = append(errs, "to must be in "+format+" format")
+	}
+	if len(errs) > 0 {
+		return SpendRange{}, errors.New(strings.Join(errs, ", "))
+	}
+
+	r := SpendRange{Granularity: granularity, From: fromTime, To: toTime}
+	if fromTime.After(toTime) {
+		return SpendRange{}, errors.New("from must be on or before to")
+	}
+	if r.End().After(fromTime.AddDate(MaxSpendRangeYears, 0, 0)) {
+		return SpendRange{}, fmt.Errorf("the range must be %d years or less", MaxSpendRangeYears)
+	}
+
+	return r, nil
+}
+
+// 集計期間の終わり（Toの次の期間の初日）
+func (r SpendRange) End() time.Time {
+	return r.next(r.To)
+}
+
+// 集計期間に含まれる期間を順に返す。月単位の場合はYYYY-MM形式、年単位の場合はYYYY形式
+func (r SpendRange) Periods() []string {
+	var periods []string
+	for t := r.From; !t.After(r.To); t = r.next(t) {
+		periods = append(periods, t.Format(spendPeriodLayouts[r.Granularity]))
+	}
+	return periods
+}
+
+func (r SpendRange) next(t time.Time) time.Time {
+	if r.Granularity == SpendGranularityYear {
+		return t.AddDate(1, 0, 0)
+	}
+	return t.AddDate(0, 1, 0)
+}
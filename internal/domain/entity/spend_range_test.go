@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpendRange(t *testing.T) {
+	tests := []struct {
+		name            string
+		granularity     string
+		from            string
+		to              string
+		expectedPeriods []string
+		expectedErr     string
+	}{
+		{
+			name:            "正常系: 月単位（年をまたぐ）",
+			granularity:     "month",
+			from:            "2023-11",
+			to:              "2024-02",
+			expectedPeriods: []string{"2023-11", "2023-12", "2024-01", "2024-02"},
+		},
+		{
+			name:            "正常系: 未指定の場合は月単位",
+			from:            "2024-03",
+			to:              "2024-03",
+			expectedPeriods: []string{"2024-03"},
+		},
+		{
+			name:            "正常系: 年単位",
+			granularity:     "year",
+			from:            "2022",
+			to:              "2024",
+			expectedPeriods: []string{"2022", "2023", "2024"},
+		},
+		{
+			name:            "正常系: ちょうど10年",
+			granularity:     "month",
+			from:            "2015-01",
+			to:              "2024-12",
+			expectedPeriods: nil,
+		},
+		{
+			name:        "異常系: 10年を超える（月単位）",
+			granularity: "month",
+			from:        "2015-01",
+			to:          "2025-01",
+			expectedErr: "the range must be 10 years or less",
+		},
+		{
+			name:        "異常系: 10年を超える（年単位）",
+			granularity: "year",
+			from:        "2015",
+			to:          "2025",
+			expectedErr: "the range must be 10 years or less",
+		},
+		{
+			name:        "異常系: fromがtoより後",
+			granularity: "month",
+			from:        "2024-02",
+			to:          "2024-01",
+			expectedErr: "from must be on or before to",
+		},
+		{
+			name:        "異常系: 形式が集計単位と一致しない",
+			granularity: "year",
+			from:        "2023-01",
+			to:          "",
+			expectedErr: "from must be in YYYY format, to must be in YYYY format",
+		},
+		{
+			name:        "異常系: 存在しない月",
+			granularity: "month",
+			from:        "2023-13",
+			to:          "2024-01",
+			expectedErr: "from must be in YYYY-MM format",
+		},
+		{
+			name:        "異常系: 無効な集計単位",
+			granularity: "week",
+			from:        "2023-01",
+			to:          "2023-02",
+			expectedErr: "granularity must be one of: month, year",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := ParseSpendRange(tt.granularity, tt.from, tt.to)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expectedPeriods != nil {
+				assert.Equal(t, tt.expectedPeriods, r.Periods())
+			} else {
+				assert.Len(t, r.Periods(), MaxSpendRangeYears*12)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// タグの最大文字数
+const MaxTagLength = 30
+
+// 1つのアイテムに付けられるタグの上限
+const MaxTagsPerItem = 10
+
+// タグと、そのタグが付いたアイテム（論理削除されたものを除く）の件数
+type TagCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// 空白を正規化し、空のタグと重複を取り除いて名前順に並べる。タグがない場合はnilを返す
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	var normalized []string
+	for _, tag := range tags {
+		tag = NormalizeText(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// 正規化済みのタグが同じかどうか
+func SameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// タグの数と各タグの文字数のバリデーション
+func validateTags(tags []string, errs *domainErrors.ValidationErrors) {
+	if len(tags) > MaxTagsPerItem {
+		errs.Append(domainErrors.NewRuleError("tags", domainErrors.CodeTooMany, fmt.Sprintf("an item can have at most %d tags", MaxTagsPerItem), map[string]interface{}{"max": MaxTagsPerItem}))
+	}
+	for _, tag := range tags {
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			errs.Append(domainErrors.NewRuleError("tags", domainErrors.CodeTooLong, tagLengthErrorMessage(), map[string]interface{}{"max": MaxTagLength}))
+			break
+		}
+	}
+}
+
+func tagLengthErrorMessage() string {
+	return fmt.Sprintf("each tag must be %d characters or less", MaxTagLength)
+}
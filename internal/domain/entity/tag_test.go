@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		expected []string
+	}{
+		{name: "正常系: 名前順に並べる", tags: []string{"限定品", "プレゼント"}, expected: []string{"プレゼント", "限定品"}},
+		{name: "正常系: 前後の空白を除いて重複を取り除く", tags: []string{"限定品", " 限定品 ", "プレゼント"}, expected: []string{"プレゼント", "限定品"}},
+		{name: "正常系: 空のタグは取り除く", tags: []string{"", "  "}, expected: nil},
+		{name: "正常系: 未指定", tags: nil, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeTags(tt.tags))
+		})
+	}
+}
+
+func TestNewItem_Tags(t *testing.T) {
+	newItem := func(tags []string) (*Item, error) {
+		return NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-15"), Tags: tags, Categories: testCategories})
+	}
+
+	t.Run("正常系: 上限までのタグ", func(t *testing.T) {
+		tags := make([]string, MaxTagsPerItem)
+		for i := range tags {
+			tags[i] = strings.Repeat("あ", i+1)
+		}
+
+		item, err := newItem(append(tags, tags[0]))
+
+		require.NoError(t, err)
+		assert.Len(t, item.Tags, MaxTagsPerItem)
+	})
+
+	t.Run("異常系: タグが多すぎる", func(t *testing.T) {
+		tags := make([]string, MaxTagsPerItem+1)
+		for i := range tags {
+			tags[i] = strings.Repeat("あ", i+1)
+		}
+
+		_, err := newItem(tags)
+
+		var errs domainErrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Equal(t, "tags", errs[0].Field)
+		assert.Equal(t, "an item can have at most 10 tags", errs[0].Message)
+	})
+
+	t.Run("異常系: 長すぎるタグ", func(t *testing.T) {
+		_, err := newItem([]string{strings.Repeat("あ", MaxTagLength+1)})
+
+		var errs domainErrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Equal(t, "each tag must be 30 characters or less", errs[0].Message)
+	})
+
+	t.Run("正常系: タグがない場合もJSONには空の配列を含める", func(t *testing.T) {
+		item, err := newItem(nil)
+		require.NoError(t, err)
+
+		data, err := json.Marshal(item)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"tags":[]`)
+	})
+}
+
+func TestItem_UpdatePartial_Tags(t *testing.T) {
+	item, err := NewItem(NewItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000, Currency: "JPY", PurchaseDate: MustParsePurchaseDate("2023-01-15"), Tags: []string{"限定品"}, Categories: testCategories})
+	require.NoError(t, err)
+
+	// 未指定の場合は変更しない
+	require.NoError(t, item.UpdatePartial(item.Version, stringPtr("デイトナ2"), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, testCategories))
+	assert.Equal(t, []string{"限定品"}, item.Tags)
+
+	tags := []string{"プレゼント"}
+	require.NoError(t, item.UpdatePartial(item.Version, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &tags, testCategories))
+	assert.Equal(t, []string{"プレゼント"}, item.Tags)
+
+	// 空の配列の場合はすべてのタグを外す
+	empty := []string{}
+	require.NoError(t, item.UpdatePartial(item.Version, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &empty, testCategories))
+	assert.Nil(t, item.Tags)
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "strings"
+
+// テキストの表記ゆれをそろえる。前後の空白（全角スペースを含む）を除き、途中の連続した空白を半角スペース1つにする
+func NormalizeText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// ブランド名の表記ゆれをそろえる。NormalizeTextに加えて、全角英数字を半角にする
+func NormalizeBrandName(s string) string {
+	return NormalizeText(strings.Map(toHalfWidthAlphanumeric, s))
+}
+
+// 全角英数字（０-９、Ａ-Ｚ、ａ-ｚ）を対応する半角の文字にする
+func toHalfWidthAlphanumeric(r rune) rune {
+	if (r >= '０' && r <= '９') || (r >= 'Ａ' && r <= 'Ｚ') || (r >= 'ａ' && r <= 'ｚ') {
+		return r - ('Ａ' - 'A')
+	}
+	return r
+}
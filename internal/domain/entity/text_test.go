@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"正常系: 変更なし", "シャネル", "シャネル"},
+		{"正常系: 前後の全角スペースを除く", "　シャネル　", "シャネル"},
+		{"正常系: 連続した空白を1つにまとめる", "Rolex 　 デイトナ\t116500", "Rolex デイトナ 116500"},
+		{"正常系: 全角英数字はそのまま", "ＲＯＬＥＸ", "ＲＯＬＥＸ"},
+		{"正常系: 全角スペースのみは空", "　　", ""},
+		{"正常系: 空文字", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeText(tt.input))
+		})
+	}
+}
+
+func TestNormalizeBrandName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"正常系: 全角英字を半角にする", "ＣＨＡＮＥＬ", "CHANEL"},
+		{"正常系: 全角数字と小文字を半角にする", "ｎｕｍｂｅｒ２１", "number21"},
+		{"正常系: 空白も正規化する", "　Louis　　Ｖｕｉｔｔｏｎ ", "Louis Vuitton"},
+		{"正常系: 全角カタカナはそのまま", "シャネル", "シャネル"},
+		{"正常系: 全角の記号はそのまま", "Ｄ＆Ｇ", "D＆G"},
+		{"正常系: 全角スペースのみは空", "　", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeBrandName(tt.input))
+		})
+	}
+}
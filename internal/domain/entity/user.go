@@ -0,0 +1,191 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ユーザーを導入する前に登録されたアイテムを割り当てた既定のユーザーのID。
+// ユーザーとして認証していない呼び出し（APIキーや認証を無効にした場合）で登録したアイテムもこのユーザーのものになる
+const DefaultUserID int64 = 1
+
+// 既定のユーザーのメールアドレス。マイグレーションで登録する値と同じ
+const DefaultUserEmail = "owner@localhost"
+
+// メールアドレスの最大文字数
+const MaxEmailLength = 254
+
+// パスワードの文字数の範囲。bcryptは72バイトを超える部分を無視するため、上限はバイト数で判定する
+const (
+	MinPasswordLength = 8
+	MaxPasswordBytes  = 72
+)
+
+// リフレッシュトークンのランダムな部分のバイト数
+const refreshTokenRandomBytes = 32
+
+// ユーザーの権限
+type UserRole string
+
+const (
+	// 自分のアイテムのみを扱う
+	UserRoleUser UserRole = "user"
+	// すべてのユーザーのアイテムと、カテゴリーやWebhookなどの管理者用のエンドポイントを扱う
+	UserRoleAdmin UserRole = "admin"
+)
+
+// 権限を解析する。大文字小文字は区別しない
+func ParseUserRole(s string) (UserRole, error) {
+	role := UserRole(strings.ToLower(strings.TrimSpace(s)))
+	if !role.Valid() {
+		return "", fmt.Errorf("%w: role must be one of: %s, %s", domainErrors.ErrInvalidInput, UserRoleUser, UserRoleAdmin)
+	}
+	return role, nil
+}
+
+func (r UserRole) Valid() bool {
+	return r == UserRoleUser || r == UserRoleAdmin
+}
+
+// アイテムを所有するユーザー。パスワードはbcryptのハッシュ値のみを保存し、
+// PasswordHashが空のユーザー（移行時に作成した既定のユーザー）はパスワードを設定するまでログインできない
+type User struct {
+	ID           int64     `json:"id"`
+	Email        string    `json:"email"`
+	Role         UserRole  `json:"role"`
+	PasswordHash string    `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// 新しいユーザーを作成する。メールアドレスは小文字に正規化する。管理者の権限はCLIで付与する
+func NewUser(email, password string) (*User, error) {
+	now := Now()
+	user := &User{
+		Email:     NormalizeEmail(email),
+		Role:      UserRoleUser,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	var errs domainErrors.ValidationErrors
+	if err := user.Validate(); err != nil {
+		errs.AddError("email", err)
+	}
+	if err := validatePassword(password); err != nil {
+		errs.AddError("password", err)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := user.SetPassword(password); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ログインや重複の確認で比較できるよう、前後の空白を除いて小文字にする
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// パスワードを検証し、bcryptのハッシュ値に置き換える
+func (u *User) SetPassword(password string) error {
+	if err := validatePassword(password); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	u.PasswordHash = string(hash)
+	return nil
+}
+
+// パスワードが一致するかどうか。パスワードが設定されていない場合は常にfalse
+func (u *User) CheckPassword(password string) bool {
+	if u.PasswordHash == "" {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+// Userフィールドのバリデーション。パスワードはハッシュ値にする前にSetPasswordで検証する
+func (u *User) Validate() error {
+	var errs domainErrors.ValidationErrors
+
+	if u.Email == "" {
+		errs.Append(domainErrors.Required("email"))
+	} else if utf8.RuneCountInString(u.Email) > MaxEmailLength {
+		errs.Append(domainErrors.TooLong("email", MaxEmailLength))
+	} else if addr, err := mail.ParseAddress(u.Email); err != nil || addr.Address != u.Email {
+		// 表示名付きの「Name <a@example.com>」などはアドレスとして受け付けない
+		errs.Append(domainErrors.NewRuleError("email", domainErrors.CodeInvalidFormat, "email must be a valid email address", map[string]interface{}{"format": "email"}))
+	}
+
+	return errs.Err()
+}
+
+func validatePassword(password string) error {
+	var errs domainErrors.ValidationErrors
+
+	if password == "" {
+		errs.Append(domainErrors.Required("password"))
+	} else if utf8.RuneCountInString(password) < MinPasswordLength {
+		errs.Append(domainErrors.TooShort("password", MinPasswordLength))
+	} else if len(password) > MaxPasswordBytes {
+		errs.Append(domainErrors.NewRuleError("password", domainErrors.CodeTooLong, fmt.Sprintf("password must be %d bytes or less", MaxPasswordBytes), map[string]interface{}{"max": MaxPasswordBytes}))
+	}
+
+	return errs.Err()
+}
+
+// アクセストークンの再発行に使うトークン。トークンそのものは発行時に一度だけ返し、SHA-256のハッシュ値のみを保存する。
+// 再発行に使ったトークンはRevokedAtを設定し、同じトークンを二度使えないようにする
+type RefreshToken struct {
+	ID        int64
+	UserID    int64
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+// ユーザーに新しいリフレッシュトークンを発行し、トークンそのものとともに返す
+func NewRefreshToken(userID int64, ttl time.Duration) (*RefreshToken, string, error) {
+	b := make([]byte, refreshTokenRandomBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	now := Now()
+	return &RefreshToken{
+		UserID:    userID,
+		TokenHash: HashRefreshToken(token),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, token, nil
+}
+
+// リフレッシュトークンを保存・照合するハッシュ値。APIキーと同じくSHA-256の16進数
+func HashRefreshToken(token string) string {
+	return HashAPIKey(token)
+}
+
+// 再発行に使えるかどうか。失効済みか期限切れの場合はfalse
+func (t *RefreshToken) Usable(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUser(t *testing.T) {
+	user, err := NewUser(" Alice@Example.com ", "correct horse")
+	require.NoError(t, err)
+
+	assert.Equal(t, "alice@example.com", user.Email)
+	assert.Equal(t, UserRoleUser, user.Role)
+	// パスワードそのものではなくハッシュ値を保持する
+	assert.NotEmpty(t, user.PasswordHash)
+	assert.NotContains(t, user.PasswordHash, "correct horse")
+	assert.True(t, user.CheckPassword("correct horse"))
+	assert.False(t, user.CheckPassword("wrong horse"))
+}
+
+func TestNewUser_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		fields   []string
+	}{
+		{name: "メールアドレスなし", email: " ", password: "password", fields: []string{"email"}},
+		{name: "メールアドレスの形式", email: "alice", password: "password", fields: []string{"email"}},
+		{name: "表示名付きのアドレス", email: "Alice <alice@example.com>", password: "password", fields: []string{"email"}},
+		{name: "パスワードが短い", email: "alice@example.com", password: "short", fields: []string{"password"}},
+		{name: "パスワードが長い", email: "alice@example.com", password: strings.Repeat("あ", 25), fields: []string{"password"}},
+		{name: "両方", email: "", password: "", fields: []string{"email", "password"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewUser(tt.email, tt.password)
+			require.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+
+			var errs domainErrors.ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			fields := make([]string, len(errs))
+			for i, fieldErr := range errs {
+				fields[i] = fieldErr.Field
+			}
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestParseUserRole(t *testing.T) {
+	role, err := ParseUserRole(" Admin ")
+	require.NoError(t, err)
+	assert.Equal(t, UserRoleAdmin, role)
+
+	_, err = ParseUserRole("owner")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+}
+
+func TestUser_CheckPassword_NoPassword(t *testing.T) {
+	// 移行時に作成した既定のユーザーはパスワードを設定するまでログインできない
+	user := &User{ID: DefaultUserID, Email: "owner@localhost"}
+	assert.False(t, user.CheckPassword(""))
+	assert.False(t, user.CheckPassword("password"))
+}
+
+func TestNewRefreshToken(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t.Cleanup(SetClock(testutil.NewFixedClock(now)))
+
+	refreshToken, token, err := NewRefreshToken(7, time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(7), refreshToken.UserID)
+	assert.Equal(t, HashRefreshToken(token), refreshToken.TokenHash)
+	assert.Equal(t, now.Add(time.Hour), refreshToken.ExpiresAt)
+
+	assert.True(t, refreshToken.Usable(now))
+	assert.False(t, refreshToken.Usable(now.Add(time.Hour)))
+
+	refreshToken.RevokedAt = &now
+	assert.False(t, refreshToken.Usable(now))
+}
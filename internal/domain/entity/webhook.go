@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// Webhookで送信するアイテムのイベント
+const (
+	WebhookEventItemCreated = "item.created"
+	WebhookEventItemUpdated = "item.updated"
+	WebhookEventItemDeleted = "item.deleted"
+)
+
+// 送信先のURLの最大文字数と、署名に使うシークレットの文字数の範囲
+const (
+	MaxWebhookURLLength    = 2048
+	MinWebhookSecretLength = 16
+	MaxWebhookSecretLength = 255
+)
+
+// 配信の記録を保持する期間。これより古い記録は定期的に削除する
+const WebhookDeliveryRetention = 7 * 24 * time.Hour
+
+// アイテムのイベントの送信先。Secretは署名にのみ使い、レスポンスには含めない。
+// 連続して失敗した配信の回数がFailureCountで、上限に達すると無効（Enabledがfalse）になる
+type Webhook struct {
+	ID           int64     `json:"id"`
+	URL          string    `json:"url"`
+	Secret       string    `json:"-"`
+	Enabled      bool      `json:"enabled"`
+	FailureCount int       `json:"failure_count"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func NewWebhook(rawURL, secret string) (*Webhook, error) {
+	webhook := &Webhook{
+		URL:       strings.TrimSpace(rawURL),
+		Secret:    secret,
+		Enabled:   true,
+		CreatedAt: Now(),
+		UpdatedAt: Now(),
+	}
+
+	if err := webhook.Validate(); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// Webhookフィールドのバリデーション
+func (w *Webhook) Validate() error {
+	var errs domainErrors.ValidationErrors
+
+	if w.URL == "" {
+		errs.Append(domainErrors.Required("url"))
+	} else if utf8.RuneCountInString(w.URL) > MaxWebhookURLLength {
+		errs.Append(domainErrors.TooLong("url", MaxWebhookURLLength))
+	} else if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs.Append(domainErrors.NewRuleError("url", domainErrors.CodeInvalidFormat, "url must be an absolute http or https URL", map[string]interface{}{"format": "URL"}))
+	}
+
+	if w.Secret == "" {
+		errs.Append(domainErrors.Required("secret"))
+	} else if length := utf8.RuneCountInString(w.Secret); length < MinWebhookSecretLength {
+		errs.Append(domainErrors.TooShort("secret", MinWebhookSecretLength))
+	} else if length > MaxWebhookSecretLength {
+		errs.Append(domainErrors.TooLong("secret", MaxWebhookSecretLength))
+	}
+
+	return errs.Err()
+}
+
+// 1回の送信の記録。StatusCodeは応答がなかった場合はnilで、Errorに理由を設定する
+type WebhookDelivery struct {
+	ID         int64     `json:"id"`
+	WebhookID  int64     `json:"webhook_id"`
+	EventID    string    `json:"event_id"`
+	Event      string    `json:"event"`
+	ItemID     int64     `json:"item_id"`
+	Attempt    int       `json:"attempt"` // 1から始まる試行の回数
+	StatusCode *int      `json:"status_code"`
+	Error      string    `json:"error,omitempty"`
+	Succeeded  bool      `json:"succeeded"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,20 @@
+package errors
+
+import "fmt"
+
+// 名前・ブランド・購入日が同じアイテムが登録済みの場合に返す
+type DuplicateItemError struct {
+	ExistingID int64
+}
+
+func NewDuplicateItemError(existingID int64) *DuplicateItemError {
+	return &DuplicateItemError{ExistingID: existingID}
+}
+
+func (e *DuplicateItemError) Error() string {
+	return fmt.Sprintf("%s: item %d has the same name, brand and purchase_date", ErrDuplicateItem.Error(), e.ExistingID)
+}
+
+func (e *DuplicateItemError) Unwrap() error {
+	return ErrDuplicateItem
+}
@@ -2,15 +2,69 @@ package errors
 
 import "errors"
 
+// エラーの分類。ハンドラーはこの分類でHTTPステータスを決める
 var (
-	ErrItemNotFound   = errors.New("item not found")
-	ErrInvalidInput   = errors.New("invalid input")
-	ErrDatabaseError  = errors.New("database error")
-	ErrDuplicateEntry = errors.New("duplicate entry")
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+	ErrValidation = errors.New("validation failed")
 )
 
+var (
+	ErrItemNotFound          = newClassifiedError("item not found", ErrNotFound)
+	ErrInvalidInput          = newClassifiedError("invalid input", ErrValidation)
+	ErrInvalidQuery          = newClassifiedError("invalid query parameter", ErrInvalidInput)
+	ErrInvalidCursor         = newClassifiedError("invalid cursor", ErrInvalidQuery)
+	ErrDatabaseError         = errors.New("database error")
+	ErrDuplicateEntry        = newClassifiedError("duplicate entry", ErrConflict)
+	ErrDuplicateItem         = newClassifiedError("duplicate item", ErrDuplicateEntry)
+	ErrDuplicateSerialNumber = newClassifiedError("duplicate serial number", ErrDuplicateEntry)
+	ErrImageNotFound         = newClassifiedError("image not found", ErrNotFound)
+	ErrFileTooLarge          = errors.New("file too large")
+	ErrImageLimitExceeded    = newClassifiedError("image limit exceeded", ErrConflict)
+	ErrCategoryNotFound      = newClassifiedError("category not found", ErrNotFound)
+	ErrCategoryInUse         = newClassifiedError("category is in use", ErrConflict)
+	ErrBrandNotFound         = newClassifiedError("brand not found", ErrNotFound)
+	ErrWebhookNotFound       = newClassifiedError("webhook not found", ErrNotFound)
+	ErrImportJobNotFound     = newClassifiedError("import job not found", ErrNotFound)
+	ErrImportQueueFull       = errors.New("import queue is full")
+	ErrAPIKeyNotFound        = newClassifiedError("api key not found", ErrNotFound)
+	ErrUnauthenticated       = errors.New("unauthenticated")
+	ErrForbidden             = errors.New("forbidden")
+	ErrUserNotFound          = newClassifiedError("user not found", ErrNotFound)
+	ErrDuplicateEmail        = newClassifiedError("email is already registered", ErrDuplicateEntry)
+	ErrRefreshTokenNotFound  = newClassifiedError("refresh token not found", ErrNotFound)
+	ErrVersionConflict       = newClassifiedError("version conflict", ErrConflict)
+
+	ErrInvalidStatusTransition = newClassifiedError("invalid status transition", ErrConflict)
+
+	ErrCurrencyMismatch = newClassifiedError("currency mismatch", ErrValidation)
+	ErrAmountOverflow   = newClassifiedError("amount overflow", ErrValidation)
+
+	ErrIdempotencyKeyNotFound = newClassifiedError("idempotency key not found", ErrNotFound)
+	ErrIdempotencyKeyExists   = newClassifiedError("idempotency key already exists", ErrConflict)
+	ErrIdempotencyKeyMismatch = newClassifiedError("idempotency key was used with a different request", ErrValidation)
+)
+
+// 分類を持つエラー。errors.Isで自身と分類の両方に一致する
+type classifiedError struct {
+	msg   string
+	class error
+}
+
+func newClassifiedError(msg string, class error) error {
+	return &classifiedError{msg: msg, class: class}
+}
+
+func (e *classifiedError) Error() string {
+	return e.msg
+}
+
+func (e *classifiedError) Unwrap() error {
+	return e.class
+}
+
 func IsNotFoundError(err error) bool {
-	return errors.Is(err, ErrItemNotFound)
+	return errors.Is(err, ErrNotFound)
 }
 
 func IsDatabaseError(err error) bool {
@@ -18,5 +72,9 @@ func IsDatabaseError(err error) bool {
 }
 
 func IsValidationError(err error) bool {
-	return errors.Is(err, ErrInvalidInput)
+	return errors.Is(err, ErrValidation)
+}
+
+func IsConflictError(err error) bool {
+	return errors.Is(err, ErrConflict)
 }
@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorClasses(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		notFound   bool
+		conflict   bool
+		validation bool
+	}{
+		{"正常系: アイテムが見つからない", ErrItemNotFound, true, false, false},
+		{"正常系: 画像が見つからない", ErrImageNotFound, true, false, false},
+		{"正常系: カテゴリーが見つからない", ErrCategoryNotFound, true, false, false},
+		{"正常系: 重複", ErrDuplicateEntry, false, true, false},
+		{"正常系: アイテムの重複", ErrDuplicateItem, false, true, false},
+		{"正常系: 使用中のカテゴリー", ErrCategoryInUse, false, true, false},
+		{"正常系: 画像の上限", ErrImageLimitExceeded, false, true, false},
+		{"正常系: 所有状況の遷移", NewStatusTransitionError("sold", "listed"), false, true, false},
+		{"正常系: 入力値の誤り", ErrInvalidInput, false, false, true},
+		{"正常系: クエリパラメータの誤り", NewInvalidQueryError("sort must be one of: created_at"), false, false, true},
+		{"正常系: 冪等キーのリクエスト内容の不一致", ErrIdempotencyKeyMismatch, false, false, true},
+		{"正常系: フィールドのエラー", NewFieldError("name", "name is required"), false, false, true},
+		{"正常系: データベースのエラーはどれにも属さない", ErrDatabaseError, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("failed to do something: %w", tt.err)
+			assert.Equal(t, tt.notFound, IsNotFoundError(err))
+			assert.Equal(t, tt.conflict, IsConflictError(err))
+			assert.Equal(t, tt.validation, IsValidationError(err))
+		})
+	}
+}
+
+func TestErrDuplicateItem_IsDuplicateEntry(t *testing.T) {
+	err := fmt.Errorf("%w: Duplicate entry", ErrDuplicateItem)
+	assert.ErrorIs(t, err, ErrDuplicateItem)
+	assert.ErrorIs(t, err, ErrDuplicateEntry)
+	assert.NotErrorIs(t, err, ErrCategoryInUse)
+}
@@ -0,0 +1,18 @@
+package errors
+
+// 一覧の絞り込み・並び替え・ページネーションの指定の誤り。リクエストボディの検証の誤りとは区別する
+type InvalidQueryError struct {
+	Message string
+}
+
+func NewInvalidQueryError(message string) *InvalidQueryError {
+	return &InvalidQueryError{Message: message}
+}
+
+func (e *InvalidQueryError) Error() string {
+	return ErrInvalidQuery.Error() + ": " + e.Message
+}
+
+func (e *InvalidQueryError) Unwrap() error {
+	return ErrInvalidQuery
+}
@@ -0,0 +1,21 @@
+package errors
+
+import "fmt"
+
+// 所有状況を変更できない遷移が指定された場合に返す
+type StatusTransitionError struct {
+	CurrentStatus   string
+	RequestedStatus string
+}
+
+func NewStatusTransitionError(currentStatus, requestedStatus string) *StatusTransitionError {
+	return &StatusTransitionError{CurrentStatus: currentStatus, RequestedStatus: requestedStatus}
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("%s: cannot change status from %s to %s", ErrInvalidStatusTransition.Error(), e.CurrentStatus, e.RequestedStatus)
+}
+
+func (e *StatusTransitionError) Unwrap() error {
+	return ErrInvalidStatusTransition
+}
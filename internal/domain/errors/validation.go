@@ -0,0 +1,125 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// バリデーションエラーの種類を表す機械可読なコード。
+// 表示用のメッセージはハンドラー層でコードとParamsから言語ごとに組み立てる
+const (
+	CodeRequired           = "required"             // 必須のフィールドがない
+	CodeEmpty              = "empty"                // 空文字が指定された
+	CodeTooLong            = "too_long"             // 文字数が多すぎる（params: max）
+	CodeTooShort           = "too_short"            // 文字数が少なすぎる（params: min）
+	CodeTooSmall           = "too_small"            // 値が小さすぎる（params: min）
+	CodeTooLarge           = "too_large"            // 値が大きすぎる（params: max）
+	CodeTooMany            = "too_many"             // 要素が多すぎる（params: max）
+	CodeOneOf              = "one_of"               // 指定できる値のいずれでもない（params: values）
+	CodeInvalidFormat      = "invalid_format"       // 形式が誤っている（params: format）
+	CodeInvalidType        = "invalid_type"         // JSONの値の型が誤っている（params: type）
+	CodeInvalidDate        = "invalid_date"         // 存在しない日付（params: value）
+	CodeFutureDate         = "future_date"          // 未来の日付
+	CodeBeforePurchaseDate = "before_purchase_date" // 購入日より前の日付
+	CodeNotRegistered      = "not_registered"       // 登録済みの値ではない
+	CodeInvalid            = "invalid"              // 上記に当てはまらないエラー
+)
+
+// 1つのフィールドのバリデーションエラー。Messageは英語のメッセージで、ログ出力やメッセージの翻訳がない場合に使う
+type FieldError struct {
+	Field   string                 `json:"field,omitempty"` // 特定のフィールドに紐づかないエラーでは空
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Params  map[string]interface{} `json:"params,omitempty"` // メッセージに埋め込む値（最大文字数など）
+}
+
+// コードとパラメーターを指定してフィールドのエラーを作る
+func NewRuleError(field, code, message string, params map[string]interface{}) FieldError {
+	return FieldError{Field: field, Code: code, Message: message, Params: params}
+}
+
+func Required(field string) FieldError {
+	return NewRuleError(field, CodeRequired, field+" is required", nil)
+}
+
+func Empty(field string) FieldError {
+	return NewRuleError(field, CodeEmpty, field+" cannot be empty", nil)
+}
+
+func TooLong(field string, max int) FieldError {
+	return NewRuleError(field, CodeTooLong, fmt.Sprintf("%s must be %d characters or less", field, max), map[string]interface{}{"max": max})
+}
+
+func TooShort(field string, min int) FieldError {
+	return NewRuleError(field, CodeTooShort, fmt.Sprintf("%s must be at least %d characters", field, min), map[string]interface{}{"min": min})
+}
+
+func TooSmall(field string, min int64) FieldError {
+	return NewRuleError(field, CodeTooSmall, fmt.Sprintf("%s must be %d or greater", field, min), map[string]interface{}{"min": min})
+}
+
+func TooLarge(field string, max int64) FieldError {
+	return NewRuleError(field, CodeTooLarge, fmt.Sprintf("%s must be %d or less", field, max), map[string]interface{}{"max": max})
+}
+
+func OneOf(field string, values []string) FieldError {
+	return NewRuleError(field, CodeOneOf, field+" must be one of: "+strings.Join(values, ", "), map[string]interface{}{"values": values})
+}
+
+// 入力値の検証で見つかったエラーの一覧。errors.IsではErrInvalidInputとして扱える
+type ValidationErrors []FieldError
+
+// コードを持たないエラーを追加する
+func (e *ValidationErrors) Add(field, message string) {
+	*e = append(*e, FieldError{Field: field, Code: CodeInvalid, Message: message})
+}
+
+func (e *ValidationErrors) Append(fieldErrs ...FieldError) {
+	*e = append(*e, fieldErrs...)
+}
+
+// errがValidationErrorsの場合はそのまま追加し、それ以外はfieldのエラーとしてメッセージを追加する
+func (e *ValidationErrors) AddError(field string, err error) {
+	e.Append(FieldErrorFrom(field, err)...)
+}
+
+// エラーが1件もなければnilを返す
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// ログ出力用にメッセージをカンマ区切りで連結する
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Message
+	}
+	return strings.Join(messages, ", ")
+}
+
+func (e ValidationErrors) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// 1つのフィールドのエラーからValidationErrorsを作る
+func NewFieldError(field, message string) ValidationErrors {
+	return ValidationErrors{{Field: field, Code: CodeInvalid, Message: message}}
+}
+
+// errがValidationErrorsの場合はそれを返し、それ以外はfieldのエラーとしてメッセージを使う
+func FieldErrorFrom(field string, err error) ValidationErrors {
+	var validationErrs ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return validationErrs
+	}
+	return NewFieldError(field, err.Error())
+}
+
+// コード付きのエラーからValidationErrorsを作る
+func NewValidationErrors(fieldErrs ...FieldError) ValidationErrors {
+	return ValidationErrors(fieldErrs)
+}
@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationErrors(t *testing.T) {
+	var errs ValidationErrors
+	assert.NoError(t, errs.Err())
+
+	errs.Append(Required("name"))
+	errs.Add("purchase_price", "purchase_price must be 0 or greater")
+	err := fmt.Errorf("failed to create item: %w", errs.Err())
+
+	// ログ出力用の文字列は従来と同じカンマ区切り
+	assert.Equal(t, "failed to create item: name is required, purchase_price must be 0 or greater", err.Error())
+	assert.True(t, IsValidationError(err))
+
+	var got ValidationErrors
+	require.True(t, errors.As(err, &got))
+	assert.Equal(t, ValidationErrors{
+		{Field: "name", Code: CodeRequired, Message: "name is required"},
+		{Field: "purchase_price", Code: CodeInvalid, Message: "purchase_price must be 0 or greater"},
+	}, got)
+}
+
+func TestValidationErrors_AddError(t *testing.T) {
+	var errs ValidationErrors
+	errs.AddError("purchase_date", NewValidationErrors(NewRuleError("purchase_date", CodeInvalidFormat, "purchase_date must be in YYYY-MM-DD format", map[string]interface{}{"format": "YYYY-MM-DD"})))
+	errs.AddError("sold_date", errors.New("sold_date is invalid"))
+
+	assert.Equal(t, ValidationErrors{
+		{Field: "purchase_date", Code: CodeInvalidFormat, Message: "purchase_date must be in YYYY-MM-DD format", Params: map[string]interface{}{"format": "YYYY-MM-DD"}},
+		{Field: "sold_date", Code: CodeInvalid, Message: "sold_date is invalid"},
+	}, errs)
+}
@@ -0,0 +1,20 @@
+package errors
+
+import "fmt"
+
+// 楽観的ロックの競合。クライアントが送ったバージョンが最新ではない場合に返す
+type VersionConflictError struct {
+	CurrentVersion int64
+}
+
+func NewVersionConflictError(currentVersion int64) *VersionConflictError {
+	return &VersionConflictError{CurrentVersion: currentVersion}
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s: current version is %d", ErrVersionConflict.Error(), e.CurrentVersion)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
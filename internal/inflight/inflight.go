@@ -0,0 +1,65 @@
+// リクエストの処理中にどの層（ユースケース・リポジトリ）の処理が制限時間を過ぎたかをctxで受け渡す。
+// リクエストの制限時間を過ぎた場合に、時間がかかっていた処理をログに出すのに使う
+package inflight
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// 処理の層
+const (
+	LayerHandler    = "handler"
+	LayerUsecase    = "usecase"
+	LayerRepository = "repository"
+)
+
+// 制限時間を過ぎた処理
+type Stage struct {
+	Layer     string
+	Operation string // ItemRepository.FindAll など
+}
+
+// リクエストの処理のうち、最初に制限時間の超過で終わった処理を記録する
+type Recorder struct {
+	mu      sync.Mutex
+	expired *Stage
+}
+
+type contextKey struct{}
+
+// 記録先のRecorderを持つctxを返す
+func NewContext(ctx context.Context) (context.Context, *Recorder) {
+	r := &Recorder{}
+	return context.WithValue(ctx, contextKey{}, r), r
+}
+
+// layerのoperationの処理を始める。返す関数は処理の終わりにそのエラーとともに呼び出す。
+// 内側の処理から先に終わるため、制限時間の超過で最初に終わった処理が、制限時間を過ぎた時点で実行していた最も内側の処理になる
+func Enter(ctx context.Context, layer, operation string) func(err error) {
+	r, ok := ctx.Value(contextKey{}).(*Recorder)
+	if !ok {
+		return func(error) {}
+	}
+	return func(err error) {
+		if !errors.Is(err, context.DeadlineExceeded) {
+			return
+		}
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.expired == nil {
+			r.expired = &Stage{Layer: layer, Operation: operation}
+		}
+	}
+}
+
+// 制限時間を過ぎた処理。どの層の処理も制限時間の超過で終わっていない場合は、ハンドラー自身の処理（レスポンスの送信など）とする
+func (r *Recorder) Expired() Stage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.expired == nil {
+		return Stage{Layer: LayerHandler}
+	}
+	return *r.expired
+}
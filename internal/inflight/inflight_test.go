@@ -0,0 +1,40 @@
+package inflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx, r := NewContext(context.Background())
+	assert.Equal(t, Stage{Layer: LayerHandler}, r.Expired())
+
+	endUsecase := Enter(ctx, LayerUsecase, "ItemUsecase.GetAllItems")
+	endCount := Enter(ctx, LayerRepository, "ItemRepository.Count")
+	endCount(nil)
+	endFindAll := Enter(ctx, LayerRepository, "ItemRepository.FindAll")
+	// 内側の処理から先に終わる
+	endFindAll(fmt.Errorf("query failed: %w", context.DeadlineExceeded))
+	endUsecase(fmt.Errorf("failed to retrieve items: %w", context.DeadlineExceeded))
+
+	assert.Equal(t, Stage{Layer: LayerRepository, Operation: "ItemRepository.FindAll"}, r.Expired())
+}
+
+func TestRecorder_OtherErrors(t *testing.T) {
+	ctx, r := NewContext(context.Background())
+
+	Enter(ctx, LayerRepository, "ItemRepository.FindByID")(errors.New("not found"))
+	Enter(ctx, LayerUsecase, "ItemUsecase.GetItemByID")(context.Canceled)
+
+	// 制限時間の超過以外のエラーは記録しない
+	assert.Equal(t, Stage{Layer: LayerHandler}, r.Expired())
+}
+
+func TestEnter_WithoutRecorder(t *testing.T) {
+	// リクエストの処理中でない場合（バックグラウンドの処理など）は何もしない
+	Enter(context.Background(), LayerRepository, "ItemRepository.FindAll")(context.DeadlineExceeded)
+}
@@ -0,0 +1,78 @@
+// ユーザーのアクセストークンをJWT（HS256）で発行・検証する
+package accesstoken
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// HS256で署名・検証するusecase.AccessTokenSigner。同じ鍵を持つサーバー間でトークンを共有できる
+type Signer struct {
+	secret []byte
+	now    func() time.Time
+}
+
+func NewSigner(secret []byte) *Signer {
+	return &Signer{secret: secret, now: entity.Now}
+}
+
+// ユーザーのIDはsubに文字列で、権限はroleに入れる
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Role entity.UserRole `json:"role,omitempty"`
+}
+
+func (s *Signer) Sign(claims usecase.AccessTokenClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatInt(claims.UserID, 10),
+			IssuedAt:  jwt.NewNumericDate(claims.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(claims.ExpiresAt),
+		},
+		Role: claims.Role,
+	})
+
+	signed, err := token.SignedString(s.secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return signed, nil
+}
+
+// 署名と有効期限を検証する。改ざん・期限切れ・HS256以外のアルゴリズムのトークンはErrUnauthenticatedを返す
+func (s *Signer) Verify(token string) (*usecase.AccessTokenClaims, error) {
+	var claims tokenClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired(), jwt.WithTimeFunc(s.now))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrUnauthenticated, err)
+	}
+
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil || userID <= 0 {
+		return nil, fmt.Errorf("%w: invalid subject %q", domainErrors.ErrUnauthenticated, claims.Subject)
+	}
+
+	// 権限を導入する前に発行したトークンはroleを持たないため、一般のユーザーとして扱う
+	role := claims.Role
+	if role == "" {
+		role = entity.UserRoleUser
+	}
+	if !role.Valid() {
+		return nil, fmt.Errorf("%w: invalid role %q", domainErrors.ErrUnauthenticated, claims.Role)
+	}
+
+	verified := &usecase.AccessTokenClaims{UserID: userID, Role: role, ExpiresAt: claims.ExpiresAt.Time}
+	if claims.IssuedAt != nil {
+		verified.IssuedAt = claims.IssuedAt.Time
+	}
+	return verified, nil
+}
@@ -0,0 +1,96 @@
+package accesstoken
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func newTestSigner(secret string) (*Signer, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewSigner([]byte(secret))
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestSigner_SignAndVerify(t *testing.T) {
+	s, now := newTestSigner("0123456789abcdef0123456789abcdef")
+	token, err := s.Sign(usecase.AccessTokenClaims{UserID: 7, Role: entity.UserRoleAdmin, IssuedAt: *now, ExpiresAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+
+	claims, err := s.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), claims.UserID)
+	assert.Equal(t, entity.UserRoleAdmin, claims.Role)
+	assert.True(t, claims.ExpiresAt.Equal(now.Add(time.Minute)))
+
+	// 有効期限を過ぎたトークンは認証しない
+	*now = now.Add(time.Minute)
+	_, err = s.Verify(token)
+	assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+}
+
+func TestSigner_VerifyWithoutRole(t *testing.T) {
+	s, now := newTestSigner("0123456789abcdef0123456789abcdef")
+	// 権限を導入する前に発行したトークンは一般のユーザーとして扱う
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   "7",
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+	}).SignedString([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	claims, err := s.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserRoleUser, claims.Role)
+}
+
+func TestSigner_VerifyRejects(t *testing.T) {
+	s, now := newTestSigner("0123456789abcdef0123456789abcdef")
+	other, _ := newTestSigner("another secret of at least 32 bytes")
+	otherToken, err := other.Sign(usecase.AccessTokenClaims{UserID: 7, IssuedAt: *now, ExpiresAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+
+	// 署名を検証しないalg=noneのトークン
+	none, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{
+		Subject:   "7",
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	// 有効期限のないトークン
+	noExpiry, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: "7"}).
+		SignedString([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	// 未知の権限のトークン
+	unknownRole, err := jwt.NewWithClaims(jwt.SigningMethodHS256, tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "7", ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute))},
+		Role:             "root",
+	}).SignedString([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "別の鍵で署名したトークン", token: otherToken},
+		{name: "署名のないトークン", token: none},
+		{name: "有効期限のないトークン", token: noExpiry},
+		{name: "未知の権限のトークン", token: unknownRole},
+		{name: "JWTではない文字列", token: "aicon_key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Verify(tt.token)
+			assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// プロセスのメモリ上で値と世代を保持するusecase.Cache。maxEntriesを超えると最も長く使われていない値から破棄する
+type Memory struct {
+	mu         sync.Mutex
+	maxEntries int
+	// 先頭ほど最近使った値
+	order       *list.List
+	entries     map[string]*list.Element
+	generations map[string]int64
+}
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewMemory(maxEntries int) *Memory {
+	if maxEntries <= 0 {
+		maxEntries = 1
+	}
+	return &Memory{
+		maxEntries:  maxEntries,
+		order:       list.New(),
+		entries:     make(map[string]*list.Element),
+		generations: make(map[string]int64),
+	}
+}
+
+func (m *Memory) Get(ctx context.Context, key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryEntry)
+	if !entity.Now().Before(entry.expiresAt) {
+		m.remove(elem)
+		return nil, false
+	}
+	m.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (m *Memory) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiresAt := entity.Now().Add(ttl)
+	if elem, ok := m.entries[key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		m.order.MoveToFront(elem)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	for m.order.Len() > m.maxEntries {
+		m.remove(m.order.Back())
+	}
+}
+
+func (m *Memory) Delete(ctx context.Context, keys ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		if elem, ok := m.entries[key]; ok {
+			m.remove(elem)
+		}
+	}
+}
+
+func (m *Memory) Generation(ctx context.Context, name string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.generations[name]
+}
+
+func (m *Memory) Invalidate(ctx context.Context, names []string, keys ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, name := range names {
+		m.generations[name]++
+	}
+	for _, key := range keys {
+		if elem, ok := m.entries[key]; ok {
+			m.remove(elem)
+		}
+	}
+}
+
+// 保持している値の件数。期限切れで未破棄の値も含む
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// 呼び出し元はロックを取得しておく
+func (m *Memory) remove(elem *list.Element) {
+	m.order.Remove(elem)
+	delete(m.entries, elem.Value.(*memoryEntry).key)
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/testutil"
+)
+
+func TestMemory_Expiry(t *testing.T) {
+	clock := testutil.NewFixedClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	defer entity.SetClock(clock)()
+	ctx := context.Background()
+	m := NewMemory(10)
+
+	m.Set(ctx, "a", []byte("1"), time.Minute)
+	value, ok := m.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+
+	clock.Advance(time.Minute)
+	_, ok = m.Get(ctx, "a")
+	assert.False(t, ok)
+	// 期限切れの値は取得時に破棄する
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestMemory_EvictLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(2)
+
+	m.Set(ctx, "a", []byte("1"), time.Minute)
+	m.Set(ctx, "b", []byte("2"), time.Minute)
+	// aを使うと、最も長く使われていないのはbになる
+	_, ok := m.Get(ctx, "a")
+	assert.True(t, ok)
+	m.Set(ctx, "c", []byte("3"), time.Minute)
+
+	assert.Equal(t, 2, m.Len())
+	_, ok = m.Get(ctx, "b")
+	assert.False(t, ok)
+	_, ok = m.Get(ctx, "a")
+	assert.True(t, ok)
+	_, ok = m.Get(ctx, "c")
+	assert.True(t, ok)
+}
+
+func TestMemory_SetReplacesAndDelete(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(2)
+
+	m.Set(ctx, "a", []byte("1"), time.Minute)
+	m.Set(ctx, "a", []byte("2"), time.Minute)
+	assert.Equal(t, 1, m.Len())
+	value, _ := m.Get(ctx, "a")
+	assert.Equal(t, []byte("2"), value)
+
+	m.Delete(ctx, "a", "missing")
+	_, ok := m.Get(ctx, "a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, m.Len())
+}
@@ -0,0 +1,252 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redisの1回の操作の制限時間のデフォルト値。Redisが停止している場合にリクエストを長く待たせないよう短くする
+const defaultRedisTimeout = 200 * time.Millisecond
+
+// 購読が切断された場合に再接続するまでの待ち時間
+const resubscribeDelay = time.Second
+
+type RedisConfig struct {
+	Addr      string        // Redisのアドレス（host:port）
+	KeyPrefix string        // キー・世代・通知のチャンネルの名前の前に付ける文字列。同じRedisを使う他のアプリケーションと区別する
+	Timeout   time.Duration // 1回の操作の制限時間。0はデフォルト値
+}
+
+// Redisで値と世代を保持し、複数のサーバーで共有するusecase.Cache。
+// 世代はRedisのカウンターを進めたサーバーが通知のチャンネルに送信し、Runで購読した各サーバーが手元の値を更新する。
+// Redisに接続できない場合は警告をログに出し、値がないものとして扱う（呼び出し元はデータベースから読み込む）
+type Redis struct {
+	client  *redis.Client
+	prefix  string
+	channel string
+
+	// 手元に保持している世代。購読を開始するたびにRedisから読み直す
+	mu          sync.RWMutex
+	generations map[string]int64
+
+	// Redisに接続できない状態かどうか。警告を状態が変わった時だけログに出すために使う
+	unavailable atomic.Bool
+}
+
+func NewRedis(cfg RedisConfig) *Redis {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultRedisTimeout
+	}
+
+	return &Redis{
+		client: redis.NewClient(&redis.Options{
+			Addr:         cfg.Addr,
+			DialTimeout:  cfg.Timeout,
+			ReadTimeout:  cfg.Timeout,
+			WriteTimeout: cfg.Timeout,
+			// 停止している場合にリクエストを待たせないよう、やり直さずにデータベースから読み込む
+			MaxRetries: -1,
+		}),
+		prefix:      cfg.KeyPrefix,
+		channel:     cfg.KeyPrefix + "invalidations",
+		generations: make(map[string]int64),
+	}
+}
+
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool) {
+	value, err := r.client.Get(ctx, r.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		r.report(nil)
+		return nil, false
+	}
+	r.report(err)
+	return value, err == nil
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	r.report(r.client.Set(ctx, r.prefix+key, value, ttl).Err())
+}
+
+func (r *Redis) Delete(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	r.report(r.client.Del(ctx, r.prefixed(keys)...).Err())
+}
+
+// 手元の世代を返す。まだ保持していない場合はRedisから読み込み、読み込めない場合は0を返す
+func (r *Redis) Generation(ctx context.Context, name string) int64 {
+	r.mu.RLock()
+	generation, ok := r.generations[name]
+	r.mu.RUnlock()
+	if ok {
+		return generation
+	}
+
+	generation, err := r.client.Get(ctx, r.prefix+name).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		r.report(err)
+		return 0
+	}
+	r.report(nil)
+	r.update(map[string]int64{name: generation})
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.generations[name]
+}
+
+// Redisの世代を進めてキーを削除し、進めた世代を他のサーバーに通知する
+func (r *Redis) Invalidate(ctx context.Context, names []string, keys ...string) {
+	incrs := make(map[string]*redis.IntCmd, len(names))
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, name := range names {
+			incrs[name] = pipe.Incr(ctx, r.prefix+name)
+		}
+		if len(keys) > 0 {
+			pipe.Del(ctx, r.prefixed(keys)...)
+		}
+		return nil
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "⚠️  Redisのキャッシュを破棄できませんでした。他のサーバーには有効期限まで変更前の値が残ることがあります", "error", err)
+		r.report(err)
+		return
+	}
+
+	generations := make(map[string]int64, len(incrs))
+	for name, incr := range incrs {
+		generations[name] = incr.Val()
+	}
+	r.update(generations)
+
+	message, err := json.Marshal(generations)
+	if err != nil {
+		return
+	}
+	r.report(r.client.Publish(ctx, r.channel, message).Err())
+}
+
+// ctxがキャンセルされるまで他のサーバーからの世代の通知を購読する。
+// 切断中の通知は受け取れないため、購読を開始するたびに保持している世代をRedisから読み直す
+func (r *Redis) Run(ctx context.Context) {
+	pubsub := r.client.Subscribe(ctx, r.channel)
+	defer pubsub.Close()
+	// 受信を待っている間はctxのキャンセルで戻らないため、購読を閉じて中断する
+	stop := context.AfterFunc(ctx, func() { pubsub.Close() })
+	defer stop()
+
+	for {
+		msg, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			r.report(err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(resubscribeDelay):
+			}
+			continue
+		}
+
+		switch msg := msg.(type) {
+		case *redis.Subscription:
+			r.reload(ctx)
+		case *redis.Message:
+			var generations map[string]int64
+			if err := json.Unmarshal([]byte(msg.Payload), &generations); err != nil {
+				slog.WarnContext(ctx, "⚠️  キャッシュの破棄の通知を読み込めませんでした", "error", err)
+				continue
+			}
+			r.update(generations)
+		}
+	}
+}
+
+// Redisに接続できるかどうかを確認する。準備状態の確認に使う
+func (r *Redis) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
+
+// 保持している世代をRedisの値に置き換える。Redisが再起動して世代が戻った場合も合わせる
+func (r *Redis) reload(ctx context.Context) {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.generations))
+	for name := range r.generations {
+		names = append(names, name)
+	}
+	r.mu.RUnlock()
+	if len(names) == 0 {
+		return
+	}
+
+	values, err := r.client.MGet(ctx, r.prefixed(names)...).Result()
+	r.report(err)
+	if err != nil {
+		// 読み直せない世代は、次に使う時にRedisから読み込む
+		r.mu.Lock()
+		r.generations = make(map[string]int64)
+		r.mu.Unlock()
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, name := range names {
+		// まだ進めていない世代はRedisにないため0
+		var generation int64
+		if s, ok := values[i].(string); ok {
+			if generation, err = strconv.ParseInt(s, 10, 64); err != nil {
+				delete(r.generations, name)
+				continue
+			}
+		}
+		r.generations[name] = generation
+	}
+}
+
+// 通知などで受け取った世代を反映する。順序が入れ替わって届いても戻さないよう、大きい方を使う
+func (r *Redis) update(generations map[string]int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, generation := range generations {
+		if current, ok := r.generations[name]; !ok || generation > current {
+			r.generations[name] = generation
+		}
+	}
+}
+
+func (r *Redis) prefixed(keys []string) []string {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = r.prefix + key
+	}
+	return prefixed
+}
+
+// 接続できなくなった時と回復した時に1回ずつログに出す。errはnilなら成功
+func (r *Redis) report(err error) {
+	if err != nil {
+		if r.unavailable.CompareAndSwap(false, true) {
+			slog.Warn("⚠️  Redisのキャッシュを使えないため、データベースから読み込みます", "error", err)
+		}
+		return
+	}
+	if r.unavailable.CompareAndSwap(true, false) {
+		slog.Info("✅ Redisのキャッシュに再接続しました")
+	}
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/memory"
+	"Aicon-assignment/internal/usecase"
+)
+
+// addrのRedisに接続し、通知の購読を開始する
+func startRedis(t *testing.T, addr string) *Redis {
+	t.Helper()
+
+	r := NewRedis(RedisConfig{Addr: addr, KeyPrefix: "test:"})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		r.Close()
+	})
+	return r
+}
+
+func TestRedis_GetSetDelete(t *testing.T) {
+	mr := miniredis.RunT(t)
+	r := startRedis(t, mr.Addr())
+	ctx := context.Background()
+
+	r.Set(ctx, "a", []byte("1"), time.Minute)
+	value, ok := r.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+	// キーにはプレフィックスを付ける
+	assert.True(t, mr.Exists("test:a"))
+
+	mr.FastForward(time.Minute)
+	_, ok = r.Get(ctx, "a")
+	assert.False(t, ok)
+
+	r.Set(ctx, "b", []byte("2"), time.Minute)
+	r.Delete(ctx, "b", "missing")
+	_, ok = r.Get(ctx, "b")
+	assert.False(t, ok)
+}
+
+func TestRedis_InvalidateOtherInstances(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := startRedis(t, mr.Addr())
+	b := startRedis(t, mr.Addr())
+	ctx := context.Background()
+
+	assert.Equal(t, int64(0), b.Generation(ctx, "items:list"))
+	b.Set(ctx, "items:find_by_id:0:1", []byte("{}"), time.Minute)
+
+	a.Invalidate(ctx, []string{"items:list"}, "items:find_by_id:0:1")
+
+	assert.Equal(t, int64(1), a.Generation(ctx, "items:list"))
+	// 他のサーバーには通知で届く
+	require.Eventually(t, func() bool {
+		return b.Generation(ctx, "items:list") == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	_, ok := b.Get(ctx, "items:find_by_id:0:1")
+	assert.False(t, ok)
+}
+
+func TestRedis_Unavailable(t *testing.T) {
+	mr := miniredis.RunT(t)
+	r := startRedis(t, mr.Addr())
+	mr.Close()
+	ctx := context.Background()
+
+	repo := &memory.ItemRepository{Store: memory.NewStore()}
+	item, err := repo.Create(ctx, &entity.Item{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", Currency: "JPY", Status: entity.ItemStatusOwned})
+	require.NoError(t, err)
+
+	// Redisに接続できない場合もエラーにせず、リポジトリから読み込む
+	cached := usecase.NewItemCache(r, time.Minute, nil).ItemRepository(repo)
+	found, err := cached.FindByID(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "ロレックス デイトナ", found.Name)
+
+	_, err = cached.Delete(ctx, item.ID)
+	require.NoError(t, err)
+	_, ok := r.Get(ctx, "items:list")
+	assert.False(t, ok)
+}
@@ -1,38 +1,729 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"io/fs"
+	"log/slog"
+	"math"
+	"net"
+	"net/url"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// サーバーとマイグレーションのコマンドの設定。Loadで環境変数から読み込み、mainから各依存先に渡す。
+// envタグは読み込む環境変数の名前で、secretタグを付けたフィールドは起動時の出力で伏せる
+type Config struct {
+	Port     int `env:"PORT"`      // 待ち受けるポート
+	GRPCPort int `env:"GRPC_PORT"` // gRPCのサーバーが待ち受けるポート。0の場合はgRPCのサーバーを起動しない
+
+	PublicBaseURL string `env:"PUBLIC_BASE_URL"` // クライアントからAPIを呼び出すURLのスキームとホスト（QRコードのURLなど）。未設定の場合はhttp://localhost:PORT
+
+	DBUser     string `env:"DB_USER"`
+	DBPassword string `env:"DB_PASSWORD" secret:"true"`
+	DBHost     string `env:"DB_HOST"`
+	DBName     string `env:"DB_NAME"`
+	DBPort     string `env:"DB_PORT"`
+
+	ImageStorageDir string `env:"IMAGE_STORAGE_DIR"` // 画像の保存先ディレクトリ
+	ImageBaseURL    string `env:"IMAGE_BASE_URL"`    // 画像を公開するURLのパス
+	ImageMaxSize    int64  `env:"IMAGE_MAX_SIZE"`    // アップロードできる画像の最大サイズ（バイト）
+
+	MaxBodySize   int64 `env:"MAX_BODY_SIZE"`   // JSONなどのリクエストボディの最大サイズ（バイト）
+	MaxUploadSize int64 `env:"MAX_UPLOAD_SIZE"` // multipart/form-dataのアップロード（画像・CSV）のリクエストボディの最大サイズ（バイト）
+
+	MaxPurchasePrice     int64          `env:"MAX_PURCHASE_PRICE"`     // 登録できる購入価格の上限
+	PurchaseDateLocation *time.Location `env:"PURCHASE_DATE_TIMEZONE"` // 購入日が未来かどうかを判定するタイムゾーン
+
+	BaseCurrency  string             `env:"BASE_CURRENCY"`  // 集計時の換算先の通貨
+	ExchangeRates map[string]float64 `env:"EXCHANGE_RATES"` // 1単位あたりの基準通貨での価値
+
+	RequirePreconditions bool `env:"REQUIRE_PRECONDITIONS"` // アイテムの更新・削除でIf-Matchヘッダーを必須にするかどうか
+
+	BrandValidation string `env:"BRAND_VALIDATION"` // アイテムのブランドを登録済みのブランドと照合するモード（off, soft, strict）
+
+	Repository      string `env:"REPOSITORY"`       // データの保存先（mysql, postgres, sqlite, memory）
+	SQLitePath      string `env:"SQLITE_PATH"`      // Repositoryがsqliteの場合のデータベースファイル
+	PostgresSSLMode string `env:"POSTGRES_SSLMODE"` // Repositoryがpostgresの場合のsslmode
+	AutoMigrate     bool   `env:"AUTO_MIGRATE"`     // 起動時に未適用のマイグレーションを適用するかどうか
+
+	QueryTimeout time.Duration `env:"QUERY_TIMEOUT"` // リポジトリの呼び出し1回あたりの制限時間（0は無制限）
+
+	RequestTimeout       time.Duration `env:"REQUEST_TIMEOUT"`        // リクエスト1件あたりの制限時間（0は無制限）
+	ImportRequestTimeout time.Duration `env:"IMPORT_REQUEST_TIMEOUT"` // CSVのインポートとJSONの一括登録のリクエストの制限時間（0は無制限）
+	StreamIdleTimeout    time.Duration `env:"STREAM_IDLE_TIMEOUT"`    // エクスポートでレスポンスの書き込みが途絶えてから打ち切るまでの時間（0は無制限）
+
+	DBConnectAttempts int           `env:"DB_CONNECT_ATTEMPTS"` // 起動時にデータベースへの接続を試みる回数の上限
+	DBConnectInterval time.Duration `env:"DB_CONNECT_INTERVAL"` // 起動時の接続に失敗してから次に試みるまでの間隔
+
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT"` // 終了時に処理中のリクエストの完了を待つ時間
+
+	ReadinessCacheTTL time.Duration `env:"READINESS_CACHE_TTL"` // /readyzで依存先の確認の結果を使い回す期間
+	ReadinessTimeout  time.Duration `env:"READINESS_TIMEOUT"`   // /readyzで依存先の確認1回あたりの制限時間
+
+	RateLimit      float64      `env:"RATE_LIMIT"`       // クライアントごとに1秒あたりに受け付けるリクエスト数（0は制限しない）
+	RateLimitBurst int          `env:"RATE_LIMIT_BURST"` // クライアントごとに連続して受け付けるリクエストの上限
+	TrustedProxies []*net.IPNet `env:"TRUSTED_PROXIES"`  // X-Forwarded-Forヘッダーを信頼するプロキシのアドレスの範囲
+
+	IPRateLimit      float64 `env:"IP_RATE_LIMIT"`       // 認証の前にIPアドレスごとに1秒あたりに受け付けるリクエスト数（0は制限しない）
+	IPRateLimitBurst int     `env:"IP_RATE_LIMIT_BURST"` // 認証の前にIPアドレスごとに連続して受け付けるリクエストの上限
+
+	CORSAllowedOrigins   []string      `env:"CORS_ALLOWED_ORIGINS"`   // ブラウザから別のオリジンで呼び出せるオリジン（*はすべて）。空の場合はCORSのヘッダーを返さない
+	CORSAllowedMethods   []string      `env:"CORS_ALLOWED_METHODS"`   // プリフライトで許可するメソッド
+	CORSAllowedHeaders   []string      `env:"CORS_ALLOWED_HEADERS"`   // プリフライトで許可するリクエストヘッダー
+	CORSExposedHeaders   []string      `env:"CORS_EXPOSED_HEADERS"`   // ブラウザのスクリプトから読めるようにするレスポンスヘッダー
+	CORSAllowCredentials bool          `env:"CORS_ALLOW_CREDENTIALS"` // Cookieや認証ヘッダーを付けたリクエストを許可するかどうか
+	CORSMaxAge           time.Duration `env:"CORS_MAX_AGE"`           // プリフライトの結果をブラウザがキャッシュする期間（0はキャッシュさせない）
+
+	Compression          bool `env:"COMPRESSION"`           // Accept-Encoding: gzipを送ったクライアントへのレスポンスを圧縮するかどうか
+	CompressionMinSize   int  `env:"COMPRESSION_MIN_SIZE"`  // 圧縮するレスポンスの最小のサイズ（バイト）
+	CompressionStreaming bool `env:"COMPRESSION_STREAMING"` // 逐次送信するNDJSONのエクスポートも圧縮するかどうか
+
+	GraphQLMaxDepth      int `env:"GRAPHQL_MAX_DEPTH"`      // GraphQLのクエリで選択できるフィールドの深さの上限
+	GraphQLMaxComplexity int `env:"GRAPHQL_MAX_COMPLEXITY"` // GraphQLのクエリの複雑さ（リストの件数を掛けたフィールド数の見積もり）の上限
+
+	APIKeyAuth bool `env:"API_KEY_AUTH"` // APIの呼び出しにAPIキーかアクセストークンを必須にするかどうか
+
+	JWTSecret     string        `env:"JWT_SECRET" secret:"true"` // アクセストークン（HS256）の署名の鍵。未設定の場合は起動ごとにランダムな鍵を生成する
+	JWTAccessTTL  time.Duration `env:"JWT_ACCESS_TTL"`           // アクセストークンの有効期間
+	JWTRefreshTTL time.Duration `env:"JWT_REFRESH_TTL"`          // リフレッシュトークンの有効期間
+
+	WebhookWorkers     int           `env:"WEBHOOK_WORKERS"`      // Webhookを送信する並行数
+	WebhookQueueSize   int           `env:"WEBHOOK_QUEUE_SIZE"`   // 送信待ちのイベントの上限。超えたイベントは破棄する
+	WebhookTimeout     time.Duration `env:"WEBHOOK_TIMEOUT"`      // Webhookの1回の送信の制限時間
+	WebhookMaxFailures int           `env:"WEBHOOK_MAX_FAILURES"` // 連続して失敗するとWebhookを無効にする回数
+
+	ImportWorkers   int   `env:"IMPORT_WORKERS"`    // CSVインポートのジョブを同時に実行する数
+	ImportQueueSize int   `env:"IMPORT_QUEUE_SIZE"` // 実行待ちのジョブの上限。超えた場合はインポートを受け付けない
+	ImportMaxSize   int64 `env:"IMPORT_MAX_SIZE"`   // インポートできるCSVの最大サイズ（バイト）
+
+	Cache           string        `env:"CACHE"`             // アイテムの読み込みのキャッシュ（off, memory, redis）
+	CacheTTL        time.Duration `env:"CACHE_TTL"`         // キャッシュした値を使う期間
+	CacheMaxEntries int           `env:"CACHE_MAX_ENTRIES"` // Cacheがmemoryの場合に保持する値の上限。超えると最も長く使われていない値から破棄する
+	CacheKeyPrefix  string        `env:"CACHE_KEY_PREFIX"`  // Cacheがredisの場合にキーの前に付ける文字列
+	RedisAddr       string        `env:"REDIS_ADDR"`        // Cacheがredisの場合のRedisのアドレス（host:port）
+
+	TracingExport bool   // OTLPでトレースを送信するかどうか。送信先の環境変数を設定すると有効になる
+	ServiceName   string `env:"OTEL_SERVICE_NAME"` // トレースに付けるサービスの名前
+
+	LogFormat string     `env:"LOG_FORMAT"` // ログの出力形式（json, text）
+	LogLevel  slog.Level `env:"LOG_LEVEL"`  // 起動時のログのレベル。実行中は/admin/log-levelで変更できる
+}
+
+// 待ち受けるポートのデフォルト値
+const (
+	defaultPort     = 8080
+	defaultGRPCPort = 9090
+)
+
+// 画像設定のデフォルト値
+const (
+	defaultImageStorageDir = "uploads"
+	defaultImageBaseURL    = "/images"
+	defaultImageMaxSize    = 5 << 20 // 5MB
+)
+
+// リクエストボディの最大サイズのデフォルト値。アップロードはIMPORT_MAX_SIZEのファイルに他の項目を加えても収まる大きさにする
+const (
+	defaultMaxBodySize   = 1 << 20  // 1MB
+	defaultMaxUploadSize = 20 << 20 // 20MB
+)
+
+// 購入価格の上限のデフォルト値
+const defaultMaxPurchasePrice = 1_000_000_000
+
+// 購入日の判定に使うタイムゾーンのデフォルト値
+const defaultPurchaseDateTimezone = "Asia/Tokyo"
+
+// ブランドの検証モードのデフォルト値と指定できる値
+const defaultBrandValidation = "off"
+
+var validBrandValidations = []string{"off", "soft", "strict"}
+
+// データの保存先のデフォルト値と指定できる値。memoryはMySQLに接続せず、データはプロセスの終了とともに失われる
+const defaultRepository = "mysql"
+
+var validRepositories = []string{"mysql", "postgres", "sqlite", "memory"}
+
+// SQLiteのデータベースファイルのデフォルト値
+const defaultSQLitePath = "items.db"
+
+// PostgreSQLのsslmodeのデフォルト値
+const defaultPostgresSSLMode = "disable"
+
+// リポジトリの呼び出しの制限時間のデフォルト値
+const defaultQueryTimeout = 5 * time.Second
+
+// リクエストの制限時間のデフォルト値。インポートはファイルの受信に、エクスポートは件数に応じて時間がかかる
+const (
+	defaultRequestTimeout       = 10 * time.Second
+	defaultImportRequestTimeout = 2 * time.Minute
+	defaultStreamIdleTimeout    = 30 * time.Second
+)
+
+// 起動時のデータベースへの接続のやり直しのデフォルト値
+const (
+	defaultDBConnectAttempts = 5
+	defaultDBConnectInterval = 2 * time.Second
+)
+
+// 終了時に処理中のリクエストの完了を待つ時間のデフォルト値
+const defaultShutdownTimeout = 10 * time.Second
+
+// 準備状態の確認のデフォルト値
+const (
+	defaultReadinessCacheTTL = 2 * time.Second
+	defaultReadinessTimeout  = time.Second
+)
+
+// リクエストの頻度の制限のデフォルト値
+const (
+	defaultRateLimit      = 20
+	defaultRateLimitBurst = 40
+	// 同じプロキシの背後にある複数のユーザーを妨げないよう、ユーザーごとの制限より緩くする
+	defaultIPRateLimit      = 100
+	defaultIPRateLimitBurst = 200
+)
+
+// CORSの設定のデフォルト値。オリジンは指定しない限り許可しない
 var (
-	DBUser     string
-	DBPassword string
-	DBHost     string
-	DBName     string
-	DBPort     string
+	defaultCORSAllowedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+	defaultCORSAllowedHeaders = []string{"Accept-Language", "Authorization", "Content-Type", "Idempotency-Key", "If-Match", "If-None-Match", "X-API-Key", "X-Request-ID"}
+	defaultCORSExposedHeaders = []string{"Deprecation", "ETag", "Idempotent-Replayed", "Link", "Location", "Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "X-Request-ID"}
 )
 
-func init() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Println("⚠️  .envファイルが見つかりませんでした。")
+const defaultCORSMaxAge = 10 * time.Minute
+
+// レスポンスを圧縮する最小のサイズのデフォルト値。小さいレスポンスは圧縮しても通信量がほとんど減らない
+const defaultCompressionMinSize = 1024
+
+// GraphQLのクエリの上限のデフォルト値。一覧の上限件数の一覧を入れ子にしたクエリは上限を超える
+const (
+	defaultGraphQLMaxDepth      = 8
+	defaultGraphQLMaxComplexity = 5000
+)
+
+// ユーザーのトークンの有効期間のデフォルト値と、署名の鍵の最小のバイト数
+const (
+	defaultJWTAccessTTL  = 15 * time.Minute
+	defaultJWTRefreshTTL = 30 * 24 * time.Hour
+	minJWTSecretBytes    = 32
+)
+
+// Webhookの送信設定のデフォルト値
+const (
+	defaultWebhookWorkers     = 4
+	defaultWebhookQueueSize   = 100
+	defaultWebhookTimeout     = 5 * time.Second
+	defaultWebhookMaxFailures = 5
+)
+
+// CSVインポートの設定のデフォルト値
+const (
+	defaultImportWorkers   = 2
+	defaultImportQueueSize = 10
+	defaultImportMaxSize   = 10 << 20 // 10MB
+)
+
+// キャッシュの設定のデフォルト値と指定できる値
+const (
+	defaultCache           = "off"
+	defaultCacheTTL        = time.Minute
+	defaultCacheMaxEntries = 1000
+	defaultCacheKeyPrefix  = "aicon:"
+	defaultRedisAddr       = "localhost:6379"
+)
+
+var validCaches = []string{"off", "memory", "redis"}
+
+// トレースに付けるサービスの名前のデフォルト値
+const defaultServiceName = "aicon-assignment"
+
+// ログの設定のデフォルト値と指定できる値。開発環境ではLOG_FORMAT=textを指定すると読みやすい
+const (
+	defaultLogFormat = "json"
+	defaultLogLevel  = slog.LevelInfo
+)
+
+var validLogFormats = []string{"json", "text"}
+
+// 通貨設定のデフォルト値。レートは「通貨=レート」のカンマ区切り
+const (
+	defaultBaseCurrency  = "JPY"
+	defaultExchangeRates = "USD=150,EUR=160,GBP=190,CHF=170"
+)
+
+// 設定の誤り。最初の誤りで止めずに、不足している・不正な環境変数をすべてまとめて返す
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(e.Problems, "; ")
+}
+
+// 環境変数から設定を読み込む。開発環境向けに、カレントディレクトリに.envファイルがあれば先に環境変数に読み込む
+// （設定済みの環境変数は上書きしない）。未設定の環境変数はデフォルト値にし、
+// 不足している・不正な環境変数がある場合はすべてを*ValidationErrorにまとめて返す
+func Load() (*Config, error) {
+	var problems []string
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		problems = append(problems, fmt.Sprintf(".env: %v", err))
+	}
+
+	l := &loader{}
+	cfg := &Config{
+		Port:     l.port("PORT", defaultPort),
+		GRPCPort: l.optionalPort("GRPC_PORT", defaultGRPCPort),
+
+		PublicBaseURL: l.origin("PUBLIC_BASE_URL"),
+
+		DBUser:     l.string("DB_USER", ""),
+		DBPassword: l.string("DB_PASSWORD", ""),
+		DBHost:     l.string("DB_HOST", ""),
+		DBPort:     l.string("DB_PORT", ""),
+		DBName:     l.string("DB_NAME", ""),
+
+		ImageStorageDir: l.string("IMAGE_STORAGE_DIR", defaultImageStorageDir),
+		ImageBaseURL:    l.string("IMAGE_BASE_URL", defaultImageBaseURL),
+		ImageMaxSize:    l.positiveInt64("IMAGE_MAX_SIZE", defaultImageMaxSize),
+
+		MaxBodySize:   l.positiveInt64("MAX_BODY_SIZE", defaultMaxBodySize),
+		MaxUploadSize: l.positiveInt64("MAX_UPLOAD_SIZE", defaultMaxUploadSize),
+
+		MaxPurchasePrice:     l.positiveInt64("MAX_PURCHASE_PRICE", defaultMaxPurchasePrice),
+		PurchaseDateLocation: l.location("PURCHASE_DATE_TIMEZONE", defaultPurchaseDateTimezone),
+
+		BaseCurrency:  l.string("BASE_CURRENCY", defaultBaseCurrency),
+		ExchangeRates: l.exchangeRates("EXCHANGE_RATES", defaultExchangeRates),
+
+		RequirePreconditions: l.bool("REQUIRE_PRECONDITIONS", false),
+
+		BrandValidation: l.oneOf("BRAND_VALIDATION", defaultBrandValidation, validBrandValidations),
+
+		Repository:      l.oneOf("REPOSITORY", defaultRepository, validRepositories),
+		SQLitePath:      l.string("SQLITE_PATH", defaultSQLitePath),
+		PostgresSSLMode: l.string("POSTGRES_SSLMODE", defaultPostgresSSLMode),
+		AutoMigrate:     l.bool("AUTO_MIGRATE", true),
+
+		QueryTimeout: l.duration("QUERY_TIMEOUT", defaultQueryTimeout, true),
+
+		RequestTimeout:       l.duration("REQUEST_TIMEOUT", defaultRequestTimeout, true),
+		ImportRequestTimeout: l.duration("IMPORT_REQUEST_TIMEOUT", defaultImportRequestTimeout, true),
+		StreamIdleTimeout:    l.duration("STREAM_IDLE_TIMEOUT", defaultStreamIdleTimeout, true),
+
+		DBConnectAttempts: l.positiveInt("DB_CONNECT_ATTEMPTS", defaultDBConnectAttempts),
+		DBConnectInterval: l.duration("DB_CONNECT_INTERVAL", defaultDBConnectInterval, false),
+
+		ShutdownTimeout: l.duration("SHUTDOWN_TIMEOUT", defaultShutdownTimeout, false),
+
+		ReadinessCacheTTL: l.duration("READINESS_CACHE_TTL", defaultReadinessCacheTTL, false),
+		ReadinessTimeout:  l.duration("READINESS_TIMEOUT", defaultReadinessTimeout, false),
+
+		RateLimit:      l.nonNegativeFloat("RATE_LIMIT", defaultRateLimit),
+		RateLimitBurst: l.positiveInt("RATE_LIMIT_BURST", defaultRateLimitBurst),
+		TrustedProxies: l.ipNets("TRUSTED_PROXIES"),
+
+		IPRateLimit:      l.nonNegativeFloat("IP_RATE_LIMIT", defaultIPRateLimit),
+		IPRateLimitBurst: l.positiveInt("IP_RATE_LIMIT_BURST", defaultIPRateLimitBurst),
+
+		CORSAllowedOrigins:   l.origins("CORS_ALLOWED_ORIGINS"),
+		CORSAllowedMethods:   l.list("CORS_ALLOWED_METHODS", defaultCORSAllowedMethods),
+		CORSAllowedHeaders:   l.list("CORS_ALLOWED_HEADERS", defaultCORSAllowedHeaders),
+		CORSExposedHeaders:   l.list("CORS_EXPOSED_HEADERS", defaultCORSExposedHeaders),
+		CORSAllowCredentials: l.bool("CORS_ALLOW_CREDENTIALS", false),
+		CORSMaxAge:           l.duration("CORS_MAX_AGE", defaultCORSMaxAge, true),
+
+		Compression:          l.bool("COMPRESSION", true),
+		CompressionMinSize:   l.positiveInt("COMPRESSION_MIN_SIZE", defaultCompressionMinSize),
+		CompressionStreaming: l.bool("COMPRESSION_STREAMING", false),
+
+		GraphQLMaxDepth:      l.positiveInt("GRAPHQL_MAX_DEPTH", defaultGraphQLMaxDepth),
+		GraphQLMaxComplexity: l.positiveInt("GRAPHQL_MAX_COMPLEXITY", defaultGraphQLMaxComplexity),
+
+		APIKeyAuth: l.bool("API_KEY_AUTH", true),
+
+		JWTSecret:     l.string("JWT_SECRET", ""),
+		JWTAccessTTL:  l.duration("JWT_ACCESS_TTL", defaultJWTAccessTTL, false),
+		JWTRefreshTTL: l.duration("JWT_REFRESH_TTL", defaultJWTRefreshTTL, false),
+
+		WebhookWorkers:     l.positiveInt("WEBHOOK_WORKERS", defaultWebhookWorkers),
+		WebhookQueueSize:   l.positiveInt("WEBHOOK_QUEUE_SIZE", defaultWebhookQueueSize),
+		WebhookTimeout:     l.duration("WEBHOOK_TIMEOUT", defaultWebhookTimeout, false),
+		WebhookMaxFailures: l.positiveInt("WEBHOOK_MAX_FAILURES", defaultWebhookMaxFailures),
+
+		ImportWorkers:   l.positiveInt("IMPORT_WORKERS", defaultImportWorkers),
+		ImportQueueSize: l.positiveInt("IMPORT_QUEUE_SIZE", defaultImportQueueSize),
+		ImportMaxSize:   l.positiveInt64("IMPORT_MAX_SIZE", defaultImportMaxSize),
+
+		Cache:           l.oneOf("CACHE", defaultCache, validCaches),
+		CacheTTL:        l.duration("CACHE_TTL", defaultCacheTTL, false),
+		CacheMaxEntries: l.positiveInt("CACHE_MAX_ENTRIES", defaultCacheMaxEntries),
+		CacheKeyPrefix:  l.string("CACHE_KEY_PREFIX", defaultCacheKeyPrefix),
+		RedisAddr:       l.string("REDIS_ADDR", defaultRedisAddr),
+
+		// 送信先はOpenTelemetryの標準の環境変数で指定する。OTEL_SDK_DISABLEDがtrueの場合は送信しない
+		TracingExport: (l.string("OTEL_EXPORTER_OTLP_ENDPOINT", "") != "" || l.string("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "") != "") &&
+			!strings.EqualFold(l.string("OTEL_SDK_DISABLED", ""), "true"),
+		ServiceName: l.string("OTEL_SERVICE_NAME", defaultServiceName),
+
+		LogFormat: l.oneOf("LOG_FORMAT", defaultLogFormat, validLogFormats),
+		LogLevel:  l.logLevel("LOG_LEVEL", defaultLogLevel),
+	}
+
+	// SQLのデータベースに接続する場合は接続先が必要。パスワードは空の場合もあるため必須にしない
+	if cfg.Repository == "mysql" || cfg.Repository == "postgres" {
+		for _, key := range []string{"DB_HOST", "DB_PORT", "DB_USER", "DB_NAME"} {
+			l.require(key, "REPOSITORY="+cfg.Repository)
+		}
+		if cfg.DBPort != "" {
+			if port, err := strconv.Atoi(cfg.DBPort); err != nil || port <= 0 || port > 65535 {
+				l.invalid("DB_PORT", "must be a port number (1-65535)")
+			}
+		}
+	}
+
+	if cfg.PublicBaseURL == "" {
+		cfg.PublicBaseURL = fmt.Sprintf("http://localhost:%d", cfg.Port)
+	}
+
+	// HTTPとgRPCのサーバーは同じポートで待ち受けられない
+	if cfg.GRPCPort == cfg.Port {
+		l.invalid("GRPC_PORT", "must be different from PORT")
+	}
+
+	// アップロードの上限より大きいファイルは受け付けられない
+	if cfg.MaxUploadSize < max(cfg.ImageMaxSize, cfg.ImportMaxSize) {
+		l.invalid("MAX_UPLOAD_SIZE", "must be at least IMAGE_MAX_SIZE and IMPORT_MAX_SIZE")
 	}
 
-	DBUser = os.Getenv("DB_USER")
-	DBPassword = os.Getenv("DB_PASSWORD")
-	DBHost = os.Getenv("DB_HOST")
-	DBPort = os.Getenv("DB_PORT")
-	DBName = os.Getenv("DB_NAME")
+	// HS256の鍵は推測されないよう、ハッシュの長さ以上にする
+	if cfg.JWTSecret != "" && len(cfg.JWTSecret) < minJWTSecretBytes {
+		l.invalid("JWT_SECRET", fmt.Sprintf("must be at least %d bytes", minJWTSecretBytes))
+	}
+
+	problems = append(problems, l.problems...)
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+	return cfg, nil
+}
+
+// 保存先（Repository）のデータベースに接続する文字列。sqliteの場合はデータベースファイルのパス
+func (c *Config) DatabaseDSN() string {
+	switch c.Repository {
+	case "sqlite":
+		return c.SQLitePath
+	case "postgres":
+		return c.PostgresDSN()
+	default:
+		return c.MySQLDSN()
+	}
 }
 
-// DB接続文字列を返す
-func GetDSN() string {
+// MySQLの接続文字列を返す
+func (c *Config) MySQLDSN() string {
 	return fmt.Sprintf(
 		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&collation=utf8mb4_unicode_ci&parseTime=true&loc=Local&sql_mode=TRADITIONAL&multiStatements=true",
-		DBUser, DBPassword, DBHost, DBPort, DBName,
+		c.DBUser, c.DBPassword, c.DBHost, c.DBPort, c.DBName,
 	)
 }
+
+// PostgreSQLの接続文字列を返す。接続先はMySQLと同じDB_*の環境変数で指定する
+func (c *Config) PostgresDSN() string {
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.DBUser, c.DBPassword),
+		Host:     net.JoinHostPort(c.DBHost, c.DBPort),
+		Path:     "/" + c.DBName,
+		RawQuery: url.Values{"sslmode": {c.PostgresSSLMode}}.Encode(),
+	}
+	return dsn.String()
+}
+
+// 待ち受けるアドレス
+func (c *Config) ListenAddr() string {
+	return fmt.Sprintf(":%d", c.Port)
+}
+
+// gRPCのサーバーが待ち受けるアドレス
+func (c *Config) GRPCListenAddr() string {
+	return fmt.Sprintf(":%d", c.GRPCPort)
+}
+
+// 環境変数を読み込み、不正な値をproblemsに記録する。不正な値の場合はデフォルト値を返す
+type loader struct {
+	problems []string
+}
+
+func (l *loader) invalid(key, reason string) {
+	l.problems = append(l.problems, fmt.Sprintf("%s: %s", key, reason))
+}
+
+// 環境変数を取得し、未設定の場合はデフォルト値を返す
+func (l *loader) string(key, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
+
+// 必須の環境変数が設定されているかを確認する。reasonは必須である理由
+func (l *loader) require(key, reason string) {
+	if os.Getenv(key) == "" {
+		l.invalid(key, "required when "+reason)
+	}
+}
+
+// 環境変数を正の整数として取得する
+func (l *loader) positiveInt(key string, defaultValue int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		l.invalid(key, fmt.Sprintf("must be a positive integer, got %q", v))
+		return defaultValue
+	}
+	return n
+}
+
+// 環境変数を正の整数（64ビット）として取得する
+func (l *loader) positiveInt64(key string, defaultValue int64) int64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultValue
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n <= 0 {
+		l.invalid(key, fmt.Sprintf("must be a positive integer, got %q", v))
+		return defaultValue
+	}
+	return n
+}
+
+// 環境変数をポート番号として取得する
+func (l *loader) port(key string, defaultValue int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 || n > 65535 {
+		l.invalid(key, fmt.Sprintf("must be a port number (1-65535), got %q", v))
+		return defaultValue
+	}
+	return n
+}
+
+// 環境変数をポート番号として取得する。0は無効にすることを表す
+func (l *loader) optionalPort(key string, defaultValue int) int {
+	if os.Getenv(key) == "0" {
+		return 0
+	}
+	return l.port(key, defaultValue)
+}
+
+// 環境変数を0以上の数値として取得する
+func (l *loader) nonNegativeFloat(key string, defaultValue float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultValue
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 || math.IsInf(f, 0) || math.IsNaN(f) {
+		l.invalid(key, fmt.Sprintf("must be a non-negative number, got %q", v))
+		return defaultValue
+	}
+	return f
+}
+
+// 環境変数をカンマ区切りのアドレスの範囲（CIDR）として取得する。範囲のないアドレスはそのアドレスのみを表す
+func (l *loader) ipNets(key string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				l.invalid(key, fmt.Sprintf("invalid IP address %q", value))
+				continue
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			l.invalid(key, fmt.Sprintf("invalid CIDR %q", value))
+			continue
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets
+}
+
+// 環境変数をカンマ区切りの値の一覧として取得する。未設定の場合はデフォルト値を返す
+func (l *loader) list(key string, defaultValue []string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return defaultValue
+	}
+	return values
+}
+
+// 環境変数をカンマ区切りのオリジン（scheme://host[:port]）か*として取得する
+func (l *loader) origins(key string) []string {
+	var origins []string
+	for _, origin := range l.list(key, nil) {
+		if origin != "*" {
+			if !isOrigin(origin) {
+				l.invalid(key, fmt.Sprintf("invalid origin %q, must be scheme://host[:port] or *", origin))
+				continue
+			}
+			// ブラウザが送るOriginヘッダーには末尾の/が付かない
+			origin = strings.TrimSuffix(origin, "/")
+		}
+		origins = append(origins, origin)
+	}
+	return origins
+}
+
+// 環境変数をオリジン（scheme://host[:port]）として取得する。末尾の/は取り除き、未設定の場合は空文字列を返す
+func (l *loader) origin(key string) string {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return ""
+	}
+	if !isOrigin(v) {
+		l.invalid(key, fmt.Sprintf("invalid URL %q, must be scheme://host[:port]", v))
+		return ""
+	}
+	return strings.TrimSuffix(v, "/")
+}
+
+// vがhttpかhttpsのscheme://host[:port]（末尾の/は可）かどうか
+func isOrigin(v string) bool {
+	u, err := url.Parse(v)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && (u.Path == "" || u.Path == "/") && u.RawQuery == "" && u.Fragment == ""
+}
+
+// 環境変数を時間（Goの時間の形式）として取得する。allowZeroがfalseの場合は正の時間のみを受け付ける
+func (l *loader) duration(key string, defaultValue time.Duration, allowZero bool) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 || (d == 0 && !allowZero) {
+		if allowZero {
+			l.invalid(key, fmt.Sprintf("must be a non-negative duration such as 5s, got %q", v))
+		} else {
+			l.invalid(key, fmt.Sprintf("must be a positive duration such as 5s, got %q", v))
+		}
+		return defaultValue
+	}
+	return d
+}
+
+// 環境変数を真偽値として取得する
+func (l *loader) bool(key string, defaultValue bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		l.invalid(key, fmt.Sprintf("must be true or false, got %q", v))
+		return defaultValue
+	}
+	return b
+}
+
+// 環境変数をvalidのいずれか（大文字・小文字を区別しない）として取得する
+func (l *loader) oneOf(key, defaultValue string, valid []string) string {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultValue
+	}
+	value := strings.ToLower(strings.TrimSpace(v))
+	if !slices.Contains(valid, value) {
+		l.invalid(key, fmt.Sprintf("must be one of %s, got %q", strings.Join(valid, ", "), v))
+		return defaultValue
+	}
+	return value
+}
+
+// 環境変数をタイムゾーンの名前として読み込む
+func (l *loader) location(key, defaultValue string) *time.Location {
+	name := l.string(key, defaultValue)
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		l.invalid(key, fmt.Sprintf("unknown time zone %q", name))
+		return time.UTC
+	}
+	return loc
+}
+
+// 環境変数を「USD=150,EUR=160」形式のレートとして読み込む
+func (l *loader) exchangeRates(key, defaultValue string) map[string]float64 {
+	rates, err := parseExchangeRates(l.string(key, defaultValue))
+	if err != nil {
+		l.invalid(key, err.Error())
+		rates, _ = parseExchangeRates(defaultValue)
+	}
+	return rates
+}
+
+// 環境変数をログのレベル（debug, info, warn, error）として読み込む
+func (l *loader) logLevel(key string, defaultValue slog.Level) slog.Level {
+	v := os.Getenv(key)
+	if v == "" {
+		return defaultValue
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(v))); err != nil {
+		l.invalid(key, fmt.Sprintf("must be one of debug, info, warn, error, got %q", v))
+		return defaultValue
+	}
+	return level
+}
+
+// 「USD=150,EUR=160」形式のレート設定を解析する
+func parseExchangeRates(value string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		currency, rateStr, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate %q", pair)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid rate %q", pair)
+		}
+		rates[strings.ToUpper(strings.TrimSpace(currency))] = rate
+	}
+	return rates, nil
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// 伏せた値の代わりに出力する文字列
+const redactedValue = "********"
+
+// 設定を「環境変数の名前=値」の形式で1行ずつwに出力する。起動時の確認に使う。
+// redactedがtrueの場合、secretタグを付けた値（データベースのパスワードなど）は伏せる。
+// 環境変数から直接読み込まない値（envタグのないフィールド）は出力しない
+func Print(w io.Writer, cfg *Config, redacted bool) error {
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := field.Tag.Get("env")
+		if key == "" {
+			continue
+		}
+
+		value := formatValue(v.Field(i).Interface())
+		if redacted && field.Tag.Get("secret") == "true" && value != "" {
+			value = redactedValue
+		}
+		if _, err := fmt.Fprintf(w, "%s=%s\n", key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 設定の値を環境変数に指定する形式の文字列にする
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case *time.Location:
+		if v == nil {
+			return ""
+		}
+		return v.String()
+	case time.Duration:
+		return v.String()
+	case slog.Level:
+		return strings.ToLower(v.String())
+	case []*net.IPNet:
+		ranges := make([]string, len(v))
+		for i, ipNet := range v {
+			ranges[i] = ipNet.String()
+		}
+		return strings.Join(ranges, ",")
+	case []string:
+		return strings.Join(v, ",")
+	case map[string]float64:
+		pairs := make([]string, 0, len(v))
+		for currency, rate := range v {
+			pairs = append(pairs, fmt.Sprintf("%s=%g", currency, rate))
+		}
+		slices.Sort(pairs)
+		return strings.Join(pairs, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
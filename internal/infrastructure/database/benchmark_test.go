@@ -0,0 +1,163 @@
+package databaseInfra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 1回の操作で登録する行数。CSVインポートのような数千行の登録を想定する
+const benchmarkRows = 1000
+
+func newBenchmarkItems(b *testing.B) []*entity.Item {
+	b.Helper()
+
+	items := make([]*entity.Item, benchmarkRows)
+	for i := range items {
+		items[i] = newTestItem(b, fmt.Sprintf("ロレックス デイトナ %d", i))
+	}
+	return items
+}
+
+// 1行ずつINSERTする場合
+func BenchmarkItemRepository_CreateMany_PerRow(b *testing.B) {
+	handler := openTestSQLite(b)
+	transactor := &database.Transactor{SqlHandler: handler}
+	repo := &database.ItemRepository{SqlHandler: transactor, Dialect: database.SQLite}
+	items := newBenchmarkItems(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			for _, item := range items {
+				if _, err := repo.CreateMany(ctx, []*entity.Item{item}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// 複数行INSERTでまとめて登録する場合
+func BenchmarkItemRepository_CreateMany_Batch(b *testing.B) {
+	handler := openTestSQLite(b)
+	repo := &database.ItemRepository{SqlHandler: handler, Dialect: database.SQLite}
+	items := newBenchmarkItems(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.CreateMany(ctx, items); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// エクスポートのベンチマークの行数。全件をスライスに読み込むと数百MiBになる件数にする
+const exportBenchmarkRows = 300000
+
+// rows件のアイテムをSQLの中で生成して登録する
+func insertSyntheticItems(t testing.TB, handler database.SqlHandler, rows int) {
+	t.Helper()
+
+	_, err := handler.Execute(context.Background(), `
+        WITH RECURSIVE seq(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM seq WHERE n < ?)
+        INSERT INTO items (name, category, brand, purchase_price, purchase_date, notes)
+        SELECT 'ロレックス デイトナ ' || n, '時計', 'ROLEX', 1000000 + n, '2023-01-15', '付属品あり。箱・保証書・予備のコマを保管している' FROM seq
+    `, rows)
+	require.NoError(t, err)
+}
+
+// 処理中のヒープの最大使用量を測る。sampleは一定の件数ごとに呼ぶ
+type heapPeak struct {
+	baseline uint64
+	peak     uint64
+}
+
+func newHeapPeak() *heapPeak {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return &heapPeak{baseline: stats.HeapAlloc, peak: stats.HeapAlloc}
+}
+
+func (h *heapPeak) sample() {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	h.peak = max(h.peak, stats.HeapAlloc)
+}
+
+// ベースラインからのヒープの最大増加量（MiB）
+func (h *heapPeak) report(b *testing.B) {
+	b.ReportMetric(float64(h.peak-h.baseline)/(1<<20), "peak-heap-MiB")
+}
+
+// 1件ずつ読み込みながらNDJSONに書き込む場合。ヒープの使用量は件数によらず数MiBに収まる
+func BenchmarkItemUsecase_ExportItems(b *testing.B) {
+	handler := openTestSQLite(b)
+	insertSyntheticItems(b, handler, exportBenchmarkRows)
+	transactor := &database.Transactor{SqlHandler: handler}
+	items := &database.ItemRepository{SqlHandler: transactor, Dialect: database.SQLite}
+	categories := &database.CategoryRepository{SqlHandler: transactor, Dialect: database.SQLite}
+	uc := usecase.NewItemUsecase(items, categories, nil, nil, transactor, nil)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		heap := newHeapPeak()
+		encoder := json.NewEncoder(io.Discard)
+		exported := 0
+		err := uc.ExportItems(ctx, entity.ItemFilter{}, func(item *entity.Item) error {
+			if exported++; exported%10000 == 0 {
+				heap.sample()
+			}
+			return encoder.Encode(item)
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if exported != exportBenchmarkRows {
+			b.Fatalf("exported %d items, want %d", exported, exportBenchmarkRows)
+		}
+		heap.report(b)
+	}
+}
+
+// 比較のため、全件をスライスに読み込んでから書き込む場合
+func BenchmarkItemRepository_FindAll_AllRows(b *testing.B) {
+	handler := openTestSQLite(b)
+	insertSyntheticItems(b, handler, exportBenchmarkRows)
+	repo := &database.ItemRepository{SqlHandler: handler, Dialect: database.SQLite}
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		heap := newHeapPeak()
+		all, err := repo.FindAll(ctx, entity.ItemFilter{}, entity.ItemSort{}.WithDefaults(), entity.Pagination{Limit: exportBenchmarkRows})
+		if err != nil {
+			b.Fatal(err)
+		}
+		heap.sample()
+		encoder := json.NewEncoder(io.Discard)
+		for _, item := range all {
+			if err := encoder.Encode(item); err != nil {
+				b.Fatal(err)
+			}
+		}
+		heap.report(b)
+	}
+}
@@ -0,0 +1,105 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// 完了までに数十秒かかるクエリ
+var slowQueries = map[string]string{
+	"MySQL":      "SELECT SLEEP(30)",
+	"PostgreSQL": "SELECT pg_sleep(30)",
+	"SQLite":     "WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c WHERE x < 10000000000) SELECT COUNT(*) FROM c",
+}
+
+func TestSqlHandler_CanceledContextAbortsQuery(t *testing.T) {
+	backends := []struct {
+		name    string
+		dsnEnv  string
+		handler func(t *testing.T, dsn string) database.SqlHandler
+	}{
+		{"MySQL", mysqlDSNEnv, func(t *testing.T, dsn string) database.SqlHandler {
+			conn, err := sql.Open("mysql", dsn)
+			require.NoError(t, err)
+			t.Cleanup(func() { conn.Close() })
+			return &MySqlHandler{Conn: conn}
+		}},
+		{"PostgreSQL", postgresDSNEnv, func(t *testing.T, dsn string) database.SqlHandler {
+			handler, err := OpenPostgres(dsn)
+			require.NoError(t, err)
+			t.Cleanup(func() { handler.Close() })
+			return handler
+		}},
+		{"SQLite", "", func(t *testing.T, _ string) database.SqlHandler { return openTestSQLite(t) }},
+	}
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			var dsn string
+			if backend.dsnEnv != "" {
+				dsn = os.Getenv(backend.dsnEnv)
+				if dsn == "" {
+					t.Skipf("%s is not set", backend.dsnEnv)
+				}
+			}
+			handler := backend.handler(t, dsn)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(100*time.Millisecond, cancel)
+
+			start := time.Now()
+			var result interface{}
+			err := handler.QueryRow(ctx, slowQueries[backend.name]).Scan(&result)
+
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.Less(t, time.Since(start), 5*time.Second, "the query should be aborted soon after the cancellation")
+		})
+	}
+}
+
+// リポジトリのエラーはコンテキストのエラーを含み、ハンドラーで中断と制限時間の超過を判別できる
+func TestItemRepository_ContextErrors(t *testing.T) {
+	repo := &database.ItemRepository{SqlHandler: openTestSQLite(t), Dialect: database.SQLite}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := repo.FindAll(canceled, entity.ItemFilter{}, entity.ItemSort{}, entity.Pagination{Limit: 10})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+	_, err = repo.Count(expired, entity.ItemFilter{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, errors.Is(err, context.Canceled))
+}
+
+// 1件ずつの読み込みの途中でコンテキストが終了した場合は、残りの行を読み込まずにクエリを中断する
+func TestItemRepository_EachItem_CanceledMidStream(t *testing.T) {
+	handler := openTestSQLite(t)
+	insertSyntheticItems(t, handler, 5000)
+	repo := &database.ItemRepository{SqlHandler: handler, Dialect: database.SQLite}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	read := 0
+	err := repo.EachItem(ctx, entity.ItemFilter{}, entity.ItemSort{}.WithDefaults(), func(*entity.Item) error {
+		if read++; read == 10 {
+			cancel()
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, read, 5000)
+}
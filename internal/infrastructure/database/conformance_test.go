@@ -0,0 +1,99 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/infrastructure/migration"
+	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+	"Aicon-assignment/internal/usecase/repositorytest"
+)
+
+// 共通のテストを実行するデータベースの接続文字列の環境変数。設定したデータベースに対してのみ実行する。
+// テストのたびに全テーブルを作成し直すため、テスト専用のデータベースを指定する（MySQLはmultiStatements=trueが必要）
+const (
+	mysqlDSNEnv    = "TEST_MYSQL_DSN"
+	postgresDSNEnv = "TEST_POSTGRES_DSN"
+)
+
+// 作成し直すテーブル。外部キーで参照するテーブルを先に削除する
+var conformanceTables = []string{"schema_migrations", "import_jobs", "webhook_deliveries", "webhooks", "item_tags", "tags", "item_images", "item_histories", "idempotency_keys", "items", "brand_aliases", "brands", "categories", "refresh_tokens", "users", "api_keys", "audit_logs"}
+
+func TestItemRepository_Conformance(t *testing.T) {
+	backends := []struct {
+		name    string
+		dsnEnv  string
+		factory func(t *testing.T, dsn string) repositorytest.ItemRepositoryFactory
+	}{
+		{"MySQL", mysqlDSNEnv, mysqlRepositoryFactory},
+		{"PostgreSQL", postgresDSNEnv, postgresRepositoryFactory},
+		{"SQLite", "", sqliteRepositoryFactory},
+	}
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			var dsn string
+			if backend.dsnEnv != "" {
+				dsn = os.Getenv(backend.dsnEnv)
+				if dsn == "" {
+					t.Skipf("%s is not set", backend.dsnEnv)
+				}
+			}
+			repositorytest.RunItemRepositoryTests(t, backend.factory(t, dsn))
+		})
+	}
+}
+
+func mysqlRepositoryFactory(t *testing.T, dsn string) repositorytest.ItemRepositoryFactory {
+	conn, err := sql.Open("mysql", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	handler := &MySqlHandler{Conn: conn}
+
+	return func(t *testing.T) usecase.ItemRepository {
+		resetDatabase(t, handler, "", "mysql")
+		// マイグレーションで登録したサンプルのアイテムは削除し、初期カテゴリーのみの状態にする
+		_, err := conn.Exec("DELETE FROM items")
+		require.NoError(t, err)
+		return &database.ItemRepository{SqlHandler: handler, Dialect: database.MySQL}
+	}
+}
+
+func postgresRepositoryFactory(t *testing.T, dsn string) repositorytest.ItemRepositoryFactory {
+	handler, err := OpenPostgres(dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { handler.Close() })
+
+	return func(t *testing.T) usecase.ItemRepository {
+		resetDatabase(t, handler, " CASCADE", "postgres")
+		return &database.ItemRepository{SqlHandler: handler, Dialect: database.Postgres}
+	}
+}
+
+// SQLiteはテストごとに一時ディレクトリにデータベースファイルを作成する
+func sqliteRepositoryFactory(t *testing.T, _ string) repositorytest.ItemRepositoryFactory {
+	return func(t *testing.T) usecase.ItemRepository {
+		return &database.ItemRepository{SqlHandler: openTestSQLite(t), Dialect: database.SQLite}
+	}
+}
+
+// テーブルを削除し、backendのマイグレーションで作成し直す。dropOptionはDROP TABLEの末尾に付ける
+func resetDatabase(t *testing.T, handler database.SqlHandler, dropOption, backend string) {
+	t.Helper()
+	ctx := context.Background()
+
+	for _, table := range conformanceTables {
+		_, err := handler.Execute(ctx, "DROP TABLE IF EXISTS "+table+dropOption)
+		require.NoError(t, err)
+	}
+
+	migrator, err := migration.New(handler, backend)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+}
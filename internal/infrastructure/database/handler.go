@@ -0,0 +1,65 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// 接続プールの統計を返すハンドラー。NewHandlerが返すハンドラーはすべて実装する
+type StatsHandler interface {
+	Stats() sql.DBStats
+}
+
+// 接続できるかどうかを確認するハンドラー。NewHandlerが返すハンドラーはすべて実装する
+type PingHandler interface {
+	Ping(ctx context.Context) error
+}
+
+// 起動時の接続の確認のやり直しの設定
+type ConnectConfig struct {
+	Attempts int           // 接続を試みる回数の上限
+	Interval time.Duration // 失敗してから次に試みるまでの間隔
+}
+
+// 保存先（mysql, postgres, sqlite）のdsnのデータベースに接続し、リポジトリに設定するDialectとともに返す。
+// sqliteの場合のdsnはデータベースファイルのパス。起動直後でデータベースの準備ができていない場合に備えて、接続できるまでcfg.Attempts回までやり直し、
+// それでも接続できない場合は最後のエラーを返す
+func NewHandler(ctx context.Context, kind, dsn string, cfg ConnectConfig) (database.SqlHandler, database.Dialect, error) {
+	open, dialect := opener(kind)
+	for attempt := 1; ; attempt++ {
+		handler, err := open(dsn)
+		if err == nil {
+			slog.InfoContext(ctx, "✅ Successfully connected to the database", "repository", kind, "attempt", attempt)
+			return handler, dialect, nil
+		}
+		if attempt >= cfg.Attempts {
+			return nil, nil, fmt.Errorf("failed to connect to the %s database after %d attempts: %w", kind, attempt, err)
+		}
+
+		slog.WarnContext(ctx, "⚠️  データベースに接続できないため、やり直します", "repository", kind, "attempt", attempt, "max_attempts", cfg.Attempts, "retry_in", cfg.Interval, "error", err)
+		timer := time.NewTimer(cfg.Interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, fmt.Errorf("gave up connecting to the %s database: %w", kind, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// 保存先に接続する関数とDialect
+func opener(kind string) (func(dsn string) (database.SqlHandler, error), database.Dialect) {
+	switch kind {
+	case "sqlite":
+		return func(dsn string) (database.SqlHandler, error) { return OpenSQLite(dsn) }, database.SQLite
+	case "postgres":
+		return func(dsn string) (database.SqlHandler, error) { return OpenPostgres(dsn) }, database.Postgres
+	default:
+		return func(dsn string) (database.SqlHandler, error) { return OpenMySQL(dsn) }, database.MySQL
+	}
+}
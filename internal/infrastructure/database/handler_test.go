@@ -0,0 +1,52 @@
+package databaseInfra
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+func TestNewHandler(t *testing.T) {
+	handler, dialect, err := NewHandler(context.Background(), "sqlite", filepath.Join(t.TempDir(), "items.db"), ConnectConfig{Attempts: 1})
+	require.NoError(t, err)
+	defer handler.Close()
+	assert.Equal(t, database.SQLite, dialect)
+}
+
+// 接続を受け付けないアドレスのPostgreSQLの接続文字列
+func unreachablePostgresDSN(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+	return "postgres://user:password@" + addr + "/items?sslmode=disable"
+}
+
+func TestNewHandler_GivesUpAfterAttempts(t *testing.T) {
+	dsn := unreachablePostgresDSN(t)
+
+	start := time.Now()
+	_, _, err := NewHandler(context.Background(), "postgres", dsn, ConnectConfig{Attempts: 3, Interval: 20 * time.Millisecond})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "after 3 attempts")
+	// 試みの間に2回待つ
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestNewHandler_Canceled(t *testing.T) {
+	dsn := unreachablePostgresDSN(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := NewHandler(ctx, "postgres", dsn, ConnectConfig{Attempts: 10, Interval: time.Hour})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,99 @@
+package databaseInfra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/principal"
+)
+
+func TestImportJobRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	repo := &database.ImportJobRepository{SqlHandler: openTestSQLite(t), Dialect: database.SQLite}
+
+	job, err := repo.Create(ctx, entity.NewImportJob(true, false))
+	require.NoError(t, err)
+	assert.Equal(t, entity.ImportJobStatusQueued, job.Status)
+	assert.True(t, job.BestEffort)
+	assert.Empty(t, job.Errors)
+	assert.Nil(t, job.StartedAt)
+
+	t.Run("正常系: 開始して進捗と結果を記録し、終了後は変更しない", func(t *testing.T) {
+		require.NoError(t, repo.Start(ctx, job.ID))
+		require.NoError(t, repo.UpdateProgress(ctx, job.ID, 100))
+		found, err := repo.FindByID(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ImportJobStatusRunning, found.Status)
+		assert.Equal(t, 100, found.RowsProcessed)
+		assert.NotNil(t, found.StartedAt)
+
+		found.Status = entity.ImportJobStatusDone
+		found.RowsProcessed = 150
+		found.Succeeded = 149
+		found.Failed = 1
+		found.Errors = []entity.ImportRowError{{Row: 3, Message: "purchase_price must be an integer"}}
+		require.NoError(t, repo.Finish(ctx, found))
+
+		found.Status = entity.ImportJobStatusFailed
+		require.NoError(t, repo.Finish(ctx, found))
+		require.NoError(t, repo.UpdateProgress(ctx, job.ID, 1))
+
+		finished, err := repo.FindByID(ctx, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ImportJobStatusDone, finished.Status)
+		assert.Equal(t, 150, finished.RowsProcessed)
+		assert.Equal(t, 149, finished.Succeeded)
+		assert.Equal(t, []entity.ImportRowError{{Row: 3, Message: "purchase_price must be an integer"}}, finished.Errors)
+		assert.NotNil(t, finished.FinishedAt)
+	})
+
+	t.Run("正常系: 未完了のジョブのみ失敗にする", func(t *testing.T) {
+		queued, err := repo.Create(ctx, entity.NewImportJob(false, false))
+		require.NoError(t, err)
+
+		failed, err := repo.FailUnfinished(ctx, "interrupted")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), failed)
+
+		found, err := repo.FindByID(ctx, queued.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ImportJobStatusFailed, found.Status)
+		assert.Equal(t, "interrupted", found.Error)
+		assert.NotNil(t, found.FinishedAt)
+	})
+
+	t.Run("正常系: 保持期間を過ぎたジョブを削除する", func(t *testing.T) {
+		deleted, err := repo.DeleteFinishedBefore(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+
+		deleted, err = repo.DeleteFinishedBefore(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		_, err = repo.FindByID(ctx, job.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrImportJobNotFound)
+	})
+
+	t.Run("異常系: ほかのユーザーのジョブは見つからない", func(t *testing.T) {
+		alice := principal.NewContext(ctx, principal.Principal{UserID: 2})
+		bob := principal.NewContext(ctx, principal.Principal{UserID: 3})
+		owned, err := repo.Create(alice, entity.NewImportJob(false, true))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), owned.UserID)
+
+		_, err = repo.FindByID(bob, owned.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrImportJobNotFound)
+
+		found, err := repo.FindByID(alice, owned.ID)
+		require.NoError(t, err)
+		assert.Equal(t, owned.ID, found.ID)
+	})
+}
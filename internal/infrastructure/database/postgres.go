@@ -0,0 +1,145 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	_ "github.com/lib/pq"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+type PostgresHandler struct {
+	Conn *sql.DB
+}
+
+// dsnのデータベースに接続する
+func OpenPostgres(dsn string) (*PostgresHandler, error) {
+	conn, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &PostgresHandler{Conn: conn}, nil
+}
+
+func (h *PostgresHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	result, err := h.Conn.ExecContext(ctx, rebindPostgres(statement), args...)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlResult{result: result}, nil
+}
+
+func (h *PostgresHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	rows, err := h.Conn.QueryContext(ctx, rebindPostgres(statement), args...)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlRows{rows: rows}, nil
+}
+
+func (h *PostgresHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	row := h.Conn.QueryRowContext(ctx, rebindPostgres(statement), args...)
+	return &mysqlRow{row: row}
+}
+
+func (h *PostgresHandler) Begin(ctx context.Context) (database.Transaction, error) {
+	tx, err := h.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &postgresTx{tx: tx}, nil
+}
+
+func (h *PostgresHandler) Close() error {
+	if h.Conn != nil {
+		return h.Conn.Close()
+	}
+	return nil
+}
+
+// 接続プールの統計。接続数の指標に使う
+func (h *PostgresHandler) Stats() sql.DBStats {
+	return h.Conn.Stats()
+}
+
+// データベースに接続できるかどうかを確認する。準備状態の確認に使う
+func (h *PostgresHandler) Ping(ctx context.Context) error {
+	return h.Conn.PingContext(ctx)
+}
+
+type postgresTx struct {
+	tx *sql.Tx
+}
+
+func (t *postgresTx) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	result, err := t.tx.ExecContext(ctx, rebindPostgres(statement), args...)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlResult{result: result}, nil
+}
+
+func (t *postgresTx) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	rows, err := t.tx.QueryContext(ctx, rebindPostgres(statement), args...)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlRows{rows: rows}, nil
+}
+
+func (t *postgresTx) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	row := t.tx.QueryRowContext(ctx, rebindPostgres(statement), args...)
+	return &mysqlRow{row: row}
+}
+
+func (t *postgresTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *postgresTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// リポジトリのSQLのプレースホルダ?を、PostgreSQLの$1, $2, ...に置き換える。
+// 文字列リテラルと引用符で囲んだ識別子の中の?はそのまま残す
+func rebindPostgres(statement string) string {
+	if !strings.Contains(statement, "?") {
+		return statement
+	}
+
+	var b strings.Builder
+	b.Grow(len(statement) + 8)
+
+	var quote byte
+	n := 0
+	for i := 0; i < len(statement); i++ {
+		c := statement[i]
+		switch {
+		case quote != 0:
+			// 引用符を2つ重ねたエスケープは、閉じてすぐ開き直したものとして扱われる
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '?':
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
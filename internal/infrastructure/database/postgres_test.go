@@ -0,0 +1,27 @@
+package databaseInfra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebindPostgres(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		expected  string
+	}{
+		{"プレースホルダがない", "SELECT COUNT(*) FROM items", "SELECT COUNT(*) FROM items"},
+		{"順に番号を振る", "SELECT id FROM items WHERE id = ? AND version = ?", "SELECT id FROM items WHERE id = $1 AND version = $2"},
+		{"IN句", "DELETE FROM tags WHERE name IN (?, ?, ?)", "DELETE FROM tags WHERE name IN ($1, $2, $3)"},
+		{"文字列リテラル内の?は置き換えない", "SELECT '?', name FROM items WHERE notes = 'it''s ?' AND id = ?", "SELECT '?', name FROM items WHERE notes = 'it''s ?' AND id = $1"},
+		{"引用符で囲んだ識別子内の?は置き換えない", `SELECT "a?" FROM items WHERE id = ?`, `SELECT "a?" FROM items WHERE id = $1`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, rebindPostgres(tt.statement))
+		})
+	}
+}
@@ -4,11 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"os"
 
 	_ "github.com/go-sql-driver/mysql"
 
-	"Aicon-assignment/internal/infrastructure/config"
 	"Aicon-assignment/internal/interfaces/database"
 )
 
@@ -16,32 +14,20 @@ type MySqlHandler struct {
 	Conn *sql.DB
 }
 
-func NewSqlHandler() database.SqlHandler {
-	dsn := config.GetDSN()
+// dsnのデータベースに接続する
+func OpenMySQL(dsn string) (*MySqlHandler, error) {
 	conn, err := sql.Open("mysql", dsn)
 	if err != nil {
-		panic(fmt.Sprintf("❌ Failed to connect to database: %v", err))
+		return nil, err
 	}
 
 	// DB接続が確立できているかを確認
 	if err := conn.Ping(); err != nil {
-		panic(fmt.Sprintf("❌ Failed to ping database: %v", err))
-	}
-
-	fmt.Println("✅ Successfully connected to the database!")
-
-	sqlBytes, err := os.ReadFile("sql/init.sql")
-	if err != nil {
-		fmt.Printf("❌ Failed to read init.sql: %v\n", err)
-	} else {
-		if _, err := conn.Exec(string(sqlBytes)); err != nil {
-			fmt.Printf("❌ Failed to execute init.sql: %v\n", err)
-		} else {
-			fmt.Println("✅ Successfully initialized database from init.sql")
-		}
+		conn.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &MySqlHandler{Conn: conn}
+	return &MySqlHandler{Conn: conn}, nil
 }
 
 func (h *MySqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
@@ -65,6 +51,14 @@ func (h *MySqlHandler) QueryRow(ctx context.Context, statement string, args ...i
 	return &mysqlRow{row: row}
 }
 
+func (h *MySqlHandler) Begin(ctx context.Context) (database.Transaction, error) {
+	tx, err := h.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlTx{tx: tx}, nil
+}
+
 func (h *MySqlHandler) Close() error {
 	if h.Conn != nil {
 		return h.Conn.Close()
@@ -72,6 +66,49 @@ func (h *MySqlHandler) Close() error {
 	return nil
 }
 
+// 接続プールの統計。接続数の指標に使う
+func (h *MySqlHandler) Stats() sql.DBStats {
+	return h.Conn.Stats()
+}
+
+// データベースに接続できるかどうかを確認する。準備状態の確認に使う
+func (h *MySqlHandler) Ping(ctx context.Context) error {
+	return h.Conn.PingContext(ctx)
+}
+
+type mysqlTx struct {
+	tx *sql.Tx
+}
+
+func (t *mysqlTx) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	result, err := t.tx.ExecContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlResult{result: result}, nil
+}
+
+func (t *mysqlTx) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	rows, err := t.tx.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlRows{rows: rows}, nil
+}
+
+func (t *mysqlTx) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	row := t.tx.QueryRowContext(ctx, statement, args...)
+	return &mysqlRow{row: row}
+}
+
+func (t *mysqlTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *mysqlTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
 type mysqlResult struct {
 	result sql.Result
 }
@@ -0,0 +1,141 @@
+package databaseInfra
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"modernc.org/sqlite"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// SQLiteに保存する時刻の形式。CURRENT_TIMESTAMPと同じUTCの形式にして、文字列のまま大小を比較できるようにする
+const sqliteTimeFormat = "2006-01-02 15:04:05.999999999"
+
+func init() {
+	// SQLite組み込みのLOWERはASCIIのみを小文字にするため、MySQLと同じくUnicodeの大文字も小文字にする
+	sqlite.MustRegisterDeterministicScalarFunction("lower", 1, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		switch v := args[0].(type) {
+		case string:
+			return strings.ToLower(v), nil
+		case []byte:
+			return strings.ToLower(string(v)), nil
+		default:
+			return v, nil
+		}
+	})
+}
+
+type SQLiteHandler struct {
+	Conn *sql.DB
+}
+
+// pathのデータベースファイルを開く
+func OpenSQLite(path string) (*SQLiteHandler, error) {
+	// 外部キー制約はSQLiteの既定では無効のため、接続ごとに有効にする
+	dsn := "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+	conn, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLiteは書き込みをデータベース単位で直列化するため、接続を1つにしてロックの競合を避ける
+	conn.SetMaxOpenConns(1)
+
+	return &SQLiteHandler{Conn: conn}, nil
+}
+
+func (h *SQLiteHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	result, err := h.Conn.ExecContext(ctx, statement, sqliteArgs(args)...)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlResult{result: result}, nil
+}
+
+func (h *SQLiteHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	rows, err := h.Conn.QueryContext(ctx, statement, sqliteArgs(args)...)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlRows{rows: rows}, nil
+}
+
+func (h *SQLiteHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	row := h.Conn.QueryRowContext(ctx, statement, sqliteArgs(args)...)
+	return &mysqlRow{row: row}
+}
+
+func (h *SQLiteHandler) Begin(ctx context.Context) (database.Transaction, error) {
+	tx, err := h.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteTx{tx: tx}, nil
+}
+
+func (h *SQLiteHandler) Close() error {
+	if h.Conn != nil {
+		return h.Conn.Close()
+	}
+	return nil
+}
+
+// 接続プールの統計。接続数の指標に使う
+func (h *SQLiteHandler) Stats() sql.DBStats {
+	return h.Conn.Stats()
+}
+
+// データベースに接続できるかどうかを確認する。準備状態の確認に使う
+func (h *SQLiteHandler) Ping(ctx context.Context) error {
+	return h.Conn.PingContext(ctx)
+}
+
+type sqliteTx struct {
+	tx *sql.Tx
+}
+
+func (t *sqliteTx) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	result, err := t.tx.ExecContext(ctx, statement, sqliteArgs(args)...)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlResult{result: result}, nil
+}
+
+func (t *sqliteTx) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	rows, err := t.tx.QueryContext(ctx, statement, sqliteArgs(args)...)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlRows{rows: rows}, nil
+}
+
+func (t *sqliteTx) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	row := t.tx.QueryRowContext(ctx, statement, sqliteArgs(args)...)
+	return &mysqlRow{row: row}
+}
+
+func (t *sqliteTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *sqliteTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// 時刻の引数をsqliteTimeFormatの文字列に変換する
+func sqliteArgs(args []interface{}) []interface{} {
+	converted := make([]interface{}, len(args))
+	for i, arg := range args {
+		if t, ok := arg.(time.Time); ok {
+			converted[i] = t.UTC().Format(sqliteTimeFormat)
+			continue
+		}
+		converted[i] = arg
+	}
+	return converted
+}
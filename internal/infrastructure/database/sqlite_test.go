@@ -0,0 +1,27 @@
+package databaseInfra
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/infrastructure/migration"
+)
+
+// テストごとに一時ディレクトリにデータベースファイルを作成し、マイグレーションを適用する
+func openTestSQLite(t testing.TB) *SQLiteHandler {
+	t.Helper()
+
+	handler, err := OpenSQLite(filepath.Join(t.TempDir(), "items.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { handler.Close() })
+
+	migrator, err := migration.New(handler, "sqlite")
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	return handler
+}
@@ -0,0 +1,37 @@
+package databaseInfra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/principal"
+)
+
+func TestTagRepository_FindAllWithCounts_OwnerScope(t *testing.T) {
+	ctx := context.Background()
+	alice := principal.NewContext(ctx, principal.Principal{UserID: 2})
+	bob := principal.NewContext(ctx, principal.Principal{UserID: 3})
+	handler := openTestSQLite(t)
+	items := &database.ItemRepository{SqlHandler: handler, Dialect: database.SQLite}
+	tags := &database.TagRepository{SqlHandler: handler}
+
+	_, err := items.Create(alice, &entity.Item{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), Tags: []string{"正規品", "限定"}})
+	require.NoError(t, err)
+	_, err = items.Create(bob, &entity.Item{Name: "バーキン", Category: "バッグ", Brand: "HERMÈS", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), Tags: []string{"正規品"}})
+	require.NoError(t, err)
+
+	// ほかのユーザーのアイテムにのみ付いているタグは含めず、件数も自分のアイテムのみを数える
+	found, err := tags.FindAllWithCounts(bob)
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.TagCount{{Name: "正規品", Count: 1}}, found)
+
+	// ユーザーとして認証していない場合はすべてのユーザーのアイテムを数える
+	found, err = tags.FindAllWithCounts(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.TagCount{{Name: "正規品", Count: 2}, {Name: "限定", Count: 1}}, found)
+}
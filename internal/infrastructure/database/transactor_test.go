@@ -0,0 +1,226 @@
+package databaseInfra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/infrastructure/eventbus"
+	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 各テーブルの行数
+func countRows(ctx context.Context, t *testing.T, handler database.SqlHandler) map[string]int {
+	t.Helper()
+
+	counts := make(map[string]int)
+	for _, table := range []string{"items", "item_histories", "categories"} {
+		var count int
+		require.NoError(t, handler.QueryRow(ctx, "SELECT COUNT(*) FROM "+table).Scan(&count))
+		counts[table] = count
+	}
+	return counts
+}
+
+func newTestItem(t testing.TB, name string) *entity.Item {
+	t.Helper()
+
+	item, err := entity.NewItem(entity.NewItemInput{
+		Name:          name,
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: 1500000,
+		Currency:      "JPY",
+		PurchaseDate:  entity.MustParsePurchaseDate("2023-01-15"),
+		Categories:    entity.NewCategorySet("時計"),
+	})
+	require.NoError(t, err)
+	return item
+}
+
+// 途中で失敗した場合は、リポジトリが個別に開始したトランザクションの書き込みも含めてすべて取り消す
+func TestTransactor_RollsBackEveryWrite(t *testing.T) {
+	handler := openTestSQLite(t)
+	transactor := &database.Transactor{SqlHandler: handler}
+	items := &database.ItemRepository{SqlHandler: transactor, Dialect: database.SQLite}
+	categories := &database.CategoryRepository{SqlHandler: transactor, Dialect: database.SQLite}
+
+	ctx := context.Background()
+	before := countRows(context.Background(), t, handler)
+	failure := errors.New("failure")
+
+	err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+		created, err := items.Create(ctx, newTestItem(t, "ロレックス デイトナ"))
+		require.NoError(t, err)
+
+		_, err = items.CreateMany(ctx, []*entity.Item{newTestItem(t, "ロレックス サブマリーナ"), newTestItem(t, "ロレックス GMTマスター")})
+		require.NoError(t, err)
+
+		category, err := entity.NewCategory("楽器", "instruments", "Instruments")
+		require.NoError(t, err)
+		_, err = categories.Create(ctx, category)
+		require.NoError(t, err)
+
+		change, err := items.Delete(ctx, created.ID)
+		require.NoError(t, err)
+		require.NoError(t, items.CreateHistory(ctx, created.ID, entity.HistoryActionDelete, change.Before, change.After))
+
+		// トランザクション内では書き込みが見える
+		assert.Equal(t, before["items"]+3, countRows(ctx, t, transactor)["items"])
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	assert.Equal(t, before, countRows(context.Background(), t, handler))
+}
+
+func TestTransactor_Commits(t *testing.T) {
+	handler := openTestSQLite(t)
+	transactor := &database.Transactor{SqlHandler: handler}
+	items := &database.ItemRepository{SqlHandler: transactor, Dialect: database.SQLite}
+
+	ctx := context.Background()
+	before := countRows(context.Background(), t, handler)
+
+	err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+		created, err := items.Create(ctx, newTestItem(t, "ロレックス デイトナ"))
+		if err != nil {
+			return err
+		}
+		// ネストしたWithinTxは外側のトランザクションに含める
+		return transactor.WithinTx(ctx, func(ctx context.Context) error {
+			change, err := items.Delete(ctx, created.ID)
+			if err != nil {
+				return err
+			}
+			return items.CreateHistory(ctx, created.ID, entity.HistoryActionDelete, change.Before, change.After)
+		})
+	})
+	require.NoError(t, err)
+
+	after := countRows(context.Background(), t, handler)
+	assert.Equal(t, before["items"]+1, after["items"])
+	assert.Equal(t, before["item_histories"]+1, after["item_histories"])
+}
+
+// サーバーと同じく、変更の履歴をイベントの処理で記録するusecase
+func newItemUsecase(items usecase.ItemRepository, categories usecase.CategoryRepository, transactor usecase.Transactor) usecase.ItemUsecase {
+	bus := eventbus.New()
+	bus.Subscribe(usecase.NewHistoryHandler(items))
+	return usecase.NewItemUsecase(items, categories, nil, nil, transactor, bus)
+}
+
+// 登録したアイテムの取得に失敗するリポジトリ
+type failingFindByIDsRepository struct {
+	usecase.ItemRepository
+}
+
+func (r *failingFindByIDsRepository) FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	return nil, domainErrors.ErrDatabaseError
+}
+
+// 一括登録で登録後の取得に失敗した場合は、登録したアイテムも残さない
+func TestItemUsecase_BulkCreateItems_RollsBack(t *testing.T) {
+	handler := openTestSQLite(t)
+	transactor := &database.Transactor{SqlHandler: handler}
+	items := &failingFindByIDsRepository{&database.ItemRepository{SqlHandler: transactor, Dialect: database.SQLite}}
+	categories := &database.CategoryRepository{SqlHandler: transactor, Dialect: database.SQLite}
+
+	before := countRows(context.Background(), t, handler)
+
+	created, err := usecase.NewItemUsecase(items, categories, nil, nil, transactor, nil).BulkCreateItems(context.Background(), []usecase.CreateItemInput{
+		{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"},
+		{Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2000000, PurchaseDate: "2023-02-20"},
+	})
+	assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	assert.Nil(t, created)
+
+	assert.Equal(t, before, countRows(context.Background(), t, handler))
+}
+
+// 同じアイテムへの2つの部分更新を同時に実行しても、どちらの変更も失われない。
+// 古いバージョンで更新した側は競合となるため、クライアントと同様に競合時の現在のバージョンで更新し直す
+func TestItemUsecase_UpdateItem_Concurrent(t *testing.T) {
+	handler := openTestSQLite(t)
+	transactor := &database.Transactor{SqlHandler: handler}
+	items := &database.ItemRepository{SqlHandler: transactor, Dialect: database.SQLite}
+	categories := &database.CategoryRepository{SqlHandler: transactor, Dialect: database.SQLite}
+	uc := newItemUsecase(items, categories, transactor)
+
+	ctx := context.Background()
+	created, err := items.Create(ctx, newTestItem(t, "ロレックス デイトナ"))
+	require.NoError(t, err)
+	before := countRows(ctx, t, handler)
+
+	patch := func(input usecase.UpdateItemInput) error {
+		version := created.Version
+		for attempt := 0; attempt < 5; attempt++ {
+			input.Version = &version
+			_, err := uc.UpdateItem(ctx, created.ID, input)
+			var conflict *domainErrors.VersionConflictError
+			if !errors.As(err, &conflict) {
+				return err
+			}
+			version = conflict.CurrentVersion
+		}
+		return errors.New("too many version conflicts")
+	}
+
+	name, notes := "ロレックス デイトナ 116500LN", "付属品あり"
+	inputs := []usecase.UpdateItemInput{{Name: &name}, {Notes: &notes}}
+	errs := make(chan error, len(inputs))
+	start := make(chan struct{})
+	for _, input := range inputs {
+		go func() {
+			<-start
+			errs <- patch(input)
+		}()
+	}
+	close(start)
+	for range inputs {
+		require.NoError(t, <-errs)
+	}
+
+	updated, err := items.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, name, updated.Name)
+	assert.Equal(t, notes, updated.Notes)
+	assert.Equal(t, created.Version+2, updated.Version)
+	assert.Equal(t, before["item_histories"]+2, countRows(ctx, t, handler)["item_histories"])
+}
+
+// 厳密モードの一括削除で見つからないIDがある場合は、削除した分も取り消す。best_effortの場合は削除できた分を残す
+func TestItemUsecase_BulkDeleteItems(t *testing.T) {
+	handler := openTestSQLite(t)
+	transactor := &database.Transactor{SqlHandler: handler}
+	items := &database.ItemRepository{SqlHandler: transactor, Dialect: database.SQLite}
+	categories := &database.CategoryRepository{SqlHandler: transactor, Dialect: database.SQLite}
+	uc := newItemUsecase(items, categories, transactor)
+
+	ctx := context.Background()
+	created, err := items.Create(ctx, newTestItem(t, "ロレックス デイトナ"))
+	require.NoError(t, err)
+	before := countRows(ctx, t, handler)
+
+	_, err = uc.BulkDeleteItems(ctx, []int64{created.ID, 999999}, false)
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	_, err = items.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, before, countRows(ctx, t, handler))
+
+	results, err := uc.BulkDeleteItems(ctx, []int64{created.ID, 999999}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []usecase.BulkDeleteResult{
+		{ID: created.ID, Status: usecase.BulkDeleteStatusDeleted},
+		{ID: 999999, Status: usecase.BulkDeleteStatusNotFound},
+	}, results)
+	_, err = items.FindByID(ctx, created.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrNotFound)
+	assert.Equal(t, before["item_histories"]+1, countRows(ctx, t, handler)["item_histories"])
+}
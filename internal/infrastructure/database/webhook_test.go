@@ -0,0 +1,73 @@
+package databaseInfra
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+func TestWebhookRepository_SQLite(t *testing.T) {
+	ctx := context.Background()
+	repo := &database.WebhookRepository{SqlHandler: openTestSQLite(t), Dialect: database.SQLite}
+
+	webhook, err := repo.Create(ctx, &entity.Webhook{URL: "https://example.com/hooks", Secret: "0123456789abcdef", Enabled: true})
+	require.NoError(t, err)
+	assert.True(t, webhook.Enabled)
+	assert.Equal(t, "0123456789abcdef", webhook.Secret)
+
+	t.Run("正常系: 失敗が上限に達すると無効になり、有効に戻すと回数を0にする", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			require.NoError(t, repo.RecordResult(ctx, webhook.ID, false, 2))
+		}
+		found, err := repo.FindByID(ctx, webhook.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, found.FailureCount)
+		assert.False(t, found.Enabled)
+
+		enabled, err := repo.Enable(ctx, webhook.ID)
+		require.NoError(t, err)
+		assert.True(t, enabled.Enabled)
+		assert.Zero(t, enabled.FailureCount)
+	})
+
+	t.Run("正常系: 送信の記録を新しい順に返し、応答がない場合のステータスはnil", func(t *testing.T) {
+		status := http.StatusBadGateway
+		require.NoError(t, repo.CreateDelivery(ctx, &entity.WebhookDelivery{WebhookID: webhook.ID, EventID: "e1", Event: entity.WebhookEventItemCreated, ItemID: 1, Attempt: 1, StatusCode: &status, Error: "unexpected status 502"}))
+		require.NoError(t, repo.CreateDelivery(ctx, &entity.WebhookDelivery{WebhookID: webhook.ID, EventID: "e1", Event: entity.WebhookEventItemCreated, ItemID: 1, Attempt: 2, Error: "connection refused", DurationMs: 3}))
+
+		deliveries, err := repo.FindDeliveries(ctx, webhook.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		assert.Equal(t, 2, deliveries[0].Attempt)
+		assert.Nil(t, deliveries[0].StatusCode)
+		assert.Equal(t, "connection refused", deliveries[0].Error)
+		require.NotNil(t, deliveries[1].StatusCode)
+		assert.Equal(t, http.StatusBadGateway, *deliveries[1].StatusCode)
+		assert.False(t, deliveries[1].Succeeded)
+
+		deleted, err := repo.DeleteDeliveriesBefore(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+	})
+
+	t.Run("正常系: 削除したWebhookの送信の記録も削除する", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, webhook.ID))
+
+		deliveries, err := repo.FindDeliveries(ctx, webhook.ID, 10)
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+
+		assert.ErrorIs(t, repo.Delete(ctx, webhook.ID), domainErrors.ErrWebhookNotFound)
+		_, err = repo.Enable(ctx, webhook.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrWebhookNotFound)
+		assert.ErrorIs(t, repo.CreateDelivery(ctx, &entity.WebhookDelivery{WebhookID: webhook.ID}), domainErrors.ErrWebhookNotFound)
+	})
+}
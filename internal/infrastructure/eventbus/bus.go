@@ -0,0 +1,46 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 発行されたイベントを、同じゴルーチンで登録順にハンドラーへ渡すusecase.EventPublisher。
+// ハンドラーは起動時にSubscribeで登録し、イベントの発行を始めた後は登録しない
+type Bus struct {
+	subscriptions []subscription
+}
+
+type subscription struct {
+	handler usecase.EventHandler
+	types   map[entity.EventType]bool // 空の場合はすべての種類
+}
+
+func New() *Bus {
+	return &Bus{}
+}
+
+// handlerを登録する。typesを指定した場合はその種類のイベントのみを渡す
+func (b *Bus) Subscribe(handler usecase.EventHandler, types ...entity.EventType) {
+	s := subscription{handler: handler, types: make(map[entity.EventType]bool, len(types))}
+	for _, t := range types {
+		s.types[t] = true
+	}
+	b.subscriptions = append(b.subscriptions, s)
+}
+
+// 登録順にハンドラーを呼び出し、最初のエラーで打ち切って返す
+func (b *Bus) Publish(ctx context.Context, event entity.Event) error {
+	for _, s := range b.subscriptions {
+		if len(s.types) > 0 && !s.types[event.Type] {
+			continue
+		}
+		if err := s.handler.HandleEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to handle %s event: %w", event.Type, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 受け取ったイベントの種類を名前つきで記録し、errを返すハンドラー
+type recordingHandler struct {
+	name     string
+	received *[]string
+	err      error
+}
+
+func (h recordingHandler) HandleEvent(ctx context.Context, event entity.Event) error {
+	*h.received = append(*h.received, h.name+":"+string(event.Type))
+	return h.err
+}
+
+func TestBus_Publish(t *testing.T) {
+	t.Run("正常系: 登録順に、購読した種類のイベントのみを渡す", func(t *testing.T) {
+		var received []string
+		bus := New()
+		bus.Subscribe(recordingHandler{name: "all", received: &received})
+		bus.Subscribe(recordingHandler{name: "deleted", received: &received}, entity.EventItemDeleted, entity.EventItemHardDeleted)
+
+		assert.NoError(t, bus.Publish(context.Background(), entity.Event{Type: entity.EventItemCreated}))
+		assert.NoError(t, bus.Publish(context.Background(), entity.Event{Type: entity.EventItemDeleted}))
+
+		assert.Equal(t, []string{"all:item.created", "all:item.deleted", "deleted:item.deleted"}, received)
+	})
+
+	t.Run("異常系: エラーを返したハンドラーで打ち切る", func(t *testing.T) {
+		var received []string
+		failure := errors.New("failure")
+		bus := New()
+		bus.Subscribe(recordingHandler{name: "first", received: &received, err: failure})
+		bus.Subscribe(recordingHandler{name: "second", received: &received})
+
+		err := bus.Publish(context.Background(), entity.Event{Type: entity.EventItemUpdated})
+
+		assert.ErrorIs(t, err, failure)
+		assert.Equal(t, []string{"first:item.updated"}, received)
+	})
+}
@@ -0,0 +1,35 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+)
+
+// 固定レートで換算するExchangeRateProvider
+type StaticRateProvider struct {
+	base  string
+	rates map[string]float64 // 1単位あたりの基準通貨での価値
+}
+
+func NewStaticRateProvider(base string, rates map[string]float64) *StaticRateProvider {
+	copied := make(map[string]float64, len(rates))
+	for currency, rate := range rates {
+		copied[currency] = rate
+	}
+	return &StaticRateProvider{base: base, rates: copied}
+}
+
+func (p *StaticRateProvider) BaseCurrency() string {
+	return p.base
+}
+
+func (p *StaticRateProvider) Rate(ctx context.Context, currency string) (float64, error) {
+	if currency == p.base {
+		return 1, nil
+	}
+	rate, ok := p.rates[currency]
+	if !ok {
+		return 0, fmt.Errorf("no exchange rate for %s", currency)
+	}
+	return rate, nil
+}
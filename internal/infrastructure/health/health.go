@@ -0,0 +1,75 @@
+// 依存先（データベースなど）に接続できるかどうかの確認
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// 依存先に接続できるかどうかを確認し、できない場合はエラーを返す
+type Probe func(ctx context.Context) error
+
+// 接続できるかどうかを確認できる依存先。Pingをそのまま確認に使う
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// probeの結果をttlの間保持して返す確認。
+// 準備状態の確認は数秒ごとに呼ばれるため、呼び出しごとにデータベースに問い合わせないようにする。
+// 依存先の応答が遅い場合に接続を使い続けないよう、同時に実行するprobeは1つにまとめ、timeoutで打ち切る
+type Cached struct {
+	probe   Probe
+	ttl     time.Duration
+	timeout time.Duration
+	now     func() time.Time
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	err       error
+	running   chan struct{} // 実行中のprobeが終わると閉じる。実行中でない場合はnil
+}
+
+func NewCached(probe Probe, ttl, timeout time.Duration) *Cached {
+	return &Cached{probe: probe, ttl: ttl, timeout: timeout, now: time.Now}
+}
+
+// 保持している結果か、期限を過ぎている場合はprobeを実行した結果を返す。
+// 他の呼び出しがprobeを実行中の場合はその結果を待つ。ctxが終わった場合は待たずにctxのエラーを返す
+func (c *Cached) Check(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.checkedAt.IsZero() && c.now().Sub(c.checkedAt) < c.ttl {
+		err := c.err
+		c.mu.Unlock()
+		return err
+	}
+	if c.running == nil {
+		c.running = make(chan struct{})
+		go c.run(c.running)
+	}
+	running := c.running
+	c.mu.Unlock()
+
+	select {
+	case <-running:
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// probeを実行して結果を保持する。結果を待つ呼び出しが他にもあるため、呼び出し元のctxではなく自身の制限時間で実行する
+func (c *Cached) run(done chan struct{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	err := c.probe(ctx)
+
+	c.mu.Lock()
+	c.err = err
+	c.checkedAt = c.now()
+	c.running = nil
+	c.mu.Unlock()
+	close(done)
+}
@@ -0,0 +1,87 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCached_KeepsResultForTTL(t *testing.T) {
+	var calls atomic.Int32
+	failing := errors.New("connection refused")
+	cached := NewCached(func(ctx context.Context) error {
+		calls.Add(1)
+		return failing
+	}, time.Minute, time.Second)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cached.now = func() time.Time { return now }
+
+	assert.ErrorIs(t, cached.Check(context.Background()), failing)
+	assert.ErrorIs(t, cached.Check(context.Background()), failing)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// 期限を過ぎると確認し直す
+	now = now.Add(time.Minute)
+	assert.ErrorIs(t, cached.Check(context.Background()), failing)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCached_SharesRunningProbe(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	cached := NewCached(func(ctx context.Context) error {
+		calls.Add(1)
+		<-release
+		return nil
+	}, time.Minute, time.Second)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = cached.Check(context.Background())
+		}(i)
+	}
+	// すべての呼び出しがprobeの結果を待ち始めるまで待つ
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCached_Timeout(t *testing.T) {
+	cached := NewCached(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, time.Minute, 20*time.Millisecond)
+
+	start := time.Now()
+	err := cached.Check(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestCached_CallerCanceled(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	cached := NewCached(func(ctx context.Context) error {
+		<-release
+		return nil
+	}, time.Minute, time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, cached.Check(ctx), context.DeadlineExceeded)
+}
@@ -0,0 +1,79 @@
+package importjob
+
+import (
+	"context"
+	"sync"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+type Config struct {
+	Workers   int // 同時に実行するジョブの数
+	QueueSize int // 実行待ちのジョブの上限
+}
+
+// CSVインポートのジョブを上限つきのキューに入れ、Runで起動したワーカーが実行するusecase.ImportJobQueue
+type Runner struct {
+	cfg   Config
+	tasks chan usecase.ImportTask
+}
+
+func NewRunner(cfg Config) *Runner {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 1
+	}
+
+	return &Runner{
+		cfg:   cfg,
+		tasks: make(chan usecase.ImportTask, cfg.QueueSize),
+	}
+}
+
+// ジョブをキューに入れてすぐに戻る。キューが一杯の場合はErrImportQueueFullを返す
+func (r *Runner) Enqueue(task usecase.ImportTask) error {
+	select {
+	case r.tasks <- task:
+		return nil
+	default:
+		return domainErrors.ErrImportQueueFull
+	}
+}
+
+// ctxがキャンセルされるまでジョブをrunで実行する。キャンセル後は実行中のジョブの終了を待って戻り、
+// キューに残ったジョブは実行せずに一時ファイルを削除する
+func (r *Runner) Run(ctx context.Context, run func(ctx context.Context, task usecase.ImportTask)) {
+	var wg sync.WaitGroup
+	for i := 0; i < r.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case task := <-r.tasks:
+					// キャンセルと同時に取り出した場合も実行しない
+					if ctx.Err() != nil {
+						task.Discard()
+						return
+					}
+					run(ctx, task)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for {
+		select {
+		case task := <-r.tasks:
+			task.Discard()
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package importjob
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestRunner_Enqueue(t *testing.T) {
+	r := NewRunner(Config{QueueSize: 1})
+
+	require.NoError(t, r.Enqueue(usecase.ImportTask{JobID: 1}))
+	assert.ErrorIs(t, r.Enqueue(usecase.ImportTask{JobID: 2}), domainErrors.ErrImportQueueFull)
+}
+
+func TestRunner_Run(t *testing.T) {
+	r := NewRunner(Config{Workers: 2, QueueSize: 10})
+
+	var mu sync.Mutex
+	var ran []int64
+	started := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx, func(ctx context.Context, task usecase.ImportTask) {
+			mu.Lock()
+			ran = append(ran, task.JobID)
+			mu.Unlock()
+			if task.JobID == 3 {
+				// 終了するまで実行を続けるジョブ
+				close(started)
+				<-ctx.Done()
+			}
+		})
+		close(done)
+	}()
+
+	for id := int64(1); id <= 3; id++ {
+		require.NoError(t, r.Enqueue(usecase.ImportTask{JobID: id}))
+	}
+	<-started
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(ran) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	// キャンセルすると実行中のジョブの終了を待って戻る
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+	assert.ElementsMatch(t, []int64{1, 2, 3}, ran)
+}
+
+func TestRunner_Run_DiscardsQueuedTasks(t *testing.T) {
+	r := NewRunner(Config{Workers: 1, QueueSize: 1})
+	file := filepath.Join(t.TempDir(), "items.csv")
+	require.NoError(t, os.WriteFile(file, []byte("name\n"), 0o600))
+	require.NoError(t, r.Enqueue(usecase.ImportTask{JobID: 1, File: file}))
+
+	// 終了後に起動した場合、キューに残ったジョブは実行せず一時ファイルを削除する
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r.Run(ctx, func(ctx context.Context, task usecase.ImportTask) {
+		t.Errorf("task %d should not run", task.JobID)
+	})
+
+	assert.NoFileExists(t, file)
+}
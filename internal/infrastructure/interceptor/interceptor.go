@@ -0,0 +1,138 @@
+// gRPCのサーバーに共通のインターセプター。HTTPのミドルウェアと同じく、リクエストID・アクセスログ・認証・panicの回復を行う
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/infrastructure/logging"
+	"Aicon-assignment/internal/infrastructure/middleware"
+	"Aicon-assignment/internal/interfaces/rpc"
+	"Aicon-assignment/internal/principal"
+	"Aicon-assignment/internal/requestid"
+)
+
+// リクエストIDを受け取り、返すメタデータのキー。gRPCのメタデータのキーは小文字
+var requestIDKey = strings.ToLower(requestid.Header)
+
+// メタデータのx-request-idのIDか、ない場合や使えない値の場合は新しく生成したIDをctxに設定し、
+// レスポンスのヘッダーのx-request-idに返すインターセプター。ログにはctxのIDを付ける
+func RequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		id := first(ctx, requestIDKey)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+
+		if err := grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id)); err != nil {
+			slog.WarnContext(ctx, "⚠️  レスポンスにリクエストIDを設定できませんでした", "error", err)
+		}
+		return handler(requestid.NewContext(ctx, id), req)
+	}
+}
+
+// 呼び出しごとにメソッド・ステータスコード・処理時間をログに出すインターセプター。
+// ctxにメソッドを付けるため、処理中のログにも同じ属性が付く。認証の失敗も記録するよう、認証より外側で使う
+func Logging() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx = logging.With(ctx, "rpc_method", info.FullMethod)
+
+		res, err := handler(ctx, req)
+
+		attrs := []slog.Attr{
+			slog.String("code", status.Code(err).String()),
+			slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+		}
+		slog.LogAttrs(ctx, slog.LevelInfo, "rpc", attrs...)
+		return res, err
+	}
+}
+
+// メタデータのauthorization: Bearer <credential>かx-api-keyのAPIキーまたはユーザーのアクセストークンを照合し、
+// 認証できない場合はUnauthenticatedを返すインターセプター。照合の規則はHTTPのmiddleware.Authと同じ。
+// 認証したクライアントはprincipalとしてctxに設定し、APIキーのラベルかユーザーのIDをその後のログに付ける
+func Auth(apiKeys middleware.APIKeyAuthenticator, accessTokens middleware.AccessTokenAuthenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		value, isAPIKey := credential(ctx)
+		if isAPIKey {
+			apiKey, err := apiKeys.AuthenticateAPIKey(ctx, value)
+			if err != nil {
+				return nil, unauthenticated(ctx, err, "failed to authenticate api key")
+			}
+			ctx = principal.NewContext(ctx, principal.Principal{APIKeyID: apiKey.ID, APIKeyLabel: apiKey.Label, Role: apiKey.Role})
+			ctx = logging.With(ctx, "api_key", apiKey.Label)
+		} else {
+			claims, err := accessTokens.AuthenticateAccessToken(ctx, value)
+			if err != nil {
+				return nil, unauthenticated(ctx, err, "failed to authenticate access token")
+			}
+			ctx = principal.NewContext(ctx, principal.Principal{UserID: claims.UserID, Role: claims.Role})
+			ctx = logging.With(ctx, "user_id", claims.UserID)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// 認証に失敗した呼び出しへの応答。照合できない場合（保存先の障害など）は認証の失敗とせずInternalを返す
+func unauthenticated(ctx context.Context, err error, message string) error {
+	if !errors.Is(err, domainErrors.ErrUnauthenticated) {
+		return rpc.Error(ctx, err, message)
+	}
+	return status.Error(codes.Unauthenticated, "a valid API key or access token is required")
+}
+
+// メタデータの認証情報と、それをAPIキーとして照合するかどうか。authorizationを優先し、どちらもない場合は空文字列のアクセストークン
+func credential(ctx context.Context) (string, bool) {
+	if scheme, value, ok := strings.Cut(first(ctx, "authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		value = strings.TrimSpace(value)
+		return value, strings.HasPrefix(value, entity.APIKeyPrefix)
+	}
+	if key := first(ctx, strings.ToLower(middleware.HeaderAPIKey)); key != "" {
+		return key, true
+	}
+	return "", false
+}
+
+// ハンドラーのpanicを回復し、スタックトレースをリクエストIDとともにログに出してInternalを返すインターセプター。
+// アクセスログにInternalとして記録されるよう、最も内側で使う
+func Recover(observer middleware.PanicObserver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, returnErr error) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			if observer != nil {
+				observer.ObservePanic()
+			}
+			slog.ErrorContext(ctx, "ハンドラーでpanicが発生しました",
+				"panic", fmt.Sprint(recovered),
+				"stack", string(debug.Stack()),
+			)
+			res, returnErr = nil, status.Error(codes.Internal, "internal server error")
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// 受信したメタデータのkeyの最初の値。ない場合は空文字列
+func first(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
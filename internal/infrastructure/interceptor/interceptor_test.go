@@ -0,0 +1,144 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/rpc"
+	"Aicon-assignment/internal/interfaces/rpc/itemspb"
+	"Aicon-assignment/internal/principal"
+	"Aicon-assignment/internal/requestid"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 呼び出し元のリクエストIDを名前に、ユーザーのIDを所有者にしたアイテムを返すユースケース。IDが0の場合はpanicする
+type echoUsecase struct {
+	usecase.ItemUsecase
+}
+
+func (echoUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	if id == 0 {
+		panic("unexpected id")
+	}
+	p, _ := principal.From(ctx)
+	return &entity.Item{ID: id, Name: requestid.From(ctx), UserID: p.UserID}, nil
+}
+
+// "aicon_valid"のみを認証するAPIKeyAuthenticator
+type stubAPIKeys struct{}
+
+func (stubAPIKeys) AuthenticateAPIKey(ctx context.Context, key string) (*entity.APIKey, error) {
+	if key != entity.APIKeyPrefix+"valid" {
+		return nil, domainErrors.ErrUnauthenticated
+	}
+	return &entity.APIKey{ID: 1, Label: "test"}, nil
+}
+
+// "token"のみをユーザー7のトークンとして認証し、"broken"は照合できないエラーにするAccessTokenAuthenticator
+type stubAccessTokens struct{}
+
+func (stubAccessTokens) AuthenticateAccessToken(ctx context.Context, token string) (*usecase.AccessTokenClaims, error) {
+	switch token {
+	case "token":
+		return &usecase.AccessTokenClaims{UserID: 7}, nil
+	case "broken":
+		return nil, errors.New("database is down")
+	}
+	return nil, domainErrors.ErrUnauthenticated
+}
+
+func newTestClient(t *testing.T, interceptors ...grpc.UnaryServerInterceptor) itemspb.ItemServiceClient {
+	t.Helper()
+
+	ln := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+	itemspb.RegisterItemServiceServer(srv, rpc.NewItemServer(echoUsecase{}))
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return itemspb.NewItemServiceClient(conn)
+}
+
+func TestRequestID(t *testing.T) {
+	client := newTestClient(t, RequestID(), Logging())
+
+	// 受け取ったIDをctxに設定し、レスポンスのヘッダーに返す
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-123")
+	item, err := client.GetItem(ctx, &itemspb.GetItemRequest{Id: 1}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, "req-123", item.GetName())
+	assert.Equal(t, []string{"req-123"}, header.Get("x-request-id"))
+
+	// 使えない値の場合は新しく生成する
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "bad id")
+	item, err = client.GetItem(ctx, &itemspb.GetItemRequest{Id: 1}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.True(t, requestid.Valid(item.GetName()))
+	assert.NotEqual(t, "bad id", item.GetName())
+	assert.Equal(t, []string{item.GetName()}, header.Get("x-request-id"))
+}
+
+func TestAuth(t *testing.T) {
+	client := newTestClient(t, Auth(stubAPIKeys{}, stubAccessTokens{}))
+
+	tests := []struct {
+		name     string
+		md       []string
+		wantCode codes.Code
+		wantUser int64
+	}{
+		{name: "アクセストークン", md: []string{"authorization", "Bearer token"}, wantCode: codes.OK, wantUser: 7},
+		{name: "BearerのAPIキー", md: []string{"authorization", "Bearer " + entity.APIKeyPrefix + "valid"}, wantCode: codes.OK},
+		{name: "x-api-key", md: []string{"x-api-key", entity.APIKeyPrefix + "valid"}, wantCode: codes.OK},
+		{name: "認証情報なし", wantCode: codes.Unauthenticated},
+		{name: "無効なAPIキー", md: []string{"x-api-key", entity.APIKeyPrefix + "invalid"}, wantCode: codes.Unauthenticated},
+		{name: "照合できない", md: []string{"authorization", "Bearer broken"}, wantCode: codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), tt.md...)
+			item, err := client.GetItem(ctx, &itemspb.GetItemRequest{Id: 1})
+			require.Equal(t, tt.wantCode, status.Code(err), err)
+			if err == nil {
+				assert.Equal(t, tt.wantUser, item.GetUserId())
+			}
+		})
+	}
+}
+
+type panicCounter struct{ count int }
+
+func (c *panicCounter) ObservePanic() { c.count++ }
+
+func TestRecover(t *testing.T) {
+	counter := &panicCounter{}
+	client := newTestClient(t, Logging(), Recover(counter))
+
+	_, err := client.GetItem(context.Background(), &itemspb.GetItemRequest{Id: 0})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, 1, counter.count)
+
+	// panicの後も呼び出しを受け付ける
+	_, err = client.GetItem(context.Background(), &itemspb.GetItemRequest{Id: 1})
+	assert.NoError(t, err)
+}
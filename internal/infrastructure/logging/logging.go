@@ -0,0 +1,143 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/principal"
+	"Aicon-assignment/internal/requestid"
+)
+
+// ログの出力形式
+const (
+	FormatJSON = "json" // 本番用。1行に1件のJSON
+	FormatText = "text" // 開発用。key=value形式
+)
+
+// アクセスログを出力しないパス
+var unloggedPaths = map[string]bool{
+	"/metrics": true,
+	"/health":  true,
+	"/healthz": true,
+	"/readyz":  true,
+}
+
+// ルートに一致しないリクエストのrouteの値
+const unmatchedRoute = "unmatched"
+
+// formatの形式でwに出力し、ctxに付けた属性をすべてのログに付けるハンドラーを返す。
+// levelにslog.LevelVarを渡すと、実行中にログのレベルを変更できる
+func NewHandler(w io.Writer, format string, level slog.Leveler) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == FormatText {
+		return NewContextHandler(slog.NewTextHandler(w, opts))
+	}
+	return NewContextHandler(slog.NewJSONHandler(w, opts))
+}
+
+type attrsKey struct{}
+
+// argsの属性を付けたctxを返す。返したctxを渡したslogの*Context関数のログにはargsの属性が付く。
+// ユースケースやリポジトリはslog.InfoContext(ctx, ...)のようにctxを渡すだけで、リクエストの属性を付けられる
+func With(ctx context.Context, args ...any) context.Context {
+	attrs := append(cloneAttrs(Attrs(ctx)), argsToAttrs(args)...)
+	return context.WithValue(ctx, attrsKey{}, attrs)
+}
+
+// ctxに付けた属性
+func Attrs(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// 親のctxの属性の配列を共有しないよう複製する
+func cloneAttrs(attrs []slog.Attr) []slog.Attr {
+	return append([]slog.Attr(nil), attrs...)
+}
+
+// slog.Logger.Withと同じ形式（キーと値の組かslog.Attr）の引数を属性にする
+func argsToAttrs(args []any) []slog.Attr {
+	var r slog.Record
+	r.Add(args...)
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return attrs
+}
+
+// ctxに付けた属性とリクエストIDをログに付けるslog.Handler
+type ContextHandler struct {
+	slog.Handler
+}
+
+func NewContextHandler(h slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: h}
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		if id := requestid.From(ctx); id != "" {
+			r.AddAttrs(slog.String("request_id", id))
+		}
+		r.AddAttrs(Attrs(ctx)...)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// リクエストごとにメソッド・ルート・ステータス・処理時間・レスポンスのバイト数をログに出すミドルウェア。
+// リクエストのctxにメソッドとルートを付けるため、処理中のログにも同じ属性が付く
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route := c.Path()
+			if unloggedPaths[route] {
+				return next(c)
+			}
+			if route == "" || route == "/*" {
+				route = unmatchedRoute
+			}
+
+			start := time.Now()
+			req := c.Request()
+			ctx := With(req.Context(), "method", req.Method, "route", route)
+			c.SetRequest(req.WithContext(ctx))
+
+			// ステータスを確定させるため、エラーはここでレスポンスにする
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			res := c.Response()
+			attrs := []slog.Attr{
+				slog.String("path", req.URL.Path),
+				slog.Int("status", res.Status),
+				slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+				slog.Int64("bytes", res.Size),
+			}
+			// 認証は内側のミドルウェアで行うため、ハンドラーに渡したctxから認証したAPIキーかユーザーを取り出す
+			if p, ok := principal.From(c.Request().Context()); ok {
+				if p.UserID != 0 {
+					attrs = append(attrs, slog.Int64("user_id", p.UserID))
+				} else {
+					attrs = append(attrs, slog.String("api_key", p.APIKeyLabel))
+				}
+			}
+			slog.LogAttrs(ctx, slog.LevelInfo, "request", attrs...)
+			return nil
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/principal"
+	"Aicon-assignment/internal/requestid"
+)
+
+// デフォルトのロガーをbufにJSONで出力するロガーに置き換える
+func captureLogs(t *testing.T, level slog.Level) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(NewHandler(&buf, FormatJSON, level)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestWith(t *testing.T) {
+	buf := captureLogs(t, slog.LevelInfo)
+
+	parent := With(requestid.NewContext(context.Background(), "req-1"), "method", "GET")
+	child := With(parent, slog.String("route", "/items/:id"))
+	sibling := With(parent, "route", "/items")
+
+	slog.InfoContext(child, "child")
+	slog.InfoContext(sibling, "sibling")
+	slog.Info("no context")
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 3)
+	assert.Equal(t, "req-1", lines[0]["request_id"])
+	assert.Equal(t, "GET", lines[0]["method"])
+	assert.Equal(t, "/items/:id", lines[0]["route"])
+	// 同じ親から作ったctxの属性は互いに影響しない
+	assert.Equal(t, "/items", lines[1]["route"])
+	assert.NotContains(t, lines[2], "method")
+	assert.NotContains(t, lines[2], "request_id")
+}
+
+func TestNewHandler_Level(t *testing.T) {
+	level := new(slog.LevelVar)
+	level.Set(slog.LevelWarn)
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(&buf, FormatText, level))
+
+	logger.Info("hidden")
+	level.Set(slog.LevelDebug)
+	logger.Debug("shown")
+
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), "msg=shown")
+}
+
+func TestMiddleware(t *testing.T) {
+	buf := captureLogs(t, slog.LevelInfo)
+	e := echo.New()
+	e.Use(Middleware())
+	e.GET("/items/:id", func(c echo.Context) error {
+		slog.InfoContext(c.Request().Context(), "in handler")
+		return c.String(http.StatusOK, "hello")
+	})
+	e.GET("/fail", func(c echo.Context) error { return echo.NewHTTPError(http.StatusServiceUnavailable) })
+	e.GET("/health", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/1", nil))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 4)
+
+	// 処理中のログにもリクエストの属性が付く
+	assert.Equal(t, "in handler", lines[0]["msg"])
+	assert.Equal(t, "GET", lines[0]["method"])
+	assert.Equal(t, "/items/:id", lines[0]["route"])
+
+	assert.Equal(t, "request", lines[1]["msg"])
+	assert.Equal(t, "/items/:id", lines[1]["route"])
+	assert.Equal(t, "/items/1", lines[1]["path"])
+	assert.Equal(t, float64(http.StatusOK), lines[1]["status"])
+	assert.Equal(t, float64(len("hello")), lines[1]["bytes"])
+	assert.Contains(t, lines[1], "duration_ms")
+
+	assert.Equal(t, "/fail", lines[2]["route"])
+	assert.Equal(t, float64(http.StatusServiceUnavailable), lines[2]["status"])
+
+	// /healthは出力せず、存在しないパスはunmatchedにまとめる
+	assert.Equal(t, unmatchedRoute, lines[3]["route"])
+	assert.Equal(t, float64(http.StatusNotFound), lines[3]["status"])
+}
+
+func TestMiddleware_APIKey(t *testing.T) {
+	buf := captureLogs(t, slog.LevelInfo)
+	e := echo.New()
+	e.Use(Middleware())
+	// 内側のミドルウェアで認証したAPIキーもアクセスログに付ける
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := principal.NewContext(c.Request().Context(), principal.Principal{APIKeyID: 1, APIKeyLabel: "batch"})
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	})
+	e.GET("/items", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items", nil))
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "batch", lines[0]["api_key"])
+}
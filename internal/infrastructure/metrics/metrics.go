@@ -0,0 +1,193 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 自身の計測を記録しないパス
+var uninstrumentedPaths = map[string]bool{
+	"/metrics": true,
+	"/health":  true,
+	"/healthz": true,
+	"/readyz":  true,
+}
+
+// ルートに一致しないリクエストのrouteの値。存在しないパスごとに値が増えないよう1つにまとめる
+const unmatchedRoute = "unmatched"
+
+// Prometheusの指標。Newに渡したRegistererに登録し、テストでは新しいRegistryを渡す
+type Metrics struct {
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	queryDuration   *prometheus.HistogramVec
+	cacheHits       *prometheus.CounterVec
+	cacheMisses     *prometheus.CounterVec
+	panics          prometheus.Counter
+	auditFailures   prometheus.Counter
+	itemEvents      map[entity.EventType]prometheus.Counter
+	reg             prometheus.Registerer
+}
+
+func New(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTPリクエストの件数（ルートのパターン・ステータスの区分ごと）",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTPリクエストの処理時間（ルートのパターン・ステータスの区分ごと）",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "repository_query_duration_seconds",
+			Help:    "リポジトリの呼び出しの所要時間（リポジトリ・メソッドごと）",
+			Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"repository", "method"}),
+		cacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "item_cache_hits_total",
+			Help: "キャッシュから返したアイテムの読み込みの件数（読み込みの種類ごと）",
+		}, []string{"kind"}),
+		cacheMisses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "item_cache_misses_total",
+			Help: "キャッシュになくリポジトリから読み込んだアイテムの読み込みの件数（読み込みの種類ごと）",
+		}, []string{"kind"}),
+		panics: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "panics_total",
+			Help: "ハンドラーで発生し、500のレスポンスにしたpanicの件数",
+		}),
+		auditFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "audit_log_failures_total",
+			Help: "記録に失敗した監査ログの件数。アイテムの変更は成功している",
+		}),
+		itemEvents: make(map[entity.EventType]prometheus.Counter),
+		reg:        reg,
+	}
+	reg.MustRegister(m.requests, m.requestDuration, m.queryDuration, m.cacheHits, m.cacheMisses, m.panics, m.auditFailures)
+
+	// アイテムの変更の件数。イベントの種類ごとに別の指標にする
+	for eventType, name := range map[entity.EventType]string{
+		entity.EventItemCreated:     "items_created_total",
+		entity.EventItemUpdated:     "items_updated_total",
+		entity.EventItemDeleted:     "items_deleted_total",
+		entity.EventItemRestored:    "items_restored_total",
+		entity.EventItemHardDeleted: "items_hard_deleted_total",
+	} {
+		counter := prometheus.NewCounter(prometheus.CounterOpts{
+			Name: name,
+			Help: fmt.Sprintf("コミットしたアイテムの変更（%s）の件数", eventType),
+		})
+		reg.MustRegister(counter)
+		m.itemEvents[eventType] = counter
+	}
+
+	return m
+}
+
+// リクエストの件数と処理時間を記録するミドルウェア。routeはc.Path()のパターン（/api/v1/items/:id など）で、
+// 生のパスを使わないことで値の種類を登録済みのルートの数に抑える
+func (m *Metrics) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route := c.Path()
+			if uninstrumentedPaths[route] {
+				return next(c)
+			}
+
+			start := time.Now()
+			// ステータスを確定させるため、エラーはここでレスポンスにする
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			if route == "" || route == "/*" {
+				route = unmatchedRoute
+			}
+			status := statusClass(c.Response().Status)
+			m.requests.WithLabelValues(c.Request().Method, route, status).Inc()
+			m.requestDuration.WithLabelValues(c.Request().Method, route, status).Observe(time.Since(start).Seconds())
+			return nil
+		}
+	}
+}
+
+// 2xx・4xxなどのステータスの区分
+func statusClass(status int) string {
+	return strconv.Itoa(status/100) + "xx"
+}
+
+// usecase.QueryObserverの実装
+func (m *Metrics) ObserveQuery(repository, method string, d time.Duration) {
+	m.queryDuration.WithLabelValues(repository, method).Observe(d.Seconds())
+}
+
+// usecase.CacheObserverの実装
+func (m *Metrics) ObserveCacheHit(kind string) {
+	m.cacheHits.WithLabelValues(kind).Inc()
+}
+
+// usecase.CacheObserverの実装
+func (m *Metrics) ObserveCacheMiss(kind string) {
+	m.cacheMisses.WithLabelValues(kind).Inc()
+}
+
+// middleware.Recoverでpanicを回復した件数を数える
+func (m *Metrics) ObservePanic() {
+	m.panics.Inc()
+}
+
+// usecase.AuditObserverの実装
+func (m *Metrics) ObserveAuditFailure() {
+	m.auditFailures.Inc()
+}
+
+// アイテムの変更の件数を数えるusecase.EventHandler。コミットした変更のみを数えるよう、usecase.AfterCommitで包んで登録する
+func (m *Metrics) HandleEvent(ctx context.Context, e entity.Event) error {
+	if counter, ok := m.itemEvents[e.Type]; ok {
+		counter.Inc()
+	}
+	return nil
+}
+
+// データベースの接続プールの接続数を、収集のたびにstatsから読み込む指標として登録する
+func (m *Metrics) RegisterDBStats(stats func() sql.DBStats) {
+	m.reg.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "db_connections_open",
+			Help: "データベースの開いている接続の数（使用中と待機中の合計）",
+		}, func() float64 { return float64(stats().OpenConnections) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "db_connections_in_use",
+			Help: "データベースの使用中の接続の数",
+		}, func() float64 { return float64(stats().InUse) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "db_connections_idle",
+			Help: "データベースの待機中の接続の数",
+		}, func() float64 { return float64(stats().Idle) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "db_connections_wait_total",
+			Help: "接続の上限に達して接続を待った回数",
+		}, func() float64 { return float64(stats().WaitCount) }),
+	)
+}
+
+// データベースの一時的なエラーでやり直した回数を、収集のたびにcountsから読み込む指標として登録する。
+// countsはやり直した単位ごとの回数を返し、単位をkindのラベルにする
+func (m *Metrics) RegisterDatabaseRetries(counts func() map[string]int64) {
+	for kind := range counts() {
+		m.reg.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name:        "database_retries_total",
+			Help:        "データベースの一時的なエラー（デッドロックなど）でやり直した回数（クエリ・トランザクションの単位ごと）",
+			ConstLabels: prometheus.Labels{"kind": kind},
+		}, func() float64 { return float64(counts()[kind]) }))
+	}
+}
@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 新しいRegistryに登録したMetricsと、ミドルウェアを設定したEcho
+func newTestServer(t *testing.T) (*Metrics, *prometheus.Registry, *echo.Echo) {
+	t.Helper()
+
+	registry := prometheus.NewRegistry()
+	m := New(registry)
+	e := echo.New()
+	e.Use(m.Middleware())
+	e.GET("/items/:id", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/fail", func(c echo.Context) error { return echo.NewHTTPError(http.StatusBadRequest) })
+	e.GET("/health", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	return m, registry, e
+}
+
+func request(e *echo.Echo, path string) int {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec.Code
+}
+
+func TestMiddleware(t *testing.T) {
+	m, _, e := newTestServer(t)
+
+	assert.Equal(t, http.StatusOK, request(e, "/items/1"))
+	assert.Equal(t, http.StatusOK, request(e, "/items/2"))
+	assert.Equal(t, http.StatusBadRequest, request(e, "/fail"))
+	assert.Equal(t, http.StatusNotFound, request(e, "/missing/1"))
+	assert.Equal(t, http.StatusNotFound, request(e, "/missing/2"))
+	assert.Equal(t, http.StatusOK, request(e, "/health"))
+
+	// 生のパスではなくルートのパターンごとに数える
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.requests.WithLabelValues(http.MethodGet, "/items/:id", "2xx")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues(http.MethodGet, "/fail", "4xx")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.requests.WithLabelValues(http.MethodGet, unmatchedRoute, "4xx")))
+	// /healthは記録しない
+	assert.Equal(t, 3, testutil.CollectAndCount(m.requests))
+	assert.Equal(t, 3, testutil.CollectAndCount(m.requestDuration))
+}
+
+func TestHandleEvent(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := New(registry)
+
+	for _, eventType := range []entity.EventType{entity.EventItemCreated, entity.EventItemCreated, entity.EventItemDeleted} {
+		require.NoError(t, m.HandleEvent(context.Background(), entity.Event{Type: eventType}))
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.itemEvents[entity.EventItemCreated]))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.itemEvents[entity.EventItemDeleted]))
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.itemEvents[entity.EventItemUpdated]))
+}
+
+func TestObservePanic(t *testing.T) {
+	m := New(prometheus.NewRegistry())
+
+	m.ObservePanic()
+	m.ObservePanic()
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.panics))
+}
+
+func TestObserveCache(t *testing.T) {
+	m := New(prometheus.NewRegistry())
+
+	m.ObserveCacheHit("find_all")
+	m.ObserveCacheHit("find_all")
+	m.ObserveCacheMiss("find_by_id")
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.cacheHits.WithLabelValues("find_all")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.cacheMisses.WithLabelValues("find_by_id")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.cacheHits.WithLabelValues("find_by_id")))
+}
+
+func TestObserveAuditFailure(t *testing.T) {
+	m := New(prometheus.NewRegistry())
+
+	m.ObserveAuditFailure()
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.auditFailures))
+}
+
+func TestObserveQueryAndDBStats(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := New(registry)
+	m.RegisterDBStats(func() sql.DBStats { return sql.DBStats{OpenConnections: 3, InUse: 1, Idle: 2} })
+
+	m.ObserveQuery("ItemRepository", "FindAll", 10*time.Millisecond)
+	m.ObserveQuery("ItemRepository", "FindAll", 20*time.Millisecond)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(m.queryDuration))
+	count, err := testutil.GatherAndCount(registry, "db_connections_open", "db_connections_in_use", "db_connections_idle", "db_connections_wait_total")
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == "db_connections_open" {
+			assert.Equal(t, 3.0, family.GetMetric()[0].GetGauge().GetValue())
+		}
+	}
+}
+
+func TestRegisterDatabaseRetries(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	m := New(registry)
+	counts := map[string]int64{"query": 2, "transaction": 0}
+	m.RegisterDatabaseRetries(func() map[string]int64 { return counts })
+
+	counts["transaction"] = 1
+
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP database_retries_total データベースの一時的なエラー（デッドロックなど）でやり直した回数（クエリ・トランザクションの単位ごと）
+# TYPE database_retries_total counter
+database_retries_total{kind="query"} 2
+database_retries_total{kind="transaction"} 1
+`), "database_retries_total"))
+}
+
+func TestStatusClass(t *testing.T) {
+	assert.Equal(t, "2xx", statusClass(http.StatusNoContent))
+	assert.Equal(t, "4xx", statusClass(499))
+	assert.Equal(t, "5xx", statusClass(http.StatusGatewayTimeout))
+}
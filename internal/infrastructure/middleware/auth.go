@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/infrastructure/logging"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/principal"
+	"Aicon-assignment/internal/usecase"
+)
+
+// APIキーを受け取るヘッダー。Authorization: Bearer <key>と同じく扱う
+const HeaderAPIKey = "X-API-Key"
+
+// 認証せずに呼び出せるパス。ロードバランサーや監視は認証情報を持たない
+var publicPaths = map[string]bool{
+	"/metrics":      true,
+	"/health":       true,
+	"/healthz":      true,
+	"/readyz":       true,
+	"/openapi.json": true,
+}
+
+// リクエストで受け取ったAPIキーを照合する。usecase.APIKeyUsecaseが満たす
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*entity.APIKey, error)
+}
+
+// リクエストで受け取ったユーザーのアクセストークンを検証する。usecase.AuthUsecaseが満たす
+type AccessTokenAuthenticator interface {
+	AuthenticateAccessToken(ctx context.Context, token string) (*usecase.AccessTokenClaims, error)
+}
+
+// Authorization: Bearer <credential>ヘッダーかX-API-KeyヘッダーのAPIキーまたはユーザーのアクセストークンを照合し、
+// 認証できない場合は401のproblem+jsonを返すミドルウェア。publicPathsとpublicRoutesのルートと、ルーターが応答するOPTIONSは認証しない。
+// APIキーの接頭辞で始まる値とX-API-Keyヘッダーの値はAPIキーとして、それ以外はアクセストークンとして照合する。
+// 認証したクライアントはprincipalとしてリクエストのctxに設定し、APIキーのラベルかユーザーのIDをその後のログに付ける
+func Auth(apiKeys APIKeyAuthenticator, accessTokens AccessTokenAuthenticator, publicRoutes ...string) echo.MiddlewareFunc {
+	public := make(map[string]bool, len(publicPaths)+len(publicRoutes))
+	for path := range publicPaths {
+		public[path] = true
+	}
+	for _, route := range publicRoutes {
+		public[route] = true
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if public[c.Path()] || isAllowedMethodsRequest(c) {
+				return next(c)
+			}
+
+			req := c.Request()
+			ctx := req.Context()
+			value, isAPIKey := credential(req)
+			if isAPIKey {
+				apiKey, err := apiKeys.AuthenticateAPIKey(ctx, value)
+				if err != nil {
+					return unauthenticated(c, err, "failed to authenticate api key")
+				}
+				ctx = principal.NewContext(ctx, principal.Principal{APIKeyID: apiKey.ID, APIKeyLabel: apiKey.Label, Role: apiKey.Role})
+				ctx = logging.With(ctx, "api_key", apiKey.Label)
+			} else {
+				claims, err := accessTokens.AuthenticateAccessToken(ctx, value)
+				if err != nil {
+					return unauthenticated(c, err, "failed to authenticate access token")
+				}
+				ctx = principal.NewContext(ctx, principal.Principal{UserID: claims.UserID, Role: claims.Role})
+				ctx = logging.With(ctx, "user_id", claims.UserID)
+			}
+
+			c.SetRequest(req.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// ルーターが応答するOPTIONSのリクエスト。ルートの受け付けるメソッドをAllowヘッダーで返すのみで、
+// アイテムなどのデータを返さないため、認証情報を持たないクライアントも確認できるよう認証しない
+func isAllowedMethodsRequest(c echo.Context) bool {
+	return c.Request().Method == http.MethodOptions && c.Get(echo.ContextKeyHeaderAllow) != nil
+}
+
+// 認証に失敗したリクエストへの応答。照合できない場合（保存先の障害など）は認証の失敗とせず500を返す
+func unauthenticated(c echo.Context, err error, message string) error {
+	if !errors.Is(err, domainErrors.ErrUnauthenticated) {
+		return httperror.Respond(c, err, message)
+	}
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="api"`)
+	res := httperror.ErrorResponse{Error: "a valid API key or access token is required", Code: httperror.CodeUnauthorized}
+	return httperror.Write(c, httperror.NewProblem(http.StatusUnauthorized, res), res)
+}
+
+// リクエストの認証情報と、それをAPIキーとして照合するかどうか。Authorizationヘッダーを優先し、どちらもない場合は空文字列のアクセストークン
+func credential(req *http.Request) (string, bool) {
+	if scheme, value, ok := strings.Cut(req.Header.Get(echo.HeaderAuthorization), " "); ok && strings.EqualFold(scheme, "Bearer") {
+		value = strings.TrimSpace(value)
+		return value, strings.HasPrefix(value, entity.APIKeyPrefix)
+	}
+	if key := req.Header.Get(HeaderAPIKey); key != "" {
+		return key, true
+	}
+	return "", false
+}
+
+// 管理者のみに許可するルートのミドルウェア。管理者でないユーザー・APIキーには403のproblem+jsonを返す。
+// Authより後に実行する。認証を無効にした場合（principalがない場合）は管理者を確認できないため、常に403を返す
+func RequireAdmin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !principal.IsAdmin(c.Request().Context()) {
+			return httperror.Respond(c, domainErrors.ErrForbidden, "admin role is required")
+		}
+		return next(c)
+	}
+}
@@ -0,0 +1,198 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/principal"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 登録済みのキーのみを認証するAPIKeyAuthenticator
+type stubAuthenticator struct {
+	keys  map[string]*entity.APIKey
+	err   error
+	calls int
+}
+
+func (a *stubAuthenticator) AuthenticateAPIKey(ctx context.Context, key string) (*entity.APIKey, error) {
+	a.calls++
+	if a.err != nil {
+		return nil, a.err
+	}
+	apiKey, ok := a.keys[key]
+	if !ok {
+		return nil, domainErrors.ErrUnauthenticated
+	}
+	return apiKey, nil
+}
+
+// 「user:2」を一般のユーザー、「admin:3」を管理者として認証するAccessTokenAuthenticator
+type stubAccessTokenAuthenticator struct{}
+
+func (stubAccessTokenAuthenticator) AuthenticateAccessToken(ctx context.Context, token string) (*usecase.AccessTokenClaims, error) {
+	switch token {
+	case "user:2":
+		return &usecase.AccessTokenClaims{UserID: 2, Role: entity.UserRoleUser}, nil
+	case "admin:3":
+		return &usecase.AccessTokenClaims{UserID: 3, Role: entity.UserRoleAdmin}, nil
+	}
+	return nil, domainErrors.ErrUnauthenticated
+}
+
+func newAuthenticatedEcho(authenticator APIKeyAuthenticator) *echo.Echo {
+	e := echo.New()
+	e.Use(Auth(authenticator, stubAccessTokenAuthenticator{}, "/images/*"))
+	e.GET("/items", func(c echo.Context) error {
+		p, ok := principal.From(c.Request().Context())
+		if !ok {
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		if p.UserID != 0 {
+			return c.String(http.StatusOK, fmt.Sprintf("user %d", p.UserID))
+		}
+		return c.String(http.StatusOK, p.APIKeyLabel)
+	})
+	e.GET("/healthz", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/images/*", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	return e
+}
+
+func TestAuth(t *testing.T) {
+	e := newAuthenticatedEcho(&stubAuthenticator{keys: map[string]*entity.APIKey{"aicon_valid": {ID: 1, Label: "batch"}}})
+
+	tests := []struct {
+		name     string
+		path     string
+		header   string
+		value    string
+		expected int
+		body     string
+	}{
+		{name: "Bearerトークンで認証する", path: "/items", header: echo.HeaderAuthorization, value: "Bearer aicon_valid", expected: http.StatusOK, body: "batch"},
+		{name: "スキームの大文字・小文字は区別しない", path: "/items", header: echo.HeaderAuthorization, value: "bearer aicon_valid", expected: http.StatusOK, body: "batch"},
+		{name: "X-API-Keyヘッダーで認証する", path: "/items", header: HeaderAPIKey, value: "aicon_valid", expected: http.StatusOK, body: "batch"},
+		{name: "APIキーの接頭辞のないトークンはアクセストークンとして認証する", path: "/items", header: echo.HeaderAuthorization, value: "Bearer user:2", expected: http.StatusOK, body: "user 2"},
+		{name: "無効なアクセストークンは401", path: "/items", header: echo.HeaderAuthorization, value: "Bearer user:3", expected: http.StatusUnauthorized},
+		{name: "X-API-Keyヘッダーのアクセストークンは認証しない", path: "/items", header: HeaderAPIKey, value: "user:2", expected: http.StatusUnauthorized},
+		{name: "キーがない場合は401", path: "/items", expected: http.StatusUnauthorized},
+		{name: "未登録のキーは401", path: "/items", header: echo.HeaderAuthorization, value: "Bearer aicon_unknown", expected: http.StatusUnauthorized},
+		{name: "Bearer以外のスキームは401", path: "/items", header: echo.HeaderAuthorization, value: "Basic aicon_valid", expected: http.StatusUnauthorized},
+		{name: "ヘルスチェックは認証しない", path: "/healthz", expected: http.StatusOK},
+		{name: "指定したルートは認証しない", path: "/images/1.png", expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expected, rec.Code)
+			if tt.body != "" {
+				// 認証したキーのラベルかユーザーのIDをctxに設定する
+				assert.Equal(t, tt.body, rec.Body.String())
+			}
+			if tt.expected == http.StatusUnauthorized {
+				assert.Equal(t, `Bearer realm="api"`, rec.Header().Get(echo.HeaderWWWAuthenticate))
+				assert.Equal(t, httperror.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+				var problem httperror.Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+				assert.Equal(t, http.StatusUnauthorized, problem.Status)
+				assert.Equal(t, httperror.CodeUnauthorized, problem.Extensions["code"])
+			}
+		})
+	}
+}
+
+func TestAuth_AuthenticatorError(t *testing.T) {
+	e := newAuthenticatedEcho(&stubAuthenticator{err: errors.New("connection refused")})
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set(HeaderAPIKey, "aicon_valid")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	// キーを照合できない場合は認証の失敗とせず500を返す
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestAuth_Options(t *testing.T) {
+	e := newAuthenticatedEcho(&stubAuthenticator{})
+
+	// ルーターが応答するOPTIONSは、受け付けるメソッドのみを返すため認証しない
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/items", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "OPTIONS, GET", rec.Header().Get(echo.HeaderAllow))
+
+	// 受け付けないメソッドは、ルートの有無を明かさないよう認証してから405を返す
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/items", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestRequireAdmin(t *testing.T) {
+	e := echo.New()
+	keys := map[string]*entity.APIKey{
+		"aicon_valid": {ID: 1, Label: "batch", Role: entity.UserRoleUser},
+		"aicon_admin": {ID: 2, Label: "ops", Role: entity.UserRoleAdmin},
+	}
+	e.Use(Auth(&stubAuthenticator{keys: keys}, stubAccessTokenAuthenticator{}))
+	e.GET("/admin/categories", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, RequireAdmin)
+
+	tests := []struct {
+		name     string
+		token    string
+		expected int
+	}{
+		{name: "管理者のユーザー", token: "admin:3", expected: http.StatusOK},
+		{name: "管理者の権限を与えたAPIキー", token: "aicon_admin", expected: http.StatusOK},
+		{name: "一般のユーザーは403", token: "user:2", expected: http.StatusForbidden},
+		{name: "一般の権限のAPIキーは403", token: "aicon_valid", expected: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/categories", nil)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expected, rec.Code)
+			if tt.expected == http.StatusForbidden {
+				assert.Equal(t, httperror.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+				var problem httperror.Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+				assert.Equal(t, http.StatusForbidden, problem.Status)
+				assert.Equal(t, httperror.CodeForbidden, problem.Extensions["code"])
+			}
+		})
+	}
+}
+
+func TestRequireAdmin_AuthDisabled(t *testing.T) {
+	// API_KEY_AUTH=falseでAuthを登録しない場合は管理者を確認できないため、許可しない
+	e := echo.New()
+	e.GET("/admin/categories", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, RequireAdmin)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/categories", nil))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
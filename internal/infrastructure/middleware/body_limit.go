@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+)
+
+// リクエストのルートごとのボディの最大サイズ（バイト）。0以下は制限しない
+type BodyLimitFunc func(c echo.Context) int64
+
+// リクエストボディの最大サイズを制限するミドルウェア。Content-Lengthが上限を超えるリクエストは読み込む前に413を返し、
+// それ以外はボディをhttp.MaxBytesReaderで包んで、上限を超えて読み込もうとした時点でエラーにする。
+// ハンドラーはhttp.MaxBytesErrorを413に変換する（request.Decodeやrequest.FormFileなど）
+func BodyLimit(limitFor BodyLimitFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			limit := limitFor(c)
+			req := c.Request()
+			if limit <= 0 || req.Body == nil || req.Body == http.NoBody {
+				return next(c)
+			}
+
+			if req.ContentLength > limit {
+				err := fmt.Errorf("%w: request body must be %d bytes or less", httperror.ErrBodyTooLarge, limit)
+				return httperror.Respond(c, err, "request body is too large")
+			}
+			req.Body = http.MaxBytesReader(c.Response(), req.Body, limit)
+			return next(c)
+		}
+	}
+}
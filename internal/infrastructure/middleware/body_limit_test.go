@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/interfaces/controller/request"
+)
+
+func newBodyLimitEcho(limit int64, called *bool) *echo.Echo {
+	e := echo.New()
+	e.Use(BodyLimit(func(c echo.Context) int64 { return limit }))
+	e.POST("/items", func(c echo.Context) error {
+		*called = true
+		var input map[string]interface{}
+		// ハンドラーの上限はミドルウェアの上限より大きい
+		if err := request.DecodeWithLimit(c, &input, 1<<20); err != nil {
+			return httperror.Respond(c, err, "invalid request format")
+		}
+		return c.NoContent(http.StatusCreated)
+	})
+	return e
+}
+
+func TestBodyLimit(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		chunked        bool
+		expectedStatus int
+		expectedCalled bool
+	}{
+		{name: "正常系: 上限以下のボディ", body: `{"name":"a"}`, expectedStatus: http.StatusCreated, expectedCalled: true},
+		{name: "異常系: Content-Lengthが上限を超える場合は読み込まずに413", body: `{"name":"` + strings.Repeat("a", 100) + `"}`, expectedStatus: http.StatusRequestEntityTooLarge},
+		{name: "異常系: Content-Lengthがなくても読み込んだサイズで413", body: `{"name":"` + strings.Repeat("a", 100) + `"}`, chunked: true, expectedStatus: http.StatusRequestEntityTooLarge, expectedCalled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			e := newBodyLimitEcho(64, &called)
+			req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedCalled, called)
+			if tt.expectedStatus == http.StatusRequestEntityTooLarge {
+				var problem httperror.Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+				assert.Equal(t, httperror.CodeRequestTooLarge, problem.Extensions["code"])
+				// ミドルウェアの上限をメッセージで返す
+				assert.Contains(t, rec.Body.String(), "request body must be 64 bytes or less")
+			}
+		})
+	}
+}
+
+func TestBodyLimit_Disabled(t *testing.T) {
+	var called bool
+	e := newBodyLimitEcho(0, &called)
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"name":"`+strings.Repeat("a", 100)+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.True(t, called)
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/clientip"
+)
+
+// c.RealIP()で取り出したクライアントのIPアドレスをリクエストのctxに設定するミドルウェア。
+// 監査ログなど、echo.Contextを受け取らないユースケースで送信元を記録するのに使う
+func ClientIP() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			c.SetRequest(req.WithContext(clientip.NewContext(req.Context(), c.RealIP())))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/clientip"
+)
+
+func TestClientIP(t *testing.T) {
+	_, proxy, _ := net.ParseCIDR("10.0.0.0/8")
+	e := echo.New()
+	e.IPExtractor = ClientIPExtractor([]*net.IPNet{proxy})
+	e.Use(ClientIP())
+	var got string
+	e.GET("/items", func(c echo.Context) error {
+		got = clientip.From(c.Request().Context())
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set(echo.HeaderXForwardedFor, "203.0.113.7, 10.0.0.2")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	// 信頼するプロキシを除いた送信元のアドレス
+	assert.Equal(t, "203.0.113.7", got)
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CORSの設定
+type CORSConfig struct {
+	AllowOrigins     []string      // 許可するオリジン（scheme://host[:port]）。*はすべてのオリジンを許可する
+	AllowMethods     []string      // プリフライトで許可するメソッド
+	AllowHeaders     []string      // プリフライトで許可するリクエストヘッダー。*はリクエストされたヘッダーをすべて許可する
+	ExposeHeaders    []string      // ブラウザのスクリプトから読めるようにするレスポンスヘッダー
+	AllowCredentials bool          // Cookieや認証ヘッダーを付けたリクエストを許可するかどうか
+	MaxAge           time.Duration // プリフライトの結果をブラウザがキャッシュする期間（0はAccess-Control-Max-Ageを返さない）
+}
+
+// 別のオリジンのブラウザからの呼び出しにCORSのヘッダーを付けるミドルウェア。
+// プリフライト（Access-Control-Request-Methodヘッダーを付けたOPTIONS）には、認証や頻度の制限の前に204を返す。
+// 許可していないオリジンからのリクエストはエラーにせず、CORSのヘッダーを付けずに処理する（ブラウザがレスポンスを読ませない）。
+// 認証情報を付けたリクエストでは*を返せないため、AllowCredentialsがtrueの場合はリクエストのオリジンをそのまま返す
+func CORS(config CORSConfig) echo.MiddlewareFunc {
+	allowAll := slices.Contains(config.AllowOrigins, "*")
+	allowAllHeaders := slices.Contains(config.AllowHeaders, "*")
+	allowMethods := strings.Join(config.AllowMethods, ", ")
+	allowHeaders := strings.Join(config.AllowHeaders, ", ")
+	exposeHeaders := strings.Join(config.ExposeHeaders, ", ")
+	maxAge := strconv.Itoa(int(config.MaxAge / time.Second))
+
+	allowed := func(origin string) bool {
+		return allowAll || slices.ContainsFunc(config.AllowOrigins, func(o string) bool { return strings.EqualFold(o, origin) })
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			origin := req.Header.Get(echo.HeaderOrigin)
+			if origin == "" {
+				return next(c)
+			}
+			preflight := req.Method == http.MethodOptions && req.Header.Get(echo.HeaderAccessControlRequestMethod) != ""
+
+			header := c.Response().Header()
+			// オリジンごとに異なるレスポンスを返すため、キャッシュがオリジンを区別するようにする
+			header.Add(echo.HeaderVary, echo.HeaderOrigin)
+			if preflight {
+				header.Add(echo.HeaderVary, echo.HeaderAccessControlRequestMethod)
+				header.Add(echo.HeaderVary, echo.HeaderAccessControlRequestHeaders)
+			}
+
+			if !allowed(origin) {
+				if preflight {
+					return c.NoContent(http.StatusNoContent)
+				}
+				return next(c)
+			}
+
+			if allowAll && !config.AllowCredentials {
+				header.Set(echo.HeaderAccessControlAllowOrigin, "*")
+			} else {
+				header.Set(echo.HeaderAccessControlAllowOrigin, origin)
+			}
+			if config.AllowCredentials {
+				header.Set(echo.HeaderAccessControlAllowCredentials, "true")
+			}
+
+			if !preflight {
+				if exposeHeaders != "" {
+					header.Set(echo.HeaderAccessControlExposeHeaders, exposeHeaders)
+				}
+				return next(c)
+			}
+
+			header.Set(echo.HeaderAccessControlAllowMethods, allowMethods)
+			// 認証情報を付けたリクエストでは*がヘッダーの名前として扱われるため、リクエストされたヘッダーを返す
+			if allowAllHeaders && config.AllowCredentials {
+				if requested := req.Header.Get(echo.HeaderAccessControlRequestHeaders); requested != "" {
+					header.Set(echo.HeaderAccessControlAllowHeaders, requested)
+				}
+			} else if allowHeaders != "" {
+				header.Set(echo.HeaderAccessControlAllowHeaders, allowHeaders)
+			}
+			if config.MaxAge > 0 {
+				header.Set(echo.HeaderAccessControlMaxAge, maxAge)
+			}
+			return c.NoContent(http.StatusNoContent)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCORSEcho(config CORSConfig) *echo.Echo {
+	e := echo.New()
+	e.Use(CORS(config))
+	e.GET("/items", func(c echo.Context) error {
+		c.Response().Header().Set("ETag", `"1"`)
+		return c.NoContent(http.StatusOK)
+	})
+	e.POST("/items", func(c echo.Context) error { return c.NoContent(http.StatusCreated) })
+	return e
+}
+
+func corsRequest(e *echo.Echo, method, origin string, header map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/items", nil)
+	if origin != "" {
+		req.Header.Set(echo.HeaderOrigin, origin)
+	}
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+var testCORSConfig = CORSConfig{
+	AllowOrigins:  []string{"https://app.example.com"},
+	AllowMethods:  []string{"GET", "POST"},
+	AllowHeaders:  []string{"Content-Type", "X-API-Key"},
+	ExposeHeaders: []string{"ETag", "X-Request-ID"},
+	MaxAge:        10 * time.Minute,
+}
+
+func TestCORS_AllowedOrigin(t *testing.T) {
+	e := newCORSEcho(testCORSConfig)
+
+	rec := corsRequest(e, http.MethodGet, "https://app.example.com", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "https://app.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "ETag, X-Request-ID", rec.Header().Get(echo.HeaderAccessControlExposeHeaders))
+	assert.Equal(t, []string{"Origin"}, rec.Header().Values(echo.HeaderVary))
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	e := newCORSEcho(testCORSConfig)
+
+	rec := corsRequest(e, http.MethodOptions, "https://app.example.com", map[string]string{
+		echo.HeaderAccessControlRequestMethod:  http.MethodPost,
+		echo.HeaderAccessControlRequestHeaders: "content-type",
+	})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://app.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "GET, POST", rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+	assert.Equal(t, "Content-Type, X-API-Key", rec.Header().Get(echo.HeaderAccessControlAllowHeaders))
+	assert.Equal(t, "600", rec.Header().Get(echo.HeaderAccessControlMaxAge))
+	// 公開するヘッダーはプリフライトではなく実際のリクエストのレスポンスに付ける
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlExposeHeaders))
+}
+
+func TestCORS_DisallowedOrigin(t *testing.T) {
+	e := newCORSEcho(testCORSConfig)
+
+	// エラーにせず、CORSのヘッダーを付けずに処理する
+	rec := corsRequest(e, http.MethodGet, "https://evil.example.com", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlExposeHeaders))
+
+	rec = corsRequest(e, http.MethodOptions, "https://evil.example.com", map[string]string{
+		echo.HeaderAccessControlRequestMethod: http.MethodPost,
+	})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+}
+
+func TestCORS_NoOrigin(t *testing.T) {
+	e := newCORSEcho(testCORSConfig)
+
+	// 同じオリジンからのリクエストやブラウザ以外のクライアントにはヘッダーを付けない
+	rec := corsRequest(e, http.MethodGet, "", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Empty(t, rec.Header().Get(echo.HeaderVary))
+}
+
+func TestCORS_Wildcard(t *testing.T) {
+	config := testCORSConfig
+	config.AllowOrigins = []string{"*"}
+	e := newCORSEcho(config)
+
+	rec := corsRequest(e, http.MethodGet, "http://localhost:5173", nil)
+	assert.Equal(t, "*", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+}
+
+func TestCORS_Credentials(t *testing.T) {
+	config := testCORSConfig
+	config.AllowOrigins = []string{"*"}
+	config.AllowHeaders = []string{"*"}
+	config.AllowCredentials = true
+	e := newCORSEcho(config)
+
+	// 認証情報を付けたリクエストには*を返せないため、リクエストのオリジンを返す
+	rec := corsRequest(e, http.MethodGet, "http://localhost:5173", map[string]string{echo.HeaderCookie: "session=1"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "http://localhost:5173", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "true", rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+	assert.Equal(t, []string{"Origin"}, rec.Header().Values(echo.HeaderVary))
+
+	// 許可するヘッダーの*もヘッダーの名前として扱われるため、リクエストされたヘッダーを返す
+	rec = corsRequest(e, http.MethodOptions, "http://localhost:5173", map[string]string{
+		echo.HeaderAccessControlRequestMethod:  http.MethodPost,
+		echo.HeaderAccessControlRequestHeaders: "authorization, content-type",
+	})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "http://localhost:5173", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Equal(t, "true", rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+	assert.Equal(t, "authorization, content-type", rec.Header().Get(echo.HeaderAccessControlAllowHeaders))
+
+	// 許可していないオリジンには認証情報の許可も返さない
+	config.AllowOrigins = []string{"https://app.example.com"}
+	e = newCORSEcho(config)
+	rec = corsRequest(e, http.MethodGet, "http://localhost:5173", nil)
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+}
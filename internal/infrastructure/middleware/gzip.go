@@ -0,0 +1,260 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// gzipの設定
+type GzipConfig struct {
+	MinSize   int  // 圧縮するレスポンスの最小のサイズ（バイト）。これより小さいレスポンスは圧縮しない
+	Streaming bool // 逐次送信するエクスポート（NDJSON）も圧縮するかどうか
+}
+
+// 圧縮しないContent-Type。圧縮済みの形式は圧縮しても小さくならない
+var incompressibleTypes = map[string]bool{
+	"application/gzip": true,
+	"application/zip":  true,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": true, // xlsxはzip形式
+	"image/gif":  true,
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+// 逐次送信するため、GzipConfig.Streamingがtrueの場合のみ圧縮するContent-Type
+var streamingTypes = map[string]bool{
+	"application/x-ndjson": true,
+}
+
+// 圧縮したレスポンスのETagの引用符の内側に付ける接尾辞。圧縮前とはバイト列が異なるため、強いETagを区別する
+const gzipETagSuffix = "-gzip"
+
+var gzipWriters = sync.Pool{
+	New: func() interface{} { return gzip.NewWriter(nil) },
+}
+
+// Accept-Encoding: gzipを送ったクライアントへのレスポンスをgzipで圧縮するミドルウェア。
+// 最初のMinSizeバイトまでは書き込みを溜め、それより小さいレスポンスや圧縮しないContent-Typeのレスポンスはそのまま返す。
+// ハンドラーがFlushした場合は、逐次送信のレスポンスとしてサイズによらずその時点で圧縮するかどうかを決める。
+// 圧縮したレスポンスのETagには-gzipを付け、圧縮しないレスポンスと異なる強いETagにする。
+// クライアントが送るIf-Match・If-None-Matchからは-gzipを取り除くため、ハンドラーは圧縮によらず同じETagと比較できる
+func Gzip(config GzipConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// 圧縮を受け付けないリクエストでも、以前に圧縮したレスポンスのETagを送ることがある
+			stripGzipETags(c.Request().Header, "If-Match")
+			hadGzipETag := stripGzipETags(c.Request().Header, "If-None-Match")
+
+			res := c.Response()
+			// 圧縮するかどうかはAccept-Encodingヘッダーで変わるため、キャッシュが区別するようにする
+			res.Header().Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+			if c.Request().Method == http.MethodHead || !acceptsGzip(c.Request().Header.Get(echo.HeaderAcceptEncoding)) {
+				return next(c)
+			}
+
+			w := &gzipResponseWriter{ResponseWriter: res.Writer, config: config, gzipNotModified: hadGzipETag}
+			res.Writer = w
+			defer func() {
+				w.Close()
+				res.Writer = w.ResponseWriter
+			}()
+
+			// エラーのレスポンスも圧縮するよう、ここでレスポンスにする
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+			return nil
+		}
+	}
+}
+
+// headerのnameヘッダーのETagの一覧から-gzipを取り除き、取り除いたETagがあったかどうかを返す
+func stripGzipETags(header http.Header, name string) bool {
+	value := header.Get(name)
+	if !strings.Contains(value, gzipETagSuffix+`"`) {
+		return false
+	}
+	header.Set(name, strings.ReplaceAll(value, gzipETagSuffix+`"`, `"`))
+	return true
+}
+
+// 圧縮したレスポンスのETag。引用符で囲まれていないETagはそのまま返す
+func gzipETag(etag string) string {
+	if len(etag) < 2 || !strings.HasSuffix(etag, `"`) {
+		return etag
+	}
+	return etag[:len(etag)-1] + gzipETagSuffix + `"`
+}
+
+// Accept-Encodingヘッダーでgzipを受け付けているかどうか。q=0は受け付けないことを表す
+func acceptsGzip(acceptEncoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+		name, value, ok := strings.Cut(strings.TrimSpace(params), "=")
+		if ok && strings.TrimSpace(name) == "q" {
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && q == 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// 書き込みを溜めて圧縮するかどうかを決め、決めた後は圧縮するかそのまま書き込むhttp.ResponseWriter
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	config GzipConfig
+
+	status  int
+	buf     []byte
+	decided bool
+	gz      *gzip.Writer
+
+	// If-None-Matchに圧縮したレスポンスのETagがあったかどうか。304には、クライアントが保持する圧縮したレスポンスのETagを返す
+	gzipNotModified bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	// 1xxは中間のレスポンスのため、そのまま送る
+	if status < http.StatusOK {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if w.status == 0 {
+		w.status = status
+	}
+	// ボディのないレスポンスは溜めずにそのまま送る
+	if status == http.StatusNoContent || status == http.StatusNotModified {
+		w.decide(false)
+	}
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if !w.decided {
+		w.buf = append(w.buf, b...)
+		if len(w.buf) < w.config.MinSize {
+			return len(b), nil
+		}
+		if err := w.flushBuffer(w.compressible()); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+	if w.gz != nil {
+		return w.gz.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// ハンドラーが逐次送信する場合。圧縮したデータも溜めずに送る
+func (w *gzipResponseWriter) Flush() {
+	if !w.decided {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		if err := w.flushBuffer(w.compressible()); err != nil {
+			return
+		}
+	}
+	if w.gz != nil {
+		if err := w.gz.Flush(); err != nil {
+			return
+		}
+	}
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// http.ResponseControllerが元のhttp.ResponseWriterを使えるようにする
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// レスポンスの終わり。MinSizeに満たなかった書き込みはそのまま送る
+func (w *gzipResponseWriter) Close() {
+	if !w.decided {
+		if w.status == 0 {
+			return
+		}
+		if err := w.flushBuffer(false); err != nil {
+			return
+		}
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+		gzipWriters.Put(w.gz)
+		w.gz = nil
+	}
+}
+
+// 溜めた書き込みを、圧縮するかどうかを決めたうえで送る
+func (w *gzipResponseWriter) flushBuffer(compress bool) error {
+	w.decide(compress)
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.gz != nil {
+		_, err := w.gz.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// 圧縮するかどうかを決め、ヘッダーを送る
+func (w *gzipResponseWriter) decide(compress bool) {
+	w.decided = true
+	header := w.ResponseWriter.Header()
+	if etag := header.Get("ETag"); etag != "" && (compress || (w.status == http.StatusNotModified && w.gzipNotModified)) {
+		header.Set("ETag", gzipETag(etag))
+	}
+	if compress {
+		header.Set(echo.HeaderContentEncoding, "gzip")
+		// 圧縮後のサイズは送り終えるまで分からない
+		header.Del(echo.HeaderContentLength)
+		gz := gzipWriters.Get().(*gzip.Writer)
+		gz.Reset(w.ResponseWriter)
+		w.gz = gz
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+// レスポンスのヘッダーから、圧縮するレスポンスかどうか
+func (w *gzipResponseWriter) compressible() bool {
+	header := w.ResponseWriter.Header()
+	// ハンドラーが圧縮済み（/metricsなど）
+	if header.Get(echo.HeaderContentEncoding) != "" {
+		return false
+	}
+	if w.status == http.StatusNoContent || w.status == http.StatusNotModified || w.status == http.StatusPartialContent {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(header.Get(echo.HeaderContentType))
+	if err != nil {
+		// Content-Typeのないレスポンスは、net/httpがボディから推測する型が分からないため圧縮しない
+		return false
+	}
+	if incompressibleTypes[mediaType] {
+		return false
+	}
+	if streamingTypes[mediaType] {
+		return w.config.Streaming
+	}
+	return true
+}
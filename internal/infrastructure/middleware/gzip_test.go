@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var largeBody = strings.Repeat(`{"name":"ロレックス デイトナ"}`, 100)
+
+func newGzipEcho(config GzipConfig) *echo.Echo {
+	e := echo.New()
+	e.Use(Gzip(config))
+	e.GET("/items", func(c echo.Context) error {
+		c.Response().Header().Set("ETag", `"3"`)
+		return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, []byte(largeBody))
+	})
+	e.GET("/items/1", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]int{"id": 1})
+	})
+	e.GET("/items/error", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound, strings.Repeat("not found ", 200))
+	})
+	e.GET("/items/not-modified", func(c echo.Context) error {
+		return c.NoContent(http.StatusNotModified)
+	})
+	e.GET("/items/export.xlsx", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", []byte(largeBody))
+	})
+	e.GET("/items/export.ndjson", func(c echo.Context) error {
+		res := c.Response()
+		res.Header().Set(echo.HeaderContentType, "application/x-ndjson")
+		res.WriteHeader(http.StatusOK)
+		for i := 0; i < 3; i++ {
+			if _, err := io.WriteString(res, `{"id":1}`+"\n"); err != nil {
+				return err
+			}
+			res.Flush()
+		}
+		return nil
+	})
+	return e
+}
+
+func gzipRequest(e *echo.Echo, path, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set(echo.HeaderAcceptEncoding, acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func gunzip(t *testing.T, body io.Reader) string {
+	t.Helper()
+
+	r, err := gzip.NewReader(body)
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestGzip_CompressesLargeResponse(t *testing.T) {
+	e := newGzipEcho(GzipConfig{MinSize: 1024})
+
+	rec := gzipRequest(e, "/items", "br, gzip")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Equal(t, []string{"Accept-Encoding"}, rec.Header().Values(echo.HeaderVary))
+	// 圧縮しないレスポンスとバイト列が異なるため、強いETagを区別する
+	assert.Equal(t, `"3-gzip"`, rec.Header().Get("ETag"))
+	assert.Less(t, rec.Body.Len(), len(largeBody))
+	assert.Equal(t, largeBody, gunzip(t, rec.Body))
+}
+
+func TestGzip_SkipsSmallResponse(t *testing.T) {
+	e := newGzipEcho(GzipConfig{MinSize: 1024})
+
+	rec := gzipRequest(e, "/items/1", "gzip")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Equal(t, []string{"Accept-Encoding"}, rec.Header().Values(echo.HeaderVary))
+	assert.JSONEq(t, `{"id":1}`, rec.Body.String())
+}
+
+func TestGzip_ClientDoesNotAcceptGzip(t *testing.T) {
+	e := newGzipEcho(GzipConfig{MinSize: 1024})
+
+	for _, acceptEncoding := range []string{"", "br", "gzip;q=0", "*;q=0"} {
+		rec := gzipRequest(e, "/items", acceptEncoding)
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding), acceptEncoding)
+		assert.Equal(t, largeBody, rec.Body.String(), acceptEncoding)
+		// 圧縮しない場合もAccept-Encodingによってレスポンスが変わることを示す
+		assert.Equal(t, []string{"Accept-Encoding"}, rec.Header().Values(echo.HeaderVary), acceptEncoding)
+	}
+}
+
+func TestGzip_CompressesErrorResponse(t *testing.T) {
+	e := newGzipEcho(GzipConfig{MinSize: 1024})
+
+	rec := gzipRequest(e, "/items/error", "gzip")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Contains(t, gunzip(t, rec.Body), "not found")
+}
+
+func TestGzip_NoBody(t *testing.T) {
+	e := newGzipEcho(GzipConfig{MinSize: 1})
+
+	rec := gzipRequest(e, "/items/not-modified", "gzip")
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestGzip_SkipsCompressedContentType(t *testing.T) {
+	e := newGzipEcho(GzipConfig{MinSize: 1024})
+
+	rec := gzipRequest(e, "/items/export.xlsx", "gzip")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Equal(t, largeBody, rec.Body.String())
+}
+
+func TestGzip_Streaming(t *testing.T) {
+	expected := strings.Repeat(`{"id":1}`+"\n", 3)
+
+	// 逐次送信するNDJSONは、有効にしない限り圧縮しない
+	rec := gzipRequest(newGzipEcho(GzipConfig{MinSize: 1024}), "/items/export.ndjson", "gzip")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Equal(t, expected, rec.Body.String())
+	assert.True(t, rec.Flushed)
+
+	// 有効にした場合は、最小のサイズに満たなくてもFlushした時点で圧縮して送る
+	rec = gzipRequest(newGzipEcho(GzipConfig{MinSize: 1024, Streaming: true}), "/items/export.ndjson", "gzip")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get(echo.HeaderContentEncoding))
+	assert.True(t, rec.Flushed)
+	assert.Equal(t, expected, gunzip(t, rec.Body))
+}
+
+func TestGzip_StripsGzipETagFromPreconditions(t *testing.T) {
+	e := echo.New()
+	e.Use(Gzip(GzipConfig{MinSize: 1}))
+	var ifMatch, ifNoneMatch string
+	e.GET("/items/1", func(c echo.Context) error {
+		ifMatch = c.Request().Header.Get("If-Match")
+		ifNoneMatch = c.Request().Header.Get("If-None-Match")
+		c.Response().Header().Set("ETag", `"3"`)
+		if strings.TrimPrefix(ifNoneMatch, "W/") == `"3"` {
+			return c.NoContent(http.StatusNotModified)
+		}
+		return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, []byte(largeBody))
+	})
+	serve := func(header, value, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+		req.Header.Set(header, value)
+		if acceptEncoding != "" {
+			req.Header.Set(echo.HeaderAcceptEncoding, acceptEncoding)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("If-Matchの-gzipはハンドラーに渡す前に取り除く", func(t *testing.T) {
+		serve("If-Match", `"3-gzip", "4"`, "")
+		assert.Equal(t, `"3", "4"`, ifMatch)
+	})
+
+	t.Run("圧縮したレスポンスのETagで304を返す場合は、同じETagを返す", func(t *testing.T) {
+		rec := serve("If-None-Match", `W/"3-gzip"`, "gzip")
+		assert.Equal(t, `W/"3"`, ifNoneMatch)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Equal(t, `"3-gzip"`, rec.Header().Get("ETag"))
+	})
+
+	t.Run("圧縮しないレスポンスのETagで304を返す場合は、そのまま返す", func(t *testing.T) {
+		rec := serve("If-None-Match", `"3"`, "")
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+	})
+}
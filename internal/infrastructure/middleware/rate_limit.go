@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/infrastructure/ratelimit"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/principal"
+)
+
+// 頻度を制限しないパス。ロードバランサーや監視からの定期的な確認が制限されないようにする
+var unlimitedPaths = map[string]bool{
+	"/metrics": true,
+	"/health":  true,
+	"/healthz": true,
+	"/readyz":  true,
+}
+
+// リクエストの頻度を制限するキー
+type RateLimitKeyFunc func(c echo.Context) string
+
+// クライアントのIPアドレスをキーにする。IPアドレスはEchoのIPExtractor（ClientIPExtractor）で取り出す
+func ClientIPKey(c echo.Context) string {
+	return "ip:" + c.RealIP()
+}
+
+// ユーザーとして認証したリクエストはユーザーのIDを、APIキーで認証したリクエストはキーのIDを、それ以外はクライアントのIPアドレスをキーにする。
+// 同じプロキシの背後にある複数のクライアントも、認証したクライアントごとに制限できる。Authの内側で使う
+func PrincipalOrClientIPKey(c echo.Context) string {
+	p, ok := principal.From(c.Request().Context())
+	switch {
+	case !ok:
+		return ClientIPKey(c)
+	case p.UserID != 0:
+		return "user:" + strconv.FormatInt(p.UserID, 10)
+	default:
+		return "api_key:" + strconv.FormatInt(p.APIKeyID, 10)
+	}
+}
+
+// キーごとにリクエストの頻度を制限するミドルウェア。
+// 受け付けたリクエストにはX-RateLimit-Limit・X-RateLimit-Remaining・X-RateLimit-Reset（秒）ヘッダーを付け、
+// 上限を超えたリクエストにはRetry-After（秒）ヘッダーとともに429のproblem+jsonを返す。
+// limiterがエラーを返した場合は、制限の状態を保持する先の障害でAPI全体が止まらないよう受け付ける
+func RateLimit(limiter ratelimit.Limiter, key RateLimitKeyFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if unlimitedPaths[c.Path()] {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+			decision, err := limiter.Allow(ctx, key(c))
+			if err != nil {
+				slog.WarnContext(ctx, "⚠️  リクエストの頻度を確認できないため、制限せずに受け付けます", "error", err)
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set("X-RateLimit-Limit", strconv.Itoa(decision.Limit))
+			header.Set("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+			header.Set("X-RateLimit-Reset", seconds(decision.Reset))
+			if decision.Allowed {
+				return next(c)
+			}
+
+			// 0秒と返すとすぐに再送されるため、少なくとも1秒待たせる
+			header.Set(echo.HeaderRetryAfter, seconds(max(decision.RetryAfter, time.Second)))
+			res := httperror.ErrorResponse{Error: "too many requests, try again later", Code: httperror.CodeRateLimited}
+			return httperror.Write(c, httperror.NewProblem(http.StatusTooManyRequests, res), res)
+		}
+	}
+}
+
+// ヘッダーに返す秒数。端数は切り上げる
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// クライアントのIPアドレスを取り出すEchoのIPExtractor。
+// trustedProxiesが空の場合はX-Forwarded-Forヘッダーを信頼せず、接続元のアドレスを使う。
+// 指定した場合は、X-Forwarded-Forヘッダーを右から辿り、trustedProxiesに含まれない最初のアドレスを使う
+func ClientIPExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	// 既定で信頼するループバック・プライベートアドレスも、指定された範囲のみを信頼する
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, proxy := range trustedProxies {
+		options = append(options, echo.TrustIPRange(proxy))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
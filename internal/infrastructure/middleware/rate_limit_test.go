@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/infrastructure/ratelimit"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/principal"
+)
+
+// 常に同じ判定を返すLimiter
+type stubLimiter struct {
+	decision ratelimit.Decision
+	err      error
+	keys     []string
+}
+
+func (l *stubLimiter) Allow(ctx context.Context, key string) (ratelimit.Decision, error) {
+	l.keys = append(l.keys, key)
+	return l.decision, l.err
+}
+
+func newRateLimitedEcho(limiter ratelimit.Limiter) *echo.Echo {
+	e := echo.New()
+	e.Use(RateLimit(limiter, ClientIPKey))
+	e.GET("/items", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/healthz", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/metrics", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	return e
+}
+
+func TestRateLimit(t *testing.T) {
+	e := newRateLimitedEcho(ratelimit.NewMemory(ratelimit.Config{Rate: 1, Burst: 2}))
+
+	request := func(path, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := request("/items", "192.0.2.1:1234")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", rec.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "1", rec.Header().Get("X-RateLimit-Reset"))
+	assert.Equal(t, http.StatusOK, request("/items", "192.0.2.1:1234").Code)
+
+	// 上限を超えると429を返す
+	rec = request("/items", "192.0.2.1:5678")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get(echo.HeaderRetryAfter))
+	assert.Equal(t, "0", rec.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, httperror.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	var problem httperror.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, http.StatusTooManyRequests, problem.Status)
+	assert.Equal(t, httperror.CodeRateLimited, problem.Extensions["code"])
+
+	// 他のクライアントと、ヘルスチェック・指標は制限しない
+	assert.Equal(t, http.StatusOK, request("/items", "192.0.2.2:1234").Code)
+	for i := 0; i < 5; i++ {
+		rec = request("/healthz", "192.0.2.1:1234")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, http.StatusOK, request("/metrics", "192.0.2.1:1234").Code)
+	}
+}
+
+func TestRateLimit_RetryAfter(t *testing.T) {
+	limiter := &stubLimiter{decision: ratelimit.Decision{Limit: 10, RetryAfter: 2500 * time.Millisecond, Reset: 30 * time.Second}}
+	e := newRateLimitedEcho(limiter)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items", nil))
+
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	// 端数は切り上げる
+	assert.Equal(t, "3", rec.Header().Get(echo.HeaderRetryAfter))
+	assert.Equal(t, "30", rec.Header().Get("X-RateLimit-Reset"))
+}
+
+func TestRateLimit_LimiterError(t *testing.T) {
+	limiter := &stubLimiter{err: errors.New("redis: connection refused")}
+	e := newRateLimitedEcho(limiter)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items", nil))
+
+	// 制限の状態を確認できない場合は受け付ける
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, limiter.keys, 1)
+}
+
+func TestRateLimit_BeforeAuth(t *testing.T) {
+	authenticator := &stubAuthenticator{}
+	e := echo.New()
+	e.Use(RateLimit(ratelimit.NewMemory(ratelimit.Config{Rate: 1, Burst: 2}), ClientIPKey))
+	e.Use(Auth(authenticator, stubAccessTokenAuthenticator{}))
+	e.GET("/items", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	request := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/items", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("X-API-Key", "aicon_invalid")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, request())
+	assert.Equal(t, http.StatusUnauthorized, request())
+	// 不正なキーを送り続けるクライアントも制限し、上限を超えた分はキーを照合しない
+	assert.Equal(t, http.StatusTooManyRequests, request())
+	assert.Equal(t, 2, authenticator.calls)
+}
+
+func TestPrincipalOrClientIPKey(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+
+	assert.Equal(t, "ip:192.0.2.1", PrincipalOrClientIPKey(e.NewContext(req, httptest.NewRecorder())))
+
+	// 認証したリクエストは同じアドレスからでもキー・ユーザーごとに数える
+	apiKeyReq := req.WithContext(principal.NewContext(req.Context(), principal.Principal{APIKeyID: 7, APIKeyLabel: "batch"}))
+	assert.Equal(t, "api_key:7", PrincipalOrClientIPKey(e.NewContext(apiKeyReq, httptest.NewRecorder())))
+	userReq := req.WithContext(principal.NewContext(req.Context(), principal.Principal{UserID: 2}))
+	assert.Equal(t, "user:2", PrincipalOrClientIPKey(e.NewContext(userReq, httptest.NewRecorder())))
+}
+
+func TestClientIPExtractor(t *testing.T) {
+	_, proxy, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		trustedProxies []*net.IPNet
+		remoteAddr     string
+		forwardedFor   string
+		expected       string
+	}{
+		{name: "信頼するプロキシがない場合はX-Forwarded-Forを無視する", remoteAddr: "10.0.0.1:1234", forwardedFor: "203.0.113.9", expected: "10.0.0.1"},
+		{name: "信頼するプロキシからのX-Forwarded-Forを使う", trustedProxies: []*net.IPNet{proxy}, remoteAddr: "10.0.0.1:1234", forwardedFor: "203.0.113.9, 10.0.0.2", expected: "203.0.113.9"},
+		{name: "クライアントが付けた偽のアドレスは使わない", trustedProxies: []*net.IPNet{proxy}, remoteAddr: "10.0.0.1:1234", forwardedFor: "198.51.100.1, 203.0.113.9", expected: "203.0.113.9"},
+		{name: "信頼しない接続元のX-Forwarded-Forは無視する", trustedProxies: []*net.IPNet{proxy}, remoteAddr: "192.0.2.1:1234", forwardedFor: "203.0.113.9", expected: "192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/items", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(echo.HeaderXForwardedFor, tt.forwardedFor)
+
+			assert.Equal(t, tt.expected, ClientIPExtractor(tt.trustedProxies)(req))
+		})
+	}
+}
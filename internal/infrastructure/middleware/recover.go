@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+)
+
+// 回復したpanicの件数を記録する
+type PanicObserver interface {
+	ObservePanic()
+}
+
+// ハンドラーのpanicを回復し、スタックトレースをリクエストIDとともにログに出して500のproblem+jsonを返すミドルウェア。
+// アクセスログと指標に500として記録されるよう、それらのミドルウェアの内側で使う。
+// http.ErrAbortHandlerはnet/httpの規約に従い、レスポンスを中断するため回復せずにpanicし直す
+func Recover(observer PanicObserver) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (returnErr error) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(recovered)
+				}
+
+				if observer != nil {
+					observer.ObservePanic()
+				}
+				slog.ErrorContext(c.Request().Context(), "ハンドラーでpanicが発生しました",
+					"panic", fmt.Sprint(recovered),
+					"stack", string(debug.Stack()),
+				)
+
+				// 書き込み済みのレスポンスは変更できないため、ログのみを残す
+				if c.Response().Committed {
+					return
+				}
+				res := httperror.ErrorResponse{Error: "internal server error", Code: httperror.CodeInternal}
+				returnErr = httperror.Write(c, httperror.NewProblem(http.StatusInternalServerError, res), res)
+			}()
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/requestid"
+)
+
+type countingObserver struct {
+	panics int
+}
+
+func (o *countingObserver) ObservePanic() {
+	o.panics++
+}
+
+func TestRecover(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	observer := &countingObserver{}
+	e := echo.New()
+	e.Use(RequestID(), Recover(observer))
+	e.GET("/panic", func(c echo.Context) error {
+		var items map[string]int
+		items["boom"] = 1 // nilのmapへの書き込みでpanicする
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/ok", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	// 実際のサーバーを通して、クライアントが正しい形式の500を受け取ることを確認する
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/panic", nil)
+	require.NoError(t, err)
+	req.Header.Set(requestid.Header, "req-panic")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, httperror.MIMEApplicationProblemJSON, resp.Header.Get(echo.HeaderContentType))
+	var problem httperror.Problem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	assert.Equal(t, http.StatusInternalServerError, problem.Status)
+	assert.Equal(t, "/problems/internal_error", problem.Type)
+	assert.Equal(t, "req-panic", problem.Extensions["request_id"])
+	assert.Equal(t, 1, observer.panics)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "ERROR", entry["level"])
+	assert.Contains(t, entry["panic"], "nil map")
+	assert.Contains(t, entry["stack"], "recover_test.go")
+
+	// panicの後も他のリクエストを処理できる
+	resp, err = http.Get(server.URL + "/ok")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestRecover_ErrAbortHandler(t *testing.T) {
+	observer := &countingObserver{}
+	e := echo.New()
+	e.Use(Recover(observer))
+	e.GET("/abort", func(c echo.Context) error {
+		panic(http.ErrAbortHandler)
+	})
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/abort", nil))
+	})
+	assert.Zero(t, observer.panics)
+}
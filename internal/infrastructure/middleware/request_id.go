@@ -0,0 +1,26 @@
+// 全エンドポイントに共通のHTTPのミドルウェア
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/requestid"
+)
+
+// リクエストのX-Request-IDヘッダーのIDか、ない場合や使えない値の場合は新しく生成したIDをリクエストのctxに設定し、
+// レスポンスのX-Request-IDヘッダーに返すミドルウェア。ログとエラーレスポンスにはctxのIDを付ける
+func RequestID() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			id := req.Header.Get(requestid.Header)
+			if !requestid.Valid(id) {
+				id = requestid.New()
+			}
+
+			c.Response().Header().Set(requestid.Header, id)
+			c.SetRequest(req.WithContext(requestid.NewContext(req.Context(), id)))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/requestid"
+)
+
+func TestRequestID(t *testing.T) {
+	e := echo.New()
+	e.Use(RequestID())
+	var handlerID string
+	e.GET("/items/:id", func(c echo.Context) error {
+		handlerID = requestid.From(c.Request().Context())
+		return httperror.Respond(c, domainErrors.ErrItemNotFound, "failed to retrieve item")
+	})
+
+	tests := []struct {
+		name     string
+		incoming string
+		wantSame bool
+	}{
+		{name: "正常系: 受け取ったIDをそのまま使う", incoming: "client-req-1", wantSame: true},
+		{name: "正常系: ヘッダーがない場合は生成する"},
+		{name: "異常系: 使えない値の場合は生成する", incoming: "bad id\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+			if tt.incoming != "" {
+				req.Header.Set(requestid.Header, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			id := rec.Header().Get(requestid.Header)
+			if tt.wantSame {
+				assert.Equal(t, tt.incoming, id)
+			} else {
+				assert.NotEqual(t, tt.incoming, id)
+				assert.True(t, requestid.Valid(id))
+			}
+			assert.Equal(t, id, handlerID)
+
+			// エラーレスポンスの本文にも同じIDを含める
+			var problem httperror.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, id, problem.Extensions["request_id"])
+		})
+	}
+}
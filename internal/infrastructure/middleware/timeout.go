@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/inflight"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+)
+
+// リクエストの制限時間
+type RequestTimeout struct {
+	Duration time.Duration // 0は制限しない
+	// trueの場合、Durationは処理全体ではなくレスポンスの書き込みの間隔の上限とする。
+	// 件数に応じて時間がかかる逐次送信のレスポンスでは、送信が進んでいる間は打ち切らない
+	Idle bool
+}
+
+// リクエストのルートごとの制限時間
+type RequestTimeoutFunc func(c echo.Context) RequestTimeout
+
+// リクエストのctxに制限時間を設定するミドルウェア。http.TimeoutHandlerのようにレスポンスを溜めず、
+// ctxの期限でリポジトリのクエリを中断させる。制限時間を過ぎた場合は、レスポンスを送信前であれば504のproblem+jsonを返し、
+// 制限時間を過ぎた時点で実行していた層（inflight）をログに出す
+func Timeout(timeoutFor RequestTimeoutFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			timeout := timeoutFor(c)
+			if timeout.Duration <= 0 {
+				return next(c)
+			}
+
+			req := c.Request()
+			parent, recorder := inflight.NewContext(req.Context())
+			var ctx context.Context
+			var cancel context.CancelFunc
+			if timeout.Idle {
+				idle := newIdleContext(parent, timeout.Duration)
+				ctx, cancel = idle, idle.release
+				res := c.Response()
+				w := &idleResponseWriter{ResponseWriter: res.Writer, ctx: idle}
+				res.Writer = w
+				defer func() { res.Writer = w.ResponseWriter }()
+			} else {
+				ctx, cancel = context.WithTimeout(parent, timeout.Duration)
+			}
+			defer cancel()
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+			// 上位のctxの期限（クライアントの切断など）ではなく、この制限時間を過ぎた場合
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) || parent.Err() != nil {
+				return err
+			}
+
+			stage := recorder.Expired()
+			slog.WarnContext(ctx, "⚠️  リクエストの制限時間を過ぎたため処理を中断しました",
+				"timeout", timeout.Duration.String(), "idle", timeout.Idle, "layer", stage.Layer, "operation", stage.Operation)
+			if c.Response().Committed {
+				// レスポンスの送信後はステータスを変更できないため、途中で打ち切る
+				return err
+			}
+			res := httperror.ErrorResponse{Error: "request timed out", Code: httperror.CodeTimeout}
+			return httperror.Write(c, httperror.NewProblem(http.StatusGatewayTimeout, res), res)
+		}
+	}
+}
+
+// 最後の書き込みからtimeoutが過ぎるとcontext.DeadlineExceededで終了するctx。
+// context.WithCancelCauseで終了するとErrがcontext.Canceledになり、クライアントの切断と区別できないため自身で実装する
+type idleContext struct {
+	parent  context.Context
+	timeout time.Duration
+	done    chan struct{}
+
+	mu    sync.Mutex
+	err   error
+	timer *time.Timer
+	stop  func() bool
+}
+
+func newIdleContext(parent context.Context, timeout time.Duration) *idleContext {
+	ctx := &idleContext{parent: parent, timeout: timeout, done: make(chan struct{})}
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.timer = time.AfterFunc(timeout, func() { ctx.cancel(context.DeadlineExceeded) })
+	ctx.stop = context.AfterFunc(parent, func() { ctx.cancel(parent.Err()) })
+	return ctx
+}
+
+func (c *idleContext) Deadline() (time.Time, bool) {
+	return c.parent.Deadline()
+}
+
+func (c *idleContext) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *idleContext) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *idleContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
+
+// 書き込みがあったため、期限を延ばす
+func (c *idleContext) touch() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil {
+		c.timer.Reset(c.timeout)
+	}
+}
+
+func (c *idleContext) cancel(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	c.timer.Stop()
+	close(c.done)
+}
+
+// リクエストの処理の終わりに、タイマーと上位のctxの監視を止める
+func (c *idleContext) release() {
+	c.stop()
+	c.cancel(context.Canceled)
+}
+
+// 書き込みのたびにidleContextの期限を延ばすhttp.ResponseWriter
+type idleResponseWriter struct {
+	http.ResponseWriter
+	ctx *idleContext
+}
+
+func (w *idleResponseWriter) WriteHeader(status int) {
+	w.ctx.touch()
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *idleResponseWriter) Write(b []byte) (int, error) {
+	w.ctx.touch()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *idleResponseWriter) Flush() {
+	w.ctx.touch()
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// http.ResponseControllerが元のhttp.ResponseWriterを使えるようにする
+func (w *idleResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
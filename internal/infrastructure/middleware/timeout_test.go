@@ -0,0 +1,152 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/inflight"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+)
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &logs
+}
+
+// ctxが終了するまで待つリポジトリの呼び出し
+func slowQuery(ctx context.Context) (err error) {
+	leave := inflight.Enter(ctx, inflight.LayerRepository, "ItemRepository.FindAll")
+	defer func() { leave(err) }()
+	<-ctx.Done()
+	return fmt.Errorf("query failed: %w", ctx.Err())
+}
+
+func TestTimeout(t *testing.T) {
+	logs := captureLogs(t)
+
+	e := echo.New()
+	e.Use(Timeout(func(c echo.Context) RequestTimeout {
+		return RequestTimeout{Duration: 20 * time.Millisecond}
+	}))
+	e.GET("/items", func(c echo.Context) error {
+		// ハンドラーがエラーをそのまま返した場合も504にする
+		return slowQuery(c.Request().Context())
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items", nil))
+
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	assert.Equal(t, httperror.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	var problem httperror.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, httperror.CodeTimeout, problem.Extensions["code"])
+
+	// 制限時間を過ぎた時点で実行していた層をログに出す
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "20ms", entry["timeout"])
+	assert.Equal(t, inflight.LayerRepository, entry["layer"])
+	assert.Equal(t, "ItemRepository.FindAll", entry["operation"])
+}
+
+func TestTimeout_RespondedByHandler(t *testing.T) {
+	logs := captureLogs(t)
+
+	e := echo.New()
+	e.Use(Timeout(func(c echo.Context) RequestTimeout {
+		return RequestTimeout{Duration: 20 * time.Millisecond}
+	}))
+	e.GET("/items", func(c echo.Context) error {
+		<-c.Request().Context().Done()
+		return httperror.Respond(c, c.Request().Context().Err(), "failed to retrieve items")
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items", nil))
+
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	assert.Contains(t, logs.String(), `"layer":"handler"`)
+}
+
+func TestTimeout_Disabled(t *testing.T) {
+	e := echo.New()
+	e.Use(Timeout(func(c echo.Context) RequestTimeout {
+		return RequestTimeout{}
+	}))
+	var hasDeadline bool
+	e.GET("/items", func(c echo.Context) error {
+		_, hasDeadline = c.Request().Context().Deadline()
+		return c.NoContent(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, hasDeadline)
+}
+
+func TestTimeout_Idle(t *testing.T) {
+	captureLogs(t)
+
+	e := echo.New()
+	e.Use(Timeout(func(c echo.Context) RequestTimeout {
+		return RequestTimeout{Duration: 50 * time.Millisecond, Idle: true}
+	}))
+	var streamErr error
+	e.GET("/items/export.ndjson", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		res := c.Response()
+		res.WriteHeader(http.StatusOK)
+		// 書き込みの間隔が制限時間より短い間は、全体で制限時間を超えても打ち切らない
+		for i := 0; i < 5; i++ {
+			time.Sleep(20 * time.Millisecond)
+			if err := ctx.Err(); err != nil {
+				streamErr = err
+				return err
+			}
+			_, _ = io.WriteString(res, `{"id":1}`+"\n")
+			res.Flush()
+		}
+		// 書き込みが途絶えると打ち切る
+		streamErr = slowQuery(ctx)
+		return streamErr
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/export.ndjson", nil))
+
+	// クライアントの切断（context.Canceled）と区別できるよう、制限時間の超過として終了する
+	assert.ErrorIs(t, streamErr, context.DeadlineExceeded)
+	// 送信済みのレスポンスはそのまま打ち切る
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 5, bytes.Count(rec.Body.Bytes(), []byte("\n")))
+}
+
+func TestIdleContext_ParentCanceled(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	ctx := newIdleContext(parent, time.Minute)
+	defer ctx.release()
+
+	cancel()
+	<-ctx.Done()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
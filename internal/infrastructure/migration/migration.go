@@ -0,0 +1,396 @@
+// Package migration はバイナリに埋め込んだSQLのマイグレーションを適用し、
+// 適用済みの版数をschema_migrationsテーブルで管理する
+package migration
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// データベースごとのディレクトリに「版数_名前.up.sql」「版数_名前.down.sql」を置く
+//
+//go:embed mysql/*.sql postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// 適用済みの版数を記録するテーブル。すべてのデータベースで同じDDLを使う
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT NOT NULL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// マイグレーションの適用状況
+type Status struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at"`
+}
+
+type Migrator struct {
+	handler    database.SqlHandler
+	backend    string
+	migrations []Migration
+	adoption   *adoption
+}
+
+// 導入前のinit.sqlで作成したデータベースにマイグレーションを導入する方法
+type adoption struct {
+	// schema_migrationsがなく、init.sqlで作成したテーブルがあるかどうか
+	detect func(ctx context.Context, h database.SqlHandler) (bool, error)
+	// init.sqlで適用済みの変更を再び適用した場合のエラーかどうか
+	alreadyApplied func(err error) bool
+}
+
+// PostgreSQLとSQLiteの最初のマイグレーションはIF NOT EXISTSで作成するため、導入前のデータベースにもそのまま適用できる
+var adoptions = map[string]*adoption{
+	"mysql": {detect: detectMySQLLegacySchema, alreadyApplied: isMySQLAlreadyExists},
+}
+
+// backend（mysql, postgres, sqlite）のマイグレーションをhandlerのデータベースに適用するMigratorを作成する
+func New(handler database.SqlHandler, backend string) (*Migrator, error) {
+	migrations, err := load(backend)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		handler:    handler,
+		backend:    backend,
+		migrations: migrations,
+		adoption:   adoptions[backend],
+	}, nil
+}
+
+// マイグレーションの対象のデータベースの種類
+func (m *Migrator) Backend() string {
+	return m.backend
+}
+
+// backendのディレクトリのマイグレーションを版数の順に読み込む
+func load(backend string) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, backend)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for %q: %w", backend, err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version: %s", entry.Name())
+		}
+
+		content, err := fs.ReadFile(files, path.Join(backend, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// 未適用のマイグレーションを版数の順にすべて適用し、適用したマイグレーションを返す。
+// 失敗した場合はそれ以降のマイグレーションを適用しない
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	adopting := false
+	if m.adoption != nil {
+		var err error
+		if adopting, err = m.adoption.detect(ctx, m.handler); err != nil {
+			return nil, fmt.Errorf("failed to inspect the existing schema: %w", err)
+		}
+	}
+
+	if _, err := m.handler.Execute(ctx, createSchemaMigrations); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		if adopting {
+			err = m.adopt(ctx, migration)
+		} else {
+			err = m.apply(ctx, migration.Up, func(tx database.Transaction) error {
+				_, err := tx.Execute(ctx, "INSERT INTO schema_migrations (version, name) VALUES (?, ?)", migration.Version, migration.Name)
+				return err
+			})
+		}
+		if err != nil {
+			return done, fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// 適用済みのマイグレーションを新しいものからsteps件取り消し、取り消したマイグレーションを返す
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if _, err := m.handler.Execute(ctx, createSchemaMigrations); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		err := m.apply(ctx, migration.Down, func(tx database.Transaction) error {
+			_, err := tx.Execute(ctx, "DELETE FROM schema_migrations WHERE version = ?", migration.Version)
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("failed to roll back migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// すべてのマイグレーションの適用状況を版数の順に返す。
+// このバイナリにないマイグレーションが適用済みの場合は、記録された名前で末尾に含める
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if _, err := m.handler.Execute(ctx, createSchemaMigrations); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &record.appliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+
+	unknown := make([]Status, 0, len(applied))
+	for version, record := range applied {
+		unknown = append(unknown, Status{Version: version, Name: record.name, Applied: true, AppliedAt: &record.appliedAt})
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Version < unknown[j].Version })
+
+	return append(statuses, unknown...), nil
+}
+
+// 未適用のマイグレーションの件数を返す
+func Pending(statuses []Status) int {
+	pending := 0
+	for _, status := range statuses {
+		if !status.Applied {
+			pending++
+		}
+	}
+	return pending
+}
+
+type appliedMigration struct {
+	name      string
+	appliedAt time.Time
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int64]appliedMigration, error) {
+	rows, err := m.handler.Query(ctx, "SELECT version, name, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var version int64
+		var record appliedMigration
+		if err := rows.Scan(&version, &record.name, &record.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		applied[version] = record
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	return applied, nil
+}
+
+// スクリプトとschema_migrationsの更新を1つのトランザクションで実行する。
+// MySQLのDDLは暗黙的にコミットされるため、失敗した場合に取り消されるのはDDLより後の変更のみ
+func (m *Migrator) apply(ctx context.Context, script string, record func(tx database.Transaction) error) error {
+	tx, err := m.handler.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 引数のないExecは複数の文をまとめて実行できる（MySQLはmultiStatements=trueが必要）
+	if _, err := tx.Execute(ctx, script); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := record(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// 導入前のデータベースでは、init.sqlで適用済みの変更をエラーとせずに文ごとに実行する
+func (m *Migrator) adopt(ctx context.Context, migration Migration) error {
+	for _, statement := range splitStatements(migration.Up) {
+		if _, err := m.handler.Execute(ctx, statement); err != nil && !m.adoption.alreadyApplied(err) {
+			return err
+		}
+	}
+
+	_, err := m.handler.Execute(ctx, "INSERT INTO schema_migrations (version, name) VALUES (?, ?)", migration.Version, migration.Name)
+	return err
+}
+
+// 行末のセミコロンで文を区切る。コメントのみの部分は除く
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); hasSQL(statement) {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(script, "\n") {
+		current.WriteString(line)
+		if strings.HasSuffix(strings.TrimSpace(line), ";") {
+			flush()
+		}
+	}
+	flush()
+
+	return statements
+}
+
+func hasSQL(statement string) bool {
+	for _, line := range strings.Split(statement, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "--") {
+			return true
+		}
+	}
+	return false
+}
+
+func detectMySQLLegacySchema(ctx context.Context, h database.SqlHandler) (bool, error) {
+	rows, err := h.Query(ctx, "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name IN ('items', 'schema_migrations')")
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	tables := make(map[string]bool)
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return false, err
+		}
+		tables[table] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+
+	return tables["items"] && !tables["schema_migrations"], nil
+}
+
+// テーブル・列・インデックスが既に存在する場合のMySQLのエラー番号
+var mysqlAlreadyExistsErrors = []uint16{
+	1050, // ER_TABLE_EXISTS_ERROR
+	1060, // ER_DUP_FIELDNAME
+	1061, // ER_DUP_KEYNAME
+}
+
+func isMySQLAlreadyExists(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	for _, number := range mysqlAlreadyExistsErrors {
+		if mysqlErr.Number == number {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package migration
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+)
+
+func TestLoad(t *testing.T) {
+	latest := make(map[string]int64)
+	for _, backend := range []string{"mysql", "postgres", "sqlite"} {
+		t.Run(backend, func(t *testing.T) {
+			migrations, err := load(backend)
+			require.NoError(t, err)
+			require.NotEmpty(t, migrations)
+
+			for i := 1; i < len(migrations); i++ {
+				assert.Less(t, migrations[i-1].Version, migrations[i].Version)
+			}
+			latest[backend] = migrations[len(migrations)-1].Version
+		})
+	}
+
+	// 以降のマイグレーションで同じ版数を使えるよう、すべてのデータベースで最新の版数をそろえる
+	assert.Equal(t, latest["mysql"], latest["postgres"])
+	assert.Equal(t, latest["mysql"], latest["sqlite"])
+
+	_, err := load("oracle")
+	assert.Error(t, err)
+}
+
+func TestMigrator_SQLite(t *testing.T) {
+	ctx := context.Background()
+	handler, err := databaseInfra.OpenSQLite(filepath.Join(t.TempDir(), "items.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { handler.Close() })
+
+	migrator, err := New(handler, "sqlite")
+	require.NoError(t, err)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(migrator.migrations), Pending(statuses))
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(migrator.migrations))
+
+	var count int
+	require.NoError(t, handler.Conn.QueryRow("SELECT COUNT(*) FROM categories").Scan(&count))
+	assert.Equal(t, 5, count)
+
+	// 適用済みの場合は何もしない
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, Pending(statuses))
+	for _, status := range statuses {
+		assert.True(t, status.Applied)
+		assert.NotNil(t, status.AppliedAt)
+	}
+
+	rolledBack, err := migrator.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, rolledBack, 1)
+	assert.Equal(t, migrator.migrations[len(migrator.migrations)-1].Version, rolledBack[0].Version)
+
+	var columns int
+	require.NoError(t, handler.Conn.QueryRow("SELECT COUNT(*) FROM pragma_table_info('idempotency_keys') WHERE name = 'user_id'").Scan(&columns))
+	assert.Zero(t, columns)
+
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, Pending(statuses))
+
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, 1)
+}
+
+// マイグレーション導入前のinit_sqlite.sqlで作成したデータベースにもそのまま適用できる
+func TestMigrator_SQLite_ExistingSchema(t *testing.T) {
+	ctx := context.Background()
+	handler, err := databaseInfra.OpenSQLite(filepath.Join(t.TempDir(), "items.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { handler.Close() })
+
+	migrator, err := New(handler, "sqlite")
+	require.NoError(t, err)
+
+	// init_sqlite.sqlは最初のマイグレーションと同じスキーマ
+	_, err = handler.Conn.Exec(migrator.migrations[0].Up)
+	require.NoError(t, err)
+	_, err = handler.Conn.Exec("INSERT INTO items (name, category, brand, purchase_date) VALUES ('ロレックス デイトナ', '時計', 'ROLEX', '2023-01-15')")
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	var count int
+	require.NoError(t, handler.Conn.QueryRow("SELECT COUNT(*) FROM items").Scan(&count))
+	assert.Equal(t, 1, count)
+	// 既存のアイテムは既定のユーザーに割り当てる
+	var userID int64
+	require.NoError(t, handler.Conn.QueryRow("SELECT user_id FROM items").Scan(&userID))
+	assert.Equal(t, int64(1), userID)
+	// 既定のユーザーは管理者にする
+	var role string
+	require.NoError(t, handler.Conn.QueryRow("SELECT role FROM users WHERE id = 1").Scan(&role))
+	assert.Equal(t, "admin", role)
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `-- コメントのみの行
+ALTER TABLE items ADD COLUMN notes VARCHAR(2000) NOT NULL DEFAULT '' COMMENT 'a; b';
+-- 複数行の文
+CREATE TABLE tags (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY
+);
+
+-- 末尾のコメント
+`
+
+	assert.Equal(t, []string{
+		"-- コメントのみの行\nALTER TABLE items ADD COLUMN notes VARCHAR(2000) NOT NULL DEFAULT '' COMMENT 'a; b';",
+		"-- 複数行の文\nCREATE TABLE tags (\n    id BIGINT AUTO_INCREMENT PRIMARY KEY\n);",
+	}, splitStatements(script))
+}
+
+func TestSplitStatements_MySQLMigrations(t *testing.T) {
+	migrations, err := load("mysql")
+	require.NoError(t, err)
+
+	// 導入前のデータベースでは文ごとに実行するため、どのマイグレーションも1つ以上の文に分けられる
+	for _, migration := range migrations {
+		assert.NotEmpty(t, splitStatements(migration.Up), "%d_%s", migration.Version, migration.Name)
+	}
+}
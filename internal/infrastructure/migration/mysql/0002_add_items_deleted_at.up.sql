@@ -0,0 +1,2 @@
+ALTER TABLE items ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL COMMENT 'Soft delete timestamp (NULL if not deleted)' AFTER updated_at;
+ALTER TABLE items ADD INDEX idx_deleted_at (deleted_at);
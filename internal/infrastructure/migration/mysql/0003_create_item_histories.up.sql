@@ -0,0 +1,12 @@
+-- Create item_histories table for recording item changes
+-- items への外部キーは設けず、物理削除後も履歴を参照できるようにする
+CREATE TABLE item_histories (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Changed item ID',
+    action VARCHAR(20) NOT NULL COMMENT 'Change action: update, delete, restore, hard_delete',
+    before_snapshot JSON NULL COMMENT 'Item snapshot before the change',
+    after_snapshot JSON NULL COMMENT 'Item snapshot after the change (NULL for hard delete)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_id (item_id, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for recording item change history';
@@ -0,0 +1,12 @@
+-- Create item_images table for item photos
+-- 物理削除されたアイテムの画像レコードは外部キーで連鎖削除する
+CREATE TABLE item_images (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Item ID',
+    url VARCHAR(500) NOT NULL COMMENT 'Public URL of the image',
+    display_order INT NOT NULL COMMENT 'Display order (ascending)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_id_display_order (item_id, display_order),
+    CONSTRAINT fk_item_images_item_id FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for managing item images';
@@ -0,0 +1,2 @@
+ALTER TABLE items MODIFY COLUMN category VARCHAR(50) NOT NULL COMMENT 'Item category: 時計, バッグ, ジュエリー, 靴, その他';
+DROP TABLE categories;
@@ -0,0 +1,19 @@
+-- Create categories table for managing item categories
+CREATE TABLE categories (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(50) NOT NULL COMMENT 'Category name',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    UNIQUE KEY uk_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for managing item categories';
+
+-- 初期カテゴリー。登録済みの場合は何もしない
+INSERT IGNORE INTO categories (name) VALUES
+('時計'),
+('バッグ'),
+('ジュエリー'),
+('靴'),
+('その他');
+
+ALTER TABLE items MODIFY COLUMN category VARCHAR(50) NOT NULL COMMENT 'Item category (name in categories table)';
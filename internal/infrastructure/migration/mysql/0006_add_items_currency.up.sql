@@ -0,0 +1 @@
+ALTER TABLE items ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'JPY' COMMENT 'ISO 4217 currency code of purchase_price' AFTER purchase_price;
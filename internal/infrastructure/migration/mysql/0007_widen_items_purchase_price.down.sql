@@ -0,0 +1,2 @@
+-- INTの範囲を超える購入価格がある場合は失敗する
+ALTER TABLE items MODIFY COLUMN purchase_price INT NOT NULL DEFAULT 0 COMMENT 'Purchase price in yen';
@@ -0,0 +1 @@
+ALTER TABLE items MODIFY COLUMN purchase_price BIGINT NOT NULL DEFAULT 0 COMMENT 'Purchase price in the currency below';
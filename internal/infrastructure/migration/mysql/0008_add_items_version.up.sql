@@ -0,0 +1 @@
+ALTER TABLE items ADD COLUMN version BIGINT NOT NULL DEFAULT 1 COMMENT 'Optimistic lock version, incremented on every update' AFTER purchase_date;
@@ -0,0 +1,10 @@
+-- Create idempotency_keys table for deduplicating retried item creations
+-- 主キーの一意制約で同じキーの同時リクエストを防ぐ。有効期限を過ぎた行は定期的に削除する
+CREATE TABLE idempotency_keys (
+    idempotency_key VARCHAR(255) NOT NULL PRIMARY KEY COMMENT 'Value of the Idempotency-Key header',
+    request_hash CHAR(64) NOT NULL COMMENT 'SHA-256 hash of the request payload',
+    item_id BIGINT NOT NULL COMMENT 'ID of the item created by the first request',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for idempotency keys of item creation';
@@ -0,0 +1,3 @@
+ALTER TABLE items ADD COLUMN serial_number VARCHAR(64) NULL DEFAULT NULL COMMENT 'Serial number (NULL if not registered)' AFTER purchase_date;
+-- NULLは重複とみなされないため、シリアル番号のないアイテムはいくつでも登録できる
+ALTER TABLE items ADD UNIQUE KEY uk_serial_number (serial_number);
@@ -0,0 +1 @@
+ALTER TABLE items ADD COLUMN notes VARCHAR(2000) NOT NULL DEFAULT '' COMMENT 'Free-form notes such as provenance, repairs and storage location' AFTER serial_number;
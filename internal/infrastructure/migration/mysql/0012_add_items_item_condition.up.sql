@@ -0,0 +1,3 @@
+-- conditionはMySQLの予約語のため列名をitem_conditionとする
+ALTER TABLE items ADD COLUMN item_condition VARCHAR(10) NULL DEFAULT NULL COMMENT 'Item condition (新品, 未使用, 中古A, 中古B, 中古C; NULL if not graded)' AFTER serial_number;
+ALTER TABLE items ADD INDEX idx_item_condition (item_condition);
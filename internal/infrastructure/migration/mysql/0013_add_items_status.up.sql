@@ -0,0 +1,2 @@
+ALTER TABLE items ADD COLUMN status VARCHAR(10) NOT NULL DEFAULT 'owned' COMMENT 'Ownership status (owned, listed, sold)' AFTER notes;
+ALTER TABLE items ADD INDEX idx_status (status);
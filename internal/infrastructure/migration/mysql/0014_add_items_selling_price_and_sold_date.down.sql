@@ -0,0 +1,2 @@
+ALTER TABLE items DROP COLUMN sold_date;
+ALTER TABLE items DROP COLUMN selling_price;
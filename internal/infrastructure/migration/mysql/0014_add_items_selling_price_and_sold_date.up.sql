@@ -0,0 +1,3 @@
+ALTER TABLE items ADD COLUMN selling_price BIGINT NULL DEFAULT NULL COMMENT 'Selling price in the currency of purchase_price (NULL if not sold)' AFTER status;
+ALTER TABLE items ADD COLUMN sold_date DATE NULL DEFAULT NULL COMMENT 'Sold date in YYYY-MM-DD format (NULL if not sold)' AFTER selling_price;
+ALTER TABLE items ADD INDEX idx_sold_date (sold_date);
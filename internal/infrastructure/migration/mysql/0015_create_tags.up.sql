@@ -0,0 +1,20 @@
+-- Create tags and item_tags tables for free-form item tags
+-- utf8mb4_unicode_ciでは「ハハ」と「パパ」のように濁点の有無を区別しないため、タグ名はバイナリで比較する
+CREATE TABLE tags (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(30) NOT NULL COLLATE utf8mb4_bin COMMENT 'Tag name',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE KEY uk_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for managing item tags';
+
+-- どのアイテムにも付いていないタグは、アイテムからタグを外す際に削除する
+CREATE TABLE item_tags (
+    item_id BIGINT NOT NULL COMMENT 'Item ID',
+    tag_id BIGINT NOT NULL COMMENT 'Tag ID',
+
+    PRIMARY KEY (item_id, tag_id),
+    INDEX idx_tag_id (tag_id),
+    CONSTRAINT fk_item_tags_item_id FOREIGN KEY (item_id) REFERENCES items (id) ON DELETE CASCADE,
+    CONSTRAINT fk_item_tags_tag_id FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for associating tags with items';
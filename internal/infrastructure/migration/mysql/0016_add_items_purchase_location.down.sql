@@ -0,0 +1 @@
+ALTER TABLE items DROP COLUMN purchase_location;
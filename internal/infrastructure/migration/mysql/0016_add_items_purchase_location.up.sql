@@ -0,0 +1,3 @@
+-- 店舗ごとの集計で濁点の有無などを区別するため、バイナリで比較する
+ALTER TABLE items ADD COLUMN purchase_location VARCHAR(100) NULL DEFAULT NULL COLLATE utf8mb4_bin COMMENT 'Shop name where the item was purchased, whitespace-normalized (NULL if not registered)' AFTER notes;
+ALTER TABLE items ADD INDEX idx_purchase_location (purchase_location);
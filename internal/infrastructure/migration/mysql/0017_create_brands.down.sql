@@ -0,0 +1,2 @@
+DROP TABLE brand_aliases;
+DROP TABLE brands;
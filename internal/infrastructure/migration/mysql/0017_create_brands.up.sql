@@ -0,0 +1,21 @@
+-- Create brands and brand_aliases tables for canonical brand names
+-- 名前と別名はutf8mb4_unicode_ciで比較し、大文字小文字やアクセント記号の違う表記を同じブランドとみなす。
+-- 名前と別名の間の重複はアプリケーションで確認する
+CREATE TABLE brands (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL COMMENT 'Canonical brand name',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    UNIQUE KEY uk_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for managing canonical brand names';
+
+CREATE TABLE brand_aliases (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    brand_id BIGINT NOT NULL COMMENT 'Brand ID',
+    alias VARCHAR(100) NOT NULL COMMENT 'Alternative spelling or abbreviation of the brand name',
+
+    UNIQUE KEY uk_alias (alias),
+    INDEX idx_brand_id (brand_id),
+    CONSTRAINT fk_brand_aliases_brand_id FOREIGN KEY (brand_id) REFERENCES brands (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for managing brand aliases';
@@ -0,0 +1,3 @@
+ALTER TABLE categories DROP COLUMN name_en;
+ALTER TABLE categories DROP COLUMN slug;
+ALTER TABLE categories MODIFY COLUMN name VARCHAR(50) NOT NULL COMMENT 'Category name';
@@ -0,0 +1,16 @@
+ALTER TABLE categories ADD COLUMN slug VARCHAR(50) NOT NULL DEFAULT '' COMMENT 'Stable identifier of the category (lowercase letters, digits and hyphens)' AFTER id;
+ALTER TABLE categories ADD COLUMN name_en VARCHAR(50) NOT NULL DEFAULT '' COMMENT 'English label' AFTER name;
+ALTER TABLE categories MODIFY COLUMN name VARCHAR(50) NOT NULL COMMENT 'Category name (Japanese label stored in items.category)';
+
+-- 初期カテゴリーのスラッグと英語名。追加されたカテゴリーはIDから一意なスラッグを作り、英語名は日本語名のままにする
+UPDATE categories SET slug = 'watch', name_en = 'Watch' WHERE name = '時計' AND slug = '';
+UPDATE categories SET slug = 'bag', name_en = 'Bag' WHERE name = 'バッグ' AND slug = '';
+UPDATE categories SET slug = 'jewelry', name_en = 'Jewelry' WHERE name = 'ジュエリー' AND slug = '';
+UPDATE categories SET slug = 'shoes', name_en = 'Shoes' WHERE name = '靴' AND slug = '';
+UPDATE categories SET slug = 'other', name_en = 'Other' WHERE name = 'その他' AND slug = '';
+UPDATE categories SET slug = CONCAT('category-', id) WHERE slug = '';
+UPDATE categories SET name_en = name WHERE name_en = '';
+
+ALTER TABLE categories ALTER COLUMN slug DROP DEFAULT;
+ALTER TABLE categories ALTER COLUMN name_en DROP DEFAULT;
+ALTER TABLE categories ADD UNIQUE KEY uk_slug (slug);
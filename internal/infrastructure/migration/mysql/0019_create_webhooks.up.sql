@@ -0,0 +1,30 @@
+-- Create webhooks and webhook_deliveries tables for notifying item changes to external systems
+-- 連続して失敗した配信の回数がfailure_countで、上限に達したWebhookはenabledをFALSEにして送信を止める
+CREATE TABLE webhooks (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL COMMENT 'Endpoint that receives item events',
+    secret VARCHAR(255) NOT NULL COMMENT 'Key for the HMAC-SHA256 signature of the request body',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE COMMENT 'Whether events are sent to the endpoint',
+    failure_count INT NOT NULL DEFAULT 0 COMMENT 'Number of consecutive failed deliveries',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for managing webhook endpoints';
+
+-- 送信の1回ごとの記録。保持期間を過ぎた行は定期的に削除する
+CREATE TABLE webhook_deliveries (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    webhook_id BIGINT NOT NULL COMMENT 'Webhook ID',
+    event_id VARCHAR(64) NOT NULL COMMENT 'ID of the event, shared by the retries of a delivery',
+    event VARCHAR(50) NOT NULL COMMENT 'Event name such as item.created',
+    item_id BIGINT NOT NULL COMMENT 'ID of the item in the event',
+    attempt INT NOT NULL COMMENT 'Attempt number starting from 1',
+    status_code INT NULL DEFAULT NULL COMMENT 'HTTP status of the response, NULL when no response was received',
+    error VARCHAR(1000) NOT NULL DEFAULT '' COMMENT 'Reason of the failure',
+    succeeded BOOLEAN NOT NULL COMMENT 'Whether the endpoint returned a 2xx response',
+    duration_ms BIGINT NOT NULL COMMENT 'Time taken by the request in milliseconds',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_webhook_id (webhook_id, id),
+    INDEX idx_created_at (created_at),
+    CONSTRAINT fk_webhook_deliveries_webhook_id FOREIGN KEY (webhook_id) REFERENCES webhooks (id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for webhook delivery attempts';
@@ -0,0 +1,20 @@
+-- Create import_jobs table for CSV imports processed in the background
+-- アップロードされたファイルは保存しないため、サーバーの終了時に未完了のジョブはfailedにする
+CREATE TABLE import_jobs (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    status VARCHAR(20) NOT NULL COMMENT 'Job status: queued, running, done or failed',
+    best_effort BOOLEAN NOT NULL COMMENT 'Whether valid rows are imported even if other rows have errors',
+    dry_run BOOLEAN NOT NULL COMMENT 'Whether the file is validated without importing',
+    rows_processed INT NOT NULL DEFAULT 0 COMMENT 'Number of data rows parsed so far',
+    succeeded INT NOT NULL DEFAULT 0 COMMENT 'Number of imported rows',
+    failed INT NOT NULL DEFAULT 0 COMMENT 'Number of rows with errors',
+    row_errors JSON NULL COMMENT 'Errors of each invalid row',
+    error VARCHAR(1000) NOT NULL DEFAULT '' COMMENT 'Reason of the failure of the job',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    started_at TIMESTAMP NULL DEFAULT NULL COMMENT 'Time when a worker started the job',
+    finished_at TIMESTAMP NULL DEFAULT NULL COMMENT 'Time when the job finished',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    INDEX idx_status (status),
+    INDEX idx_finished_at (finished_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for background CSV import jobs';
@@ -0,0 +1,11 @@
+-- Create api_keys table for authenticating API clients
+-- キーそのものは保存せず、SHA-256のハッシュ値で照合する。失効したキーはrevoked_atを設定して残す
+CREATE TABLE api_keys (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    label VARCHAR(100) NOT NULL COMMENT 'Purpose or owner of the key, recorded in logs',
+    key_hash CHAR(64) NOT NULL COMMENT 'Hex-encoded SHA-256 hash of the key',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    revoked_at TIMESTAMP NULL DEFAULT NULL COMMENT 'Time when the key was revoked',
+
+    UNIQUE KEY uk_key_hash (key_hash)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for API keys';
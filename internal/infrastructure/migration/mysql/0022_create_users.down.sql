@@ -0,0 +1,5 @@
+ALTER TABLE items DROP INDEX uk_serial_number, ADD UNIQUE KEY uk_serial_number (serial_number);
+ALTER TABLE items DROP INDEX idx_user_id;
+ALTER TABLE items DROP COLUMN user_id;
+DROP TABLE refresh_tokens;
+DROP TABLE users;
@@ -0,0 +1,33 @@
+-- Create users and refresh_tokens tables and assign every item to an owner
+-- パスワードはbcryptのハッシュ値で保存する。既存のアイテムはすべて既定のユーザー（ID 1）に割り当てる。
+-- 既定のユーザーはパスワードが空でログインできないため、main user passwdで設定してから使う
+CREATE TABLE users (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    email VARCHAR(254) NOT NULL COMMENT 'Login email address, stored in lower case',
+    password_hash VARCHAR(100) NOT NULL DEFAULT '' COMMENT 'bcrypt hash of the password (empty if login is disabled)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    UNIQUE KEY uk_email (email)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for users owning items';
+
+INSERT INTO users (id, email) VALUES (1, 'owner@localhost');
+
+-- トークンそのものは保存せず、SHA-256のハッシュ値で照合する。使用済み・失効したトークンはrevoked_atを設定して残す
+CREATE TABLE refresh_tokens (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL COMMENT 'User the token was issued to',
+    token_hash CHAR(64) NOT NULL COMMENT 'Hex-encoded SHA-256 hash of the token',
+    expires_at TIMESTAMP NOT NULL COMMENT 'Time after which the token cannot be used',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    revoked_at TIMESTAMP NULL DEFAULT NULL COMMENT 'Time when the token was used or revoked',
+
+    UNIQUE KEY uk_token_hash (token_hash),
+    INDEX idx_user_id (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for refresh tokens';
+
+ALTER TABLE items ADD COLUMN user_id BIGINT NOT NULL DEFAULT 1 COMMENT 'User who owns the item' AFTER id;
+ALTER TABLE items ADD INDEX idx_user_id (user_id);
+-- シリアル番号はユーザーごとに一意にし、ほかのユーザーのアイテムの有無が分からないようにする
+ALTER TABLE items DROP INDEX uk_serial_number, ADD UNIQUE KEY uk_serial_number (user_id, serial_number);
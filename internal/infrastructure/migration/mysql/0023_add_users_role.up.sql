@@ -0,0 +1,4 @@
+-- Add a role to users
+-- adminはすべてのユーザーのアイテムと管理者用のエンドポイントを扱える。移行前のデータを持つ既定のユーザー（ID 1）は管理者にする
+ALTER TABLE users ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'user' COMMENT 'user or admin' AFTER email;
+UPDATE users SET role = 'admin' WHERE id = 1;
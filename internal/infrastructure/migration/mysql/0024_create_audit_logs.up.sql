@@ -0,0 +1,14 @@
+-- Create audit_logs table recording who changed which item and from where
+-- 物理削除したアイテムの記録も残すため、item_idには外部キー制約を付けない
+CREATE TABLE audit_logs (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    actor VARCHAR(300) NOT NULL COMMENT 'Who made the change: user:<id>, api_key:<label> or anonymous',
+    action VARCHAR(20) NOT NULL COMMENT 'Operation: create, update, delete, import, restore or hard_delete',
+    item_id BIGINT NOT NULL COMMENT 'Changed item',
+    source_ip VARCHAR(45) NOT NULL DEFAULT '' COMMENT 'IP address of the client (empty if unknown)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Time when the change was made',
+
+    INDEX idx_actor (actor),
+    INDEX idx_action (action),
+    INDEX idx_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for the audit log of changes to items';
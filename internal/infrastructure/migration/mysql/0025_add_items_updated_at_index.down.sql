@@ -0,0 +1 @@
+ALTER TABLE items DROP INDEX idx_updated_at;
@@ -0,0 +1,3 @@
+-- Add an index on updated_at
+-- 一覧のLast-Modifiedに使う最新の更新日時を、行を読み込まずに取得するため
+ALTER TABLE items ADD INDEX idx_updated_at (updated_at);
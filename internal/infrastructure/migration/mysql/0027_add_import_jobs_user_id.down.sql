@@ -0,0 +1 @@
+ALTER TABLE import_jobs DROP COLUMN user_id;
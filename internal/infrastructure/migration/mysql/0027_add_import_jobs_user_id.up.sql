@@ -0,0 +1,3 @@
+-- Add an owner to import_jobs
+-- ジョブの結果は登録したユーザーのみが参照できる。移行前のジョブは既定のユーザー（ID 1）に割り当てる
+ALTER TABLE import_jobs ADD COLUMN user_id BIGINT NOT NULL DEFAULT 1 COMMENT 'User who submitted the job' AFTER id;
@@ -0,0 +1,4 @@
+-- ユーザーごとに一意なキーは全体で一意にできないため、期限内のキーも含めて削除する
+DELETE FROM idempotency_keys;
+ALTER TABLE idempotency_keys DROP PRIMARY KEY, ADD PRIMARY KEY (idempotency_key);
+ALTER TABLE idempotency_keys DROP COLUMN user_id;
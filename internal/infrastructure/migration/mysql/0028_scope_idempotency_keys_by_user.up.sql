@@ -0,0 +1,5 @@
+-- Make idempotency keys unique per user
+-- キーはユーザーごとに一意にし、ほかのユーザーが使ったキーの有無が分からないようにする。移行前のキーは作成したアイテムの所有者に割り当てる
+ALTER TABLE idempotency_keys ADD COLUMN user_id BIGINT NOT NULL DEFAULT 1 COMMENT 'Owner of the item created with the key' FIRST;
+UPDATE idempotency_keys k JOIN items i ON i.id = k.item_id SET k.user_id = i.user_id;
+ALTER TABLE idempotency_keys DROP PRIMARY KEY, ADD PRIMARY KEY (user_id, idempotency_key);
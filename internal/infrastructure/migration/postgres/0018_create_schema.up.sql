@@ -0,0 +1,152 @@
+-- PostgreSQL用のスキーマ。MySQLの0001〜0018を適用した状態と同じテーブルを作成する。
+-- 版数をMySQLに合わせ、以降のマイグレーションはすべてのデータベースで同じ版数を使う。
+-- 導入前のinit_postgres.sqlで作成したデータベースにもそのまま適用できるよう、IF NOT EXISTSで作成する
+-- MySQLのutf8mb4_unicode_ciで比較している名前・ブランド・シリアル番号はcitextとし、大文字小文字を区別しない
+-- utf8mb4_binで比較している列はCOLLATE "C"とし、バイナリ順で並べる
+CREATE EXTENSION IF NOT EXISTS citext;
+
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    slug VARCHAR(50) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    name_en VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uk_categories_slug UNIQUE (slug),
+    CONSTRAINT uk_categories_name UNIQUE (name)
+);
+
+-- 初期カテゴリー。登録済みの場合は何もしない
+INSERT INTO categories (slug, name, name_en) VALUES
+('watch', '時計', 'Watch'),
+('bag', 'バッグ', 'Bag'),
+('jewelry', 'ジュエリー', 'Jewelry'),
+('shoes', '靴', 'Shoes'),
+('other', 'その他', 'Other')
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS items (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name CITEXT NOT NULL,
+    category VARCHAR(50) NOT NULL,
+    brand CITEXT NOT NULL,
+    purchase_price BIGINT NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL DEFAULT 'JPY',
+    purchase_date DATE NOT NULL,
+    serial_number CITEXT NULL DEFAULT NULL,
+    item_condition VARCHAR(10) NULL DEFAULT NULL,
+    notes VARCHAR(2000) NOT NULL DEFAULT '',
+    -- 店舗ごとの集計で濁点の有無などを区別するため、バイナリで比較する
+    purchase_location VARCHAR(100) COLLATE "C" NULL DEFAULT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'owned',
+    selling_price BIGINT NULL DEFAULT NULL,
+    sold_date DATE NULL DEFAULT NULL,
+    version BIGINT NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ NULL DEFAULT NULL,
+
+    -- NULLは重複とみなされないため、シリアル番号のないアイテムはいくつでも登録できる
+    CONSTRAINT uk_serial_number UNIQUE (serial_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_items_category ON items (category);
+CREATE INDEX IF NOT EXISTS idx_items_brand ON items (brand);
+CREATE INDEX IF NOT EXISTS idx_items_purchase_date ON items (purchase_date);
+CREATE INDEX IF NOT EXISTS idx_items_purchase_location ON items (purchase_location);
+CREATE INDEX IF NOT EXISTS idx_items_item_condition ON items (item_condition);
+CREATE INDEX IF NOT EXISTS idx_items_status ON items (status);
+CREATE INDEX IF NOT EXISTS idx_items_sold_date ON items (sold_date);
+CREATE INDEX IF NOT EXISTS idx_items_created_at ON items (created_at);
+CREATE INDEX IF NOT EXISTS idx_items_deleted_at ON items (deleted_at);
+
+CREATE TABLE IF NOT EXISTS item_images (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    item_id BIGINT NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+    url VARCHAR(500) NOT NULL,
+    display_order INT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_images_item_id_display_order ON item_images (item_id, display_order);
+
+-- タグ名はバイナリで比較する
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name VARCHAR(30) COLLATE "C" NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uk_tags_name UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS item_tags (
+    item_id BIGINT NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+
+    PRIMARY KEY (item_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_tags_tag_id ON item_tags (tag_id);
+
+CREATE TABLE IF NOT EXISTS brands (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name CITEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uk_brands_name UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS brand_aliases (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    brand_id BIGINT NOT NULL REFERENCES brands (id) ON DELETE CASCADE,
+    alias CITEXT NOT NULL,
+
+    CONSTRAINT uk_alias UNIQUE (alias)
+);
+
+CREATE INDEX IF NOT EXISTS idx_brand_aliases_brand_id ON brand_aliases (brand_id);
+
+-- items への外部キーは設けず、物理削除後も履歴を参照できるようにする
+CREATE TABLE IF NOT EXISTS item_histories (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    item_id BIGINT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    before_snapshot JSON NULL,
+    after_snapshot JSON NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_histories_item_id ON item_histories (item_id, id);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key VARCHAR(255) NOT NULL PRIMARY KEY,
+    request_hash CHAR(64) NOT NULL,
+    item_id BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys (created_at);
+
+-- MySQLのON UPDATE CURRENT_TIMESTAMPの代わりに、updated_atを変更しない更新でも更新日時を進める
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS trigger AS $$
+BEGIN
+    IF NEW.updated_at IS NOT DISTINCT FROM OLD.updated_at THEN
+        NEW.updated_at = CURRENT_TIMESTAMP;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_categories_updated_at ON categories;
+CREATE TRIGGER trg_categories_updated_at BEFORE UPDATE ON categories
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+DROP TRIGGER IF EXISTS trg_items_updated_at ON items;
+CREATE TRIGGER trg_items_updated_at BEFORE UPDATE ON items
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+DROP TRIGGER IF EXISTS trg_brands_updated_at ON brands;
+CREATE TRIGGER trg_brands_updated_at BEFORE UPDATE ON brands
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,32 @@
+-- 連続して失敗した配信の回数がfailure_countで、上限に達したWebhookはenabledをFALSEにして送信を止める
+CREATE TABLE IF NOT EXISTS webhooks (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    failure_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 送信の1回ごとの記録。保持期間を過ぎた行は定期的に削除する
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id VARCHAR(64) NOT NULL,
+    event VARCHAR(50) NOT NULL,
+    item_id BIGINT NOT NULL,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER NULL DEFAULT NULL,
+    error VARCHAR(1000) NOT NULL DEFAULT '',
+    succeeded BOOLEAN NOT NULL,
+    duration_ms BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id, id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created_at ON webhook_deliveries (created_at);
+
+DROP TRIGGER IF EXISTS trg_webhooks_updated_at ON webhooks;
+CREATE TRIGGER trg_webhooks_updated_at BEFORE UPDATE ON webhooks
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,23 @@
+-- アップロードされたファイルは保存しないため、サーバーの終了時に未完了のジョブはfailedにする
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    status VARCHAR(20) NOT NULL,
+    best_effort BOOLEAN NOT NULL,
+    dry_run BOOLEAN NOT NULL,
+    rows_processed INTEGER NOT NULL DEFAULT 0,
+    succeeded INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    row_errors JSON NULL,
+    error VARCHAR(1000) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMPTZ NULL DEFAULT NULL,
+    finished_at TIMESTAMPTZ NULL DEFAULT NULL,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_status ON import_jobs (status);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_finished_at ON import_jobs (finished_at);
+
+DROP TRIGGER IF EXISTS trg_import_jobs_updated_at ON import_jobs;
+CREATE TRIGGER trg_import_jobs_updated_at BEFORE UPDATE ON import_jobs
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
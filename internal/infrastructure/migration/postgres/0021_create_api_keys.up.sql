@@ -0,0 +1,10 @@
+-- キーそのものは保存せず、SHA-256のハッシュ値で照合する。失効したキーはrevoked_atを設定して残す
+CREATE TABLE IF NOT EXISTS api_keys (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    label VARCHAR(100) NOT NULL,
+    key_hash CHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMPTZ NULL DEFAULT NULL,
+
+    CONSTRAINT uk_api_keys_key_hash UNIQUE (key_hash)
+);
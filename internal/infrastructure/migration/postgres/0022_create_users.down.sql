@@ -0,0 +1,6 @@
+ALTER TABLE items DROP CONSTRAINT uk_serial_number;
+ALTER TABLE items ADD CONSTRAINT uk_serial_number UNIQUE (serial_number);
+DROP INDEX IF EXISTS idx_items_user_id;
+ALTER TABLE items DROP COLUMN user_id;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS users;
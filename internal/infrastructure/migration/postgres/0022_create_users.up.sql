@@ -0,0 +1,35 @@
+-- パスワードはbcryptのハッシュ値で保存する。既存のアイテムはすべて既定のユーザー（ID 1）に割り当てる。
+-- 既定のユーザーはパスワードが空でログインできないため、main user passwdで設定してから使う
+CREATE TABLE IF NOT EXISTS users (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    email VARCHAR(254) NOT NULL,
+    password_hash VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uk_users_email UNIQUE (email)
+);
+
+INSERT INTO users (id, email) VALUES (1, 'owner@localhost');
+-- IDを指定して登録しても採番は進まないため、次のユーザーが2から採番されるようにする
+SELECT setval(pg_get_serial_sequence('users', 'id'), 1);
+
+-- トークンそのものは保存せず、SHA-256のハッシュ値で照合する。使用済み・失効したトークンはrevoked_atを設定して残す
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMPTZ NULL DEFAULT NULL,
+
+    CONSTRAINT uk_refresh_tokens_token_hash UNIQUE (token_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+
+ALTER TABLE items ADD COLUMN user_id BIGINT NOT NULL DEFAULT 1;
+CREATE INDEX IF NOT EXISTS idx_items_user_id ON items (user_id);
+-- シリアル番号はユーザーごとに一意にし、ほかのユーザーのアイテムの有無が分からないようにする
+ALTER TABLE items DROP CONSTRAINT uk_serial_number;
+ALTER TABLE items ADD CONSTRAINT uk_serial_number UNIQUE (user_id, serial_number);
@@ -0,0 +1,13 @@
+-- 物理削除したアイテムの記録も残すため、item_idには外部キー制約を付けない
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    actor VARCHAR(300) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    item_id BIGINT NOT NULL,
+    source_ip VARCHAR(45) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON audit_logs (actor);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs (action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
@@ -0,0 +1,2 @@
+-- 一覧のLast-Modifiedに使う最新の更新日時を、行を読み込まずに取得するため
+CREATE INDEX IF NOT EXISTS idx_items_updated_at ON items (updated_at);
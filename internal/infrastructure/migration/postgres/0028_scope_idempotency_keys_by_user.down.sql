@@ -0,0 +1,5 @@
+-- ユーザーごとに一意なキーは全体で一意にできないため、期限内のキーも含めて削除する
+DELETE FROM idempotency_keys;
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (idempotency_key);
+ALTER TABLE idempotency_keys DROP COLUMN user_id;
@@ -0,0 +1,5 @@
+-- キーはユーザーごとに一意にし、ほかのユーザーが使ったキーの有無が分からないようにする。移行前のキーは作成したアイテムの所有者に割り当てる
+ALTER TABLE idempotency_keys ADD COLUMN user_id BIGINT NOT NULL DEFAULT 1;
+UPDATE idempotency_keys k SET user_id = i.user_id FROM items i WHERE i.id = k.item_id;
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (user_id, idempotency_key);
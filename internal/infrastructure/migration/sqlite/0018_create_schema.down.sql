@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS idempotency_keys;
+DROP TABLE IF EXISTS item_histories;
+DROP TABLE IF EXISTS brand_aliases;
+DROP TABLE IF EXISTS brands;
+DROP TABLE IF EXISTS item_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS item_images;
+DROP TABLE IF EXISTS items;
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,145 @@
+-- SQLite用のスキーマ。MySQLの0001〜0018を適用した状態と同じテーブルを作成する。
+-- 版数をMySQLに合わせ、以降のマイグレーションはすべてのデータベースで同じ版数を使う。
+-- 導入前のinit_sqlite.sqlで作成したデータベースにもそのまま適用できるよう、IF NOT EXISTSで作成する
+-- MySQLのutf8mb4_unicode_ciで比較している列はCOLLATE NOCASEとし、大文字小文字を区別しない（NOCASEはASCIIの範囲のみ）
+-- DATE・TIMESTAMPの列はTEXTとして保存し、時刻はUTCの「YYYY-MM-DD HH:MM:SS」形式とする
+
+CREATE TABLE IF NOT EXISTS categories (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    slug TEXT NOT NULL COLLATE NOCASE,
+    name TEXT NOT NULL COLLATE NOCASE,
+    name_en TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_categories_slug ON categories (slug);
+CREATE UNIQUE INDEX IF NOT EXISTS uk_categories_name ON categories (name);
+
+-- 初期カテゴリー。登録済みの場合は何もしない
+INSERT OR IGNORE INTO categories (slug, name, name_en) VALUES
+('watch', '時計', 'Watch'),
+('bag', 'バッグ', 'Bag'),
+('jewelry', 'ジュエリー', 'Jewelry'),
+('shoes', '靴', 'Shoes'),
+('other', 'その他', 'Other');
+
+CREATE TABLE IF NOT EXISTS items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL COLLATE NOCASE,
+    category TEXT NOT NULL,
+    brand TEXT NOT NULL COLLATE NOCASE,
+    purchase_price INTEGER NOT NULL DEFAULT 0,
+    currency TEXT NOT NULL DEFAULT 'JPY',
+    purchase_date DATE NOT NULL,
+    serial_number TEXT NULL DEFAULT NULL COLLATE NOCASE,
+    item_condition TEXT NULL DEFAULT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    -- 店舗ごとの集計で濁点の有無などを区別するため、バイナリで比較する
+    purchase_location TEXT NULL DEFAULT NULL,
+    status TEXT NOT NULL DEFAULT 'owned',
+    selling_price INTEGER NULL DEFAULT NULL,
+    sold_date DATE NULL DEFAULT NULL,
+    version INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_items_category ON items (category);
+CREATE INDEX IF NOT EXISTS idx_items_brand ON items (brand);
+CREATE INDEX IF NOT EXISTS idx_items_purchase_date ON items (purchase_date);
+CREATE INDEX IF NOT EXISTS idx_items_purchase_location ON items (purchase_location);
+CREATE INDEX IF NOT EXISTS idx_items_item_condition ON items (item_condition);
+CREATE INDEX IF NOT EXISTS idx_items_status ON items (status);
+CREATE INDEX IF NOT EXISTS idx_items_sold_date ON items (sold_date);
+CREATE INDEX IF NOT EXISTS idx_items_created_at ON items (created_at);
+CREATE INDEX IF NOT EXISTS idx_items_deleted_at ON items (deleted_at);
+-- NULLは重複とみなされないため、シリアル番号のないアイテムはいくつでも登録できる
+CREATE UNIQUE INDEX IF NOT EXISTS uk_serial_number ON items (serial_number);
+
+CREATE TABLE IF NOT EXISTS item_images (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    display_order INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_images_item_id_display_order ON item_images (item_id, display_order);
+
+-- タグ名はバイナリで比較する
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_tags_name ON tags (name);
+
+CREATE TABLE IF NOT EXISTS item_tags (
+    item_id INTEGER NOT NULL REFERENCES items (id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+
+    PRIMARY KEY (item_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_tags_tag_id ON item_tags (tag_id);
+
+CREATE TABLE IF NOT EXISTS brands (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL COLLATE NOCASE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_brands_name ON brands (name);
+
+CREATE TABLE IF NOT EXISTS brand_aliases (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    brand_id INTEGER NOT NULL REFERENCES brands (id) ON DELETE CASCADE,
+    alias TEXT NOT NULL COLLATE NOCASE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_alias ON brand_aliases (alias);
+CREATE INDEX IF NOT EXISTS idx_brand_aliases_brand_id ON brand_aliases (brand_id);
+
+-- items への外部キーは設けず、物理削除後も履歴を参照できるようにする
+CREATE TABLE IF NOT EXISTS item_histories (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    item_id INTEGER NOT NULL,
+    action TEXT NOT NULL,
+    before_snapshot TEXT NULL,
+    after_snapshot TEXT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_item_histories_item_id ON item_histories (item_id, id);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key TEXT NOT NULL PRIMARY KEY,
+    request_hash TEXT NOT NULL,
+    item_id INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys (created_at);
+
+-- MySQLのON UPDATE CURRENT_TIMESTAMPの代わりに、updated_atを変更しない更新でも更新日時を進める
+CREATE TRIGGER IF NOT EXISTS trg_categories_updated_at AFTER UPDATE ON categories
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE categories SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_items_updated_at AFTER UPDATE ON items
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE items SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trg_brands_updated_at AFTER UPDATE ON brands
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE brands SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
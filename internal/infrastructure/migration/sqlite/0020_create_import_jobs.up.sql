@@ -0,0 +1,25 @@
+-- アップロードされたファイルは保存しないため、サーバーの終了時に未完了のジョブはfailedにする
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    status TEXT NOT NULL,
+    best_effort INTEGER NOT NULL,
+    dry_run INTEGER NOT NULL,
+    rows_processed INTEGER NOT NULL DEFAULT 0,
+    succeeded INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    row_errors TEXT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP NULL DEFAULT NULL,
+    finished_at TIMESTAMP NULL DEFAULT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_status ON import_jobs (status);
+CREATE INDEX IF NOT EXISTS idx_import_jobs_finished_at ON import_jobs (finished_at);
+
+CREATE TRIGGER IF NOT EXISTS trg_import_jobs_updated_at AFTER UPDATE ON import_jobs
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE import_jobs SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
@@ -0,0 +1,8 @@
+-- キーそのものは保存せず、SHA-256のハッシュ値で照合する。失効したキーはrevoked_atを設定して残す
+CREATE TABLE IF NOT EXISTS api_keys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    label TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP NULL DEFAULT NULL
+);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS uk_serial_number;
+CREATE UNIQUE INDEX IF NOT EXISTS uk_serial_number ON items (serial_number);
+DROP INDEX IF EXISTS idx_items_user_id;
+ALTER TABLE items DROP COLUMN user_id;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,29 @@
+-- パスワードはbcryptのハッシュ値で保存する。既存のアイテムはすべて既定のユーザー（ID 1）に割り当てる。
+-- 既定のユーザーはパスワードが空でログインできないため、main user passwdで設定してから使う
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    email TEXT NOT NULL UNIQUE,
+    password_hash TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT OR IGNORE INTO users (id, email) VALUES (1, 'owner@localhost');
+
+-- トークンそのものは保存せず、SHA-256のハッシュ値で照合する。使用済み・失効したトークンはrevoked_atを設定して残す
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP NULL DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+
+ALTER TABLE items ADD COLUMN user_id INTEGER NOT NULL DEFAULT 1;
+CREATE INDEX IF NOT EXISTS idx_items_user_id ON items (user_id);
+-- シリアル番号はユーザーごとに一意にし、ほかのユーザーのアイテムの有無が分からないようにする
+DROP INDEX IF EXISTS uk_serial_number;
+CREATE UNIQUE INDEX IF NOT EXISTS uk_serial_number ON items (user_id, serial_number);
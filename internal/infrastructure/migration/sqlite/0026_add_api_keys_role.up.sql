@@ -0,0 +1,2 @@
+-- adminのキーのみ管理者用のエンドポイントとすべてのユーザーのアイテムを扱える。移行前のキーも一般の権限にし、必要なキーはmain apikey roleで変更する
+ALTER TABLE api_keys ADD COLUMN role TEXT NOT NULL DEFAULT 'user';
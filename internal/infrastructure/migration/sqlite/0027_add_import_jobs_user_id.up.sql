@@ -0,0 +1,2 @@
+-- ジョブの結果は登録したユーザーのみが参照できる。移行前のジョブは既定のユーザー（ID 1）に割り当てる
+ALTER TABLE import_jobs ADD COLUMN user_id INTEGER NOT NULL DEFAULT 1;
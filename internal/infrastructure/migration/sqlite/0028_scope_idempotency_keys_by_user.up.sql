@@ -0,0 +1,19 @@
+-- キーはユーザーごとに一意にし、ほかのユーザーが使ったキーの有無が分からないようにする。移行前のキーは作成したアイテムの所有者に割り当てる。
+-- SQLiteは主キーを変更できないため、テーブルを作り直す
+CREATE TABLE idempotency_keys_new (
+    user_id INTEGER NOT NULL DEFAULT 1,
+    idempotency_key TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    item_id INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (user_id, idempotency_key)
+);
+
+INSERT INTO idempotency_keys_new (user_id, idempotency_key, request_hash, item_id, created_at)
+SELECT COALESCE((SELECT i.user_id FROM items i WHERE i.id = k.item_id), 1), k.idempotency_key, k.request_hash, k.item_id, k.created_at
+FROM idempotency_keys k;
+
+DROP TABLE idempotency_keys;
+ALTER TABLE idempotency_keys_new RENAME TO idempotency_keys;
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys (created_at);
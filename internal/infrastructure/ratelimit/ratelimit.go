@@ -0,0 +1,101 @@
+// クライアントごとのリクエストの頻度の制限（トークンバケット）
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// キーごとにリクエストを受け付けるかどうかを判定する。
+// 1台のサーバーではMemoryを使い、複数のサーバーで状態を共有する場合はRedisなどで実装する
+type Limiter interface {
+	Allow(ctx context.Context, key string) (Decision, error)
+}
+
+// 判定の結果。レスポンスのX-RateLimit-*ヘッダーとRetry-Afterヘッダーに使う
+type Decision struct {
+	Allowed    bool
+	Limit      int           // 連続して受け付けられるリクエストの上限（バケットの容量）
+	Remaining  int           // 今受け付けられる残りのリクエスト数
+	RetryAfter time.Duration // 受け付けなかった場合に、次のリクエストを受け付けられるまでの時間
+	Reset      time.Duration // 残りのリクエスト数が上限まで戻るまでの時間
+}
+
+type Config struct {
+	Rate  float64 // 1秒あたりに補充するリクエスト数
+	Burst int     // 連続して受け付けられるリクエストの上限
+}
+
+// 使われていないバケットを削除する間隔
+const sweepInterval = time.Minute
+
+// プロセスのメモリ上でキーごとのトークンバケットを保持するLimiter
+type Memory struct {
+	cfg Config
+	now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+func NewMemory(cfg Config) *Memory {
+	return &Memory{cfg: cfg, now: time.Now, buckets: make(map[string]*bucket)}
+}
+
+// keyのバケットからトークンを1つ取り出せる場合に受け付ける
+func (m *Memory) Allow(ctx context.Context, key string) (Decision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	capacity := float64(m.cfg.Burst)
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updatedAt: now}
+		m.buckets[key] = b
+	}
+	// 前回から経過した時間の分だけ補充する
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updatedAt).Seconds()*m.cfg.Rate)
+	b.updatedAt = now
+
+	decision := Decision{Limit: m.cfg.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = m.duration(1 - b.tokens)
+	}
+	decision.Remaining = int(b.tokens)
+	decision.Reset = m.duration(capacity - b.tokens)
+	return decision, nil
+}
+
+// tokens個のトークンが補充されるまでの時間
+func (m *Memory) duration(tokens float64) time.Duration {
+	return time.Duration(math.Ceil(tokens / m.cfg.Rate * float64(time.Second)))
+}
+
+// 満杯に戻ったバケットは新しく作るのと同じため削除し、クライアントの数だけメモリが増え続けないようにする
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	refill := m.duration(float64(m.cfg.Burst))
+	for key, b := range m.buckets {
+		if now.Sub(b.updatedAt) >= refill {
+			delete(m.buckets, key)
+		}
+	}
+}
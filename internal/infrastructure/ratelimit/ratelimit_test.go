@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMemory(cfg Config) (*Memory, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewMemory(cfg)
+	m.now = func() time.Time { return now }
+	return m, &now
+}
+
+func TestMemory_Allow(t *testing.T) {
+	m, now := newTestMemory(Config{Rate: 2, Burst: 3})
+	ctx := context.Background()
+
+	// 上限まで連続して受け付ける
+	for i := 2; i >= 0; i-- {
+		d, err := m.Allow(ctx, "client")
+		require.NoError(t, err)
+		assert.True(t, d.Allowed)
+		assert.Equal(t, 3, d.Limit)
+		assert.Equal(t, i, d.Remaining)
+	}
+
+	d, err := m.Allow(ctx, "client")
+	require.NoError(t, err)
+	assert.False(t, d.Allowed)
+	assert.Equal(t, 0, d.Remaining)
+	// 1秒に2つ補充するため、次のトークンまで0.5秒、満杯まで1.5秒
+	assert.Equal(t, 500*time.Millisecond, d.RetryAfter)
+	assert.Equal(t, 1500*time.Millisecond, d.Reset)
+
+	// 他のキーには影響しない
+	d, err = m.Allow(ctx, "other")
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+
+	// 補充されると再び受け付ける
+	*now = now.Add(500 * time.Millisecond)
+	d, err = m.Allow(ctx, "client")
+	require.NoError(t, err)
+	assert.True(t, d.Allowed)
+	assert.Equal(t, 0, d.Remaining)
+}
+
+func TestMemory_Sweep(t *testing.T) {
+	m, now := newTestMemory(Config{Rate: 1, Burst: 5})
+	ctx := context.Background()
+
+	_, err := m.Allow(ctx, "idle")
+	require.NoError(t, err)
+	*now = now.Add(sweepInterval)
+	_, err = m.Allow(ctx, "active")
+	require.NoError(t, err)
+
+	// 満杯に戻ったバケットは削除する
+	assert.NotContains(t, m.buckets, "idle")
+	assert.Contains(t, m.buckets, "active")
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"Aicon-assignment/internal/infrastructure/interceptor"
+	"Aicon-assignment/internal/infrastructure/middleware"
+	"Aicon-assignment/internal/interfaces/rpc"
+	"Aicon-assignment/internal/interfaces/rpc/itemspb"
+	"Aicon-assignment/internal/usecase"
+)
+
+// gRPCのサーバーの認証。nilの場合は認証しない
+type grpcAuth struct {
+	apiKeys      middleware.APIKeyAuthenticator
+	accessTokens middleware.AccessTokenAuthenticator
+}
+
+// アイテムのサービスを登録したgRPCのサーバー。インターセプターはHTTPのミドルウェアと同じく、
+// リクエストID→アクセスログ→認証→panicの回復の順に外側から実行する
+func newGRPCServer(itemUsecase usecase.ItemUsecase, auth *grpcAuth, panics middleware.PanicObserver) *grpc.Server {
+	interceptors := []grpc.UnaryServerInterceptor{interceptor.RequestID(), interceptor.Logging()}
+	if auth != nil {
+		interceptors = append(interceptors, interceptor.Auth(auth.apiKeys, auth.accessTokens))
+	}
+	interceptors = append(interceptors, interceptor.Recover(panics))
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+	itemspb.RegisterItemServiceServer(srv, rpc.NewItemServer(itemUsecase))
+	return srv
+}
+
+// lnでgRPCの呼び出しを受け付け、SIGINT・SIGTERMを受け取るかctxがキャンセルされると新しい接続の受け付けをやめ、
+// 処理中の呼び出しの完了をdrainTimeoutまで待って戻る。待ちきれない場合は残りの呼び出しのctxをキャンセルして打ち切る
+func serveGRPC(ctx context.Context, srv *grpc.Server, ln net.Listener, drainTimeout time.Duration) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		slog.Info("🚀 gRPC server starting", "addr", ln.Addr().String())
+		errCh <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("grpc server stopped unexpectedly: %w", err)
+	case <-ctx.Done():
+		slog.Info("🛑 Shutting down gRPC server...", "drain_timeout", drainTimeout)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(drainTimeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+		slog.Info("✅ gRPC server exited gracefully")
+		return nil
+	case <-timer.C:
+		srv.Stop()
+		<-stopped
+		return fmt.Errorf("grpc server forced to shutdown after %s", drainTimeout)
+	}
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/rpc/itemspb"
+	"Aicon-assignment/internal/usecase"
+)
+
+// GetItemByIDの呼び出しをstartedで知らせ、releaseが閉じられるかctxがキャンセルされるまで待つユースケース
+type blockingUsecase struct {
+	usecase.ItemUsecase
+	started chan struct{}
+	release chan struct{}
+}
+
+func (u *blockingUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	close(u.started)
+	select {
+	case <-u.release:
+		return &entity.Item{ID: id}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// serveGRPCを起動し、つないだクライアントと、終了した結果を受け取るチャネルを返す
+func startGRPC(t *testing.T, ctx context.Context, itemUsecase usecase.ItemUsecase, drainTimeout time.Duration) (itemspb.ItemServiceClient, <-chan error) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	served := make(chan error, 1)
+	go func() { served <- serveGRPC(ctx, newGRPCServer(itemUsecase, nil, nil), ln, drainTimeout) }()
+
+	conn, err := grpc.NewClient(ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return itemspb.NewItemServiceClient(conn), served
+}
+
+func TestServeGRPC_CompletesInFlightCallOnShutdown(t *testing.T) {
+	u := &blockingUsecase{started: make(chan struct{}), release: make(chan struct{})}
+	ctx, cancel := context.WithCancel(context.Background())
+	client, served := startGRPC(t, ctx, u, 5*time.Second)
+
+	type result struct {
+		item *itemspb.Item
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		item, err := client.GetItem(context.Background(), &itemspb.GetItemRequest{Id: 1})
+		results <- result{item, err}
+	}()
+
+	// 呼び出しの処理中に終了を始め、終了を待っている間に処理を終える
+	<-u.started
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	close(u.release)
+
+	res := <-results
+	require.NoError(t, res.err)
+	assert.Equal(t, int64(1), res.item.GetId())
+	require.NoError(t, <-served)
+}
+
+func TestServeGRPC_DrainTimeout(t *testing.T) {
+	u := &blockingUsecase{started: make(chan struct{}), release: make(chan struct{})}
+	defer close(u.release)
+	ctx, cancel := context.WithCancel(context.Background())
+	client, served := startGRPC(t, ctx, u, 50*time.Millisecond)
+
+	go client.GetItem(context.Background(), &itemspb.GetItemRequest{Id: 1})
+	<-u.started
+	cancel()
+
+	// 処理中の呼び出しが終わらなくても、制限時間を過ぎると打ち切って戻る
+	select {
+	case err := <-served:
+		assert.ErrorContains(t, err, "forced to shutdown")
+	case <-time.After(5 * time.Second):
+		t.Fatal("serveGRPC did not return after the drain timeout")
+	}
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/system"
+	"Aicon-assignment/internal/interfaces/openapi"
+)
+
+// OpenAPIの文書を返すパス
+const openAPIPath = "/openapi.json"
+
+// バージョンのないパスの、ハンドラーのパッケージに属さないルートの操作
+func serverOperations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/metrics", ID: "metrics", Summary: "Prometheusの指標", Tags: []string{"system"},
+			Responses: openapi.Responses{http.StatusOK: openapi.Content("Prometheusのテキスト形式", "text/plain", openapi.String())},
+			Public:    true,
+		},
+		{
+			Method: http.MethodGet, Path: openAPIPath, ID: "openAPI", Summary: "このAPIのOpenAPI 3.0の文書", Tags: []string{"system"},
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("OpenAPIの文書", &openapi.Schema{Type: "object"})},
+			Public:    true,
+		},
+	}
+}
+
+// ハンドラーのパッケージが宣言した操作から作る、APIのバージョンごとのパスのOpenAPIの文書。
+// 非推奨のエイリアスのパスは載せない
+func openAPIDocument() *openapi.Document {
+	doc := openapi.NewDocument(openapi.Info{
+		Title:       "所持品管理API",
+		Version:     "1.0.0",
+		Description: "高級品やコレクションアイテムを管理するREST API",
+	})
+	doc.Add("", system.Operations()...)
+	doc.Add("", serverOperations()...)
+	for _, v := range apiVersions {
+		doc.Add(v.prefix, v.operations()...)
+	}
+	return doc
+}
+
+// 起動時に生成した文書を返すハンドラー
+func openAPIHandler(doc *openapi.Document) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, doc)
+	}
+}
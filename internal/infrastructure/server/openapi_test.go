@@ -0,0 +1,84 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 文書に載せた操作と、バージョンごとに登録したルートが一致することを確かめる。
+// ルートを追加して操作を宣言し忘れた場合や、宣言した操作のルートがない場合に失敗する
+func TestOpenAPIDocument_CoversVersionedRoutes(t *testing.T) {
+	e := echo.New()
+	registerRoutes(e, newTestHandlers())
+
+	param := regexp.MustCompile(`:(\w+)`)
+	var registered []string
+	for _, r := range e.Routes() {
+		if strings.HasPrefix(r.Path, "/api/") {
+			registered = append(registered, r.Method+" "+param.ReplaceAllString(r.Path, "{$1}"))
+		}
+	}
+	slices.Sort(registered)
+
+	var documented []string
+	for _, route := range openAPIDocument().Routes() {
+		if strings.Contains(route, " /api/") {
+			documented = append(documented, route)
+		}
+	}
+
+	assert.Equal(t, registered, documented)
+}
+
+func TestOpenAPIDocument_JSON(t *testing.T) {
+	body, err := json.Marshal(openAPIDocument())
+	require.NoError(t, err)
+
+	var doc struct {
+		OpenAPI    string                                `json:"openapi"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(body, &doc))
+
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	for _, name := range []string{"Item", "ValidationError", "Pagination", "Problem"} {
+		assert.Contains(t, doc.Components.Schemas, name)
+	}
+	assert.Contains(t, doc.Paths["/api/v1/items/{id}"], "patch")
+	assert.Contains(t, doc.Paths["/api/v2/items"], "get")
+	assert.Contains(t, doc.Paths, "/readyz")
+	// 非推奨のエイリアスは載せない
+	assert.NotContains(t, doc.Paths, "/items")
+	assert.NotContains(t, doc.Paths, "/v2/items")
+
+	// 同じ内容の文書を毎回生成する
+	again, err := json.Marshal(openAPIDocument())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(body), string(again))
+}
+
+func TestOpenAPIDocument_Served(t *testing.T) {
+	e := echo.New()
+	doc := openAPIDocument()
+	e.GET(openAPIPath, openAPIHandler(doc))
+
+	req := httptest.NewRequest(http.MethodGet, openAPIPath, nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+	assert.NoError(t, doc.ValidateResponse(http.MethodGet, openAPIPath, rec.Code, rec.Header().Get(echo.HeaderContentType), rec.Body.Bytes()))
+}
@@ -0,0 +1,216 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"Aicon-assignment/internal/infrastructure/cache"
+	"Aicon-assignment/internal/infrastructure/config"
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/infrastructure/migration"
+	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/interfaces/memory"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ユースケースに渡すリポジトリ
+type repositories struct {
+	item      usecase.ItemRepository
+	category  usecase.CategoryRepository
+	tag       usecase.TagRepository
+	brand     usecase.BrandRepository
+	webhook   usecase.WebhookRepository
+	importJob usecase.ImportJobRepository
+	apiKey    usecase.APIKeyRepository
+	user      usecase.UserRepository
+	audit     usecase.AuditLogRepository
+
+	transactor usecase.Transactor // 各リポジトリの呼び出しを1つのトランザクションにまとめる
+
+	dbStats func() sql.DBStats              // データベースの接続プールの統計。メモリ上のリポジトリではnil
+	dbPing  func(ctx context.Context) error // データベースに接続できるかどうかの確認。メモリ上のリポジトリではnil
+}
+
+// 保存先（cfg.Repository）に応じてリポジトリを作成する。closeで接続を閉じる。
+// SQLのデータベースに接続できない場合は、cfg.DBConnectAttempts回までやり直してからエラーを返す。
+// SQLのデータベースではcfg.AutoMigrateがtrueの場合に未適用のマイグレーションを適用し、失敗した場合はエラーを返す。
+// メモリ上のリポジトリではMigratorはnil
+func newRepositories(ctx context.Context, cfg *config.Config) (*repositories, *migration.Migrator, func() error, error) {
+	kind := cfg.Repository
+	if kind == "memory" {
+		slog.Warn("⚠️  メモリ上のリポジトリを使用します。データはサーバーの終了とともに失われます")
+		store := memory.NewSeededStore()
+		return &repositories{
+			item:      &memory.ItemRepository{Store: store},
+			category:  &memory.CategoryRepository{Store: store},
+			tag:       &memory.TagRepository{Store: store},
+			brand:     &memory.BrandRepository{Store: store},
+			webhook:   &memory.WebhookRepository{Store: store},
+			importJob: &memory.ImportJobRepository{Store: store},
+			apiKey:    &memory.APIKeyRepository{Store: store},
+			user:      &memory.UserRepository{Store: store},
+			audit:     &memory.AuditLogRepository{Store: store},
+
+			transactor: &memory.Transactor{Store: store},
+		}, nil, func() error { return nil }, nil
+	}
+
+	dbHandler, dialect, err := databaseInfra.NewHandler(ctx, kind, cfg.DatabaseDSN(), databaseInfra.ConnectConfig{Attempts: cfg.DBConnectAttempts, Interval: cfg.DBConnectInterval})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	migrator, err := migration.New(dbHandler, kind)
+	if err != nil {
+		dbHandler.Close()
+		return nil, nil, nil, err
+	}
+	if err := migrate(ctx, migrator, cfg.AutoMigrate); err != nil {
+		dbHandler.Close()
+		return nil, nil, nil, err
+	}
+
+	// リポジトリはTransactorを通して、WithinTxのトランザクション内ではそのトランザクションでクエリを実行する。
+	// デッドロックなど一時的なエラーの場合は、読み込みとトランザクション全体をやり直す
+	transactor := &itemDatabase.Transactor{SqlHandler: dbHandler, Dialect: dialect}
+	var dbStats func() sql.DBStats
+	if h, ok := dbHandler.(databaseInfra.StatsHandler); ok {
+		dbStats = h.Stats
+	}
+	var dbPing func(ctx context.Context) error
+	if h, ok := dbHandler.(databaseInfra.PingHandler); ok {
+		dbPing = h.Ping
+	}
+	return &repositories{
+		item:      &itemDatabase.ItemRepository{SqlHandler: transactor, Dialect: dialect},
+		category:  &itemDatabase.CategoryRepository{SqlHandler: transactor, Dialect: dialect},
+		tag:       &itemDatabase.TagRepository{SqlHandler: transactor},
+		brand:     &itemDatabase.BrandRepository{SqlHandler: transactor, Dialect: dialect},
+		webhook:   &itemDatabase.WebhookRepository{SqlHandler: transactor, Dialect: dialect},
+		importJob: &itemDatabase.ImportJobRepository{SqlHandler: transactor, Dialect: dialect},
+		apiKey:    &itemDatabase.APIKeyRepository{SqlHandler: transactor, Dialect: dialect},
+		user:      &itemDatabase.UserRepository{SqlHandler: transactor, Dialect: dialect},
+		audit:     &itemDatabase.AuditLogRepository{SqlHandler: transactor, Dialect: dialect},
+
+		transactor: transactor,
+		dbStats:    dbStats,
+		dbPing:     dbPing,
+	}, migrator, dbHandler.Close, nil
+}
+
+// 各リポジトリの呼び出しの所要時間をobserverに記録するリポジトリを返す
+func (r *repositories) withMetrics(observer usecase.QueryObserver) *repositories {
+	return &repositories{
+		item:      usecase.ItemRepositoryWithMetrics(r.item, observer),
+		category:  usecase.CategoryRepositoryWithMetrics(r.category, observer),
+		tag:       usecase.TagRepositoryWithMetrics(r.tag, observer),
+		brand:     usecase.BrandRepositoryWithMetrics(r.brand, observer),
+		webhook:   usecase.WebhookRepositoryWithMetrics(r.webhook, observer),
+		importJob: usecase.ImportJobRepositoryWithMetrics(r.importJob, observer),
+		apiKey:    usecase.APIKeyRepositoryWithMetrics(r.apiKey, observer),
+		user:      usecase.UserRepositoryWithMetrics(r.user, observer),
+		audit:     usecase.AuditLogRepositoryWithMetrics(r.audit, observer),
+
+		transactor: r.transactor,
+		dbStats:    r.dbStats,
+		dbPing:     r.dbPing,
+	}
+}
+
+// 各リポジトリの呼び出しをtracerのスパンで囲むリポジトリを返す
+func (r *repositories) withTracing(tracer usecase.Tracer) *repositories {
+	return &repositories{
+		item:      usecase.ItemRepositoryWithTracing(r.item, tracer),
+		category:  usecase.CategoryRepositoryWithTracing(r.category, tracer),
+		tag:       usecase.TagRepositoryWithTracing(r.tag, tracer),
+		brand:     usecase.BrandRepositoryWithTracing(r.brand, tracer),
+		webhook:   usecase.WebhookRepositoryWithTracing(r.webhook, tracer),
+		importJob: usecase.ImportJobRepositoryWithTracing(r.importJob, tracer),
+		apiKey:    usecase.APIKeyRepositoryWithTracing(r.apiKey, tracer),
+		user:      usecase.UserRepositoryWithTracing(r.user, tracer),
+		audit:     usecase.AuditLogRepositoryWithTracing(r.audit, tracer),
+
+		transactor: r.transactor,
+		dbStats:    r.dbStats,
+		dbPing:     r.dbPing,
+	}
+}
+
+// 各リポジトリの呼び出しにtimeoutの制限時間を設けたリポジトリを返す
+func (r *repositories) withTimeout(timeout time.Duration) *repositories {
+	return &repositories{
+		item:      usecase.ItemRepositoryWithTimeout(r.item, timeout),
+		category:  usecase.CategoryRepositoryWithTimeout(r.category, timeout),
+		tag:       usecase.TagRepositoryWithTimeout(r.tag, timeout),
+		brand:     usecase.BrandRepositoryWithTimeout(r.brand, timeout),
+		webhook:   usecase.WebhookRepositoryWithTimeout(r.webhook, timeout),
+		importJob: usecase.ImportJobRepositoryWithTimeout(r.importJob, timeout),
+		apiKey:    usecase.APIKeyRepositoryWithTimeout(r.apiKey, timeout),
+		user:      usecase.UserRepositoryWithTimeout(r.user, timeout),
+		audit:     usecase.AuditLogRepositoryWithTimeout(r.audit, timeout),
+
+		transactor: r.transactor,
+		dbStats:    r.dbStats,
+		dbPing:     r.dbPing,
+	}
+}
+
+// アイテムの読み込みをcacheにttlの間キャッシュし、ヒット・ミスをobserverに数えるリポジトリを返す。
+// アイテム・カテゴリー・ブランドの変更でキャッシュを破棄するため、3つをまとめて包む
+func (r *repositories) withCache(cache usecase.Cache, ttl time.Duration, observer usecase.CacheObserver) *repositories {
+	itemCache := usecase.NewItemCache(cache, ttl, observer)
+	cached := *r
+	cached.item = itemCache.ItemRepository(r.item)
+	cached.category = itemCache.CategoryRepository(r.category)
+	cached.brand = itemCache.BrandRepository(r.brand)
+	return &cached
+}
+
+// 保存先（cfg.Cache）に応じてキャッシュを作成する。offの場合はnil。closeで購読を止めて接続を閉じる
+func newCache(ctx context.Context, cfg *config.Config) (usecase.Cache, func()) {
+	switch cfg.Cache {
+	case "memory":
+		return cache.NewMemory(cfg.CacheMaxEntries), func() {}
+	case "redis":
+		// 他のサーバーの変更による破棄の通知を購読する
+		redisCache := cache.NewRedis(cache.RedisConfig{Addr: cfg.RedisAddr, KeyPrefix: cfg.CacheKeyPrefix})
+		runCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			redisCache.Run(runCtx)
+			close(done)
+		}()
+		return redisCache, func() {
+			cancel()
+			<-done
+			redisCache.Close()
+		}
+	}
+	return nil, func() {}
+}
+
+// 未適用のマイグレーションを適用する。autoMigrateがfalseの場合は未適用の件数を警告するのみ
+func migrate(ctx context.Context, migrator *migration.Migrator, autoMigrate bool) error {
+	if !autoMigrate {
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to read migration status: %w", err)
+		}
+		if pending := migration.Pending(statuses); pending > 0 {
+			slog.Warn("⚠️  未適用のマイグレーションがあります。`main migrate up` で適用してください", "pending", pending)
+		}
+		return nil
+	}
+
+	applied, err := migrator.Up(ctx)
+	for _, m := range applied {
+		slog.Info("✅ Applied migration", "version", m.Version, "name", m.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	return nil
+}
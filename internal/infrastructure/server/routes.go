@@ -0,0 +1,285 @@
+package server
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/infrastructure/config"
+	"Aicon-assignment/internal/infrastructure/middleware"
+	auditController "Aicon-assignment/internal/interfaces/controller/audit"
+	authController "Aicon-assignment/internal/interfaces/controller/auth"
+	brandController "Aicon-assignment/internal/interfaces/controller/brands"
+	categoryController "Aicon-assignment/internal/interfaces/controller/categories"
+	importController "Aicon-assignment/internal/interfaces/controller/imports"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/system"
+	tagController "Aicon-assignment/internal/interfaces/controller/tags"
+	webhookController "Aicon-assignment/internal/interfaces/controller/webhooks"
+	"Aicon-assignment/internal/interfaces/graphql"
+	"Aicon-assignment/internal/interfaces/openapi"
+)
+
+// ルートに登録するハンドラー
+type Handlers struct {
+	Auth      *authController.AuthHandler
+	Item      *itemController.ItemHandler
+	Image     *itemController.ItemImageHandler
+	QRCode    *itemController.ItemQRCodeHandler
+	Category  *categoryController.CategoryHandler
+	Tag       *tagController.TagHandler
+	Brand     *brandController.BrandHandler
+	Webhook   *webhookController.WebhookHandler
+	Import    *importController.ImportJobHandler
+	Audit     *auditController.AuditHandler
+	Migration *system.MigrationHandler
+	LogLevel  *system.LogLevelHandler
+	GraphQL   *graphql.Handler
+}
+
+// APIのバージョンごとのルートの登録方法
+type apiVersion struct {
+	prefix   string // /api/v1 など
+	register func(g *echo.Group, h *Handlers)
+	// OpenAPIの文書に載せる、registerで登録するルートの操作
+	operations func() []openapi.Operation
+	// 非推奨のエイリアスとして残す、移行前のパスの接頭辞（""はバージョンのないパス）
+	alias string
+}
+
+// 公開するAPIのバージョン。新しいバージョンはここに追加する
+var apiVersions = []apiVersion{
+	{prefix: "/api/v1", register: RegisterV1, operations: v1Operations, alias: ""},
+	{prefix: "/api/v2", register: RegisterV2, operations: itemController.EnvelopeOperations, alias: "/v2"},
+}
+
+// すべてのバージョンのルートと、その非推奨のエイリアスを登録する。
+// 全バージョン共通のミドルウェアはe.Useで登録し、バージョンごとには設定しない
+func registerRoutes(e *echo.Echo, h *Handlers) {
+	// グループにミドルウェアを設定するとキャッチオールのルートが登録され405が404になるため、
+	// エイリアスのルートを記録し、e.Useのミドルウェアで判定する
+	aliases := make(map[string]apiVersion)
+	for _, v := range apiVersions {
+		v.register(e.Group(v.prefix), h)
+
+		registered := routeKeys(e)
+		v.register(e.Group(v.alias), h)
+		for key := range routeKeys(e) {
+			if !registered[key] {
+				aliases[key] = v
+			}
+		}
+	}
+	e.Use(deprecatedAliases(aliases))
+}
+
+// 認証せずに呼び出せるv1のルート。トークンを得る前に呼び出すため
+var publicV1Routes = []string{"/auth/register", "/auth/login", "/auth/refresh"}
+
+// 認証せずに呼び出せるルートの、バージョンごとのパスとエイリアスのパス
+func publicRoutes() []string {
+	v1 := apiVersions[0]
+	routes := make([]string, 0, 2*len(publicV1Routes))
+	for _, route := range publicV1Routes {
+		routes = append(routes, v1.prefix+route, v1.alias+route)
+	}
+	return routes
+}
+
+// 制限時間を長くするルートと、書き込みの間隔で制限する逐次送信のルート。
+// バージョンのパスとエイリアスのパスで共通の末尾で判定する
+var (
+	importRoutes    = []string{"/items/import", "/items/bulk"}
+	streamingRoutes = []string{"/items/export.csv", "/items/export.ndjson", "/items/export.xlsx"}
+)
+
+// ルートごとのリクエストの制限時間。インポートはファイルの受信に時間がかかるため長くし、
+// エクスポートは件数に応じて時間がかかるため、全体ではなく書き込みの間隔で制限する
+func requestTimeouts(cfg *config.Config) middleware.RequestTimeoutFunc {
+	hasSuffix := func(route string, suffixes []string) bool {
+		return slices.ContainsFunc(suffixes, func(suffix string) bool { return strings.HasSuffix(route, suffix) })
+	}
+	return func(c echo.Context) middleware.RequestTimeout {
+		route := c.Path()
+		switch {
+		case hasSuffix(route, streamingRoutes):
+			return middleware.RequestTimeout{Duration: cfg.StreamIdleTimeout, Idle: true}
+		case hasSuffix(route, importRoutes):
+			return middleware.RequestTimeout{Duration: cfg.ImportRequestTimeout}
+		default:
+			return middleware.RequestTimeout{Duration: cfg.RequestTimeout}
+		}
+	}
+}
+
+// ルートごとのリクエストボディの最大サイズ。multipart/form-dataのアップロード（インポートと画像）は大きい上限とし、
+// 一括登録は最大件数のアイテムを受け取れる大きさにする。それ以外のルートはJSONの上限とする
+func bodyLimits(cfg *config.Config) middleware.BodyLimitFunc {
+	return func(c echo.Context) int64 {
+		switch {
+		case strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm):
+			return cfg.MaxUploadSize
+		case strings.HasSuffix(c.Path(), "/items/bulk"):
+			return max(cfg.MaxBodySize, itemController.MaxBulkBodySize)
+		default:
+			return cfg.MaxBodySize
+		}
+	}
+}
+
+func routeKeys(e *echo.Echo) map[string]bool {
+	keys := make(map[string]bool)
+	for _, r := range e.Routes() {
+		keys[r.Method+" "+r.Path] = true
+	}
+	return keys
+}
+
+// v1のルートを登録する
+func RegisterV1(g *echo.Group, h *Handlers) {
+	// ユーザーの登録とトークンの発行
+	authGroup := g.Group("/auth")
+	{
+		authGroup.POST("/register", h.Auth.Register) // POST /auth/register
+		authGroup.POST("/login", h.Auth.Login)       // POST /auth/login
+		authGroup.POST("/refresh", h.Auth.Refresh)   // POST /auth/refresh
+	}
+
+	// アイテムに関するエンドポイント
+	itemsGroup := g.Group("/items")
+	{
+		itemsGroup.GET("", h.Item.GetItems)                            // GET /items
+		itemsGroup.POST("", h.Item.CreateItem)                         // POST /items
+		itemsGroup.DELETE("", h.Item.BulkDeleteItems)                  // DELETE /items
+		itemsGroup.GET("/export.csv", h.Item.ExportItemsCSV)           // GET /items/export.csv
+		itemsGroup.GET("/export.ndjson", h.Item.ExportItemsNDJSON)     // GET /items/export.ndjson
+		itemsGroup.GET("/export.xlsx", h.Item.ExportItemsXLSX)         // GET /items/export.xlsx
+		itemsGroup.GET("/lookup", h.Item.LookupItem)                   // GET /items/lookup?serial_number=...
+		itemsGroup.POST("/import", h.Import.ImportItems)               // POST /items/import
+		itemsGroup.POST("/bulk", h.Item.BulkCreateItems)               // POST /items/bulk
+		itemsGroup.GET("/batch", h.Item.GetItemsBatch)                 // GET /items/batch?ids=1,2,3
+		itemsGroup.GET("/recent", h.Item.GetRecentItems)               // GET /items/recent?window=7d
+		itemsGroup.GET("/:id", h.Item.GetItem)                         // GET /items/{id}
+		itemsGroup.PUT("/:id", h.Item.ReplaceItem)                     // PUT /items/{id}
+		itemsGroup.PATCH("/:id", h.Item.UpdateItem)                    // PATCH /items/{id}
+		itemsGroup.DELETE("/:id", h.Item.DeleteItem)                   // DELETE /items/{id}
+		itemsGroup.POST("/:id/restore", h.Item.RestoreItem)            // POST /items/{id}/restore
+		itemsGroup.POST("/:id/clone", h.Item.CloneItem)                // POST /items/{id}/clone
+		itemsGroup.POST("/:id/status", h.Item.ChangeItemStatus)        // POST /items/{id}/status
+		itemsGroup.POST("/:id/sell", h.Item.MarkItemSold)              // POST /items/{id}/sell
+		itemsGroup.GET("/:id/history", h.Item.GetItemHistory)          // GET /items/{id}/history
+		itemsGroup.GET("/:id/images", h.Image.ListImages)              // GET /items/{id}/images
+		itemsGroup.POST("/:id/images", h.Image.AddImage)               // POST /items/{id}/images
+		itemsGroup.PUT("/:id/images/order", h.Image.ReorderImages)     // PUT /items/{id}/images/order
+		itemsGroup.DELETE("/:id/images/:imageId", h.Image.DeleteImage) // DELETE /items/{id}/images/{imageId}
+		itemsGroup.GET("/:id/qr", h.QRCode.GetQRCode)                  // GET /items/{id}/qr?size=256&label=true
+		itemsGroup.GET("/summary", h.Item.GetSummary)                  // GET /items/summary (bonus)
+		itemsGroup.GET("/summary/brands", h.Item.GetBrandSummary)      // GET /items/summary/brands?limit=...
+		itemsGroup.GET("/stats", h.Item.GetItemStats)                  // GET /items/stats
+		itemsGroup.GET("/report/profit", h.Item.GetProfitReport)       // GET /items/report/profit?year=...
+		itemsGroup.GET("/report/locations", h.Item.GetLocationReport)  // GET /items/report/locations
+		itemsGroup.GET("/report/spend", h.Item.GetSpendReport)         // GET /items/report/spend?granularity=...&from=...&to=...
+	}
+
+	// CSVインポートのジョブ
+	g.GET("/imports/:job_id", h.Import.GetImportJob) // GET /imports/{job_id}
+
+	// タグとカテゴリーの一覧
+	g.GET("/tags", h.Tag.GetTags)                  // GET /tags
+	g.GET("/categories", h.Category.GetCategories) // GET /categories
+
+	// ブランドの候補
+	g.GET("/brands", h.Brand.SearchBrands) // GET /brands?q=...&limit=...
+
+	// アイテムの読み取り専用のGraphQL
+	g.GET("/graphql", h.GraphQL.Query)  // GET /graphql?query=...
+	g.POST("/graphql", h.GraphQL.Query) // POST /graphql
+
+	// 管理者用のエンドポイント。グループにミドルウェアを設定すると405が404になるため、ルートごとに権限を確認する
+	admin := middleware.RequireAdmin
+
+	// アイテムの変更の監査ログ
+	g.GET("/audit", h.Audit.GetAuditLogs, admin) // GET /audit?actor=...&action=...&from=...&to=...
+
+	adminGroup := g.Group("/admin")
+	{
+		adminGroup.DELETE("/items/:id", h.Item.HardDeleteItem, admin) // DELETE /admin/items/{id}
+
+		adminGroup.GET("/categories", h.Category.GetCategories, admin)         // GET /admin/categories
+		adminGroup.POST("/categories", h.Category.CreateCategory, admin)       // POST /admin/categories
+		adminGroup.GET("/categories/:id", h.Category.GetCategory, admin)       // GET /admin/categories/{id}
+		adminGroup.PUT("/categories/:id", h.Category.UpdateCategory, admin)    // PUT /admin/categories/{id}
+		adminGroup.DELETE("/categories/:id", h.Category.DeleteCategory, admin) // DELETE /admin/categories/{id}
+
+		adminGroup.POST("/brands", h.Brand.CreateBrand, admin)           // POST /admin/brands
+		adminGroup.POST("/brands/:id/merge", h.Brand.MergeBrands, admin) // POST /admin/brands/{id}/merge
+
+		adminGroup.GET("/webhooks", h.Webhook.GetWebhooks, admin)                  // GET /admin/webhooks
+		adminGroup.POST("/webhooks", h.Webhook.CreateWebhook, admin)               // POST /admin/webhooks
+		adminGroup.DELETE("/webhooks/:id", h.Webhook.DeleteWebhook, admin)         // DELETE /admin/webhooks/{id}
+		adminGroup.POST("/webhooks/:id/enable", h.Webhook.EnableWebhook, admin)    // POST /admin/webhooks/{id}/enable
+		adminGroup.GET("/webhooks/:id/deliveries", h.Webhook.GetDeliveries, admin) // GET /admin/webhooks/{id}/deliveries?limit=...
+
+		adminGroup.GET("/migrations", h.Migration.GetMigrationStatus, admin) // GET /admin/migrations
+
+		adminGroup.GET("/log-level", h.LogLevel.GetLogLevel, admin)    // GET /admin/log-level
+		adminGroup.PUT("/log-level", h.LogLevel.UpdateLogLevel, admin) // PUT /admin/log-level
+	}
+}
+
+// RegisterV1で登録するルートの操作
+func v1Operations() []openapi.Operation {
+	var operations []openapi.Operation
+	for _, ops := range [][]openapi.Operation{
+		authController.Operations(),
+		itemController.Operations(),
+		itemController.ImageOperations(),
+		itemController.QRCodeOperations(),
+		importController.Operations(),
+		tagController.Operations(),
+		categoryController.Operations(),
+		brandController.Operations(),
+		auditController.Operations(),
+		webhookController.Operations(),
+		graphql.Operations(),
+		system.AdminOperations(),
+	} {
+		operations = append(operations, ops...)
+	}
+	return operations
+}
+
+// v2のルートを登録する。アイテムのレスポンスをitems・itemで包んで返す
+func RegisterV2(g *echo.Group, h *Handlers) {
+	item := h.Item.WithEnvelope()
+	itemsGroup := g.Group("/items")
+	{
+		itemsGroup.GET("", item.GetItems)                     // GET /items
+		itemsGroup.POST("", item.CreateItem)                  // POST /items
+		itemsGroup.GET("/lookup", item.LookupItem)            // GET /items/lookup?serial_number=...
+		itemsGroup.GET("/:id", item.GetItem)                  // GET /items/{id}
+		itemsGroup.PUT("/:id", item.ReplaceItem)              // PUT /items/{id}
+		itemsGroup.PATCH("/:id", item.UpdateItem)             // PATCH /items/{id}
+		itemsGroup.DELETE("/:id", item.DeleteItem)            // DELETE /items/{id}
+		itemsGroup.POST("/:id/restore", item.RestoreItem)     // POST /items/{id}/restore
+		itemsGroup.POST("/:id/clone", item.CloneItem)         // POST /items/{id}/clone
+		itemsGroup.POST("/:id/status", item.ChangeItemStatus) // POST /items/{id}/status
+		itemsGroup.POST("/:id/sell", item.MarkItemSold)       // POST /items/{id}/sell
+	}
+}
+
+// 非推奨のエイリアスのレスポンスにDeprecationヘッダーと、移行先のパスを示すLinkヘッダーを付ける
+func deprecatedAliases(aliases map[string]apiVersion) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if v, ok := aliases[c.Request().Method+" "+c.Path()]; ok {
+				successor := v.prefix + strings.TrimPrefix(c.Request().URL.Path, v.alias)
+				header := c.Response().Header()
+				header.Set("Deprecation", "true")
+				header.Set("Link", "<"+successor+`>; rel="successor-version"`)
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/config"
+	"Aicon-assignment/internal/infrastructure/middleware"
+	auditController "Aicon-assignment/internal/interfaces/controller/audit"
+	authController "Aicon-assignment/internal/interfaces/controller/auth"
+	brandController "Aicon-assignment/internal/interfaces/controller/brands"
+	categoryController "Aicon-assignment/internal/interfaces/controller/categories"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	importController "Aicon-assignment/internal/interfaces/controller/imports"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	tagController "Aicon-assignment/internal/interfaces/controller/tags"
+	webhookController "Aicon-assignment/internal/interfaces/controller/webhooks"
+	"Aicon-assignment/internal/interfaces/graphql"
+	"Aicon-assignment/internal/principal"
+)
+
+// ユースケースを持たないハンドラー。ルーティングのみを確認する
+func newTestHandlers() *Handlers {
+	return &Handlers{
+		Auth:     authController.NewAuthHandler(nil),
+		Item:     itemController.NewItemHandler(nil, nil, false),
+		Image:    itemController.NewItemImageHandler(nil),
+		Category: categoryController.NewCategoryHandler(nil),
+		Tag:      tagController.NewTagHandler(nil),
+		Brand:    brandController.NewBrandHandler(nil),
+		Webhook:  webhookController.NewWebhookHandler(nil),
+		Import:   importController.NewImportJobHandler(nil),
+		Audit:    auditController.NewAuditHandler(nil),
+		GraphQL:  graphql.NewHandler(nil, graphql.Limits{}),
+	}
+}
+
+func TestRegisterRoutes(t *testing.T) {
+	e := echo.New()
+	// IDの形式の誤りはユースケースを呼ぶ前に400となるため、ユースケースなしでルーティングを確認できる
+	registerRoutes(e, newTestHandlers())
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		expectedCode int
+		expectedLink string
+	}{
+		{name: "正常系: v1のパス", method: http.MethodGet, path: "/api/v1/items/abc", expectedCode: http.StatusBadRequest},
+		// 認証していない場合は管理者を確認できないため、管理者用のパスは403
+		{name: "正常系: v1の管理者用のパス", method: http.MethodDelete, path: "/api/v1/admin/categories/abc", expectedCode: http.StatusForbidden},
+		{name: "正常系: v1のWebhookの管理者用のパス", method: http.MethodDelete, path: "/api/v1/admin/webhooks/abc", expectedCode: http.StatusForbidden},
+		{name: "正常系: v1のインポートのジョブのパス", method: http.MethodGet, path: "/api/v1/imports/abc", expectedCode: http.StatusBadRequest},
+		{name: "正常系: v2のパス", method: http.MethodGet, path: "/api/v2/items/abc", expectedCode: http.StatusBadRequest},
+		{name: "正常系: バージョンのないパスは非推奨のエイリアス", method: http.MethodGet, path: "/items/abc", expectedCode: http.StatusBadRequest, expectedLink: `</api/v1/items/abc>; rel="successor-version"`},
+		{name: "正常系: /v2のパスは非推奨のエイリアス", method: http.MethodGet, path: "/v2/items/abc", expectedCode: http.StatusBadRequest, expectedLink: `</api/v2/items/abc>; rel="successor-version"`},
+		{name: "異常系: v2にないパス", method: http.MethodGet, path: "/api/v2/items/summary/brands", expectedCode: http.StatusNotFound},
+		{name: "異常系: 存在しないパス", method: http.MethodGet, path: "/unknown", expectedCode: http.StatusNotFound},
+		{name: "異常系: 許可されていないメソッド", method: http.MethodPost, path: "/api/v1/items/1", expectedCode: http.StatusMethodNotAllowed},
+		{name: "異常系: 存在しないv1のパス", method: http.MethodGet, path: "/api/v1/unknown", expectedCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expectedCode, rec.Code, rec.Body.String())
+			if tt.expectedLink != "" {
+				assert.Equal(t, "true", rec.Header().Get("Deprecation"))
+				assert.Equal(t, tt.expectedLink, rec.Header().Get("Link"))
+			} else {
+				assert.Empty(t, rec.Header().Get("Deprecation"))
+				assert.Empty(t, rec.Header().Get("Link"))
+			}
+		})
+	}
+}
+
+func TestRegisterRoutes_MethodNotAllowed(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = httperror.HandleError
+	registerRoutes(e, newTestHandlers())
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		expectedCode  int
+		expectedAllow string
+	}{
+		{name: "異常系: コレクションへのPUTは405", method: http.MethodPut, path: "/api/v1/items", expectedCode: http.StatusMethodNotAllowed, expectedAllow: "OPTIONS, DELETE, GET, POST"},
+		{name: "異常系: アイテムへのPOSTは405", method: http.MethodPost, path: "/api/v1/items/1", expectedCode: http.StatusMethodNotAllowed, expectedAllow: "OPTIONS, DELETE, GET, PATCH, PUT"},
+		{name: "異常系: v2はv2で登録したメソッドのみ", method: http.MethodDelete, path: "/api/v2/items", expectedCode: http.StatusMethodNotAllowed, expectedAllow: "OPTIONS, GET, POST"},
+		{name: "異常系: エイリアスのパスも405", method: http.MethodPut, path: "/items", expectedCode: http.StatusMethodNotAllowed, expectedAllow: "OPTIONS, DELETE, GET, POST"},
+		{name: "異常系: 存在しないパスは404", method: http.MethodPut, path: "/api/v1/unknown", expectedCode: http.StatusNotFound},
+		{name: "異常系: 存在しないアイテムの下のパスは404", method: http.MethodGet, path: "/api/v1/items/1/unknown", expectedCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.Equal(t, tt.expectedAllow, rec.Header().Get(echo.HeaderAllow))
+			assert.Equal(t, httperror.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+			var problem httperror.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, tt.expectedCode, problem.Status)
+		})
+	}
+}
+
+func TestRegisterRoutes_Options(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = httperror.HandleError
+	registerRoutes(e, newTestHandlers())
+
+	// 登録したすべてのパスで、OPTIONSはそのパスに登録したメソッドをAllowヘッダーで返す
+	methods := make(map[string][]string)
+	for _, r := range e.Routes() {
+		methods[r.Path] = append(methods[r.Path], r.Method)
+	}
+	param := regexp.MustCompile(`:[^/]+`)
+	for path, registered := range methods {
+		t.Run(path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, param.ReplaceAllString(path, "1"), nil))
+
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.ElementsMatch(t, append(registered, http.MethodOptions), strings.Split(rec.Header().Get(echo.HeaderAllow), ", "))
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/api/v1/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestPublicRoutes(t *testing.T) {
+	e := echo.New()
+	registerRoutes(e, newTestHandlers())
+
+	// 認証しないルートは、バージョンのあるパスとエイリアスのどちらも登録済みのルート
+	registered := routeKeys(e)
+	routes := publicRoutes()
+	assert.Contains(t, routes, "/api/v1/auth/login")
+	assert.Contains(t, routes, "/auth/login")
+	for _, route := range routes {
+		assert.True(t, registered[http.MethodPost+" "+route], route)
+	}
+}
+
+func TestRequestTimeouts(t *testing.T) {
+	e := echo.New()
+	registerRoutes(e, newTestHandlers())
+	cfg := &config.Config{RequestTimeout: 10 * time.Second, ImportRequestTimeout: 2 * time.Minute, StreamIdleTimeout: 30 * time.Second}
+	timeoutFor := requestTimeouts(cfg)
+
+	tests := []struct {
+		method   string
+		path     string
+		expected middleware.RequestTimeout
+	}{
+		{http.MethodGet, "/api/v1/items", middleware.RequestTimeout{Duration: 10 * time.Second}},
+		{http.MethodPost, "/api/v1/items/import", middleware.RequestTimeout{Duration: 2 * time.Minute}},
+		{http.MethodPost, "/items/bulk", middleware.RequestTimeout{Duration: 2 * time.Minute}},
+		{http.MethodGet, "/api/v1/items/export.ndjson", middleware.RequestTimeout{Duration: 30 * time.Second, Idle: true}},
+		{http.MethodGet, "/items/export.xlsx", middleware.RequestTimeout{Duration: 30 * time.Second, Idle: true}},
+	}
+	for _, tt := range tests {
+		c := e.NewContext(httptest.NewRequest(tt.method, tt.path, nil), httptest.NewRecorder())
+		e.Router().Find(tt.method, tt.path, c)
+		assert.Equal(t, tt.expected, timeoutFor(c), tt.path)
+	}
+}
+
+func TestBodyLimits(t *testing.T) {
+	e := echo.New()
+	registerRoutes(e, newTestHandlers())
+	cfg := &config.Config{MaxBodySize: 1 << 20, MaxUploadSize: 20 << 20}
+	limitFor := bodyLimits(cfg)
+
+	tests := []struct {
+		path        string
+		contentType string
+		expected    int64
+	}{
+		{"/api/v1/items", echo.MIMEApplicationJSON, 1 << 20},
+		{"/api/v1/items/bulk", echo.MIMEApplicationJSON, itemController.MaxBulkBodySize},
+		{"/api/v1/items/import", "multipart/form-data; boundary=x", 20 << 20},
+		{"/items/1/images", "multipart/form-data; boundary=x", 20 << 20},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+		req.Header.Set(echo.HeaderContentType, tt.contentType)
+		c := e.NewContext(req, httptest.NewRecorder())
+		e.Router().Find(http.MethodPost, tt.path, c)
+		assert.Equal(t, tt.expected, limitFor(c), tt.path)
+	}
+}
+
+func TestAdminRoutes_RequireAdmin(t *testing.T) {
+	e := echo.New()
+	// 一般のユーザーとしてログインしたリクエスト
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := principal.NewContext(c.Request().Context(), principal.Principal{UserID: 2, Role: entity.UserRoleUser})
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	})
+	registerRoutes(e, newTestHandlers())
+
+	// パスのパラメーターは権限の確認の後に解析するため、任意の値でよい
+	param := regexp.MustCompile(`:[^/]+`)
+	var checked int
+	for _, r := range e.Routes() {
+		if !strings.Contains(r.Path, "/admin/") && !strings.HasSuffix(r.Path, "/audit") {
+			continue
+		}
+		checked++
+		path := param.ReplaceAllString(r.Path, "1")
+		t.Run(r.Method+" "+r.Path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(r.Method, path, nil))
+
+			assert.Equal(t, http.StatusForbidden, rec.Code)
+			assert.Equal(t, httperror.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+			var problem httperror.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, httperror.CodeForbidden, problem.Extensions["code"])
+		})
+	}
+	// v1とバージョンのないエイリアスの両方の管理者用のルートを確認する
+	assert.Equal(t, 34, checked)
+}
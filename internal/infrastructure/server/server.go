@@ -2,92 +2,484 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
-	"net/http"
+	"log/slog"
+	"net"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
-	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/accesstoken"
+	"Aicon-assignment/internal/infrastructure/cache"
+	"Aicon-assignment/internal/infrastructure/config"
+	"Aicon-assignment/internal/infrastructure/eventbus"
+	"Aicon-assignment/internal/infrastructure/exchange"
+	"Aicon-assignment/internal/infrastructure/health"
+	"Aicon-assignment/internal/infrastructure/importjob"
+	"Aicon-assignment/internal/infrastructure/logging"
+	"Aicon-assignment/internal/infrastructure/metrics"
+	"Aicon-assignment/internal/infrastructure/middleware"
+	"Aicon-assignment/internal/infrastructure/migration"
+	"Aicon-assignment/internal/infrastructure/ratelimit"
+	"Aicon-assignment/internal/infrastructure/storage"
+	"Aicon-assignment/internal/infrastructure/tracing"
+	"Aicon-assignment/internal/infrastructure/webhook"
+	auditController "Aicon-assignment/internal/interfaces/controller/audit"
+	authController "Aicon-assignment/internal/interfaces/controller/auth"
+	brandController "Aicon-assignment/internal/interfaces/controller/brands"
+	categoryController "Aicon-assignment/internal/interfaces/controller/categories"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	importController "Aicon-assignment/internal/interfaces/controller/imports"
 	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/request"
 	"Aicon-assignment/internal/interfaces/controller/system"
+	tagController "Aicon-assignment/internal/interfaces/controller/tags"
+	webhookController "Aicon-assignment/internal/interfaces/controller/webhooks"
 	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/interfaces/graphql"
+	"Aicon-assignment/internal/principal"
 	"Aicon-assignment/internal/usecase"
 )
 
 // サーバー用の構造体
-type Server struct{}
+type Server struct {
+	cfg      *config.Config
+	logLevel *slog.LevelVar // ログのハンドラーに設定したレベル。/admin/log-levelで変更する
+}
 
-func NewServer() *Server {
-	return &Server{}
+// cfgの設定から各依存先を作成して起動するサーバー
+func NewServer(cfg *config.Config, logLevel *slog.LevelVar) *Server {
+	return &Server{cfg: cfg, logLevel: logLevel}
 }
 
 // サーバー起動
 func (s *Server) Run(ctx context.Context) error {
+	cfg := s.cfg
 	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+	// ルーターの404・405とミドルウェアのエラーも、ハンドラーと同じproblem+jsonで返す
+	e.HTTPErrorHandler = httperror.HandleError
+
+	// リクエストIDはログとエラーレスポンスに付けるため、最初に設定する
+	e.Use(middleware.RequestID())
+	// c.RealIP()で取り出すクライアントのIPアドレス。信頼するプロキシからのX-Forwarded-Forのみを使う
+	e.IPExtractor = middleware.ClientIPExtractor(cfg.TrustedProxies)
+	// 監査ログに記録するよう、クライアントのIPアドレスをリクエストのctxに設定する
+	e.Use(middleware.ClientIP())
+
+	// 購入価格の上限と購入日のタイムゾーンを設定から反映
+	entity.MaxPurchasePrice = cfg.MaxPurchasePrice
+	entity.PurchaseDateLocation = cfg.PurchaseDateLocation
+	// JSONのリクエストボディの最大サイズを設定から反映
+	request.MaxBodySize = cfg.MaxBodySize
 
 	// 依存性注入
-	dbHandler := databaseInfra.NewSqlHandler()
-	defer dbHandler.Close()
+	// マイグレーションに失敗した場合はリクエストを受け付けずに終了する
+	repos, migrator, closeRepos, err := newRepositories(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeRepos()
+
+	// トレース。OTLPの送信先が設定されていない場合はスパンを送信しない
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{ServiceName: cfg.ServiceName, Export: cfg.TracingExport})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			slog.Warn("⚠️  送信待ちのスパンを送信できませんでした", "error", err)
+		}
+	}()
+	tracer := tracing.NewTracer(cfg.Repository)
+	e.Use(tracing.Middleware())
+	// アクセスログ。トレースのIDを付けるため、トレースのミドルウェアの内側で出力する
+	e.Use(logging.Middleware())
+
+	// Prometheusの指標。/metricsで公開する
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	appMetrics := metrics.New(registry)
+	if repos.dbStats != nil {
+		appMetrics.RegisterDBStats(repos.dbStats)
+		appMetrics.RegisterDatabaseRetries(itemDatabase.RetryCounts)
+	}
+	e.Use(appMetrics.Middleware())
+	// レスポンスの圧縮。エラーのレスポンスも圧縮するよう、認証やハンドラーのエラーをレスポンスにする位置より外側で圧縮する
+	if cfg.Compression {
+		e.Use(middleware.Gzip(middleware.GzipConfig{MinSize: cfg.CompressionMinSize, Streaming: cfg.CompressionStreaming}))
+	}
+	// リクエストの制限時間。認証のクエリも含めて打ち切るよう、認証より外側で設定する
+	e.Use(middleware.Timeout(requestTimeouts(cfg)))
+
+	// 所要時間はキャッシュと制限時間の待ちを含まないよう、リポジトリの直前で記録する
+	repos = repos.withMetrics(appMetrics)
+	// キャッシュから返した読み込みはクエリを実行しないため、キャッシュの内側でスパンを作成する
+	repos = repos.withTracing(tracer)
+	// クライアントが切断した場合や時間がかかりすぎる場合にクエリを中断する
+	repos = repos.withTimeout(cfg.QueryTimeout)
+	// キャッシュした値は制限時間を待たずに返すよう、制限時間の外側でキャッシュする
+	itemCache, closeCache := newCache(ctx, cfg)
+	defer closeCache()
+	if itemCache != nil {
+		repos = repos.withCache(itemCache, cfg.CacheTTL, appMetrics)
+	}
+
+	imageStorage, err := storage.NewLocalStorage(cfg.ImageStorageDir, cfg.ImageBaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to initialize image storage: %w", err)
+	}
+
+	exchangeRates := exchange.NewStaticRateProvider(cfg.BaseCurrency, cfg.ExchangeRates)
+
+	// アイテムの変更はコミット後にキューに入れ、登録済みのWebhookに非同期で送信する
+	dispatcher := webhook.NewDispatcher(repos.webhook, webhook.Config{
+		Workers:     cfg.WebhookWorkers,
+		QueueSize:   cfg.WebhookQueueSize,
+		Timeout:     cfg.WebhookTimeout,
+		MaxFailures: cfg.WebhookMaxFailures,
+	})
+
+	// アイテムの変更のイベントの処理。履歴は変更と同じトランザクションで記録し、監査ログの記録とWebhookの送信はコミット後に行う
+	bus := eventbus.New()
+	bus.Subscribe(usecase.NewHistoryHandler(repos.item))
+	bus.Subscribe(usecase.AfterCommit(usecase.NewAuditHandler(repos.audit, appMetrics)))
+	bus.Subscribe(usecase.AfterCommit(dispatcher))
+	bus.Subscribe(usecase.AfterCommit(appMetrics))
+
+	// 各ユースケースのメソッドの呼び出しをスパンで囲む
+	itemUsecase := usecase.ItemUsecaseWithTracing(usecase.NewItemUsecase(repos.item, repos.category, imageStorage, exchangeRates, repos.transactor, bus), tracer)
+	categoryUsecase := usecase.CategoryUsecaseWithTracing(usecase.NewCategoryUsecase(repos.category), tracer)
+	tagUsecase := usecase.TagUsecaseWithTracing(usecase.NewTagUsecase(repos.tag), tracer)
+	brandUsecase := usecase.BrandUsecaseWithTracing(usecase.NewBrandUsecase(repos.brand, cfg.BrandValidation), tracer)
+	imageUsecase := usecase.ItemImageUsecaseWithTracing(usecase.NewItemImageUsecase(repos.item, imageStorage, cfg.ImageMaxSize), tracer)
+	webhookUsecase := usecase.WebhookUsecaseWithTracing(usecase.NewWebhookUsecase(repos.webhook), tracer)
+	auditUsecase := usecase.AuditUsecaseWithTracing(usecase.NewAuditUsecase(repos.audit), tracer)
 
-	itemRepo := &itemDatabase.ItemRepository{
-		SqlHandler: dbHandler,
+	// CSVインポートはジョブとして受け付け、上限つきのキューからワーカーが実行する
+	importRunner := importjob.NewRunner(importjob.Config{Workers: cfg.ImportWorkers, QueueSize: cfg.ImportQueueSize})
+	importJobUsecase := usecase.ImportJobUsecaseWithTracing(usecase.NewImportJobUsecase(repos.importJob, itemUsecase, importRunner, cfg.ImportMaxSize), tracer)
+	// 前回の終了時に失敗にできなかったジョブ（異常終了など）は、ファイルが残っておらず再開できないため失敗にする
+	if failed, err := importJobUsecase.FailUnfinishedImportJobs(ctx); err != nil {
+		slog.Warn("⚠️  未完了のインポートのジョブを失敗にできませんでした", "error", err)
+	} else if failed > 0 {
+		slog.Warn("⚠️  前回の終了時に未完了だったインポートのジョブを失敗にしました", "count", failed)
 	}
 
-	itemUsecase := usecase.NewItemUsecase(itemRepo)
+	// ユーザーの登録とアクセストークン（JWT）の発行
+	authUsecase := usecase.AuthUsecaseWithTracing(usecase.NewAuthUsecase(repos.user, accesstoken.NewSigner(jwtSecret(cfg)), usecase.AuthConfig{
+		AccessTokenTTL:  cfg.JWTAccessTTL,
+		RefreshTokenTTL: cfg.JWTRefreshTTL,
+	}), tracer)
 
-	systemHandler := system.NewSystemHandler()
-	itemHandler := itemController.NewItemHandler(itemUsecase)
+	// 別のオリジンのブラウザからの呼び出し。プリフライトは認証情報を付けずに送られるため、認証と頻度の制限の外側で応答する
+	if len(cfg.CORSAllowedOrigins) > 0 {
+		e.Use(middleware.CORS(middleware.CORSConfig{
+			AllowOrigins:     cfg.CORSAllowedOrigins,
+			AllowMethods:     cfg.CORSAllowedMethods,
+			AllowHeaders:     cfg.CORSAllowedHeaders,
+			ExposeHeaders:    cfg.CORSExposedHeaders,
+			AllowCredentials: cfg.CORSAllowCredentials,
+			MaxAge:           cfg.CORSMaxAge,
+		}))
+	}
+
+	// リクエストボディの最大サイズ。Content-Lengthが上限を超えるリクエストは、認証の前にボディを読まずに413を返す
+	e.Use(middleware.BodyLimit(bodyLimits(cfg)))
+
+	// IPアドレスごとのリクエストの頻度の制限。不正なAPIキー・アクセストークンを送り続けるクライアントも、
+	// 認証でデータベースを照会する前に制限できるよう、認証の外側で判定する
+	if cfg.IPRateLimit > 0 {
+		ipLimiter := ratelimit.NewMemory(ratelimit.Config{Rate: cfg.IPRateLimit, Burst: cfg.IPRateLimitBurst})
+		e.Use(middleware.RateLimit(ipLimiter, middleware.ClientIPKey))
+	}
+
+	// APIキーとアクセストークンの認証。401もアクセスログと指標に記録されるよう、それらの内側で判定する
+	apiKeyUsecase := usecase.APIKeyUsecaseWithTracing(usecase.NewAPIKeyUsecase(repos.apiKey), tracer)
+	if cfg.APIKeyAuth {
+		// アップロードされた画像はimgタグから読み込めるよう認証しない。ユーザーの登録とログインはトークンを得る前に呼び出す
+		e.Use(middleware.Auth(apiKeyUsecase, authUsecase, append(publicRoutes(), cfg.ImageBaseURL+"*")...))
+		if cfg.Repository == "memory" {
+			issueDevelopmentAPIKey(ctx, apiKeyUsecase)
+		}
+	} else {
+		slog.Warn("⚠️  認証が無効です。APIは誰でも呼び出せ、アイテムはユーザーごとに分けられません")
+	}
+	// クライアントごとのリクエストの頻度の制限。429もアクセスログと指標に記録されるよう、それらの内側で判定する。
+	// 認証したリクエストはユーザー・APIキーごとに制限するため、認証の内側で判定する
+	if cfg.RateLimit > 0 {
+		limiter := ratelimit.NewMemory(ratelimit.Config{Rate: cfg.RateLimit, Burst: cfg.RateLimitBurst})
+		e.Use(middleware.RateLimit(limiter, middleware.PrincipalOrClientIPKey))
+	}
+	// ハンドラーのpanicは500にする。アクセスログと指標に500として記録されるよう、最も内側で回復する
+	e.Use(middleware.Recover(appMetrics))
+
+	systemHandler := system.NewSystemHandler(dependencies(cfg, repos, itemCache)...)
+	authHandler := authController.NewAuthHandler(authUsecase)
+	itemHandler := itemController.NewItemHandler(itemUsecase, brandUsecase, cfg.RequirePreconditions)
+	imageHandler := itemController.NewItemImageHandler(imageUsecase)
+	// QRコードのPNGはアイテムのキャッシュの設定によらず、プロセスのメモリ上にキャッシュする
+	qrCodeHandler := itemController.NewItemQRCodeHandler(itemUsecase, cache.NewMemory(qrCodeCacheMaxEntries), cfg.PublicBaseURL)
+	categoryHandler := categoryController.NewCategoryHandler(categoryUsecase)
+	tagHandler := tagController.NewTagHandler(tagUsecase)
+	brandHandler := brandController.NewBrandHandler(brandUsecase)
+	webhookHandler := webhookController.NewWebhookHandler(webhookUsecase)
+	importHandler := importController.NewImportJobHandler(importJobUsecase)
+	auditHandler := auditController.NewAuditHandler(auditUsecase)
+	migrationHandler := system.NewMigrationHandler(cfg.Repository, migrationStatus(migrator))
+	logLevelHandler := system.NewLogLevelHandler(s.logLevel)
+	graphQLHandler := graphql.NewHandler(graphql.NewItemSchema(itemUsecase, imageUsecase), graphql.Limits{
+		MaxDepth:      cfg.GraphQLMaxDepth,
+		MaxComplexity: cfg.GraphQLMaxComplexity,
+	})
 
 	// ヘルスチェック
 	e.GET("/health", func(c echo.Context) error {
 		systemHandler.Health(c)
 		return nil
 	})
+	// ロードバランサー向けの生存確認と、依存先を確認する準備状態の確認
+	e.GET("/healthz", systemHandler.Liveness)
+	e.GET("/readyz", systemHandler.Readiness)
+
+	// Prometheusの指標
+	e.GET("/metrics", echo.WrapHandler(promhttp.HandlerFor(registry, promhttp.HandlerOpts{})))
+	// ハンドラーが宣言した操作から生成したOpenAPIの文書
+	e.GET(openAPIPath, openAPIHandler(openAPIDocument()))
+
+	// バージョンごとのAPIと、バージョンのないパスのエイリアス
+	registerRoutes(e, &Handlers{
+		Auth:      authHandler,
+		Item:      itemHandler,
+		Image:     imageHandler,
+		QRCode:    qrCodeHandler,
+		Category:  categoryHandler,
+		Tag:       tagHandler,
+		Brand:     brandHandler,
+		Webhook:   webhookHandler,
+		Import:    importHandler,
+		Audit:     auditHandler,
+		Migration: migrationHandler,
+		LogLevel:  logLevelHandler,
+		GraphQL:   graphQLHandler,
+	})
+
+	// アップロードされた画像の配信
+	e.Static(cfg.ImageBaseURL, cfg.ImageStorageDir)
+
+	// 終了時はdeferの逆順に、HTTPサーバー（serve）→gRPCのサーバー→インポートのジョブ→Webhookの送信→定期的な削除→
+	// キャッシュ→トレースの送信→データベースの接続の順に止める。各ワーカーは処理中の作業を終えるか記録してから止まる
+
+	// バックグラウンドの処理はリクエストの呼び出し元を持たないため、サーバー内の処理として実行する
+	systemCtx := principal.NewContext(ctx, principal.System)
+
+	// 有効期間を過ぎた冪等キーとWebhookの送信の記録、インポートのジョブを定期的に削除する
+	defer runInBackground(systemCtx, func(ctx context.Context) {
+		s.cleanupExpired(ctx, itemUsecase, webhookUsecase, importJobUsecase)
+	})()
+
+	// サーバーの終了時は送信中の試行の完了を待ち、送信待ちのイベントは破棄する
+	defer runInBackground(systemCtx, dispatcher.Run)()
+
+	// サーバーの終了時は実行中のインポートを中断し、未完了のジョブを失敗にする
+	defer s.runImportJobs(systemCtx, importRunner, importJobUsecase)()
+
+	// gRPCのサーバー。HTTPと同じユースケースと認証を使い、別のポートで待ち受ける。
+	// シグナルを受け取るとHTTPのサーバーと並行して処理中の呼び出しの完了を待ち、HTTPのサーバーの後に終了を待つ
+	if cfg.GRPCPort != 0 {
+		grpcLn, err := net.Listen("tcp", cfg.GRPCListenAddr())
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", cfg.GRPCListenAddr(), err)
+		}
+		var auth *grpcAuth
+		if cfg.APIKeyAuth {
+			auth = &grpcAuth{apiKeys: apiKeyUsecase, accessTokens: authUsecase}
+		}
+		grpcServer := newGRPCServer(itemUsecase, auth, appMetrics)
+		defer runInBackground(ctx, func(ctx context.Context) {
+			if err := serveGRPC(ctx, grpcServer, grpcLn, cfg.ShutdownTimeout); err != nil {
+				slog.Error("gRPC server failed", "error", err)
+			}
+		})()
+	}
+
+	// データベースに接続できることを確認してから待ち受けを始める
+	ln, err := net.Listen("tcp", cfg.ListenAddr())
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", cfg.ListenAddr(), err)
+	}
+	return serve(ctx, e, ln, cfg.ShutdownTimeout)
+}
+
+// /readyzで確認する依存先。データベースは必須、キャッシュはデータベースから読み込めるため必須としない。
+// 数秒ごとに呼ばれても問い合わせが増えないよう、確認の結果はcfg.ReadinessCacheTTLの間使い回す
+func dependencies(cfg *config.Config, repos *repositories, itemCache usecase.Cache) []system.Dependency {
+	var dependencies []system.Dependency
+	if repos.dbPing != nil {
+		dependencies = append(dependencies, system.Dependency{
+			Name:     "database",
+			Required: true,
+			Check:    health.NewCached(repos.dbPing, cfg.ReadinessCacheTTL, cfg.ReadinessTimeout).Check,
+		})
+	}
+	if pinger, ok := itemCache.(health.Pinger); ok {
+		dependencies = append(dependencies, system.Dependency{
+			Name:  "cache",
+			Check: health.NewCached(pinger.Ping, cfg.ReadinessCacheTTL, cfg.ReadinessTimeout).Check,
+		})
+	}
+	return dependencies
+}
 
-	// アイテムに関するエンドポイント
-	itemsGroup := e.Group("/items")
-	{
-		itemsGroup.GET("", itemHandler.GetItems)           // GET /items
-		itemsGroup.POST("", itemHandler.CreateItem)        // POST /items
-		itemsGroup.GET("/:id", itemHandler.GetItem)        // GET /items/{id}
-		itemsGroup.PATCH("/:id", itemHandler.UpdateItem)   // PATCH /items/{id}
-		itemsGroup.DELETE("/:id", itemHandler.DeleteItem)  // DELETE /items/{id}
-		itemsGroup.GET("/summary", itemHandler.GetSummary) // GET /items/summary (bonus)
+// メモリ上のリポジトリには発行済みのAPIキーがないため、開発用のキーを発行してログに出力する。
+// 管理者用のエンドポイントも試せるよう管理者の権限を与え、データとともにキーもサーバーの終了で失われる
+func issueDevelopmentAPIKey(ctx context.Context, apiKeyUsecase usecase.APIKeyUsecase) {
+	_, key, err := apiKeyUsecase.CreateAPIKey(ctx, "development", entity.UserRoleAdmin)
+	if err != nil {
+		slog.Warn("⚠️  開発用のAPIキーを発行できませんでした", "error", err)
+		return
 	}
+	slog.Warn("⚠️  開発用のAPIキーを発行しました。Authorization: Bearer <key> ヘッダーで指定してください", "api_key", key)
+}
 
-	return s.startWithGracefulShutdown(ctx, e)
+// アクセストークンの署名の鍵。未設定の場合はランダムな鍵を生成するため、再起動すると発行済みのアクセストークンは使えなくなる。
+// リフレッシュトークンは保存先で照合するため、再起動後も再発行に使える
+func jwtSecret(cfg *config.Config) []byte {
+	if cfg.JWTSecret != "" {
+		return []byte(cfg.JWTSecret)
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(fmt.Sprintf("failed to generate jwt secret: %v", err))
+	}
+	slog.Warn("⚠️  JWT_SECRETが未設定のため、ランダムな鍵でアクセストークンに署名します。再起動すると発行済みのアクセストークンは使えなくなります")
+	return secret
 }
 
-func (s *Server) startWithGracefulShutdown(ctx context.Context, e *echo.Echo) error {
+// fnをゴルーチンで実行し、ctxをキャンセルしてfnが戻るのを待つ関数を返す
+func runInBackground(ctx context.Context, fn func(ctx context.Context)) (stop func()) {
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
 	go func() {
-		port := ":8080"
-		fmt.Printf("🚀 Server starting on port %s\n", port)
+		fn(runCtx)
+		close(done)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
 
-		if err := e.Start(port); err != nil && err != http.ErrServerClosed {
-			e.Logger.Fatal("Server startup failed:", err)
-		}
+// インポートのジョブを実行するワーカーを起動し、停止する関数を返す。
+// 停止する関数は実行中のジョブを中断して終了を待ち、実行待ちのジョブも含めて未完了のジョブを失敗にする
+func (s *Server) runImportJobs(ctx context.Context, runner *importjob.Runner, importJobUsecase usecase.ImportJobUsecase) func() {
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		runner.Run(runCtx, importJobUsecase.RunImportJob)
+		close(done)
 	}()
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
+	return func() {
+		cancel()
+		<-done
+
+		// 終了の原因となったctxはキャンセル済みのため、別の制限時間で記録する
+		failCtx, cancelFail := context.WithTimeout(principal.NewContext(context.Background(), principal.System), 10*time.Second)
+		defer cancelFail()
+		if failed, err := importJobUsecase.FailUnfinishedImportJobs(failCtx); err != nil {
+			slog.Warn("⚠️  未完了のインポートのジョブを失敗にできませんでした", "error", err)
+		} else if failed > 0 {
+			slog.Warn("⚠️  未完了のインポートのジョブを失敗にしました", "count", failed)
+		}
+	}
+}
+
+// migratorの適用状況をハンドラーの形式で返す関数。migratorがnilの場合はnil
+func migrationStatus(migrator *migration.Migrator) system.MigrationStatusFunc {
+	if migrator == nil {
+		return nil
+	}
+	return func(ctx context.Context) ([]system.MigrationStatus, error) {
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return nil, err
+		}
+		converted := make([]system.MigrationStatus, len(statuses))
+		for i, s := range statuses {
+			converted[i] = system.MigrationStatus(s)
+		}
+		return converted, nil
+	}
+}
+
+// 期限切れのデータの削除の間隔
+const cleanupInterval = time.Hour
+
+// プロセスのメモリ上にキャッシュするQRコードのPNGの件数（アイテム・バージョン・幅の組み合わせごと）
+const qrCodeCacheMaxEntries = 1000
+
+// ctxがキャンセルされるまで、一定間隔で期限切れの冪等キーとWebhookの送信の記録、インポートのジョブを削除する
+func (s *Server) cleanupExpired(ctx context.Context, itemUsecase usecase.ItemUsecase, webhookUsecase usecase.WebhookUsecase, importJobUsecase usecase.ImportJobUsecase) {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := itemUsecase.DeleteExpiredIdempotencyKeys(ctx); err != nil {
+				slog.WarnContext(ctx, "⚠️  期限切れの冪等キーの削除に失敗しました", "error", err)
+			}
+			if _, err := webhookUsecase.DeleteExpiredDeliveries(ctx); err != nil {
+				slog.WarnContext(ctx, "⚠️  保持期間を過ぎたWebhookの送信の記録の削除に失敗しました", "error", err)
+			}
+			if _, err := importJobUsecase.DeleteExpiredImportJobs(ctx); err != nil {
+				slog.WarnContext(ctx, "⚠️  保持期間を過ぎたインポートのジョブの削除に失敗しました", "error", err)
+			}
+		}
+	}
+}
+
+// lnでリクエストを受け付け、SIGINT・SIGTERMを受け取るかctxがキャンセルされると新しい接続の受け付けをやめ、
+// 処理中のリクエストの完了をdrainTimeoutまで待って戻る
+func serve(ctx context.Context, e *echo.Echo, ln net.Listener, drainTimeout time.Duration) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	e.Listener = ln
+	errCh := make(chan error, 1)
+	go func() {
+		slog.Info("🚀 Server starting", "addr", ln.Addr().String())
+		errCh <- e.Start("")
+	}()
 
 	select {
-	case <-quit:
-		fmt.Println("\n🛑 Shutting down server...")
+	case err := <-errCh:
+		return fmt.Errorf("server stopped unexpectedly: %w", err)
 	case <-ctx.Done():
-		fmt.Println("\n🛑 Context cancelled, shutting down server...")
+		slog.Info("🛑 Shutting down server...", "drain_timeout", drainTimeout)
 	}
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
 	defer cancel()
 
 	if err := e.Shutdown(shutdownCtx); err != nil {
 		return fmt.Errorf("server forced to shutdown: %w", err)
 	}
 
-	fmt.Println("✅ Server exited gracefully")
+	slog.Info("✅ Server exited gracefully")
 	return nil
 }
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/infrastructure/cache"
+	"Aicon-assignment/internal/infrastructure/config"
+)
+
+func TestServe_CompletesInFlightRequestOnShutdown(t *testing.T) {
+	started := make(chan struct{})
+	e := echo.New()
+	e.HideBanner = true
+	e.GET("/slow", func(c echo.Context) error {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		return c.String(http.StatusOK, "done")
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- serve(ctx, e, ln, 5*time.Second) }()
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	responses := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/slow")
+		if err != nil {
+			responses <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		responses <- result{status: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	// リクエストの処理中に終了を始める
+	<-started
+	cancel()
+
+	res := <-responses
+	require.NoError(t, res.err)
+	assert.Equal(t, http.StatusOK, res.status)
+	assert.Equal(t, "done", res.body)
+	require.NoError(t, <-served)
+
+	// 終了後は新しい接続を受け付けない
+	_, err = net.DialTimeout("tcp", ln.Addr().String(), 100*time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestServe_DrainTimeout(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	e := echo.New()
+	e.HideBanner = true
+	e.GET("/stuck", func(c echo.Context) error {
+		close(started)
+		<-release
+		return c.NoContent(http.StatusOK)
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- serve(ctx, e, ln, 50*time.Millisecond) }()
+
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/stuck")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+	cancel()
+
+	// 待つ時間を過ぎても終わらないリクエストがある場合はエラーにする
+	assert.ErrorIs(t, <-served, context.DeadlineExceeded)
+}
+
+func TestRunInBackground(t *testing.T) {
+	finished := false
+	stop := runInBackground(context.Background(), func(ctx context.Context) {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		finished = true
+	})
+
+	stop()
+	// stopはfnが戻るまで待つ
+	assert.True(t, finished)
+}
+
+func TestDependencies(t *testing.T) {
+	// メモリ上のリポジトリとキャッシュなしでは確認する依存先がない
+	cfg := &config.Config{ReadinessCacheTTL: time.Second, ReadinessTimeout: time.Second}
+	assert.Empty(t, dependencies(cfg, &repositories{}, nil))
+
+	pingErr := errors.New("connection refused")
+	deps := dependencies(cfg, &repositories{dbPing: func(ctx context.Context) error { return pingErr }}, cache.NewMemory(10))
+	require.Len(t, deps, 1)
+	assert.Equal(t, "database", deps[0].Name)
+	assert.True(t, deps[0].Required)
+	assert.ErrorIs(t, deps[0].Check(context.Background()), pingErr)
+}
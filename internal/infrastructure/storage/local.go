@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ローカルファイルシステムに画像を保存するストレージ
+type LocalStorage struct {
+	dir     string // 保存先のディレクトリ
+	baseURL string // 保存したファイルを公開するURLのプレフィックス
+}
+
+func NewLocalStorage(dir, baseURL string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &LocalStorage{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+func (s *LocalStorage) Save(ctx context.Context, name string, r io.Reader) (string, error) {
+	// ディレクトリの外に書き込まないようファイル名のみを使う
+	name = filepath.Base(name)
+	path := filepath.Join(s.dir, name)
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("failed to close file: %w", err)
+	}
+
+	return s.baseURL + "/" + name, nil
+}
+
+// URLに対応するファイルを削除する。既に存在しない場合は何もしない
+func (s *LocalStorage) Delete(ctx context.Context, url string) error {
+	name, ok := strings.CutPrefix(url, s.baseURL+"/")
+	if !ok || name == "" || name != filepath.Base(name) {
+		return fmt.Errorf("url is not managed by this storage: %s", url)
+	}
+
+	if err := os.Remove(filepath.Join(s.dir, name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"Aicon-assignment/internal/inflight"
+)
+
+// スパンを作成する計装の名前
+const instrumentationName = "Aicon-assignment"
+
+// 自身のスパンを作成しないパス
+var untracedPaths = map[string]bool{
+	"/metrics": true,
+	"/health":  true,
+	"/healthz": true,
+	"/readyz":  true,
+}
+
+// ルートに一致しないリクエストのスパンの名前に使うルート
+const unmatchedRoute = "unmatched"
+
+type Config struct {
+	ServiceName string // スパンを送信するサービスの名前（service.name）
+	Export      bool   // OTLPでスパンを送信するかどうか。falseの場合はno-opのプロバイダーのままにする
+}
+
+// W3C Trace Contextの伝播を設定し、cfg.Exportの場合はOTLPでスパンを送信するプロバイダーを設定する。
+// 送信先やヘッダーはエクスポーターがOTEL_EXPORTER_OTLP_*の環境変数から読み込む。
+// shutdownは送信待ちのスパンを送信してから終了する
+func Setup(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if !cfg.Export {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(cfg.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// リクエストごとにサーバーのスパンを作成するミドルウェア。traceparentヘッダーがあればそのトレースを続ける。
+// スパンの名前はメソッドとc.Path()のパターン（GET /api/v1/items/:id など）
+func Middleware() echo.MiddlewareFunc {
+	tracer := otel.Tracer(instrumentationName)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route := c.Path()
+			if untracedPaths[route] {
+				return next(c)
+			}
+			if route == "" || route == "/*" {
+				route = unmatchedRoute
+			}
+
+			req := c.Request()
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			ctx, span := tracer.Start(ctx, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					semconv.HTTPRoute(route),
+					semconv.URLPath(req.URL.Path),
+				),
+			)
+			defer span.End()
+			c.SetRequest(req.WithContext(ctx))
+
+			// ステータスを確定させるため、エラーはここでレスポンスにする
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			// クライアントのエラー（4xx）はサーバーのスパンではエラーにしない
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+			return nil
+		}
+	}
+}
+
+// usecase.Tracerの実装。リポジトリのスパンには保存先をdb.systemの属性として付ける
+type Tracer struct {
+	tracer   trace.Tracer
+	dbSystem attribute.KeyValue
+}
+
+// repository（config.Repository）に保存するリポジトリのスパンを作成するTracer
+func NewTracer(repository string) *Tracer {
+	t := &Tracer{tracer: otel.Tracer(instrumentationName)}
+	switch repository {
+	case "mysql":
+		t.dbSystem = semconv.DBSystemMySQL
+	case "postgres":
+		t.dbSystem = semconv.DBSystemPostgreSQL
+	case "sqlite":
+		t.dbSystem = semconv.DBSystemSqlite
+	}
+	return t
+}
+
+func (t *Tracer) StartUsecase(ctx context.Context, usecase, method string) (context.Context, func(error)) {
+	ctx, span := t.tracer.Start(ctx, usecase+"."+method)
+	return ctx, end(span, inflight.Enter(ctx, inflight.LayerUsecase, usecase+"."+method))
+}
+
+// SQLの全文は値を含み長くなるため、実行する文の名前としてリポジトリのメソッドを属性にする
+func (t *Tracer) StartQuery(ctx context.Context, repository, method string) (context.Context, func(error)) {
+	attrs := []attribute.KeyValue{semconv.DBOperationName(method)}
+	// メモリ上のリポジトリはデータベースではないためdb.systemを付けない
+	if t.dbSystem.Valid() {
+		attrs = append(attrs, t.dbSystem)
+	}
+	ctx, span := t.tracer.Start(ctx, repository+"."+method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	return ctx, end(span, inflight.Enter(ctx, inflight.LayerRepository, repository+"."+method))
+}
+
+// errがあれば記録してスパンを終了する関数。リクエストの制限時間を過ぎた場合にログに出せるよう、
+// スパンと同じ範囲をinflightにも記録する
+func end(span trace.Span, leave func(error)) func(error) {
+	return func(err error) {
+		leave(err)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,119 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 終了したスパンを記録するプロバイダーをグローバルに設定する
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	_, err := Setup(context.Background(), Config{})
+	require.NoError(t, err)
+	return recorder
+}
+
+func spanAttr(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := recordSpans(t)
+	e := echo.New()
+	e.Use(Middleware())
+	var handlerSpan trace.SpanContext
+	e.GET("/items/:id", func(c echo.Context) error {
+		handlerSpan = trace.SpanContextFromContext(c.Request().Context())
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/fail", func(c echo.Context) error { return echo.NewHTTPError(http.StatusServiceUnavailable) })
+	e.GET("/health", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	// traceparentのトレースを続ける
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "GET /items/:id", spans[0].Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	assert.Equal(t, spans[0].SpanContext().SpanID(), handlerSpan.SpanID())
+	assert.Equal(t, int64(http.StatusOK), spanAttr(spans[0], "http.response.status_code").AsInt64())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	assert.Equal(t, "GET /fail", spans[1].Name())
+	assert.False(t, spans[1].Parent().IsValid())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+func TestTracer_StartQuery(t *testing.T) {
+	recorder := recordSpans(t)
+	tracer := NewTracer("postgres")
+
+	ctx, endUsecase := tracer.StartUsecase(context.Background(), "ItemUsecase", "GetItemByID")
+	_, endQuery := tracer.StartQuery(ctx, "ItemRepository", "FindByID")
+	endQuery(assert.AnError)
+	endUsecase(nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	query, usecase := spans[0], spans[1]
+	assert.Equal(t, "ItemRepository.FindByID", query.Name())
+	assert.Equal(t, usecase.SpanContext().SpanID(), query.Parent().SpanID())
+	assert.Equal(t, "FindByID", spanAttr(query, "db.operation.name").AsString())
+	assert.Equal(t, "postgresql", spanAttr(query, "db.system").AsString())
+	assert.Equal(t, codes.Error, query.Status().Code)
+	assert.Len(t, query.Events(), 1)
+	assert.Equal(t, codes.Unset, usecase.Status().Code)
+
+	// メモリ上のリポジトリにはdb.systemを付けない
+	_, endQuery = NewTracer("memory").StartQuery(context.Background(), "ItemRepository", "FindByID")
+	endQuery(nil)
+	assert.Equal(t, attribute.INVALID, spanAttr(recorder.Ended()[2], "db.system").Type())
+}
+
+func TestLogHandler(t *testing.T) {
+	recordSpans(t)
+	var buf bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewTextHandler(&buf, nil)))
+
+	logger.InfoContext(context.Background(), "スパンの外")
+	assert.NotContains(t, buf.String(), "trace_id")
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "test")
+	defer span.End()
+	buf.Reset()
+	logger.With("key", "value").InfoContext(ctx, "スパンの中")
+	assert.Contains(t, buf.String(), "trace_id="+span.SpanContext().TraceID().String())
+	assert.Contains(t, buf.String(), "span_id="+span.SpanContext().SpanID().String())
+	assert.Contains(t, buf.String(), "key=value")
+}
@@ -0,0 +1,307 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/requestid"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 送信時に付けるヘッダー
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// 最初の送信と3回のやり直しを合わせた送信の回数
+const MaxAttempts = 4
+
+// やり直すまでの待ち時間。n回目のやり直しはn番目の値だけ待つ
+var defaultRetryDelays = []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+
+// 記録するエラーの最大文字数（webhook_deliveries.errorの長さ）
+const maxErrorLength = 1000
+
+type Config struct {
+	Workers     int           // 送信する並行数
+	QueueSize   int           // 送信待ちのイベントの上限
+	Timeout     time.Duration // 1回の送信の制限時間
+	MaxFailures int           // 連続して失敗するとWebhookを無効にする回数
+}
+
+// アイテムのイベントを登録済みのWebhookに非同期で送信するusecase.EventHandler。コミットした変更のみを送信するよう、usecase.AfterCommitで包んで登録する。
+// イベントは上限つきのキューに入れ、Runで起動したワーカーが送信する。キューが一杯の場合は破棄する
+type Dispatcher struct {
+	repo        usecase.WebhookRepository
+	cfg         Config
+	client      *http.Client
+	events      chan event
+	retryDelays []time.Duration
+}
+
+// 送信するイベント。本文は通知の時点でJSONにしておく
+type event struct {
+	id        string
+	name      string
+	itemID    int64
+	body      []byte
+	requestID string // 変更したリクエストのID。送信時にX-Request-IDヘッダーで転送する
+}
+
+// 1つのWebhookへの1つのイベントの配信
+type job struct {
+	webhook *entity.Webhook
+	event   event
+}
+
+// Webhookに送信する本文
+type payload struct {
+	ID        string       `json:"id"`
+	Event     string       `json:"event"`
+	CreatedAt time.Time    `json:"created_at"`
+	Data      *entity.Item `json:"data"`
+}
+
+func NewDispatcher(repo usecase.WebhookRepository, cfg Config) *Dispatcher {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 1
+	}
+
+	return &Dispatcher{
+		repo: repo,
+		cfg:  cfg,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			// リダイレクトではPOSTの本文が失われるため、応答をそのまま失敗として扱う
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		events:      make(chan event, cfg.QueueSize),
+		retryDelays: defaultRetryDelays,
+	}
+}
+
+// イベントの種類ごとのWebhookのイベント名。物理削除は送信しない
+var webhookEvents = map[entity.EventType]string{
+	entity.EventItemCreated:  entity.WebhookEventItemCreated,
+	entity.EventItemUpdated:  entity.WebhookEventItemUpdated,
+	entity.EventItemRestored: entity.WebhookEventItemUpdated,
+	entity.EventItemDeleted:  entity.WebhookEventItemDeleted,
+}
+
+// イベントをキューに入れてすぐに戻る。キューが一杯の場合はイベントを破棄して警告する。
+// 送信できなくても変更は取り消さないため、エラーは返さない
+func (d *Dispatcher) HandleEvent(ctx context.Context, e entity.Event) error {
+	name, ok := webhookEvents[e.Type]
+	if !ok {
+		return nil
+	}
+	// 削除したアイテムは取得できないため、削除前の内容を送信する
+	item := e.After
+	if e.Type == entity.EventItemDeleted {
+		item = e.Before
+	}
+
+	id, err := newEventID()
+	if err != nil {
+		slog.WarnContext(ctx, "⚠️  WebhookのイベントIDを生成できないため、イベントを破棄しました", "event", name, "error", err)
+		return nil
+	}
+	body, err := json.Marshal(payload{ID: id, Event: name, CreatedAt: e.OccurredAt, Data: item})
+	if err != nil {
+		slog.WarnContext(ctx, "⚠️  Webhookの本文を作成できないため、イベントを破棄しました", "event", name, "error", err)
+		return nil
+	}
+
+	select {
+	case d.events <- event{id: id, name: name, itemID: e.ItemID, body: body, requestID: requestid.From(ctx)}:
+	default:
+		slog.WarnContext(ctx, "⚠️  Webhookの送信待ちが上限に達したため、イベントを破棄しました", "queue_size", d.cfg.QueueSize, "event", name, "item_id", e.ItemID)
+	}
+	return nil
+}
+
+// ctxがキャンセルされるまでイベントを送信する。キャンセル後は送信中の試行の完了を待って戻り、
+// キューに残ったイベントは送信せずに終了する
+func (d *Dispatcher) Run(ctx context.Context) {
+	jobs := make(chan job)
+
+	var wg sync.WaitGroup
+	for i := 0; i < d.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				d.deliver(ctx, j)
+			}
+		}()
+	}
+
+	d.fanOut(ctx, jobs)
+	close(jobs)
+	wg.Wait()
+}
+
+// キューのイベントを有効なWebhookごとの配信に分けてワーカーに渡す
+func (d *Dispatcher) fanOut(ctx context.Context, jobs chan<- job) {
+	for {
+		var e event
+		select {
+		case <-ctx.Done():
+			return
+		case e = <-d.events:
+		}
+
+		webhooks, err := d.repo.FindAll(ctx)
+		if err != nil {
+			slog.WarnContext(ctx, "⚠️  Webhookの取得に失敗したため、イベントを破棄しました", "event", e.name, "item_id", e.itemID, "error", err)
+			continue
+		}
+		for _, webhook := range webhooks {
+			if !webhook.Enabled {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- job{webhook: webhook, event: e}:
+			}
+		}
+	}
+}
+
+// 成功するか送信の回数の上限に達するまで送信し、結果をWebhookの失敗の回数に反映する。
+// ctxがキャンセルされた場合（サーバーの終了時）も送信中の試行は完了させて記録し、やり直しは待たずにやめる
+func (d *Dispatcher) deliver(ctx context.Context, j job) {
+	// 送信の制限時間はclientのTimeoutで区切られるため、キャンセルを引き継がなくても終了を長く待たせない
+	sendCtx := context.WithoutCancel(ctx)
+	succeeded := false
+	for attempt := 1; attempt <= MaxAttempts; attempt++ {
+		if attempt > 1 && !d.wait(ctx, attempt-1) {
+			return
+		}
+
+		delivery := d.send(sendCtx, j, attempt)
+		if err := d.repo.CreateDelivery(sendCtx, delivery); err != nil {
+			// 送信中に削除されたWebhookにはやり直さない
+			if errors.Is(err, domainErrors.ErrWebhookNotFound) {
+				return
+			}
+			slog.WarnContext(sendCtx, "⚠️  Webhookの送信の記録に失敗しました", "webhook_id", j.webhook.ID, "error", err)
+		}
+		if delivery.Succeeded {
+			succeeded = true
+			break
+		}
+	}
+
+	if err := d.repo.RecordResult(sendCtx, j.webhook.ID, succeeded, d.cfg.MaxFailures); err != nil {
+		slog.WarnContext(sendCtx, "⚠️  Webhookの送信結果の記録に失敗しました", "webhook_id", j.webhook.ID, "error", err)
+	}
+}
+
+// n回目のやり直しまで待つ。待っている間にctxがキャンセルされた場合はfalse
+func (d *Dispatcher) wait(ctx context.Context, n int) bool {
+	delay := d.retryDelays[len(d.retryDelays)-1]
+	if n <= len(d.retryDelays) {
+		delay = d.retryDelays[n-1]
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// 1回送信し、その記録を返す。2xxの応答のみを成功とする
+func (d *Dispatcher) send(ctx context.Context, j job, attempt int) *entity.WebhookDelivery {
+	delivery := &entity.WebhookDelivery{
+		WebhookID: j.webhook.ID,
+		EventID:   j.event.id,
+		Event:     j.event.name,
+		ItemID:    j.event.itemID,
+		Attempt:   attempt,
+	}
+
+	start := time.Now()
+	resp, err := d.post(ctx, j)
+	delivery.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		delivery.Error = truncate(err.Error(), maxErrorLength)
+		return delivery
+	}
+	defer resp.Body.Close()
+	// 接続を再利用できるよう、本文を読み捨てる
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	delivery.StatusCode = &resp.StatusCode
+	delivery.Succeeded = resp.StatusCode >= 200 && resp.StatusCode < 300
+	if !delivery.Succeeded {
+		delivery.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+	return delivery
+}
+
+func (d *Dispatcher) post(ctx context.Context, j job) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, j.webhook.URL, bytes.NewReader(j.event.body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, j.event.name)
+	req.Header.Set(HeaderDelivery, j.event.id)
+	req.Header.Set(HeaderSignature, Sign(j.webhook.Secret, j.event.body))
+	if j.event.requestID != "" {
+		req.Header.Set(requestid.Header, j.event.requestID)
+	}
+
+	return d.client.Do(req)
+}
+
+// 本文のHMAC-SHA256の署名を「sha256=16進数」の形式で返す
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// やり直しでも変わらないイベントのID
+func newEventID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
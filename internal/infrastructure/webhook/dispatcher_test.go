@@ -0,0 +1,193 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/memory"
+	"Aicon-assignment/internal/requestid"
+)
+
+const testSecret = "0123456789abcdef"
+
+// メモリ上のリポジトリとWebhookを作成し、Dispatcherを起動する
+func startDispatcher(t *testing.T, url string, maxFailures int) (*Dispatcher, *memory.WebhookRepository, *entity.Webhook) {
+	t.Helper()
+
+	repo := &memory.WebhookRepository{Store: memory.NewStore()}
+	webhook, err := entity.NewWebhook(url, testSecret)
+	require.NoError(t, err)
+	webhook, err = repo.Create(context.Background(), webhook)
+	require.NoError(t, err)
+
+	d := NewDispatcher(repo, Config{Workers: 2, QueueSize: 10, Timeout: time.Second, MaxFailures: maxFailures})
+	d.retryDelays = []time.Duration{time.Millisecond}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	return d, repo, webhook
+}
+
+// 変更後のアイテムがIDのみのイベント
+func itemEvent(eventType entity.EventType, id int64) entity.Event {
+	return entity.NewItemEvent(eventType, id, entity.ItemChange{Before: &entity.Item{ID: id}, After: &entity.Item{ID: id}})
+}
+
+// 送信の記録がn件になるまで待つ
+func waitDeliveries(t *testing.T, repo *memory.WebhookRepository, webhookID int64, n int) []*entity.WebhookDelivery {
+	t.Helper()
+
+	var deliveries []*entity.WebhookDelivery
+	require.Eventually(t, func() bool {
+		var err error
+		deliveries, err = repo.FindDeliveries(context.Background(), webhookID, 100)
+		require.NoError(t, err)
+		return len(deliveries) >= n
+	}, 5*time.Second, 10*time.Millisecond)
+	return deliveries
+}
+
+func TestDispatcher_SignedDelivery(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	d, repo, webhook := startDispatcher(t, server.URL, 3)
+	item := &entity.Item{ID: 7, Name: "ロレックス デイトナ"}
+	// 変更したリクエストのIDを転送する
+	ctx := requestid.NewContext(context.Background(), "req-1")
+	require.NoError(t, d.HandleEvent(ctx, entity.NewItemEvent(entity.EventItemCreated, item.ID, entity.ItemChange{After: item})))
+
+	r := <-received
+	body := <-bodies
+	assert.Equal(t, http.MethodPost, r.Method)
+	assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+	assert.Equal(t, entity.WebhookEventItemCreated, r.Header.Get(HeaderEvent))
+	assert.Equal(t, Sign(testSecret, body), r.Header.Get(HeaderSignature))
+	assert.Equal(t, "req-1", r.Header.Get(requestid.Header))
+	assert.Contains(t, string(body), `"event":"item.created"`)
+	assert.Contains(t, string(body), `"name":"ロレックス デイトナ"`)
+
+	deliveries := waitDeliveries(t, repo, webhook.ID, 1)
+	require.Len(t, deliveries, 1)
+	assert.True(t, deliveries[0].Succeeded)
+	assert.Equal(t, 1, deliveries[0].Attempt)
+	assert.Equal(t, r.Header.Get(HeaderDelivery), deliveries[0].EventID)
+	assert.Equal(t, int64(7), deliveries[0].ItemID)
+	require.NotNil(t, deliveries[0].StatusCode)
+	assert.Equal(t, http.StatusNoContent, *deliveries[0].StatusCode)
+}
+
+func TestDispatcher_RetryThenSuccess(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	d, repo, webhook := startDispatcher(t, server.URL, 3)
+	require.NoError(t, d.HandleEvent(context.Background(), itemEvent(entity.EventItemUpdated, 1)))
+
+	// 新しい順
+	deliveries := waitDeliveries(t, repo, webhook.ID, 3)
+	require.Len(t, deliveries, 3)
+	assert.True(t, deliveries[0].Succeeded)
+	assert.Equal(t, 3, deliveries[0].Attempt)
+	assert.False(t, deliveries[2].Succeeded)
+	assert.Equal(t, "unexpected status 503", deliveries[2].Error)
+	// やり直しでもイベントのIDは変わらない
+	assert.Equal(t, deliveries[0].EventID, deliveries[2].EventID)
+
+	require.Eventually(t, func() bool {
+		stored, err := repo.FindByID(context.Background(), webhook.ID)
+		require.NoError(t, err)
+		return stored.FailureCount == 0 && stored.Enabled
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestDispatcher_DisableAfterMaxFailures(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	d, repo, webhook := startDispatcher(t, server.URL, 2)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, d.HandleEvent(context.Background(), itemEvent(entity.EventItemDeleted, 1)))
+	}
+
+	require.Eventually(t, func() bool {
+		stored, err := repo.FindByID(context.Background(), webhook.ID)
+		require.NoError(t, err)
+		return !stored.Enabled
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(2*MaxAttempts), calls.Load())
+
+	stored, err := repo.FindByID(context.Background(), webhook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.FailureCount)
+
+	// 無効なWebhookには送信しない
+	require.NoError(t, d.HandleEvent(context.Background(), itemEvent(entity.EventItemDeleted, 1)))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(2*MaxAttempts), calls.Load())
+}
+
+func TestDispatcher_DropWhenQueueIsFull(t *testing.T) {
+	d := NewDispatcher(&memory.WebhookRepository{Store: memory.NewStore()}, Config{QueueSize: 1})
+
+	require.NoError(t, d.HandleEvent(context.Background(), itemEvent(entity.EventItemCreated, 1)))
+	require.NoError(t, d.HandleEvent(context.Background(), itemEvent(entity.EventItemCreated, 2)))
+
+	assert.Len(t, d.events, 1)
+}
+
+func TestDispatcher_HandleEvent(t *testing.T) {
+	d := NewDispatcher(&memory.WebhookRepository{Store: memory.NewStore()}, Config{QueueSize: 10})
+
+	before := &entity.Item{ID: 1, Name: "ロレックス デイトナ"}
+	now := entity.Now()
+	after := &entity.Item{ID: 1, Name: "ロレックス デイトナ", DeletedAt: &now}
+	require.NoError(t, d.HandleEvent(context.Background(), entity.NewItemEvent(entity.EventItemDeleted, 1, entity.ItemChange{Before: before, After: after})))
+	require.NoError(t, d.HandleEvent(context.Background(), itemEvent(entity.EventItemRestored, 1)))
+	// 物理削除は送信しない
+	require.NoError(t, d.HandleEvent(context.Background(), entity.NewItemEvent(entity.EventItemHardDeleted, 1, entity.ItemChange{Before: before})))
+
+	require.Len(t, d.events, 2)
+	deleted := <-d.events
+	assert.Equal(t, entity.WebhookEventItemDeleted, deleted.name)
+	// 削除は削除前の内容を送信する
+	assert.NotContains(t, string(deleted.body), `"deleted_at"`)
+	restored := <-d.events
+	assert.Equal(t, entity.WebhookEventItemUpdated, restored.name)
+}
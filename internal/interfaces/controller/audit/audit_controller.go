@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuditHandler struct {
+	auditUsecase usecase.AuditUsecase
+}
+
+func NewAuditHandler(auditUsecase usecase.AuditUsecase) *AuditHandler {
+	return &AuditHandler{
+		auditUsecase: auditUsecase,
+	}
+}
+
+// GET /audit?actor=...&action=...&from=...&to=...&limit=...&offset=...
+// 監査ログを新しい順に返す
+func (h *AuditHandler) GetAuditLogs(c echo.Context) error {
+	var validationErrors []string
+	filter := entity.AuditLogFilter{
+		Actor:  strings.TrimSpace(c.QueryParam("actor")),
+		Action: strings.TrimSpace(c.QueryParam("action")),
+		From:   parseTimeQuery(c, "from", false, &validationErrors),
+		To:     parseTimeQuery(c, "to", true, &validationErrors),
+	}
+	limit := parseIntQuery(c, "limit", 1, &validationErrors)
+	offset := parseIntQuery(c, "offset", 0, &validationErrors)
+	if len(validationErrors) > 0 {
+		return httperror.BadRequest(c, "validation failed", validationErrors...)
+	}
+
+	logs, err := h.auditUsecase.ListAuditLogs(c.Request().Context(), filter, limit, offset)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve audit logs")
+	}
+
+	return c.JSON(http.StatusOK, logs)
+}
+
+// 時刻のクエリパラメータを解析。RFC 3339の日時か、YYYY-MM-DDの日付（UTC）を受け付ける。
+// endがtrueの場合は日付をその日の終わり（翌日の0時）として扱い、指定した日を範囲に含める
+func parseTimeQuery(c echo.Context, name string, end bool, errs *[]string) *time.Time {
+	valueStr := strings.TrimSpace(c.QueryParam(name))
+	if valueStr == "" {
+		return nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, valueStr); err == nil {
+		return &t
+	}
+	date, err := time.Parse(time.DateOnly, valueStr)
+	if err != nil {
+		*errs = append(*errs, name+" must be an RFC 3339 timestamp or in YYYY-MM-DD format")
+		return nil
+	}
+	if end {
+		date = date.AddDate(0, 0, 1)
+	}
+	return &date
+}
+
+// 整数のクエリパラメータを解析。未指定または不正な値の場合は0を返す
+func parseIntQuery(c echo.Context, name string, min int, errs *[]string) int {
+	valueStr := c.QueryParam(name)
+	if valueStr == "" {
+		return 0
+	}
+
+	v, err := strconv.Atoi(valueStr)
+	if err != nil {
+		*errs = append(*errs, name+" must be an integer")
+		return 0
+	}
+	if v < min {
+		*errs = append(*errs, name+" must be "+strconv.Itoa(min)+" or greater")
+		return 0
+	}
+	return v
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/interfaces/openapi/openapitest"
+	"Aicon-assignment/internal/usecase"
+)
+
+type stubAuditUsecase struct {
+	filter        entity.AuditLogFilter
+	limit, offset int
+}
+
+func (u *stubAuditUsecase) ListAuditLogs(ctx context.Context, filter entity.AuditLogFilter, limit, offset int) (*usecase.AuditLogList, error) {
+	u.filter, u.limit, u.offset = filter, limit, offset
+	return &usecase.AuditLogList{AuditLogs: []*entity.AuditLog{}, Limit: limit, Offset: offset}, nil
+}
+
+func TestAuditHandler_GetAuditLogs(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	dayAfter := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	instant := time.Date(2024, 1, 15, 9, 30, 0, 0, time.FixedZone("", 9*60*60))
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedFilter entity.AuditLogFilter
+		expectedLimit  int
+		expectedOffset int
+	}{
+		{name: "正常系: 条件なし", expectedStatus: http.StatusOK},
+		{
+			name:           "正常系: 日付の終わりは翌日の0時",
+			query:          "?actor=user:2&action=update&from=2024-01-01&to=2024-01-31&limit=10&offset=20",
+			expectedStatus: http.StatusOK,
+			expectedFilter: entity.AuditLogFilter{Actor: "user:2", Action: "update", From: &from, To: &dayAfter},
+			expectedLimit:  10,
+			expectedOffset: 20,
+		},
+		{
+			name:           "正常系: RFC 3339の日時",
+			query:          "?from=2024-01-15T09:30:00%2B09:00",
+			expectedStatus: http.StatusOK,
+			expectedFilter: entity.AuditLogFilter{From: &instant},
+		},
+		{name: "異常系: 日時の形式", query: "?from=2024/01/01", expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 数値でないlimit", query: "?limit=abc", expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 負のoffset", query: "?offset=-1", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubAuditUsecase{}
+			h := NewAuditHandler(stub)
+			req := httptest.NewRequest(http.MethodGet, "/audit"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, h.GetAuditLogs(echo.New().NewContext(req, rec)))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			openapitest.AssertExchange(t, openapitest.Document("", Operations()...), http.MethodGet, "/audit", "", rec)
+			if tt.expectedStatus != http.StatusOK {
+				var problem httperror.Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+				return
+			}
+			assert.Equal(t, tt.expectedFilter.Actor, stub.filter.Actor)
+			assert.Equal(t, tt.expectedFilter.Action, stub.filter.Action)
+			assertTime(t, tt.expectedFilter.From, stub.filter.From)
+			assertTime(t, tt.expectedFilter.To, stub.filter.To)
+			assert.Equal(t, tt.expectedLimit, stub.limit)
+			assert.Equal(t, tt.expectedOffset, stub.offset)
+		})
+	}
+}
+
+func assertTime(t *testing.T, expected, actual *time.Time) {
+	t.Helper()
+	if expected == nil {
+		assert.Nil(t, actual)
+		return
+	}
+	require.NotNil(t, actual)
+	assert.True(t, expected.Equal(*actual), "expected %s, got %s", expected, actual)
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"net/http"
+
+	"Aicon-assignment/internal/interfaces/openapi"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 監査ログのルートの操作
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/audit", ID: "listAuditLogs", Summary: "アイテムの変更の監査ログ（管理者用、ページネーション対応）", Tags: []string{"admin"},
+			Parameters: []*openapi.Parameter{
+				openapi.Query("actor", openapi.String(), "変更したユーザー"),
+				openapi.Query("action", openapi.String(), "操作（create, update, deleteなど）"),
+				openapi.Query("from", openapi.String(), "期間の開始（RFC 3339の日時かYYYY-MM-DD）"),
+				openapi.Query("to", openapi.String(), "期間の終了（RFC 3339の日時かYYYY-MM-DD。日付の場合はその日を含む）"),
+				openapi.Query("limit", openapi.Integer(), "取得する件数"),
+				openapi.Query("offset", openapi.Integer(), "読み飛ばす件数"),
+			},
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("新しい順の監査ログ", openapi.Of(usecase.AuditLogList{}))},
+			Errors:    []int{http.StatusBadRequest, http.StatusForbidden},
+		},
+	}
+}
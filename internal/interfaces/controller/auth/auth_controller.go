@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/interfaces/controller/request"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuthHandler struct {
+	authUsecase usecase.AuthUsecase
+}
+
+func NewAuthHandler(authUsecase usecase.AuthUsecase) *AuthHandler {
+	return &AuthHandler{
+		authUsecase: authUsecase,
+	}
+}
+
+// 登録とログインのリクエストボディ
+type credentialsRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// 再発行のリクエストボディ
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// 発行したトークン。アクセストークンはAuthorization: Bearer <token>ヘッダーで指定する
+type tokenResponse struct {
+	AccessToken           string    `json:"access_token"`
+	TokenType             string    `json:"token_type"`
+	ExpiresIn             int64     `json:"expires_in"` // アクセストークンの有効期間（秒）
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+type registerResponse struct {
+	User *entity.User `json:"user"`
+	tokenResponse
+}
+
+func newTokenResponse(tokens *usecase.AuthTokens) tokenResponse {
+	return tokenResponse{
+		AccessToken:           tokens.AccessToken,
+		TokenType:             "Bearer",
+		ExpiresIn:             int64(tokens.AccessTokenExpiresAt.Sub(entity.Now()).Seconds()),
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	}
+}
+
+// POST /auth/register
+// ユーザーを登録し、そのユーザーのトークンを返す
+func (h *AuthHandler) Register(c echo.Context) error {
+	var req credentialsRequest
+	if err := request.Decode(c, &req); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	user, tokens, err := h.authUsecase.Register(c.Request().Context(), req.Email, req.Password)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to register user")
+	}
+
+	return c.JSON(http.StatusCreated, registerResponse{User: user, tokenResponse: newTokenResponse(tokens)})
+}
+
+// POST /auth/login
+// メールアドレスとパスワードが一致しない場合は、どちらが誤っているかを区別せずに401を返す
+func (h *AuthHandler) Login(c echo.Context) error {
+	var req credentialsRequest
+	if err := request.Decode(c, &req); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	tokens, err := h.authUsecase.Login(c.Request().Context(), req.Email, req.Password)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to log in")
+	}
+
+	return c.JSON(http.StatusOK, newTokenResponse(tokens))
+}
+
+// POST /auth/refresh
+// リフレッシュトークンを失効させ、新しいトークンの組を返す
+func (h *AuthHandler) Refresh(c echo.Context) error {
+	var req refreshRequest
+	if err := request.Decode(c, &req); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	tokens, err := h.authUsecase.Refresh(c.Request().Context(), req.RefreshToken)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to refresh token")
+	}
+
+	return c.JSON(http.StatusOK, newTokenResponse(tokens))
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/openapi/openapitest"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 使わないメソッドは埋め込んだインターフェースに委ねる（呼ぶとpanicする）
+type stubAuthUsecase struct {
+	usecase.AuthUsecase
+}
+
+func stubTokens() *usecase.AuthTokens {
+	return &usecase.AuthTokens{
+		AccessToken:           "access",
+		AccessTokenExpiresAt:  entity.Now().Add(15 * time.Minute),
+		RefreshToken:          "refresh",
+		RefreshTokenExpiresAt: entity.Now().Add(time.Hour),
+	}
+}
+
+func (u *stubAuthUsecase) Register(ctx context.Context, email, password string) (*entity.User, *usecase.AuthTokens, error) {
+	return &entity.User{ID: 2, Email: email, PasswordHash: "hash"}, stubTokens(), nil
+}
+
+func (u *stubAuthUsecase) Login(ctx context.Context, email, password string) (*usecase.AuthTokens, error) {
+	if password != "password" {
+		return nil, domainErrors.ErrUnauthenticated
+	}
+	return stubTokens(), nil
+}
+
+func post(t *testing.T, handler echo.HandlerFunc, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler(echo.New().NewContext(req, rec)))
+	openapitest.AssertExchange(t, openapitest.Document("", Operations()...), http.MethodPost, path, body, rec)
+	return rec
+}
+
+func TestAuthHandler_Register(t *testing.T) {
+	h := NewAuthHandler(&stubAuthUsecase{})
+
+	rec := post(t, h.Register, "/auth/register", `{"email": "alice@example.com", "password": "password"}`)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var res map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, "alice@example.com", res["user"].(map[string]interface{})["email"])
+	assert.Equal(t, "access", res["access_token"])
+	assert.Equal(t, "Bearer", res["token_type"])
+	assert.Equal(t, "refresh", res["refresh_token"])
+	// パスワードのハッシュ値はレスポンスに含めない
+	assert.NotContains(t, rec.Body.String(), "hash")
+}
+
+func TestAuthHandler_Login(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "正常系: トークンを返す", body: `{"email": "alice@example.com", "password": "password"}`, expectedStatus: http.StatusOK},
+		{name: "異常系: パスワードの誤りは401", body: `{"email": "alice@example.com", "password": "wrong"}`, expectedStatus: http.StatusUnauthorized},
+		{name: "異常系: 未知のフィールドは400", body: `{"email": "alice@example.com", "pass": "password"}`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := post(t, NewAuthHandler(&stubAuthUsecase{}).Login, "/auth/login", tt.body)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+}
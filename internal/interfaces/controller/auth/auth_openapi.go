@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"net/http"
+
+	"Aicon-assignment/internal/interfaces/openapi"
+)
+
+// 認証のルートの操作。トークンを得る前に呼び出すため、認証せずに呼び出せる
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method: http.MethodPost, Path: "/auth/register", ID: "register", Summary: "ユーザー登録（トークンを発行）", Tags: []string{"auth"},
+			RequestBody: openapi.JSONBody(openapi.Of(credentialsRequest{})),
+			Responses:   openapi.Responses{http.StatusCreated: openapi.JSON("登録したユーザーとトークン", openapi.Of(registerResponse{}))},
+			Errors:      []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity},
+			Public:      true,
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/login", ID: "login", Summary: "ログイン（トークンを発行）", Tags: []string{"auth"},
+			RequestBody: openapi.JSONBody(openapi.Of(credentialsRequest{})),
+			Responses:   openapi.Responses{http.StatusOK: openapi.JSON("トークン", openapi.Of(tokenResponse{}))},
+			Errors:      []int{http.StatusBadRequest, http.StatusUnauthorized},
+			Public:      true,
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/refresh", ID: "refreshToken", Summary: "リフレッシュトークンでトークンを再発行", Tags: []string{"auth"},
+			RequestBody: openapi.JSONBody(openapi.Of(refreshRequest{})),
+			Responses:   openapi.Responses{http.StatusOK: openapi.JSON("新しいトークン", openapi.Of(tokenResponse{}))},
+			Errors:      []int{http.StatusBadRequest, http.StatusUnauthorized},
+			Public:      true,
+		},
+	}
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/interfaces/controller/request"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type BrandHandler struct {
+	brandUsecase usecase.BrandUsecase
+}
+
+func NewBrandHandler(brandUsecase usecase.BrandUsecase) *BrandHandler {
+	return &BrandHandler{
+		brandUsecase: brandUsecase,
+	}
+}
+
+type MergeBrandRequest struct {
+	TargetID int64 `json:"target_id"`
+}
+
+// GET /brands?q=lo&limit=10
+// 名前か別名が前方一致するブランドを返す（入力補完用）
+func (h *BrandHandler) SearchBrands(c echo.Context) error {
+	var limit int
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		v, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return httperror.BadRequest(c, "invalid limit parameter", "limit must be an integer")
+		}
+		if v < 1 {
+			return httperror.BadRequest(c, "invalid limit parameter", "limit must be 1 or greater")
+		}
+		limit = v
+	}
+
+	brands, err := h.brandUsecase.SearchBrands(c.Request().Context(), c.QueryParam("q"), limit)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to search brands")
+	}
+
+	return c.JSON(http.StatusOK, brands)
+}
+
+// POST /admin/brands
+func (h *BrandHandler) CreateBrand(c echo.Context) error {
+	var input usecase.CreateBrandInput
+	if err := request.Decode(c, &input); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	brand, err := h.brandUsecase.CreateBrand(c.Request().Context(), input)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to create brand")
+	}
+
+	return c.JSON(http.StatusCreated, brand)
+}
+
+// POST /admin/brands/{id}/merge
+// ブランドをtarget_idのブランドに統合し、アイテムのブランド名も書き換える
+func (h *BrandHandler) MergeBrands(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid brand ID")
+	}
+
+	var req MergeBrandRequest
+	if err := request.Decode(c, &req); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	result, err := h.brandUsecase.MergeBrands(c.Request().Context(), id, req.TargetID)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to merge brands")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"net/http"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/openapi"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ブランドのルートの操作
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/brands", ID: "searchBrands", Summary: "ブランドの候補（名前・別名の前方一致）", Tags: []string{"brands"},
+			Parameters: []*openapi.Parameter{
+				openapi.Query("q", openapi.String(), "名前か別名の前方"),
+				openapi.Query("limit", openapi.Integer(), "返すブランドの数"),
+			},
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("ブランドの候補", openapi.ArrayOf(openapi.Of(entity.Brand{})))},
+			Errors:    []int{http.StatusBadRequest},
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/brands", ID: "createBrand", Summary: "ブランド登録（管理者用）", Tags: []string{"admin"},
+			RequestBody: openapi.JSONBody(openapi.Of(usecase.CreateBrandInput{})),
+			Responses:   openapi.Responses{http.StatusCreated: openapi.JSON("登録したブランド", openapi.Of(entity.Brand{}))},
+			Errors:      []int{http.StatusBadRequest, http.StatusForbidden, http.StatusConflict, http.StatusUnprocessableEntity},
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/brands/{id}/merge", ID: "mergeBrands", Summary: "ブランドの統合（管理者用）", Tags: []string{"admin"},
+			Parameters:  []*openapi.Parameter{openapi.PathID("id", "統合するブランドのID")},
+			RequestBody: openapi.JSONBody(openapi.Of(MergeBrandRequest{})),
+			Responses:   openapi.Responses{http.StatusOK: openapi.JSON("統合の結果", openapi.Of(usecase.BrandMergeResult{}))},
+			Errors:      []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+		},
+	}
+}
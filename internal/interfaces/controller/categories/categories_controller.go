@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/interfaces/controller/request"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CategoryHandler struct {
+	categoryUsecase usecase.CategoryUsecase
+}
+
+func NewCategoryHandler(categoryUsecase usecase.CategoryUsecase) *CategoryHandler {
+	return &CategoryHandler{
+		categoryUsecase: categoryUsecase,
+	}
+}
+
+// 使用中のカテゴリーを削除しようとした場合のレスポンス
+type CategoryInUseResponse struct {
+	Error     string `json:"error"`
+	Code      string `json:"code"`
+	ItemCount int    `json:"item_count"`
+}
+
+type CategoryRequest struct {
+	Name string `json:"name"`
+}
+
+// GET /categories, GET /admin/categories
+func (h *CategoryHandler) GetCategories(c echo.Context) error {
+	categories, err := h.categoryUsecase.ListCategories(c.Request().Context())
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve categories")
+	}
+
+	return c.JSON(http.StatusOK, categories)
+}
+
+// GET /admin/categories/{id}
+func (h *CategoryHandler) GetCategory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid category ID")
+	}
+
+	category, err := h.categoryUsecase.GetCategory(c.Request().Context(), id)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve category")
+	}
+
+	return c.JSON(http.StatusOK, category)
+}
+
+// POST /admin/categories
+func (h *CategoryHandler) CreateCategory(c echo.Context) error {
+	var input usecase.CreateCategoryInput
+	if err := request.Decode(c, &input); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	category, err := h.categoryUsecase.CreateCategory(c.Request().Context(), input)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to create category")
+	}
+
+	return c.JSON(http.StatusCreated, category)
+}
+
+// PUT /admin/categories/{id}
+// カテゴリー名を変更すると、そのカテゴリーのアイテムも新しい名前に付け替わる
+func (h *CategoryHandler) UpdateCategory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid category ID")
+	}
+
+	var req CategoryRequest
+	if err := request.Decode(c, &req); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	category, err := h.categoryUsecase.UpdateCategory(c.Request().Context(), id, req.Name)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to update category")
+	}
+
+	return c.JSON(http.StatusOK, category)
+}
+
+// DELETE /admin/categories/{id}
+func (h *CategoryHandler) DeleteCategory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid category ID")
+	}
+
+	err = h.categoryUsecase.DeleteCategory(c.Request().Context(), id)
+	if err != nil {
+		var inUseErr *usecase.CategoryInUseError
+		if errors.As(err, &inUseErr) {
+			res := CategoryInUseResponse{
+				Error:     "category is in use",
+				Code:      httperror.CodeCategoryInUse,
+				ItemCount: inUseErr.ItemCount,
+			}
+			problem := httperror.NewProblem(http.StatusConflict, httperror.ErrorResponse{Error: res.Error, Code: res.Code})
+			problem.Extensions["item_count"] = res.ItemCount
+			return httperror.Write(c, problem, res)
+		}
+		return httperror.Respond(c, err, "failed to delete category")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/openapi/openapitest"
+	"Aicon-assignment/internal/usecase"
+)
+
+// stubCategoryUsecase は名前の変更のみを記録するテスト用のユースケース。
+// 使わないメソッドは埋め込んだインターフェースに委ねる（呼ぶとpanicする）
+type stubCategoryUsecase struct {
+	usecase.CategoryUsecase
+	renamed string
+}
+
+func (u *stubCategoryUsecase) UpdateCategory(ctx context.Context, id int64, name string) (*entity.Category, error) {
+	u.renamed = name
+	return &entity.Category{ID: id, Name: name}, nil
+}
+
+func TestCategoryHandler_UpdateCategory_DecodeBody(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		expectedStatus  int
+		expectedDetail  string
+		expectedRenamed string
+	}{
+		{
+			name:            "正常系: 名前の変更",
+			body:            `{"name": "腕時計"}`,
+			expectedStatus:  http.StatusOK,
+			expectedRenamed: "腕時計",
+		},
+		{
+			name:           "異常系: 未知のフィールド",
+			body:           `{"name": "腕時計", "slug": "watches"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetail: `malformed request body: unknown field "slug"`,
+		},
+		{
+			name:           "異常系: JSONの後に余分なデータがある",
+			body:           `{"name": "腕時計"}[]`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetail: "malformed request body: request body must contain a single JSON value",
+		},
+		{
+			name:           "異常系: 最大サイズを超える",
+			body:           `{"name": "` + strings.Repeat("a", 1<<20) + `"}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedDetail: "request body too large: request body must be 1048576 bytes or less",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubCategoryUsecase{}
+			h := NewCategoryHandler(stub)
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/admin/categories/1", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			require.NoError(t, h.UpdateCategory(c))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedRenamed, stub.renamed)
+			openapitest.AssertExchange(t, openapitest.Document("", Operations()...), http.MethodPut, "/admin/categories/{id}", tt.body, rec)
+			if tt.expectedDetail != "" {
+				var body struct {
+					Detail string `json:"detail"`
+				}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tt.expectedDetail, body.Detail)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"net/http"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/openapi"
+	"Aicon-assignment/internal/usecase"
+)
+
+// カテゴリーのルートの操作
+func Operations() []openapi.Operation {
+	categories := openapi.ArrayOf(openapi.Of(entity.Category{}))
+	categoryID := openapi.PathID("id", "カテゴリーのID")
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/categories", ID: "listCategories", Summary: "有効なカテゴリーの一覧（スラッグ・日本語名・英語名）", Tags: []string{"categories"},
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("カテゴリーの一覧", categories)},
+		},
+		{
+			Method: http.MethodGet, Path: "/admin/categories", ID: "adminListCategories", Summary: "カテゴリー一覧（管理者用）", Tags: []string{"admin"},
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("カテゴリーの一覧", categories)},
+			Errors:    []int{http.StatusForbidden},
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/categories", ID: "createCategory", Summary: "カテゴリー登録（管理者用）", Tags: []string{"admin"},
+			RequestBody: openapi.JSONBody(openapi.Of(usecase.CreateCategoryInput{})),
+			Responses:   openapi.Responses{http.StatusCreated: openapi.JSON("登録したカテゴリー", openapi.Of(entity.Category{}))},
+			Errors:      []int{http.StatusBadRequest, http.StatusForbidden, http.StatusConflict, http.StatusUnprocessableEntity},
+		},
+		{
+			Method: http.MethodGet, Path: "/admin/categories/{id}", ID: "getCategory", Summary: "特定カテゴリー取得（管理者用）", Tags: []string{"admin"},
+			Parameters: []*openapi.Parameter{categoryID},
+			Responses:  openapi.Responses{http.StatusOK: openapi.JSON("カテゴリー", openapi.Of(entity.Category{}))},
+			Errors:     []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodPut, Path: "/admin/categories/{id}", ID: "updateCategory", Summary: "カテゴリー名の変更（管理者用）", Tags: []string{"admin"},
+			Parameters:  []*openapi.Parameter{categoryID},
+			RequestBody: openapi.JSONBody(openapi.Of(CategoryRequest{})),
+			Responses:   openapi.Responses{http.StatusOK: openapi.JSON("変更したカテゴリー", openapi.Of(entity.Category{}))},
+			Errors:      []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity},
+		},
+		{
+			Method: http.MethodDelete, Path: "/admin/categories/{id}", ID: "deleteCategory", Summary: "カテゴリー削除（管理者用）", Tags: []string{"admin"},
+			Parameters: []*openapi.Parameter{categoryID},
+			Responses: openapi.Responses{
+				http.StatusNoContent: openapi.NoContent("削除した"),
+				http.StatusConflict: openapi.ProblemResponse("カテゴリーのアイテムがあるため削除できない",
+					map[string]*openapi.Schema{"item_count": openapi.Integer().Describe("カテゴリーのアイテムの件数")}, openapi.Of(CategoryInUseResponse{})),
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound},
+		},
+	}
+}
@@ -0,0 +1,57 @@
+package httperror
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// echoのルーターとミドルウェアが返したエラーをproblem+jsonで返す。e.HTTPErrorHandlerに設定する。
+// ルートのないパスは404、パスはあるがメソッドを受け付けない場合は405とし、405はルーターが設定したAllowヘッダーとともに返す。
+// echo.HTTPError以外のエラー（ハンドラーを通らずに返したエラー）はRespondと同じくステータスコードに対応付ける
+func HandleError(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	var httpErr *echo.HTTPError
+	if !errors.As(err, &httpErr) {
+		err = Respond(c, err, "internal server error")
+	} else {
+		res := ErrorResponse{Error: strings.ToLower(http.StatusText(httpErr.Code)), Code: codeForStatus(httpErr.Code)}
+		if allow := c.Response().Header().Get(echo.HeaderAllow); httpErr.Code == http.StatusMethodNotAllowed && allow != "" {
+			res.Details = []string{"allowed methods: " + allow}
+		}
+		err = Write(c, NewProblem(httpErr.Code, res), res)
+	}
+	if err != nil {
+		slog.WarnContext(c.Request().Context(), "⚠️  エラーレスポンスを返せませんでした", "error", err)
+	}
+}
+
+// echo.HTTPErrorのステータスコードに対応するエラーコード
+func codeForStatus(status int) string {
+	switch status {
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusRequestEntityTooLarge:
+		return CodeRequestTooLarge
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
@@ -0,0 +1,101 @@
+package httperror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleError(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = HandleError
+	e.GET("/items/:id", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.PATCH("/items/:id", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/broken", func(c echo.Context) error { return errors.New("connection refused") })
+	e.GET("/large", func(c echo.Context) error { return echo.ErrStatusRequestEntityTooLarge })
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		wantStatus  int
+		wantProblem Problem
+		wantAllow   string
+	}{
+		{
+			name:       "正常系: ルートのないパスは404",
+			method:     http.MethodGet,
+			path:       "/unknown",
+			wantStatus: http.StatusNotFound,
+			wantProblem: Problem{
+				Type: "/problems/not_found", Title: "not found", Status: http.StatusNotFound,
+				Extensions: map[string]interface{}{"code": CodeNotFound},
+			},
+		},
+		{
+			name:       "正常系: パスが受け付けないメソッドはAllowヘッダーとともに405",
+			method:     http.MethodPost,
+			path:       "/items/1",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantProblem: Problem{
+				Type: "/problems/method_not_allowed", Title: "method not allowed", Status: http.StatusMethodNotAllowed,
+				Detail:     "allowed methods: OPTIONS, GET, PATCH",
+				Extensions: map[string]interface{}{"code": CodeMethodNotAllowed},
+			},
+			wantAllow: "OPTIONS, GET, PATCH",
+		},
+		{
+			name:       "正常系: ミドルウェアが返したechoのエラーはステータスコードに対応するコード",
+			method:     http.MethodGet,
+			path:       "/large",
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantProblem: Problem{
+				Type: "/problems/request_too_large", Title: "request entity too large", Status: http.StatusRequestEntityTooLarge,
+				Extensions: map[string]interface{}{"code": CodeRequestTooLarge},
+			},
+		},
+		{
+			name:       "異常系: 分類できないエラーは詳細を返さずに500",
+			method:     http.MethodGet,
+			path:       "/broken",
+			wantStatus: http.StatusInternalServerError,
+			wantProblem: Problem{
+				Type: "/problems/internal_error", Title: "internal server error", Status: http.StatusInternalServerError,
+				Extensions: map[string]interface{}{"code": CodeInternal},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, tt.wantAllow, rec.Header().Get(echo.HeaderAllow))
+			var problem Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, tt.wantProblem, problem)
+		})
+	}
+}
+
+func TestHandleError_Committed(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items", nil), rec)
+	require.NoError(t, c.String(http.StatusOK, "partial"))
+
+	// 送信済みのレスポンスには書き込まない
+	HandleError(echo.ErrNotFound, c)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "partial", rec.Body.String())
+}
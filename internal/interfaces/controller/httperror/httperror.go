@@ -0,0 +1,222 @@
+package httperror
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// エラーレスポンスの機械可読なコード
+const (
+	CodeBadRequest           = "bad_request"
+	CodeValidationFailed     = "validation_failed"
+	CodeNotFound             = "not_found"
+	CodeMethodNotAllowed     = "method_not_allowed"
+	CodeItemNotFound         = "item_not_found"
+	CodeImageNotFound        = "image_not_found"
+	CodeCategoryNotFound     = "category_not_found"
+	CodeBrandNotFound        = "brand_not_found"
+	CodeWebhookNotFound      = "webhook_not_found"
+	CodeImportJobNotFound    = "import_job_not_found"
+	CodeConflict             = "conflict"
+	CodeDuplicateEntry       = "duplicate_entry"
+	CodeDuplicateItem        = "duplicate_item"
+	CodeDuplicateSerial      = "duplicate_serial_number"
+	CodeDuplicateEmail       = "duplicate_email"
+	CodeCategoryInUse        = "category_in_use"
+	CodeImageLimitExceeded   = "image_limit_exceeded"
+	CodeVersionConflict      = "version_conflict"
+	CodeInvalidTransition    = "invalid_status_transition"
+	CodeFileTooLarge         = "file_too_large"
+	CodeRequestTooLarge      = "request_too_large"
+	CodeIdempotencyMismatch  = "idempotency_key_mismatch"
+	CodePreconditionFailed   = "precondition_failed"
+	CodePreconditionRequired = "precondition_required"
+	CodeImportQueueFull      = "import_queue_full"
+	CodeRateLimited          = "rate_limited"
+	CodeUnauthorized         = "unauthorized"
+	CodeForbidden            = "forbidden"
+	CodeTimeout              = "timeout"
+	CodeInternal             = "internal_error"
+)
+
+// クライアントが応答を待たずに切断したことを表すステータスコード（nginxの独自のコード）。アクセスログの記録にのみ使う
+const StatusClientClosedRequest = 499
+
+// 条件付きリクエストとリクエストボディのエラー。HTTPの層でのみ使う
+var (
+	ErrPreconditionFailed   = errors.New("precondition failed")
+	ErrPreconditionRequired = errors.New("precondition required")
+	ErrMalformedBody        = errors.New("malformed request body")
+	ErrBodyTooLarge         = errors.New("request body too large")
+)
+
+// エラーの内容。Problemに変換して返し、Acceptヘッダーで求められた場合は旧形式としてそのまま返す
+type ErrorResponse struct {
+	Error   string                        `json:"error"`
+	Code    string                        `json:"code"`
+	Details []string                      `json:"details,omitempty"`
+	Errors  domainErrors.ValidationErrors `json:"errors,omitempty"`
+
+	CurrentVersion int64 `json:"current_version,omitempty"`  // バージョンの競合時のみ、サーバー上の現在のバージョン
+	ExistingItemID int64 `json:"existing_item_id,omitempty"` // 重複登録時のみ、登録済みのアイテムのID
+
+	// 所有状況を変更できない場合のみ、現在と指定された所有状況
+	CurrentStatus   string `json:"current_status,omitempty"`
+	RequestedStatus string `json:"requested_status,omitempty"`
+
+	RequestID string `json:"request_id,omitempty"` // ログと照合するためのリクエストID
+}
+
+// ドメインのエラーとステータスコードの対応
+type mapping struct {
+	target  error
+	status  int
+	code    string
+	message string
+	// err.Error()をdetailsに含めるかどうか
+	withDetails bool
+}
+
+// 上から順に判定する。個別のエラーを先に、分類を後に並べる
+var mappings = []mapping{
+	{ErrPreconditionFailed, http.StatusPreconditionFailed, CodePreconditionFailed, "precondition failed", false},
+	{ErrPreconditionRequired, http.StatusPreconditionRequired, CodePreconditionRequired, "If-Match header is required", false},
+	{ErrMalformedBody, http.StatusBadRequest, CodeBadRequest, "invalid request format", true},
+	{ErrBodyTooLarge, http.StatusRequestEntityTooLarge, CodeRequestTooLarge, "request body is too large", true},
+	{domainErrors.ErrItemNotFound, http.StatusNotFound, CodeItemNotFound, "item not found", false},
+	{domainErrors.ErrImageNotFound, http.StatusNotFound, CodeImageNotFound, "image not found", false},
+	{domainErrors.ErrCategoryNotFound, http.StatusNotFound, CodeCategoryNotFound, "category not found", false},
+	{domainErrors.ErrBrandNotFound, http.StatusNotFound, CodeBrandNotFound, "brand not found", false},
+	{domainErrors.ErrWebhookNotFound, http.StatusNotFound, CodeWebhookNotFound, "webhook not found", false},
+	{domainErrors.ErrImportJobNotFound, http.StatusNotFound, CodeImportJobNotFound, "import job not found", false},
+	{domainErrors.ErrNotFound, http.StatusNotFound, CodeNotFound, "resource not found", false},
+	{domainErrors.ErrDuplicateSerialNumber, http.StatusConflict, CodeDuplicateSerial, "serial number is already registered", false},
+	{domainErrors.ErrDuplicateEmail, http.StatusConflict, CodeDuplicateEmail, "email is already registered", false},
+	{domainErrors.ErrDuplicateItem, http.StatusConflict, CodeDuplicateItem, "item already exists", true},
+	{domainErrors.ErrCategoryInUse, http.StatusConflict, CodeCategoryInUse, "category is in use", true},
+	{domainErrors.ErrImageLimitExceeded, http.StatusConflict, CodeImageLimitExceeded, "image limit exceeded", true},
+	{domainErrors.ErrVersionConflict, http.StatusConflict, CodeVersionConflict, "item has been modified by another request", false},
+	{domainErrors.ErrInvalidStatusTransition, http.StatusConflict, CodeInvalidTransition, "status cannot be changed", true},
+	{domainErrors.ErrDuplicateEntry, http.StatusConflict, CodeDuplicateEntry, "resource already exists", true},
+	{domainErrors.ErrConflict, http.StatusConflict, CodeConflict, "conflict", true},
+	{domainErrors.ErrFileTooLarge, http.StatusRequestEntityTooLarge, CodeFileTooLarge, "file is too large", true},
+	{domainErrors.ErrIdempotencyKeyMismatch, http.StatusUnprocessableEntity, CodeIdempotencyMismatch, "Idempotency-Key has already been used with a different request", false},
+	{domainErrors.ErrInvalidCursor, http.StatusBadRequest, CodeBadRequest, "invalid cursor", true},
+	{domainErrors.ErrInvalidQuery, http.StatusBadRequest, CodeBadRequest, "validation failed", true},
+	{domainErrors.ErrValidation, http.StatusUnprocessableEntity, CodeValidationFailed, "validation failed", true},
+	{domainErrors.ErrImportQueueFull, http.StatusServiceUnavailable, CodeImportQueueFull, "too many imports are in progress, try again later", false},
+	{domainErrors.ErrUnauthenticated, http.StatusUnauthorized, CodeUnauthorized, "invalid credentials", false},
+	{domainErrors.ErrForbidden, http.StatusForbidden, CodeForbidden, "admin role is required", false},
+	{context.DeadlineExceeded, http.StatusGatewayTimeout, CodeTimeout, "request timed out", false},
+}
+
+// エラーをステータスコードとレスポンスに変換する。
+// 分類できないエラーは500とし、内部の詳細は返さずmessageを使う
+func From(err error, message string) (int, ErrorResponse) {
+	for _, m := range mappings {
+		if !errors.Is(err, m.target) {
+			continue
+		}
+
+		res := ErrorResponse{Error: m.message, Code: m.code}
+		var validationErrors domainErrors.ValidationErrors
+		var queryErr *domainErrors.InvalidQueryError
+		if errors.As(err, &validationErrors) {
+			res.Errors = validationErrors
+		} else if errors.As(err, &queryErr) {
+			// ラップしたエラーのメッセージは含めず、指定の誤りのみを返す
+			res.Details = []string{queryErr.Message}
+		} else if m.withDetails {
+			res.Details = []string{err.Error()}
+		}
+		var conflictErr *domainErrors.VersionConflictError
+		if errors.As(err, &conflictErr) {
+			res.CurrentVersion = conflictErr.CurrentVersion
+		}
+		var duplicateErr *domainErrors.DuplicateItemError
+		if errors.As(err, &duplicateErr) {
+			res.ExistingItemID = duplicateErr.ExistingID
+		}
+		var transitionErr *domainErrors.StatusTransitionError
+		if errors.As(err, &transitionErr) {
+			res.CurrentStatus = transitionErr.CurrentStatus
+			res.RequestedStatus = transitionErr.RequestedStatus
+		}
+		return m.status, res
+	}
+
+	return http.StatusInternalServerError, ErrorResponse{Error: message, Code: CodeInternal}
+}
+
+// エラーをステータスコードに対応付けてproblem+jsonで返す。
+// バリデーションエラーのメッセージはAccept-Languageヘッダーの言語で返す。
+// クライアントの切断で処理を中断した場合は、レスポンスを受け取る相手がいないためログのみを残す
+func Respond(c echo.Context, err error, message string) error {
+	if errors.Is(err, context.Canceled) {
+		slog.WarnContext(c.Request().Context(), "⚠️  クライアントが切断したため処理を中断しました", "method", c.Request().Method, "path", c.Request().URL.Path)
+		return c.NoContent(StatusClientClosedRequest)
+	}
+
+	status, res := From(err, message)
+	logError(c, status, err)
+	res.Errors = LocalizeFor(c, res.Errors)
+	return Write(c, NewProblem(status, res), res)
+}
+
+// 予期しないエラー（5xx）はラップされた原因をたどれるようerrorで、入力値の検証の失敗はinfoでログに出す
+func logError(c echo.Context, status int, err error) {
+	ctx := c.Request().Context()
+	switch {
+	case status >= http.StatusInternalServerError:
+		slog.ErrorContext(ctx, "リクエストの処理に失敗しました", "status", status, "error", err, "causes", causes(err))
+	case errors.Is(err, domainErrors.ErrValidation):
+		slog.InfoContext(ctx, "入力値の検証に失敗しました", "status", status, "error", err)
+	}
+}
+
+// errから順にラップされたエラーのメッセージ。複数のエラーをまとめたエラーはすべての原因をたどる
+func causes(err error) []string {
+	var chain []string
+	for err != nil {
+		chain = append(chain, err.Error())
+		switch wrapped := err.(type) {
+		case interface{ Unwrap() error }:
+			err = wrapped.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range wrapped.Unwrap() {
+				chain = append(chain, causes(e)...)
+			}
+			return chain
+		default:
+			return chain
+		}
+	}
+	return chain
+}
+
+// リクエストのAccept-Languageヘッダーの言語でバリデーションエラーのメッセージを置き換え、Content-Languageヘッダーを設定する
+func LocalizeFor(c echo.Context, errs domainErrors.ValidationErrors) domainErrors.ValidationErrors {
+	if len(errs) == 0 {
+		return errs
+	}
+	lang := Language(c.Request().Header.Get("Accept-Language"))
+	c.Response().Header().Set("Content-Language", lang)
+	return Localize(errs, lang)
+}
+
+// リクエストの形式の誤りを400で返す
+func BadRequest(c echo.Context, message string, details ...string) error {
+	slog.InfoContext(c.Request().Context(), "リクエストの形式が正しくありません", "error", message, "details", details)
+	res := ErrorResponse{
+		Error:   message,
+		Code:    CodeBadRequest,
+		Details: details,
+	}
+	return Write(c, NewProblem(http.StatusBadRequest, res), res)
+}
@@ -0,0 +1,342 @@
+package httperror
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantMessage string
+	}{
+		{"正常系: アイテムが見つからない場合は404", domainErrors.ErrItemNotFound, http.StatusNotFound, CodeItemNotFound, "item not found"},
+		{"正常系: 画像が見つからない場合は404", domainErrors.ErrImageNotFound, http.StatusNotFound, CodeImageNotFound, "image not found"},
+		{"正常系: カテゴリーが見つからない場合は404", domainErrors.ErrCategoryNotFound, http.StatusNotFound, CodeCategoryNotFound, "category not found"},
+		{"正常系: ブランドが見つからない場合は404", domainErrors.ErrBrandNotFound, http.StatusNotFound, CodeBrandNotFound, "brand not found"},
+		{"正常系: Webhookが見つからない場合は404", domainErrors.ErrWebhookNotFound, http.StatusNotFound, CodeWebhookNotFound, "webhook not found"},
+		{"正常系: アイテムの重複は409", domainErrors.ErrDuplicateItem, http.StatusConflict, CodeDuplicateItem, "item already exists"},
+		{"正常系: シリアル番号の重複は409", domainErrors.ErrDuplicateSerialNumber, http.StatusConflict, CodeDuplicateSerial, "serial number is already registered"},
+		{"正常系: メールアドレスの重複は409", domainErrors.ErrDuplicateEmail, http.StatusConflict, CodeDuplicateEmail, "email is already registered"},
+		{"正常系: 認証の失敗は401", domainErrors.ErrUnauthenticated, http.StatusUnauthorized, CodeUnauthorized, "invalid credentials"},
+		{"正常系: 権限の不足は403", domainErrors.ErrForbidden, http.StatusForbidden, CodeForbidden, "admin role is required"},
+		{"正常系: 重複は409", domainErrors.ErrDuplicateEntry, http.StatusConflict, CodeDuplicateEntry, "resource already exists"},
+		{"正常系: 使用中のカテゴリーは409", domainErrors.ErrCategoryInUse, http.StatusConflict, CodeCategoryInUse, "category is in use"},
+		{"正常系: 画像の上限は409", domainErrors.ErrImageLimitExceeded, http.StatusConflict, CodeImageLimitExceeded, "image limit exceeded"},
+		{"正常系: 分類のみの競合は409", domainErrors.ErrConflict, http.StatusConflict, CodeConflict, "conflict"},
+		{"正常系: ファイルサイズの超過は413", domainErrors.ErrFileTooLarge, http.StatusRequestEntityTooLarge, CodeFileTooLarge, "file is too large"},
+		{"正常系: 入力値の誤りは422", domainErrors.ErrInvalidInput, http.StatusUnprocessableEntity, CodeValidationFailed, "validation failed"},
+		{"正常系: 不正なカーソルは400", domainErrors.ErrInvalidCursor, http.StatusBadRequest, CodeBadRequest, "invalid cursor"},
+		{"正常系: クエリパラメータの誤りは400", domainErrors.NewInvalidQueryError("sort must be one of: created_at"), http.StatusBadRequest, CodeBadRequest, "validation failed"},
+		{"正常系: データベースの制限時間の超過は504", fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, context.DeadlineExceeded), http.StatusGatewayTimeout, CodeTimeout, "request timed out"},
+		{"正常系: データベースのエラーは500", domainErrors.ErrDatabaseError, http.StatusInternalServerError, CodeInternal, "failed to do something"},
+		{"正常系: 分類できないエラーは500", fmt.Errorf("unexpected"), http.StatusInternalServerError, CodeInternal, "failed to do something"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("usecase: %w", tt.err)
+
+			status, res := From(err, "failed to do something")
+
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantCode, res.Code)
+			assert.Equal(t, tt.wantMessage, res.Error)
+		})
+	}
+}
+
+func TestFrom_Details(t *testing.T) {
+	t.Run("正常系: 404では内部のメッセージを返さない", func(t *testing.T) {
+		_, res := From(fmt.Errorf("failed to get item: %w", domainErrors.ErrItemNotFound), "failed")
+		assert.Empty(t, res.Details)
+	})
+
+	t.Run("正常系: 500では内部のメッセージを返さない", func(t *testing.T) {
+		_, res := From(fmt.Errorf("%w: connection refused", domainErrors.ErrDatabaseError), "failed")
+		assert.Empty(t, res.Details)
+	})
+
+	t.Run("正常系: 409ではエラーの内容をdetailsに含める", func(t *testing.T) {
+		_, res := From(fmt.Errorf("%w: category 時計 already exists", domainErrors.ErrDuplicateEntry), "failed")
+		assert.Equal(t, []string{"duplicate entry: category 時計 already exists"}, res.Details)
+	})
+
+	t.Run("正常系: バージョンの競合では現在のバージョンを返す", func(t *testing.T) {
+		status, res := From(fmt.Errorf("failed to update item: %w", domainErrors.NewVersionConflictError(4)), "failed")
+
+		assert.Equal(t, http.StatusConflict, status)
+		assert.Equal(t, CodeVersionConflict, res.Code)
+		assert.Equal(t, int64(4), res.CurrentVersion)
+		assert.Empty(t, res.Details)
+	})
+
+	t.Run("正常系: 重複登録では既存のアイテムのIDを返す", func(t *testing.T) {
+		status, res := From(domainErrors.NewDuplicateItemError(5), "failed")
+
+		assert.Equal(t, http.StatusConflict, status)
+		assert.Equal(t, CodeDuplicateItem, res.Code)
+		assert.Equal(t, int64(5), res.ExistingItemID)
+		assert.Equal(t, []string{"duplicate item: item 5 has the same name, brand and purchase_date"}, res.Details)
+	})
+
+	t.Run("正常系: 所有状況を変更できない場合は現在と指定された所有状況を返す", func(t *testing.T) {
+		status, res := From(domainErrors.NewStatusTransitionError("sold", "listed"), "failed")
+
+		assert.Equal(t, http.StatusConflict, status)
+		assert.Equal(t, CodeInvalidTransition, res.Code)
+		assert.Equal(t, "sold", res.CurrentStatus)
+		assert.Equal(t, "listed", res.RequestedStatus)
+		assert.Equal(t, []string{"invalid status transition: cannot change status from sold to listed"}, res.Details)
+	})
+
+	t.Run("正常系: クエリパラメータの誤りは分類のメッセージを付けずにdetailsに含める", func(t *testing.T) {
+		status, res := From(fmt.Errorf("failed to retrieve items: %w", domainErrors.NewInvalidQueryError("q must be 100 characters or less")), "failed")
+
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, CodeBadRequest, res.Code)
+		assert.Equal(t, []string{"q must be 100 characters or less"}, res.Details)
+	})
+
+	t.Run("正常系: フィールドのエラーはerrorsに含める", func(t *testing.T) {
+		var errs domainErrors.ValidationErrors
+		errs.Add("name", "name is required")
+		errs.Add("purchase_price", "purchase_price must be 0 or greater")
+
+		status, res := From(fmt.Errorf("failed to create item: %w", errs), "failed")
+
+		assert.Equal(t, http.StatusUnprocessableEntity, status)
+		assert.Equal(t, errs, res.Errors)
+		assert.Empty(t, res.Details)
+	})
+}
+
+func TestRespond(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items/1", nil), rec)
+
+	require.NoError(t, Respond(c, domainErrors.ErrItemNotFound, "failed to retrieve item"))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, map[string]interface{}{
+		"type":       "/problems/item_not_found",
+		"title":      "item not found",
+		"status":     float64(http.StatusNotFound),
+		"extensions": map[string]interface{}{"code": "item_not_found"},
+	}, body)
+}
+
+func TestRespond_Canceled(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items", nil), rec)
+
+	err := fmt.Errorf("failed to retrieve items: %w", fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, context.Canceled))
+	require.NoError(t, Respond(c, err, "failed to retrieve items"))
+
+	assert.Equal(t, StatusClientClosedRequest, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
+
+func TestRespond_Log(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantLevel   string
+		wantCauses  []interface{}
+		wantNoEntry bool
+	}{
+		{
+			name:       "正常系: 予期しないエラーは原因をたどってerrorで出力する",
+			err:        fmt.Errorf("failed to retrieve item: %w", fmt.Errorf("query: %w", errors.New("connection refused"))),
+			wantLevel:  "ERROR",
+			wantCauses: []interface{}{"failed to retrieve item: query: connection refused", "query: connection refused", "connection refused"},
+		},
+		{
+			name:       "正常系: 複数のエラーをまとめたエラーはすべての原因をたどる",
+			err:        errors.Join(errors.New("a"), fmt.Errorf("b: %w", errors.New("c"))),
+			wantLevel:  "ERROR",
+			wantCauses: []interface{}{"a\nb: c", "a", "b: c", "c"},
+		},
+		{
+			name:      "正常系: 入力値の検証の失敗はinfoで出力する",
+			err:       domainErrors.NewValidationErrors(domainErrors.NewRuleError("name", domainErrors.CodeRequired, "name is required", nil)),
+			wantLevel: "INFO",
+		},
+		{
+			name:        "正常系: その他のクライアントのエラーは出力しない",
+			err:         domainErrors.ErrItemNotFound,
+			wantNoEntry: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			previous := slog.Default()
+			slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+			t.Cleanup(func() { slog.SetDefault(previous) })
+
+			e := echo.New()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items/1", nil), httptest.NewRecorder())
+			require.NoError(t, Respond(c, tt.err, "failed to retrieve item"))
+
+			if tt.wantNoEntry {
+				assert.Empty(t, buf.String())
+				return
+			}
+			var entry map[string]interface{}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, tt.wantLevel, entry["level"])
+			if tt.wantCauses != nil {
+				assert.Equal(t, tt.wantCauses, entry["causes"])
+			}
+		})
+	}
+}
+
+func TestRespond_Problem(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatus     int
+		wantDetail     string
+		wantExtensions map[string]interface{}
+	}{
+		{
+			name:       "正常系: 重複登録では既存のアイテムのIDをextensionsに含める",
+			err:        domainErrors.NewDuplicateItemError(5),
+			wantStatus: http.StatusConflict,
+			wantDetail: "duplicate item: item 5 has the same name, brand and purchase_date",
+			wantExtensions: map[string]interface{}{
+				"code":             "duplicate_item",
+				"existing_item_id": float64(5),
+			},
+		},
+		{
+			name:       "正常系: バリデーションエラーではフィールドのエラーをextensionsに含める",
+			err:        domainErrors.NewValidationErrors(domainErrors.Required("name")),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantExtensions: map[string]interface{}{
+				"code": "validation_failed",
+				"errors": []interface{}{
+					map[string]interface{}{"field": "name", "code": "required", "message": "名前は必須です"},
+				},
+			},
+		},
+		{
+			name:       "正常系: バージョンの競合では現在のバージョンをextensionsに含める",
+			err:        domainErrors.NewVersionConflictError(4),
+			wantStatus: http.StatusConflict,
+			wantExtensions: map[string]interface{}{
+				"code":            "version_conflict",
+				"current_version": float64(4),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/items", nil), rec)
+
+			require.NoError(t, Respond(c, tt.err, "failed"))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, float64(tt.wantStatus), body["status"])
+			if tt.wantDetail != "" {
+				assert.Equal(t, tt.wantDetail, body["detail"])
+			} else {
+				assert.NotContains(t, body, "detail")
+			}
+			assert.Equal(t, tt.wantExtensions, body["extensions"])
+		})
+	}
+}
+
+func TestRespond_Negotiation(t *testing.T) {
+	tests := []struct {
+		name       string
+		accept     string
+		wantLegacy bool
+	}{
+		{name: "正常系: Acceptがない場合はproblem+json", accept: "", wantLegacy: false},
+		{name: "正常系: ワイルドカードはproblem+json", accept: "*/*", wantLegacy: false},
+		{name: "正常系: problem+jsonを指定", accept: "application/problem+json", wantLegacy: false},
+		{name: "正常系: 両方を指定した場合はproblem+json", accept: "application/json, application/problem+json", wantLegacy: false},
+		{name: "正常系: application/jsonのみは旧形式", accept: "application/json", wantLegacy: true},
+		{name: "正常系: ほかの形式と一緒に指定した場合も旧形式", accept: "text/plain, application/json;q=0.9, */*;q=0.1", wantLegacy: true},
+		{name: "正常系: 優先度が0のapplication/jsonは無視する", accept: "application/json;q=0", wantLegacy: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+			if tt.accept != "" {
+				req.Header.Set(echo.HeaderAccept, tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			require.NoError(t, Respond(c, domainErrors.ErrItemNotFound, "failed to retrieve item"))
+
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			if tt.wantLegacy {
+				assert.Equal(t, echo.MIMEApplicationJSONCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, "true", rec.Header().Get("Deprecation"))
+				assert.Equal(t, map[string]interface{}{"error": "item not found", "code": "item_not_found"}, body)
+			} else {
+				assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+				assert.Empty(t, rec.Header().Get("Deprecation"))
+				assert.Equal(t, "item not found", body["title"])
+			}
+		})
+	}
+}
+
+func TestBadRequest(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items?limit=x", nil), rec)
+
+	require.NoError(t, BadRequest(c, "validation failed", "limit must be an integer"))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	var res Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, Problem{
+		Type:       "/problems/bad_request",
+		Title:      "validation failed",
+		Status:     http.StatusBadRequest,
+		Detail:     "limit must be an integer",
+		Extensions: map[string]interface{}{"code": CodeBadRequest},
+	}, res)
+}
@@ -0,0 +1,170 @@
+package httperror
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// バリデーションエラーのメッセージの言語
+const (
+	LanguageJa      = "ja"
+	LanguageEn      = "en"
+	DefaultLanguage = LanguageJa
+)
+
+// 言語ごとのメッセージのカタログ。キーは「フィールド.コード」か、フィールドによらない「コード」で、
+// {field}はフィールドの表示名、{max}などはFieldError.Paramsの値に置き換える
+var messageCatalogs = map[string]map[string]string{
+	LanguageJa: {
+		domainErrors.CodeRequired:           "{field}は必須です",
+		domainErrors.CodeEmpty:              "{field}を空にすることはできません",
+		domainErrors.CodeTooLong:            "{field}は{max}文字以内で入力してください",
+		domainErrors.CodeTooShort:           "{field}は{min}文字以上で入力してください",
+		domainErrors.CodeTooSmall:           "{field}は{min}以上で入力してください",
+		domainErrors.CodeTooLarge:           "{field}は{max}以下で入力してください",
+		domainErrors.CodeTooMany:            "{field}は{max}個まで指定できます",
+		domainErrors.CodeOneOf:              "{field}は次のいずれかを指定してください: {values}",
+		domainErrors.CodeInvalidFormat:      "{field}は{format}形式で入力してください",
+		domainErrors.CodeInvalidType:        "{field}の値の型が正しくありません",
+		domainErrors.CodeInvalidDate:        "{field}の{value}は存在しない日付です",
+		domainErrors.CodeFutureDate:         "{field}に未来の日付は指定できません",
+		domainErrors.CodeBeforePurchaseDate: "{field}には購入日以降の日付を指定してください",
+		domainErrors.CodeNotRegistered:      "{field}には登録済みの値を指定してください",
+		"aliases.too_long":                  "別名はそれぞれ{max}文字以内で入力してください",
+		"tags.too_long":                     "タグはそれぞれ{max}文字以内で入力してください",
+		"brand.not_registered":              "ブランドには登録済みのブランド名か別名を指定してください",
+		"purchase_price.invalid_type":       "購入価格は整数か数字の文字列で指定してください",
+		"url.invalid_format":                "URLにはhttpかhttpsの絶対URLを指定してください",
+		"email.invalid_format":              "メールアドレスの形式が正しくありません",
+		"password.too_long":                 "パスワードは{max}バイト以内で入力してください",
+	},
+	LanguageEn: {
+		domainErrors.CodeRequired:           "{field} is required",
+		domainErrors.CodeEmpty:              "{field} cannot be empty",
+		domainErrors.CodeTooLong:            "{field} must be {max} characters or less",
+		domainErrors.CodeTooShort:           "{field} must be at least {min} characters",
+		domainErrors.CodeTooSmall:           "{field} must be {min} or greater",
+		domainErrors.CodeTooLarge:           "{field} must be {max} or less",
+		domainErrors.CodeTooMany:            "{field} can have at most {max} values",
+		domainErrors.CodeOneOf:              "{field} must be one of: {values}",
+		domainErrors.CodeInvalidFormat:      "{field} must be in {format} format",
+		domainErrors.CodeInvalidType:        "{field} must be of type {type}",
+		domainErrors.CodeInvalidDate:        "{field} {value} is not a valid calendar date",
+		domainErrors.CodeFutureDate:         "{field} must not be in the future",
+		domainErrors.CodeBeforePurchaseDate: "{field} must be on or after purchase_date",
+		domainErrors.CodeNotRegistered:      "{field} must be a registered value",
+		"aliases.too_many":                  "a brand can have at most {max} aliases",
+		"aliases.too_long":                  "each alias must be {max} characters or less",
+		"tags.too_many":                     "an item can have at most {max} tags",
+		"tags.too_long":                     "each tag must be {max} characters or less",
+		"brand.not_registered":              "brand must be a registered brand name or alias",
+		"category.not_registered":           "category must be one of the registered categories",
+		"purchase_price.invalid_type":       "purchase_price must be an integer or a numeric string",
+		"url.invalid_format":                "url must be an absolute http or https URL",
+		"email.invalid_format":              "email must be a valid email address",
+		"password.too_long":                 "password must be {max} bytes or less",
+	},
+}
+
+// メッセージに埋め込むフィールドの表示名。登録がない言語・フィールドはフィールド名をそのまま使う
+var fieldLabels = map[string]map[string]string{
+	LanguageJa: {
+		"name":              "名前",
+		"category":          "カテゴリー",
+		"brand":             "ブランド",
+		"purchase_price":    "購入価格",
+		"currency":          "通貨",
+		"purchase_date":     "購入日",
+		"serial_number":     "シリアル番号",
+		"condition":         "状態",
+		"notes":             "メモ",
+		"purchase_location": "購入店舗",
+		"tags":              "タグ",
+		"aliases":           "別名",
+		"status":            "所有状況",
+		"selling_price":     "売却価格",
+		"sold_date":         "売却日",
+		"version":           "バージョン",
+		"url":               "URL",
+		"secret":            "シークレット",
+		"email":             "メールアドレス",
+		"password":          "パスワード",
+	},
+}
+
+// Accept-Languageヘッダーから、カタログのある言語のうち最も優先度の高いものを選ぶ。
+// 対応する言語がない場合はDefaultLanguageを返す
+func Language(acceptLanguage string) string {
+	best, bestQuality := "", 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			q, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			quality = q
+		}
+
+		// ja-JPやen-USは言語の部分のみで判定する
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if _, ok := messageCatalogs[primary]; ok && quality > bestQuality {
+			best, bestQuality = primary, quality
+		}
+	}
+
+	if best == "" {
+		return DefaultLanguage
+	}
+	return best
+}
+
+// バリデーションエラーのメッセージを指定した言語に置き換えた一覧を返す。
+// カタログにないコードのエラーはドメインのメッセージのままとする
+func Localize(errs domainErrors.ValidationErrors, lang string) domainErrors.ValidationErrors {
+	if errs == nil {
+		return nil
+	}
+
+	localized := make(domainErrors.ValidationErrors, len(errs))
+	for i, fieldErr := range errs {
+		localized[i] = fieldErr
+		if message, ok := localizeMessage(fieldErr, lang); ok {
+			localized[i].Message = message
+		}
+	}
+	return localized
+}
+
+func localizeMessage(fieldErr domainErrors.FieldError, lang string) (string, bool) {
+	catalog := messageCatalogs[lang]
+	template, ok := catalog[fieldErr.Field+"."+fieldErr.Code]
+	if !ok {
+		template, ok = catalog[fieldErr.Code]
+	}
+	if !ok {
+		return "", false
+	}
+
+	label := fieldErr.Field
+	if l, ok := fieldLabels[lang][fieldErr.Field]; ok {
+		label = l
+	}
+
+	replacements := []string{"{field}", label}
+	for key, value := range fieldErr.Params {
+		replacements = append(replacements, "{"+key+"}", formatParam(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(template), true
+}
+
+func formatParam(value interface{}) string {
+	if values, ok := value.([]string); ok {
+		return strings.Join(values, ", ")
+	}
+	return fmt.Sprint(value)
+}
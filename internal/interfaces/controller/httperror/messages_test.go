@@ -0,0 +1,131 @@
+package httperror
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{name: "正常系: 未指定の場合は日本語", acceptLanguage: "", expected: LanguageJa},
+		{name: "正常系: 英語", acceptLanguage: "en", expected: LanguageEn},
+		{name: "正常系: 地域付きの言語", acceptLanguage: "en-US,en;q=0.9", expected: LanguageEn},
+		{name: "正常系: 優先度の高い言語を選ぶ", acceptLanguage: "ja;q=0.5, en;q=0.8", expected: LanguageEn},
+		{name: "正常系: 対応していない言語は飛ばす", acceptLanguage: "fr-FR, en;q=0.7", expected: LanguageEn},
+		{name: "正常系: 対応していない言語のみの場合は日本語", acceptLanguage: "fr-FR,de;q=0.9", expected: LanguageJa},
+		{name: "正常系: ワイルドカードは日本語", acceptLanguage: "*", expected: LanguageJa},
+		{name: "正常系: 不正な優先度は無視する", acceptLanguage: "en;q=abc", expected: LanguageJa},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Language(tt.acceptLanguage))
+		})
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	errs := domainErrors.NewValidationErrors(
+		domainErrors.Required("name"),
+		domainErrors.TooLong("notes", 2000),
+		domainErrors.OneOf("currency", []string{"JPY", "USD"}),
+		domainErrors.NewRuleError("tags", domainErrors.CodeTooLong, "each tag must be 30 characters or less", map[string]interface{}{"max": 30}),
+		domainErrors.NewFieldError("sold_date", "sold_date is invalid")[0],
+	)
+
+	tests := []struct {
+		name     string
+		lang     string
+		expected []string
+	}{
+		{
+			name: "正常系: 日本語",
+			lang: LanguageJa,
+			expected: []string{
+				"名前は必須です",
+				"メモは2000文字以内で入力してください",
+				"通貨は次のいずれかを指定してください: JPY, USD",
+				"タグはそれぞれ30文字以内で入力してください",
+				"sold_date is invalid",
+			},
+		},
+		{
+			name: "正常系: 英語",
+			lang: LanguageEn,
+			expected: []string{
+				"name is required",
+				"notes must be 2000 characters or less",
+				"currency must be one of: JPY, USD",
+				"each tag must be 30 characters or less",
+				"sold_date is invalid",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localized := Localize(errs, tt.lang)
+
+			require.Len(t, localized, len(tt.expected))
+			for i, message := range tt.expected {
+				assert.Equal(t, message, localized[i].Message)
+				assert.Equal(t, errs[i].Code, localized[i].Code)
+				assert.Equal(t, errs[i].Field, localized[i].Field)
+			}
+			// 元のエラーのメッセージは変更しない
+			assert.Equal(t, "name is required", errs[0].Message)
+		})
+	}
+}
+
+func TestRespond_LocalizedValidationErrors(t *testing.T) {
+	tests := []struct {
+		name            string
+		acceptLanguage  string
+		expectedLang    string
+		expectedMessage string
+	}{
+		{name: "正常系: 未指定の場合は日本語", expectedLang: LanguageJa, expectedMessage: "購入日に未来の日付は指定できません"},
+		{name: "正常系: 英語", acceptLanguage: "en-GB", expectedLang: LanguageEn, expectedMessage: "purchase_date must not be in the future"},
+		{name: "正常系: 対応していない言語は日本語にフォールバック", acceptLanguage: "fr", expectedLang: LanguageJa, expectedMessage: "購入日に未来の日付は指定できません"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/items", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			err := domainErrors.NewValidationErrors(domainErrors.NewRuleError("purchase_date", domainErrors.CodeFutureDate, "purchase_date must not be in the future", nil))
+
+			require.NoError(t, Respond(c, err, "validation failed"))
+
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			assert.Equal(t, tt.expectedLang, rec.Header().Get("Content-Language"))
+			var body struct {
+				Extensions struct {
+					Errors domainErrors.ValidationErrors `json:"errors"`
+				} `json:"extensions"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			require.Len(t, body.Extensions.Errors, 1)
+			assert.Equal(t, domainErrors.CodeFutureDate, body.Extensions.Errors[0].Code)
+			assert.Equal(t, tt.expectedMessage, body.Extensions.Errors[0].Message)
+		})
+	}
+}
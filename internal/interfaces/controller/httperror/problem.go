@@ -0,0 +1,99 @@
+package httperror
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/requestid"
+)
+
+// RFC 7807のエラーレスポンスのメディアタイプ
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// 問題の種類を表すURIの接頭辞。続けてエラーコードを付ける
+const ProblemTypeBase = "/problems/"
+
+// RFC 7807のエラーレスポンス。エラーコードや項目ごとのエラーなど、エラーごとの情報はExtensionsに含める
+type Problem struct {
+	Type       string                 `json:"type"`
+	Title      string                 `json:"title"`
+	Status     int                    `json:"status"`
+	Detail     string                 `json:"detail,omitempty"`
+	Extensions map[string]interface{} `json:"extensions"`
+}
+
+// ErrorResponseをProblemに変換する。detailsは1つの文字列にまとめ、それ以外の項目はExtensionsに含める
+func NewProblem(status int, res ErrorResponse) *Problem {
+	problem := &Problem{
+		Type:       ProblemTypeBase + res.Code,
+		Title:      res.Error,
+		Status:     status,
+		Detail:     strings.Join(res.Details, "; "),
+		Extensions: map[string]interface{}{"code": res.Code},
+	}
+	if len(res.Errors) > 0 {
+		problem.Extensions["errors"] = res.Errors
+	}
+	if res.CurrentVersion != 0 {
+		problem.Extensions["current_version"] = res.CurrentVersion
+	}
+	if res.ExistingItemID != 0 {
+		problem.Extensions["existing_item_id"] = res.ExistingItemID
+	}
+	if res.CurrentStatus != "" {
+		problem.Extensions["current_status"] = res.CurrentStatus
+		problem.Extensions["requested_status"] = res.RequestedStatus
+	}
+	return problem
+}
+
+// エラーレスポンスを返す。全エンドポイントのエラーはこの関数で返す。
+// Acceptヘッダーで旧形式（application/json）が求められた場合はlegacyを返し、それ以外はproblemをapplication/problem+jsonで返す。
+// ログと照合できるよう、リクエストIDをproblemのExtensionsと、ErrorResponseの旧形式に含める
+func Write(c echo.Context, problem *Problem, legacy interface{}) error {
+	if id := requestid.From(c.Request().Context()); id != "" {
+		problem.Extensions["request_id"] = id
+		if res, ok := legacy.(ErrorResponse); ok {
+			res.RequestID = id
+			legacy = res
+		}
+	}
+
+	header := c.Response().Header()
+	if prefersLegacy(c.Request().Header.Get(echo.HeaderAccept)) {
+		// 旧形式は移行期間のみ提供する
+		header.Set("Deprecation", "true")
+		header.Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		return c.JSON(problem.Status, legacy)
+	}
+
+	header.Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+	return c.JSON(problem.Status, problem)
+}
+
+// Acceptヘッダーにapplication/jsonがあり、application/problem+jsonがない場合に旧形式を返す
+func prefersLegacy(accept string) bool {
+	var wantsJSON, wantsProblem bool
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if q, ok := params["q"]; ok {
+			if weight, err := strconv.ParseFloat(q, 64); err != nil || weight <= 0 {
+				continue
+			}
+		}
+
+		switch mediaType {
+		case echo.MIMEApplicationJSON:
+			wantsJSON = true
+		case MIMEApplicationProblemJSON:
+			wantsProblem = true
+		}
+	}
+	return wantsJSON && !wantsProblem
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/interfaces/controller/request"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ImportJobHandler struct {
+	importJobUsecase usecase.ImportJobUsecase
+}
+
+func NewImportJobHandler(importJobUsecase usecase.ImportJobUsecase) *ImportJobHandler {
+	return &ImportJobHandler{
+		importJobUsecase: importJobUsecase,
+	}
+}
+
+// POST /items/import
+// multipart/form-data の file フィールドで受け取ったCSVからアイテムを一括登録するジョブを登録し、202を返す。
+// 結果は GET /imports/{job_id} で取得する
+func (h *ImportJobHandler) ImportItems(c echo.Context) error {
+	var opts usecase.ImportOptions
+	if bestEffortStr := c.QueryParam("best_effort"); bestEffortStr != "" {
+		bestEffort, err := strconv.ParseBool(bestEffortStr)
+		if err != nil {
+			return httperror.BadRequest(c, "validation failed", "best_effort must be true or false")
+		}
+		opts.BestEffort = bestEffort
+	}
+	if dryRunStr := c.QueryParam("dry_run"); dryRunStr != "" {
+		dryRun, err := strconv.ParseBool(dryRunStr)
+		if err != nil {
+			return httperror.BadRequest(c, "validation failed", "dry_run must be true or false")
+		}
+		opts.DryRun = dryRun
+	}
+
+	// ファイルはメモリに読み込まず、リクエストボディから一時ファイルに書き出す
+	file, err := request.MultipartFile(c, "file")
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrFileTooLarge) {
+			return httperror.Respond(c, err, "failed to start import")
+		}
+		return httperror.BadRequest(c, "file is required")
+	}
+
+	job, err := h.importJobUsecase.StartImport(c.Request().Context(), file, opts)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to start import")
+	}
+
+	return c.JSON(http.StatusAccepted, job)
+}
+
+// GET /imports/{job_id}
+func (h *ImportJobHandler) GetImportJob(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("job_id"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid import job ID")
+	}
+
+	job, err := h.importJobUsecase.GetImportJob(c.Request().Context(), id)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve import job")
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
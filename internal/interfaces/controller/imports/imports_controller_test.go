@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/openapi/openapitest"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 使わないメソッドは埋め込んだインターフェースに委ねる（呼ぶとpanicする）
+type stubImportJobUsecase struct {
+	usecase.ImportJobUsecase
+	opts usecase.ImportOptions
+	data string
+}
+
+func (u *stubImportJobUsecase) StartImport(ctx context.Context, r io.Reader, opts usecase.ImportOptions) (*entity.ImportJob, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	u.opts = opts
+	u.data = string(data)
+	job := entity.NewImportJob(opts.BestEffort, opts.DryRun)
+	job.ID = 1
+	return job, nil
+}
+
+func (u *stubImportJobUsecase) GetImportJob(ctx context.Context, id int64) (*entity.ImportJob, error) {
+	if id != 1 {
+		return nil, domainErrors.ErrImportJobNotFound
+	}
+	job := entity.NewImportJob(false, false)
+	job.ID = id
+	return job, nil
+}
+
+// fileフィールドにCSVを添付したリクエスト
+func newImportRequest(t *testing.T, query, csv string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "items.csv")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(csv))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/items/import"+query, &body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	return req
+}
+
+func TestImportJobHandler_ImportItems(t *testing.T) {
+	u := &stubImportJobUsecase{}
+	h := NewImportJobHandler(u)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, h.ImportItems(echo.New().NewContext(newImportRequest(t, "?best_effort=true", "name\n"), rec)))
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"queued"`)
+	openapitest.AssertExchange(t, openapitest.Document("", Operations()...), http.MethodPost, "/items/import", "", rec)
+	assert.True(t, u.opts.BestEffort)
+	assert.Equal(t, "name\n", u.data)
+
+	rec = httptest.NewRecorder()
+	require.NoError(t, h.ImportItems(echo.New().NewContext(newImportRequest(t, "?dry_run=abc", "name\n"), rec)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestImportJobHandler_ImportItems_TooLarge(t *testing.T) {
+	h := NewImportJobHandler(&stubImportJobUsecase{})
+	req := newImportRequest(t, "", "name\n"+strings.Repeat("a,", 1024))
+	rec := httptest.NewRecorder()
+	// BodyLimitのミドルウェアがボディを上限つきにした状態
+	req.Body = http.MaxBytesReader(rec, req.Body, 512)
+
+	require.NoError(t, h.ImportItems(echo.New().NewContext(req, rec)))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"file_too_large"`)
+	openapitest.AssertExchange(t, openapitest.Document("", Operations()...), http.MethodPost, "/items/import", "", rec)
+}
+
+func TestImportJobHandler_GetImportJob(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "正常系: ジョブを返す", id: "1", expectedStatus: http.StatusOK},
+		{name: "異常系: 存在しないジョブ", id: "2", expectedStatus: http.StatusNotFound, expectedCode: `"code":"import_job_not_found"`},
+		{name: "異常系: 数値でないID", id: "abc", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewImportJobHandler(&stubImportJobUsecase{})
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/imports/"+tt.id, nil), rec)
+			c.SetParamNames("job_id")
+			c.SetParamValues(tt.id)
+
+			require.NoError(t, h.GetImportJob(c))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			openapitest.AssertExchange(t, openapitest.Document("", Operations()...), http.MethodGet, "/imports/{job_id}", "", rec)
+			if tt.expectedCode != "" {
+				assert.Contains(t, rec.Body.String(), tt.expectedCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"net/http"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/openapi"
+)
+
+// CSVインポートのルートの操作
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method: http.MethodPost, Path: "/items/import", ID: "importItems", Summary: "CSVからアイテムを一括登録するジョブを登録", Tags: []string{"import"},
+			Parameters: []*openapi.Parameter{
+				openapi.Query("best_effort", openapi.Boolean(), "trueの場合は無効な行を除いて登録する"),
+				openapi.Query("dry_run", openapi.Boolean(), "trueの場合は検証のみ行い、登録しない"),
+			},
+			RequestBody: openapi.FileUpload("file", "インポートするCSV"),
+			Responses:   openapi.Responses{http.StatusAccepted: openapi.JSON("登録したジョブ。結果は GET /imports/{job_id} で取得する", openapi.Of(entity.ImportJob{}))},
+			Errors:      []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusServiceUnavailable},
+		},
+		{
+			Method: http.MethodGet, Path: "/imports/{job_id}", ID: "getImportJob", Summary: "CSVインポートのジョブの状態と結果", Tags: []string{"import"},
+			Parameters: []*openapi.Parameter{openapi.PathID("job_id", "ジョブのID")},
+			Responses:  openapi.Responses{http.StatusOK: openapi.JSON("ジョブ", openapi.Of(entity.ImportJob{}))},
+			Errors:     []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+	}
+}
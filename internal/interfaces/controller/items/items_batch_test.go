@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+	"Aicon-assignment/internal/usecase/usecasetest"
+)
+
+func TestItemHandler_GetItemsBatch(t *testing.T) {
+	fake := usecasetest.NewItemUsecase(entity.NewCategorySet("時計"),
+		&entity.Item{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), Version: entity.InitialItemVersion},
+		&entity.Item{ID: 2, Name: "サブマリーナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1200000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-02-01"), Version: entity.InitialItemVersion},
+	)
+	h := NewItemHandler(fake, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+	serve := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/items/batch?"+query, nil), rec)
+		require.NoError(t, h.GetItemsBatch(c))
+		return rec
+	}
+
+	t.Run("正常系: 指定した順序で返し、重複したIDは1件にまとめる", func(t *testing.T) {
+		rec := serve("ids=2,%201,9,2")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var body usecase.ItemBatch
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body.Items, 2)
+		assert.Equal(t, int64(2), body.Items[0].ID)
+		assert.Equal(t, int64(1), body.Items[1].ID)
+		assert.Equal(t, []int64{9}, body.MissingIDs)
+	})
+
+	ids := make([]string, usecase.MaxBatchGetItems+1)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i + 1)
+	}
+	invalid := []struct {
+		name  string
+		query string
+	}{
+		{"異常系: idsがない", ""},
+		{"異常系: 数値でないID", "ids=1,abc"},
+		{"異常系: 空の要素", "ids=1,,2"},
+		{"異常系: 0以下のID", "ids=0"},
+		{"異常系: 上限を超える件数", "ids=" + strings.Join(ids, ",")},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(tt.query)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/interfaces/controller/request"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 一括登録のリクエストボディの最大サイズ（バイト）。最大件数のアイテムを登録できる大きさにする
+const MaxBulkBodySize int64 = 4 << 20
+
+// 一括登録のバリデーションエラーの旧形式のレスポンス
+type BulkErrorResponse struct {
+	Error  string                  `json:"error"`
+	Code   string                  `json:"code"`
+	Errors []usecase.BulkItemError `json:"errors"`
+}
+
+type BulkCreateResponse struct {
+	Items []*entity.Item `json:"items"`
+}
+
+// POST /items/bulk
+// JSON配列で受け取ったアイテムを1つのトランザクションで一括登録する
+func (h *ItemHandler) BulkCreateItems(c echo.Context) error {
+	var inputs []usecase.CreateItemInput
+	if err := request.DecodeWithLimit(c, &inputs, MaxBulkBodySize); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	items, err := h.itemUsecase.BulkCreateItems(c.Request().Context(), inputs)
+	if err != nil {
+		var bulkErr *usecase.BulkValidationError
+		if errors.As(err, &bulkErr) {
+			for i := range bulkErr.Errors {
+				bulkErr.Errors[i].Errors = httperror.LocalizeFor(c, bulkErr.Errors[i].Errors)
+			}
+			res := BulkErrorResponse{
+				Error:  "validation failed",
+				Code:   httperror.CodeValidationFailed,
+				Errors: bulkErr.Errors,
+			}
+			problem := httperror.NewProblem(http.StatusUnprocessableEntity, httperror.ErrorResponse{Error: res.Error, Code: res.Code})
+			problem.Extensions["errors"] = res.Errors
+			return httperror.Write(c, problem, res)
+		}
+		return httperror.Respond(c, err, "failed to create items")
+	}
+
+	return c.JSON(http.StatusCreated, BulkCreateResponse{Items: items})
+}
+
+type BulkDeleteRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+type BulkDeleteResponse struct {
+	Results []usecase.BulkDeleteResult `json:"results"`
+}
+
+// 厳密モードの一括削除で全件を取り消した場合の旧形式のレスポンス
+type BulkDeleteErrorResponse struct {
+	Error   string                     `json:"error"`
+	Code    string                     `json:"code"`
+	Results []usecase.BulkDeleteResult `json:"results"`
+}
+
+// DELETE /items
+// ボディのidsで指定したアイテムを1つのトランザクションで一括削除する。
+// 1件でも削除できない場合は全件を取り消し、best_effort=trueの場合は削除できたアイテムのみ削除する
+func (h *ItemHandler) BulkDeleteItems(c echo.Context) error {
+	bestEffort := false
+	if value := c.QueryParam("best_effort"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return httperror.BadRequest(c, "invalid query parameters", "best_effort must be true or false")
+		}
+		bestEffort = parsed
+	}
+
+	var req BulkDeleteRequest
+	if err := request.Decode(c, &req); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+	ids, validationErrors := validateBulkDeleteIDs(req.IDs)
+	if len(validationErrors) > 0 {
+		return httperror.BadRequest(c, "invalid ids", validationErrors...)
+	}
+
+	results, err := h.itemUsecase.BulkDeleteItems(c.Request().Context(), ids, bestEffort)
+	if err != nil {
+		var bulkErr *usecase.BulkDeleteError
+		if errors.As(err, &bulkErr) {
+			res := BulkDeleteErrorResponse{
+				Error:   "item not found",
+				Code:    httperror.CodeItemNotFound,
+				Results: bulkErr.Results,
+			}
+			problem := httperror.NewProblem(http.StatusNotFound, httperror.ErrorResponse{Error: res.Error, Code: res.Code})
+			problem.Extensions["results"] = res.Results
+			return httperror.Write(c, problem, res)
+		}
+		return httperror.Respond(c, err, "failed to delete items")
+	}
+
+	return c.JSON(http.StatusOK, BulkDeleteResponse{Results: results})
+}
+
+// 一括削除のIDを検証する。重複したIDは最初の1件のみ残す
+func validateBulkDeleteIDs(ids []int64) ([]int64, []string) {
+	if len(ids) == 0 {
+		return nil, []string{"ids is required"}
+	}
+
+	unique := make([]int64, 0, len(ids))
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, []string{fmt.Sprintf("ids must be positive integers: %d", id)}
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	if len(unique) > usecase.MaxBulkDeleteItems {
+		return nil, []string{fmt.Sprintf("at most %d items can be deleted at once", usecase.MaxBulkDeleteItems)}
+	}
+	return unique, nil
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/interfaces/openapi/openapitest"
+	"Aicon-assignment/internal/usecase"
+	"Aicon-assignment/internal/usecase/usecasetest"
+)
+
+func TestItemHandler_BulkDeleteItems(t *testing.T) {
+	newFake := func() *usecasetest.ItemUsecase {
+		return usecasetest.NewItemUsecase(entity.NewCategorySet("時計"),
+			&entity.Item{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), Version: entity.InitialItemVersion},
+			&entity.Item{ID: 2, Name: "サブマリーナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1200000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-02-01"), Version: entity.InitialItemVersion},
+		)
+	}
+
+	serve := func(fake *usecasetest.ItemUsecase, query, body string) *httptest.ResponseRecorder {
+		h := NewItemHandler(fake, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+		req := httptest.NewRequest(http.MethodDelete, "/items?"+query, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		require.NoError(t, h.BulkDeleteItems(echo.New().NewContext(req, rec)))
+		openapitest.AssertExchange(t, openapitest.Document("", Operations()...), http.MethodDelete, "/items", body, rec)
+		return rec
+	}
+
+	t.Run("正常系: 重複を除いた指定順でIDごとの結果を返す", func(t *testing.T) {
+		fake := newFake()
+		rec := serve(fake, "", `{"ids":[2,1,2]}`)
+
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var body BulkDeleteResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, []usecase.BulkDeleteResult{{ID: 2, Status: usecase.BulkDeleteStatusDeleted}, {ID: 1, Status: usecase.BulkDeleteStatusDeleted}}, body.Results)
+	})
+
+	t.Run("異常系: 見つからないIDがある場合は404で返し、どのアイテムも削除しない", func(t *testing.T) {
+		fake := newFake()
+		rec := serve(fake, "", `{"ids":[1,9]}`)
+
+		require.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+		assert.Equal(t, httperror.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+		var body struct {
+			Extensions struct {
+				Code    string                     `json:"code"`
+				Results []usecase.BulkDeleteResult `json:"results"`
+			} `json:"extensions"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, httperror.CodeItemNotFound, body.Extensions.Code)
+		assert.Equal(t, []usecase.BulkDeleteResult{{ID: 9, Status: usecase.BulkDeleteStatusNotFound}}, body.Extensions.Results)
+
+		_, err := fake.GetItemByID(context.Background(), 1)
+		assert.NoError(t, err)
+	})
+
+	t.Run("正常系: best_effort=trueでは見つからないIDを結果に含めて残りを削除する", func(t *testing.T) {
+		fake := newFake()
+		rec := serve(fake, "best_effort=true", `{"ids":[9,1]}`)
+
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var body BulkDeleteResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, []usecase.BulkDeleteResult{{ID: 9, Status: usecase.BulkDeleteStatusNotFound}, {ID: 1, Status: usecase.BulkDeleteStatusDeleted}}, body.Results)
+	})
+
+	ids := make([]string, usecase.MaxBulkDeleteItems+1)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i + 1)
+	}
+	invalid := []struct {
+		name  string
+		query string
+		body  string
+	}{
+		{"異常系: ボディがない", "", ""},
+		{"異常系: 空のID", "", `{"ids":[]}`},
+		{"異常系: 0以下のID", "", `{"ids":[1,-1]}`},
+		{"異常系: 上限を超える件数", "", `{"ids":[` + strings.Join(ids, ",") + `]}`},
+		{"異常系: best_effortが真偽値でない", "best_effort=yes", `{"ids":[1]}`},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(newFake(), tt.query, tt.body)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		})
+	}
+}
@@ -1,39 +1,99 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/interfaces/controller/request"
+	"Aicon-assignment/internal/interfaces/controller/response"
 	"Aicon-assignment/internal/usecase"
 
 	"github.com/labstack/echo/v4"
 )
 
+// アイテム登録の冪等キーを指定するヘッダー
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// 未登録のブランドで保存した場合に付与する警告のヘッダー
+const warningHeader = "Warning"
+
 type ItemHandler struct {
-	itemUsecase usecase.ItemUsecase
+	itemUsecase  usecase.ItemUsecase
+	brandUsecase usecase.BrandUsecase // 登録・更新時のブランド名の解決に使う
+	// trueの場合、更新・削除でIf-Matchヘッダーを必須とする
+	requirePreconditions bool
+	// trueの場合、レスポンスをitems・itemで包んで返す（/v2/items）
+	envelope bool
 }
 
-func NewItemHandler(itemUsecase usecase.ItemUsecase) *ItemHandler {
+func NewItemHandler(itemUsecase usecase.ItemUsecase, brandUsecase usecase.BrandUsecase, requirePreconditions bool) *ItemHandler {
 	return &ItemHandler{
-		itemUsecase: itemUsecase,
+		itemUsecase:          itemUsecase,
+		brandUsecase:         brandUsecase,
+		requirePreconditions: requirePreconditions,
 	}
 }
 
-// エラーレスポンスの形式
-type ErrorResponse struct {
-	Error   string   `json:"error"`
-	Details []string `json:"details,omitempty"`
+// レスポンスをitems・itemで包んで返すハンドラーを返す。/v2/itemsで使う
+func (h *ItemHandler) WithEnvelope() *ItemHandler {
+	v2 := *h
+	v2.envelope = true
+	return &v2
+}
+
+// アイテム（fields=で選んだフィールドだけにしたものを含む）を返す。/v2/itemsではitemで包み、metaを付ける
+func (h *ItemHandler) respondItem(c echo.Context, status int, item interface{}) error {
+	if h.envelope {
+		return response.Item(c, status, item)
+	}
+	return c.JSON(status, item)
 }
 
 func (h *ItemHandler) GetItems(c echo.Context) error {
-	items, err := h.itemUsecase.GetAllItems(c.Request().Context())
+	input, validationErrors := parseListItemsQuery(c)
+	fields, fieldErrors := selectFields(c, itemJSONFields)
+	validationErrors = append(validationErrors, fieldErrors...)
+	if len(validationErrors) > 0 {
+		return httperror.BadRequest(c, "validation failed", validationErrors...)
+	}
+
+	// 一覧を取得する前に最新の更新日時を確かめ、変更がない場合は一覧を取得せずに304を返す
+	latest, err := h.itemUsecase.GetItemsLastModified(c.Request().Context(), input.Filter)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve items",
-		})
+		return httperror.Respond(c, err, "failed to retrieve items")
+	}
+	if latest != nil {
+		modified := lastModified(*latest)
+		setLastModified(c, modified)
+		if notModified(c, "", modified) {
+			return c.NoContent(http.StatusNotModified)
+		}
 	}
 
+	items, err := h.itemUsecase.GetAllItems(c.Request().Context(), input)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve items")
+	}
+
+	if h.envelope {
+		return response.List(c, http.StatusOK, projectItems(items.Items, fields), response.Pagination{Total: items.Total, Limit: items.Limit, Offset: items.Offset, NextCursor: items.NextCursor})
+	}
+	if fields != nil {
+		return c.JSON(http.StatusOK, projectedItemList{
+			Items:      projectItems(items.Items, fields),
+			Total:      items.Total,
+			Limit:      items.Limit,
+			Offset:     items.Offset,
+			NextCursor: items.NextCursor,
+		})
+	}
 	return c.JSON(http.StatusOK, items)
 }
 
@@ -41,77 +101,343 @@ func (h *ItemHandler) GetItem(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return httperror.BadRequest(c, "invalid item ID")
+	}
+	fields, validationErrors := selectFields(c, itemJSONFields)
+	if len(validationErrors) > 0 {
+		return httperror.BadRequest(c, "validation failed", validationErrors...)
 	}
 
 	item, err := h.itemUsecase.GetItemByID(c.Request().Context(), id)
 	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
+		return httperror.Respond(c, err, "failed to retrieve item")
+	}
+
+	etag := itemETag(item)
+	modified := lastModified(item.UpdatedAt)
+	c.Response().Header().Set("ETag", etag)
+	setLastModified(c, modified)
+	if notModified(c, etag, modified) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return h.respondItem(c, http.StatusOK, projectItem(item, fields))
+}
+
+// シリアル番号でアイテムを取得する
+func (h *ItemHandler) LookupItem(c echo.Context) error {
+	serialNumber := strings.TrimSpace(c.QueryParam("serial_number"))
+	if serialNumber == "" {
+		return httperror.BadRequest(c, "serial_number is required")
+	}
+	fields, validationErrors := selectFields(c, itemJSONFields)
+	if len(validationErrors) > 0 {
+		return httperror.BadRequest(c, "validation failed", validationErrors...)
+	}
+
+	item, err := h.itemUsecase.GetItemBySerialNumber(c.Request().Context(), serialNumber)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve item")
+	}
+
+	c.Response().Header().Set("ETag", itemETag(item))
+	return h.respondItem(c, http.StatusOK, projectItem(item, fields))
+}
+
+// GET /items/batch?ids=1,2,3 指定したIDのアイテムを指定した順序で返す
+func (h *ItemHandler) GetItemsBatch(c echo.Context) error {
+	ids, validationErrors := parseIDsQuery(c.QueryParam("ids"))
+	if len(validationErrors) > 0 {
+		return httperror.BadRequest(c, "invalid ids parameter", validationErrors...)
+	}
+
+	batch, err := h.itemUsecase.GetItemsByIDs(c.Request().Context(), ids)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve items")
+	}
+
+	return c.JSON(http.StatusOK, batch)
+}
+
+// GET /items/recent?window=7d&limit=10 期間内に登録・更新したアイテムを、含めた理由とともに返す
+func (h *ItemHandler) GetRecentItems(c echo.Context) error {
+	window, err := entity.ParseRecentWindow(c.QueryParam("window"))
+	if err != nil {
+		return httperror.BadRequest(c, "invalid window parameter", err.Error())
+	}
+	var limit int
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		v, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return httperror.BadRequest(c, "invalid limit parameter", "limit must be an integer")
+		}
+		if v < 1 {
+			return httperror.BadRequest(c, "invalid limit parameter", "limit must be 1 or greater")
+		}
+		limit = v
+	}
+
+	recent, err := h.itemUsecase.GetRecentItems(c.Request().Context(), window, limit)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve recent items")
+	}
+
+	return c.JSON(http.StatusOK, recent)
+}
+
+// カンマ区切りのIDを解析する。重複したIDは最初の1件のみ残す
+func parseIDsQuery(value string) ([]int64, []string) {
+	if strings.TrimSpace(value) == "" {
+		return nil, []string{"ids is required"}
+	}
+
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, part := range strings.Split(value, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil || id <= 0 {
+			return nil, []string{fmt.Sprintf("ids must be comma-separated positive integers: %q", part)}
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve item",
-		})
 	}
 
-	return c.JSON(http.StatusOK, item)
+	if len(ids) > usecase.MaxBatchGetItems {
+		return nil, []string{fmt.Sprintf("at most %d ids can be requested at once", usecase.MaxBatchGetItems)}
+	}
+	return ids, nil
 }
 
 func (h *ItemHandler) CreateItem(c echo.Context) error {
 	var input usecase.CreateItemInput
-	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request format",
-		})
+	if err := request.Decode(c, &input); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	// 同じアイテムを複数所持している場合は、force=trueで重複の確認を省略できる
+	if v := c.QueryParam("force"); v != "" {
+		force, err := strconv.ParseBool(v)
+		if err != nil {
+			return httperror.BadRequest(c, "invalid force parameter", "force must be true or false")
+		}
+		input.Force = force
 	}
 
 	// バリデーション
 	if validationErrors := validateCreateItemInput(input); len(validationErrors) > 0 {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation failed",
-			Details: validationErrors,
-		})
+		return httperror.Respond(c, validationErrors, "validation failed")
 	}
 
-	item, err := h.itemUsecase.CreateItem(c.Request().Context(), input)
+	brand, err := h.resolveBrand(c, input.Brand)
 	if err != nil {
-		if domainErrors.IsValidationError(err) {
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "validation failed",
-				Details: []string{err.Error()},
-			})
+		return httperror.Respond(c, err, "failed to create item")
+	}
+	input.Brand = brand
+
+	// Idempotency-Keyが指定された場合は、同じキーでの再送に最初のレスポンスを返す
+	var item *entity.Item
+	if keys := c.Request().Header.Values(idempotencyKeyHeader); len(keys) > 0 {
+		if len(keys) != 1 || strings.TrimSpace(keys[0]) == "" || len(keys[0]) > entity.MaxIdempotencyKeyLength {
+			return httperror.BadRequest(c, "invalid Idempotency-Key header", fmt.Sprintf("Idempotency-Key must be a single value of 1 to %d characters", entity.MaxIdempotencyKeyLength))
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to create item",
-		})
+
+		var replayed bool
+		item, replayed, err = h.itemUsecase.CreateItemWithIdempotencyKey(c.Request().Context(), keys[0], input)
+		if err == nil && replayed {
+			c.Response().Header().Set("Idempotent-Replayed", "true")
+		}
+	} else {
+		item, err = h.itemUsecase.CreateItem(c.Request().Context(), input)
+	}
+	if err != nil {
+		return httperror.Respond(c, err, "failed to create item")
 	}
 
-	return c.JSON(http.StatusCreated, item)
+	return h.respondCreated(c, item)
+}
+
+// 作成したアイテムを201で返す。ボディは保存後に読み込み直したアイテム（IDや日時、正規化した値を含む）で、
+// 取得し直さなくてもそのまま使えるようにし、LocationヘッダーにそのアイテムのURLを設定する
+func (h *ItemHandler) respondCreated(c echo.Context, item *entity.Item) error {
+	c.Response().Header().Set("ETag", itemETag(item))
+	c.Response().Header().Set(echo.HeaderLocation, itemLocation(c, item.ID))
+	return h.respondItem(c, http.StatusCreated, item)
+}
+
+// アイテムのURL。リクエストのパス（/api/v1/items、/api/v2/items/{id}/clone など）の/itemsまでをそのまま使い、
+// 同じバージョンのAPIのURLにする
+func itemLocation(c echo.Context, id int64) string {
+	path := c.Request().URL.Path
+	if i := strings.Index(path, "/items"); i >= 0 {
+		path = path[:i]
+	}
+	return path + "/items/" + strconv.FormatInt(id, 10)
 }
 
 func (h *ItemHandler) DeleteItem(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return httperror.BadRequest(c, "invalid item ID")
 	}
 
-	err = h.itemUsecase.DeleteItem(c.Request().Context(), id)
+	version, err := h.ifMatchVersion(c, id)
 	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
+		return httperror.Respond(c, err, "failed to delete item")
+	}
+
+	err = h.itemUsecase.DeleteItem(c.Request().Context(), id, version)
+	if err != nil {
+		if version != nil {
+			err = preconditionFailed(err)
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to delete item",
-		})
+		return httperror.Respond(c, err, "failed to delete item")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// POST /items/{id}/restore
+func (h *ItemHandler) RestoreItem(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid item ID")
+	}
+
+	item, err := h.itemUsecase.RestoreItem(c.Request().Context(), id)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to restore item")
+	}
+
+	return h.respondItem(c, http.StatusOK, item)
+}
+
+// POST /items/{id}/clone
+// ボディは省略でき、指定したフィールドで複製元の値を置き換える
+func (h *ItemHandler) CloneItem(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid item ID")
+	}
+
+	var input usecase.CloneItemInput
+	if err := request.Decode(c, &input); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	item, err := h.itemUsecase.CloneItem(c.Request().Context(), id, input)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to clone item")
+	}
+
+	return h.respondCreated(c, item)
+}
+
+// POST /items/{id}/status
+func (h *ItemHandler) ChangeItemStatus(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid item ID")
+	}
+
+	var input usecase.ChangeItemStatusInput
+	if err := request.Decode(c, &input); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	// If-Matchを指定した場合は、ボディのversionより優先する
+	version, err := h.ifMatchVersion(c, id)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to change item status")
+	}
+	if version != nil {
+		input.Version = version
+	}
+
+	item, err := h.itemUsecase.ChangeItemStatus(c.Request().Context(), id, input)
+	if err != nil {
+		if version != nil {
+			err = preconditionFailed(err)
+		}
+		return httperror.Respond(c, err, "failed to change item status")
+	}
+
+	c.Response().Header().Set("ETag", itemETag(item))
+	return h.respondItem(c, http.StatusOK, item)
+}
+
+// POST /items/{id}/sell
+func (h *ItemHandler) MarkItemSold(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid item ID")
+	}
+
+	var input usecase.MarkItemSoldInput
+	if err := request.Decode(c, &input); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	// If-Matchを指定した場合は、ボディのversionより優先する
+	version, err := h.ifMatchVersion(c, id)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to mark item as sold")
+	}
+	if version != nil {
+		input.Version = version
+	}
+
+	item, err := h.itemUsecase.MarkItemSold(c.Request().Context(), id, input)
+	if err != nil {
+		if version != nil {
+			err = preconditionFailed(err)
+		}
+		return httperror.Respond(c, err, "failed to mark item as sold")
+	}
+
+	c.Response().Header().Set("ETag", itemETag(item))
+	return h.respondItem(c, http.StatusOK, item)
+}
+
+// 変更履歴を新しい順に返す。物理削除されたアイテムの履歴も取得できる
+func (h *ItemHandler) GetItemHistory(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid item ID")
+	}
+
+	var validationErrors []string
+	limit, offset := parsePaginationQuery(c, &validationErrors)
+	if len(validationErrors) > 0 {
+		return httperror.BadRequest(c, "validation failed", validationErrors...)
+	}
+
+	histories, err := h.itemUsecase.GetItemHistory(c.Request().Context(), id, limit, offset)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve item history")
+	}
+
+	return c.JSON(http.StatusOK, histories)
+}
+
+// DELETE /admin/items/{id}
+func (h *ItemHandler) HardDeleteItem(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid item ID")
+	}
+
+	err = h.itemUsecase.HardDeleteItem(c.Request().Context(), id)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to delete item")
 	}
 
 	return c.NoContent(http.StatusNoContent)
@@ -121,110 +447,416 @@ func (h *ItemHandler) UpdateItem(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return httperror.BadRequest(c, "invalid item ID")
 	}
 
 	var input usecase.UpdateItemInput
-	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request format",
-		})
+	if err := request.Decode(c, &input); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	// 更新対象のフィールドが1つもない場合は何も変更せず400を返す
+	if input.IsEmpty() {
+		return httperror.BadRequest(c, "at least one field must be specified for update")
+	}
+
+	// If-Matchを指定した場合は、ボディのversionを省略できる
+	version, err := h.ifMatchVersion(c, id)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to update item")
+	}
+	if input.Version == nil {
+		input.Version = version
 	}
 
 	// バリデーション
 	if validationErrors := validateUpdateItemInput(input); len(validationErrors) > 0 {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation failed",
-			Details: validationErrors,
-		})
+		return httperror.Respond(c, validationErrors, "validation failed")
+	}
+
+	if input.Brand != nil {
+		brand, err := h.resolveBrand(c, *input.Brand)
+		if err != nil {
+			return httperror.Respond(c, err, "failed to update item")
+		}
+		input.Brand = &brand
 	}
 
 	item, err := h.itemUsecase.UpdateItem(c.Request().Context(), id, input)
 	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
+		if version != nil {
+			err = preconditionFailed(err)
 		}
-		if domainErrors.IsValidationError(err) {
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "validation failed",
-				Details: []string{err.Error()},
-			})
+		return httperror.Respond(c, err, "failed to update item")
+	}
+
+	c.Response().Header().Set("ETag", itemETag(item))
+	return h.respondItem(c, http.StatusOK, item)
+}
+
+// PUT /items/{id}
+// 登録と同じフィールドをすべて置き換える。省略した任意のフィールドは未設定に戻す。
+// PATCHと同じく、ボディのversionかIf-Matchで取得時のバージョンを指定する
+func (h *ItemHandler) ReplaceItem(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid item ID")
+	}
+
+	var input usecase.ReplaceItemInput
+	if err := request.Decode(c, &input); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	version, err := h.ifMatchVersion(c, id)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to update item")
+	}
+	if input.Version == nil {
+		input.Version = version
+	}
+
+	if validationErrors := validateReplaceItemInput(input); len(validationErrors) > 0 {
+		return httperror.Respond(c, validationErrors, "validation failed")
+	}
+
+	brand, err := h.resolveBrand(c, input.Brand)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to update item")
+	}
+	input.Brand = brand
+
+	item, err := h.itemUsecase.ReplaceItem(c.Request().Context(), id, input)
+	if err != nil {
+		if version != nil {
+			err = preconditionFailed(err)
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to update item",
-		})
+		return httperror.Respond(c, err, "failed to update item")
 	}
 
-	return c.JSON(http.StatusOK, item)
+	c.Response().Header().Set("ETag", itemETag(item))
+	return h.respondItem(c, http.StatusOK, item)
+}
+
+// 登録済みのブランドの名前か別名を正式な名前に置き換える。
+// 未登録のブランドを保存できる検証モードでは、レスポンスにWarningヘッダーを付与する
+func (h *ItemHandler) resolveBrand(c echo.Context, brand string) (string, error) {
+	resolution, err := h.brandUsecase.ResolveItemBrand(c.Request().Context(), brand)
+	if err != nil {
+		return "", err
+	}
+	if resolution.Warning != "" {
+		c.Response().Header().Set(warningHeader, fmt.Sprintf("299 - %q", resolution.Warning))
+	}
+	return resolution.Brand, nil
 }
 
 func (h *ItemHandler) GetSummary(c echo.Context) error {
 	summary, err := h.itemUsecase.GetCategorySummary(c.Request().Context())
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve summary",
-		})
+		return httperror.Respond(c, err, "failed to retrieve summary")
+	}
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+// GET /items/summary/brands?limit=10
+func (h *ItemHandler) GetBrandSummary(c echo.Context) error {
+	var limit int
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		v, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return httperror.BadRequest(c, "invalid limit parameter", "limit must be an integer")
+		}
+		if v < 1 {
+			return httperror.BadRequest(c, "invalid limit parameter", "limit must be 1 or greater")
+		}
+		limit = v
+	}
+
+	summary, err := h.itemUsecase.GetBrandSummary(c.Request().Context(), limit)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve brand summary")
 	}
 
 	return c.JSON(http.StatusOK, summary)
 }
 
-func validateCreateItemInput(input usecase.CreateItemInput) []string {
+// GET /items/report/profit?year=2024
+func (h *ItemHandler) GetProfitReport(c echo.Context) error {
+	var year int
+	if yearStr := c.QueryParam("year"); yearStr != "" {
+		v, err := strconv.Atoi(yearStr)
+		if err != nil {
+			return httperror.BadRequest(c, "invalid year parameter", "year must be an integer")
+		}
+		year = v
+	}
+
+	report, err := h.itemUsecase.GetProfitReport(c.Request().Context(), year)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve profit report")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// GET /items/report/locations
+func (h *ItemHandler) GetLocationReport(c echo.Context) error {
+	report, err := h.itemUsecase.GetLocationReport(c.Request().Context())
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve location report")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// GET /items/report/spend?granularity=month&from=2023-01&to=2024-12
+func (h *ItemHandler) GetSpendReport(c echo.Context) error {
+	granularity := strings.ToLower(strings.TrimSpace(c.QueryParam("granularity")))
+	spendRange, err := entity.ParseSpendRange(granularity, strings.TrimSpace(c.QueryParam("from")), strings.TrimSpace(c.QueryParam("to")))
+	if err != nil {
+		return httperror.BadRequest(c, "invalid spend report range", err.Error())
+	}
+
+	report, err := h.itemUsecase.GetSpendReport(c.Request().Context(), spendRange)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve spend report")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// GET /items/stats
+// 一覧と同じ絞り込み条件でアイテムの統計を返す
+func (h *ItemHandler) GetItemStats(c echo.Context) error {
+	var validationErrors []string
+	filter := parseItemFilterQuery(c, &validationErrors)
+	if len(validationErrors) > 0 {
+		return httperror.BadRequest(c, "validation failed", validationErrors...)
+	}
+
+	stats, err := h.itemUsecase.GetItemStats(c.Request().Context(), filter)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve item stats")
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+// 一覧取得のクエリパラメータ(絞り込み条件, 並び替え, limit, offset, cursor)を解析
+func parseListItemsQuery(c echo.Context) (usecase.ListItemsInput, []string) {
+	var input usecase.ListItemsInput
 	var errs []string
 
+	input.Filter = parseItemFilterQuery(c, &errs)
+	if includeDeletedStr := c.QueryParam("include_deleted"); includeDeletedStr != "" {
+		includeDeleted, err := strconv.ParseBool(includeDeletedStr)
+		if err != nil {
+			errs = append(errs, "include_deleted must be true or false")
+		}
+		input.Filter.IncludeDeleted = includeDeleted
+	}
+
+	input.Sort.Field = strings.ToLower(strings.TrimSpace(c.QueryParam("sort")))
+	input.Sort.Order = strings.ToLower(strings.TrimSpace(c.QueryParam("order")))
+
+	input.Limit, input.Offset = parsePaginationQuery(c, &errs)
+	input.Cursor = c.QueryParam("cursor")
+	if input.Cursor != "" && input.Offset > 0 {
+		errs = append(errs, "cursor and offset cannot be used together")
+	}
+
+	return input, errs
+}
+
+// limit, offsetのクエリパラメータを解析。未指定の場合は0を返す
+func parsePaginationQuery(c echo.Context, errs *[]string) (int, int) {
+	var limit, offset int
+
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		v, err := strconv.Atoi(limitStr)
+		if err != nil {
+			*errs = append(*errs, "limit must be an integer")
+		} else if v < 1 {
+			*errs = append(*errs, "limit must be 1 or greater")
+		} else {
+			limit = v
+		}
+	}
+
+	if offsetStr := c.QueryParam("offset"); offsetStr != "" {
+		v, err := strconv.Atoi(offsetStr)
+		if err != nil {
+			*errs = append(*errs, "offset must be an integer")
+		} else if v < 0 {
+			*errs = append(*errs, "offset must be 0 or greater")
+		} else {
+			offset = v
+		}
+	}
+
+	return limit, offset
+}
+
+// 絞り込み条件のクエリパラメータを解析
+func parseItemFilterQuery(c echo.Context, errs *[]string) entity.ItemFilter {
+	var filter entity.ItemFilter
+
+	filter.Category = entity.NormalizeText(c.QueryParam("category"))
+	filter.Condition = strings.TrimSpace(c.QueryParam("condition"))
+	filter.Status = strings.ToLower(strings.TrimSpace(c.QueryParam("status")))
+	if location := entity.NormalizePurchaseLocation(c.QueryParam("purchase_location")); location != nil {
+		filter.PurchaseLocation = *location
+	}
+	// tagは複数指定でき、すべてのタグが付いたアイテムに絞り込む
+	filter.Tags = entity.NormalizeTags(c.QueryParams()["tag"])
+	filter.Brand = entity.NormalizeBrandName(c.QueryParam("brand"))
+	filter.Keyword = c.QueryParam("q")
+	filter.MinPrice = parseNonNegativeIntQuery(c, "min_price", errs)
+	filter.MaxPrice = parseNonNegativeIntQuery(c, "max_price", errs)
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		*errs = append(*errs, "min_price must be less than or equal to max_price")
+	}
+	// 所有者での絞り込みは管理者のみ指定できる（ユースケースで確認する）
+	filter.OwnerID = parseNonNegativeIntQuery(c, "owner_id", errs)
+	filter.PurchasedFrom = parseDateQuery(c, "purchased_from", errs)
+	filter.PurchasedTo = parseDateQuery(c, "purchased_to", errs)
+	if filter.PurchasedFrom != nil && filter.PurchasedTo != nil && filter.PurchasedFrom.After(*filter.PurchasedTo) {
+		*errs = append(*errs, "purchased_from must be on or before purchased_to")
+	}
+
+	return filter
+}
+
+// 0以上の整数のクエリパラメータを解析。未指定または不正な値の場合はnilを返す
+func parseNonNegativeIntQuery(c echo.Context, name string, errs *[]string) *int64 {
+	valueStr := c.QueryParam(name)
+	if valueStr == "" {
+		return nil
+	}
+
+	value, err := strconv.ParseInt(valueStr, 10, 64)
+	if err != nil {
+		*errs = append(*errs, name+" must be an integer")
+		return nil
+	}
+	if value < 0 {
+		*errs = append(*errs, name+" must be 0 or greater")
+		return nil
+	}
+
+	return &value
+}
+
+// 日付のクエリパラメータを解析。未指定または不正な値の場合はnilを返す
+func parseDateQuery(c echo.Context, name string, errs *[]string) *time.Time {
+	valueStr := strings.TrimSpace(c.QueryParam(name))
+	if valueStr == "" {
+		return nil
+	}
+
+	date, err := entity.ParsePurchaseDate(valueStr)
+	if err != nil {
+		*errs = append(*errs, name+" must be in YYYY-MM-DD format")
+		return nil
+	}
+
+	value := date.Time()
+	return &value
+}
+
+func validateCreateItemInput(input usecase.CreateItemInput) domainErrors.ValidationErrors {
+	var errs domainErrors.ValidationErrors
+
 	// Basic required field validation
 	if input.Name == "" {
-		errs = append(errs, "name is required")
+		errs.Append(domainErrors.Required("name"))
 	}
 	if input.Category == "" {
-		errs = append(errs, "category is required")
+		errs.Append(domainErrors.Required("category"))
 	}
 	if input.Brand == "" {
-		errs = append(errs, "brand is required")
+		errs.Append(domainErrors.Required("brand"))
 	}
 	if input.PurchaseDate == "" {
-		errs = append(errs, "purchase_date is required")
+		errs.Append(domainErrors.Required("purchase_date"))
 	}
 	if input.PurchasePrice < 0 {
-		errs = append(errs, "purchase_price must be 0 or greater")
+		errs.Append(domainErrors.TooSmall("purchase_price", 0))
+	}
+	if input.Currency != "" && !entity.IsSupportedCurrency(entity.NormalizeCurrency(input.Currency)) {
+		errs.Append(domainErrors.OneOf("currency", entity.SupportedCurrencies))
 	}
 
 	return errs
 }
 
-func validateUpdateItemInput(input usecase.UpdateItemInput) []string {
-	var errs []string
+// 全置換は登録と同じ必須フィールドに加えて、取得時のバージョンを必須とする
+func validateReplaceItemInput(input usecase.ReplaceItemInput) domainErrors.ValidationErrors {
+	var errs domainErrors.ValidationErrors
+	if input.Version == nil {
+		errs.Append(domainErrors.Required("version"))
+	}
+	errs.Append(validateCreateItemInput(usecase.CreateItemInput{
+		Name:          input.Name,
+		Category:      input.Category,
+		Brand:         input.Brand,
+		PurchasePrice: input.PurchasePrice,
+		Currency:      input.Currency,
+		PurchaseDate:  input.PurchaseDate,
+	})...)
+	return errs
+}
+
+func validateUpdateItemInput(input usecase.UpdateItemInput) domainErrors.ValidationErrors {
+	var errs domainErrors.ValidationErrors
 
-	// 最低1つのフィールドが指定されているかチェック
-	if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil {
-		errs = append(errs, "at least one field must be specified for update")
-		return errs
+	// 楽観的ロックのため、取得時のバージョンを必須とする
+	if input.Version == nil {
+		errs.Append(domainErrors.Required("version"))
 	}
 
-	// 指定されたフィールドのバリデーション
+	// 指定されたフィールドのバリデーション。空文字はフィールドの省略とは区別してエラーにする
 	if input.Name != nil {
-		if *input.Name == "" {
-			errs = append(errs, "name cannot be empty")
-		} else if len(*input.Name) > 100 {
-			errs = append(errs, "name must be 100 characters or less")
+		if strings.TrimSpace(*input.Name) == "" {
+			errs.Append(domainErrors.Empty("name"))
+		} else if utf8.RuneCountInString(entity.NormalizeText(*input.Name)) > entity.MaxItemNameLength {
+			errs.Append(domainErrors.TooLong("name", entity.MaxItemNameLength))
 		}
 	}
 
+	if input.Category != nil && strings.TrimSpace(*input.Category) == "" {
+		errs.Append(domainErrors.Empty("category"))
+	}
+
 	if input.Brand != nil {
-		if *input.Brand == "" {
-			errs = append(errs, "brand cannot be empty")
-		} else if len(*input.Brand) > 100 {
-			errs = append(errs, "brand must be 100 characters or less")
+		if strings.TrimSpace(*input.Brand) == "" {
+			errs.Append(domainErrors.Empty("brand"))
+		} else if utf8.RuneCountInString(entity.NormalizeBrandName(*input.Brand)) > entity.MaxBrandNameLength {
+			errs.Append(domainErrors.TooLong("brand", entity.MaxBrandNameLength))
 		}
 	}
 
 	if input.PurchasePrice != nil {
 		if *input.PurchasePrice < 0 {
-			errs = append(errs, "purchase_price must be 0 or greater")
+			errs.Append(domainErrors.TooSmall("purchase_price", 0))
+		}
+	}
+
+	if input.Currency != nil {
+		if strings.TrimSpace(*input.Currency) == "" {
+			errs.Append(domainErrors.Empty("currency"))
+		} else if !entity.IsSupportedCurrency(entity.NormalizeCurrency(*input.Currency)) {
+			errs.Append(domainErrors.OneOf("currency", entity.SupportedCurrencies))
+		}
+	}
+
+	if input.PurchaseDate != nil {
+		if strings.TrimSpace(*input.PurchaseDate) == "" {
+			errs.Append(domainErrors.Empty("purchase_date"))
+		} else if _, err := entity.ParsePurchaseDate(*input.PurchaseDate); err != nil {
+			errs.AddError("purchase_date", err)
 		}
 	}
 
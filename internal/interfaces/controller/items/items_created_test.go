@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/memory"
+	"Aicon-assignment/internal/interfaces/openapi/openapitest"
+	"Aicon-assignment/internal/usecase"
+)
+
+// メモリ上のリポジトリを使うユースケースで、登録と取得のルートを持つサーバーを作る。
+// 正規化した値が保存後のアイテムと一致することを確かめるため、テスト用のユースケースではなく実際のユースケースを使う
+func newCreatedItemServer() *echo.Echo {
+	store := memory.NewStore(&entity.Category{Slug: "watch", Name: "時計"}, &entity.Category{Slug: "bag", Name: "バッグ"})
+	itemUsecase := usecase.NewItemUsecase(&memory.ItemRepository{Store: store}, &memory.CategoryRepository{Store: store}, nil, nil, nil, nil)
+	h := NewItemHandler(itemUsecase, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+	e := echo.New()
+	for prefix, handler := range map[string]*ItemHandler{"/api/v1": h, "/api/v2": h.WithEnvelope()} {
+		g := e.Group(prefix)
+		g.POST("/items", handler.CreateItem)
+		g.POST("/items/bulk", handler.BulkCreateItems)
+		g.GET("/items/:id", handler.GetItem)
+		g.POST("/items/:id/clone", handler.CloneItem)
+	}
+	return e
+}
+
+func serveCreated(e *echo.Echo, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+// 表記ゆれのある入力。購入日・購入価格・購入店舗・タグは正規化して保存する
+const unnormalizedItemBody = `{
+	"name": "デイトナ", "category": "時計", "brand": "ROLEX",
+	"purchase_price": "1,500,000", "purchase_date": "2023/01/15",
+	"purchase_location": "  銀座　 本店 ", "tags": ["限定", "限定"]
+}`
+
+func TestItemHandler_CreateItem_Location(t *testing.T) {
+	e := newCreatedItemServer()
+
+	rec := serveCreated(e, http.MethodPost, "/api/v1/items", unnormalizedItemBody)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Equal(t, "/api/v1/items/1", rec.Header().Get(echo.HeaderLocation))
+	assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+
+	// サーバーが設定した値と正規化した値をボディで返す
+	var created map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, float64(1), created["id"])
+	assert.Equal(t, float64(1), created["version"])
+	assert.Equal(t, "watch", created["category_slug"])
+	assert.Equal(t, "2023-01-15", created["purchase_date"])
+	assert.Equal(t, float64(1500000), created["purchase_price"])
+	assert.Equal(t, "JPY", created["currency"])
+	assert.Equal(t, "銀座 本店", created["purchase_location"])
+	assert.Equal(t, []interface{}{"限定"}, created["tags"])
+	assert.NotEmpty(t, created["created_at"])
+	assert.NotEmpty(t, created["updated_at"])
+
+	// Locationを取得すると登録時のボディと同じアイテムを返す
+	got := serveCreated(e, http.MethodGet, rec.Header().Get(echo.HeaderLocation), "")
+	require.Equal(t, http.StatusOK, got.Code)
+	assert.JSONEq(t, rec.Body.String(), got.Body.String())
+}
+
+func TestItemHandler_CreateItem_Location_V2(t *testing.T) {
+	e := newCreatedItemServer()
+
+	rec := serveCreated(e, http.MethodPost, "/api/v2/items", unnormalizedItemBody)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Equal(t, "/api/v2/items/1", rec.Header().Get(echo.HeaderLocation))
+
+	var res struct {
+		Item map[string]interface{} `json:"item"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, "2023-01-15", res.Item["purchase_date"])
+}
+
+func TestItemHandler_CloneItem_Location(t *testing.T) {
+	e := newCreatedItemServer()
+	require.Equal(t, http.StatusCreated, serveCreated(e, http.MethodPost, "/api/v1/items", unnormalizedItemBody).Code)
+
+	body := `{"purchase_date": "2024/02/01", "purchase_location": "新宿　店"}`
+	rec := serveCreated(e, http.MethodPost, "/api/v1/items/1/clone", body)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Equal(t, "/api/v1/items/2", rec.Header().Get(echo.HeaderLocation))
+	assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+	openapitest.AssertExchange(t, openapitest.Document("", Operations()...), http.MethodPost, "/items/{id}/clone", body, rec)
+
+	var cloned map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &cloned))
+	assert.Equal(t, float64(2), cloned["id"])
+	assert.Equal(t, "2024-02-01", cloned["purchase_date"])
+	assert.Equal(t, "新宿 店", cloned["purchase_location"])
+	assert.Equal(t, "デイトナ", cloned["name"])
+
+	got := serveCreated(e, http.MethodGet, rec.Header().Get(echo.HeaderLocation), "")
+	require.Equal(t, http.StatusOK, got.Code)
+	assert.JSONEq(t, rec.Body.String(), got.Body.String())
+}
+
+func TestItemHandler_BulkCreateItems_WithoutLocation(t *testing.T) {
+	e := newCreatedItemServer()
+
+	body := `[` + unnormalizedItemBody + `, {"name": "バーキン", "category": "バッグ", "brand": "HERMÈS", "purchase_price": 2000000, "purchase_date": "2023-02-20"}]`
+	rec := serveCreated(e, http.MethodPost, "/api/v1/items/bulk", body)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	// 複数のアイテムを登録するためLocationは返さず、各要素がIDを持つ
+	assert.Empty(t, rec.Header().Get(echo.HeaderLocation))
+	var res struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	require.Len(t, res.Items, 2)
+	assert.Equal(t, float64(1), res.Items[0]["id"])
+	assert.Equal(t, float64(2), res.Items[1]["id"])
+	assert.Equal(t, "2023-01-15", res.Items[0]["purchase_date"])
+	assert.Equal(t, "銀座 本店", res.Items[0]["purchase_location"])
+
+	// 各要素は取得したアイテムと同じ
+	for _, item := range res.Items {
+		got := serveCreated(e, http.MethodGet, fmt.Sprintf("/api/v1/items/%v", item["id"]), "")
+		require.Equal(t, http.StatusOK, got.Code)
+		expected, err := json.Marshal(item)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(expected), got.Body.String())
+	}
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemHandler_DecodeBody(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+		expectedDetail string
+	}{
+		{
+			name:           "異常系: 登録で未知のフィールド",
+			method:         http.MethodPost,
+			body:           `{"name": "ロレックス デイトナ", "purchace_price": 1500000}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetail: `malformed request body: unknown field "purchace_price"`,
+		},
+		{
+			name:           "異常系: 登録でJSONの後に余分なデータがある",
+			method:         http.MethodPost,
+			body:           `{"name": "ロレックス デイトナ"}}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetail: "malformed request body: request body must contain a single JSON value",
+		},
+		{
+			name:           "異常系: 登録で最大サイズを超える",
+			method:         http.MethodPost,
+			body:           `{"notes": "` + strings.Repeat("a", 1<<20) + `"}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedDetail: "request body too large: request body must be 1048576 bytes or less",
+		},
+		{
+			name:           "異常系: 更新で未知のフィールド",
+			method:         http.MethodPatch,
+			body:           `{"nmae": "オメガ", "version": 1}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetail: `malformed request body: unknown field "nmae"`,
+		},
+		{
+			name:           "異常系: 更新でJSONの後に余分なデータがある",
+			method:         http.MethodPatch,
+			body:           `{"name": "オメガ", "version": 1} garbage`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetail: "malformed request body: request body must contain a single JSON value",
+		},
+		{
+			name:           "異常系: 更新で最大サイズを超える",
+			method:         http.MethodPatch,
+			body:           `{"notes": "` + strings.Repeat("a", 1<<20) + `", "version": 1}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedDetail: "request body too large: request body must be 1048576 bytes or less",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := newStubItemUsecase()
+			h := NewItemHandler(stub, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+			rec := serveItem(h, tt.method, tt.body, nil)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			var body struct {
+				Detail string `json:"detail"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.expectedDetail, body.Detail)
+			// 何も変更しない
+			assert.Equal(t, entity.InitialItemVersion, stub.item.Version)
+		})
+	}
+}
+
+func TestItemHandler_DecodeBody_PurchasePrice(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		body            string
+		expectedStatus  int
+		expectedField   string
+		expectedCode    string
+		expectedMessage string
+	}{
+		{
+			name:            "異常系: 登録で小数の購入価格",
+			method:          http.MethodPost,
+			body:            `{"name": "ロレックス デイトナ", "purchase_price": 128000.5}`,
+			expectedStatus:  http.StatusUnprocessableEntity,
+			expectedField:   "purchase_price",
+			expectedCode:    "invalid_type",
+			expectedMessage: "購入価格は整数か数字の文字列で指定してください",
+		},
+		{
+			name:            "異常系: 更新で数字以外の購入価格",
+			method:          http.MethodPatch,
+			body:            `{"purchase_price": "12万円", "version": 1}`,
+			expectedStatus:  http.StatusUnprocessableEntity,
+			expectedField:   "purchase_price",
+			expectedCode:    "invalid_type",
+			expectedMessage: "購入価格は整数か数字の文字列で指定してください",
+		},
+		{
+			name:            "異常系: ほかのフィールドの型の誤り",
+			method:          http.MethodPatch,
+			body:            `{"name": 123, "version": 1}`,
+			expectedStatus:  http.StatusUnprocessableEntity,
+			expectedField:   "name",
+			expectedCode:    "invalid_type",
+			expectedMessage: "名前の値の型が正しくありません",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewItemHandler(newStubItemUsecase(), usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+			rec := serveItem(h, tt.method, tt.body, nil)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			var body struct {
+				Extensions struct {
+					Errors []map[string]interface{} `json:"errors"`
+				} `json:"extensions"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			require.Len(t, body.Extensions.Errors, 1)
+			assert.Equal(t, tt.expectedField, body.Extensions.Errors[0]["field"])
+			assert.Equal(t, tt.expectedCode, body.Extensions.Errors[0]["code"])
+			assert.Equal(t, tt.expectedMessage, body.Extensions.Errors[0]["message"])
+		})
+	}
+}
+
+func TestItemHandler_UpdateItem_PurchasePriceString(t *testing.T) {
+	stub := newStubItemUsecase()
+	h := NewItemHandler(stub, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+	rec := serveItem(h, http.MethodPatch, `{"name": "オメガ", "purchase_price": "2,000,000", "version": 1}`, nil)
+
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "オメガ", stub.item.Name)
+	assert.Equal(t, int64(2000000), stub.item.PurchasePrice)
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/openapi/openapitest"
+	"Aicon-assignment/internal/usecase"
+)
+
+func (u *stubItemUsecase) GetAllItems(ctx context.Context, input usecase.ListItemsInput) (*usecase.ItemList, error) {
+	return &usecase.ItemList{Items: []*entity.Item{u.item}, Total: 1, Limit: 50, Offset: 0}, nil
+}
+
+func TestItemHandler_Envelope(t *testing.T) {
+	h := NewItemHandler(newStubItemUsecase(), usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+	v1 := openapitest.Document("", Operations()...)
+	v2 := openapitest.Document("", EnvelopeOperations()...)
+
+	t.Run("正常系: /itemsは従来の形式で返す", func(t *testing.T) {
+		rec := serveItem(h, http.MethodGet, "", nil)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, float64(1), body["id"])
+		assert.NotContains(t, body, "item")
+		openapitest.AssertExchange(t, v1, http.MethodGet, "/items/{id}", "", rec)
+	})
+
+	t.Run("正常系: /v2/itemsではitemで包み、リクエストIDをmetaに含める", func(t *testing.T) {
+		rec := serveItem(h.WithEnvelope(), http.MethodGet, "", map[string]string{echo.HeaderXRequestID: "req-1"})
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var body struct {
+			Item *entity.Item      `json:"item"`
+			Meta map[string]string `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.NotNil(t, body.Item)
+		assert.Equal(t, int64(1), body.Item.ID)
+		assert.Equal(t, map[string]string{"request_id": "req-1"}, body.Meta)
+		assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+		openapitest.AssertExchange(t, v2, http.MethodGet, "/items/{id}", "", rec)
+	})
+
+	t.Run("正常系: /v2/itemsの一覧はitemsとpaginationで返す", func(t *testing.T) {
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/v2/items", nil), rec)
+
+		require.NoError(t, h.WithEnvelope().GetItems(c))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Len(t, body["items"], 1)
+		assert.Equal(t, map[string]interface{}{"total": float64(1), "limit": float64(50), "offset": float64(0)}, body["pagination"])
+		assert.NotContains(t, body, "total")
+		assert.NotContains(t, body, "meta")
+		openapitest.AssertExchange(t, v2, http.MethodGet, "/items", "", rec)
+	})
+}
+
+func TestItemHandler_GetItems_CursorWithOffset(t *testing.T) {
+	h := NewItemHandler(newStubItemUsecase(), usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items?cursor=eyJpZCI6MX0&offset=10", nil), rec)
+
+	require.NoError(t, h.GetItems(c))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "cursor and offset cannot be used together")
+}
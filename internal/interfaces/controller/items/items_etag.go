@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+
+	"github.com/labstack/echo/v4"
+)
+
+// アイテムのETag。バージョンから作るため、更新のたびに変わる
+func itemETag(item *entity.Item) string {
+	return strconv.Quote(strconv.FormatInt(item.Version, 10))
+}
+
+// If-Match / If-None-Match のETagの一覧にetagが含まれるかどうか。
+// If-Matchは強い比較のためW/付きのETagには一致せず、If-None-Matchは弱い比較でW/を無視する
+func etagMatches(header, etag string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// If-Matchヘッダーを評価し、一致した場合はその時点のバージョンを返す。
+// ヘッダーがない場合はnilを返すが、requirePreconditionsが有効な場合はErrPreconditionRequiredとする
+func (h *ItemHandler) ifMatchVersion(c echo.Context, id int64) (*int64, error) {
+	ifMatch := c.Request().Header.Get("If-Match")
+	if ifMatch == "" {
+		if h.requirePreconditions {
+			return nil, httperror.ErrPreconditionRequired
+		}
+		return nil, nil
+	}
+
+	item, err := h.itemUsecase.GetItemByID(c.Request().Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	if !etagMatches(ifMatch, itemETag(item), false) {
+		return nil, preconditionFailed(domainErrors.NewVersionConflictError(item.Version))
+	}
+	return &item.Version, nil
+}
+
+// If-Matchを指定したリクエストでのバージョンの競合は412とする
+func preconditionFailed(err error) error {
+	if errors.Is(err, domainErrors.ErrVersionConflict) {
+		return fmt.Errorf("%w: %w", httperror.ErrPreconditionFailed, err)
+	}
+	return err
+}
@@ -0,0 +1,235 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/openapi/openapitest"
+	"Aicon-assignment/internal/usecase"
+)
+
+// stubItemUsecase は1件のアイテムをメモリ上で保持するテスト用のユースケース。
+// 使わないメソッドは埋め込んだインターフェースに委ねる（呼ぶとpanicする）
+type stubItemUsecase struct {
+	usecase.ItemUsecase
+	item    *entity.Item
+	deleted bool
+}
+
+func newStubItemUsecase() *stubItemUsecase {
+	return &stubItemUsecase{item: &entity.Item{
+		ID:            1,
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: 1500000,
+		Currency:      "JPY",
+		PurchaseDate:  entity.MustParsePurchaseDate("2023-01-15"),
+		Version:       entity.InitialItemVersion,
+	}}
+}
+
+func (u *stubItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	if id != u.item.ID || u.deleted {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	item := *u.item
+	return &item, nil
+}
+
+func (u *stubItemUsecase) UpdateItem(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+	if err := u.item.CheckVersion(*input.Version); err != nil {
+		return nil, err
+	}
+	if input.Name != nil {
+		u.item.Name = *input.Name
+	}
+	if input.PurchasePrice != nil {
+		u.item.PurchasePrice = int64(*input.PurchasePrice)
+	}
+	u.item.Version++
+	return u.GetItemByID(ctx, id)
+}
+
+func (u *stubItemUsecase) DeleteItem(ctx context.Context, id int64, expectedVersion *int64) error {
+	if expectedVersion != nil {
+		if err := u.item.CheckVersion(*expectedVersion); err != nil {
+			return err
+		}
+	}
+	u.deleted = true
+	return nil
+}
+
+func serveItem(h *ItemHandler, method, body string, headers map[string]string) *httptest.ResponseRecorder {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/items/1", strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	switch method {
+	case http.MethodGet:
+		_ = h.GetItem(c)
+	case http.MethodPost:
+		_ = h.CreateItem(c)
+	case http.MethodPut:
+		_ = h.ReplaceItem(c)
+	case http.MethodPatch:
+		_ = h.UpdateItem(c)
+	case http.MethodDelete:
+		_ = h.DeleteItem(c)
+	}
+	return rec
+}
+
+func TestItemHandler_ETag(t *testing.T) {
+	h := NewItemHandler(newStubItemUsecase(), usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+	rec := serveItem(h, http.MethodGet, "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+
+	// 変更がなければ304を本文なしで返す
+	rec = serveItem(h, http.MethodGet, "", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	openapitest.AssertExchange(t, openapitest.Document("", Operations()...), http.MethodGet, "/items/{id}", "", rec)
+
+	// W/付きでも弱い比較で一致する
+	rec = serveItem(h, http.MethodGet, "", map[string]string{"If-None-Match": `"9", W/` + etag})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+
+	rec = serveItem(h, http.MethodPatch, `{"name": "オメガ スピードマスター"}`, map[string]string{"If-Match": etag})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	updatedETag := rec.Header().Get("ETag")
+	assert.Equal(t, `"2"`, updatedETag)
+
+	// 更新後はETagが変わり、古いETagでは304にならない
+	rec = serveItem(h, http.MethodGet, "", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, updatedETag, rec.Header().Get("ETag"))
+}
+
+func TestItemHandler_IfMatch(t *testing.T) {
+	tests := []struct {
+		name                 string
+		method               string
+		body                 string
+		headers              map[string]string
+		requirePreconditions bool
+		wantStatus           int
+		wantBody             string
+	}{
+		{
+			name:       "正常系: If-Matchが一致すればversionを省略して更新できる",
+			method:     http.MethodPatch,
+			body:       `{"name": "更新"}`,
+			headers:    map[string]string{"If-Match": `"1"`},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "正常系: If-Matchに*を指定",
+			method:     http.MethodPatch,
+			body:       `{"name": "更新"}`,
+			headers:    map[string]string{"If-Match": "*"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "異常系: 古いETagでの更新は412",
+			method:     http.MethodPatch,
+			body:       `{"name": "更新"}`,
+			headers:    map[string]string{"If-Match": `"0"`},
+			wantStatus: http.StatusPreconditionFailed,
+			wantBody:   `"current_version":1`,
+		},
+		{
+			name:       "異常系: If-Matchは強い比較のためW/付きは一致しない",
+			method:     http.MethodPatch,
+			body:       `{"name": "更新"}`,
+			headers:    map[string]string{"If-Match": `W/"1"`},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "異常系: 古いETagでの削除は412",
+			method:     http.MethodDelete,
+			headers:    map[string]string{"If-Match": `"0"`},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "正常系: If-Matchが一致すれば削除できる",
+			method:     http.MethodDelete,
+			headers:    map[string]string{"If-Match": `"1"`},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "正常系: If-Matchなしでも削除できる",
+			method:     http.MethodDelete,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:                 "異常系: 必須モードではIf-Matchなしの更新は428",
+			method:               http.MethodPatch,
+			body:                 `{"name": "更新", "version": 1}`,
+			requirePreconditions: true,
+			wantStatus:           http.StatusPreconditionRequired,
+			wantBody:             `"code":"precondition_required"`,
+		},
+		{
+			name:                 "異常系: 必須モードではIf-Matchなしの削除は428",
+			method:               http.MethodDelete,
+			requirePreconditions: true,
+			wantStatus:           http.StatusPreconditionRequired,
+		},
+		{
+			name:                 "正常系: 必須モードでもIf-Matchがあれば更新できる",
+			method:               http.MethodPatch,
+			body:                 `{"name": "更新"}`,
+			headers:              map[string]string{"If-Match": `"1"`},
+			requirePreconditions: true,
+			wantStatus:           http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewItemHandler(newStubItemUsecase(), usecase.NewBrandUsecase(nil, entity.BrandValidationOff), tt.requirePreconditions)
+
+			rec := serveItem(h, tt.method, tt.body, tt.headers)
+
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			openapitest.AssertExchange(t, openapitest.Document("", Operations()...), tt.method, "/items/{id}", tt.body, rec)
+			if tt.wantBody != "" {
+				assert.Contains(t, rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestEtagMatches(t *testing.T) {
+	etag := strconv.Quote("3")
+	assert.True(t, etagMatches(`"3"`, etag, false))
+	assert.True(t, etagMatches(`"1", "3"`, etag, false))
+	assert.True(t, etagMatches("*", etag, false))
+	assert.False(t, etagMatches(`"2"`, etag, false))
+	assert.False(t, etagMatches(`W/"3"`, etag, false))
+	assert.True(t, etagMatches(`W/"3"`, etag, true))
+}
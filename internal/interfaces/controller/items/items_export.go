@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Excelで文字化けしないように先頭に付与するUTF-8のBOM
+const utf8BOM = "\ufeff"
+
+// CSVの列。fields=で出力する列を選べる。未設定の値は空の文字列とする
+var csvColumns = []itemColumn[string]{
+	{"id", func(i *entity.Item) string { return strconv.FormatInt(i.ID, 10) }},
+	{"name", func(i *entity.Item) string { return i.Name }},
+	{"category", func(i *entity.Item) string { return i.Category }},
+	{"brand", func(i *entity.Item) string { return i.Brand }},
+	{"purchase_price", func(i *entity.Item) string { return strconv.FormatInt(i.PurchasePrice, 10) }},
+	{"currency", func(i *entity.Item) string { return i.Currency }},
+	{"purchase_date", func(i *entity.Item) string { return i.PurchaseDate.String() }},
+	{"serial_number", func(i *entity.Item) string { return stringOrEmpty(i.SerialNumber) }},
+	{"condition", func(i *entity.Item) string { return stringOrEmpty(i.Condition) }},
+	{"notes", func(i *entity.Item) string { return i.Notes }},
+	{"purchase_location", func(i *entity.Item) string { return stringOrEmpty(i.PurchaseLocation) }},
+	{"status", func(i *entity.Item) string { return i.Status }},
+	{"selling_price", func(i *entity.Item) string {
+		if i.SellingPrice == nil {
+			return ""
+		}
+		return strconv.FormatInt(*i.SellingPrice, 10)
+	}},
+	{"sold_date", func(i *entity.Item) string {
+		if i.SoldDate == nil {
+			return ""
+		}
+		return i.SoldDate.String()
+	}},
+	{"created_at", func(i *entity.Item) string { return i.CreatedAt.Format(time.RFC3339) }},
+}
+
+// すべての列を出力する場合のヘッダー。Excelのエクスポートも同じ列を使う
+var csvHeader = columnNames(csvColumns)
+
+// GET /items/export.csv
+// 一覧と同じ絞り込み条件でアイテムをCSVとして出力する
+func (h *ItemHandler) ExportItemsCSV(c echo.Context) error {
+	var validationErrors []string
+	filter := parseItemFilterQuery(c, &validationErrors)
+	columns, fieldErrors := selectFields(c, csvColumns)
+	validationErrors = append(validationErrors, fieldErrors...)
+	if columns == nil {
+		columns = csvColumns
+	}
+
+	withBOM := false
+	if bomStr := c.QueryParam("bom"); bomStr != "" {
+		b, err := strconv.ParseBool(bomStr)
+		if err != nil {
+			validationErrors = append(validationErrors, "bom must be true or false")
+		}
+		withBOM = b
+	}
+
+	if len(validationErrors) > 0 {
+		return httperror.BadRequest(c, "validation failed", validationErrors...)
+	}
+
+	res := c.Response()
+	w := csv.NewWriter(res)
+
+	// ヘッダーは最初のアイテムを書き込む直前に送信する。
+	// 送信前のエラーであれば通常のエラーレスポンスを返せる
+	started := false
+	start := func() error {
+		started = true
+		res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+		res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="items.csv"`)
+		res.WriteHeader(http.StatusOK)
+		if withBOM {
+			if _, err := res.Write([]byte(utf8BOM)); err != nil {
+				return err
+			}
+		}
+		return w.Write(columnNames(columns))
+	}
+
+	err := h.itemUsecase.ExportItems(c.Request().Context(), filter, func(item *entity.Item) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return w.Write(csvRecord(item, columns))
+	})
+	if err != nil {
+		if started {
+			// レスポンスの送信後はステータスを変更できないため、途中で打ち切る
+			return err
+		}
+		return httperror.Respond(c, err, "failed to export items")
+	}
+
+	if !started {
+		if err := start(); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func columnNames[V any](columns []itemColumn[V]) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.name
+	}
+	return names
+}
+
+func csvRecord(item *entity.Item, columns []itemColumn[string]) []string {
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = column.value(item)
+	}
+	return record
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+
+	"github.com/labstack/echo/v4"
+)
+
+// NDJSON（改行区切りのJSON）のメディアタイプ
+const MIMEApplicationNDJSON = "application/x-ndjson"
+
+// NDJSONのエクスポートで、書き込んだ内容をクライアントへ送信する間隔（件数）
+const ndjsonFlushInterval = 100
+
+// GET /items/export.ndjson
+// 一覧と同じ絞り込み条件のアイテムを、1行に1件のJSONとして読み込みながら送信する。
+// 全件をメモリに載せないため、件数が多くてもメモリの使用量は増えない
+func (h *ItemHandler) ExportItemsNDJSON(c echo.Context) error {
+	var validationErrors []string
+	filter := parseItemFilterQuery(c, &validationErrors)
+	if len(validationErrors) > 0 {
+		return httperror.BadRequest(c, "validation failed", validationErrors...)
+	}
+
+	res := c.Response()
+	encoder := json.NewEncoder(res)
+
+	// ヘッダーは最初のアイテムを書き込む直前に送信する。
+	// 送信前のエラーであれば通常のエラーレスポンスを返せる
+	started := false
+	start := func() {
+		started = true
+		res.Header().Set(echo.HeaderContentType, MIMEApplicationNDJSON)
+		res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="items.ndjson"`)
+		res.WriteHeader(http.StatusOK)
+	}
+
+	written := 0
+	err := h.itemUsecase.ExportItems(c.Request().Context(), filter, func(item *entity.Item) error {
+		if !started {
+			start()
+		}
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+		written++
+		if written%ndjsonFlushInterval == 0 {
+			res.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if started {
+			// レスポンスの送信後はステータスを変更できないため、途中で打ち切る
+			return err
+		}
+		return httperror.Respond(c, err, "failed to export items")
+	}
+
+	if !started {
+		start()
+	}
+	res.Flush()
+	return nil
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ndjsonStubItemUsecase はstubItemUsecaseのアイテムをcount件エクスポートする。errを設定した場合は最初のアイテムの前に返す
+type ndjsonStubItemUsecase struct {
+	*stubItemUsecase
+	count  int
+	err    error
+	filter entity.ItemFilter
+}
+
+func (u *ndjsonStubItemUsecase) ExportItems(ctx context.Context, filter entity.ItemFilter, fn func(*entity.Item) error) error {
+	u.filter = filter
+	if u.err != nil {
+		return u.err
+	}
+	for i := 0; i < u.count; i++ {
+		item := *u.item
+		item.ID = int64(i + 1)
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestItemHandler_ExportItemsNDJSON(t *testing.T) {
+	serve := func(stub *ndjsonStubItemUsecase, target string) *httptest.ResponseRecorder {
+		h := NewItemHandler(stub, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+		rec := httptest.NewRecorder()
+		require.NoError(t, h.ExportItemsNDJSON(echo.New().NewContext(httptest.NewRequest(http.MethodGet, target, nil), rec)))
+		return rec
+	}
+
+	t.Run("正常系: 1行に1件のJSONを書き込み、絞り込み条件を渡す", func(t *testing.T) {
+		stub := &ndjsonStubItemUsecase{stubItemUsecase: newStubItemUsecase(), count: ndjsonFlushInterval*2 + 1}
+		rec := serve(stub, "/items/export.ndjson?category=時計")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, MIMEApplicationNDJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.True(t, rec.Flushed)
+		assert.Equal(t, "時計", stub.filter.Category)
+
+		scanner := bufio.NewScanner(rec.Body)
+		lines := 0
+		for scanner.Scan() {
+			var item entity.Item
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &item))
+			lines++
+			assert.Equal(t, int64(lines), item.ID)
+		}
+		assert.Equal(t, stub.count, lines)
+	})
+
+	t.Run("正常系: 該当するアイテムがない場合は空のボディを返す", func(t *testing.T) {
+		rec := serve(&ndjsonStubItemUsecase{stubItemUsecase: newStubItemUsecase()}, "/items/export.ndjson")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, MIMEApplicationNDJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("異常系: 無効な絞り込み条件", func(t *testing.T) {
+		rec := serve(&ndjsonStubItemUsecase{stubItemUsecase: newStubItemUsecase()}, "/items/export.ndjson?min_price=abc")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("異常系: 書き込み前のエラーは通常のエラーレスポンスで返す", func(t *testing.T) {
+		rec := serve(&ndjsonStubItemUsecase{stubItemUsecase: newStubItemUsecase(), err: domainErrors.ErrDatabaseError}, "/items/export.ndjson")
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
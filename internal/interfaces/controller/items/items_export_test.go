@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// exportStubItemUsecase はstubItemUsecaseのアイテムをエクスポートする
+type exportStubItemUsecase struct {
+	*stubItemUsecase
+}
+
+func (u *exportStubItemUsecase) ExportItems(ctx context.Context, filter entity.ItemFilter, fn func(*entity.Item) error) error {
+	return fn(u.item)
+}
+
+func TestItemHandler_ExportItemsCSV_Notes(t *testing.T) {
+	stub := &exportStubItemUsecase{stubItemUsecase: newStubItemUsecase()}
+	stub.item.Notes = "銀座で購入。\n2024-03 オーバーホール, \"保証書\"あり\r\n金庫に保管"
+	h := NewItemHandler(stub, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items/export.csv", nil), rec)
+	require.NoError(t, h.ExportItemsCSV(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// 改行や引用符を含むメモも1つのフィールドとして読み戻せる
+	records, err := csv.NewReader(strings.NewReader(rec.Body.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, csvHeader, records[0])
+
+	notesIndex := -1
+	for i, column := range csvHeader {
+		if column == "notes" {
+			notesIndex = i
+		}
+	}
+	require.NotEqual(t, -1, notesIndex)
+	// csv.Readerはフィールド内の\r\nを\nとして読む
+	assert.Equal(t, strings.ReplaceAll(stub.item.Notes, "\r\n", "\n"), records[1][notesIndex])
+}
@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"io"
+	"net/http"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+
+	"github.com/labstack/echo/v4"
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxのメディアタイプ
+const MIMEApplicationXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// Excelのエクスポートのシート名
+const xlsxSheetName = "items"
+
+// Excelのエクスポートで、ブックを組み立てている間にレスポンスの書き込みの期限を延ばす間隔（件数）
+const xlsxFlushInterval = 100
+
+// GET /items/export.xlsx
+// 一覧と同じ絞り込み条件のアイテムを、CSVと同じ列のExcelのブックとして出力する。
+// 行はexcelizeのStreamWriterで書き込み（大きくなった分は一時ファイルに退避する）、すべての行を書き込んでからブックを送信する。
+// 価格は数値、日付は日付のセルとし、見出し行にはオートフィルターを設定する
+func (h *ItemHandler) ExportItemsXLSX(c echo.Context) error {
+	var validationErrors []string
+	filter := parseItemFilterQuery(c, &validationErrors)
+	if len(validationErrors) > 0 {
+		return httperror.BadRequest(c, "validation failed", validationErrors...)
+	}
+
+	res := c.Response()
+	var book *itemsWorkbook
+	defer func() {
+		if book != nil {
+			_ = book.close()
+		}
+	}()
+
+	// ヘッダーは最初のアイテムを書き込む直前に送信する。
+	// 送信前のエラーであれば通常のエラーレスポンスを返せる
+	start := func() error {
+		var err error
+		if book, err = newItemsWorkbook(); err != nil {
+			return err
+		}
+		res.Header().Set(echo.HeaderContentType, MIMEApplicationXLSX)
+		res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="items.xlsx"`)
+		res.WriteHeader(http.StatusOK)
+		return nil
+	}
+
+	written := 0
+	err := h.itemUsecase.ExportItems(c.Request().Context(), filter, func(item *entity.Item) error {
+		if book == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := book.writeItem(item); err != nil {
+			return err
+		}
+		written++
+		// ブックは最後にまとめて送信するため、組み立てている間も書き込みの期限（STREAM_IDLE_TIMEOUT）を延ばす
+		if written%xlsxFlushInterval == 0 {
+			res.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		if res.Committed {
+			// レスポンスの送信後はステータスを変更できないため、途中で打ち切る
+			return err
+		}
+		return httperror.Respond(c, err, "failed to export items")
+	}
+
+	if book == nil {
+		if err := start(); err != nil {
+			return httperror.Respond(c, err, "failed to export items")
+		}
+	}
+	return book.writeBook(res)
+}
+
+// アイテムの一覧のブック。見出し行は太字で固定表示し、日付と日時のセルには表示形式を設定する
+type itemsWorkbook struct {
+	file          *excelize.File
+	stream        *excelize.StreamWriter
+	dateStyle     int
+	dateTimeStyle int
+	rows          int
+}
+
+func newItemsWorkbook() (*itemsWorkbook, error) {
+	book := &itemsWorkbook{file: excelize.NewFile()}
+	if err := book.init(); err != nil {
+		_ = book.close()
+		return nil, err
+	}
+	return book, nil
+}
+
+func (b *itemsWorkbook) init() error {
+	f := b.file
+	if err := f.SetSheetName(f.GetSheetName(0), xlsxSheetName); err != nil {
+		return err
+	}
+
+	headerStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+	dateFormat, dateTimeFormat := "yyyy-mm-dd", "yyyy-mm-dd hh:mm:ss"
+	if b.dateStyle, err = f.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat}); err != nil {
+		return err
+	}
+	if b.dateTimeStyle, err = f.NewStyle(&excelize.Style{CustomNumFmt: &dateTimeFormat}); err != nil {
+		return err
+	}
+
+	if b.stream, err = f.NewStreamWriter(xlsxSheetName); err != nil {
+		return err
+	}
+	// 見出し行を固定する。ペインは行より先に設定する必要がある
+	if err := b.stream.SetPanes(&excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return err
+	}
+
+	header := make([]interface{}, len(csvHeader))
+	for i, name := range csvHeader {
+		header[i] = excelize.Cell{StyleID: headerStyle, Value: name}
+	}
+	return b.writeRow(header)
+}
+
+func (b *itemsWorkbook) writeRow(values []interface{}) error {
+	b.rows++
+	cell, err := excelize.CoordinatesToCellName(1, b.rows)
+	if err != nil {
+		return err
+	}
+	return b.stream.SetRow(cell, values)
+}
+
+// csvHeaderと同じ順序で1行を書き込む。未設定の値は空のセルとし、登録日時はPurchaseDateLocationの日時とする
+func (b *itemsWorkbook) writeItem(item *entity.Item) error {
+	var serialNumber, condition, purchaseLocation, sellingPrice, soldDate interface{}
+	if item.SerialNumber != nil {
+		serialNumber = *item.SerialNumber
+	}
+	if item.Condition != nil {
+		condition = *item.Condition
+	}
+	if item.PurchaseLocation != nil {
+		purchaseLocation = *item.PurchaseLocation
+	}
+	if item.SellingPrice != nil {
+		sellingPrice = *item.SellingPrice
+	}
+	if item.SoldDate != nil {
+		soldDate = excelize.Cell{StyleID: b.dateStyle, Value: item.SoldDate.Time()}
+	}
+
+	return b.writeRow([]interface{}{
+		item.ID,
+		item.Name,
+		item.Category,
+		item.Brand,
+		item.PurchasePrice,
+		item.Currency,
+		excelize.Cell{StyleID: b.dateStyle, Value: item.PurchaseDate.Time()},
+		serialNumber,
+		condition,
+		item.Notes,
+		purchaseLocation,
+		item.Status,
+		sellingPrice,
+		soldDate,
+		excelize.Cell{StyleID: b.dateTimeStyle, Value: item.CreatedAt.In(entity.PurchaseDateLocation)},
+	})
+}
+
+// 見出し行から最後の行までにオートフィルターを設定し、ブックをwに書き込む
+func (b *itemsWorkbook) writeBook(w io.Writer) error {
+	last, err := excelize.CoordinatesToCellName(len(csvHeader), b.rows)
+	if err != nil {
+		return err
+	}
+	if err := b.file.AutoFilter(xlsxSheetName, "A1:"+last, nil); err != nil {
+		return err
+	}
+	if err := b.stream.Flush(); err != nil {
+		return err
+	}
+	return b.file.Write(w)
+}
+
+// 一時ファイルを削除する
+func (b *itemsWorkbook) close() error {
+	return b.file.Close()
+}
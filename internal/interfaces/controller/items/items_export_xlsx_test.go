@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemHandler_ExportItemsXLSX(t *testing.T) {
+	serve := func(stub *ndjsonStubItemUsecase, target string) *httptest.ResponseRecorder {
+		h := NewItemHandler(stub, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+		rec := httptest.NewRecorder()
+		require.NoError(t, h.ExportItemsXLSX(echo.New().NewContext(httptest.NewRequest(http.MethodGet, target, nil), rec)))
+		return rec
+	}
+	openBook := func(t *testing.T, body []byte) *excelize.File {
+		f, err := excelize.OpenReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = f.Close() })
+		return f
+	}
+
+	t.Run("正常系: 見出し行とアイテムの行のブックを返し、絞り込み条件を渡す", func(t *testing.T) {
+		stub := &ndjsonStubItemUsecase{stubItemUsecase: newStubItemUsecase(), count: 2}
+		rec := serve(stub, "/items/export.xlsx?category=時計")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, MIMEApplicationXLSX, rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="items.xlsx"`, rec.Header().Get(echo.HeaderContentDisposition))
+		assert.Equal(t, "時計", stub.filter.Category)
+
+		f := openBook(t, rec.Body.Bytes())
+		rows, err := f.GetRows(xlsxSheetName)
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		assert.Equal(t, csvHeader, rows[0])
+		// 購入日は表示形式を設定した日付のセル
+		assert.Equal(t, "2023-01-15", rows[1][6])
+
+		// 購入価格は数値のセル
+		cellType, err := f.GetCellType(xlsxSheetName, "E2")
+		require.NoError(t, err)
+		assert.NotEqual(t, excelize.CellTypeInlineString, cellType)
+		assert.NotEqual(t, excelize.CellTypeSharedString, cellType)
+		price, err := f.GetCellValue(xlsxSheetName, "E2", excelize.Options{RawCellValue: true})
+		require.NoError(t, err)
+		assert.Equal(t, "1500000", price)
+
+		// オートフィルターの範囲はブックの名前に保存される
+		filters := f.GetDefinedName()
+		require.Len(t, filters, 1)
+		assert.Equal(t, "'items'!$A$1:$O$3", filters[0].RefersTo)
+
+		panes, err := f.GetPanes(xlsxSheetName)
+		require.NoError(t, err)
+		assert.True(t, panes.Freeze)
+		assert.Equal(t, 1, panes.YSplit)
+	})
+
+	t.Run("正常系: 該当するアイテムがない場合は見出し行のみのブックを返す", func(t *testing.T) {
+		rec := serve(&ndjsonStubItemUsecase{stubItemUsecase: newStubItemUsecase()}, "/items/export.xlsx")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		rows, err := openBook(t, rec.Body.Bytes()).GetRows(xlsxSheetName)
+		require.NoError(t, err)
+		assert.Len(t, rows, 1)
+	})
+
+	t.Run("異常系: 無効な絞り込み条件", func(t *testing.T) {
+		rec := serve(&ndjsonStubItemUsecase{stubItemUsecase: newStubItemUsecase()}, "/items/export.xlsx?min_price=abc")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("異常系: 書き込み前のエラーは通常のエラーレスポンスで返す", func(t *testing.T) {
+		rec := serve(&ndjsonStubItemUsecase{stubItemUsecase: newStubItemUsecase(), err: domainErrors.ErrDatabaseError}, "/items/export.xlsx")
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+	"Aicon-assignment/internal/usecase/usecasetest"
+)
+
+func TestItemHandler_WithFakeUsecase(t *testing.T) {
+	fake := usecasetest.NewItemUsecase(entity.NewCategorySet("時計", "バッグ"))
+	h := NewItemHandler(fake, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+	rec := serveItem(h, http.MethodPost, `{"name": "デイトナ", "category": "時計", "brand": "ROLEX", "purchase_price": 1500000, "purchase_date": "2023-01-15"}`, nil)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = serveItem(h, http.MethodPatch, `{"purchase_price": 1600000, "version": 1}`, nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveItem(h, http.MethodGet, "", nil)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var item entity.Item
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &item))
+	assert.Equal(t, int64(1600000), item.PurchasePrice)
+	assert.Equal(t, int64(2), item.Version)
+
+	rec = serveItem(h, http.MethodDelete, "", map[string]string{"If-Match": `"2"`})
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = serveItem(h, http.MethodGet, "", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestItemHandler_ReplaceItem(t *testing.T) {
+	fake := usecasetest.NewItemUsecase(entity.NewCategorySet("時計", "バッグ"))
+	h := NewItemHandler(fake, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+	rec := serveItem(h, http.MethodPost, `{"name": "デイトナ", "category": "時計", "brand": "ROLEX", "purchase_price": 1500000, "purchase_date": "2023-01-15", "notes": "箱あり", "tags": ["限定品"]}`, nil)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	t.Run("正常系: すべてのフィールドを置き換え、省略した任意のフィールドは未設定に戻す", func(t *testing.T) {
+		rec := serveItem(h, http.MethodPut, `{"name": "スピードマスター", "category": "時計", "brand": "OMEGA", "purchase_price": 800000, "purchase_date": "2024-02-01", "version": 1}`, nil)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+
+		var item entity.Item
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &item))
+		assert.Equal(t, "スピードマスター", item.Name)
+		assert.Equal(t, "OMEGA", item.Brand)
+		assert.Empty(t, item.Notes)
+		assert.Empty(t, item.Tags)
+		assert.Equal(t, int64(2), item.Version)
+	})
+
+	t.Run("正常系: versionの代わりにIf-Matchを指定できる", func(t *testing.T) {
+		rec := serveItem(h, http.MethodPut, `{"name": "スピードマスター", "category": "時計", "brand": "OMEGA", "purchase_price": 900000, "purchase_date": "2024-02-01"}`, map[string]string{"If-Match": `"2"`})
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+	})
+
+	t.Run("異常系: 古いversionは409", func(t *testing.T) {
+		rec := serveItem(h, http.MethodPut, `{"name": "デイトナ", "category": "時計", "brand": "ROLEX", "purchase_price": 1500000, "purchase_date": "2023-01-15", "version": 1}`, nil)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+
+	t.Run("異常系: 一致しないIf-Matchは412", func(t *testing.T) {
+		rec := serveItem(h, http.MethodPut, `{"name": "デイトナ", "category": "時計", "brand": "ROLEX", "purchase_price": 1500000, "purchase_date": "2023-01-15"}`, map[string]string{"If-Match": `"1"`})
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	})
+
+	t.Run("異常系: 必須フィールドを省略した場合は422", func(t *testing.T) {
+		rec := serveItem(h, http.MethodPut, `{"name": "デイトナ", "version": 3}`, nil)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
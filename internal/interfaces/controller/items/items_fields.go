@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// アイテムのフィールドと、その値の取り出し方。fields=で選べるフィールドの一覧と、CSVの列に使う
+type itemColumn[V any] struct {
+	name  string
+	value func(item *entity.Item) V
+}
+
+// fields=で選べるアイテムのJSONのフィールド。レスポンスと同じ順序で並べる。
+// アイテムのレスポンスにフィールドを追加した場合は、ここにも追加する（追加しないと選べない）
+var itemJSONFields = []itemColumn[interface{}]{
+	{"id", func(i *entity.Item) interface{} { return i.ID }},
+	{"user_id", func(i *entity.Item) interface{} { return i.UserID }},
+	{"name", func(i *entity.Item) interface{} { return i.Name }},
+	{"category", func(i *entity.Item) interface{} { return i.Category }},
+	{"category_slug", func(i *entity.Item) interface{} { return i.CategorySlug }},
+	{"brand", func(i *entity.Item) interface{} { return i.Brand }},
+	{"purchase_price", func(i *entity.Item) interface{} { return i.PurchasePrice }},
+	{"currency", func(i *entity.Item) interface{} { return i.Currency }},
+	{"purchase_date", func(i *entity.Item) interface{} { return i.PurchaseDate }},
+	{"serial_number", func(i *entity.Item) interface{} { return i.SerialNumber }},
+	{"condition", func(i *entity.Item) interface{} { return i.Condition }},
+	{"notes", func(i *entity.Item) interface{} { return i.Notes }},
+	{"purchase_location", func(i *entity.Item) interface{} { return i.PurchaseLocation }},
+	{"status", func(i *entity.Item) interface{} { return i.Status }},
+	{"selling_price", func(i *entity.Item) interface{} { return i.SellingPrice }},
+	{"sold_date", func(i *entity.Item) interface{} { return i.SoldDate }},
+	{"version", func(i *entity.Item) interface{} { return i.Version }},
+	{"created_at", func(i *entity.Item) interface{} { return i.CreatedAt }},
+	{"updated_at", func(i *entity.Item) interface{} { return i.UpdatedAt }},
+	{"deleted_at", func(i *entity.Item) interface{} { return i.DeletedAt }},
+	{"tags", func(i *entity.Item) interface{} {
+		if i.Tags == nil {
+			return []string{}
+		}
+		return i.Tags
+	}},
+	// 画像は単一アイテムの取得時のみ読み込むため、一覧ではnullになる
+	{"images", func(i *entity.Item) interface{} { return i.Images }},
+	{"profit", func(i *entity.Item) interface{} { return i.Profit() }},
+}
+
+// fieldsのクエリパラメータ（カンマ区切りのフィールド名）で選んだ列を、allの順序で返す。
+// 指定がない場合はnilを返す。allにない名前はバリデーションエラーとする
+func selectFields[V any](c echo.Context, all []itemColumn[V]) ([]itemColumn[V], []string) {
+	value := c.QueryParam("fields")
+	if value == "" {
+		return nil, nil
+	}
+
+	requested := make(map[string]bool)
+	var errs []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !containsColumn(all, name) {
+			errs = append(errs, fmt.Sprintf("fields: unknown field %q", name))
+			continue
+		}
+		requested[name] = true
+	}
+	if len(errs) > 0 {
+		return nil, append(errs, "fields must be a comma-separated list of: "+strings.Join(columnNames(all), ", "))
+	}
+	if len(requested) == 0 {
+		return nil, []string{"fields must contain at least one field name"}
+	}
+
+	selected := make([]itemColumn[V], 0, len(requested))
+	for _, column := range all {
+		if requested[column.name] {
+			selected = append(selected, column)
+		}
+	}
+	return selected, nil
+}
+
+func containsColumn[V any](columns []itemColumn[V], name string) bool {
+	for _, column := range columns {
+		if column.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fields=で選んだフィールドだけを含むアイテムのレスポンス。
+// 選んだフィールドは値が未設定でも（omitemptyのフィールドも）nullとして含める
+type itemProjection struct {
+	item   *entity.Item
+	fields []itemColumn[interface{}]
+}
+
+func (p *itemProjection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range p.fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		v, err := json.Marshal(field.value(p.item))
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "%q:", field.name)
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// fieldsを指定した場合は選んだフィールドだけのアイテムを、指定しない場合はアイテムをそのまま返す
+func projectItem(item *entity.Item, fields []itemColumn[interface{}]) interface{} {
+	if fields == nil {
+		return item
+	}
+	return &itemProjection{item: item, fields: fields}
+}
+
+func projectItems(items []*entity.Item, fields []itemColumn[interface{}]) interface{} {
+	if fields == nil {
+		return items
+	}
+	projected := make([]*itemProjection, len(items))
+	for i, item := range items {
+		projected[i] = &itemProjection{item: item, fields: fields}
+	}
+	return projected
+}
+
+// fields=を指定した一覧のレスポンス。usecase.ItemListのアイテムを選んだフィールドだけにしたもの
+type projectedItemList struct {
+	Items      interface{} `json:"items"`
+	Total      int         `json:"total"`
+	Limit      int         `json:"limit"`
+	Offset     int         `json:"offset"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// すべてのフィールドを設定したアイテムのJSONのキーは、fields=で選べるフィールドと一致する
+func TestItemJSONFields_CoverItemResponse(t *testing.T) {
+	serialNumber, condition, location := "116500LN", "中古A", "銀座店"
+	sellingPrice := int64(1800000)
+	soldDate := entity.MustParsePurchaseDate("2024-01-15")
+	deletedAt := time.Now()
+	item := &entity.Item{
+		ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY",
+		PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), SerialNumber: &serialNumber, Condition: &condition,
+		PurchaseLocation: &location, Status: entity.ItemStatusSold, SellingPrice: &sellingPrice, SoldDate: &soldDate,
+		DeletedAt: &deletedAt, Tags: []string{"限定"}, Images: []*entity.ItemImage{{ID: 1}},
+	}
+
+	data, err := json.Marshal(item)
+	require.NoError(t, err)
+	var full map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &full))
+
+	keys := make([]string, 0, len(full))
+	for key := range full {
+		keys = append(keys, key)
+	}
+	assert.ElementsMatch(t, keys, columnNames(itemJSONFields))
+
+	// 選んだフィールドの値は、フィールドを選ばない場合のレスポンスの値と同じ
+	data, err = json.Marshal(projectItem(item, itemJSONFields))
+	require.NoError(t, err)
+	var projected map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &projected))
+	assert.Equal(t, full, projected)
+}
+
+func serveItemsWithFields(t *testing.T, h echo.HandlerFunc, target string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, target, nil), rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	require.NoError(t, h(c))
+	return rec
+}
+
+func TestItemHandler_Fields(t *testing.T) {
+	h := NewItemHandler(newStubItemUsecase(), usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+	t.Run("正常系: 一覧は選んだフィールドのみをレスポンスの順序で返す", func(t *testing.T) {
+		rec := serveItemsWithFields(t, h.GetItems, "/items?fields=purchase_price,id,name")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"items": [{"id": 1, "name": "ロレックス デイトナ", "purchase_price": 1500000}], "total": 1, "limit": 50, "offset": 0}`, rec.Body.String())
+		assert.Contains(t, rec.Body.String(), `{"id":1,"name":"ロレックス デイトナ","purchase_price":1500000}`)
+	})
+
+	t.Run("正常系: 詳細も選んだフィールドのみを返し、未設定の値はnull", func(t *testing.T) {
+		rec := serveItemsWithFields(t, h.GetItem, "/items/1?fields=id,+selling_price,tags,")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"id": 1, "selling_price": null, "tags": []}`, rec.Body.String())
+		assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+	})
+
+	t.Run("正常系: /v2/itemsではitemsの要素を選んだフィールドにする", func(t *testing.T) {
+		rec := serveItemsWithFields(t, h.WithEnvelope().GetItems, "/v2/items?fields=name")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"items": [{"name": "ロレックス デイトナ"}], "pagination": {"total": 1, "limit": 50, "offset": 0}}`, rec.Body.String())
+	})
+
+	t.Run("正常系: 指定しない場合はすべてのフィールドを返す", func(t *testing.T) {
+		rec := serveItemsWithFields(t, h.GetItem, "/items/1")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Contains(t, body, "version")
+		assert.NotContains(t, body, "selling_price")
+	})
+}
+
+func TestItemHandler_Fields_Invalid(t *testing.T) {
+	h := NewItemHandler(newStubItemUsecase(), usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+	tests := []struct {
+		name    string
+		handler echo.HandlerFunc
+		target  string
+		details []string
+	}{
+		{
+			name:    "異常系: 一覧で存在しないフィールド",
+			handler: h.GetItems,
+			target:  "/items?fields=id,password,price",
+			details: []string{`fields: unknown field "password"`, `fields: unknown field "price"`},
+		},
+		{
+			name:    "異常系: 詳細で存在しないフィールド",
+			handler: h.GetItem,
+			target:  "/items/1?fields=ID",
+			details: []string{`fields: unknown field "ID"`},
+		},
+		{
+			name:    "異常系: フィールド名がない",
+			handler: h.GetItems,
+			target:  "/items?fields=,",
+			details: []string{"fields must contain at least one field name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveItemsWithFields(t, tt.handler, tt.target)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			for _, detail := range tt.details {
+				assert.Contains(t, rec.Body.String(), strings.ReplaceAll(detail, `"`, `\"`))
+			}
+		})
+	}
+}
+
+func TestItemHandler_ExportItemsCSV_Fields(t *testing.T) {
+	stub := &exportStubItemUsecase{stubItemUsecase: newStubItemUsecase()}
+	h := NewItemHandler(stub, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+	t.Run("正常系: 選んだ列のみをCSVの列の順序で出力する", func(t *testing.T) {
+		rec := serveItemsWithFields(t, h.ExportItemsCSV, "/items/export.csv?fields=purchase_price,id,name")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		records, err := csv.NewReader(strings.NewReader(rec.Body.String())).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"id", "name", "purchase_price"},
+			{"1", "ロレックス デイトナ", "1500000"},
+		}, records)
+	})
+
+	t.Run("異常系: CSVにない列", func(t *testing.T) {
+		rec := serveItemsWithFields(t, h.ExportItemsCSV, "/items/export.csv?fields=id,tags")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `fields: unknown field \"tags\"`)
+	})
+}
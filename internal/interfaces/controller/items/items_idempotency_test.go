@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// idempotentStubItemUsecase は冪等キーごとに最初の入力を保持し、再送では同じアイテムを返す
+type idempotentStubItemUsecase struct {
+	*stubItemUsecase
+	inputs  map[string]usecase.CreateItemInput
+	created int
+}
+
+func (u *idempotentStubItemUsecase) CreateItem(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+	u.created++
+	return u.GetItemByID(ctx, u.item.ID)
+}
+
+func (u *idempotentStubItemUsecase) CreateItemWithIdempotencyKey(ctx context.Context, key string, input usecase.CreateItemInput) (*entity.Item, bool, error) {
+	if stored, ok := u.inputs[key]; ok {
+		if !reflect.DeepEqual(stored, input) {
+			return nil, false, domainErrors.ErrIdempotencyKeyMismatch
+		}
+		item, err := u.GetItemByID(ctx, u.item.ID)
+		return item, true, err
+	}
+	u.inputs[key] = input
+	item, err := u.CreateItem(ctx, input)
+	return item, false, err
+}
+
+func TestItemHandler_CreateItem_IdempotencyKey(t *testing.T) {
+	body := `{"name": "ロレックス デイトナ", "category": "時計", "brand": "ROLEX", "purchase_price": 1500000, "purchase_date": "2023-01-15"}`
+	otherBody := `{"name": "オメガ スピードマスター", "category": "時計", "brand": "OMEGA", "purchase_price": 800000, "purchase_date": "2023-01-15"}`
+
+	stub := &idempotentStubItemUsecase{stubItemUsecase: newStubItemUsecase(), inputs: map[string]usecase.CreateItemInput{}}
+	h := NewItemHandler(stub, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+	rec := serveItem(h, http.MethodPost, body, map[string]string{"Idempotency-Key": "key-1"})
+	assert.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.Empty(t, rec.Header().Get("Idempotent-Replayed"))
+	firstBody := rec.Body.String()
+
+	// 同じキーでの再送は作成せずに最初のレスポンスを返す
+	rec = serveItem(h, http.MethodPost, body, map[string]string{"Idempotency-Key": "key-1"})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, firstBody, rec.Body.String())
+	assert.Equal(t, 1, stub.created)
+
+	// 同じキーで内容が異なる場合は422
+	rec = serveItem(h, http.MethodPost, otherBody, map[string]string{"Idempotency-Key": "key-1"})
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"idempotency_key_mismatch"`)
+
+	// キーがなければ毎回作成する
+	rec = serveItem(h, http.MethodPost, body, nil)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, 2, stub.created)
+
+	// 空や長すぎるキーは400
+	for _, key := range []string{" ", strings.Repeat("a", entity.MaxIdempotencyKeyLength+1)} {
+		rec = serveItem(h, http.MethodPost, body, map[string]string{"Idempotency-Key": key})
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+	assert.Equal(t, 2, stub.created)
+}
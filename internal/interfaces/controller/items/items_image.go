@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/interfaces/controller/request"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ItemImageHandler struct {
+	imageUsecase usecase.ItemImageUsecase
+}
+
+func NewItemImageHandler(imageUsecase usecase.ItemImageUsecase) *ItemImageHandler {
+	return &ItemImageHandler{
+		imageUsecase: imageUsecase,
+	}
+}
+
+// 画像の並べ替えリクエスト。アイテムの全画像のIDを表示したい順に指定する
+type ReorderImagesRequest struct {
+	ImageIDs []int64 `json:"image_ids"`
+}
+
+// GET /items/{id}/images
+func (h *ItemImageHandler) ListImages(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid item ID")
+	}
+
+	images, err := h.imageUsecase.ListItemImages(c.Request().Context(), id)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve images")
+	}
+
+	return c.JSON(http.StatusOK, images)
+}
+
+// POST /items/{id}/images
+// multipart/form-data の image フィールドで受け取ったJPEG/PNGをアイテムの画像の末尾に追加する
+func (h *ItemImageHandler) AddImage(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid item ID")
+	}
+
+	file, err := request.FormFile(c, "image")
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrFileTooLarge) {
+			return httperror.Respond(c, err, "failed to upload image")
+		}
+		return httperror.BadRequest(c, "image is required")
+	}
+	defer file.Close()
+
+	image, err := h.imageUsecase.AddItemImage(c.Request().Context(), id, file)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to upload image")
+	}
+
+	return c.JSON(http.StatusCreated, image)
+}
+
+// DELETE /items/{id}/images/{imageId}
+func (h *ItemImageHandler) DeleteImage(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid item ID")
+	}
+	imageID, err := strconv.ParseInt(c.Param("imageId"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid image ID")
+	}
+
+	if err := h.imageUsecase.DeleteItemImage(c.Request().Context(), id, imageID); err != nil {
+		return httperror.Respond(c, err, "failed to delete image")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PUT /items/{id}/images/order
+func (h *ItemImageHandler) ReorderImages(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid item ID")
+	}
+
+	var req ReorderImagesRequest
+	if err := request.Decode(c, &req); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	images, err := h.imageUsecase.ReorderItemImages(c.Request().Context(), id, req.ImageIDs)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to reorder images")
+	}
+
+	return c.JSON(http.StatusOK, images)
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 更新日時をLast-Modifiedの日時にする。HTTPの日時は秒までのため秒未満を切り捨て、
+// サーバーの現在時刻より未来の日時（データベースとの時計のずれ）は現在時刻に丸める
+func lastModified(updatedAt time.Time) time.Time {
+	modified := updatedAt.Truncate(time.Second)
+	if now := entity.Now().Truncate(time.Second); modified.After(now) {
+		return now
+	}
+	return modified
+}
+
+// Last-Modifiedヘッダーを設定する。更新日時が分からない（ゼロ値の）場合は設定しない
+func setLastModified(c echo.Context, modified time.Time) {
+	if modified.IsZero() {
+		return
+	}
+	c.Response().Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+}
+
+// If-None-Match / If-Modified-Since を評価し、変更がない（304を返す）場合はtrueを返す。
+// If-None-Matchを指定した場合はそれのみを評価し、If-Modified-Sinceは無視する。etagが空の場合（一覧）はどのETagにも一致しない。
+// 解析できないIf-Modified-Sinceと、サーバーの現在時刻より未来の日時（クライアントの時計のずれ）は無視する
+func notModified(c echo.Context, etag string, modified time.Time) bool {
+	header := c.Request().Header
+	if ifNoneMatch := header.Get("If-None-Match"); ifNoneMatch != "" {
+		return etag != "" && etagMatches(ifNoneMatch, etag, true)
+	}
+
+	since, err := http.ParseTime(header.Get("If-Modified-Since"))
+	if err != nil || modified.IsZero() || since.After(entity.Now()) {
+		return false
+	}
+	return !modified.After(since)
+}
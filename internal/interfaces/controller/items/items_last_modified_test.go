@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/testutil"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 更新日時が未設定の場合は、更新日時が分からないものとしてnilを返す
+func (u *stubItemUsecase) GetItemsLastModified(ctx context.Context, filter entity.ItemFilter) (*time.Time, error) {
+	if u.item.UpdatedAt.IsZero() {
+		return nil, nil
+	}
+	updatedAt := u.item.UpdatedAt
+	return &updatedAt, nil
+}
+
+// 一覧を取得した回数を数えるstubItemUsecase
+type countingStubItemUsecase struct {
+	*stubItemUsecase
+	listed int
+}
+
+func (u *countingStubItemUsecase) GetAllItems(ctx context.Context, input usecase.ListItemsInput) (*usecase.ItemList, error) {
+	u.listed++
+	return u.stubItemUsecase.GetAllItems(ctx, input)
+}
+
+// サーバーの現在時刻
+var lastModifiedNow = time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+// 秒未満を含む更新日時。Last-Modifiedでは2024-01-15 10:30:00になる
+var lastModifiedUpdatedAt = time.Date(2024, 1, 15, 10, 30, 0, 750_000_000, time.UTC)
+
+func TestItemHandler_GetItem_LastModified(t *testing.T) {
+	t.Cleanup(entity.SetClock(testutil.NewFixedClock(lastModifiedNow)))
+
+	tests := []struct {
+		name                 string
+		updatedAt            time.Time
+		headers              map[string]string
+		expectedStatus       int
+		expectedLastModified string
+	}{
+		{
+			name:                 "正常系: 更新日時を秒未満を切り捨ててLast-Modifiedで返す",
+			updatedAt:            lastModifiedUpdatedAt,
+			expectedStatus:       http.StatusOK,
+			expectedLastModified: "Mon, 15 Jan 2024 10:30:00 GMT",
+		},
+		{
+			name:                 "正常系: Last-Modifiedと同じ日時のIf-Modified-Sinceは秒未満の更新があっても304",
+			updatedAt:            lastModifiedUpdatedAt,
+			headers:              map[string]string{"If-Modified-Since": "Mon, 15 Jan 2024 10:30:00 GMT"},
+			expectedStatus:       http.StatusNotModified,
+			expectedLastModified: "Mon, 15 Jan 2024 10:30:00 GMT",
+		},
+		{
+			name:                 "正常系: 更新日時より前のIf-Modified-Sinceは200",
+			updatedAt:            lastModifiedUpdatedAt,
+			headers:              map[string]string{"If-Modified-Since": "Mon, 15 Jan 2024 10:29:59 GMT"},
+			expectedStatus:       http.StatusOK,
+			expectedLastModified: "Mon, 15 Jan 2024 10:30:00 GMT",
+		},
+		{
+			name:                 "正常系: If-None-Matchを指定した場合はIf-Modified-Sinceを無視する",
+			updatedAt:            lastModifiedUpdatedAt,
+			headers:              map[string]string{"If-None-Match": `"2"`, "If-Modified-Since": "Mon, 15 Jan 2024 11:00:00 GMT"},
+			expectedStatus:       http.StatusOK,
+			expectedLastModified: "Mon, 15 Jan 2024 10:30:00 GMT",
+		},
+		{
+			name:                 "正常系: サーバーの現在時刻より未来のIf-Modified-Since（クライアントの時計のずれ）は無視する",
+			updatedAt:            lastModifiedUpdatedAt,
+			headers:              map[string]string{"If-Modified-Since": "Mon, 15 Jan 2024 12:00:01 GMT"},
+			expectedStatus:       http.StatusOK,
+			expectedLastModified: "Mon, 15 Jan 2024 10:30:00 GMT",
+		},
+		{
+			name:                 "正常系: 解析できないIf-Modified-Sinceは無視する",
+			updatedAt:            lastModifiedUpdatedAt,
+			headers:              map[string]string{"If-Modified-Since": "2024-01-15T11:00:00Z"},
+			expectedStatus:       http.StatusOK,
+			expectedLastModified: "Mon, 15 Jan 2024 10:30:00 GMT",
+		},
+		{
+			name:                 "正常系: 現在時刻より未来の更新日時（データベースとの時計のずれ）は現在時刻にする",
+			updatedAt:            lastModifiedNow.Add(90 * time.Second),
+			headers:              map[string]string{"If-Modified-Since": "Mon, 15 Jan 2024 12:00:00 GMT"},
+			expectedStatus:       http.StatusNotModified,
+			expectedLastModified: "Mon, 15 Jan 2024 12:00:00 GMT",
+		},
+		{
+			name:                 "正常系: タイムゾーンが異なる更新日時もGMTで返す",
+			updatedAt:            time.Date(2024, 1, 15, 19, 30, 0, 0, time.FixedZone("JST", 9*60*60)),
+			expectedStatus:       http.StatusOK,
+			expectedLastModified: "Mon, 15 Jan 2024 10:30:00 GMT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := newStubItemUsecase()
+			stub.item.UpdatedAt = tt.updatedAt
+			h := NewItemHandler(stub, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+			rec := serveItem(h, http.MethodGet, "", tt.headers)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedLastModified, rec.Header().Get("Last-Modified"))
+			assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestItemHandler_GetItems_LastModified(t *testing.T) {
+	t.Cleanup(entity.SetClock(testutil.NewFixedClock(lastModifiedNow)))
+
+	serveList := func(stub *countingStubItemUsecase, headers map[string]string) *httptest.ResponseRecorder {
+		h := NewItemHandler(stub, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+		req := httptest.NewRequest(http.MethodGet, "/items?category=時計", nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		require.NoError(t, h.GetItems(echo.New().NewContext(req, rec)))
+		return rec
+	}
+
+	t.Run("正常系: 一致するアイテムの最新の更新日時をLast-Modifiedで返す", func(t *testing.T) {
+		stub := &countingStubItemUsecase{stubItemUsecase: newStubItemUsecase()}
+		stub.item.UpdatedAt = lastModifiedUpdatedAt
+
+		rec := serveList(stub, nil)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "Mon, 15 Jan 2024 10:30:00 GMT", rec.Header().Get("Last-Modified"))
+		assert.Equal(t, 1, stub.listed)
+	})
+
+	t.Run("正常系: 変更がない場合は一覧を取得せずに304", func(t *testing.T) {
+		stub := &countingStubItemUsecase{stubItemUsecase: newStubItemUsecase()}
+		stub.item.UpdatedAt = lastModifiedUpdatedAt
+
+		rec := serveList(stub, map[string]string{"If-Modified-Since": "Mon, 15 Jan 2024 10:30:00 GMT"})
+
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Equal(t, "Mon, 15 Jan 2024 10:30:00 GMT", rec.Header().Get("Last-Modified"))
+		assert.Empty(t, rec.Body.String())
+		assert.Equal(t, 0, stub.listed)
+	})
+
+	t.Run("正常系: 一覧にETagはないため、If-None-Matchを指定した場合は200", func(t *testing.T) {
+		stub := &countingStubItemUsecase{stubItemUsecase: newStubItemUsecase()}
+		stub.item.UpdatedAt = lastModifiedUpdatedAt
+
+		rec := serveList(stub, map[string]string{"If-None-Match": `"1"`, "If-Modified-Since": "Mon, 15 Jan 2024 10:30:00 GMT"})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 1, stub.listed)
+	})
+
+	t.Run("正常系: アイテムがない場合はLast-Modifiedを返さない", func(t *testing.T) {
+		stub := &countingStubItemUsecase{stubItemUsecase: newStubItemUsecase()}
+
+		rec := serveList(stub, map[string]string{"If-Modified-Since": "Mon, 15 Jan 2024 10:30:00 GMT"})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Last-Modified"))
+	})
+}
@@ -0,0 +1,317 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/openapi"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 一覧・集計・エクスポートで共通の絞り込み条件のクエリパラメーター
+func filterParameters() []*openapi.Parameter {
+	return []*openapi.Parameter{
+		openapi.Query("category", openapi.String(), "カテゴリー（表示名またはスラッグ）"),
+		openapi.Query("condition", openapi.String(), "状態"),
+		openapi.Query("status", openapi.Enum(entity.ItemStatusOwned, entity.ItemStatusListed, entity.ItemStatusSold), "所有状況"),
+		openapi.Query("purchase_location", openapi.String(), "購入店舗"),
+		openapi.Query("tag", openapi.ArrayOf(openapi.String()), "タグ。複数指定した場合はすべてのタグが付いたアイテム"),
+		openapi.Query("brand", openapi.String(), "ブランド"),
+		openapi.Query("q", openapi.String(), "名前・ブランド・メモのキーワード"),
+		openapi.Query("min_price", openapi.Integer(), "購入価格の下限"),
+		openapi.Query("max_price", openapi.Integer(), "購入価格の上限"),
+		openapi.Query("owner_id", openapi.Integer(), "所有するユーザーのID（管理者のみ）"),
+		openapi.Query("purchased_from", &openapi.Schema{Type: "string", Format: "date"}, "購入日の下限"),
+		openapi.Query("purchased_to", &openapi.Schema{Type: "string", Format: "date"}, "購入日の上限"),
+	}
+}
+
+func paginationParameters() []*openapi.Parameter {
+	return []*openapi.Parameter{
+		openapi.Query("limit", openapi.Integer(), "取得する件数"),
+		openapi.Query("offset", openapi.Integer(), "読み飛ばす件数"),
+	}
+}
+
+var (
+	itemID   = openapi.PathID("id", "アイテムのID")
+	fields   = openapi.Query("fields", openapi.String(), "レスポンスに含めるフィールド（カンマ区切り）。指定した場合は指定したフィールドのみを返す")
+	ifMatch  = openapi.RequestHeader("If-Match", "取得時のETag。指定した場合はボディのversionより優先する")
+	etag     = "アイテムのバージョンを表すETag"
+	location = "登録したアイテムのURL"
+
+	ifModifiedSince = openapi.RequestHeader("If-Modified-Since", "取得済みのLast-Modified。If-None-Matchを指定した場合は無視する")
+)
+
+// v1のアイテムのルートの操作
+func Operations() []openapi.Operation {
+	item := openapi.Of(entity.Item{})
+	operations := itemOperations(item, openapi.Of(usecase.ItemList{}), "")
+	return append(operations,
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/items", ID: "bulkDeleteItems", Summary: "IDを指定してアイテムを一括削除（論理削除、最大100件）", Tags: []string{"items"},
+			Parameters:  []*openapi.Parameter{openapi.Query("best_effort", openapi.Boolean(), "trueの場合は削除できたアイテムのみ削除する")},
+			RequestBody: openapi.JSONBody(openapi.Of(BulkDeleteRequest{})),
+			Responses: openapi.Responses{
+				http.StatusOK: openapi.JSON("アイテムごとの結果", openapi.Of(BulkDeleteResponse{})),
+				http.StatusNotFound: openapi.ProblemResponse("削除できないIDがあったため全件を取り消した",
+					map[string]*openapi.Schema{"results": openapi.ArrayOf(openapi.Of(usecase.BulkDeleteResult{}))}, openapi.Of(BulkDeleteErrorResponse{})),
+			},
+			Errors: []int{http.StatusBadRequest},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/items/export.csv", ID: "exportItemsCSV", Summary: "アイテムのCSVエクスポート", Tags: []string{"export"},
+			Parameters: append(filterParameters(),
+				openapi.Query("bom", openapi.Boolean(), "trueの場合はExcel向けにBOMを付ける"),
+				openapi.Query("fields", openapi.String(), "出力する列（カンマ区切り）。指定しない場合はすべての列"),
+			),
+			Responses: openapi.Responses{http.StatusOK: openapi.Content("CSV", "text/csv", openapi.String())},
+			Errors:    []int{http.StatusBadRequest},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/items/export.ndjson", ID: "exportItemsNDJSON", Summary: "アイテムのNDJSONエクスポート（1行に1件のJSON）", Tags: []string{"export"},
+			Parameters: filterParameters(),
+			Responses:  openapi.Responses{http.StatusOK: openapi.Content("1行に1件のアイテム", MIMEApplicationNDJSON, item)},
+			Errors:     []int{http.StatusBadRequest},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/items/export.xlsx", ID: "exportItemsXLSX", Summary: "アイテムのExcel（xlsx）エクスポート", Tags: []string{"export"},
+			Parameters: filterParameters(),
+			Responses:  openapi.Responses{http.StatusOK: openapi.Content("xlsx", MIMEApplicationXLSX, &openapi.Schema{Type: "string", Format: "binary"})},
+			Errors:     []int{http.StatusBadRequest},
+		},
+		openapi.Operation{
+			Method: http.MethodPost, Path: "/items/bulk", ID: "bulkCreateItems", Summary: "JSON配列でアイテムを一括登録", Tags: []string{"items"},
+			RequestBody: openapi.JSONBody(openapi.ArrayOf(openapi.Of(usecase.CreateItemInput{}))),
+			Responses: openapi.Responses{
+				http.StatusCreated: openapi.JSON("登録したアイテム（入力と同じ順序。Locationは返さない）", openapi.Of(BulkCreateResponse{})),
+				http.StatusUnprocessableEntity: openapi.ProblemResponse("バリデーションに失敗した要素",
+					map[string]*openapi.Schema{"errors": openapi.ArrayOf(openapi.Of(usecase.BulkItemError{}))}, openapi.Of(BulkErrorResponse{})),
+			},
+			Errors: []int{http.StatusBadRequest},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/items/batch", ID: "getItemsBatch", Summary: "IDを指定して複数のアイテムを取得", Tags: []string{"items"},
+			Parameters: []*openapi.Parameter{openapi.Query("ids", openapi.String(), "カンマ区切りのID（1,2,3）")},
+			Responses:  openapi.Responses{http.StatusOK: openapi.JSON("指定した順序のアイテム", openapi.Of(usecase.ItemBatch{}))},
+			Errors:     []int{http.StatusBadRequest},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/items/recent", ID: "getRecentItems", Summary: "最近登録・更新したアイテム（更新日時の新しい順）", Tags: []string{"items"},
+			Parameters: []*openapi.Parameter{
+				openapi.Query("window", openapi.String(), "期間（7d・12hのように日数か時間数で指定、最大90d）。指定しない場合は7d"),
+				openapi.Query("limit", openapi.Integer(), "取得する件数（最大100）"),
+			},
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("アイテムと、含めた理由（created・updated）", openapi.Of(usecase.RecentItemList{}))},
+			Errors:    []int{http.StatusBadRequest},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/items/{id}/history", ID: "getItemHistory", Summary: "アイテムの変更履歴（新しい順）", Tags: []string{"items"},
+			Parameters: append([]*openapi.Parameter{itemID}, paginationParameters()...),
+			Responses:  openapi.Responses{http.StatusOK: openapi.JSON("変更履歴", openapi.Of(usecase.ItemHistoryList{}))},
+			Errors:     []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/items/summary", ID: "getCategorySummary", Summary: "カテゴリー別集計", Tags: []string{"reports"},
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("カテゴリー別の件数と金額", openapi.Of(usecase.CategorySummary{}))},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/items/summary/brands", ID: "getBrandSummary", Summary: "ブランド別集計", Tags: []string{"reports"},
+			Parameters: []*openapi.Parameter{openapi.Query("limit", openapi.Integer(), "返すブランドの数")},
+			Responses:  openapi.Responses{http.StatusOK: openapi.JSON("ブランド別の件数と金額", openapi.Of(usecase.BrandSummary{}))},
+			Errors:     []int{http.StatusBadRequest},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/items/stats", ID: "getItemStats", Summary: "アイテムの統計（一覧と同じ絞り込み条件）", Tags: []string{"reports"},
+			Parameters: filterParameters(),
+			Responses:  openapi.Responses{http.StatusOK: openapi.JSON("統計", openapi.Of(usecase.ItemStats{}))},
+			Errors:     []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/items/report/profit", ID: "getProfitReport", Summary: "売却による利益の集計", Tags: []string{"reports"},
+			Parameters: []*openapi.Parameter{openapi.Query("year", openapi.Integer(), "集計する年")},
+			Responses:  openapi.Responses{http.StatusOK: openapi.JSON("利益の集計", openapi.Of(usecase.ProfitReport{}))},
+			Errors:     []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/items/report/locations", ID: "getLocationReport", Summary: "購入店舗ごとの支出の集計", Tags: []string{"reports"},
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("購入店舗ごとの支出", openapi.Of(usecase.LocationReport{}))},
+		},
+		openapi.Operation{
+			Method: http.MethodGet, Path: "/items/report/spend", ID: "getSpendReport", Summary: "月別・年別の支出の集計", Tags: []string{"reports"},
+			Parameters: []*openapi.Parameter{
+				openapi.Query("granularity", openapi.Enum("month", "year"), "集計の単位"),
+				openapi.Query("from", openapi.String(), "集計の開始（YYYY-MM または YYYY）"),
+				openapi.Query("to", openapi.String(), "集計の終了（YYYY-MM または YYYY）"),
+			},
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("期間ごとの支出", openapi.Of(usecase.SpendReport{}))},
+			Errors:    []int{http.StatusBadRequest},
+		},
+		openapi.Operation{
+			Method: http.MethodDelete, Path: "/admin/items/{id}", ID: "hardDeleteItem", Summary: "アイテムの物理削除（管理者用）", Tags: []string{"admin"},
+			Parameters: []*openapi.Parameter{itemID},
+			Responses:  openapi.Responses{http.StatusNoContent: openapi.NoContent("削除した")},
+			Errors:     []int{http.StatusForbidden, http.StatusNotFound},
+		},
+	)
+}
+
+// v2のアイテムのルートの操作。アイテムをitem、一覧をitemsとpaginationで包んで返す
+func EnvelopeOperations() []openapi.Operation {
+	item := openapi.Of(entity.Item{})
+	return itemOperations(openapi.Envelope(item), openapi.List(item), "V2")
+}
+
+// v1とv2で共通のアイテムの操作。itemは1件、listは一覧のレスポンスのスキーマ
+func itemOperations(item, list *openapi.Schema, idSuffix string) []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/items", ID: "listItems" + idSuffix, Summary: "アイテム一覧取得（ページネーション対応）", Tags: []string{"items"},
+			Parameters: append(append(filterParameters(),
+				openapi.Query("include_deleted", openapi.Boolean(), "論理削除したアイテムも含める"),
+				openapi.Query("sort", openapi.String(), "並び替えるフィールド。未指定時はcreated_atの降順"),
+				openapi.Query("order", openapi.Enum("asc", "desc"), "並び順。同値の場合は同じ向きのidで順序を確定する"),
+				openapi.Query("cursor", openapi.String(), "前のページのnext_cursor。offsetとは同時に指定できない"),
+				fields,
+				ifModifiedSince,
+			), paginationParameters()...),
+			Responses: openapi.Responses{
+				http.StatusOK:          openapi.JSON("アイテムの一覧", list).WithHeader("Last-Modified", "絞り込み条件に一致するアイテム（論理削除したアイテムを含む）の最新の更新日時"),
+				http.StatusNotModified: openapi.NoContent("If-Modified-Since の日時から変更されていない"),
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusUnprocessableEntity},
+		},
+		{
+			Method: http.MethodPost, Path: "/items", ID: "createItem" + idSuffix, Summary: "アイテム登録", Tags: []string{"items"},
+			Parameters: []*openapi.Parameter{
+				openapi.Query("force", openapi.Boolean(), "trueの場合は重複の確認を省略する"),
+				openapi.RequestHeader(idempotencyKeyHeader, "同じキーでの再送に最初のレスポンスを返す"),
+			},
+			RequestBody: openapi.JSONBody(openapi.Of(usecase.CreateItemInput{})),
+			Responses: openapi.Responses{http.StatusCreated: openapi.JSON("登録したアイテム", item).
+				WithHeader("ETag", etag).
+				WithHeader("Location", location).
+				WithHeader("Idempotent-Replayed", "再送に最初のレスポンスを返した場合はtrue").
+				WithHeader(warningHeader, "ブランドを別名から正式な名前に置き換えた場合の警告")},
+			Errors: []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity},
+		},
+		{
+			Method: http.MethodGet, Path: "/items/lookup", ID: "lookupItem" + idSuffix, Summary: "シリアル番号でアイテムを取得", Tags: []string{"items"},
+			Parameters: []*openapi.Parameter{openapi.Query("serial_number", openapi.String(), "シリアル番号"), fields},
+			Responses:  openapi.Responses{http.StatusOK: openapi.JSON("アイテム", item).WithHeader("ETag", etag)},
+			Errors:     []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodGet, Path: "/items/{id}", ID: "getItem" + idSuffix, Summary: "特定アイテム取得", Tags: []string{"items"},
+			Parameters: []*openapi.Parameter{itemID, fields, openapi.RequestHeader("If-None-Match", "取得済みのETag"), ifModifiedSince},
+			Responses: openapi.Responses{
+				http.StatusOK:          openapi.JSON("アイテム", item).WithHeader("ETag", etag).WithHeader("Last-Modified", "アイテムの更新日時（秒未満は切り捨て）"),
+				http.StatusNotModified: openapi.NoContent("If-None-Match のETag、またはIf-Modified-Since の日時から変更されていない"),
+			},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodPut, Path: "/items/{id}", ID: "replaceItem" + idSuffix, Summary: "アイテムの全置換", Tags: []string{"items"},
+			Description: "登録と同じフィールドをすべて置き換え、省略した任意のフィールドは未設定に戻す。versionかIf-Matchヘッダーで取得時のバージョンを指定する",
+			Parameters:  []*openapi.Parameter{itemID, ifMatch},
+			RequestBody: openapi.JSONBody(openapi.Of(usecase.ReplaceItemInput{})),
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("更新したアイテム", item).
+				WithHeader("ETag", etag).
+				WithHeader(warningHeader, "ブランドを別名から正式な名前に置き換えた場合の警告")},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusUnprocessableEntity, http.StatusPreconditionRequired},
+		},
+		{
+			Method: http.MethodPatch, Path: "/items/{id}", ID: "updateItem" + idSuffix, Summary: "アイテムの部分更新", Tags: []string{"items"},
+			Description: "指定したフィールドのみ変更する。versionかIf-Matchヘッダーで取得時のバージョンを指定する",
+			Parameters:  []*openapi.Parameter{itemID, ifMatch},
+			RequestBody: openapi.JSONBody(openapi.Of(usecase.UpdateItemInput{})),
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("更新したアイテム", item).
+				WithHeader("ETag", etag).
+				WithHeader(warningHeader, "ブランドを別名から正式な名前に置き換えた場合の警告")},
+			Errors: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusUnprocessableEntity, http.StatusPreconditionRequired},
+		},
+		{
+			Method: http.MethodDelete, Path: "/items/{id}", ID: "deleteItem" + idSuffix, Summary: "アイテム削除（論理削除）", Tags: []string{"items"},
+			Parameters: []*openapi.Parameter{itemID, ifMatch},
+			Responses:  openapi.Responses{http.StatusNoContent: openapi.NoContent("削除した")},
+			Errors:     []int{http.StatusBadRequest, http.StatusNotFound, http.StatusPreconditionFailed, http.StatusPreconditionRequired},
+		},
+		{
+			Method: http.MethodPost, Path: "/items/{id}/restore", ID: "restoreItem" + idSuffix, Summary: "論理削除したアイテムの復元", Tags: []string{"items"},
+			Parameters: []*openapi.Parameter{itemID},
+			Responses:  openapi.Responses{http.StatusOK: openapi.JSON("復元したアイテム", item)},
+			Errors:     []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodPost, Path: "/items/{id}/clone", ID: "cloneItem" + idSuffix, Summary: "既存のアイテムを複製して登録（指定したフィールドは置き換え）", Tags: []string{"items"},
+			Parameters:  []*openapi.Parameter{itemID},
+			RequestBody: openapi.JSONBody(openapi.Of(usecase.CloneItemInput{})),
+			Responses:   openapi.Responses{http.StatusCreated: openapi.JSON("登録したアイテム", item).WithHeader("ETag", etag).WithHeader("Location", location)},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity},
+		},
+		{
+			Method: http.MethodPost, Path: "/items/{id}/status", ID: "changeItemStatus" + idSuffix, Summary: "所有状況の変更（owned, listed, sold）", Tags: []string{"items"},
+			Parameters:  []*openapi.Parameter{itemID, ifMatch},
+			RequestBody: openapi.JSONBody(openapi.Of(usecase.ChangeItemStatusInput{})),
+			Responses:   openapi.Responses{http.StatusOK: openapi.JSON("変更したアイテム", item).WithHeader("ETag", etag)},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusUnprocessableEntity, http.StatusPreconditionRequired},
+		},
+		{
+			Method: http.MethodPost, Path: "/items/{id}/sell", ID: "markItemSold" + idSuffix, Summary: "売却の記録（売却価格・売却日）", Tags: []string{"items"},
+			Parameters:  []*openapi.Parameter{itemID, ifMatch},
+			RequestBody: openapi.JSONBody(openapi.Of(usecase.MarkItemSoldInput{})),
+			Responses:   openapi.Responses{http.StatusOK: openapi.JSON("売却を記録したアイテム", item).WithHeader("ETag", etag)},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed, http.StatusUnprocessableEntity, http.StatusPreconditionRequired},
+		},
+	}
+}
+
+// アイテムの画像のルートの操作
+func ImageOperations() []openapi.Operation {
+	images := openapi.ArrayOf(openapi.Of(entity.ItemImage{}))
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/items/{id}/images", ID: "listItemImages", Summary: "アイテム画像の一覧（表示順）", Tags: []string{"images"},
+			Parameters: []*openapi.Parameter{itemID},
+			Responses:  openapi.Responses{http.StatusOK: openapi.JSON("画像の一覧", images)},
+			Errors:     []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodPost, Path: "/items/{id}/images", ID: "addItemImage", Summary: "アイテム画像の追加（JPEG/PNG）", Tags: []string{"images"},
+			Parameters:  []*openapi.Parameter{itemID},
+			RequestBody: openapi.FileUpload("image", "JPEGまたはPNGの画像"),
+			Responses:   openapi.Responses{http.StatusCreated: openapi.JSON("追加した画像", openapi.Of(entity.ItemImage{}))},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity},
+		},
+		{
+			Method: http.MethodPut, Path: "/items/{id}/images/order", ID: "reorderItemImages", Summary: "アイテム画像の並べ替え", Tags: []string{"images"},
+			Parameters:  []*openapi.Parameter{itemID},
+			RequestBody: openapi.JSONBody(openapi.Of(ReorderImagesRequest{})),
+			Responses:   openapi.Responses{http.StatusOK: openapi.JSON("並べ替えた画像の一覧", images)},
+			Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+		},
+		{
+			Method: http.MethodDelete, Path: "/items/{id}/images/{imageId}", ID: "deleteItemImage", Summary: "アイテム画像の削除", Tags: []string{"images"},
+			Parameters: []*openapi.Parameter{itemID, openapi.PathID("imageId", "画像のID")},
+			Responses:  openapi.Responses{http.StatusNoContent: openapi.NoContent("削除した")},
+			Errors:     []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+	}
+}
+
+// アイテムのQRコードのルートの操作
+func QRCodeOperations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/items/{id}/qr", ID: "getItemQRCode", Summary: "アイテムのURLを符号化したQRコード（PNG）", Tags: []string{"items"},
+			Parameters: []*openapi.Parameter{
+				itemID,
+				openapi.Query("size", openapi.Integer(), fmt.Sprintf("画像の幅（ピクセル、%d〜%d、デフォルト%d）", MinQRCodeSize, MaxQRCodeSize, DefaultQRCodeSize)),
+				openapi.Query("label", openapi.Boolean(), "trueの場合はQRコードの下にアイテムの名前とブランドを書き込んだ印刷用のラベルにする"),
+			},
+			Responses: openapi.Responses{http.StatusOK: openapi.Content("QRコードのPNG", "image/png", &openapi.Schema{Type: "string", Format: "binary"})},
+			Errors:    []int{http.StatusBadRequest, http.StatusNotFound},
+		},
+	}
+}
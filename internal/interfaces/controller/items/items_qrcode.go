@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/skip2/go-qrcode"
+
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/usecase"
+)
+
+// QRコードの画像の幅（ピクセル）
+const (
+	DefaultQRCodeSize = 256
+	MinQRCodeSize     = 128
+	MaxQRCodeSize     = 1024
+)
+
+// 生成したPNGをキャッシュする期間。キーにアイテムのバージョンを含めるため、論理削除や更新の前のPNGを使い続けることはない
+const qrCodeCacheTTL = 24 * time.Hour
+
+type ItemQRCodeHandler struct {
+	itemUsecase usecase.ItemUsecase
+	cache       usecase.Cache // 生成したPNG
+	baseURL     string        // QRコードに符号化するURLのスキームとホスト（末尾の/を除く）
+}
+
+// baseURLはAPIを公開するURLのスキームとホスト（https://api.example.comなど）。
+// リクエストのHostヘッダーはクライアントが自由に指定できるため、QRコードのURLには使わない
+func NewItemQRCodeHandler(itemUsecase usecase.ItemUsecase, cache usecase.Cache, baseURL string) *ItemQRCodeHandler {
+	return &ItemQRCodeHandler{
+		itemUsecase: itemUsecase,
+		cache:       cache,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// GET /items/{id}/qr
+// アイテムのURLを符号化したQRコードのPNGを返す。label=trueの場合は、QRコードの下にアイテムの名前とブランドを書き込む。
+// 存在しないアイテムのQRコードは作らずに404を返すため、キャッシュがあってもアイテムは毎回取得する
+func (h *ItemQRCodeHandler) GetQRCode(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid item ID")
+	}
+
+	size := DefaultQRCodeSize
+	if sizeStr := c.QueryParam("size"); sizeStr != "" {
+		v, err := strconv.Atoi(sizeStr)
+		if err != nil || v < MinQRCodeSize || v > MaxQRCodeSize {
+			return httperror.BadRequest(c, "validation failed", fmt.Sprintf("size must be an integer between %d and %d", MinQRCodeSize, MaxQRCodeSize))
+		}
+		size = v
+	}
+
+	label := false
+	if v := c.QueryParam("label"); v != "" {
+		label, err = strconv.ParseBool(v)
+		if err != nil {
+			return httperror.BadRequest(c, "validation failed", "label must be true or false")
+		}
+	}
+
+	ctx := c.Request().Context()
+	item, err := h.itemUsecase.GetItemByID(ctx, id)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve item")
+	}
+
+	key := fmt.Sprintf("qrcode:%d:%d:%d:%t", id, item.Version, size, label)
+	if cached, ok := h.cache.Get(ctx, key); ok {
+		return c.Blob(http.StatusOK, "image/png", cached)
+	}
+
+	content := h.baseURL + itemLocation(c, id)
+	var png []byte
+	if label {
+		png, err = encodeQRCodeLabel(content, size, []string{item.Name, item.Brand})
+	} else {
+		png, err = qrcode.Encode(content, qrcode.Medium, size)
+	}
+	if err != nil {
+		return httperror.Respond(c, err, "failed to generate QR code")
+	}
+	h.cache.Set(ctx, key, png, qrCodeCacheTTL)
+
+	return c.Blob(http.StatusOK, "image/png", png)
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+
+	"github.com/hajimehoshi/bitmapfont/v3"
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// ラベルの文字を拡大する前の、QRコードの幅あたりの大きさ（ピクセル）。
+// 幅256ピクセルでは2倍に拡大し、全角で10文字程度が1行に収まる
+const qrLabelUnit = 128
+
+// 幅に収まらない行の末尾に付ける省略記号
+const qrLabelEllipsis = "…"
+
+// contentを符号化したQRコードの下に、linesを1行ずつ中央揃えで書き込んだ印刷用のラベルのPNG。
+// 文字は日本語を含む12ピクセルのビットマップフォントで書き込み、QRコードの幅に合わせて整数倍に拡大する。
+// 幅に収まらない行は末尾を…にする
+func encodeQRCodeLabel(content string, size int, lines []string) ([]byte, error) {
+	code, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+	qr := code.Image(size)
+
+	// 拡大する前の大きさで文字を書き込む
+	face := bitmapfont.Face
+	scale := max(1, size/qrLabelUnit)
+	lineHeight := face.Metrics().Height.Ceil()
+	text := image.NewGray(image.Rect(0, 0, size/scale, len(lines)*lineHeight+lineHeight/2))
+	draw.Draw(text, text.Bounds(), image.White, image.Point{}, draw.Src)
+	drawer := &font.Drawer{Dst: text, Src: image.Black, Face: face}
+	// 左右に4ピクセルずつ余白を設ける
+	maxWidth := fixed.I(text.Bounds().Dx() - 8)
+	for i, line := range lines {
+		line = truncateToWidth(face, line, maxWidth)
+		drawer.Dot = fixed.Point26_6{
+			X: (fixed.I(text.Bounds().Dx()) - font.MeasureString(face, line)) / 2,
+			Y: fixed.I(i*lineHeight) + face.Metrics().Ascent,
+		}
+		drawer.DrawString(line)
+	}
+
+	label := image.NewGray(image.Rect(0, 0, size, size+text.Bounds().Dy()*scale))
+	draw.Draw(label, label.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(label, qr.Bounds(), qr, image.Point{}, draw.Src)
+	left := (size - text.Bounds().Dx()*scale) / 2
+	textArea := image.Rect(left, size, left+text.Bounds().Dx()*scale, label.Bounds().Dy())
+	draw.NearestNeighbor.Scale(label, textArea, text, text.Bounds(), draw.Src, nil)
+
+	// 白黒の2色のみのため、パレットの画像にして小さくする
+	paletted := image.NewPaletted(label.Bounds(), color.Palette{color.White, color.Black})
+	draw.Draw(paletted, paletted.Bounds(), label, image.Point{}, draw.Src)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, paletted); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// widthに収まるようsの末尾を省略する
+func truncateToWidth(face font.Face, s string, width fixed.Int26_6) string {
+	if font.MeasureString(face, s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && font.MeasureString(face, string(runes)+qrLabelEllipsis) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + qrLabelEllipsis
+}
@@ -0,0 +1,212 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/bitmapfont/v3"
+	"github.com/labstack/echo/v4"
+	"github.com/makiuchi-d/gozxing"
+	gozxingQRCode "github.com/makiuchi-d/gozxing/qrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/memory"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 期限を考慮しないmapのCache。値を読み込めた回数と保存した回数を数える
+type countingCache struct {
+	values     map[string][]byte
+	hits, sets int
+}
+
+func newCountingCache() *countingCache {
+	return &countingCache{values: make(map[string][]byte)}
+}
+
+func (c *countingCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	value, ok := c.values[key]
+	if ok {
+		c.hits++
+	}
+	return value, ok
+}
+
+func (c *countingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	c.sets++
+	c.values[key] = value
+}
+
+func (c *countingCache) Delete(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		delete(c.values, key)
+	}
+}
+
+func (c *countingCache) Generation(ctx context.Context, name string) int64 { return 0 }
+
+func (c *countingCache) Invalidate(ctx context.Context, names []string, keys ...string) {
+	c.Delete(ctx, keys...)
+}
+
+func TestItemQRCodeHandler_GetQRCode(t *testing.T) {
+	store := memory.NewStore(&entity.Category{Slug: "watch", Name: "時計"})
+	itemUsecase := usecase.NewItemUsecase(&memory.ItemRepository{Store: store}, &memory.CategoryRepository{Store: store}, nil, nil, nil, nil)
+	ctx := context.Background()
+	item, err := itemUsecase.CreateItem(ctx, usecase.CreateItemInput{Name: "Daytona", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"})
+	require.NoError(t, err)
+
+	qrCache := newCountingCache()
+	h := NewItemQRCodeHandler(itemUsecase, qrCache, "https://api.example.com/")
+	e := echo.New()
+	e.GET("/api/v1/items/:id/qr", h.GetQRCode)
+	serve := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+	// PNGをQRコードとして読み取り、画像の大きさと符号化された文字列を返す
+	decode := func(rec *httptest.ResponseRecorder) (width, height int, text string) {
+		t.Helper()
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.Equal(t, "image/png", rec.Header().Get(echo.HeaderContentType))
+		img, err := png.Decode(rec.Body)
+		require.NoError(t, err)
+		bmp, err := gozxing.NewBinaryBitmapFromImage(img)
+		require.NoError(t, err)
+		result, err := gozxingQRCode.NewQRCodeReader().Decode(bmp, nil)
+		require.NoError(t, err)
+		return img.Bounds().Dx(), img.Bounds().Dy(), result.GetText()
+	}
+
+	t.Run("正常系: 幅を指定しない場合は256ピクセルの正方形で、設定した公開URLのアイテムのURLを符号化する", func(t *testing.T) {
+		width, height, text := decode(serve("/api/v1/items/1/qr"))
+		assert.Equal(t, DefaultQRCodeSize, width)
+		assert.Equal(t, DefaultQRCodeSize, height)
+		assert.Equal(t, "https://api.example.com/api/v1/items/1", text)
+	})
+
+	t.Run("正常系: 指定した幅にする", func(t *testing.T) {
+		width, height, text := decode(serve("/api/v1/items/1/qr?size=512"))
+		assert.Equal(t, 512, width)
+		assert.Equal(t, 512, height)
+		assert.Equal(t, "https://api.example.com/api/v1/items/1", text)
+	})
+
+	t.Run("正常系: HostヘッダーはURLにもキャッシュのキーにも使わない", func(t *testing.T) {
+		*qrCache = *newCountingCache()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/items/1/qr", nil)
+		req.Host = "attacker.example"
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		_, _, text := decode(rec)
+		assert.Equal(t, "https://api.example.com/api/v1/items/1", text)
+
+		decode(serve("/api/v1/items/1/qr"))
+		assert.Equal(t, 1, qrCache.hits)
+		assert.Equal(t, 1, qrCache.sets)
+	})
+
+	t.Run("正常系: 同じアイテム・幅の2回目はキャッシュから返し、更新後は作り直す", func(t *testing.T) {
+		*qrCache = *newCountingCache()
+		first := serve("/api/v1/items/1/qr?size=300")
+		second := serve("/api/v1/items/1/qr?size=300")
+		require.Equal(t, http.StatusOK, second.Code)
+		assert.Equal(t, first.Body.Bytes(), second.Body.Bytes())
+		assert.Equal(t, 1, qrCache.hits)
+		assert.Equal(t, 1, qrCache.sets)
+
+		// 幅が異なる場合は別に作る
+		decode(serve("/api/v1/items/1/qr?size=301"))
+		assert.Equal(t, 2, qrCache.sets)
+
+		// 更新するとバージョンが変わり、キャッシュしたPNGは使わない
+		name := "Daytona 116500LN"
+		_, err := itemUsecase.UpdateItem(ctx, item.ID, usecase.UpdateItemInput{Name: &name, Version: &item.Version})
+		require.NoError(t, err)
+		decode(serve("/api/v1/items/1/qr?size=300"))
+		assert.Equal(t, 1, qrCache.hits)
+		assert.Equal(t, 3, qrCache.sets)
+	})
+
+	t.Run("正常系: label=trueの場合はQRコードの下に名前とブランドを書き込み、ラベルなしとは別にキャッシュする", func(t *testing.T) {
+		*qrCache = *newCountingCache()
+		plain := serve("/api/v1/items/1/qr").Body.Bytes()
+		rec := serve("/api/v1/items/1/qr?label=true")
+		body := bytes.Clone(rec.Body.Bytes())
+		img, err := png.Decode(bytes.NewReader(body))
+		require.NoError(t, err)
+
+		width, height, text := decode(rec)
+		assert.Equal(t, DefaultQRCodeSize, width)
+		assert.Greater(t, height, DefaultQRCodeSize)
+		assert.Equal(t, "https://api.example.com/api/v1/items/1", text)
+		// QRコードの下の余白に文字を書き込む
+		assert.True(t, hasDarkPixel(img, image.Rect(0, DefaultQRCodeSize, width, height)))
+		assert.NotEqual(t, plain, body)
+		assert.Equal(t, 2, qrCache.sets)
+
+		assert.Equal(t, body, serve("/api/v1/items/1/qr?label=true").Body.Bytes())
+		assert.Equal(t, 1, qrCache.hits)
+	})
+
+	t.Run("異常系: 存在しないアイテムはQRコードを作らずに404", func(t *testing.T) {
+		*qrCache = *newCountingCache()
+		rec := serve("/api/v1/items/999/qr")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, 0, qrCache.sets)
+	})
+
+	invalid := []struct {
+		name  string
+		query string
+	}{
+		{"異常系: 下限より小さい幅", "size=127"},
+		{"異常系: 上限より大きい幅", "size=1025"},
+		{"異常系: 整数でない幅", "size=large"},
+		{"異常系: 真偽値でないlabel", "label=yes"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve("/api/v1/items/1/qr?" + tt.query)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+
+	t.Run("異常系: 不正なID", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve("/api/v1/items/abc/qr").Code)
+	})
+}
+
+// rectの範囲に黒に近いピクセルがあるかどうか
+func hasDarkPixel(img image.Image, rect image.Rectangle) bool {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			if r, g, b, _ := img.At(x, y).RGBA(); r+g+b < 0x8000 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestTruncateToWidth(t *testing.T) {
+	face := bitmapfont.Face
+	assert.Equal(t, "ROLEX", truncateToWidth(face, "ROLEX", font.MeasureString(face, "ROLEX")))
+
+	// 全角の文字も幅で数え、末尾を…にする
+	truncated := truncateToWidth(face, "ロレックス デイトナ 116500LN", fixed.I(60))
+	assert.True(t, strings.HasSuffix(truncated, qrLabelEllipsis))
+	assert.LessOrEqual(t, font.MeasureString(face, truncated), fixed.I(60))
+}
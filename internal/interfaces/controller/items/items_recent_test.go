@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/memory"
+	"Aicon-assignment/internal/interfaces/openapi/openapitest"
+	"Aicon-assignment/internal/testutil"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemHandler_GetRecentItems(t *testing.T) {
+	clock := testutil.NewFixedClock(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+	t.Cleanup(entity.SetClock(clock))
+
+	store := memory.NewStore(&entity.Category{Slug: "watch", Name: "時計"})
+	itemUsecase := usecase.NewItemUsecase(&memory.ItemRepository{Store: store}, &memory.CategoryRepository{Store: store}, nil, nil, nil, nil)
+	h := NewItemHandler(itemUsecase, usecase.NewBrandUsecase(nil, entity.BrandValidationOff), false)
+
+	ctx := context.Background()
+	create := func(name string) *entity.Item {
+		item, err := itemUsecase.CreateItem(ctx, usecase.CreateItemInput{Name: name, Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"})
+		require.NoError(t, err)
+		return item
+	}
+	// 古いアイテムは10日前に登録して23時間前に更新し、新しいアイテムは1日前に登録する。未更新のアイテムは期間外
+	updated := create("デイトナ")
+	unchanged := create("サブマリーナ")
+	clock.Advance(10 * 24 * time.Hour)
+	added := create("GMTマスター")
+	clock.Advance(time.Hour)
+	name := "デイトナ 116500LN"
+	_, err := itemUsecase.UpdateItem(ctx, updated.ID, usecase.UpdateItemInput{Name: &name, Version: &updated.Version})
+	require.NoError(t, err)
+	clock.Advance(23 * time.Hour)
+
+	serve := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/items/recent?"+query, nil), rec)
+		require.NoError(t, h.GetRecentItems(c))
+		return rec
+	}
+	events := func(rec *httptest.ResponseRecorder) map[int64]string {
+		var body usecase.RecentItemList
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		events := make(map[int64]string, len(body.Items))
+		for _, recent := range body.Items {
+			events[recent.Item.ID] = recent.Event
+		}
+		return events
+	}
+
+	t.Run("正常系: 期間を指定しない場合は7日で、更新日時の新しい順に登録・更新の別を付けて返す", func(t *testing.T) {
+		rec := serve("")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		openapitest.AssertExchange(t, openapitest.Document("", Operations()...), http.MethodGet, "/items/recent", "", rec)
+		var body usecase.RecentItemList
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, entity.Now().Add(-7*24*time.Hour), body.Since)
+		require.Len(t, body.Items, 2)
+		assert.Equal(t, updated.ID, body.Items[0].Item.ID)
+		assert.Equal(t, entity.RecentEventUpdated, body.Items[0].Event)
+		assert.Equal(t, added.ID, body.Items[1].Item.ID)
+		assert.Equal(t, entity.RecentEventCreated, body.Items[1].Event)
+	})
+
+	t.Run("正常系: 期間を時間数で指定する", func(t *testing.T) {
+		assert.Equal(t, map[int64]string{updated.ID: entity.RecentEventUpdated}, events(serve("window=23h")))
+		assert.Empty(t, events(serve("window=22h")))
+	})
+
+	t.Run("正常系: 期間を広げると、期間内に登録したアイテムは更新していても登録として返す", func(t *testing.T) {
+		expected := map[int64]string{updated.ID: entity.RecentEventCreated, unchanged.ID: entity.RecentEventCreated, added.ID: entity.RecentEventCreated}
+		assert.Equal(t, expected, events(serve("window=11d")))
+	})
+
+	t.Run("正常系: limitで件数を制限する", func(t *testing.T) {
+		assert.Equal(t, map[int64]string{updated.ID: entity.RecentEventUpdated}, events(serve("limit=1")))
+	})
+
+	for _, query := range []string{"window=abc", "window=7", "window=2w", "window=0d", "window=91d", "window=2161h", "limit=0", "limit=x"} {
+		t.Run("異常系: "+query, func(t *testing.T) {
+			rec := serve(query)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+
+	"github.com/labstack/echo/v4"
+)
+
+// リクエストボディの最大サイズ（バイト）のデフォルト値
+const DefaultMaxBodySize int64 = 1 << 20
+
+// Decodeで読み込むリクエストボディの最大サイズ（バイト）。サーバーの起動時に設定から反映する
+var MaxBodySize = DefaultMaxBodySize
+
+// JSONのリクエストボディをvに読み込む。最大サイズはMaxBodySize
+func Decode(c echo.Context, v interface{}) error {
+	return DecodeWithLimit(c, v, MaxBodySize)
+}
+
+// JSONのリクエストボディをvに読み込む。
+// フィールドの型の誤りはフィールドごとのバリデーションエラー、
+// 未知のフィールドと、JSONの値の後に続く余分なデータはhttperror.ErrMalformedBody、
+// maxBytesを超えるボディはhttperror.ErrBodyTooLargeとする。ボディが空の場合はvを変更しない
+func DecodeWithLimit(c echo.Context, v interface{}, maxBytes int64) error {
+	req := c.Request()
+	if req.ContentLength > maxBytes {
+		return bodyTooLarge(maxBytes)
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(c.Response(), req.Body, maxBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			// ミドルウェアでより小さい上限を設定している場合は、その上限を返す
+			return bodyTooLarge(min(maxBytes, maxBytesErr.Limit))
+		}
+		return fmt.Errorf("%w: failed to read request body", httperror.ErrMalformedBody)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(req.Header.Get(echo.HeaderContentType)); err != nil || mediaType != echo.MIMEApplicationJSON {
+		return fmt.Errorf("%w: Content-Type must be %s", httperror.ErrMalformedBody, echo.MIMEApplicationJSON)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		// フィールドの型の誤りと、UnmarshalJSONでのバリデーションエラーは、フィールドごとのバリデーションエラーとして返す
+		var validationErrors domainErrors.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return validationErrors
+		}
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			name := typeName(typeErr.Type)
+			return domainErrors.NewValidationErrors(domainErrors.NewRuleError(typeErr.Field, domainErrors.CodeInvalidType, fmt.Sprintf("%s must be of type %s", typeErr.Field, name), map[string]interface{}{"type": name}))
+		}
+		return fmt.Errorf("%w: %s", httperror.ErrMalformedBody, describe(err))
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: request body must contain a single JSON value", httperror.ErrMalformedBody)
+	}
+
+	return nil
+}
+
+func bodyTooLarge(maxBytes int64) error {
+	return fmt.Errorf("%w: request body must be %d bytes or less", httperror.ErrBodyTooLarge, maxBytes)
+}
+
+// デコードのエラーを、原因のフィールドがわかるメッセージにする
+func describe(err error) string {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return fmt.Sprintf("invalid JSON at offset %d", syntaxErr.Offset)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return "unexpected end of JSON input"
+	case errors.As(err, &typeErr):
+		return fmt.Sprintf("request body must not be a JSON %s", typeErr.Value)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		return "unknown field " + strings.TrimPrefix(err.Error(), "json: unknown field ")
+	default:
+		return err.Error()
+	}
+}
+
+// バリデーションエラーに使う、Goの型に対応するJSONの型の名前
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return "value"
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
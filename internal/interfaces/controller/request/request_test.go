@@ -0,0 +1,149 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+)
+
+type testInput struct {
+	Name          string `json:"name"`
+	PurchasePrice int64  `json:"purchase_price"`
+}
+
+func newContext(body, contentType string) echo.Context {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set(echo.HeaderContentType, contentType)
+	}
+	return e.NewContext(req, httptest.NewRecorder())
+}
+
+func TestDecodeWithLimit(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		expected    testInput
+		expectedErr error
+		expectedMsg string
+	}{
+		{
+			name:        "正常系: JSONオブジェクト",
+			body:        `{"name": "ロレックス", "purchase_price": 1500000}`,
+			contentType: echo.MIMEApplicationJSON,
+			expected:    testInput{Name: "ロレックス", PurchasePrice: 1500000},
+		},
+		{
+			name:        "正常系: charset付きのContent-Type",
+			body:        `{"name": "ロレックス"}` + "\n",
+			contentType: echo.MIMEApplicationJSONCharsetUTF8,
+			expected:    testInput{Name: "ロレックス"},
+		},
+		{
+			name:     "正常系: 空のボディ",
+			body:     "",
+			expected: testInput{},
+		},
+		{
+			name:        "異常系: 未知のフィールド",
+			body:        `{"name": "ロレックス", "purchace_price": 1500000}`,
+			contentType: echo.MIMEApplicationJSON,
+			expectedErr: httperror.ErrMalformedBody,
+			expectedMsg: `malformed request body: unknown field "purchace_price"`,
+		},
+		{
+			name:        "異常系: JSONの後に余分なデータがある",
+			body:        `{"name": "ロレックス"} {"name": "オメガ"}`,
+			contentType: echo.MIMEApplicationJSON,
+			expectedErr: httperror.ErrMalformedBody,
+			expectedMsg: "malformed request body: request body must contain a single JSON value",
+		},
+		{
+			name:        "異常系: JSONの後に文字列がある",
+			body:        `{"name": "ロレックス"}garbage`,
+			contentType: echo.MIMEApplicationJSON,
+			expectedErr: httperror.ErrMalformedBody,
+			expectedMsg: "malformed request body: request body must contain a single JSON value",
+		},
+		{
+			name:        "異常系: 不正なJSON",
+			body:        `{"name": }`,
+			contentType: echo.MIMEApplicationJSON,
+			expectedErr: httperror.ErrMalformedBody,
+			expectedMsg: "malformed request body: invalid JSON at offset 10",
+		},
+		{
+			name:        "異常系: 途中で終わっているJSON",
+			body:        `{"name": "ロレックス"`,
+			contentType: echo.MIMEApplicationJSON,
+			expectedErr: httperror.ErrMalformedBody,
+			expectedMsg: "malformed request body: unexpected end of JSON input",
+		},
+		{
+			name:        "異常系: フィールドの型の誤り",
+			body:        `{"purchase_price": "abc"}`,
+			contentType: echo.MIMEApplicationJSON,
+			expectedErr: domainErrors.ErrValidation,
+			expectedMsg: "purchase_price must be of type integer",
+		},
+		{
+			name:        "異常系: ボディがオブジェクトではない",
+			body:        `[1, 2]`,
+			contentType: echo.MIMEApplicationJSON,
+			expectedErr: httperror.ErrMalformedBody,
+			expectedMsg: "malformed request body: request body must not be a JSON array",
+		},
+		{
+			name:        "異常系: JSON以外のContent-Type",
+			body:        `name=ロレックス`,
+			contentType: echo.MIMEApplicationForm,
+			expectedErr: httperror.ErrMalformedBody,
+			expectedMsg: "malformed request body: Content-Type must be application/json",
+		},
+		{
+			name:        "異常系: 最大サイズを超える",
+			body:        `{"name": "` + strings.Repeat("a", 100) + `"}`,
+			contentType: echo.MIMEApplicationJSON,
+			expectedErr: httperror.ErrBodyTooLarge,
+			expectedMsg: "request body too large: request body must be 64 bytes or less",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var input testInput
+			err := DecodeWithLimit(newContext(tt.body, tt.contentType), &input, 64)
+
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tt.expectedErr))
+				assert.EqualError(t, err, tt.expectedMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, input)
+		})
+	}
+}
+
+func TestDecodeWithLimit_ChunkedBody(t *testing.T) {
+	// Content-Lengthがない場合も、読み込んだサイズで判定する
+	c := newContext(`{"name": "`+strings.Repeat("a", 100)+`"}`, echo.MIMEApplicationJSON)
+	c.Request().ContentLength = -1
+
+	var input testInput
+	err := DecodeWithLimit(c, &input, 64)
+
+	assert.True(t, errors.Is(err, httperror.ErrBodyTooLarge))
+}
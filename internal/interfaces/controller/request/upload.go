@@ -0,0 +1,68 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// multipart/form-dataのnameのファイルを開く。ファイルが小さければメモリ上に、大きければ一時ファイルに読み込む。
+// リクエストボディがBodyLimitのミドルウェアの上限を超えた場合はdomainErrors.ErrFileTooLarge、
+// ファイルがない場合はhttp.ErrMissingFileなどのエラーを返す
+func FormFile(c echo.Context, name string) (multipart.File, error) {
+	fileHeader, err := c.FormFile(name)
+	if err != nil {
+		return nil, uploadError(err)
+	}
+	return fileHeader.Open()
+}
+
+// multipart/form-dataのnameのファイルを、メモリや一時ファイルに読み込まずにリクエストボディから読み込むio.Reader。
+// name より前の項目は読み飛ばす。読み込み中にリクエストボディが上限を超えた場合はdomainErrors.ErrFileTooLargeを返す。
+// ファイルがない場合はhttp.ErrMissingFileを返す
+func MultipartFile(c echo.Context, name string) (io.Reader, error) {
+	reader, err := c.Request().MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, http.ErrMissingFile
+		}
+		if err != nil {
+			return nil, uploadError(err)
+		}
+		if part.FormName() == name && part.FileName() != "" {
+			return &uploadReader{r: part}, nil
+		}
+	}
+}
+
+// リクエストボディの上限の超過をdomainErrors.ErrFileTooLargeにするio.Reader
+type uploadReader struct {
+	r io.Reader
+}
+
+func (u *uploadReader) Read(p []byte) (int, error) {
+	n, err := u.r.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = uploadError(err)
+	}
+	return n, err
+}
+
+// http.MaxBytesReaderの上限を超えた場合はdomainErrors.ErrFileTooLargeにする
+func uploadError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return fmt.Errorf("%w: request body must be %d bytes or less", domainErrors.ErrFileTooLarge, maxBytesErr.Limit)
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+package request
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 説明の項目の後にnameのファイルを添付したmultipart/form-dataのリクエスト。
+// limitが0より大きい場合は、BodyLimitのミドルウェアと同様にボディをhttp.MaxBytesReaderで包む
+func newUploadContext(t *testing.T, name, content string, limit int64) echo.Context {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	require.NoError(t, writer.WriteField("description", "items"))
+	part, err := writer.CreateFormFile(name, "upload.bin")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	if limit > 0 {
+		req.Body = http.MaxBytesReader(rec, req.Body, limit)
+	}
+	return echo.New().NewContext(req, rec)
+}
+
+func TestFormFile(t *testing.T) {
+	file, err := FormFile(newUploadContext(t, "image", "png", 0), "image")
+	require.NoError(t, err)
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, "png", string(data))
+
+	_, err = FormFile(newUploadContext(t, "file", "png", 0), "image")
+	assert.ErrorIs(t, err, http.ErrMissingFile)
+
+	// リクエストボディの上限を超えた場合は413にする
+	_, err = FormFile(newUploadContext(t, "image", strings.Repeat("a", 1024), 512), "image")
+	assert.ErrorIs(t, err, domainErrors.ErrFileTooLarge)
+	assert.Contains(t, err.Error(), "request body must be 512 bytes or less")
+}
+
+func TestMultipartFile(t *testing.T) {
+	r, err := MultipartFile(newUploadContext(t, "file", "name\n", 0), "file")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "name\n", string(data))
+
+	_, err = MultipartFile(newUploadContext(t, "image", "name\n", 0), "file")
+	assert.ErrorIs(t, err, http.ErrMissingFile)
+
+	// ファイルを読み込む途中でリクエストボディの上限を超えた場合は413にする
+	r, err = MultipartFile(newUploadContext(t, "file", strings.Repeat("a", 1024), 512), "file")
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	assert.ErrorIs(t, err, domainErrors.ErrFileTooLarge)
+}
@@ -0,0 +1,61 @@
+package response
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// 一覧のページネーションの情報
+type Pagination struct {
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// レスポンスに付けるリクエストの情報
+type Meta struct {
+	RequestID string `json:"request_id"`
+}
+
+// 一覧のレスポンスの形式
+type ListResponse struct {
+	Items      interface{} `json:"items"`
+	Pagination Pagination  `json:"pagination"`
+	Meta       *Meta       `json:"meta,omitempty"`
+}
+
+// 1件のリソースのレスポンスの形式
+type ItemResponse struct {
+	Item interface{} `json:"item"`
+	Meta *Meta       `json:"meta,omitempty"`
+}
+
+// 一覧をitemsとpaginationで包んで返す
+func List(c echo.Context, status int, items interface{}, pagination Pagination) error {
+	return c.JSON(status, ListResponse{
+		Items:      items,
+		Pagination: pagination,
+		Meta:       meta(c),
+	})
+}
+
+// 1件のリソースをitemで包んで返す
+func Item(c echo.Context, status int, item interface{}) error {
+	return c.JSON(status, ItemResponse{
+		Item: item,
+		Meta: meta(c),
+	})
+}
+
+// リクエストIDがある場合のみmetaを返す。
+// ミドルウェアがレスポンスに設定したIDを優先し、なければリクエストのヘッダーの値を使う
+func meta(c echo.Context) *Meta {
+	requestID := c.Response().Header().Get(echo.HeaderXRequestID)
+	if requestID == "" {
+		requestID = c.Request().Header.Get(echo.HeaderXRequestID)
+	}
+	if requestID == "" {
+		return nil
+	}
+	return &Meta{RequestID: requestID}
+}
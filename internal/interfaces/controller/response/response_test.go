@@ -0,0 +1,66 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name              string
+		requestHeader     string
+		responseHeader    string
+		expectedRequestID interface{}
+	}{
+		{name: "正常系: リクエストIDがない場合はmetaを省略する"},
+		{name: "正常系: リクエストのヘッダーのID", requestHeader: "req-1", expectedRequestID: "req-1"},
+		{name: "正常系: レスポンスに設定されたIDを優先する", requestHeader: "req-1", responseHeader: "res-1", expectedRequestID: "res-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/v2/items", nil)
+			if tt.requestHeader != "" {
+				req.Header.Set(echo.HeaderXRequestID, tt.requestHeader)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if tt.responseHeader != "" {
+				c.Response().Header().Set(echo.HeaderXRequestID, tt.responseHeader)
+			}
+
+			require.NoError(t, List(c, http.StatusOK, []string{"a", "b"}, Pagination{Total: 5, Limit: 2, Offset: 0}))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, []interface{}{"a", "b"}, body["items"])
+			assert.Equal(t, map[string]interface{}{"total": float64(5), "limit": float64(2), "offset": float64(0)}, body["pagination"])
+			if tt.expectedRequestID == nil {
+				assert.NotContains(t, body, "meta")
+			} else {
+				assert.Equal(t, map[string]interface{}{"request_id": tt.expectedRequestID}, body["meta"])
+			}
+		})
+	}
+}
+
+func TestItem(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/v2/items", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-1")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	require.NoError(t, Item(c, http.StatusCreated, map[string]interface{}{"id": 1}))
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.JSONEq(t, `{"item":{"id":1},"meta":{"request_id":"req-1"}}`, rec.Body.String())
+}
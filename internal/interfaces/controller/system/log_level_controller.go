@@ -0,0 +1,53 @@
+package system
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/interfaces/controller/request"
+)
+
+// ログのレベル（debug, info, warn, error）
+type LogLevel struct {
+	Level string `json:"level"`
+}
+
+type LogLevelHandler struct {
+	level *slog.LevelVar
+}
+
+// levelはログのハンドラーに設定したLevelVar。変更は再起動せずに次のログから反映される
+func NewLogLevelHandler(level *slog.LevelVar) *LogLevelHandler {
+	return &LogLevelHandler{level: level}
+}
+
+// GET /admin/log-level
+func (h *LogLevelHandler) GetLogLevel(c echo.Context) error {
+	return c.JSON(http.StatusOK, LogLevel{Level: levelName(h.level.Level())})
+}
+
+// PUT /admin/log-level
+func (h *LogLevelHandler) UpdateLogLevel(c echo.Context) error {
+	var input LogLevel
+	if err := request.Decode(c, &input); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(input.Level))); err != nil {
+		return httperror.BadRequest(c, "invalid log level", "level must be one of: debug, info, warn, error")
+	}
+
+	previous := h.level.Level()
+	h.level.Set(level)
+	slog.InfoContext(c.Request().Context(), "ログのレベルを変更しました", "from", levelName(previous), "to", levelName(level))
+	return c.JSON(http.StatusOK, LogLevel{Level: levelName(level)})
+}
+
+func levelName(level slog.Level) string {
+	return strings.ToLower(level.String())
+}
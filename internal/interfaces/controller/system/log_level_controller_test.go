@@ -0,0 +1,55 @@
+package system
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/openapi/openapitest"
+)
+
+func TestLogLevelHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedLevel  slog.Level
+		expectedBody   string
+	}{
+		{name: "正常系: レベルを変更する", body: `{"level":"debug"}`, expectedStatus: http.StatusOK, expectedLevel: slog.LevelDebug, expectedBody: `{"level":"debug"}`},
+		{name: "正常系: 大文字も受け付ける", body: `{"level":"WARN"}`, expectedStatus: http.StatusOK, expectedLevel: slog.LevelWarn, expectedBody: `{"level":"warn"}`},
+		{name: "異常系: 不明なレベル", body: `{"level":"verbose"}`, expectedStatus: http.StatusBadRequest, expectedLevel: slog.LevelInfo},
+		{name: "異常系: 形式の誤り", body: `{"level":`, expectedStatus: http.StatusBadRequest, expectedLevel: slog.LevelInfo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level := new(slog.LevelVar)
+			handler := NewLogLevelHandler(level)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/log-level", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, handler.UpdateLogLevel(e.NewContext(req, rec)))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			openapitest.AssertExchange(t, openapitest.Document("", AdminOperations()...), http.MethodPut, "/admin/log-level", tt.body, rec)
+			assert.Equal(t, tt.expectedLevel, level.Level())
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+
+				// 変更したレベルを取得できる
+				rec = httptest.NewRecorder()
+				require.NoError(t, handler.GetLogLevel(e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/admin/log-level", nil), rec)))
+				assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
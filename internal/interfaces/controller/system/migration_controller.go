@@ -0,0 +1,63 @@
+package system
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+)
+
+// マイグレーションの適用状況
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at"`
+}
+
+// マイグレーションの適用状況を版数の順に返す
+type MigrationStatusFunc func(ctx context.Context) ([]MigrationStatus, error)
+
+type MigrationStatusResponse struct {
+	Backend        string            `json:"backend"`
+	CurrentVersion int64             `json:"current_version"` // 適用済みの最大の版数。未適用の場合は0
+	Pending        int               `json:"pending"`
+	Migrations     []MigrationStatus `json:"migrations"`
+}
+
+type MigrationHandler struct {
+	backend string
+	status  MigrationStatusFunc
+}
+
+// statusがnilの場合はマイグレーションのない保存先（memory）として空の一覧を返す
+func NewMigrationHandler(backend string, status MigrationStatusFunc) *MigrationHandler {
+	return &MigrationHandler{backend: backend, status: status}
+}
+
+// GET /admin/migrations
+func (h *MigrationHandler) GetMigrationStatus(c echo.Context) error {
+	response := MigrationStatusResponse{Backend: h.backend, Migrations: []MigrationStatus{}}
+	if h.status == nil {
+		return c.JSON(http.StatusOK, response)
+	}
+
+	migrations, err := h.status(c.Request().Context())
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve migration status")
+	}
+
+	for _, m := range migrations {
+		if !m.Applied {
+			response.Pending++
+		} else if m.Version > response.CurrentVersion {
+			response.CurrentVersion = m.Version
+		}
+	}
+	response.Migrations = append(response.Migrations, migrations...)
+
+	return c.JSON(http.StatusOK, response)
+}
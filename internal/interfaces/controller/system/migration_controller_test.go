@@ -0,0 +1,83 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/openapi/openapitest"
+)
+
+func TestMigrationHandler_GetMigrationStatus(t *testing.T) {
+	appliedAt := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		status           MigrationStatusFunc
+		expectedStatus   int
+		expectedResponse MigrationStatusResponse
+	}{
+		{
+			name: "正常系: 適用済みの最大の版数と未適用の件数",
+			status: func(ctx context.Context) ([]MigrationStatus, error) {
+				return []MigrationStatus{
+					{Version: 1, Name: "create_items", Applied: true, AppliedAt: &appliedAt},
+					{Version: 2, Name: "add_items_deleted_at", Applied: true, AppliedAt: &appliedAt},
+					{Version: 3, Name: "create_item_histories"},
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: MigrationStatusResponse{
+				Backend:        "mysql",
+				CurrentVersion: 2,
+				Pending:        1,
+				Migrations: []MigrationStatus{
+					{Version: 1, Name: "create_items", Applied: true, AppliedAt: &appliedAt},
+					{Version: 2, Name: "add_items_deleted_at", Applied: true, AppliedAt: &appliedAt},
+					{Version: 3, Name: "create_item_histories"},
+				},
+			},
+		},
+		{
+			name:             "正常系: マイグレーションのない保存先は空の一覧",
+			expectedStatus:   http.StatusOK,
+			expectedResponse: MigrationStatusResponse{Backend: "mysql", Migrations: []MigrationStatus{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/admin/migrations", nil), rec)
+
+			require.NoError(t, NewMigrationHandler("mysql", tt.status).GetMigrationStatus(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			openapitest.AssertExchange(t, openapitest.Document("", AdminOperations()...), http.MethodGet, "/admin/migrations", "", rec)
+
+			var response MigrationStatusResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedResponse, response)
+		})
+	}
+}
+
+func TestMigrationHandler_GetMigrationStatus_Error(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/admin/migrations", nil), rec)
+
+	handler := NewMigrationHandler("mysql", func(ctx context.Context) ([]MigrationStatus, error) {
+		return nil, errors.New("connection refused")
+	})
+	require.NoError(t, handler.GetMigrationStatus(c))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -1,17 +1,80 @@
 package system
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 )
 
-type SystemHandler struct{}
+// 準備状態の確認で確認する依存先
+type Dependency struct {
+	Name     string
+	Required bool // 接続できない場合にリクエストを受け付けられない依存先かどうか
+	Check    func(ctx context.Context) error
+}
+
+// 依存先の状態
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// サーバー全体の準備状態
+const (
+	ReadinessReady       = "ready"
+	ReadinessUnavailable = "unavailable"
+)
+
+type DependencyStatus struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Required bool   `json:"required"`
+	Error    string `json:"error,omitempty"`
+}
+
+type ReadinessResponse struct {
+	Status       string             `json:"status"`
+	Dependencies []DependencyStatus `json:"dependencies"`
+}
+
+type SystemHandler struct {
+	dependencies []Dependency
+}
 
 func (handler *SystemHandler) Health(ctx echo.Context) {
 	ctx.NoContent(http.StatusOK)
 }
 
-func NewSystemHandler() *SystemHandler {
-	return &SystemHandler{}
+// GET /healthz
+// プロセスが動いていれば常に200を返す。依存先は確認しない
+func (handler *SystemHandler) Liveness(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// GET /readyz
+// 各依存先の状態を返す。必須の依存先に接続できない場合は503を返す
+func (handler *SystemHandler) Readiness(c echo.Context) error {
+	response := ReadinessResponse{Status: ReadinessReady, Dependencies: make([]DependencyStatus, 0, len(handler.dependencies))}
+	for _, dependency := range handler.dependencies {
+		status := DependencyStatus{Name: dependency.Name, Status: StatusUp, Required: dependency.Required}
+		if err := dependency.Check(c.Request().Context()); err != nil {
+			status.Status = StatusDown
+			status.Error = err.Error()
+			if dependency.Required {
+				response.Status = ReadinessUnavailable
+			}
+		}
+		response.Dependencies = append(response.Dependencies, status)
+	}
+
+	if response.Status != ReadinessReady {
+		return c.JSON(http.StatusServiceUnavailable, response)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// dependenciesは/readyzで順に確認する依存先。確認の結果は呼び出し側で必要に応じて保持する
+func NewSystemHandler(dependencies ...Dependency) *SystemHandler {
+	return &SystemHandler{dependencies: dependencies}
 }
@@ -0,0 +1,85 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/openapi/openapitest"
+)
+
+func check(err error) func(ctx context.Context) error {
+	return func(ctx context.Context) error { return err }
+}
+
+func TestSystemHandler_Readiness(t *testing.T) {
+	down := errors.New("connection refused")
+
+	tests := []struct {
+		name           string
+		dependencies   []Dependency
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "正常系: 依存先がない",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"ready","dependencies":[]}`,
+		},
+		{
+			name: "正常系: 必須でない依存先のみ接続できない",
+			dependencies: []Dependency{
+				{Name: "database", Required: true, Check: check(nil)},
+				{Name: "cache", Check: check(down)},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"status":"ready","dependencies":[
+				{"name":"database","status":"up","required":true},
+				{"name":"cache","status":"down","required":false,"error":"connection refused"}
+			]}`,
+		},
+		{
+			name: "異常系: 必須の依存先に接続できない",
+			dependencies: []Dependency{
+				{Name: "database", Required: true, Check: check(down)},
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody: `{"status":"unavailable","dependencies":[
+				{"name":"database","status":"down","required":true,"error":"connection refused"}
+			]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewSystemHandler(tt.dependencies...)
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			require.NoError(t, handler.Readiness(e.NewContext(httptest.NewRequest(http.MethodGet, "/readyz", nil), rec)))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+			openapitest.AssertExchange(t, openapitest.Document("", Operations()...), http.MethodGet, "/readyz", "", rec)
+		})
+	}
+}
+
+func TestSystemHandler_Liveness(t *testing.T) {
+	// 依存先に接続できなくても200を返す
+	handler := NewSystemHandler(Dependency{Name: "database", Required: true, Check: check(errors.New("down"))})
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.Liveness(e.NewContext(httptest.NewRequest(http.MethodGet, "/healthz", nil), rec)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+	openapitest.AssertExchange(t, openapitest.Document("", Operations()...), http.MethodGet, "/healthz", "", rec)
+}
@@ -0,0 +1,53 @@
+package system
+
+import (
+	"net/http"
+
+	"Aicon-assignment/internal/interfaces/openapi"
+)
+
+// ヘルスチェックと準備状態の確認の操作。バージョンのないパスで、認証せずに呼び出せる
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/health", ID: "health", Summary: "ヘルスチェック", Tags: []string{"system"},
+			Responses: openapi.Responses{http.StatusOK: openapi.NoContent("動いている")},
+			Public:    true,
+		},
+		{
+			Method: http.MethodGet, Path: "/healthz", ID: "liveness", Summary: "生存確認（プロセスが動いていれば200）", Tags: []string{"system"},
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("動いている", openapi.Object(map[string]*openapi.Schema{"status": openapi.Enum("ok")}))},
+			Public:    true,
+		},
+		{
+			Method: http.MethodGet, Path: "/readyz", ID: "readiness", Summary: "準備状態の確認（データベースなどの依存先の状態）", Tags: []string{"system"},
+			Responses: openapi.Responses{
+				http.StatusOK:                 openapi.JSON("リクエストを受け付けられる", openapi.Of(ReadinessResponse{})),
+				http.StatusServiceUnavailable: openapi.JSON("必須の依存先に接続できない", openapi.Of(ReadinessResponse{})),
+			},
+			Public: true,
+		},
+	}
+}
+
+// 管理者用のマイグレーションとログのレベルの操作
+func AdminOperations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/admin/migrations", ID: "getMigrationStatus", Summary: "データベースのマイグレーションの適用状況（管理者用）", Tags: []string{"admin"},
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("マイグレーションの適用状況", openapi.Of(MigrationStatusResponse{}))},
+			Errors:    []int{http.StatusForbidden},
+		},
+		{
+			Method: http.MethodGet, Path: "/admin/log-level", ID: "getLogLevel", Summary: "現在のログのレベル（管理者用）", Tags: []string{"admin"},
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("ログのレベル", openapi.Of(LogLevel{}))},
+			Errors:    []int{http.StatusForbidden},
+		},
+		{
+			Method: http.MethodPut, Path: "/admin/log-level", ID: "updateLogLevel", Summary: "ログのレベルの変更（管理者用）", Tags: []string{"admin"},
+			RequestBody: openapi.JSONBody(openapi.Of(LogLevel{})),
+			Responses:   openapi.Responses{http.StatusOK: openapi.JSON("変更したログのレベル", openapi.Of(LogLevel{}))},
+			Errors:      []int{http.StatusBadRequest, http.StatusForbidden},
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"net/http"
+
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TagHandler struct {
+	tagUsecase usecase.TagUsecase
+}
+
+func NewTagHandler(tagUsecase usecase.TagUsecase) *TagHandler {
+	return &TagHandler{
+		tagUsecase: tagUsecase,
+	}
+}
+
+// GET /tags
+func (h *TagHandler) GetTags(c echo.Context) error {
+	tags, err := h.tagUsecase.ListTags(c.Request().Context())
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve tags")
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"net/http"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/openapi"
+)
+
+// タグのルートの操作
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/tags", ID: "listTags", Summary: "タグの一覧（アイテムの件数付き）", Tags: []string{"tags"},
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("タグの一覧", openapi.ArrayOf(openapi.Of(entity.TagCount{})))},
+		},
+	}
+}
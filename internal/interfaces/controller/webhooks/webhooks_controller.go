@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/interfaces/controller/request"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler struct {
+	webhookUsecase usecase.WebhookUsecase
+}
+
+func NewWebhookHandler(webhookUsecase usecase.WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUsecase: webhookUsecase,
+	}
+}
+
+// GET /admin/webhooks
+func (h *WebhookHandler) GetWebhooks(c echo.Context) error {
+	webhooks, err := h.webhookUsecase.ListWebhooks(c.Request().Context())
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve webhooks")
+	}
+
+	return c.JSON(http.StatusOK, webhooks)
+}
+
+// POST /admin/webhooks
+func (h *WebhookHandler) CreateWebhook(c echo.Context) error {
+	var input usecase.CreateWebhookInput
+	if err := request.Decode(c, &input); err != nil {
+		return httperror.Respond(c, err, "invalid request format")
+	}
+
+	webhook, err := h.webhookUsecase.CreateWebhook(c.Request().Context(), input)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to create webhook")
+	}
+
+	return c.JSON(http.StatusCreated, webhook)
+}
+
+// DELETE /admin/webhooks/{id}
+func (h *WebhookHandler) DeleteWebhook(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid webhook ID")
+	}
+
+	if err := h.webhookUsecase.DeleteWebhook(c.Request().Context(), id); err != nil {
+		return httperror.Respond(c, err, "failed to delete webhook")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// POST /admin/webhooks/{id}/enable
+// 失敗が続いて無効になったWebhookを有効に戻す
+func (h *WebhookHandler) EnableWebhook(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid webhook ID")
+	}
+
+	webhook, err := h.webhookUsecase.EnableWebhook(c.Request().Context(), id)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to enable webhook")
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+// GET /admin/webhooks/{id}/deliveries?limit=50
+// 送信の記録を新しい順に返す
+func (h *WebhookHandler) GetDeliveries(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return httperror.BadRequest(c, "invalid webhook ID")
+	}
+
+	var limit int
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		v, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return httperror.BadRequest(c, "invalid limit parameter", "limit must be an integer")
+		}
+		if v < 1 {
+			return httperror.BadRequest(c, "invalid limit parameter", "limit must be 1 or greater")
+		}
+		limit = v
+	}
+
+	deliveries, err := h.webhookUsecase.ListDeliveries(c.Request().Context(), id, limit)
+	if err != nil {
+		return httperror.Respond(c, err, "failed to retrieve webhook deliveries")
+	}
+
+	return c.JSON(http.StatusOK, deliveries)
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/openapi/openapitest"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 使わないメソッドは埋め込んだインターフェースに委ねる（呼ぶとpanicする）
+type stubWebhookUsecase struct {
+	usecase.WebhookUsecase
+	limit int
+}
+
+func (u *stubWebhookUsecase) CreateWebhook(ctx context.Context, input usecase.CreateWebhookInput) (*entity.Webhook, error) {
+	return &entity.Webhook{ID: 1, URL: input.URL, Secret: input.Secret, Enabled: true}, nil
+}
+
+func (u *stubWebhookUsecase) ListDeliveries(ctx context.Context, id int64, limit int) ([]*entity.WebhookDelivery, error) {
+	if id != 1 {
+		return nil, domainErrors.ErrWebhookNotFound
+	}
+	u.limit = limit
+	return []*entity.WebhookDelivery{}, nil
+}
+
+func TestWebhookHandler_CreateWebhook(t *testing.T) {
+	h := NewWebhookHandler(&stubWebhookUsecase{})
+	req := httptest.NewRequest(http.MethodPost, "/admin/webhooks", strings.NewReader(`{"url": "https://example.com/hooks", "secret": "0123456789abcdef"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, h.CreateWebhook(echo.New().NewContext(req, rec)))
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"url":"https://example.com/hooks"`)
+	openapitest.AssertExchange(t, openapitest.Document("", Operations()...), http.MethodPost, "/admin/webhooks", `{"url": "https://example.com/hooks", "secret": "0123456789abcdef"}`, rec)
+	// シークレットはレスポンスに含めない
+	assert.NotContains(t, rec.Body.String(), "0123456789abcdef")
+}
+
+func TestWebhookHandler_GetDeliveries(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		query          string
+		expectedStatus int
+		expectedLimit  int
+	}{
+		{name: "正常系: limitを渡す", id: "1", query: "?limit=10", expectedStatus: http.StatusOK, expectedLimit: 10},
+		{name: "異常系: 数値でないlimit", id: "1", query: "?limit=abc", expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 0のlimit", id: "1", query: "?limit=0", expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 無効なID", id: "abc", expectedStatus: http.StatusBadRequest},
+		{name: "異常系: 存在しないWebhook", id: "2", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubWebhookUsecase{}
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/admin/webhooks/"+tt.id+"/deliveries"+tt.query, nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+
+			require.NoError(t, NewWebhookHandler(stub).GetDeliveries(c))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedLimit, stub.limit)
+			openapitest.AssertExchange(t, openapitest.Document("", Operations()...), http.MethodGet, "/admin/webhooks/{id}/deliveries", "", rec)
+		})
+	}
+}
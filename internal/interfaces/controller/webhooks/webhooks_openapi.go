@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"net/http"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/openapi"
+	"Aicon-assignment/internal/usecase"
+)
+
+// Webhookのルートの操作
+func Operations() []openapi.Operation {
+	webhookID := openapi.PathID("id", "WebhookのID")
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/admin/webhooks", ID: "listWebhooks", Summary: "Webhook一覧（管理者用）", Tags: []string{"admin"},
+			Responses: openapi.Responses{http.StatusOK: openapi.JSON("Webhookの一覧", openapi.ArrayOf(openapi.Of(entity.Webhook{})))},
+			Errors:    []int{http.StatusForbidden},
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/webhooks", ID: "createWebhook", Summary: "Webhook登録（管理者用）", Tags: []string{"admin"},
+			RequestBody: openapi.JSONBody(openapi.Of(usecase.CreateWebhookInput{})),
+			Responses:   openapi.Responses{http.StatusCreated: openapi.JSON("登録したWebhook", openapi.Of(entity.Webhook{}))},
+			Errors:      []int{http.StatusBadRequest, http.StatusForbidden, http.StatusUnprocessableEntity},
+		},
+		{
+			Method: http.MethodDelete, Path: "/admin/webhooks/{id}", ID: "deleteWebhook", Summary: "Webhook削除（管理者用）", Tags: []string{"admin"},
+			Parameters: []*openapi.Parameter{webhookID},
+			Responses:  openapi.Responses{http.StatusNoContent: openapi.NoContent("削除した")},
+			Errors:     []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodPost, Path: "/admin/webhooks/{id}/enable", ID: "enableWebhook", Summary: "無効になったWebhookの再有効化（管理者用）", Tags: []string{"admin"},
+			Parameters: []*openapi.Parameter{webhookID},
+			Responses:  openapi.Responses{http.StatusOK: openapi.JSON("有効にしたWebhook", openapi.Of(entity.Webhook{}))},
+			Errors:     []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound},
+		},
+		{
+			Method: http.MethodGet, Path: "/admin/webhooks/{id}/deliveries", ID: "listWebhookDeliveries", Summary: "Webhookの送信の記録（管理者用）", Tags: []string{"admin"},
+			Parameters: []*openapi.Parameter{webhookID, openapi.Query("limit", openapi.Integer(), "返す記録の数")},
+			Responses:  openapi.Responses{http.StatusOK: openapi.JSON("新しい順の送信の記録", openapi.ArrayOf(openapi.Of(entity.WebhookDelivery{})))},
+			Errors:     []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound},
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type APIKeyRepository struct {
+	SqlHandler
+	// 未設定の場合はMySQL
+	Dialect Dialect
+}
+
+func (r *APIKeyRepository) dialect() Dialect {
+	return dialectOrDefault(r.Dialect)
+}
+
+// scanAPIKeyと同じ順序で並べたSELECT対象の列
+const apiKeySelectColumns = "id, label, role, key_hash, created_at, revoked_at"
+
+func (r *APIKeyRepository) FindAll(ctx context.Context) ([]*entity.APIKey, error) {
+	query := `SELECT ` + apiKeySelectColumns + ` FROM api_keys ORDER BY id`
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	keys := make([]*entity.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	query := `SELECT ` + apiKeySelectColumns + ` FROM api_keys WHERE key_hash = ?`
+
+	return r.findOne(ctx, query, keyHash)
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error) {
+	query := `INSERT INTO api_keys (label, role, key_hash) VALUES (?, ?, ?)`
+
+	id, err := insertID(ctx, r.dialect(), r.SqlHandler, query, key.Label, key.Role, key.KeyHash)
+	if err != nil {
+		return nil, wrapWriteError(ctx, r.dialect(), err, domainErrors.ErrDuplicateEntry)
+	}
+
+	return r.findByID(ctx, id)
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, id int64) (*entity.APIKey, error) {
+	query := `UPDATE api_keys SET revoked_at = ` + r.dialect().now() + ` WHERE id = ? AND revoked_at IS NULL`
+
+	if _, err := r.Execute(ctx, query, id); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	// 存在しない場合はErrAPIKeyNotFoundを返す
+	return r.findByID(ctx, id)
+}
+
+func (r *APIKeyRepository) UpdateRole(ctx context.Context, id int64, role entity.UserRole) (*entity.APIKey, error) {
+	query := `UPDATE api_keys SET role = ? WHERE id = ?`
+
+	if _, err := r.Execute(ctx, query, role, id); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	// 存在しない場合はErrAPIKeyNotFoundを返す
+	return r.findByID(ctx, id)
+}
+
+func (r *APIKeyRepository) findByID(ctx context.Context, id int64) (*entity.APIKey, error) {
+	query := `SELECT ` + apiKeySelectColumns + ` FROM api_keys WHERE id = ?`
+
+	return r.findOne(ctx, query, id)
+}
+
+func (r *APIKeyRepository) findOne(ctx context.Context, query string, args ...interface{}) (*entity.APIKey, error) {
+	key, err := scanAPIKey(r.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainErrors.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return key, nil
+}
+
+func scanAPIKey(row Row) (*entity.APIKey, error) {
+	var key entity.APIKey
+	var revokedAt sql.NullTime
+	if err := row.Scan(&key.ID, &key.Label, &key.Role, &key.KeyHash, &key.CreatedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return &key, nil
+}
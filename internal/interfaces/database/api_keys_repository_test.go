@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+var apiKeyColumns = []string{"id", "label", "role", "key_hash", "created_at", "revoked_at"}
+
+func newMockAPIKeyRepository(t *testing.T) (*APIKeyRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return &APIKeyRepository{SqlHandler: &testSqlHandler{db: db}}, mock
+}
+
+func TestAPIKeyRepository_FindByHash(t *testing.T) {
+	now := time.Now()
+
+	t.Run("正常系: 失効済みのキーも取得する", func(t *testing.T) {
+		repo, mock := newMockAPIKeyRepository(t)
+		mock.ExpectQuery(`SELECT id, label, role, key_hash, created_at, revoked_at FROM api_keys WHERE key_hash = \?`).
+			WithArgs("hash").
+			WillReturnRows(sqlmock.NewRows(apiKeyColumns).AddRow(1, "batch", "admin", "hash", now, now))
+
+		key, err := repo.FindByHash(context.Background(), "hash")
+
+		require.NoError(t, err)
+		assert.Equal(t, "batch", key.Label)
+		assert.Equal(t, entity.UserRoleAdmin, key.Role)
+		assert.True(t, key.Revoked())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 未登録のキー", func(t *testing.T) {
+		repo, mock := newMockAPIKeyRepository(t)
+		mock.ExpectQuery(`FROM api_keys WHERE key_hash = \?`).
+			WillReturnRows(sqlmock.NewRows(apiKeyColumns))
+
+		_, err := repo.FindByHash(context.Background(), "hash")
+
+		assert.ErrorIs(t, err, domainErrors.ErrAPIKeyNotFound)
+	})
+}
+
+func TestAPIKeyRepository_Create(t *testing.T) {
+	apiKey := &entity.APIKey{Label: "batch", Role: entity.UserRoleUser, KeyHash: "hash"}
+
+	t.Run("正常系: ハッシュ値を保存する", func(t *testing.T) {
+		repo, mock := newMockAPIKeyRepository(t)
+		mock.ExpectExec(`INSERT INTO api_keys \(label, role, key_hash\) VALUES \(\?, \?, \?\)`).
+			WithArgs("batch", entity.UserRoleUser, "hash").
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectQuery(`FROM api_keys WHERE id = \?`).
+			WithArgs(int64(3)).
+			WillReturnRows(sqlmock.NewRows(apiKeyColumns).AddRow(3, "batch", "user", "hash", time.Now(), nil))
+
+		created, err := repo.Create(context.Background(), apiKey)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), created.ID)
+		assert.Nil(t, created.RevokedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: ハッシュ値の重複", func(t *testing.T) {
+		repo, mock := newMockAPIKeyRepository(t)
+		mock.ExpectExec(`INSERT INTO api_keys`).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'hash' for key 'uk_key_hash'"})
+
+		_, err := repo.Create(context.Background(), apiKey)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+	})
+}
+
+func TestAPIKeyRepository_Revoke(t *testing.T) {
+	repo, mock := newMockAPIKeyRepository(t)
+	mock.ExpectExec(`UPDATE api_keys SET revoked_at = NOW\(\) WHERE id = \? AND revoked_at IS NULL`).
+		WithArgs(int64(9)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`FROM api_keys WHERE id = \?`).
+		WithArgs(int64(9)).
+		WillReturnRows(sqlmock.NewRows(apiKeyColumns))
+
+	_, err := repo.Revoke(context.Background(), 9)
+
+	assert.ErrorIs(t, err, domainErrors.ErrAPIKeyNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_UpdateRole(t *testing.T) {
+	repo, mock := newMockAPIKeyRepository(t)
+	mock.ExpectExec(`UPDATE api_keys SET role = \? WHERE id = \?`).
+		WithArgs(entity.UserRoleAdmin, int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM api_keys WHERE id = \?`).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows(apiKeyColumns).AddRow(3, "batch", "admin", "hash", time.Now(), nil))
+
+	updated, err := repo.UpdateRole(context.Background(), 3, entity.UserRoleAdmin)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserRoleAdmin, updated.Role)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
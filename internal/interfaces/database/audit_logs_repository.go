@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type AuditLogRepository struct {
+	SqlHandler
+	// 未設定の場合はMySQL
+	Dialect Dialect
+}
+
+func (r *AuditLogRepository) dialect() Dialect {
+	return dialectOrDefault(r.Dialect)
+}
+
+func (r *AuditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	query := `INSERT INTO audit_logs (actor, action, item_id, source_ip, created_at) VALUES (?, ?, ?, ?, ?)`
+
+	id, err := insertID(ctx, r.dialect(), r.SqlHandler, query, log.Actor, log.Action, log.ItemID, log.SourceIP, log.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("%w: failed to insert audit log: %w", domainErrors.ErrDatabaseError, err)
+	}
+	log.ID = id
+
+	return nil
+}
+
+// 監査ログを新しい順に取得する
+func (r *AuditLogRepository) FindAll(ctx context.Context, filter entity.AuditLogFilter, page entity.Pagination) ([]*entity.AuditLog, error) {
+	where, args := buildAuditLogFilter(filter)
+	query := `
+        SELECT id, actor, action, item_id, source_ip, created_at
+        FROM audit_logs` + where + `
+        ORDER BY created_at DESC, id DESC
+        LIMIT ? OFFSET ?
+    `
+
+	rows, err := r.Query(ctx, query, append(args, page.Limit, page.Offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	logs := make([]*entity.AuditLog, 0)
+	for rows.Next() {
+		var log entity.AuditLog
+		if err := rows.Scan(&log.ID, &log.Actor, &log.Action, &log.ItemID, &log.SourceIP, &log.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		logs = append(logs, &log)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return logs, nil
+}
+
+func (r *AuditLogRepository) Count(ctx context.Context, filter entity.AuditLogFilter) (int, error) {
+	where, args := buildAuditLogFilter(filter)
+	query := `SELECT COUNT(*) FROM audit_logs` + where
+
+	var count int
+	if err := r.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return count, nil
+}
+
+// 絞り込み条件のWHERE句とプレースホルダの値
+func buildAuditLogFilter(filter entity.AuditLogFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Actor != "" {
+		conditions = append(conditions, "actor = ?")
+		args = append(args, filter.Actor)
+	}
+	if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, filter.Action)
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, *filter.To)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
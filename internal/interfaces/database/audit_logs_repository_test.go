@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+var auditLogColumns = []string{"id", "actor", "action", "item_id", "source_ip", "created_at"}
+
+func newMockAuditLogRepository(t *testing.T) (*AuditLogRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return &AuditLogRepository{SqlHandler: &testSqlHandler{db: db}}, mock
+}
+
+func TestAuditLogRepository_Create(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	log := &entity.AuditLog{Actor: "user:2", Action: entity.AuditActionUpdate, ItemID: 1, SourceIP: "203.0.113.7", CreatedAt: now}
+
+	t.Run("正常系: IDを設定する", func(t *testing.T) {
+		repo, mock := newMockAuditLogRepository(t)
+		mock.ExpectExec(`INSERT INTO audit_logs \(actor, action, item_id, source_ip, created_at\) VALUES \(\?, \?, \?, \?, \?\)`).
+			WithArgs("user:2", "update", int64(1), "203.0.113.7", now).
+			WillReturnResult(sqlmock.NewResult(5, 1))
+
+		require.NoError(t, repo.Create(context.Background(), log))
+		assert.Equal(t, int64(5), log.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		repo, mock := newMockAuditLogRepository(t)
+		mock.ExpectExec(`INSERT INTO audit_logs`).WillReturnError(errors.New("connection refused"))
+
+		err := repo.Create(context.Background(), log)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+}
+
+func TestAuditLogRepository_FindAll(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		filter        entity.AuditLogFilter
+		expectedWhere string
+		expectedArgs  []driver.Value
+	}{
+		{name: "正常系: 絞り込みなし", expectedWhere: `FROM audit_logs\s+`},
+		{
+			name:          "正常系: すべての条件",
+			filter:        entity.AuditLogFilter{Actor: "user:2", Action: entity.AuditActionDelete, From: &from, To: &to},
+			expectedWhere: `WHERE actor = \? AND action = \? AND created_at >= \? AND created_at < \?`,
+			expectedArgs:  []driver.Value{"user:2", "delete", from, to},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockAuditLogRepository(t)
+			mock.ExpectQuery(tt.expectedWhere + `[\s\S]*ORDER BY created_at DESC, id DESC\s+LIMIT \? OFFSET \?`).
+				WithArgs(append(tt.expectedArgs, 20, 0)...).
+				WillReturnRows(sqlmock.NewRows(auditLogColumns).AddRow(3, "user:2", "delete", 1, "203.0.113.7", from))
+
+			logs, err := repo.FindAll(context.Background(), tt.filter, entity.Pagination{Limit: 20})
+
+			require.NoError(t, err)
+			require.Len(t, logs, 1)
+			assert.Equal(t, &entity.AuditLog{ID: 3, Actor: "user:2", Action: "delete", ItemID: 1, SourceIP: "203.0.113.7", CreatedAt: from}, logs[0])
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAuditLogRepository_Count(t *testing.T) {
+	repo, mock := newMockAuditLogRepository(t)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM audit_logs WHERE action = \?`).
+		WithArgs("import").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+	count, err := repo.Count(context.Background(), entity.AuditLogFilter{Action: entity.AuditActionImport})
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
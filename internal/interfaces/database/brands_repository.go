@@ -0,0 +1,293 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type BrandRepository struct {
+	SqlHandler
+	// 未設定の場合はMySQL
+	Dialect Dialect
+}
+
+func (r *BrandRepository) dialect() Dialect {
+	return dialectOrDefault(r.Dialect)
+}
+
+// scanBrandと同じ順序で並べたSELECT対象の列。FROM brands bのクエリで使い、別名はJSONの配列として取得する
+func brandSelectColumns(d Dialect) string {
+	return "b.id, b.name, b.created_at, b.updated_at, " +
+		"(SELECT " + d.jsonArrayAgg("a.alias") + " FROM brand_aliases a WHERE a.brand_id = b.id)"
+}
+
+func (r *BrandRepository) Search(ctx context.Context, prefix string, limit int) ([]*entity.Brand, error) {
+	// 名前・別名の照合順序（utf8mb4_unicode_ci）により大文字小文字を区別せず前方一致で検索する
+	query := `
+        SELECT ` + brandSelectColumns(r.dialect()) + `
+        FROM brands b
+        WHERE b.name LIKE ?` + r.dialect().likeEscape() + `
+           OR EXISTS (SELECT 1 FROM brand_aliases a WHERE a.brand_id = b.id AND a.alias LIKE ?` + r.dialect().likeEscape() + `)
+        ORDER BY b.name, b.id
+        LIMIT ?
+    `
+	pattern := escapeLike(prefix) + "%"
+
+	rows, err := r.Query(ctx, query, pattern, pattern, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	brands := make([]*entity.Brand, 0)
+	for rows.Next() {
+		brand, err := scanBrand(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		brands = append(brands, brand)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return brands, nil
+}
+
+func (r *BrandRepository) FindByID(ctx context.Context, id int64) (*entity.Brand, error) {
+	query := `
+        SELECT ` + brandSelectColumns(r.dialect()) + `
+        FROM brands b
+        WHERE b.id = ?
+    `
+
+	brand, err := scanBrand(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainErrors.ErrBrandNotFound
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return brand, nil
+}
+
+func (r *BrandRepository) FindByNameOrAlias(ctx context.Context, name string) (*entity.Brand, error) {
+	query := `
+        SELECT ` + brandSelectColumns(r.dialect()) + `
+        FROM brands b
+        WHERE b.name = ?
+           OR EXISTS (SELECT 1 FROM brand_aliases a WHERE a.brand_id = b.id AND a.alias = ?)
+        ORDER BY b.id
+        LIMIT 1
+    `
+
+	brand, err := scanBrand(r.QueryRow(ctx, query, name, name))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainErrors.ErrBrandNotFound
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return brand, nil
+}
+
+func (r *BrandRepository) Create(ctx context.Context, brand *entity.Brand) (*entity.Brand, error) {
+	var id int64
+	err := inTx(ctx, r.SqlHandler, r.dialect(), func(tx Transaction) error {
+		if err := ensureBrandNamesAvailable(ctx, tx, append([]string{brand.Name}, brand.Aliases...)); err != nil {
+			return err
+		}
+
+		var err error
+		id, err = insertID(ctx, r.dialect(), tx, `INSERT INTO brands (name) VALUES (?)`, brand.Name)
+		if err != nil {
+			return wrapWriteError(ctx, r.dialect(), err, domainErrors.ErrDuplicateEntry)
+		}
+
+		return insertBrandAliases(ctx, r.dialect(), tx, id, brand.Aliases)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *BrandRepository) Merge(ctx context.Context, sourceID, targetID int64) (int64, error) {
+	var updated int64
+	err := inTx(ctx, r.SqlHandler, r.dialect(), func(tx Transaction) error {
+		// 同時に統合されないよう、両方のブランドをロックする
+		names, err := lockBrandNames(ctx, r.dialect(), tx, sourceID, targetID)
+		if err != nil {
+			return err
+		}
+		sourceName, sourceFound := names[sourceID]
+		targetName, targetFound := names[targetID]
+		if !sourceFound || !targetFound {
+			return domainErrors.ErrBrandNotFound
+		}
+
+		sourceAliases, err := findBrandAliases(ctx, tx, sourceID)
+		if err != nil {
+			return err
+		}
+
+		// 統合元の名前と別名を使っているアイテム（論理削除済みを含む）を統合先の名前に書き換える。
+		// 古いバージョンでの更新で元に戻らないよう、バージョンも進める
+		placeholders, args := inPlaceholders(append([]string{sourceName}, sourceAliases...))
+		updateQuery := `
+        UPDATE items
+        SET brand = ?, version = version + 1, updated_at = ` + r.dialect().now() + `
+        WHERE brand IN (` + placeholders + `)
+    `
+		result, err := tx.Execute(ctx, updateQuery, append([]interface{}{targetName}, args...)...)
+		if err != nil {
+			return fmt.Errorf("%w: failed to rewrite item brands: %w", domainErrors.ErrDatabaseError, err)
+		}
+		updated, err = result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get rows affected: %w", domainErrors.ErrDatabaseError, err)
+		}
+
+		// 別名は外部キーで連鎖削除されるため、統合元を削除する前に付け替える
+		if _, err := tx.Execute(ctx, `UPDATE brand_aliases SET brand_id = ? WHERE brand_id = ?`, targetID, sourceID); err != nil {
+			return fmt.Errorf("%w: failed to move brand aliases: %w", domainErrors.ErrDatabaseError, err)
+		}
+		if _, err := tx.Execute(ctx, `DELETE FROM brands WHERE id = ?`, sourceID); err != nil {
+			return fmt.Errorf("%w: failed to delete merged brand: %w", domainErrors.ErrDatabaseError, err)
+		}
+		return insertBrandAliases(ctx, r.dialect(), tx, targetID, []string{sourceName})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
+
+// 名前や別名がほかのブランドの名前・別名として登録済みの場合はErrDuplicateEntryを返す
+func ensureBrandNamesAvailable(ctx context.Context, tx Transaction, names []string) error {
+	placeholders, args := inPlaceholders(names)
+	query := `
+        SELECT (SELECT COUNT(*) FROM brands WHERE name IN (` + placeholders + `))
+             + (SELECT COUNT(*) FROM brand_aliases WHERE alias IN (` + placeholders + `))
+    `
+
+	var count int
+	if err := tx.QueryRow(ctx, query, append(args, args...)...).Scan(&count); err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: brand name or alias is already registered", domainErrors.ErrDuplicateEntry)
+	}
+
+	return nil
+}
+
+// ブランドをロックし、IDと名前の対応を返す。存在しないIDは含まれない
+func lockBrandNames(ctx context.Context, d Dialect, tx Transaction, ids ...int64) (map[int64]string, error) {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := tx.Query(ctx, `SELECT id, name FROM brands WHERE id IN (`+placeholders+`)`+d.forUpdate(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	names := make(map[int64]string, len(ids))
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		names[id] = name
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return names, nil
+}
+
+func findBrandAliases(ctx context.Context, tx Transaction, brandID int64) ([]string, error) {
+	rows, err := tx.Query(ctx, `SELECT alias FROM brand_aliases WHERE brand_id = ?`, brandID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	var aliases []string
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		aliases = append(aliases, alias)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return aliases, nil
+}
+
+func insertBrandAliases(ctx context.Context, d Dialect, tx Transaction, brandID int64, aliases []string) error {
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, len(aliases)*2)
+	for _, alias := range aliases {
+		args = append(args, brandID, alias)
+	}
+	query := `INSERT INTO brand_aliases (brand_id, alias) VALUES ` + strings.TrimSuffix(strings.Repeat("(?, ?), ", len(aliases)), ", ")
+	if _, err := tx.Execute(ctx, query, args...); err != nil {
+		return wrapWriteError(ctx, d, err, domainErrors.ErrDuplicateEntry)
+	}
+
+	return nil
+}
+
+func scanBrand(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Brand, error) {
+	var brand entity.Brand
+	var createdAt, updatedAt time.Time
+	var aliases sql.NullString
+
+	if err := scanner.Scan(&brand.ID, &brand.Name, &createdAt, &updatedAt, &aliases); err != nil {
+		return nil, err
+	}
+
+	brand.CreatedAt = createdAt
+	brand.UpdatedAt = updatedAt
+
+	brand.Aliases = make([]string, 0)
+	if aliases.Valid {
+		if err := json.Unmarshal([]byte(aliases.String), &brand.Aliases); err != nil {
+			return nil, err
+		}
+		sort.Strings(brand.Aliases)
+	}
+
+	return &brand, nil
+}
@@ -0,0 +1,163 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+var brandColumns = []string{"id", "name", "created_at", "updated_at", "aliases"}
+
+func newMockBrandRepository(t *testing.T) (*BrandRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return &BrandRepository{SqlHandler: &testSqlHandler{db: db}}, mock
+}
+
+func TestBrandRepository_Search(t *testing.T) {
+	now := time.Now()
+
+	t.Run("正常系: 名前か別名の前方一致で名前順に取得", func(t *testing.T) {
+		repo, mock := newMockBrandRepository(t)
+		mock.ExpectQuery(`FROM brands b WHERE b.name LIKE \? OR EXISTS \(SELECT 1 FROM brand_aliases a WHERE a.brand_id = b.id AND a.alias LIKE \?\) ORDER BY b.name, b.id LIMIT \?`).
+			WithArgs(`ro\%%`, `ro\%%`, 10).
+			WillReturnRows(sqlmock.NewRows(brandColumns).
+				AddRow(1, "ROLEX", now, now, `["ロレックス", "Rolex Watch"]`).
+				AddRow(2, "ROLEX TUDOR", now, now, nil))
+
+		brands, err := repo.Search(context.Background(), "ro%", 10)
+
+		require.NoError(t, err)
+		require.Len(t, brands, 2)
+		assert.Equal(t, []string{"Rolex Watch", "ロレックス"}, brands[0].Aliases)
+		assert.Equal(t, []string{}, brands[1].Aliases)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		repo, mock := newMockBrandRepository(t)
+		mock.ExpectQuery(`FROM brands b`).WillReturnError(sql.ErrConnDone)
+
+		brands, err := repo.Search(context.Background(), "ro", 10)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Nil(t, brands)
+	})
+}
+
+func TestBrandRepository_FindByNameOrAlias(t *testing.T) {
+	t.Run("異常系: 名前にも別名にも一致しない", func(t *testing.T) {
+		repo, mock := newMockBrandRepository(t)
+		mock.ExpectQuery(`FROM brands b WHERE b.name = \? OR EXISTS`).
+			WithArgs("ロレ", "ロレ").
+			WillReturnRows(sqlmock.NewRows(brandColumns))
+
+		brand, err := repo.FindByNameOrAlias(context.Background(), "ロレ")
+
+		assert.ErrorIs(t, err, domainErrors.ErrBrandNotFound)
+		assert.Nil(t, brand)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestBrandRepository_Create(t *testing.T) {
+	now := time.Now()
+
+	t.Run("正常系: ブランドと別名を登録", func(t *testing.T) {
+		repo, mock := newMockBrandRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \(SELECT COUNT\(\*\) FROM brands WHERE name IN \(\?, \?\)\) \+ \(SELECT COUNT\(\*\) FROM brand_aliases WHERE alias IN \(\?, \?\)\)`).
+			WithArgs("ROLEX", "ロレックス", "ROLEX", "ロレックス").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec(`INSERT INTO brands \(name\) VALUES \(\?\)`).
+			WithArgs("ROLEX").
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectExec(`INSERT INTO brand_aliases \(brand_id, alias\) VALUES \(\?, \?\)`).
+			WithArgs(int64(3), "ロレックス").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		mock.ExpectQuery(`FROM brands b WHERE b.id = \?`).
+			WithArgs(int64(3)).
+			WillReturnRows(sqlmock.NewRows(brandColumns).AddRow(3, "ROLEX", now, now, `["ロレックス"]`))
+
+		brand, err := repo.Create(context.Background(), &entity.Brand{Name: "ROLEX", Aliases: []string{"ロレックス"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), brand.ID)
+		assert.Equal(t, []string{"ロレックス"}, brand.Aliases)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 名前がほかのブランドの別名として登録済み", func(t *testing.T) {
+		repo, mock := newMockBrandRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \(SELECT COUNT\(\*\) FROM brands`).
+			WithArgs("ロレックス", "ロレックス").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		brand, err := repo.Create(context.Background(), &entity.Brand{Name: "ロレックス", Aliases: []string{}})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+		assert.Nil(t, brand)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestBrandRepository_Merge(t *testing.T) {
+	t.Run("正常系: アイテムのブランド名を書き換え、統合元の名前と別名を統合先の別名にする", func(t *testing.T) {
+		repo, mock := newMockBrandRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT id, name FROM brands WHERE id IN \(\?, \?\) FOR UPDATE`).
+			WithArgs(int64(2), int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "ROLEX").AddRow(2, "Rolex Japan"))
+		mock.ExpectQuery(`SELECT alias FROM brand_aliases WHERE brand_id = \?`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"alias"}).AddRow("ロレックスジャパン"))
+		mock.ExpectExec(`UPDATE items SET brand = \?, version = version \+ 1, updated_at = NOW\(\) WHERE brand IN \(\?, \?\)`).
+			WithArgs("ROLEX", "Rolex Japan", "ロレックスジャパン").
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectExec(`UPDATE brand_aliases SET brand_id = \? WHERE brand_id = \?`).
+			WithArgs(int64(1), int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM brands WHERE id = \?`).
+			WithArgs(int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO brand_aliases \(brand_id, alias\) VALUES \(\?, \?\)`).
+			WithArgs(int64(1), "Rolex Japan").
+			WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectCommit()
+
+		updated, err := repo.Merge(context.Background(), 2, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), updated)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 統合先が存在しない", func(t *testing.T) {
+		repo, mock := newMockBrandRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT id, name FROM brands WHERE id IN`).
+			WithArgs(int64(2), int64(99)).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(2, "Rolex Japan"))
+		mock.ExpectRollback()
+
+		updated, err := repo.Merge(context.Background(), 2, 99)
+
+		assert.ErrorIs(t, err, domainErrors.ErrBrandNotFound)
+		assert.Zero(t, updated)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,207 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type CategoryRepository struct {
+	SqlHandler
+	// 未設定の場合はMySQL
+	Dialect Dialect
+}
+
+func (r *CategoryRepository) dialect() Dialect {
+	return dialectOrDefault(r.Dialect)
+}
+
+const categorySelectColumns = "id, slug, name, name_en, created_at, updated_at"
+
+func (r *CategoryRepository) FindAll(ctx context.Context) ([]*entity.Category, error) {
+	query := `
+        SELECT ` + categorySelectColumns + `
+        FROM categories
+        ORDER BY id ASC
+    `
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	categories := make([]*entity.Category, 0)
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		categories = append(categories, category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryRepository) FindByID(ctx context.Context, id int64) (*entity.Category, error) {
+	query := `
+        SELECT ` + categorySelectColumns + `
+        FROM categories
+        WHERE id = ?
+    `
+
+	category, err := scanCategory(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainErrors.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return category, nil
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	var id int64
+	err := inTx(ctx, r.SqlHandler, r.dialect(), func(tx Transaction) error {
+		if err := ensureCategoryNameAvailable(ctx, tx, category.Name, 0); err != nil {
+			return err
+		}
+		if err := ensureCategorySlugAvailable(ctx, tx, category.Slug); err != nil {
+			return err
+		}
+
+		var err error
+		id, err = insertID(ctx, r.dialect(), tx, `INSERT INTO categories (slug, name, name_en) VALUES (?, ?, ?)`, category.Slug, category.Name, category.NameEn)
+		if err != nil {
+			return wrapWriteError(ctx, r.dialect(), err, domainErrors.ErrDuplicateEntry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// カテゴリー名を変更し、そのカテゴリーのアイテムも同じトランザクションで付け替える
+func (r *CategoryRepository) Rename(ctx context.Context, id int64, name string) (*entity.Category, error) {
+	err := inTx(ctx, r.SqlHandler, r.dialect(), func(tx Transaction) error {
+		current, err := scanCategory(tx.QueryRow(ctx, `SELECT `+categorySelectColumns+` FROM categories WHERE id = ?`+r.dialect().forUpdate(), id))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domainErrors.ErrCategoryNotFound
+			}
+			return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+
+		if current.Name != name {
+			if err := ensureCategoryNameAvailable(ctx, tx, name, id); err != nil {
+				return err
+			}
+
+			if _, err := tx.Execute(ctx, `UPDATE categories SET name = ?, updated_at = `+r.dialect().now()+` WHERE id = ?`, name, id); err != nil {
+				return wrapWriteError(ctx, r.dialect(), err, domainErrors.ErrDuplicateEntry)
+			}
+			if _, err := tx.Execute(ctx, `UPDATE items SET category = ?, updated_at = `+r.dialect().now()+` WHERE category = ?`, name, current.Name); err != nil {
+				return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// アイテムから参照されていない場合のみ削除する。
+// 削除されなかった場合は、存在しないか使用中のため ErrCategoryNotFound か ErrCategoryInUse を返す
+func (r *CategoryRepository) Delete(ctx context.Context, id int64) error {
+	query := `
+        DELETE FROM categories
+        WHERE id = ?
+          AND NOT EXISTS (SELECT 1 FROM items WHERE items.category = categories.name)
+    `
+
+	result, err := r.Execute(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %w", domainErrors.ErrDatabaseError, err)
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	if _, err := r.FindByID(ctx, id); err != nil {
+		return err
+	}
+	return domainErrors.ErrCategoryInUse
+}
+
+func (r *CategoryRepository) CountItems(ctx context.Context, name string) (int, error) {
+	var count int
+	if err := r.QueryRow(ctx, `SELECT COUNT(*) FROM items WHERE category = ?`, name).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return count, nil
+}
+
+// 同じ名前のカテゴリーが登録済みの場合はErrDuplicateEntryを返す。excludeIDのカテゴリーは除く
+func ensureCategoryNameAvailable(ctx context.Context, tx Transaction, name string, excludeID int64) error {
+	var count int
+	query := `SELECT COUNT(*) FROM categories WHERE name = ? AND id <> ?`
+	if err := tx.QueryRow(ctx, query, name, excludeID).Scan(&count); err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: category %s already exists", domainErrors.ErrDuplicateEntry, name)
+	}
+
+	return nil
+}
+
+// 同じスラッグのカテゴリーが登録済みの場合はErrDuplicateEntryを返す
+func ensureCategorySlugAvailable(ctx context.Context, tx Transaction, slug string) error {
+	var count int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM categories WHERE slug = ?`, slug).Scan(&count); err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: category slug %s already exists", domainErrors.ErrDuplicateEntry, slug)
+	}
+
+	return nil
+}
+
+func scanCategory(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Category, error) {
+	var category entity.Category
+	var createdAt, updatedAt time.Time
+
+	if err := scanner.Scan(&category.ID, &category.Slug, &category.Name, &category.NameEn, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	category.CreatedAt = createdAt
+	category.UpdatedAt = updatedAt
+
+	return &category, nil
+}
@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+var categoryColumns = []string{"id", "slug", "name", "name_en", "created_at", "updated_at"}
+
+func newMockCategoryRepository(t *testing.T) (*CategoryRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	itemRepo, mock := newMockRepository(t)
+	return &CategoryRepository{SqlHandler: itemRepo.SqlHandler}, mock
+}
+
+func TestCategoryRepository_FindAll(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo, mock := newMockCategoryRepository(t)
+	mock.ExpectQuery(`SELECT id, slug, name, name_en, created_at, updated_at FROM categories ORDER BY id ASC`).
+		WillReturnRows(sqlmock.NewRows(categoryColumns).
+			AddRow(1, "watch", "時計", "Watch", now, now).
+			AddRow(2, "bag", "バッグ", "Bag", now, now))
+
+	categories, err := repo.FindAll(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, categories, 2)
+	assert.Equal(t, "時計", categories[0].Name)
+	assert.Equal(t, "watch", categories[0].Slug)
+	assert.Equal(t, "Watch", categories[0].NameEn)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCategoryRepository_Create(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: カテゴリーを登録", func(t *testing.T) {
+		repo, mock := newMockCategoryRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM categories WHERE name = \? AND id <> \?`).
+			WithArgs("アクセサリー", int64(0)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM categories WHERE slug = \?`).
+			WithArgs("accessory").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec(`INSERT INTO categories \(slug, name, name_en\) VALUES \(\?, \?, \?\)`).
+			WithArgs("accessory", "アクセサリー", "Accessory").
+			WillReturnResult(sqlmock.NewResult(6, 1))
+		mock.ExpectCommit()
+		mock.ExpectQuery(`SELECT id, slug, name, name_en, created_at, updated_at FROM categories WHERE id = \?`).
+			WithArgs(int64(6)).
+			WillReturnRows(sqlmock.NewRows(categoryColumns).AddRow(6, "accessory", "アクセサリー", "Accessory", now, now))
+
+		category, err := repo.Create(context.Background(), &entity.Category{Slug: "accessory", Name: "アクセサリー", NameEn: "Accessory"})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), category.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 登録済みの名前", func(t *testing.T) {
+		repo, mock := newMockCategoryRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM categories WHERE name = \?`).
+			WithArgs("時計", int64(0)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		category, err := repo.Create(context.Background(), &entity.Category{Slug: "watch", Name: "時計", NameEn: "Watch"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+		assert.Nil(t, category)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 登録済みのスラッグ", func(t *testing.T) {
+		repo, mock := newMockCategoryRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM categories WHERE name = \?`).
+			WithArgs("腕時計", int64(0)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM categories WHERE slug = \?`).
+			WithArgs("watch").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		category, err := repo.Create(context.Background(), &entity.Category{Slug: "watch", Name: "腕時計", NameEn: "Wristwatch"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+		assert.Nil(t, category)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 同時に登録され一意制約に違反した場合もErrDuplicateEntry", func(t *testing.T) {
+		repo, mock := newMockCategoryRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM categories WHERE name = \?`).
+			WithArgs("時計", int64(0)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM categories WHERE slug = \?`).
+			WithArgs("watch").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectExec(`INSERT INTO categories`).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '時計' for key 'uk_name'"})
+		mock.ExpectRollback()
+
+		category, err := repo.Create(context.Background(), &entity.Category{Slug: "watch", Name: "時計", NameEn: "Watch"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+		assert.False(t, domainErrors.IsDatabaseError(err))
+		// ドライバのメッセージ（インデックス名）はレスポンスに含めない
+		assert.NotContains(t, err.Error(), "uk_name")
+		assert.Nil(t, category)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCategoryRepository_Rename(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo, mock := newMockCategoryRepository(t)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id, slug, name, name_en, created_at, updated_at FROM categories WHERE id = \? FOR UPDATE`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(categoryColumns).AddRow(1, "watch", "時計", "Watch", now, now))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM categories WHERE name = \? AND id <> \?`).
+		WithArgs("腕時計", int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(`UPDATE categories SET name = \?, updated_at = NOW\(\) WHERE id = \?`).
+		WithArgs("腕時計", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE items SET category = \?, updated_at = NOW\(\) WHERE category = \?`).
+		WithArgs("腕時計", "時計").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT id, slug, name, name_en, created_at, updated_at FROM categories WHERE id = \?`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(categoryColumns).AddRow(1, "watch", "腕時計", "Watch", now, now))
+
+	category, err := repo.Rename(context.Background(), 1, "腕時計")
+
+	require.NoError(t, err)
+	assert.Equal(t, "腕時計", category.Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCategoryRepository_Delete(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	deleteQuery := `DELETE FROM categories WHERE id = \? AND NOT EXISTS \(SELECT 1 FROM items WHERE items.category = categories.name\)`
+	findQuery := `SELECT id, slug, name, name_en, created_at, updated_at FROM categories WHERE id = \?`
+
+	t.Run("正常系: アイテムのないカテゴリーを削除", func(t *testing.T) {
+		repo, mock := newMockCategoryRepository(t)
+		mock.ExpectExec(deleteQuery).WithArgs(int64(6)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.Delete(context.Background(), 6)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: アイテムがあるカテゴリー", func(t *testing.T) {
+		repo, mock := newMockCategoryRepository(t)
+		mock.ExpectExec(deleteQuery).WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(findQuery).WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows(categoryColumns).AddRow(1, "watch", "時計", "Watch", now, now))
+
+		err := repo.Delete(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrCategoryInUse)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 存在しないカテゴリー", func(t *testing.T) {
+		repo, mock := newMockCategoryRepository(t)
+		mock.ExpectExec(deleteQuery).WithArgs(int64(999)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(findQuery).WithArgs(int64(999)).WillReturnRows(sqlmock.NewRows(categoryColumns))
+
+		err := repo.Delete(context.Background(), 999)
+
+		assert.ErrorIs(t, err, domainErrors.ErrCategoryNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
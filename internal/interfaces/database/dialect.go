@@ -0,0 +1,243 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// データベースごとに異なるSQLの書き方とドライバのエラーの違いを吸収する。
+// リポジトリのDialectが未設定の場合はMySQLとして扱う
+type Dialect interface {
+	// 現在日時を返す式
+	now() string
+	// 行ロックの句。行ロックがない場合は空文字
+	forUpdate() string
+	// exprの値をJSONの配列にまとめる集約関数
+	jsonArrayAgg(expr string) string
+	// exprに大文字小文字を区別するバイナリ比較の照合順序を指定した式
+	binary(expr string) string
+	// 日付の列をstrftime形式の書式（%Y・%mのみ）で文字列にする式
+	formatDate(column, format string) string
+	// LIKE句のエスケープ文字の指定。バックスラッシュが既定のエスケープ文字の場合は空文字
+	likeEscape() string
+	// 一意制約に違反する行を無視するINSERT文の末尾
+	ignoreDuplicates() string
+	// exprの昇順の並び替えでNULLを先頭にする式
+	nullsFirst(expr string) string
+	// rows行を挿入するINSERT文を実行し、採番されたIDを挿入した順に返す
+	insertIDs(ctx context.Context, ex executor, query string, rows int, args []interface{}) ([]int64, error)
+	// 1つの複数行INSERTで挿入する最大の行数
+	maxInsertRows() int
+	// 一意制約違反のエラーであればエラーメッセージを返す
+	duplicateKey(err error) (string, bool)
+	// デッドロックなど、やり直せば成功しうる一時的なエラーかどうか
+	transient(err error) bool
+}
+
+var (
+	MySQL    Dialect = mysqlDialect{}
+	SQLite   Dialect = sqliteDialect{}
+	Postgres Dialect = postgresDialect{}
+)
+
+func dialectOrDefault(d Dialect) Dialect {
+	if d == nil {
+		return MySQL
+	}
+	return d
+}
+
+// MySQLのエラー番号
+const (
+	mysqlErrDuplicateEntry  = 1062 // ER_DUP_ENTRY
+	mysqlErrLockWaitTimeout = 1205 // ER_LOCK_WAIT_TIMEOUT
+	mysqlErrLockDeadlock    = 1213 // ER_LOCK_DEADLOCK
+)
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) now() string { return "NOW()" }
+
+func (mysqlDialect) forUpdate() string { return " FOR UPDATE" }
+
+func (mysqlDialect) jsonArrayAgg(expr string) string { return "JSON_ARRAYAGG(" + expr + ")" }
+
+func (mysqlDialect) binary(expr string) string { return expr + " COLLATE utf8mb4_bin" }
+
+func (mysqlDialect) formatDate(column, format string) string {
+	return "DATE_FORMAT(" + column + ", '" + format + "')"
+}
+
+func (mysqlDialect) likeEscape() string { return "" }
+
+func (mysqlDialect) ignoreDuplicates() string { return " ON DUPLICATE KEY UPDATE id = id" }
+
+func (mysqlDialect) nullsFirst(expr string) string { return expr }
+
+// 複数行INSERTではLastInsertIdは先頭行のIDを返す
+func (mysqlDialect) insertIDs(ctx context.Context, ex executor, query string, rows int, args []interface{}) ([]int64, error) {
+	return lastInsertIDs(ctx, ex, query, rows, args, 0)
+}
+
+// プレースホルダーの数とmax_allowed_packetの上限を超えないようにする
+func (mysqlDialect) maxInsertRows() int { return 500 }
+
+func (mysqlDialect) duplicateKey(err error) (string, bool) {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		return mysqlErr.Message, true
+	}
+	return "", false
+}
+
+func (mysqlDialect) transient(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && (mysqlErr.Number == mysqlErrLockDeadlock || mysqlErr.Number == mysqlErrLockWaitTimeout)
+}
+
+// SQLiteは行ロックの代わりにデータベース全体をロックするため、FOR UPDATEは不要
+type sqliteDialect struct{}
+
+func (sqliteDialect) now() string { return "CURRENT_TIMESTAMP" }
+
+func (sqliteDialect) forUpdate() string { return "" }
+
+func (sqliteDialect) jsonArrayAgg(expr string) string { return "json_group_array(" + expr + ")" }
+
+func (sqliteDialect) binary(expr string) string { return expr + " COLLATE BINARY" }
+
+func (sqliteDialect) formatDate(column, format string) string {
+	return "strftime('" + format + "', " + column + ")"
+}
+
+func (sqliteDialect) likeEscape() string { return ` ESCAPE '\'` }
+
+func (sqliteDialect) ignoreDuplicates() string { return " ON CONFLICT DO NOTHING" }
+
+func (sqliteDialect) nullsFirst(expr string) string { return expr }
+
+// 複数行INSERTではLastInsertIdは最終行のIDを返す
+func (sqliteDialect) insertIDs(ctx context.Context, ex executor, query string, rows int, args []interface{}) ([]int64, error) {
+	return lastInsertIDs(ctx, ex, query, rows, args, rows-1)
+}
+
+// ドライバはプレースホルダーの数の2乗に比例する時間をかけて値を割り当てるため、少ない行数に分ける
+func (sqliteDialect) maxInsertRows() int { return 50 }
+
+func (sqliteDialect) duplicateKey(err error) (string, bool) {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return sqliteErr.Error(), true
+		}
+	}
+	return "", false
+}
+
+// 書き込みはbusy_timeoutの間待つため、やり直さない
+func (sqliteDialect) transient(err error) bool { return false }
+
+// PostgreSQLの一意制約違反(unique_violation)のエラーコード
+const postgresErrUniqueViolation = "23505"
+
+// PostgreSQLではプレースホルダの?をSqlHandlerが$1, $2, ...に置き換える。
+// 大文字小文字を区別しない列はcitextのため、バイナリ比較ではtextに変換する
+type postgresDialect struct{}
+
+func (postgresDialect) now() string { return "NOW()" }
+
+func (postgresDialect) forUpdate() string { return " FOR UPDATE" }
+
+func (postgresDialect) jsonArrayAgg(expr string) string { return "json_agg(" + expr + ")" }
+
+func (postgresDialect) binary(expr string) string { return "CAST(" + expr + ` AS TEXT) COLLATE "C"` }
+
+// strftime形式の書式をto_charの書式に変換する
+var postgresDateFormats = strings.NewReplacer("%Y", "YYYY", "%m", "MM")
+
+func (postgresDialect) formatDate(column, format string) string {
+	return "to_char(" + column + ", '" + postgresDateFormats.Replace(format) + "')"
+}
+
+func (postgresDialect) likeEscape() string { return "" }
+
+func (postgresDialect) ignoreDuplicates() string { return " ON CONFLICT DO NOTHING" }
+
+// PostgreSQLは昇順でNULLを末尾にするため、MySQLに合わせて先頭にする
+func (postgresDialect) nullsFirst(expr string) string { return expr + " NULLS FIRST" }
+
+// LastInsertIdには対応していないため、RETURNINGで採番されたIDを受け取る
+func (postgresDialect) insertIDs(ctx context.Context, ex executor, query string, rows int, args []interface{}) ([]int64, error) {
+	result, err := ex.Query(ctx, query+" RETURNING id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	ids := make([]int64, 0, rows)
+	for result.Next() {
+		var id int64
+		if err := result.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// プレースホルダーの数は65535個まで
+func (postgresDialect) maxInsertRows() int { return 500 }
+
+func (postgresDialect) duplicateKey(err error) (string, bool) {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == postgresErrUniqueViolation {
+		return pqErr.Message, true
+	}
+	return "", false
+}
+
+// デッドロック(40P01)などのエラーは、まだやり直しの対象にしていない
+func (postgresDialect) transient(err error) bool { return false }
+
+// INSERT文を実行し、LastInsertIdから連続したIDを求める。lastOffsetはLastInsertIdが返す行の先頭からの位置
+func lastInsertIDs(ctx context.Context, ex executor, query string, rows int, args []interface{}, lastOffset int) ([]int64, error) {
+	result, err := ex.Execute(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	lastID, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, rows)
+	for i := range ids {
+		ids[i] = lastID - int64(lastOffset) + int64(i)
+	}
+	return ids, nil
+}
+
+// 1行を挿入するINSERT文を実行し、採番されたIDを返す
+func insertID(ctx context.Context, d Dialect, ex executor, query string, args ...interface{}) (int64, error) {
+	ids, err := d.insertIDs(ctx, ex, query, 1, args)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) != 1 {
+		return 0, errors.New("no id was returned for the inserted row")
+	}
+	return ids[0], nil
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemRepository_Postgres(t *testing.T) {
+	newPostgresRepository := func(t *testing.T) (*ItemRepository, sqlmock.Sqlmock) {
+		repo, mock := newMockRepository(t)
+		repo.Dialect = Postgres
+		return repo, mock
+	}
+	newTestItem := func(name, serialNumber string) *entity.Item {
+		item, err := entity.NewItem(entity.NewItemInput{Name: name, Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), SerialNumber: serialNumber, Categories: testCategories})
+		require.NoError(t, err)
+		return item
+	}
+
+	t.Run("正常系: 採番されたIDをRETURNINGで受け取る", func(t *testing.T) {
+		repo, mock := newPostgresRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO items (.+) VALUES \(\?, \?, \?, \?, \?, \?, \?, \?, \?, \?, \?, \?\), \(\?, \?, \?, \?, \?, \?, \?, \?, \?, \?, \?, \?\) RETURNING id`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7).AddRow(8))
+		mock.ExpectCommit()
+
+		ids, err := repo.CreateMany(context.Background(), []*entity.Item{newTestItem("デイトナ", ""), newTestItem("サブマリーナ", "")})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{7, 8}, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: シリアル番号の一意制約違反はErrDuplicateSerialNumber", func(t *testing.T) {
+		repo, mock := newPostgresRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO items (.+) RETURNING id`).
+			WillReturnError(&pq.Error{Code: "23505", Message: `duplicate key value violates unique constraint "uk_serial_number"`})
+		mock.ExpectRollback()
+
+		created, err := repo.Create(context.Background(), newTestItem("デイトナ", "SN-001"))
+
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateSerialNumber)
+		assert.Nil(t, created)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("正常系: 店舗ごとの集計は店舗が未設定の行を先頭にする", func(t *testing.T) {
+		repo, mock := newPostgresRepository(t)
+		mock.ExpectQuery(`ORDER BY purchase_location NULLS FIRST, currency`).
+			WillReturnRows(sqlmock.NewRows([]string{"purchase_location", "currency", "count", "total"}))
+
+		_, err := repo.GetSpendByLocation(context.Background())
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("正常系: 期間の文字列をto_charで作る", func(t *testing.T) {
+		repo, mock := newPostgresRepository(t)
+		mock.ExpectQuery(`SELECT to_char\(purchase_date, 'YYYY-MM'\) AS period`).
+			WillReturnRows(sqlmock.NewRows([]string{"period", "currency", "count", "total"}))
+
+		_, err := repo.GetSpendByPeriod(context.Background(), entity.SpendRange{Granularity: entity.SpendGranularityMonth})
+
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,36 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// items.serial_numberの一意インデックスの名前
+const serialNumberUniqueKey = "uk_serial_number"
+
+// SQLiteの一意制約違反のメッセージに含まれる列名。SQLiteはインデックス名ではなく列名を返す
+const serialNumberColumn = "items.serial_number"
+
+// 書き込み時のドライバのエラーをドメインのエラーに変換する。
+// 一意制約違反はduplicateに、それ以外はErrDatabaseErrorに対応付ける。
+// ドライバのメッセージはインデックス名や制約名を含むため、レスポンスには含めずログにのみ出す
+func wrapWriteError(ctx context.Context, d Dialect, err error, duplicate error) error {
+	if message, ok := d.duplicateKey(err); ok {
+		slog.InfoContext(ctx, "一意制約に違反しました", "error", duplicate, "cause", message)
+		return duplicate
+	}
+	return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+}
+
+// アイテムの書き込み時のエラーを変換する。
+// シリアル番号の一意インデックスへの違反はErrDuplicateSerialNumberに、それ以外の重複はErrDuplicateItemに対応付ける
+func wrapItemWriteError(ctx context.Context, d Dialect, err error) error {
+	if message, ok := d.duplicateKey(err); ok && (strings.Contains(message, serialNumberUniqueKey) || strings.Contains(message, serialNumberColumn)) {
+		return wrapWriteError(ctx, d, err, domainErrors.ErrDuplicateSerialNumber)
+	}
+	return wrapWriteError(ctx, d, err, domainErrors.ErrDuplicateItem)
+}
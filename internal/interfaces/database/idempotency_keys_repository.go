@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+// アイテムの作成と冪等キーの登録を1つのトランザクションで行う。キーはアイテムの所有者のものとして登録する。
+// キーの重複は一意制約で検出するため、同じキーの同時リクエストでは一方のみが成功し、
+// もう一方はErrIdempotencyKeyExistsを返す。createdBefore以前に登録された期限切れのキーは削除してから登録する
+func (r *ItemRepository) CreateWithIdempotencyKey(ctx context.Context, item *entity.Item, key *entity.IdempotencyKey, createdBefore time.Time) (*entity.Item, error) {
+	var id int64
+	ownerID := principal.OwnerID(ctx)
+	err := inTx(ctx, r.SqlHandler, r.dialect(), func(tx Transaction) error {
+		deleteQuery := `DELETE FROM idempotency_keys WHERE user_id = ? AND idempotency_key = ? AND created_at <= ?`
+		if _, err := tx.Execute(ctx, deleteQuery, ownerID, key.Key, createdBefore); err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+
+		itemQuery := `
+        INSERT INTO items (user_id, name, category, brand, purchase_price, currency, purchase_date, serial_number, item_condition, notes, purchase_location, status)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `
+		var err error
+		id, err = insertID(ctx, r.dialect(), tx, itemQuery,
+			ownerID,
+			item.Name,
+			item.Category,
+			item.Brand,
+			item.PurchasePrice,
+			item.Currency,
+			item.PurchaseDate,
+			item.SerialNumber,
+			item.Condition,
+			item.Notes,
+			item.PurchaseLocation,
+			item.Status,
+		)
+		if err != nil {
+			return wrapItemWriteError(ctx, r.dialect(), err)
+		}
+
+		if err := replaceItemTags(ctx, r.dialect(), tx, id, nil, item.Tags); err != nil {
+			return err
+		}
+
+		// 同じキーで処理中のトランザクションがあれば、その完了まで待ってから一意制約で判定される
+		keyQuery := `INSERT INTO idempotency_keys (user_id, idempotency_key, request_hash, item_id) VALUES (?, ?, ?, ?)`
+		if _, err := tx.Execute(ctx, keyQuery, ownerID, key.Key, key.RequestHash, id); err != nil {
+			return wrapWriteError(ctx, r.dialect(), err, domainErrors.ErrIdempotencyKeyExists)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// 呼び出し元のユーザーがcreatedAfterより後に登録した冪等キーを取得する
+func (r *ItemRepository) FindIdempotencyKey(ctx context.Context, key string, createdAfter time.Time) (*entity.IdempotencyKey, error) {
+	query := `
+        SELECT user_id, idempotency_key, request_hash, item_id, created_at
+        FROM idempotency_keys
+        WHERE user_id = ? AND idempotency_key = ? AND created_at > ?
+    `
+
+	var k entity.IdempotencyKey
+	err := r.QueryRow(ctx, query, principal.OwnerID(ctx), key, createdAfter).Scan(&k.UserID, &k.Key, &k.RequestHash, &k.ItemID, &k.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainErrors.ErrIdempotencyKeyNotFound
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return &k, nil
+}
+
+// createdBefore以前に登録された冪等キーを削除し、削除した件数を返す
+func (r *ItemRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, createdBefore time.Time) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE created_at <= ?`
+
+	result, err := r.Execute(ctx, query, createdBefore)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return deleted, nil
+}
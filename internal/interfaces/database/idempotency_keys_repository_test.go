@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+func TestItemRepository_CreateWithIdempotencyKey(t *testing.T) {
+	createdBefore := time.Date(2024, 1, 1, 3, 4, 5, 0, time.UTC)
+	key := &entity.IdempotencyKey{Key: "key-1", RequestHash: "hash"}
+	newItem := func() *entity.Item {
+		item, _ := entity.NewItem(entity.NewItemInput{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), Categories: testCategories})
+		return item
+	}
+
+	t.Run("正常系: 期限切れのキーを削除し、アイテムとキーを同じトランザクションで登録", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		now := time.Now()
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM idempotency_keys WHERE user_id = \? AND idempotency_key = \? AND created_at <= \?`).
+			WithArgs(entity.DefaultUserID, "key-1", createdBefore).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO items`).
+			WithArgs(entity.DefaultUserID, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", "2023-01-15", nil, nil, "", nil, "owned").
+			WillReturnResult(sqlmock.NewResult(10, 1))
+		mock.ExpectExec(`INSERT INTO idempotency_keys \(user_id, idempotency_key, request_hash, item_id\) VALUES \(\?, \?, \?, \?\)`).
+			WithArgs(entity.DefaultUserID, "key-1", "hash", int64(10)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectQuery(`SELECT (.+) FROM items WHERE id = \? AND deleted_at IS NULL`).
+			WithArgs(int64(10)).
+			WillReturnRows(sqlmock.NewRows(itemColumns).
+				AddRow(10, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", "2023-01-15", nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "watch", 1))
+
+		item, err := repo.CreateWithIdempotencyKey(context.Background(), newItem(), key, createdBefore)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), item.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: キーが登録済みの場合はErrIdempotencyKeyExistsを返し、アイテムもロールバック", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM idempotency_keys`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO items`).WillReturnResult(sqlmock.NewResult(10, 1))
+		mock.ExpectExec(`INSERT INTO idempotency_keys`).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'key-1' for key 'PRIMARY'"})
+		mock.ExpectRollback()
+
+		item, err := repo.CreateWithIdempotencyKey(context.Background(), newItem(), key, createdBefore)
+
+		assert.ErrorIs(t, err, domainErrors.ErrIdempotencyKeyExists)
+		assert.NotErrorIs(t, err, domainErrors.ErrDuplicateItem)
+		assert.Nil(t, item)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: アイテムの登録に失敗した場合はロールバック", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM idempotency_keys`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO items`).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		item, err := repo.CreateWithIdempotencyKey(context.Background(), newItem(), key, createdBefore)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Nil(t, item)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemRepository_FindIdempotencyKey(t *testing.T) {
+	createdAfter := time.Date(2024, 1, 1, 3, 4, 5, 0, time.UTC)
+	columns := []string{"user_id", "idempotency_key", "request_hash", "item_id", "created_at"}
+
+	t.Run("正常系: 呼び出し元のユーザーの有効期間内のキーを取得", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		createdAt := createdAfter.Add(time.Hour)
+		mock.ExpectQuery(`SELECT (.+) FROM idempotency_keys WHERE user_id = \? AND idempotency_key = \? AND created_at > \?`).
+			WithArgs(int64(2), "key-1", createdAfter).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(2, "key-1", "hash", 10, createdAt))
+
+		ctx := principal.NewContext(context.Background(), principal.Principal{UserID: 2})
+		key, err := repo.FindIdempotencyKey(ctx, "key-1", createdAfter)
+
+		require.NoError(t, err)
+		assert.Equal(t, &entity.IdempotencyKey{UserID: 2, Key: "key-1", RequestHash: "hash", ItemID: 10, CreatedAt: createdAt}, key)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 見つからない場合はErrIdempotencyKeyNotFound", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectQuery(`SELECT (.+) FROM idempotency_keys`).WillReturnRows(sqlmock.NewRows(columns))
+
+		key, err := repo.FindIdempotencyKey(context.Background(), "key-1", createdAfter)
+
+		assert.ErrorIs(t, err, domainErrors.ErrIdempotencyKeyNotFound)
+		assert.Nil(t, key)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemRepository_DeleteExpiredIdempotencyKeys(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	createdBefore := time.Date(2024, 1, 1, 3, 4, 5, 0, time.UTC)
+	mock.ExpectExec(`DELETE FROM idempotency_keys WHERE created_at <= \?`).
+		WithArgs(createdBefore).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	deleted, err := repo.DeleteExpiredIdempotencyKeys(context.Background(), createdBefore)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,161 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+type ImportJobRepository struct {
+	SqlHandler
+	// 未設定の場合はMySQL
+	Dialect Dialect
+}
+
+func (r *ImportJobRepository) dialect() Dialect {
+	return dialectOrDefault(r.Dialect)
+}
+
+// 終了していないジョブの条件
+const unfinishedImportJobCondition = `status IN ('` + entity.ImportJobStatusQueued + `', '` + entity.ImportJobStatusRunning + `')`
+
+func (r *ImportJobRepository) Create(ctx context.Context, job *entity.ImportJob) (*entity.ImportJob, error) {
+	query := `INSERT INTO import_jobs (user_id, status, best_effort, dry_run) VALUES (?, ?, ?, ?)`
+
+	id, err := insertID(ctx, r.dialect(), r.SqlHandler, query, principal.OwnerID(ctx), job.Status, job.BestEffort, job.DryRun)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// ほかのユーザーのジョブは見つからないものとする
+func (r *ImportJobRepository) FindByID(ctx context.Context, id int64) (*entity.ImportJob, error) {
+	owner, ownerArgs := ownerCondition(ctx, "user_id")
+	query := `
+        SELECT id, user_id, status, best_effort, dry_run, rows_processed, succeeded, failed, row_errors, error, created_at, started_at, finished_at, updated_at
+        FROM import_jobs
+        WHERE id = ?` + owner + `
+    `
+
+	var job entity.ImportJob
+	var rowErrors []byte
+	var startedAt, finishedAt sql.NullTime
+	err := r.QueryRow(ctx, query, append([]interface{}{id}, ownerArgs...)...).Scan(
+		&job.ID,
+		&job.UserID,
+		&job.Status,
+		&job.BestEffort,
+		&job.DryRun,
+		&job.RowsProcessed,
+		&job.Succeeded,
+		&job.Failed,
+		&rowErrors,
+		&job.Error,
+		&job.CreatedAt,
+		&startedAt,
+		&finishedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainErrors.ErrImportJobNotFound
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	job.Errors = []entity.ImportRowError{}
+	if rowErrors != nil {
+		if err := json.Unmarshal(rowErrors, &job.Errors); err != nil {
+			return nil, fmt.Errorf("%w: failed to unmarshal row errors: %w", domainErrors.ErrDatabaseError, err)
+		}
+	}
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+
+	return &job, nil
+}
+
+func (r *ImportJobRepository) Start(ctx context.Context, id int64) error {
+	now := r.dialect().now()
+	query := `UPDATE import_jobs SET status = ?, started_at = ` + now + `, updated_at = ` + now + ` WHERE id = ? AND status = ?`
+
+	if _, err := r.Execute(ctx, query, entity.ImportJobStatusRunning, id, entity.ImportJobStatusQueued); err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+func (r *ImportJobRepository) UpdateProgress(ctx context.Context, id int64, rowsProcessed int) error {
+	query := `UPDATE import_jobs SET rows_processed = ?, updated_at = ` + r.dialect().now() + ` WHERE id = ? AND status = ?`
+
+	if _, err := r.Execute(ctx, query, rowsProcessed, id, entity.ImportJobStatusRunning); err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+func (r *ImportJobRepository) Finish(ctx context.Context, job *entity.ImportJob) error {
+	rowErrors, err := json.Marshal(job.Errors)
+	if err != nil {
+		return fmt.Errorf("%w: failed to marshal row errors: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	now := r.dialect().now()
+	query := `
+        UPDATE import_jobs
+        SET status = ?, rows_processed = ?, succeeded = ?, failed = ?, row_errors = ?, error = ?,
+            finished_at = ` + now + `, updated_at = ` + now + `
+        WHERE id = ? AND ` + unfinishedImportJobCondition
+
+	if _, err := r.Execute(ctx, query, job.Status, job.RowsProcessed, job.Succeeded, job.Failed, string(rowErrors), job.Error, job.ID); err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+func (r *ImportJobRepository) FailUnfinished(ctx context.Context, reason string) (int64, error) {
+	now := r.dialect().now()
+	query := `UPDATE import_jobs SET status = ?, error = ?, finished_at = ` + now + `, updated_at = ` + now + ` WHERE ` + unfinishedImportJobCondition
+
+	result, err := r.Execute(ctx, query, entity.ImportJobStatusFailed, reason)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return updated, nil
+}
+
+func (r *ImportJobRepository) DeleteFinishedBefore(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	result, err := r.Execute(ctx, `DELETE FROM import_jobs WHERE finished_at <= ?`, finishedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// アイテムの変更履歴を新しい順に取得する
+func (r *ItemRepository) FindHistories(ctx context.Context, itemID int64, page entity.Pagination) ([]*entity.ItemHistory, error) {
+	owner, args := historyOwnerCondition(ctx, itemID)
+	query := `
+        SELECT id, item_id, action, before_snapshot, after_snapshot, created_at
+        FROM item_histories
+        WHERE item_id = ?` + owner + `
+        ORDER BY id DESC
+        LIMIT ? OFFSET ?
+    `
+
+	rows, err := r.Query(ctx, query, append(args, page.Limit, page.Offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	histories := make([]*entity.ItemHistory, 0)
+	for rows.Next() {
+		var history entity.ItemHistory
+		var before, after []byte
+		if err := rows.Scan(&history.ID, &history.ItemID, &history.Action, &before, &after, &history.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		if before != nil {
+			history.Before = json.RawMessage(before)
+		}
+		if after != nil {
+			history.After = json.RawMessage(after)
+		}
+		histories = append(histories, &history)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return histories, nil
+}
+
+func (r *ItemRepository) CountHistories(ctx context.Context, itemID int64) (int, error) {
+	owner, args := historyOwnerCondition(ctx, itemID)
+	query := `SELECT COUNT(*) FROM item_histories WHERE item_id = ?` + owner
+
+	var count int
+	if err := r.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return count, nil
+}
+
+// ctxの呼び出し元がユーザーの場合に、そのユーザーのアイテムの履歴に限定する条件と、itemIDを含むプレースホルダの値。
+// 物理削除したアイテムの履歴は所有者が分からないため、ユーザーには返さない
+func historyOwnerCondition(ctx context.Context, itemID int64) (string, []interface{}) {
+	owner, ownerArgs := ownerCondition(ctx, "user_id")
+	if owner == "" {
+		return "", []interface{}{itemID}
+	}
+	return " AND item_id IN (SELECT id FROM items WHERE id = ?" + owner + ")", append([]interface{}{itemID, itemID}, ownerArgs...)
+}
+
+// 変更前後のスナップショットを履歴として記録する
+func (r *ItemRepository) CreateHistory(ctx context.Context, itemID int64, action string, before, after *entity.Item) error {
+	query := `
+        INSERT INTO item_histories (item_id, action, before_snapshot, after_snapshot)
+        VALUES (?, ?, ?, ?)
+    `
+
+	beforeJSON, err := snapshotJSON(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := snapshotJSON(after)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.Execute(ctx, query, itemID, action, beforeJSON, afterJSON); err != nil {
+		return fmt.Errorf("%w: failed to insert item history: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+// スナップショットをJSONに変換する。nilの場合はNULLとして保存する
+func snapshotJSON(item *entity.Item) (interface{}, error) {
+	if item == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to marshal item snapshot: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return string(b), nil
+}
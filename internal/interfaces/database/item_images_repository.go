@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const itemImageSelectColumns = "id, item_id, url, display_order, created_at"
+
+// アイテムの画像を表示順に取得する
+func (r *ItemRepository) FindImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	query := `
+        SELECT ` + itemImageSelectColumns + `
+        FROM item_images
+        WHERE item_id = ?
+        ORDER BY display_order ASC, id ASC
+    `
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	images := make([]*entity.ItemImage, 0)
+	for rows.Next() {
+		var image entity.ItemImage
+		if err := rows.Scan(&image.ID, &image.ItemID, &image.URL, &image.DisplayOrder, &image.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		images = append(images, &image)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return images, nil
+}
+
+// 複数のアイテムの画像を1回のクエリで取得し、アイテムのIDごとに表示順で返す。画像のないアイテムは結果に含まれない
+func (r *ItemRepository) FindImagesByItemIDs(ctx context.Context, itemIDs []int64) (map[int64][]*entity.ItemImage, error) {
+	images := make(map[int64][]*entity.ItemImage)
+	if len(itemIDs) == 0 {
+		return images, nil
+	}
+
+	placeholders := make([]string, len(itemIDs))
+	args := make([]interface{}, len(itemIDs))
+	for i, id := range itemIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := `
+        SELECT ` + itemImageSelectColumns + `
+        FROM item_images
+        WHERE item_id IN (` + strings.Join(placeholders, ", ") + `)
+        ORDER BY item_id ASC, display_order ASC, id ASC
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var image entity.ItemImage
+		if err := rows.Scan(&image.ID, &image.ItemID, &image.URL, &image.DisplayOrder, &image.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		images[image.ItemID] = append(images[image.ItemID], &image)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return images, nil
+}
+
+// 画像を末尾に追加する。アイテムの行をロックし、同時に追加されても上限を超えないようにする
+func (r *ItemRepository) AddImage(ctx context.Context, itemID int64, url string, maxImages int) (*entity.ItemImage, error) {
+	var image entity.ItemImage
+	err := inTx(ctx, r.SqlHandler, r.dialect(), func(tx Transaction) error {
+		if _, err := findItem(ctx, r.dialect(), tx, "id = ? AND deleted_at IS NULL"+r.dialect().forUpdate(), itemID); err != nil {
+			return err
+		}
+
+		var count, maxOrder int
+		countQuery := `SELECT COUNT(*), COALESCE(MAX(display_order), 0) FROM item_images WHERE item_id = ?`
+		if err := tx.QueryRow(ctx, countQuery, itemID).Scan(&count, &maxOrder); err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		if count >= maxImages {
+			return fmt.Errorf("%w: an item can have at most %d images", domainErrors.ErrImageLimitExceeded, maxImages)
+		}
+
+		insertQuery := `INSERT INTO item_images (item_id, url, display_order) VALUES (?, ?, ?)`
+		id, err := insertID(ctx, r.dialect(), tx, insertQuery, itemID, url, maxOrder+1)
+		if err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+
+		selectQuery := `SELECT ` + itemImageSelectColumns + ` FROM item_images WHERE id = ?`
+		if err := tx.QueryRow(ctx, selectQuery, id).Scan(&image.ID, &image.ItemID, &image.URL, &image.DisplayOrder, &image.CreatedAt); err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &image, nil
+}
+
+func (r *ItemRepository) DeleteImage(ctx context.Context, itemID, imageID int64) error {
+	query := `DELETE FROM item_images WHERE id = ? AND item_id = ?`
+
+	result, err := r.Execute(ctx, query, imageID, itemID)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %w", domainErrors.ErrDatabaseError, err)
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrImageNotFound
+	}
+
+	return nil
+}
+
+// imageIDsの順に表示順を振り直す。アイテムの画像と過不足なく一致しない場合はErrInvalidInputを返す
+func (r *ItemRepository) ReorderImages(ctx context.Context, itemID int64, imageIDs []int64) error {
+	return inTx(ctx, r.SqlHandler, r.dialect(), func(tx Transaction) error {
+		rows, err := tx.Query(ctx, `SELECT id FROM item_images WHERE item_id = ?`+r.dialect().forUpdate(), itemID)
+		if err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		current := make(map[int64]bool)
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+			}
+			current[id] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+
+		if len(imageIDs) != len(current) {
+			return fmt.Errorf("%w: image_ids must list all %d images of the item", domainErrors.ErrInvalidInput, len(current))
+		}
+		for _, id := range imageIDs {
+			if !current[id] {
+				return fmt.Errorf("%w: image %d does not belong to the item or is listed twice", domainErrors.ErrInvalidInput, id)
+			}
+			delete(current, id)
+		}
+
+		for i, id := range imageIDs {
+			if _, err := tx.Execute(ctx, `UPDATE item_images SET display_order = ? WHERE id = ?`, i+1, id); err != nil {
+				return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+			}
+		}
+		return nil
+	})
+}
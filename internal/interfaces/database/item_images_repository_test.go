@@ -0,0 +1,172 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+var itemImageColumns = []string{"id", "item_id", "url", "display_order", "created_at"}
+
+func TestItemRepository_FindImages(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo, mock := newMockRepository(t)
+	mock.ExpectQuery(`SELECT id, item_id, url, display_order, created_at FROM item_images WHERE item_id = \? ORDER BY display_order ASC, id ASC`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(itemImageColumns).
+			AddRow(2, 1, "/images/item_1_2.jpg", 1, now).
+			AddRow(1, 1, "/images/item_1_1.jpg", 2, now))
+
+	images, err := repo.FindImages(context.Background(), 1)
+
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+	assert.Equal(t, int64(2), images[0].ID)
+	assert.Equal(t, 1, images[0].DisplayOrder)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_AddImage(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	lockItem := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT .+ FROM items WHERE id = \? AND deleted_at IS NULL FOR UPDATE`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows(itemColumns).AddRow(1, "時計1", "時計", "ROLEX", 1000000, "JPY", now, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "watch", 1))
+	}
+
+	t.Run("正常系: 末尾の表示順で追加", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		lockItem(mock)
+		mock.ExpectQuery(`SELECT COUNT\(\*\), COALESCE\(MAX\(display_order\), 0\) FROM item_images WHERE item_id = \?`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"count", "max"}).AddRow(2, 2))
+		mock.ExpectExec(`INSERT INTO item_images \(item_id, url, display_order\) VALUES \(\?, \?, \?\)`).
+			WithArgs(int64(1), "/images/item_1_3.jpg", 3).
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectQuery(`SELECT id, item_id, url, display_order, created_at FROM item_images WHERE id = \?`).
+			WithArgs(int64(3)).
+			WillReturnRows(sqlmock.NewRows(itemImageColumns).AddRow(3, 1, "/images/item_1_3.jpg", 3, now))
+		mock.ExpectCommit()
+
+		image, err := repo.AddImage(context.Background(), 1, "/images/item_1_3.jpg", 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), image.ID)
+		assert.Equal(t, 3, image.DisplayOrder)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 上限に達している", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		lockItem(mock)
+		mock.ExpectQuery(`SELECT COUNT\(\*\)`).
+			WillReturnRows(sqlmock.NewRows([]string{"count", "max"}).AddRow(10, 10))
+		mock.ExpectRollback()
+
+		image, err := repo.AddImage(context.Background(), 1, "/images/item_1_11.jpg", 10)
+
+		assert.ErrorIs(t, err, domainErrors.ErrImageLimitExceeded)
+		assert.Nil(t, image)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 存在しないアイテム", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .+ FROM items WHERE id = \? AND deleted_at IS NULL FOR UPDATE`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows(itemColumns))
+		mock.ExpectRollback()
+
+		image, err := repo.AddImage(context.Background(), 1, "/images/item_1_1.jpg", 10)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.Nil(t, image)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemRepository_DeleteImage(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		expectedErr  error
+	}{
+		{name: "正常系: 画像を削除", rowsAffected: 1},
+		{name: "異常系: 存在しない画像", rowsAffected: 0, expectedErr: domainErrors.ErrImageNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+			mock.ExpectExec(`DELETE FROM item_images WHERE id = \? AND item_id = \?`).
+				WithArgs(int64(2), int64(1)).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			err := repo.DeleteImage(context.Background(), 1, 2)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestItemRepository_ReorderImages(t *testing.T) {
+	currentImages := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT id FROM item_images WHERE item_id = \? FOR UPDATE`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).AddRow(3))
+	}
+
+	t.Run("正常系: 指定順に表示順を振り直す", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		currentImages(mock)
+		for i, id := range []int64{3, 1, 2} {
+			mock.ExpectExec(`UPDATE item_images SET display_order = \? WHERE id = \?`).
+				WithArgs(i+1, id).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectCommit()
+
+		err := repo.ReorderImages(context.Background(), 1, []int64{3, 1, 2})
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	tests := []struct {
+		name     string
+		imageIDs []int64
+	}{
+		{name: "異常系: 画像が不足している", imageIDs: []int64{3, 1}},
+		{name: "異常系: 他のアイテムの画像を含む", imageIDs: []int64{3, 1, 9}},
+		{name: "異常系: 同じ画像を重複して指定", imageIDs: []int64{3, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+			mock.ExpectBegin()
+			currentImages(mock)
+			mock.ExpectRollback()
+
+			err := repo.ReorderImages(context.Background(), 1, tt.imageIDs)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -3,177 +3,1003 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
 )
 
 type ItemRepository struct {
 	SqlHandler
+	// 未設定の場合はMySQL
+	Dialect Dialect
 }
 
-func (r *ItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
+func (r *ItemRepository) dialect() Dialect {
+	return dialectOrDefault(r.Dialect)
+}
+
+// scanItemと同じ順序で並べたSELECT対象の列。FROM itemsのクエリで使い、タグはJSONの配列として、カテゴリーのスラッグはcategoriesから取得する
+func itemSelectColumns(d Dialect) string {
+	return "id, name, category, brand, purchase_price, currency, purchase_date, serial_number, item_condition, notes, purchase_location, status, selling_price, sold_date, version, created_at, updated_at, deleted_at, " +
+		"(SELECT " + d.jsonArrayAgg("t.name") + " FROM item_tags it JOIN tags t ON t.id = it.tag_id WHERE it.item_id = items.id), " +
+		"(SELECT c.slug FROM categories c WHERE c.name = items.category), " +
+		"user_id"
+}
+
+// ctxの呼び出し元がユーザーの場合に、columnの所有者がそのユーザーのアイテムに限定する条件と値。
+// 先頭に" AND "を付けて返し、限定しない場合は空文字列を返す。
+// ほかのユーザーのアイテムは存在しないものとして扱い、存在の有無も分からないようにする
+func ownerCondition(ctx context.Context, column string) (string, []interface{}) {
+	userID, ok := principal.OwnerScope(ctx)
+	if !ok {
+		return "", nil
+	}
+	return " AND " + column + " = ?", []interface{}{userID}
+}
+
+func (r *ItemRepository) FindAll(ctx context.Context, filter entity.ItemFilter, sort entity.ItemSort, page entity.Pagination) ([]*entity.Item, error) {
+	where, args := buildItemFilter(ctx, filter, r.dialect())
+	if page.After != nil {
+		condition, afterArgs := buildItemKeyset(sort, *page.After)
+		where = andCondition(where, condition)
+		args = append(args, afterArgs...)
+	}
 	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
+        SELECT ` + itemSelectColumns(r.dialect()) + `
+        FROM items` + where + buildItemOrderBy(sort) + `
+        LIMIT ? OFFSET ?
+    `
+	args = append(args, page.Limit, page.Offset)
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	// 範囲外のoffsetでもエラーにせず空のスライスを返す
+	items := make([]*entity.Item, 0)
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return items, nil
+}
+
+// 1行ずつ読み込んでfnに渡す。読み込み中はコネクションを使い続けるため、fnの中でクエリを実行しない
+func (r *ItemRepository) EachItem(ctx context.Context, filter entity.ItemFilter, sort entity.ItemSort, fn func(*entity.Item) error) error {
+	where, args := buildItemFilter(ctx, filter, r.dialect())
+	query := `
+        SELECT ` + itemSelectColumns(r.dialect()) + `
+        FROM items` + where + buildItemOrderBy(sort)
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+func (r *ItemRepository) Count(ctx context.Context, filter entity.ItemFilter) (int, error) {
+	where, args := buildItemFilter(ctx, filter, r.dialect())
+	query := `SELECT COUNT(*) FROM items` + where
+
+	var count int
+	if err := r.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return count, nil
+}
+
+// 論理削除したアイテムも対象とするため、論理削除でupdated_atを更新していれば削除も結果に反映される。
+// 集計関数ではなく並び替えで取得し、updated_atのインデックスを使うとともに、SQLiteでも列の型のまま読み込む
+func (r *ItemRepository) MaxUpdatedAt(ctx context.Context, filter entity.ItemFilter) (*time.Time, error) {
+	filter.IncludeDeleted = true
+	where, args := buildItemFilter(ctx, filter, r.dialect())
+	query := `SELECT updated_at FROM items` + where + ` ORDER BY updated_at DESC LIMIT 1`
+
+	var updatedAt time.Time
+	if err := r.QueryRow(ctx, query, args...).Scan(&updatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return &updatedAt, nil
+}
+
+// updated_atのインデックスで範囲を絞り込み、その順に読み込む。登録時はupdated_atもcreated_atと同じ日時になるため、
+// 期間内に登録したアイテムもこの条件で含まれる
+func (r *ItemRepository) FindUpdatedSince(ctx context.Context, since time.Time, limit int) ([]*entity.Item, error) {
+	owner, ownerArgs := ownerCondition(ctx, "user_id")
+	query := `
+        SELECT ` + itemSelectColumns(r.dialect()) + `
         FROM items
-        ORDER BY created_at DESC
+        WHERE updated_at >= ? AND deleted_at IS NULL` + owner + `
+        ORDER BY updated_at DESC, id DESC
+        LIMIT ?
     `
+	args := append(append([]interface{}{since}, ownerArgs...), limit)
 
-	rows, err := r.Query(ctx, query)
+	rows, err := r.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
 	}
 	defer rows.Close()
 
-	var items []*entity.Item
+	items := make([]*entity.Item, 0)
 	for rows.Next() {
 		item, err := scanItem(rows)
 		if err != nil {
-			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
 		}
 		items = append(items, item)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
 	}
 
 	return items, nil
 }
 
 func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	owner, ownerArgs := ownerCondition(ctx, "user_id")
 	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
+        SELECT ` + itemSelectColumns(r.dialect()) + `
         FROM items
-        WHERE id = ?
-    `
+        WHERE id = ? AND deleted_at IS NULL` + owner
 
-	row := r.QueryRow(ctx, query, id)
+	row := r.QueryRow(ctx, query, append([]interface{}{id}, ownerArgs...)...)
 
 	item, err := scanItem(row)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domainErrors.ErrItemNotFound
 		}
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
 	}
 
 	return item, nil
 }
 
-func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+// WithinTxのトランザクション内では、取得した行をトランザクションの終了までロックする
+func (r *ItemRepository) FindByIDForUpdate(ctx context.Context, id int64) (*entity.Item, error) {
+	owner, ownerArgs := ownerCondition(ctx, "user_id")
+	return findItem(ctx, r.dialect(), r.SqlHandler, "id = ? AND deleted_at IS NULL"+owner+r.dialect().forUpdate(), append([]interface{}{id}, ownerArgs...)...)
+}
+
+// シリアル番号が一致するアイテムを取得する。比較は列の照合順序に従う
+func (r *ItemRepository) FindBySerialNumber(ctx context.Context, serialNumber string) (*entity.Item, error) {
+	owner, ownerArgs := ownerCondition(ctx, "user_id")
+	query := `
+        SELECT ` + itemSelectColumns(r.dialect()) + `
+        FROM items
+        WHERE serial_number = ? AND deleted_at IS NULL` + owner
+
+	item, err := scanItem(r.QueryRow(ctx, query, append([]interface{}{serialNumber}, ownerArgs...)...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return item, nil
+}
+
+// 指定したIDのアイテムを取得する。存在しないIDは結果に含まれず、順序は保証しない
+func (r *ItemRepository) FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	items := make([]*entity.Item, 0, len(ids))
+	if len(ids) == 0 {
+		return items, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	owner, ownerArgs := ownerCondition(ctx, "user_id")
+	args = append(args, ownerArgs...)
+	query := `
+        SELECT ` + itemSelectColumns(r.dialect()) + `
+        FROM items
+        WHERE id IN (` + strings.Join(placeholders, ", ") + `) AND deleted_at IS NULL` + owner
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return items, nil
+}
+
+// 購入日が同じアイテムをID順に取得する。重複登録の確認に使う
+func (r *ItemRepository) FindByPurchaseDate(ctx context.Context, purchaseDate entity.PurchaseDate) ([]*entity.Item, error) {
+	owner, ownerArgs := ownerCondition(ctx, "user_id")
 	query := `
-        INSERT INTO items (name, category, brand, purchase_price, purchase_date)
-        VALUES (?, ?, ?, ?, ?)
+        SELECT ` + itemSelectColumns(r.dialect()) + `
+        FROM items
+        WHERE purchase_date = ? AND deleted_at IS NULL` + owner + `
+        ORDER BY id
     `
 
-	result, err := r.Execute(ctx, query,
-		item.Name,
-		item.Category,
-		item.Brand,
-		item.PurchasePrice,
-		item.PurchaseDate,
-	)
+	rows, err := r.Query(ctx, query, append([]interface{}{purchaseDate}, ownerArgs...)...)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
 	}
+	defer rows.Close()
+
+	items := make([]*entity.Item, 0)
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return items, nil
+}
 
-	id, err := result.LastInsertId()
+// アイテムとタグを1つのトランザクションで作成する。所有者はctxの呼び出し元のユーザーにする
+func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	query := `
+        INSERT INTO items (user_id, name, category, brand, purchase_price, currency, purchase_date, serial_number, item_condition, notes, purchase_location, status)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	var id int64
+	err := inTx(ctx, r.SqlHandler, r.dialect(), func(tx Transaction) error {
+		var err error
+		id, err = insertID(ctx, r.dialect(), tx, query,
+			principal.OwnerID(ctx),
+			item.Name,
+			item.Category,
+			item.Brand,
+			item.PurchasePrice,
+			item.Currency,
+			item.PurchaseDate,
+			item.SerialNumber,
+			item.Condition,
+			item.Notes,
+			item.PurchaseLocation,
+			item.Status,
+		)
+		if err != nil {
+			return wrapItemWriteError(ctx, r.dialect(), err)
+		}
+
+		return replaceItemTags(ctx, r.dialect(), tx, id, nil, item.Tags)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, err
 	}
 
 	return r.FindByID(ctx, id)
 }
 
-func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
+// 複数のアイテムを1つのトランザクション内の複数行INSERTで作成し、採番されたIDを入力と同じ順序で返す。
+// INSERTはDialectの上限の行数ずつに分けて実行する
+func (r *ItemRepository) CreateMany(ctx context.Context, items []*entity.Item) ([]int64, error) {
+	if len(items) == 0 {
+		return []int64{}, nil
+	}
+
+	ids := make([]int64, 0, len(items))
+	err := inTx(ctx, r.SqlHandler, r.dialect(), func(tx Transaction) error {
+		// やり直した場合に前回の途中までのIDを残さない
+		ids = ids[:0]
+		chunkSize := r.dialect().maxInsertRows()
+		for start := 0; start < len(items); start += chunkSize {
+			chunk := items[start:min(start+chunkSize, len(items))]
+			chunkIDs, err := r.insertItems(ctx, tx, chunk)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, chunkIDs...)
+		}
+
+		for i, item := range items {
+			if err := replaceItemTags(ctx, r.dialect(), tx, ids[i], nil, item.Tags); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// itemsを1つの複数行INSERTで登録し、採番されたIDを入力と同じ順序で返す
+func (r *ItemRepository) insertItems(ctx context.Context, tx Transaction, items []*entity.Item) ([]int64, error) {
+	ownerID := principal.OwnerID(ctx)
+	placeholders := make([]string, 0, len(items))
+	args := make([]interface{}, 0, len(items)*12)
+	for _, item := range items {
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args,
+			ownerID,
+			item.Name,
+			item.Category,
+			item.Brand,
+			item.PurchasePrice,
+			item.Currency,
+			item.PurchaseDate,
+			item.SerialNumber,
+			item.Condition,
+			item.Notes,
+			item.PurchaseLocation,
+			item.Status,
+		)
+	}
+
+	query := `
+        INSERT INTO items (user_id, name, category, brand, purchase_price, currency, purchase_date, serial_number, item_condition, notes, purchase_location, status)
+        VALUES ` + strings.Join(placeholders, ", ")
+
+	// 採番されたIDは入力と同じ順序で連続する
+	ids, err := r.dialect().insertIDs(ctx, tx, query, len(items), args)
+	if err != nil {
+		return nil, wrapItemWriteError(ctx, r.dialect(), err)
+	}
+	return ids, nil
+}
+
+func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	query := `
+        UPDATE items
+        SET name = ?, category = ?, brand = ?, purchase_price = ?, currency = ?, purchase_date = ?, serial_number = ?, item_condition = ?, notes = ?, purchase_location = ?, status = ?, selling_price = ?, sold_date = ?,
+            version = version + 1, updated_at = ` + r.dialect().now() + `
+        WHERE id = ? AND version = ?
+    `
+
+	change, err := r.change(ctx, item.ID, "deleted_at IS NULL", true, func(tx Transaction, before *entity.Item) error {
+		// 読み込んだ後に別のリクエストで更新されていれば上書きしない
+		if before.Version != item.Version {
+			return domainErrors.NewVersionConflictError(before.Version)
+		}
+
+		result, err := tx.Execute(ctx, query,
+			item.Name,
+			item.Category,
+			item.Brand,
+			item.PurchasePrice,
+			item.Currency,
+			item.PurchaseDate,
+			item.SerialNumber,
+			item.Condition,
+			item.Notes,
+			item.PurchaseLocation,
+			item.Status,
+			item.SellingPrice,
+			item.SoldDate,
+			item.ID,
+			item.Version,
+		)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return domainErrors.NewVersionConflictError(before.Version)
+		}
+
+		return replaceItemTags(ctx, r.dialect(), tx, item.ID, before.Tags, item.Tags)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return change.After, nil
+}
+
+// 論理削除。deleted_atを設定し、以降の取得・集計の対象から外す。
+// 一覧のLast-Modifiedに削除を反映するため、updated_atも更新する（MySQLではON UPDATEでも更新される）
+func (r *ItemRepository) Delete(ctx context.Context, id int64) (*entity.ItemChange, error) {
+	query := `UPDATE items SET deleted_at = ` + r.dialect().now() + `, updated_at = ` + r.dialect().now() + ` WHERE id = ?`
+
+	return r.change(ctx, id, "deleted_at IS NULL", true, func(tx Transaction, _ *entity.Item) error {
+		_, err := tx.Execute(ctx, query, id)
+		return err
+	})
+}
+
+// 論理削除したアイテムを元に戻す。論理削除と同じくupdated_atも更新する
+func (r *ItemRepository) Restore(ctx context.Context, id int64) (*entity.ItemChange, error) {
+	query := `UPDATE items SET deleted_at = NULL, updated_at = ` + r.dialect().now() + ` WHERE id = ?`
+
+	return r.change(ctx, id, "deleted_at IS NOT NULL", true, func(tx Transaction, _ *entity.Item) error {
+		_, err := tx.Execute(ctx, query, id)
+		return err
+	})
+}
+
+// 物理削除。論理削除済みのアイテムも対象とし、ほかのアイテムに付いていないタグも削除する
+func (r *ItemRepository) HardDelete(ctx context.Context, id int64) (*entity.ItemChange, error) {
 	query := `DELETE FROM items WHERE id = ?`
 
-	result, err := r.Execute(ctx, query, id)
+	return r.change(ctx, id, "", false, func(tx Transaction, before *entity.Item) error {
+		if err := replaceItemTags(ctx, r.dialect(), tx, id, before.Tags, nil); err != nil {
+			return err
+		}
+		_, err := tx.Execute(ctx, query, id)
+		return err
+	})
+}
+
+// アイテムを1つのトランザクションで変更し、変更前後のアイテムを返す。
+// condition に一致するctxの所有者の変更前の行をロックして取得し、存在しなければErrItemNotFoundを返す。changeには変更前のアイテムを渡す。
+// withAfterがfalseの場合（物理削除）は変更後のアイテムを取得しない
+func (r *ItemRepository) change(ctx context.Context, id int64, condition string, withAfter bool, change func(tx Transaction, before *entity.Item) error) (*entity.ItemChange, error) {
+	beforeCondition := "id = ?"
+	if condition != "" {
+		beforeCondition += " AND " + condition
+	}
+	owner, ownerArgs := ownerCondition(ctx, "user_id")
+	beforeArgs := append([]interface{}{id}, ownerArgs...)
+
+	var result entity.ItemChange
+	err := inTx(ctx, r.SqlHandler, r.dialect(), func(tx Transaction) error {
+		before, err := findItem(ctx, r.dialect(), tx, beforeCondition+owner+r.dialect().forUpdate(), beforeArgs...)
+		if err != nil {
+			return err
+		}
+
+		if err := change(tx, before); err != nil {
+			if errors.Is(err, domainErrors.ErrVersionConflict) || domainErrors.IsDatabaseError(err) {
+				return err
+			}
+			return wrapItemWriteError(ctx, r.dialect(), err)
+		}
+
+		result = entity.ItemChange{Before: before}
+		if withAfter {
+			result.After, err = findItem(ctx, r.dialect(), tx, "id = ?", id)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	return &result, nil
+}
+
+// 条件に一致するアイテムを1件取得する
+func findItem(ctx context.Context, d Dialect, q executor, condition string, args ...interface{}) (*entity.Item, error) {
+	query := `
+        SELECT ` + itemSelectColumns(d) + `
+        FROM items
+        WHERE ` + condition
+
+	item, err := scanItem(q.QueryRow(ctx, query, args...))
 	if err != nil {
-		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
 	}
 
-	if rowsAffected == 0 {
-		return domainErrors.ErrItemNotFound
+	return item, nil
+}
+
+func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) ([]*entity.CategoryCurrencyTotal, error) {
+	// カテゴリーを起点にLEFT JOINし、アイテムが0件のカテゴリーも0件の行として返す
+	// SUMはDECIMALで返るためint64で受け取り、合計のオーバーフローを避ける
+	owner, ownerArgs := ownerCondition(ctx, "i.user_id")
+	query := `
+        SELECT c.name,
+               COALESCE(i.currency, ''),
+               COALESCE(i.item_condition, ''),
+               COALESCE(i.status, ''),
+               COUNT(i.id),
+               COALESCE(SUM(i.purchase_price), 0)
+        FROM categories c
+        LEFT JOIN items i ON i.category = c.name AND i.deleted_at IS NULL` + owner + `
+        GROUP BY c.id, c.name, i.currency, i.item_condition, i.status
+        ORDER BY c.id, i.currency, ` + r.dialect().nullsFirst("i.item_condition") + `, i.status
+    `
+
+	rows, err := r.Query(ctx, query, ownerArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
 	}
+	defer rows.Close()
 
-	return nil
+	totals := make([]*entity.CategoryCurrencyTotal, 0)
+	for rows.Next() {
+		var total entity.CategoryCurrencyTotal
+		if err := rows.Scan(&total.Category, &total.Currency, &total.Condition, &total.Status, &total.Count, &total.TotalPrice); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		totals = append(totals, &total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return totals, nil
 }
 
-func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+// 売却価格を記録した売却済みのアイテムを通貨ごとに集計する。yearが0でない場合はその年に売却したアイテムのみ集計する
+func (r *ItemRepository) GetProfitByCurrency(ctx context.Context, year int) ([]*entity.ProfitCurrencyTotal, error) {
+	owner, ownerArgs := ownerCondition(ctx, "user_id")
 	query := `
-        UPDATE items 
-        SET name = ?, brand = ?, purchase_price = ?, updated_at = NOW()
-        WHERE id = ?
+        SELECT currency, COUNT(*), SUM(selling_price), SUM(purchase_price)
+        FROM items
+        WHERE status = ? AND selling_price IS NOT NULL AND deleted_at IS NULL` + owner
+	args := append([]interface{}{entity.ItemStatusSold}, ownerArgs...)
+
+	// sold_dateのインデックスを使えるよう、YEAR()ではなく日付の範囲で比較する
+	if year != 0 {
+		query += ` AND sold_date >= ? AND sold_date < ?`
+		args = append(args, fmt.Sprintf("%04d-01-01", year), fmt.Sprintf("%04d-01-01", year+1))
+	}
+	query += `
+        GROUP BY currency
+        ORDER BY currency
     `
 
-	result, err := r.Execute(ctx, query,
-		item.Name,
-		item.Brand,
-		item.PurchasePrice,
-		item.ID,
-	)
+	rows, err := r.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	totals := make([]*entity.ProfitCurrencyTotal, 0)
+	for rows.Next() {
+		var total entity.ProfitCurrencyTotal
+		if err := rows.Scan(&total.Currency, &total.Count, &total.SellingPrice, &total.PurchasePrice); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		totals = append(totals, &total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	return totals, nil
+}
+
+// 論理削除されていないアイテムを購入店舗ごと・通貨ごとに集計する。売却済みのアイテムも含める
+func (r *ItemRepository) GetSpendByLocation(ctx context.Context) ([]*entity.LocationCurrencyTotal, error) {
+	owner, ownerArgs := ownerCondition(ctx, "user_id")
+	query := `
+        SELECT purchase_location, currency, COUNT(*), SUM(purchase_price)
+        FROM items
+        WHERE deleted_at IS NULL` + owner + `
+        GROUP BY purchase_location, currency
+        ORDER BY ` + r.dialect().nullsFirst("purchase_location") + `, currency
+    `
+
+	rows, err := r.Query(ctx, query, ownerArgs...)
 	if err != nil {
-		return nil, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
 	}
+	defer rows.Close()
 
-	if rowsAffected == 0 {
-		return nil, domainErrors.ErrItemNotFound
+	totals := make([]*entity.LocationCurrencyTotal, 0)
+	for rows.Next() {
+		var total entity.LocationCurrencyTotal
+		if err := rows.Scan(&total.PurchaseLocation, &total.Currency, &total.Count, &total.TotalPrice); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		totals = append(totals, &total)
 	}
 
-	// 更新後のアイテムを取得して返す
-	return r.FindByID(ctx, item.ID)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return totals, nil
+}
+
+// ブランドの集計に使うキー。大文字小文字のみが異なるブランドをまとめ、それ以外（アクセント記号など）は区別する
+func brandGroupKey(d Dialect) string {
+	return d.binary("LOWER(brand)")
+}
+
+// 所有中・出品中のアイテムをブランドごと・通貨ごとに集計する
+func (r *ItemRepository) GetSummaryByBrand(ctx context.Context) ([]*entity.BrandCurrencyTotal, error) {
+	// 表示するブランド名はグループ内でバイナリ順が最小のものにして、結果を一定にする
+	owner, ownerArgs := ownerCondition(ctx, "user_id")
+	query := `
+        SELECT ` + brandGroupKey(r.dialect()) + ` AS brand_key, MIN(` + r.dialect().binary("brand") + `), currency, COUNT(*), SUM(purchase_price)
+        FROM items
+        WHERE deleted_at IS NULL AND status <> ?` + owner + `
+        GROUP BY brand_key, currency
+        ORDER BY brand_key, currency
+    `
+
+	rows, err := r.Query(ctx, query, append([]interface{}{entity.ItemStatusSold}, ownerArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	totals := make([]*entity.BrandCurrencyTotal, 0)
+	for rows.Next() {
+		var total entity.BrandCurrencyTotal
+		if err := rows.Scan(&total.BrandKey, &total.Brand, &total.Currency, &total.Count, &total.TotalPrice); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		totals = append(totals, &total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return totals, nil
+}
+
+// 所有中・出品中のアイテムのうち、ブランドごと・通貨ごとに購入価格が最も高いアイテムを取得する
+func (r *ItemRepository) FindMostExpensiveByBrand(ctx context.Context) ([]*entity.ItemPrice, error) {
+	owner, ownerArgs := ownerCondition(ctx, "user_id")
+	query := `
+        SELECT brand_key, id, name, purchase_price, currency
+        FROM (
+            SELECT ` + brandGroupKey(r.dialect()) + ` AS brand_key, id, name, purchase_price, currency,
+                   ROW_NUMBER() OVER (PARTITION BY ` + brandGroupKey(r.dialect()) + `, currency ORDER BY purchase_price DESC, id ASC) AS price_rank
+            FROM items
+            WHERE deleted_at IS NULL AND status <> ?` + owner + `
+        ) ranked
+        WHERE price_rank = 1
+        ORDER BY brand_key, currency
+    `
+
+	rows, err := r.Query(ctx, query, append([]interface{}{entity.ItemStatusSold}, ownerArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	items := make([]*entity.ItemPrice, 0)
+	for rows.Next() {
+		var item entity.ItemPrice
+		if err := rows.Scan(&item.BrandKey, &item.ID, &item.Name, &item.PurchasePrice, &item.Currency); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		items = append(items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return items, nil
+}
+
+// 期間の形式ごとの日付の書式。SQLにはこの一覧の値のみを埋め込む
+var spendPeriodFormats = map[string]string{
+	entity.SpendGranularityMonth: "%Y-%m",
+	entity.SpendGranularityYear:  "%Y",
 }
 
-func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
+// 論理削除されていないアイテムを購入日の期間ごと・通貨ごとに集計する。売却済みのアイテムも含める
+func (r *ItemRepository) GetSpendByPeriod(ctx context.Context, spendRange entity.SpendRange) ([]*entity.PeriodCurrencyTotal, error) {
+	format, ok := spendPeriodFormats[spendRange.Granularity]
+	if !ok {
+		format = spendPeriodFormats[entity.SpendGranularityMonth]
+	}
+
+	// purchase_dateの範囲で絞り込んでインデックスを使い、期間の文字列はSQLで作る
+	owner, ownerArgs := ownerCondition(ctx, "user_id")
 	query := `
-        SELECT category, COUNT(*) as count
+        SELECT ` + r.dialect().formatDate("purchase_date", format) + ` AS period, currency, COUNT(*), SUM(purchase_price)
         FROM items
-        GROUP BY category
+        WHERE deleted_at IS NULL AND purchase_date >= ? AND purchase_date < ?` + owner + `
+        GROUP BY period, currency
+        ORDER BY period, currency
     `
 
-	rows, err := r.Query(ctx, query)
+	args := append([]interface{}{spendRange.From.Format("2006-01-02"), spendRange.End().Format("2006-01-02")}, ownerArgs...)
+	rows, err := r.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
 	}
 	defer rows.Close()
 
-	summary := make(map[string]int)
+	totals := make([]*entity.PeriodCurrencyTotal, 0)
 	for rows.Next() {
-		var category string
-		var count int
-		if err := rows.Scan(&category, &count); err != nil {
-			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		var total entity.PeriodCurrencyTotal
+		if err := rows.Scan(&total.Period, &total.Currency, &total.Count, &total.TotalPrice); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
 		}
-		summary[category] = count
+		totals = append(totals, &total)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
 	}
 
-	return summary, nil
+	return totals, nil
+}
+
+// 絞り込み条件に一致するアイテムをカテゴリーごと・通貨ごとに集計する
+func (r *ItemRepository) GetStatsByCategory(ctx context.Context, filter entity.ItemFilter) ([]*entity.CategoryCurrencyStats, error) {
+	where, args := buildItemFilter(ctx, filter, r.dialect())
+	query := `
+        SELECT category, currency, COUNT(*), SUM(purchase_price), MAX(purchase_date)
+        FROM items` + where + `
+        GROUP BY category, currency
+        ORDER BY category, currency
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	stats := make([]*entity.CategoryCurrencyStats, 0)
+	for rows.Next() {
+		var s entity.CategoryCurrencyStats
+		if err := rows.Scan(&s.Category, &s.Currency, &s.Count, &s.TotalPrice, &s.LatestPurchaseDate); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		stats = append(stats, &s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return stats, nil
+}
+
+// 絞り込み条件に一致するアイテムのうち、通貨ごとに購入価格が最も高いアイテムを取得する。
+// 通貨が異なる価格はSQLでは比較できないため、通貨ごとに1件ずつ返す
+func (r *ItemRepository) FindMostExpensiveByCurrency(ctx context.Context, filter entity.ItemFilter) ([]*entity.ItemPrice, error) {
+	where, args := buildItemFilter(ctx, filter, r.dialect())
+	query := `
+        SELECT id, name, purchase_price, currency
+        FROM (
+            SELECT id, name, purchase_price, currency,
+                   ROW_NUMBER() OVER (PARTITION BY currency ORDER BY purchase_price DESC, id ASC) AS price_rank
+            FROM items` + where + `
+        ) ranked
+        WHERE price_rank = 1
+        ORDER BY currency
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	items := make([]*entity.ItemPrice, 0)
+	for rows.Next() {
+		var item entity.ItemPrice
+		if err := rows.Scan(&item.ID, &item.Name, &item.PurchasePrice, &item.Currency); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		items = append(items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return items, nil
+}
+
+// 絞り込み条件とctxの所有者からWHERE句とプレースホルダの値を組み立てる
+func buildItemFilter(ctx context.Context, filter entity.ItemFilter, d Dialect) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if owner, ownerArgs := ownerCondition(ctx, "user_id"); owner != "" {
+		conditions = append(conditions, strings.TrimPrefix(owner, " AND "))
+		args = append(args, ownerArgs...)
+	}
+
+	if !filter.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	if filter.OwnerID != nil {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, *filter.OwnerID)
+	}
+
+	if filter.Category != "" {
+		conditions = append(conditions, "category = ?")
+		args = append(args, filter.Category)
+	}
+
+	if filter.Condition != "" {
+		conditions = append(conditions, "item_condition = ?")
+		args = append(args, filter.Condition)
+	}
+
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+
+	// 指定したタグごとに条件を加え、すべてのタグが付いたアイテムに絞り込む
+	for _, tag := range filter.Tags {
+		conditions = append(conditions, "id IN (SELECT it.item_id FROM item_tags it JOIN tags t ON t.id = it.tag_id WHERE t.name = ?)")
+		args = append(args, tag)
+	}
+
+	if filter.PurchaseLocation != "" {
+		conditions = append(conditions, "purchase_location = ?")
+		args = append(args, filter.PurchaseLocation)
+	}
+
+	if filter.Brand != "" {
+		conditions = append(conditions, "LOWER(brand) LIKE ?"+d.likeEscape())
+		args = append(args, "%"+escapeLike(strings.ToLower(filter.Brand))+"%")
+	}
+
+	if filter.Keyword != "" {
+		keyword := "%" + escapeLike(strings.ToLower(filter.Keyword)) + "%"
+		conditions = append(conditions, "(LOWER(name) LIKE ?"+d.likeEscape()+" OR LOWER(brand) LIKE ?"+d.likeEscape()+")")
+		args = append(args, keyword, keyword)
+	}
+
+	if filter.MinPrice != nil {
+		conditions = append(conditions, "purchase_price >= ?")
+		args = append(args, *filter.MinPrice)
+	}
+
+	if filter.MaxPrice != nil {
+		conditions = append(conditions, "purchase_price <= ?")
+		args = append(args, *filter.MaxPrice)
+	}
+
+	// DATE型の列とYYYY-MM-DD形式で比較する
+	if filter.PurchasedFrom != nil {
+		conditions = append(conditions, "purchase_date >= ?")
+		args = append(args, filter.PurchasedFrom.Format("2006-01-02"))
+	}
+
+	if filter.PurchasedTo != nil {
+		conditions = append(conditions, "purchase_date <= ?")
+		args = append(args, filter.PurchasedTo.Format("2006-01-02"))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// 並び替え項目と列名の対応。SQLにはこの一覧の値のみを埋め込む
+var itemSortColumns = map[string]string{
+	entity.SortByPurchasePrice: "purchase_price",
+	entity.SortByPurchaseDate:  "purchase_date",
+	entity.SortByName:          "name",
+	entity.SortByCreatedAt:     "created_at",
+}
+
+// 並び替え指定からORDER BY句を組み立てる。同値の場合はidで順序を確定させる
+func buildItemOrderBy(sort entity.ItemSort) string {
+	sort = sort.WithDefaults()
+
+	column, ok := itemSortColumns[sort.Field]
+	if !ok {
+		column = "created_at"
+	}
+
+	direction := "ASC"
+	if sort.Order == entity.SortOrderDesc {
+		direction = "DESC"
+	}
+
+	return "\n        ORDER BY " + column + " " + direction + ", id " + direction
+}
+
+// 並び替えの順でafterより後のアイテムに絞り込む条件。並び替えの項目が同値の場合はidで比べる
+func buildItemKeyset(sort entity.ItemSort, after entity.ItemCursor) (string, []interface{}) {
+	sort = sort.WithDefaults()
+
+	column, ok := itemSortColumns[sort.Field]
+	if !ok {
+		column = "created_at"
+	}
+
+	operator := ">"
+	if sort.Order == entity.SortOrderDesc {
+		operator = "<"
+	}
+
+	condition := "(" + column + " " + operator + " ? OR (" + column + " = ? AND id " + operator + " ?))"
+	return condition, []interface{}{after.Value, after.Value, after.ID}
+}
+
+// buildItemFilterのWHERE句にconditionを加える
+func andCondition(where, condition string) string {
+	if where == "" {
+		return " WHERE " + condition
+	}
+	return where + " AND " + condition
+}
+
+// LIKE句で特殊な意味を持つ文字をエスケープ
+func escapeLike(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return replacer.Replace(s)
 }
 
 func scanItem(scanner interface {
 	Scan(dest ...interface{}) error
 }) (*entity.Item, error) {
 	var item entity.Item
-	var purchaseDate time.Time
 	var createdAt, updatedAt time.Time
+	var deletedAt sql.NullTime
+	var tags []byte
+	var categorySlug sql.NullString
 
 	err := scanner.Scan(
 		&item.ID,
@@ -181,19 +1007,44 @@ func scanItem(scanner interface {
 		&item.Category,
 		&item.Brand,
 		&item.PurchasePrice,
-		&purchaseDate,
+		&item.Currency,
+		&item.PurchaseDate,
+		&item.SerialNumber,
+		&item.Condition,
+		&item.Notes,
+		&item.PurchaseLocation,
+		&item.Status,
+		&item.SellingPrice,
+		&item.SoldDate,
+		&item.Version,
 		&createdAt,
 		&updatedAt,
+		&deletedAt,
+		&tags,
+		&categorySlug,
+		&item.UserID,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	// DATE型をYYYY-MM-DD形式の文字列に変換
-	item.PurchaseDate = purchaseDate.Format("2006-01-02")
+	// タグがない場合はNULL（SQLiteでは空の配列）になる。集約関数は順序を保証しないため並べ直す
+	if tags != nil {
+		if err := json.Unmarshal(tags, &item.Tags); err != nil {
+			return nil, err
+		}
+		if len(item.Tags) == 0 {
+			item.Tags = nil
+		}
+		sort.Strings(item.Tags)
+	}
 
+	item.CategorySlug = categorySlug.String
 	item.CreatedAt = createdAt
 	item.UpdatedAt = updatedAt
+	if deletedAt.Valid {
+		item.DeletedAt = &deletedAt.Time
+	}
 
 	return &item, nil
 }
@@ -0,0 +1,1280 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// testSqlHandler は*sql.DBをSqlHandlerとして扱うテスト用アダプタ
+type testSqlHandler struct {
+	db *sql.DB
+}
+
+func (h *testSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	return h.db.ExecContext(ctx, statement, args...)
+}
+
+func (h *testSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	return h.db.QueryContext(ctx, statement, args...)
+}
+
+func (h *testSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	return h.db.QueryRowContext(ctx, statement, args...)
+}
+
+func (h *testSqlHandler) Begin(ctx context.Context) (Transaction, error) {
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &testTx{tx: tx}, nil
+}
+
+func (h *testSqlHandler) Close() error {
+	return h.db.Close()
+}
+
+type testTx struct {
+	tx *sql.Tx
+}
+
+func (t *testTx) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	return t.tx.ExecContext(ctx, statement, args...)
+}
+
+func (t *testTx) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	return t.tx.QueryContext(ctx, statement, args...)
+}
+
+func (t *testTx) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	return t.tx.QueryRowContext(ctx, statement, args...)
+}
+
+func (t *testTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *testTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+func newMockRepository(t *testing.T) (*ItemRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return &ItemRepository{SqlHandler: &testSqlHandler{db: db}}, mock
+}
+
+var itemColumns = []string{"id", "name", "category", "brand", "purchase_price", "currency", "purchase_date", "serial_number", "item_condition", "notes", "purchase_location", "status", "selling_price", "sold_date", "version", "created_at", "updated_at", "deleted_at", "tags", "category_slug", "user_id"}
+
+func TestItemRepository_FindAll(t *testing.T) {
+	now := time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC)
+	purchaseDate := time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		filter        entity.ItemFilter
+		sort          entity.ItemSort
+		page          entity.Pagination
+		expectedQuery string
+		expectedArgs  []driver.Value
+		rows          *sqlmock.Rows
+		expectedCount int
+	}{
+		{
+			name:          "正常系: 指定したページのアイテムを取得",
+			page:          entity.Pagination{Limit: 2, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{2, 0},
+			rows: sqlmock.NewRows(itemColumns).
+				AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "watch", 1).
+				AddRow(2, "エルメス バーキン", "バッグ", "HERMÈS", 2000000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "bag", 1),
+			expectedCount: 2,
+		},
+		{
+			name:          "正常系: offsetが範囲外の場合は空のスライス",
+			page:          entity.Pagination{Limit: 50, Offset: 1000},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{50, 1000},
+			rows:          sqlmock.NewRows(itemColumns),
+			expectedCount: 0,
+		},
+		{
+			name:          "正常系: カーソルより後のアイテムを昇順で取得",
+			sort:          entity.ItemSort{Field: entity.SortByPurchasePrice, Order: entity.SortOrderAsc},
+			page:          entity.Pagination{Limit: 2, After: &entity.ItemCursor{Value: int64(1500000), ID: 1}},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND \(purchase_price > \? OR \(purchase_price = \? AND id > \?\)\) ORDER BY purchase_price ASC, id ASC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{int64(1500000), int64(1500000), int64(1), 2, 0},
+			rows: sqlmock.NewRows(itemColumns).
+				AddRow(2, "エルメス バーキン", "バッグ", "HERMÈS", 2000000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "bag", 1),
+			expectedCount: 1,
+		},
+		{
+			name:          "正常系: カーソルより後のアイテムを降順で取得",
+			filter:        entity.ItemFilter{IncludeDeleted: true},
+			page:          entity.Pagination{Limit: 2, After: &entity.ItemCursor{Value: now, ID: 2}},
+			expectedQuery: `SELECT (.+) FROM items WHERE \(created_at < \? OR \(created_at = \? AND id < \?\)\) ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{now, now, int64(2), 2, 0},
+			rows: sqlmock.NewRows(itemColumns).
+				AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "watch", 1),
+			expectedCount: 1,
+		},
+		{
+			name:          "正常系: カテゴリーで絞り込み",
+			filter:        entity.ItemFilter{Category: "時計"},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND category = \? ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{"時計", 50, 0},
+			rows: sqlmock.NewRows(itemColumns).
+				AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "watch", 1),
+			expectedCount: 1,
+		},
+		{
+			name:          "正常系: 状態で絞り込み",
+			filter:        entity.ItemFilter{Condition: "中古A"},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND item_condition = \? ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{"中古A", 50, 0},
+			rows: sqlmock.NewRows(itemColumns).
+				AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", purchaseDate, nil, "中古A", "", nil, "owned", nil, nil, 1, now, now, nil, nil, "watch", 1),
+			expectedCount: 1,
+		},
+		{
+			name:          "正常系: 所有状況で絞り込み",
+			filter:        entity.ItemFilter{Status: "listed"},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND status = \? ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{"listed", 50, 0},
+			rows: sqlmock.NewRows(itemColumns).
+				AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", purchaseDate, nil, nil, "", nil, "listed", nil, nil, 1, now, now, nil, nil, "watch", 1),
+			expectedCount: 1,
+		},
+		{
+			name:          "正常系: タグで絞り込み（すべてのタグが付いたアイテム）",
+			filter:        entity.ItemFilter{Tags: []string{"限定品", "プレゼント"}},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND id IN \(SELECT it.item_id FROM item_tags it JOIN tags t ON t.id = it.tag_id WHERE t.name = \?\) AND id IN \(SELECT it.item_id FROM item_tags it JOIN tags t ON t.id = it.tag_id WHERE t.name = \?\) ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{"限定品", "プレゼント", 50, 0},
+			rows: sqlmock.NewRows(itemColumns).
+				AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, `["限定品", "プレゼント"]`, "watch", 1),
+			expectedCount: 1,
+		},
+		{
+			name:          "正常系: 購入店舗で絞り込み",
+			filter:        entity.ItemFilter{PurchaseLocation: "銀座 本店"},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND purchase_location = \? ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{"銀座 本店", 50, 0},
+			rows: sqlmock.NewRows(itemColumns).
+				AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", purchaseDate, nil, nil, "", "銀座 本店", "owned", nil, nil, 1, now, now, nil, nil, "watch", 1),
+			expectedCount: 1,
+		},
+		{
+			name:          "正常系: ブランドで部分一致（大文字小文字を区別しない）",
+			filter:        entity.ItemFilter{Brand: "Hermès"},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND LOWER\(brand\) LIKE \? ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{"%hermès%", 50, 0},
+			rows: sqlmock.NewRows(itemColumns).
+				AddRow(2, "エルメス バーキン", "バッグ", "HERMÈS", 2000000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "bag", 1),
+			expectedCount: 1,
+		},
+		{
+			name:          "正常系: カテゴリーとブランドをANDで組み合わせ",
+			filter:        entity.ItemFilter{Category: "バッグ", Brand: "hermès"},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND category = \? AND LOWER\(brand\) LIKE \? ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{"バッグ", "%hermès%", 50, 0},
+			rows: sqlmock.NewRows(itemColumns).
+				AddRow(2, "エルメス バーキン", "バッグ", "HERMÈS", 2000000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "bag", 1),
+			expectedCount: 1,
+		},
+		{
+			name:          "正常系: 価格範囲で絞り込み（境界値を含む）",
+			filter:        entity.ItemFilter{MinPrice: int64Ptr(100000), MaxPrice: int64Ptr(500000)},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND purchase_price >= \? AND purchase_price <= \? ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{100000, 500000, 50, 0},
+			rows: sqlmock.NewRows(itemColumns).
+				AddRow(3, "ティファニー ネックレス", "ジュエリー", "Tiffany & Co.", 300000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "jewelry", 1),
+			expectedCount: 1,
+		},
+		{
+			name:          "正常系: カテゴリー・ブランド・下限価格の組み合わせ",
+			filter:        entity.ItemFilter{Category: "時計", Brand: "rolex", MinPrice: int64Ptr(0)},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND category = \? AND LOWER\(brand\) LIKE \? AND purchase_price >= \? ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{"時計", "%rolex%", 0, 50, 0},
+			rows:          sqlmock.NewRows(itemColumns),
+			expectedCount: 0,
+		},
+		{
+			name: "正常系: 購入日の範囲で絞り込み",
+			filter: entity.ItemFilter{
+				PurchasedFrom: timePtr(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+				PurchasedTo:   timePtr(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)),
+			},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND purchase_date >= \? AND purchase_date <= \? ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{"2023-01-01", "2023-12-31", 50, 0},
+			rows: sqlmock.NewRows(itemColumns).
+				AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "watch", 1),
+			expectedCount: 1,
+		},
+		{
+			name:          "正常系: 購入日の下限のみ指定（RFC3339はYYYY-MM-DDに正規化）",
+			filter:        entity.ItemFilter{PurchasedFrom: timePtr(time.Date(2023, 6, 1, 15, 30, 0, 0, time.UTC))},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND purchase_date >= \? ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{"2023-06-01", 50, 0},
+			rows:          sqlmock.NewRows(itemColumns),
+			expectedCount: 0,
+		},
+		{
+			name:          "正常系: キーワードで名前とブランドを検索",
+			filter:        entity.ItemFilter{Keyword: "Birkin"},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND \(LOWER\(name\) LIKE \? OR LOWER\(brand\) LIKE \?\) ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{"%birkin%", "%birkin%", 50, 0},
+			rows: sqlmock.NewRows(itemColumns).
+				AddRow(2, "エルメス Birkin", "バッグ", "HERMÈS", 2000000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "bag", 1),
+			expectedCount: 1,
+		},
+		{
+			name:          "正常系: 日本語のキーワードとカテゴリーを組み合わせて検索",
+			filter:        entity.ItemFilter{Category: "時計", Keyword: "デイトナ"},
+			page:          entity.Pagination{Limit: 10, Offset: 10},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND category = \? AND \(LOWER\(name\) LIKE \? OR LOWER\(brand\) LIKE \?\) ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{"時計", "%デイトナ%", "%デイトナ%", 10, 10},
+			rows: sqlmock.NewRows(itemColumns).
+				AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "watch", 1),
+			expectedCount: 1,
+		},
+		{
+			name:          "正常系: 購入価格の昇順で並び替え",
+			sort:          entity.ItemSort{Field: entity.SortByPurchasePrice, Order: entity.SortOrderAsc},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL ORDER BY purchase_price ASC, id ASC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{50, 0},
+			rows:          sqlmock.NewRows(itemColumns),
+			expectedCount: 0,
+		},
+		{
+			name:          "正常系: 購入日の降順で並び替え",
+			sort:          entity.ItemSort{Field: entity.SortByPurchaseDate, Order: entity.SortOrderDesc},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL ORDER BY purchase_date DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{50, 0},
+			rows:          sqlmock.NewRows(itemColumns),
+			expectedCount: 0,
+		},
+		{
+			name:          "正常系: インジェクションを試みる値はプレースホルダで渡される",
+			filter:        entity.ItemFilter{Brand: "%' OR '1'='1"},
+			page:          entity.Pagination{Limit: 50, Offset: 0},
+			expectedQuery: `SELECT (.+) FROM items WHERE deleted_at IS NULL AND LOWER\(brand\) LIKE \? ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`,
+			expectedArgs:  []driver.Value{`%\%' or '1'='1%`, 50, 0},
+			rows:          sqlmock.NewRows(itemColumns),
+			expectedCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+			mock.ExpectQuery(tt.expectedQuery).
+				WithArgs(tt.expectedArgs...).
+				WillReturnRows(tt.rows)
+
+			items, err := repo.FindAll(context.Background(), tt.filter, tt.sort, tt.page)
+
+			require.NoError(t, err)
+			require.NotNil(t, items)
+			assert.Len(t, items, tt.expectedCount)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestItemRepository_FindAll_DatabaseError(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	mock.ExpectQuery(`SELECT (.+) FROM items`).WillReturnError(sql.ErrConnDone)
+
+	items, err := repo.FindAll(context.Background(), entity.ItemFilter{}, entity.ItemSort{}, entity.Pagination{Limit: 50})
+
+	assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	assert.Nil(t, items)
+}
+
+func TestItemRepository_FindAll_IncludeDeleted(t *testing.T) {
+	now := time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC)
+	purchaseDate := time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	repo, mock := newMockRepository(t)
+	mock.ExpectQuery(`SELECT (.+) FROM items ORDER BY created_at DESC, id DESC LIMIT \? OFFSET \?`).
+		WithArgs(50, 0).
+		WillReturnRows(sqlmock.NewRows(itemColumns).
+			AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, now, nil, "watch", 1))
+
+	items, err := repo.FindAll(context.Background(), entity.ItemFilter{IncludeDeleted: true}, entity.ItemSort{}, entity.Pagination{Limit: 50})
+
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.NotNil(t, items[0].DeletedAt)
+	assert.Equal(t, now, *items[0].DeletedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_FindByID_ExcludesDeleted(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	mock.ExpectQuery(`SELECT (.+) FROM items WHERE id = \? AND deleted_at IS NULL`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(itemColumns))
+
+	item, err := repo.FindByID(context.Background(), 1)
+
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	assert.Nil(t, item)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// WithinTxの中では開始済みのトランザクションで行をロックする
+func TestItemRepository_FindByIDForUpdate(t *testing.T) {
+	transactor, mock := newMockTransactor(t)
+	repo := &ItemRepository{SqlHandler: transactor}
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM items WHERE id = \? AND deleted_at IS NULL FOR UPDATE$`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(itemColumns).
+			AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", "2023-01-15", nil, nil, "", nil, "owned", nil, nil, 3, now, now, nil, nil, "watch", 1))
+	mock.ExpectCommit()
+
+	err := transactor.WithinTx(context.Background(), func(ctx context.Context) error {
+		item, err := repo.FindByIDForUpdate(ctx, 1)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, int64(3), item.Version)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_FindByPurchaseDate(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	now := time.Now()
+	mock.ExpectQuery(`SELECT (.+) FROM items WHERE purchase_date = \? AND deleted_at IS NULL ORDER BY id`).
+		WithArgs("2023-01-15").
+		WillReturnRows(sqlmock.NewRows(itemColumns).
+			AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", "2023-01-15", nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "watch", 1).
+			AddRow(3, "オメガ スピードマスター", "時計", "OMEGA", 800000, "JPY", "2023-01-15", nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "watch", 1))
+
+	items, err := repo.FindByPurchaseDate(context.Background(), entity.MustParsePurchaseDate("2023-01-15"))
+
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, int64(1), items[0].ID)
+	assert.Equal(t, int64(3), items[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_FindBySerialNumber(t *testing.T) {
+	t.Run("正常系: シリアル番号で取得", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		now := time.Now()
+		mock.ExpectQuery(`SELECT (.+) FROM items WHERE serial_number = \? AND deleted_at IS NULL`).
+			WithArgs("SN-001").
+			WillReturnRows(sqlmock.NewRows(itemColumns).
+				AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", "2023-01-15", "SN-001", nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "watch", 1))
+
+		item, err := repo.FindBySerialNumber(context.Background(), "SN-001")
+
+		require.NoError(t, err)
+		require.NotNil(t, item.SerialNumber)
+		assert.Equal(t, "SN-001", *item.SerialNumber)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 見つからない場合はErrItemNotFound", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectQuery(`SELECT (.+) FROM items WHERE serial_number = \?`).WillReturnRows(sqlmock.NewRows(itemColumns))
+
+		item, err := repo.FindBySerialNumber(context.Background(), "SN-999")
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.Nil(t, item)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemRepository_Create_DuplicateSerialNumber(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	item, _ := entity.NewItem(entity.NewItemInput{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), SerialNumber: "SN-001", Categories: testCategories})
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO items`).
+		WithArgs(entity.DefaultUserID, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", "2023-01-15", "SN-001", nil, "", nil, "owned").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'SN-001' for key 'items.uk_serial_number'"})
+	mock.ExpectRollback()
+
+	created, err := repo.Create(context.Background(), item)
+
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateSerialNumber)
+	assert.NotErrorIs(t, err, domainErrors.ErrDuplicateItem)
+	assert.True(t, domainErrors.IsConflictError(err))
+	assert.False(t, domainErrors.IsDatabaseError(err))
+	assert.Nil(t, created)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_FindByID_Tags(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	now := time.Now()
+	mock.ExpectQuery(`SELECT (.+) FROM items WHERE id = \? AND deleted_at IS NULL`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(itemColumns).
+			AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", "2023-01-15", nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, `["限定品", "プレゼント"]`, "watch", 1))
+
+	item, err := repo.FindByID(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"プレゼント", "限定品"}, item.Tags)
+	assert.Equal(t, "watch", item.CategorySlug)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_Create_Tags(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	now := time.Now()
+	item, _ := entity.NewItem(entity.NewItemInput{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), Tags: []string{"限定品", "プレゼント"}, Categories: testCategories})
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO items`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO tags \(name\) VALUES \(\?\), \(\?\) ON DUPLICATE KEY UPDATE id = id`).
+		WithArgs("プレゼント", "限定品").
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectExec(`INSERT INTO item_tags \(item_id, tag_id\) SELECT \?, id FROM tags WHERE name IN \(\?, \?\)`).
+		WithArgs(int64(1), "プレゼント", "限定品").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectQuery(`SELECT (.+) FROM items WHERE id = \? AND deleted_at IS NULL`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(itemColumns).
+			AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", "2023-01-15", nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, `["限定品", "プレゼント"]`, "watch", 1))
+
+	created, err := repo.Create(context.Background(), item)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"プレゼント", "限定品"}, created.Tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_Update_Tags(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	itemRow := func(tags interface{}) *sqlmock.Rows {
+		return sqlmock.NewRows(itemColumns).
+			AddRow(1, "時計1", "時計", "ROLEX", 1000000, "JPY", now, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, tags, "watch", 1)
+	}
+	updated := &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-01"), Status: entity.ItemStatusOwned, Tags: []string{"プレゼント", "限定品"}, Version: 1}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT .+ FROM items WHERE id = \? AND deleted_at IS NULL FOR UPDATE$`).
+		WithArgs(int64(1)).
+		WillReturnRows(itemRow(`["限定品", "福袋"]`))
+	mock.ExpectExec(`UPDATE items SET`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// 外したタグの関連を削除し、ほかのアイテムに付いていなければタグも削除する
+	mock.ExpectExec(`DELETE FROM item_tags WHERE item_id = \? AND tag_id IN \(SELECT id FROM tags WHERE name IN \(\?\)\)`).
+		WithArgs(int64(1), "福袋").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM tags WHERE name IN \(\?\) AND NOT EXISTS \(SELECT 1 FROM item_tags it WHERE it.tag_id = tags.id\)`).
+		WithArgs("福袋").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// 付いていたタグはそのままで、追加したタグのみ登録する
+	mock.ExpectExec(`INSERT INTO tags \(name\) VALUES \(\?\) ON DUPLICATE KEY UPDATE id = id`).
+		WithArgs("プレゼント").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec(`INSERT INTO item_tags \(item_id, tag_id\) SELECT \?, id FROM tags WHERE name IN \(\?\)`).
+		WithArgs(int64(1), "プレゼント").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT .+ FROM items WHERE id = \?$`).
+		WithArgs(int64(1)).
+		WillReturnRows(itemRow(`["限定品", "プレゼント"]`))
+	mock.ExpectCommit()
+
+	item, err := repo.Update(context.Background(), updated)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"プレゼント", "限定品"}, item.Tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_HardDelete_Tags(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT .+ FROM items WHERE id = \? FOR UPDATE$`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(itemColumns).
+			AddRow(1, "時計1", "時計", "ROLEX", 1000000, "JPY", now, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, `["限定品"]`, "watch", 1))
+	mock.ExpectExec(`DELETE FROM item_tags WHERE item_id = \?`).
+		WithArgs(int64(1), "限定品").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM tags WHERE name IN \(\?\)`).
+		WithArgs("限定品").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM items WHERE id = \?`).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	change, err := repo.HardDelete(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"限定品"}, change.Before.Tags)
+	assert.Nil(t, change.After)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_Change(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	serialNumber := "SN-001"
+	itemRow := func(deletedAt interface{}) *sqlmock.Rows {
+		return sqlmock.NewRows(itemColumns).
+			AddRow(1, "時計1", "時計", "ROLEX", 1000000, "JPY", now, nil, nil, "", nil, "owned", nil, nil, 1, now, now, deletedAt, nil, "watch", 1)
+	}
+	updated := &entity.Item{ID: 1, Name: "時計2", Category: "時計", Brand: "OMEGA", PurchasePrice: 500000, Currency: "USD", PurchaseDate: entity.MustParsePurchaseDate("2023-02-20"), SerialNumber: &serialNumber, Status: entity.ItemStatusOwned, Version: 1}
+
+	tests := []struct {
+		name            string
+		call            func(*ItemRepository) (*entity.ItemChange, error)
+		beforeCondition string
+		beforeDeletedAt interface{}
+		expectedQuery   string
+		expectedArgs    []driver.Value
+		afterDeletedAt  interface{}
+		hardDelete      bool
+	}{
+		{
+			name: "正常系: 更新",
+			call: func(r *ItemRepository) (*entity.ItemChange, error) {
+				after, err := r.Update(context.Background(), updated)
+				return &entity.ItemChange{After: after}, err
+			},
+			beforeCondition: `id = \? AND deleted_at IS NULL FOR UPDATE`,
+			expectedQuery:   `UPDATE items SET name = \?, category = \?, brand = \?, purchase_price = \?, currency = \?, purchase_date = \?, serial_number = \?, item_condition = \?, notes = \?, purchase_location = \?, status = \?, selling_price = \?, sold_date = \?, version = version \+ 1, updated_at = NOW\(\) WHERE id = \? AND version = \?`,
+			expectedArgs:    []driver.Value{"時計2", "時計", "OMEGA", 500000, "USD", "2023-02-20", "SN-001", nil, "", nil, "owned", nil, nil, int64(1), int64(1)},
+		},
+		{
+			name:            "正常系: 論理削除",
+			call:            func(r *ItemRepository) (*entity.ItemChange, error) { return r.Delete(context.Background(), 1) },
+			beforeCondition: `id = \? AND deleted_at IS NULL FOR UPDATE`,
+			expectedQuery:   `UPDATE items SET deleted_at = NOW\(\), updated_at = NOW\(\) WHERE id = \?`,
+			expectedArgs:    []driver.Value{int64(1)},
+			afterDeletedAt:  now,
+		},
+		{
+			name:            "正常系: 復元",
+			call:            func(r *ItemRepository) (*entity.ItemChange, error) { return r.Restore(context.Background(), 1) },
+			beforeCondition: `id = \? AND deleted_at IS NOT NULL FOR UPDATE`,
+			beforeDeletedAt: now,
+			expectedQuery:   `UPDATE items SET deleted_at = NULL, updated_at = NOW\(\) WHERE id = \?`,
+			expectedArgs:    []driver.Value{int64(1)},
+		},
+		{
+			name:            "正常系: 物理削除",
+			call:            func(r *ItemRepository) (*entity.ItemChange, error) { return r.HardDelete(context.Background(), 1) },
+			beforeCondition: `id = \? FOR UPDATE`,
+			expectedQuery:   `DELETE FROM items WHERE id = \?`,
+			expectedArgs:    []driver.Value{int64(1)},
+			hardDelete:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+			mock.ExpectBegin()
+			mock.ExpectQuery(`SELECT .+ FROM items WHERE ` + tt.beforeCondition + `$`).
+				WithArgs(int64(1)).
+				WillReturnRows(itemRow(tt.beforeDeletedAt))
+			mock.ExpectExec(tt.expectedQuery).
+				WithArgs(tt.expectedArgs...).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			if !tt.hardDelete {
+				mock.ExpectQuery(`SELECT .+ FROM items WHERE id = \?$`).
+					WithArgs(int64(1)).
+					WillReturnRows(itemRow(tt.afterDeletedAt))
+			}
+			mock.ExpectCommit()
+
+			change, err := tt.call(repo)
+
+			require.NoError(t, err)
+			if tt.hardDelete {
+				assert.Nil(t, change.After)
+			} else {
+				require.NotNil(t, change.After)
+				assert.Equal(t, tt.afterDeletedAt != nil, change.After.DeletedAt != nil)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestItemRepository_Change_NotFound(t *testing.T) {
+	tests := []struct {
+		name            string
+		call            func(*ItemRepository) error
+		beforeCondition string
+	}{
+		{
+			name: "異常系: 論理削除済みのアイテムを削除",
+			call: func(r *ItemRepository) error {
+				_, err := r.Delete(context.Background(), 1)
+				return err
+			},
+			beforeCondition: `id = \? AND deleted_at IS NULL FOR UPDATE`,
+		},
+		{
+			name: "異常系: 削除されていないアイテムを復元",
+			call: func(r *ItemRepository) error {
+				_, err := r.Restore(context.Background(), 1)
+				return err
+			},
+			beforeCondition: `id = \? AND deleted_at IS NOT NULL FOR UPDATE`,
+		},
+		{
+			name: "異常系: 存在しないアイテムを更新",
+			call: func(r *ItemRepository) error {
+				_, err := r.Update(context.Background(), &entity.Item{ID: 1})
+				return err
+			},
+			beforeCondition: `id = \? AND deleted_at IS NULL FOR UPDATE`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+			mock.ExpectBegin()
+			mock.ExpectQuery(`SELECT .+ FROM items WHERE ` + tt.beforeCondition + `$`).
+				WithArgs(int64(1)).
+				WillReturnRows(sqlmock.NewRows(itemColumns))
+			mock.ExpectRollback()
+
+			err := tt.call(repo)
+
+			assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestItemRepository_Update_VersionConflict(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	itemRow := func(version int64) *sqlmock.Rows {
+		return sqlmock.NewRows(itemColumns).
+			AddRow(1, "時計1", "時計", "ROLEX", 1000000, "JPY", now, nil, nil, "", nil, "owned", nil, nil, version, now, now, nil, nil, "watch", 1)
+	}
+	updated := &entity.Item{ID: 1, Name: "時計2", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-01"), Version: 2}
+
+	t.Run("異常系: 読み込んだ時点で別の更新が反映済み", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .+ FROM items WHERE id = \? AND deleted_at IS NULL FOR UPDATE`).
+			WithArgs(int64(1)).
+			WillReturnRows(itemRow(3))
+		mock.ExpectRollback()
+
+		item, err := repo.Update(context.Background(), updated)
+
+		var conflictErr *domainErrors.VersionConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, int64(3), conflictErr.CurrentVersion)
+		assert.Nil(t, item)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 更新された行が0件", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT .+ FROM items WHERE id = \? AND deleted_at IS NULL FOR UPDATE`).
+			WithArgs(int64(1)).
+			WillReturnRows(itemRow(2))
+		mock.ExpectExec(`UPDATE items SET .+ WHERE id = \? AND version = \?`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		item, err := repo.Update(context.Background(), updated)
+
+		assert.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+		assert.False(t, domainErrors.IsDatabaseError(err))
+		assert.Nil(t, item)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemRepository_CreateHistory(t *testing.T) {
+	before := &entity.Item{ID: 1, Name: "時計1"}
+
+	t.Run("正常系: 物理削除の変更後はNULLとして記録する", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectExec(`INSERT INTO item_histories \(item_id, action, before_snapshot, after_snapshot\)`).
+			WithArgs(int64(1), entity.HistoryActionHardDelete, sqlmock.AnyArg(), nil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repo.CreateHistory(context.Background(), 1, entity.HistoryActionHardDelete, before, nil)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectExec(`INSERT INTO item_histories`).WillReturnError(sql.ErrConnDone)
+
+		err := repo.CreateHistory(context.Background(), 1, entity.HistoryActionDelete, before, before)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemRepository_FindHistories(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo, mock := newMockRepository(t)
+	mock.ExpectQuery(`SELECT id, item_id, action, before_snapshot, after_snapshot, created_at FROM item_histories WHERE item_id = \? ORDER BY id DESC LIMIT \? OFFSET \?`).
+		WithArgs(int64(1), 10, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "item_id", "action", "before_snapshot", "after_snapshot", "created_at"}).
+			AddRow(2, 1, "hard_delete", []byte(`{"id":1}`), nil, now).
+			AddRow(1, 1, "update", []byte(`{"id":1}`), []byte(`{"id":1}`), now))
+
+	histories, err := repo.FindHistories(context.Background(), 1, entity.Pagination{Limit: 10})
+
+	require.NoError(t, err)
+	require.Len(t, histories, 2)
+	assert.Equal(t, entity.HistoryActionHardDelete, histories[0].Action)
+	assert.Nil(t, histories[0].After)
+	assert.JSONEq(t, `{"id":1}`, string(histories[1].After))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_CountHistories(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM item_histories WHERE item_id = \?`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := repo.CountHistories(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBuildItemOrderBy(t *testing.T) {
+	tests := []struct {
+		name string
+		sort entity.ItemSort
+		want string
+	}{
+		{"未指定の場合はcreated_atの降順", entity.ItemSort{}, "ORDER BY created_at DESC, id DESC"},
+		{"名前の昇順", entity.ItemSort{Field: entity.SortByName, Order: entity.SortOrderAsc}, "ORDER BY name ASC, id ASC"},
+		{"一覧にない項目はcreated_atにフォールバック", entity.ItemSort{Field: "1; DROP TABLE items", Order: entity.SortOrderAsc}, "ORDER BY created_at ASC, id ASC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, strings.TrimSpace(buildItemOrderBy(tt.sort)))
+		})
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"特殊文字なし", "ROLEX", "ROLEX"},
+		{"パーセント", "100%", `100\%`},
+		{"アンダースコア", "a_b", `a\_b`},
+		{"バックスラッシュ", `a\b`, `a\\b`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, escapeLike(tt.input))
+		})
+	}
+}
+
+func TestItemRepository_Count(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM items WHERE deleted_at IS NULL`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	count, err := repo.Count(context.Background(), entity.ItemFilter{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 7, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_Count_WithFilter(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM items WHERE deleted_at IS NULL AND category = \?`).
+		WithArgs("バッグ").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	count, err := repo.Count(context.Background(), entity.ItemFilter{Category: "バッグ"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_MaxUpdatedAt(t *testing.T) {
+	t.Run("正常系: 論理削除したアイテムも含めて最新の更新日時を返す", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		updatedAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+		mock.ExpectQuery(`SELECT updated_at FROM items WHERE category = \? ORDER BY updated_at DESC LIMIT 1`).
+			WithArgs("バッグ").
+			WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(updatedAt))
+
+		got, err := repo.MaxUpdatedAt(context.Background(), entity.ItemFilter{Category: "バッグ"})
+
+		require.NoError(t, err)
+		require.NotNil(t, got)
+		assert.Equal(t, updatedAt, *got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("正常系: 一致するアイテムがない場合はnil", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectQuery(`SELECT updated_at FROM items ORDER BY updated_at DESC LIMIT 1`).
+			WillReturnRows(sqlmock.NewRows([]string{"updated_at"}))
+
+		got, err := repo.MaxUpdatedAt(context.Background(), entity.ItemFilter{})
+
+		require.NoError(t, err)
+		assert.Nil(t, got)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemRepository_FindUpdatedSince(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	since := time.Date(2024, 1, 8, 12, 0, 0, 0, time.UTC)
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	purchaseDate := time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)
+	// updated_atのインデックスで絞り込んで並べ、論理削除したアイテムは含めない
+	mock.ExpectQuery(`FROM items\s+WHERE updated_at >= \? AND deleted_at IS NULL\s+ORDER BY updated_at DESC, id DESC\s+LIMIT \?`).
+		WithArgs(since, 20).
+		WillReturnRows(sqlmock.NewRows(itemColumns).
+			AddRow(2, "エルメス バーキン", "バッグ", "HERMÈS", 2000000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 2, since.Add(-time.Hour), now, nil, nil, "bag", 1).
+			AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, since, since, nil, nil, "watch", 1))
+
+	items, err := repo.FindUpdatedSince(context.Background(), since, 20)
+
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, int64(2), items[0].ID)
+	assert.Equal(t, int64(1), items[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_CreateMany(t *testing.T) {
+	newItems := func() []*entity.Item {
+		item1, _ := entity.NewItem(entity.NewItemInput{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), SerialNumber: "SN-001", Condition: "中古A", PurchaseLocation: " 銀座　本店 ", Categories: testCategories})
+		item2, _ := entity.NewItem(entity.NewItemInput{Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2000000, Currency: "EUR", PurchaseDate: entity.MustParsePurchaseDate("2023-02-20"), Categories: testCategories})
+		return []*entity.Item{item1, item2}
+	}
+
+	t.Run("正常系: 複数行INSERTで全件登録し、連続したIDを返す", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO items \(user_id, name, category, brand, purchase_price, currency, purchase_date, serial_number, item_condition, notes, purchase_location, status\) VALUES \(\?, \?, \?, \?, \?, \?, \?, \?, \?, \?, \?, \?\), \(\?, \?, \?, \?, \?, \?, \?, \?, \?, \?, \?, \?\)`).
+			WithArgs(
+				entity.DefaultUserID, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", "2023-01-15", "SN-001", "中古A", "", "銀座 本店", "owned",
+				entity.DefaultUserID, "エルメス バーキン", "バッグ", "HERMÈS", 2000000, "EUR", "2023-02-20", nil, nil, "", nil, "owned",
+			).
+			WillReturnResult(sqlmock.NewResult(10, 2))
+		mock.ExpectCommit()
+
+		ids, err := repo.CreateMany(context.Background(), newItems())
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{10, 11}, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("正常系: 500件ごとにINSERTを分け、最後の端数もまとめて登録する", func(t *testing.T) {
+		items := make([]*entity.Item, 0, 1001)
+		for len(items) < 1001 {
+			items = append(items, newItems()...)
+		}
+		items = items[:1001]
+
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO items`).WillReturnResult(sqlmock.NewResult(10, 500))
+		mock.ExpectExec(`INSERT INTO items`).WillReturnResult(sqlmock.NewResult(1000, 500))
+		mock.ExpectExec(`INSERT INTO items \(.+\) VALUES \(\?, \?, \?, \?, \?, \?, \?, \?, \?, \?, \?, \?\)$`).
+			WithArgs(entity.DefaultUserID, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", "2023-01-15", "SN-001", "中古A", "", "銀座 本店", "owned").
+			WillReturnResult(sqlmock.NewResult(5000, 1))
+		mock.ExpectCommit()
+
+		ids, err := repo.CreateMany(context.Background(), items)
+
+		require.NoError(t, err)
+		require.Len(t, ids, 1001)
+		assert.Equal(t, int64(10), ids[0])
+		assert.Equal(t, int64(509), ids[499])
+		assert.Equal(t, int64(1000), ids[500])
+		assert.Equal(t, int64(1499), ids[999])
+		assert.Equal(t, int64(5000), ids[1000])
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 失敗した場合はロールバック", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO items`).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		ids, err := repo.CreateMany(context.Background(), newItems())
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Nil(t, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 一意制約に違反した場合はErrDuplicateItem", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO items`).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'PRIMARY'"})
+		mock.ExpectRollback()
+
+		ids, err := repo.CreateMany(context.Background(), newItems())
+
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateItem)
+		assert.True(t, domainErrors.IsConflictError(err))
+		assert.False(t, domainErrors.IsDatabaseError(err))
+		assert.Nil(t, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("正常系: 空の場合はクエリを発行しない", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+
+		ids, err := repo.CreateMany(context.Background(), nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemRepository_FindByIDs(t *testing.T) {
+	now := time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC)
+	purchaseDate := time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	repo, mock := newMockRepository(t)
+	mock.ExpectQuery(`SELECT (.+) FROM items WHERE id IN \(\?, \?, \?\) AND deleted_at IS NULL`).
+		WithArgs(int64(1), int64(2), int64(3)).
+		WillReturnRows(sqlmock.NewRows(itemColumns).
+			AddRow(1, "ロレックス デイトナ", "時計", "ROLEX", 1500000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "watch", 1).
+			AddRow(3, "ティファニー ネックレス", "ジュエリー", "Tiffany & Co.", 300000, "JPY", purchaseDate, nil, nil, "", nil, "owned", nil, nil, 1, now, now, nil, nil, "jewelry", 1))
+
+	items, err := repo.FindByIDs(context.Background(), []int64{1, 2, 3})
+
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// ヘルパー関数
+// テストで使う登録済みカテゴリー
+var testCategories = entity.NewCategorySet("時計", "バッグ", "ジュエリー", "靴", "その他")
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+var summaryColumns = []string{"name", "currency", "item_condition", "status", "count", "total_price"}
+
+func TestItemRepository_GetSummaryByCategory(t *testing.T) {
+	tests := []struct {
+		name string
+		rows *sqlmock.Rows
+		want []*entity.CategoryCurrencyTotal
+	}{
+		{
+			name: "正常系: アイテムが0件でも全カテゴリーを0で返す",
+			rows: sqlmock.NewRows(summaryColumns).
+				AddRow("時計", "", "", "", 0, "0").
+				AddRow("バッグ", "", "", "", 0, "0"),
+			want: []*entity.CategoryCurrencyTotal{
+				{Category: "時計"},
+				{Category: "バッグ"},
+			},
+		},
+		{
+			name: "正常系: 通貨・状態・所有状況ごとに集計し、合計がint32の範囲を超えてもオーバーフローしない",
+			rows: sqlmock.NewRows(summaryColumns).
+				AddRow("時計", "EUR", "新品", "owned", 1, "12000").
+				AddRow("時計", "JPY", "", "owned", 3, "6442450941").
+				AddRow("バッグ", "JPY", "中古A", "sold", 2, "350000"),
+			want: []*entity.CategoryCurrencyTotal{
+				{Category: "時計", Currency: "EUR", Condition: "新品", Status: "owned", Count: 1, TotalPrice: 12000},
+				{Category: "時計", Currency: "JPY", Status: "owned", Count: 3, TotalPrice: 6442450941},
+				{Category: "バッグ", Currency: "JPY", Condition: "中古A", Status: "sold", Count: 2, TotalPrice: 350000},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+			mock.ExpectQuery(`FROM categories c\s+LEFT JOIN items i ON i.category = c.name AND i.deleted_at IS NULL\s+GROUP BY c.id, c.name, i.currency, i.item_condition, i.status`).
+				WillReturnRows(tt.rows)
+
+			summary, err := repo.GetSummaryByCategory(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, summary)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestItemRepository_GetSummaryByCategory_DatabaseError(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	mock.ExpectQuery(`FROM categories c`).WillReturnError(sql.ErrConnDone)
+
+	summary, err := repo.GetSummaryByCategory(context.Background())
+
+	assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	assert.Nil(t, summary)
+}
+
+func TestItemRepository_GetProfitByCurrency(t *testing.T) {
+	profitColumns := []string{"currency", "count", "selling_price", "purchase_price"}
+
+	t.Run("正常系: 売却済みのアイテムを通貨ごとに集計", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectQuery(`SELECT currency, COUNT\(\*\), SUM\(selling_price\), SUM\(purchase_price\) FROM items WHERE status = \? AND selling_price IS NOT NULL AND deleted_at IS NULL GROUP BY currency`).
+			WithArgs("sold").
+			WillReturnRows(sqlmock.NewRows(profitColumns).
+				AddRow("EUR", 1, "1500", "1000").
+				AddRow("JPY", 2, "3000000", "2500000"))
+
+		totals, err := repo.GetProfitByCurrency(context.Background(), 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.ProfitCurrencyTotal{
+			{Currency: "EUR", Count: 1, SellingPrice: 1500, PurchasePrice: 1000},
+			{Currency: "JPY", Count: 2, SellingPrice: 3000000, PurchasePrice: 2500000},
+		}, totals)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("正常系: 売却年で絞り込む", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectQuery(`AND sold_date >= \? AND sold_date < \? GROUP BY currency`).
+			WithArgs("sold", "2024-01-01", "2025-01-01").
+			WillReturnRows(sqlmock.NewRows(profitColumns))
+
+		totals, err := repo.GetProfitByCurrency(context.Background(), 2024)
+
+		require.NoError(t, err)
+		assert.Empty(t, totals)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemRepository_GetSpendByLocation(t *testing.T) {
+	locationColumns := []string{"purchase_location", "currency", "count", "total_price"}
+
+	t.Run("正常系: 購入店舗と通貨ごとに集計", func(t *testing.T) {
+		location := "銀座本店"
+		repo, mock := newMockRepository(t)
+		mock.ExpectQuery(`SELECT purchase_location, currency, COUNT\(\*\), SUM\(purchase_price\) FROM items WHERE deleted_at IS NULL GROUP BY purchase_location, currency ORDER BY purchase_location, currency`).
+			WillReturnRows(sqlmock.NewRows(locationColumns).
+				AddRow(nil, "JPY", 1, "50000").
+				AddRow("銀座本店", "JPY", 2, "3000000").
+				AddRow("銀座本店", "USD", 1, "1000"))
+
+		totals, err := repo.GetSpendByLocation(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.LocationCurrencyTotal{
+			{Currency: "JPY", Count: 1, TotalPrice: 50000},
+			{PurchaseLocation: &location, Currency: "JPY", Count: 2, TotalPrice: 3000000},
+			{PurchaseLocation: &location, Currency: "USD", Count: 1, TotalPrice: 1000},
+		}, totals)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectQuery(`SELECT purchase_location, currency`).
+			WillReturnError(sql.ErrConnDone)
+
+		totals, err := repo.GetSpendByLocation(context.Background())
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Nil(t, totals)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemRepository_GetStatsByCategory(t *testing.T) {
+	statsColumns := []string{"category", "currency", "count", "total_price", "latest_purchase_date"}
+
+	t.Run("正常系: 絞り込み条件に一致するアイテムをカテゴリーと通貨ごとに集計", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectQuery(`SELECT category, currency, COUNT\(\*\), SUM\(purchase_price\), MAX\(purchase_date\) FROM items WHERE deleted_at IS NULL AND LOWER\(brand\) LIKE \? GROUP BY category, currency ORDER BY category, currency`).
+			WithArgs("%rolex%").
+			WillReturnRows(sqlmock.NewRows(statsColumns).
+				AddRow("時計", "JPY", 2, "3000000", "2024-03-01").
+				AddRow("時計", "USD", 1, "10000", "2022-01-01"))
+
+		stats, err := repo.GetStatsByCategory(context.Background(), entity.ItemFilter{Brand: "ROLEX"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.CategoryCurrencyStats{
+			{Category: "時計", Currency: "JPY", Count: 2, TotalPrice: 3000000, LatestPurchaseDate: entity.MustParsePurchaseDate("2024-03-01")},
+			{Category: "時計", Currency: "USD", Count: 1, TotalPrice: 10000, LatestPurchaseDate: entity.MustParsePurchaseDate("2022-01-01")},
+		}, stats)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectQuery(`SELECT category, currency`).WillReturnError(sql.ErrConnDone)
+
+		stats, err := repo.GetStatsByCategory(context.Background(), entity.ItemFilter{})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Nil(t, stats)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemRepository_FindMostExpensiveByCurrency(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	mock.ExpectQuery(`SELECT id, name, purchase_price, currency FROM \( SELECT id, name, purchase_price, currency, ROW_NUMBER\(\) OVER \(PARTITION BY currency ORDER BY purchase_price DESC, id ASC\) AS price_rank FROM items WHERE deleted_at IS NULL AND category = \? \) ranked WHERE price_rank = 1 ORDER BY currency`).
+		WithArgs("時計").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "purchase_price", "currency"}).
+			AddRow(2, "デイトナ", 1500000, "JPY").
+			AddRow(3, "サブマリーナー", 10000, "USD"))
+
+	items, err := repo.FindMostExpensiveByCurrency(context.Background(), entity.ItemFilter{Category: "時計"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.ItemPrice{
+		{ID: 2, Name: "デイトナ", PurchasePrice: 1500000, Currency: "JPY"},
+		{ID: 3, Name: "サブマリーナー", PurchasePrice: 10000, Currency: "USD"},
+	}, items)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_GetSpendByPeriod(t *testing.T) {
+	spendColumns := []string{"period", "currency", "count", "total_price"}
+
+	t.Run("正常系: 月単位で集計し、期間の終わりは翌月の初日", func(t *testing.T) {
+		spendRange, err := entity.ParseSpendRange("month", "2023-01", "2024-12")
+		require.NoError(t, err)
+
+		repo, mock := newMockRepository(t)
+		mock.ExpectQuery(`SELECT DATE_FORMAT\(purchase_date, '%Y-%m'\) AS period, currency, COUNT\(\*\), SUM\(purchase_price\) FROM items WHERE deleted_at IS NULL AND purchase_date >= \? AND purchase_date < \? GROUP BY period, currency ORDER BY period, currency`).
+			WithArgs("2023-01-01", "2025-01-01").
+			WillReturnRows(sqlmock.NewRows(spendColumns).
+				AddRow("2023-01", "JPY", 2, "300000").
+				AddRow("2023-01", "USD", 1, "1000"))
+
+		totals, err := repo.GetSpendByPeriod(context.Background(), spendRange)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.PeriodCurrencyTotal{
+			{Period: "2023-01", Currency: "JPY", Count: 2, TotalPrice: 300000},
+			{Period: "2023-01", Currency: "USD", Count: 1, TotalPrice: 1000},
+		}, totals)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("正常系: 年単位", func(t *testing.T) {
+		spendRange, err := entity.ParseSpendRange("year", "2023", "2024")
+		require.NoError(t, err)
+
+		repo, mock := newMockRepository(t)
+		mock.ExpectQuery(`SELECT DATE_FORMAT\(purchase_date, '%Y'\) AS period`).
+			WithArgs("2023-01-01", "2025-01-01").
+			WillReturnRows(sqlmock.NewRows(spendColumns))
+
+		totals, err := repo.GetSpendByPeriod(context.Background(), spendRange)
+
+		require.NoError(t, err)
+		assert.Empty(t, totals)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestItemRepository_GetSummaryByBrand(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	mock.ExpectQuery(`SELECT LOWER\(brand\) COLLATE utf8mb4_bin AS brand_key, MIN\(brand COLLATE utf8mb4_bin\), currency, COUNT\(\*\), SUM\(purchase_price\) FROM items WHERE deleted_at IS NULL AND status <> \? GROUP BY brand_key, currency ORDER BY brand_key, currency`).
+		WithArgs("sold").
+		WillReturnRows(sqlmock.NewRows([]string{"brand_key", "brand", "currency", "count", "total_price"}).
+			AddRow("hermes", "HERMES", "JPY", 2, "3000000").
+			AddRow("hermes", "Hermes", "USD", 1, "10000"))
+
+	totals, err := repo.GetSummaryByBrand(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.BrandCurrencyTotal{
+		{BrandKey: "hermes", Brand: "HERMES", Currency: "JPY", Count: 2, TotalPrice: 3000000},
+		{BrandKey: "hermes", Brand: "Hermes", Currency: "USD", Count: 1, TotalPrice: 10000},
+	}, totals)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestItemRepository_FindMostExpensiveByBrand(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	mock.ExpectQuery(`ROW_NUMBER\(\) OVER \(PARTITION BY LOWER\(brand\) COLLATE utf8mb4_bin, currency ORDER BY purchase_price DESC, id ASC\) AS price_rank FROM items WHERE deleted_at IS NULL AND status <> \? \) ranked WHERE price_rank = 1`).
+		WithArgs("sold").
+		WillReturnRows(sqlmock.NewRows([]string{"brand_key", "id", "name", "purchase_price", "currency"}).
+			AddRow("hermes", 1, "バーキン", 3000000, "JPY"))
+
+	items, err := repo.FindMostExpensiveByBrand(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []*entity.ItemPrice{{BrandKey: "hermes", ID: 1, Name: "バーキン", PurchasePrice: 3000000, Currency: "JPY"}}, items)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 一時的なエラーでやり直す最大の回数
+const maxRetries = 3
+
+// 1回目のやり直しまでの待ち時間の基準。やり直すたびに2倍にする
+var retryBaseDelay = 20 * time.Millisecond
+
+// やり直した単位
+const (
+	retryQuery       = "query"
+	retryTransaction = "transaction"
+)
+
+// 一時的なエラーでやり直した回数。単位ごとにRetryCountsで参照する
+var retryCount = map[string]*atomic.Int64{
+	retryQuery:       new(atomic.Int64),
+	retryTransaction: new(atomic.Int64),
+}
+
+// 一時的なエラーでやり直した回数を、やり直した単位（query・transaction）ごとに返す
+func RetryCounts() map[string]int64 {
+	counts := make(map[string]int64, len(retryCount))
+	for kind, count := range retryCount {
+		counts[kind] = count.Load()
+	}
+	return counts
+}
+
+// fnが一時的なエラーを返した場合に、間隔を空けて最大maxRetries回やり直す。
+// それ以外のエラーや、やり直しても失敗した場合は最後のエラーを返す。待っている間にctxが終了した場合はその時点で中断する
+func retry(ctx context.Context, d Dialect, kind string, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt == maxRetries || !dialectOrDefault(d).transient(err) {
+			return err
+		}
+
+		retryCount[kind].Add(1)
+		timer := time.NewTimer(retryDelay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w: %w", err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// attempt回目のやり直しまでの待ち時間。同時に失敗したリクエストが同時にやり直さないよう、
+// retryBaseDelay*2^attemptの半分から全体までの範囲でばらつかせる
+func retryDelay(attempt int) time.Duration {
+	delay := retryBaseDelay << attempt
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// 読み込みのみの文かどうか。書き込みは冪等とは限らないため、トランザクションの外ではやり直さない
+func isReadOnly(statement string) bool {
+	return strings.HasPrefix(strings.ToUpper(strings.TrimSpace(statement)), "SELECT")
+}
+
+// fnを1つのトランザクションで実行してコミットする。一時的なエラーの場合はトランザクション全体をやり直すため、
+// fnはトランザクションの外に影響を残さないようにする。
+// WithinTxのトランザクション内ではやり直さず、エラーを返してWithinTxにトランザクション全体をやり直させる
+func inTx(ctx context.Context, h SqlHandler, d Dialect, fn func(tx Transaction) error) error {
+	if t, ok := h.(*Transactor); ok && t.current(ctx) != nil {
+		return runTx(ctx, h, fn)
+	}
+	return retry(ctx, d, retryTransaction, func() error {
+		return runTx(ctx, h, fn)
+	})
+}
+
+func runTx(ctx context.Context, h SqlHandler, fn func(tx Transaction) error) error {
+	tx, err := h.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: failed to begin transaction: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%w: failed to commit transaction: %w", domainErrors.ErrDatabaseError, err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+var (
+	errDeadlock        = &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock; try restarting transaction"}
+	errLockWaitTimeout = &mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded; try restarting transaction"}
+)
+
+// テスト中はやり直しまでの待ち時間を短くする
+func shortenRetryDelay(t *testing.T) {
+	t.Helper()
+	delay := retryBaseDelay
+	retryBaseDelay = time.Millisecond
+	t.Cleanup(func() { retryBaseDelay = delay })
+}
+
+func retries(kind string) int64 {
+	return RetryCounts()[kind]
+}
+
+func TestRetry_Transaction(t *testing.T) {
+	shortenRetryDelay(t)
+	item, err := entity.NewItem(entity.NewItemInput{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), Categories: testCategories})
+	require.NoError(t, err)
+
+	t.Run("正常系: デッドロックの場合はトランザクション全体をやり直す", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO items`).WillReturnError(errDeadlock)
+		mock.ExpectRollback()
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO items`).WillReturnError(errLockWaitTimeout)
+		mock.ExpectRollback()
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO items`).WillReturnResult(sqlmock.NewResult(10, 1))
+		mock.ExpectCommit()
+		before := retries(retryTransaction)
+
+		ids, err := repo.CreateMany(context.Background(), []*entity.Item{item})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{10}, ids)
+		assert.Equal(t, before+2, retries(retryTransaction))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 3回やり直しても失敗した場合は元のエラーを返す", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		for i := 0; i < maxRetries+1; i++ {
+			mock.ExpectBegin()
+			mock.ExpectExec(`INSERT INTO items`).WillReturnError(errDeadlock)
+			mock.ExpectRollback()
+		}
+
+		_, err := repo.CreateMany(context.Background(), []*entity.Item{item})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.ErrorIs(t, err, errDeadlock)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 一時的でないエラーはやり直さない", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO items`).WillReturnError(sql.ErrConnDone)
+		mock.ExpectRollback()
+
+		_, err := repo.CreateMany(context.Background(), []*entity.Item{item})
+
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: 待っている間にコンテキストが終了した場合は中断する", func(t *testing.T) {
+		retryBaseDelay = time.Minute
+		t.Cleanup(func() { retryBaseDelay = time.Millisecond })
+
+		repo, mock := newMockRepository(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO items`).WillReturnError(errDeadlock)
+		mock.ExpectRollback()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		start := time.Now()
+		_, err := repo.CreateMany(ctx, []*entity.Item{item})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, err, errDeadlock)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRetry_OutsideTransaction(t *testing.T) {
+	shortenRetryDelay(t)
+
+	t.Run("正常系: 読み込みはやり直す", func(t *testing.T) {
+		transactor, mock := newMockTransactor(t)
+		repo := &ItemRepository{SqlHandler: transactor}
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM items`).WillReturnError(errLockWaitTimeout)
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM items`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		before := retries(retryQuery)
+
+		count, err := repo.Count(context.Background(), entity.ItemFilter{})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.Equal(t, before+1, retries(retryQuery))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: トランザクションの外の書き込みはやり直さない", func(t *testing.T) {
+		transactor, mock := newMockTransactor(t)
+		repo := &ItemRepository{SqlHandler: transactor}
+		mock.ExpectExec(`DELETE FROM item_images`).WillReturnError(errDeadlock)
+
+		err := repo.DeleteImage(context.Background(), 1, 2)
+
+		assert.ErrorIs(t, err, errDeadlock)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// WithinTxの中ではリポジトリのトランザクションだけをやり直さず、WithinTxのfnからやり直す
+func TestRetry_WithinTx(t *testing.T) {
+	shortenRetryDelay(t)
+	item, err := entity.NewItem(entity.NewItemInput{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), Categories: testCategories})
+	require.NoError(t, err)
+
+	transactor, mock := newMockTransactor(t)
+	repo := &ItemRepository{SqlHandler: transactor}
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO items`).WillReturnResult(sqlmock.NewResult(10, 1))
+	mock.ExpectExec(`INSERT INTO items`).WillReturnError(errDeadlock)
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO items`).WillReturnResult(sqlmock.NewResult(11, 1))
+	mock.ExpectExec(`INSERT INTO items`).WillReturnResult(sqlmock.NewResult(12, 1))
+	mock.ExpectCommit()
+
+	attempts := 0
+	err = transactor.WithinTx(context.Background(), func(ctx context.Context) error {
+		attempts++
+		for i := 0; i < 2; i++ {
+			if _, err := repo.CreateMany(ctx, []*entity.Item{item}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIsReadOnly(t *testing.T) {
+	assert.True(t, isReadOnly("\n        SELECT id FROM items"))
+	assert.True(t, isReadOnly("select 1"))
+	assert.False(t, isReadOnly("INSERT INTO items (name) VALUES (?) RETURNING id"))
+	assert.False(t, isReadOnly("UPDATE items SET name = ?"))
+}
+
+func TestRetryDelay(t *testing.T) {
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		delay := retryDelay(attempt)
+		max := retryBaseDelay << attempt
+		assert.GreaterOrEqual(t, delay, max/2)
+		assert.LessOrEqual(t, delay, max)
+	}
+}
@@ -6,9 +6,25 @@ type SqlHandler interface {
 	Execute(ctx context.Context, statement string, args ...interface{}) (Result, error)
 	Query(ctx context.Context, statement string, args ...interface{}) (Rows, error)
 	QueryRow(ctx context.Context, statement string, args ...interface{}) Row
+	Begin(ctx context.Context) (Transaction, error)
 	Close() error
 }
 
+type Transaction interface {
+	Execute(ctx context.Context, statement string, args ...interface{}) (Result, error)
+	Query(ctx context.Context, statement string, args ...interface{}) (Rows, error)
+	QueryRow(ctx context.Context, statement string, args ...interface{}) Row
+	Commit() error
+	Rollback() error
+}
+
+// SqlHandlerとTransactionに共通するクエリの実行
+type executor interface {
+	Execute(ctx context.Context, statement string, args ...interface{}) (Result, error)
+	Query(ctx context.Context, statement string, args ...interface{}) (Rows, error)
+	QueryRow(ctx context.Context, statement string, args ...interface{}) Row
+}
+
 type Result interface {
 	LastInsertId() (int64, error)
 	RowsAffected() (int64, error)
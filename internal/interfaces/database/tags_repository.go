@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type TagRepository struct {
+	SqlHandler
+}
+
+// タグを名前順に、論理削除されていないアイテムの件数とともに取得する。
+// 論理削除されたアイテムにのみ付いているタグは含めない。
+// 呼び出し元がユーザーの場合は、そのユーザーのアイテムに付いているタグと件数のみを数える
+func (r *TagRepository) FindAllWithCounts(ctx context.Context) ([]*entity.TagCount, error) {
+	owner, ownerArgs := ownerCondition(ctx, "i.user_id")
+	query := `
+        SELECT t.name, COUNT(*)
+        FROM tags t
+        JOIN item_tags it ON it.tag_id = t.id
+        JOIN items i ON i.id = it.item_id AND i.deleted_at IS NULL` + owner + `
+        GROUP BY t.id, t.name
+        ORDER BY t.name
+    `
+
+	rows, err := r.Query(ctx, query, ownerArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	tags := make([]*entity.TagCount, 0)
+	for rows.Next() {
+		var tag entity.TagCount
+		if err := rows.Scan(&tag.Name, &tag.Count); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		tags = append(tags, &tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return tags, nil
+}
+
+// アイテムのタグをcurrentからtagsに置き換える。外したタグがほかのアイテムに付いていなければタグ自体も削除する。
+// currentとtagsは正規化済みの値を渡す
+func replaceItemTags(ctx context.Context, d Dialect, tx Transaction, itemID int64, current, tags []string) error {
+	removed := subtractTags(current, tags)
+	added := subtractTags(tags, current)
+
+	if len(removed) > 0 {
+		placeholders, args := inPlaceholders(removed)
+
+		deleteQuery := `
+            DELETE FROM item_tags
+            WHERE item_id = ? AND tag_id IN (SELECT id FROM tags WHERE name IN (` + placeholders + `))
+        `
+		if _, err := tx.Execute(ctx, deleteQuery, append([]interface{}{itemID}, args...)...); err != nil {
+			return fmt.Errorf("%w: failed to remove item tags: %w", domainErrors.ErrDatabaseError, err)
+		}
+
+		cleanupQuery := `
+            DELETE FROM tags
+            WHERE name IN (` + placeholders + `)
+              AND NOT EXISTS (SELECT 1 FROM item_tags it WHERE it.tag_id = tags.id)
+        `
+		if _, err := tx.Execute(ctx, cleanupQuery, args...); err != nil {
+			return fmt.Errorf("%w: failed to delete unused tags: %w", domainErrors.ErrDatabaseError, err)
+		}
+	}
+
+	if len(added) > 0 {
+		placeholders, args := inPlaceholders(added)
+
+		// 登録済みのタグはそのまま使う
+		insertTagsQuery := `INSERT INTO tags (name) VALUES ` + strings.TrimSuffix(strings.Repeat("(?), ", len(added)), ", ") + d.ignoreDuplicates()
+		if _, err := tx.Execute(ctx, insertTagsQuery, args...); err != nil {
+			return fmt.Errorf("%w: failed to insert tags: %w", domainErrors.ErrDatabaseError, err)
+		}
+
+		insertQuery := `
+            INSERT INTO item_tags (item_id, tag_id)
+            SELECT ?, id FROM tags WHERE name IN (` + placeholders + `)
+        `
+		if _, err := tx.Execute(ctx, insertQuery, append([]interface{}{itemID}, args...)...); err != nil {
+			return fmt.Errorf("%w: failed to add item tags: %w", domainErrors.ErrDatabaseError, err)
+		}
+	}
+
+	return nil
+}
+
+// aに含まれ、bに含まれないタグ
+func subtractTags(a, b []string) []string {
+	exclude := make(map[string]bool, len(b))
+	for _, tag := range b {
+		exclude[tag] = true
+	}
+
+	var result []string
+	for _, tag := range a {
+		if !exclude[tag] {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+// IN句のプレースホルダと値
+func inPlaceholders(values []string) (string, []interface{}) {
+	placeholders := make([]string, len(values))
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		placeholders[i] = "?"
+		args[i] = value
+	}
+	return strings.Join(placeholders, ", "), args
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+func newMockTagRepository(t *testing.T) (*TagRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return &TagRepository{SqlHandler: &testSqlHandler{db: db}}, mock
+}
+
+func TestTagRepository_FindAllWithCounts(t *testing.T) {
+	t.Run("正常系: 論理削除されていないアイテムの件数とともに名前順で取得", func(t *testing.T) {
+		repo, mock := newMockTagRepository(t)
+		mock.ExpectQuery(`SELECT t.name, COUNT\(\*\) FROM tags t JOIN item_tags it ON it.tag_id = t.id JOIN items i ON i.id = it.item_id AND i.deleted_at IS NULL GROUP BY t.id, t.name ORDER BY t.name`).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "count"}).
+				AddRow("プレゼント", 1).
+				AddRow("限定品", 3))
+
+		tags, err := repo.FindAllWithCounts(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.TagCount{{Name: "プレゼント", Count: 1}, {Name: "限定品", Count: 3}}, tags)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("正常系: 呼び出し元がユーザーの場合はそのユーザーのアイテムのみを数える", func(t *testing.T) {
+		repo, mock := newMockTagRepository(t)
+		mock.ExpectQuery(`JOIN items i ON i.id = it.item_id AND i.deleted_at IS NULL AND i.user_id = \? GROUP BY`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"name", "count"}).AddRow("限定品", 1))
+
+		tags, err := repo.FindAllWithCounts(principal.NewContext(context.Background(), principal.Principal{UserID: 2}))
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.TagCount{{Name: "限定品", Count: 1}}, tags)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		repo, mock := newMockTagRepository(t)
+		mock.ExpectQuery(`SELECT t.name`).WillReturnError(errors.New("connection refused"))
+
+		tags, err := repo.FindAllWithCounts(context.Background())
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Nil(t, tags)
+	})
+}
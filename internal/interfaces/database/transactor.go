@@ -0,0 +1,154 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// コンテキストに保持する実行中のトランザクションのキー
+type txKey struct{}
+
+// WithinTxで開始したトランザクション。handlerはトランザクションを開始したSqlHandlerで、
+// 別のデータベースに接続したTransactorのトランザクションを使わないようにする
+type ambientTx struct {
+	handler      SqlHandler
+	tx           Transaction
+	rollbackOnly bool // トランザクション内のリポジトリがロールバックした場合はコミットしない
+}
+
+// usecase.Transactorの実装。SqlHandlerとしてリポジトリに設定すると、
+// WithinTxのコンテキストで呼ばれたクエリは開始済みのトランザクションで実行する。
+// トランザクションの外の読み込みとトランザクション全体は、Dialectが一時的とするエラーの場合にやり直す
+type Transactor struct {
+	SqlHandler
+	Dialect Dialect
+}
+
+// 一時的なエラーの場合はfnからやり直すため、fnはリポジトリの呼び出し以外の影響を残さないようにする
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	// 実行中のトランザクションがある場合はそのトランザクションに含める
+	if t.current(ctx) != nil {
+		return fn(ctx)
+	}
+
+	return retry(ctx, t.Dialect, retryTransaction, func() error {
+		return runTx(ctx, t.SqlHandler, func(tx Transaction) error {
+			ambient := &ambientTx{handler: t.SqlHandler, tx: tx}
+			if err := fn(context.WithValue(ctx, txKey{}, ambient)); err != nil {
+				return err
+			}
+			if ambient.rollbackOnly {
+				return fmt.Errorf("%w: transaction was rolled back", domainErrors.ErrDatabaseError)
+			}
+			return nil
+		})
+	})
+}
+
+// ctxで実行中のこのTransactorのトランザクション。ない場合はnil
+func (t *Transactor) current(ctx context.Context) *ambientTx {
+	ambient, ok := ctx.Value(txKey{}).(*ambientTx)
+	if !ok || ambient.handler != t.SqlHandler {
+		return nil
+	}
+	return ambient
+}
+
+// 実行中のトランザクションがあればそのトランザクションで、なければSqlHandlerで実行する
+func (t *Transactor) conn(ctx context.Context) executor {
+	if ambient := t.current(ctx); ambient != nil {
+		return ambient.tx
+	}
+	return t.SqlHandler
+}
+
+// 書き込みは冪等とは限らないため、トランザクションの外でもやり直さない
+func (t *Transactor) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	return t.conn(ctx).Execute(ctx, statement, args...)
+}
+
+// トランザクションの外の読み込みは、一時的なエラーの場合にやり直す。
+// やり直すのはクエリの実行までで、行を読み込む途中のエラーはやり直さない
+func (t *Transactor) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	if t.current(ctx) != nil || !isReadOnly(statement) {
+		return t.conn(ctx).Query(ctx, statement, args...)
+	}
+
+	var rows Rows
+	err := retry(ctx, t.Dialect, retryQuery, func() error {
+		var err error
+		rows, err = t.SqlHandler.Query(ctx, statement, args...)
+		return err
+	})
+	return rows, err
+}
+
+// トランザクションの外の読み込みは、一時的なエラーの場合にやり直す
+func (t *Transactor) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	if t.current(ctx) != nil || !isReadOnly(statement) {
+		return t.conn(ctx).QueryRow(ctx, statement, args...)
+	}
+	return &retryRow{transactor: t, ctx: ctx, statement: statement, args: args}
+}
+
+// Scanの時点でクエリを実行し、一時的なエラーの場合はやり直すRow
+type retryRow struct {
+	transactor *Transactor
+	ctx        context.Context
+	statement  string
+	args       []interface{}
+}
+
+func (r *retryRow) Scan(dest ...interface{}) error {
+	return retry(r.ctx, r.transactor.Dialect, retryQuery, func() error {
+		return r.transactor.SqlHandler.QueryRow(r.ctx, r.statement, r.args...).Scan(dest...)
+	})
+}
+
+// 実行中のトランザクションがある場合は、その一部として扱うトランザクションを返す
+func (t *Transactor) Begin(ctx context.Context) (Transaction, error) {
+	if ambient := t.current(ctx); ambient != nil {
+		return &nestedTx{ambientTx: ambient}, nil
+	}
+	return t.SqlHandler.Begin(ctx)
+}
+
+// WithinTxのトランザクション内で開始したトランザクション。
+// コミットはWithinTxに任せ、ロールバックした場合はWithinTxのトランザクション全体をロールバックする
+type nestedTx struct {
+	*ambientTx
+	done bool
+}
+
+func (n *nestedTx) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	return n.tx.Execute(ctx, statement, args...)
+}
+
+func (n *nestedTx) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	return n.tx.Query(ctx, statement, args...)
+}
+
+func (n *nestedTx) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	return n.tx.QueryRow(ctx, statement, args...)
+}
+
+func (n *nestedTx) Commit() error {
+	if n.done {
+		return sql.ErrTxDone
+	}
+	n.done = true
+	return nil
+}
+
+// リポジトリはdeferでRollbackを呼ぶため、コミット後の呼び出しでは何もしない
+func (n *nestedTx) Rollback() error {
+	if n.done {
+		return sql.ErrTxDone
+	}
+	n.done = true
+	n.rollbackOnly = true
+	return nil
+}
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func newMockTransactor(t *testing.T) (*Transactor, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return &Transactor{SqlHandler: &testSqlHandler{db: db}}, mock
+}
+
+func TestTransactor_WithinTx(t *testing.T) {
+	failure := errors.New("failure")
+
+	tests := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		fn          func(ctx context.Context, transactor *Transactor) error
+		expectedErr error
+	}{
+		{
+			name: "正常系: すべての書き込みを1つのトランザクションでコミットする",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO tags`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO item_tags`).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			fn: func(ctx context.Context, transactor *Transactor) error {
+				if _, err := transactor.Execute(ctx, "INSERT INTO tags (name) VALUES (?)", "限定"); err != nil {
+					return err
+				}
+				// ネストしたWithinTxとリポジトリのトランザクションは外側のトランザクションで実行する
+				return transactor.WithinTx(ctx, func(ctx context.Context) error {
+					tx, err := transactor.Begin(ctx)
+					if err != nil {
+						return err
+					}
+					defer tx.Rollback()
+					if _, err := tx.Execute(ctx, "INSERT INTO item_tags (item_id, tag_id) VALUES (?, ?)", 1, 1); err != nil {
+						return err
+					}
+					return tx.Commit()
+				})
+			},
+		},
+		{
+			name: "異常系: 途中で失敗した場合はそれまでの書き込みもロールバックする",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO tags`).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO item_tags`).WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			fn: func(ctx context.Context, transactor *Transactor) error {
+				if _, err := transactor.Execute(ctx, "INSERT INTO tags (name) VALUES (?)", "限定"); err != nil {
+					return err
+				}
+				_, err := transactor.Execute(ctx, "INSERT INTO item_tags (item_id, tag_id) VALUES (?, ?)", 1, 1)
+				return err
+			},
+			expectedErr: sql.ErrConnDone,
+		},
+		{
+			name: "異常系: fnのエラーはそのまま返す",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			fn: func(ctx context.Context, transactor *Transactor) error {
+				return failure
+			},
+			expectedErr: failure,
+		},
+		{
+			name: "異常系: リポジトリがロールバックした場合はエラーを返さなくてもコミットしない",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			fn: func(ctx context.Context, transactor *Transactor) error {
+				tx, err := transactor.Begin(ctx)
+				if err != nil {
+					return err
+				}
+				return tx.Rollback()
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+		{
+			name: "異常系: トランザクションを開始できない",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin().WillReturnError(sql.ErrConnDone)
+			},
+			fn: func(ctx context.Context, transactor *Transactor) error {
+				return nil
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactor, mock := newMockTransactor(t)
+			tt.setupMock(mock)
+
+			err := transactor.WithinTx(context.Background(), func(ctx context.Context) error {
+				return tt.fn(ctx, transactor)
+			})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// トランザクションの外ではSqlHandlerでそのまま実行する
+func TestTransactor_OutsideTx(t *testing.T) {
+	transactor, mock := newMockTransactor(t)
+	mock.ExpectExec(`DELETE FROM tags`).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	_, err := transactor.Execute(context.Background(), "DELETE FROM tags WHERE id = ?", 1)
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,175 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type UserRepository struct {
+	SqlHandler
+	// 未設定の場合はMySQL
+	Dialect Dialect
+}
+
+func (r *UserRepository) dialect() Dialect {
+	return dialectOrDefault(r.Dialect)
+}
+
+// scanUserと同じ順序で並べたSELECT対象の列
+const userSelectColumns = "id, email, role, password_hash, created_at, updated_at"
+
+// scanRefreshTokenと同じ順序で並べたSELECT対象の列
+const refreshTokenSelectColumns = "id, user_id, token_hash, expires_at, created_at, revoked_at"
+
+func (r *UserRepository) FindAll(ctx context.Context) ([]*entity.User, error) {
+	query := `SELECT ` + userSelectColumns + ` FROM users ORDER BY id`
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	users := make([]*entity.User, 0)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	query := `SELECT ` + userSelectColumns + ` FROM users WHERE email = ?`
+
+	return r.findOne(ctx, query, email)
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
+	query := `INSERT INTO users (email, role, password_hash) VALUES (?, ?, ?)`
+
+	id, err := insertID(ctx, r.dialect(), r.SqlHandler, query, user.Email, user.Role, user.PasswordHash)
+	if err != nil {
+		return nil, wrapWriteError(ctx, r.dialect(), err, domainErrors.ErrDuplicateEmail)
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *UserRepository) FindByID(ctx context.Context, id int64) (*entity.User, error) {
+	query := `SELECT ` + userSelectColumns + ` FROM users WHERE id = ?`
+
+	return r.findOne(ctx, query, id)
+}
+
+func (r *UserRepository) UpdateRole(ctx context.Context, id int64, role entity.UserRole) (*entity.User, error) {
+	query := `UPDATE users SET role = ?, updated_at = ` + r.dialect().now() + ` WHERE id = ?`
+
+	if _, err := r.Execute(ctx, query, role, id); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	// 存在しない場合はErrUserNotFoundを返す
+	return r.FindByID(ctx, id)
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) (*entity.User, error) {
+	query := `UPDATE users SET password_hash = ?, updated_at = ` + r.dialect().now() + ` WHERE id = ?`
+
+	if _, err := r.Execute(ctx, query, passwordHash, id); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	// 存在しない場合はErrUserNotFoundを返す
+	return r.FindByID(ctx, id)
+}
+
+func (r *UserRepository) CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error) {
+	query := `INSERT INTO refresh_tokens (user_id, token_hash, expires_at) VALUES (?, ?, ?)`
+
+	id, err := insertID(ctx, r.dialect(), r.SqlHandler, query, token.UserID, token.TokenHash, token.ExpiresAt)
+	if err != nil {
+		return nil, wrapWriteError(ctx, r.dialect(), err, domainErrors.ErrDuplicateEntry)
+	}
+
+	query = `SELECT ` + refreshTokenSelectColumns + ` FROM refresh_tokens WHERE id = ?`
+	return r.findRefreshToken(ctx, query, id)
+}
+
+func (r *UserRepository) FindRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	query := `SELECT ` + refreshTokenSelectColumns + ` FROM refresh_tokens WHERE token_hash = ?`
+
+	return r.findRefreshToken(ctx, query, tokenHash)
+}
+
+func (r *UserRepository) RevokeRefreshToken(ctx context.Context, id int64) (bool, error) {
+	query := `UPDATE refresh_tokens SET revoked_at = ` + r.dialect().now() + ` WHERE id = ? AND revoked_at IS NULL`
+
+	result, err := r.Execute(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return affected == 1, nil
+}
+
+func (r *UserRepository) findOne(ctx context.Context, query string, args ...interface{}) (*entity.User, error) {
+	user, err := scanUser(r.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainErrors.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return user, nil
+}
+
+func (r *UserRepository) findRefreshToken(ctx context.Context, query string, args ...interface{}) (*entity.RefreshToken, error) {
+	token, err := scanRefreshToken(r.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainErrors.ErrRefreshTokenNotFound
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return token, nil
+}
+
+func scanUser(row Row) (*entity.User, error) {
+	var user entity.User
+	if err := row.Scan(&user.ID, &user.Email, &user.Role, &user.PasswordHash, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func scanRefreshToken(row Row) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	var revokedAt sql.NullTime
+	if err := row.Scan(&token.ID, &token.UserID, &token.TokenHash, &token.ExpiresAt, &token.CreatedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+	return &token, nil
+}
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+var (
+	userColumns         = []string{"id", "email", "role", "password_hash", "created_at", "updated_at"}
+	refreshTokenColumns = []string{"id", "user_id", "token_hash", "expires_at", "created_at", "revoked_at"}
+)
+
+func newMockUserRepository(t *testing.T) (*UserRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return &UserRepository{SqlHandler: &testSqlHandler{db: db}}, mock
+}
+
+func TestUserRepository_Create(t *testing.T) {
+	user := &entity.User{Email: "alice@example.com", Role: entity.UserRoleUser, PasswordHash: "hash"}
+
+	t.Run("正常系: ハッシュ値を保存する", func(t *testing.T) {
+		repo, mock := newMockUserRepository(t)
+		now := time.Now()
+		mock.ExpectExec(`INSERT INTO users \(email, role, password_hash\) VALUES \(\?, \?, \?\)`).
+			WithArgs("alice@example.com", entity.UserRoleUser, "hash").
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectQuery(`FROM users WHERE id = \?`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows(userColumns).AddRow(2, "alice@example.com", "user", "hash", now, now))
+
+		created, err := repo.Create(context.Background(), user)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), created.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("異常系: メールアドレスの重複", func(t *testing.T) {
+		repo, mock := newMockUserRepository(t)
+		mock.ExpectExec(`INSERT INTO users`).
+			WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'alice@example.com' for key 'uk_email'"})
+
+		_, err := repo.Create(context.Background(), user)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateEmail)
+	})
+}
+
+func TestUserRepository_FindByEmail_NotFound(t *testing.T) {
+	repo, mock := newMockUserRepository(t)
+	mock.ExpectQuery(`FROM users WHERE email = \?`).
+		WithArgs("alice@example.com").
+		WillReturnRows(sqlmock.NewRows(userColumns))
+
+	_, err := repo.FindByEmail(context.Background(), "alice@example.com")
+
+	assert.ErrorIs(t, err, domainErrors.ErrUserNotFound)
+}
+
+func TestUserRepository_UpdateRole(t *testing.T) {
+	repo, mock := newMockUserRepository(t)
+	now := time.Now()
+	mock.ExpectExec(`UPDATE users SET role = \?, updated_at = NOW\(\) WHERE id = \?`).
+		WithArgs(entity.UserRoleAdmin, int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`FROM users WHERE id = \?`).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows(userColumns).AddRow(2, "alice@example.com", "admin", "hash", now, now))
+
+	updated, err := repo.UpdateRole(context.Background(), 2, entity.UserRoleAdmin)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserRoleAdmin, updated.Role)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_FindRefreshToken(t *testing.T) {
+	now := time.Now()
+	repo, mock := newMockUserRepository(t)
+	mock.ExpectQuery(`SELECT id, user_id, token_hash, expires_at, created_at, revoked_at FROM refresh_tokens WHERE token_hash = \?`).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows(refreshTokenColumns).AddRow(1, 2, "hash", now.Add(time.Hour), now, now))
+
+	token, err := repo.FindRefreshToken(context.Background(), "hash")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), token.UserID)
+	// 失効済みのトークンも取得し、使えるかどうかは呼び出し元で判定する
+	require.NotNil(t, token.RevokedAt)
+	assert.False(t, token.Usable(now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_RevokeRefreshToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		want     bool
+	}{
+		{name: "未失効のトークン", affected: 1, want: true},
+		// 同じトークンで同時に再発行した場合、失効できるのは一方のみ
+		{name: "失効済みのトークン", affected: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockUserRepository(t)
+			mock.ExpectExec(`UPDATE refresh_tokens SET revoked_at = NOW\(\) WHERE id = \? AND revoked_at IS NULL`).
+				WithArgs(int64(1)).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			revoked, err := repo.RevokeRefreshToken(context.Background(), 1)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, revoked)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type WebhookRepository struct {
+	SqlHandler
+	// 未設定の場合はMySQL
+	Dialect Dialect
+}
+
+func (r *WebhookRepository) dialect() Dialect {
+	return dialectOrDefault(r.Dialect)
+}
+
+// scanWebhookと同じ順序で並べたSELECT対象の列
+const webhookSelectColumns = "id, url, secret, enabled, failure_count, created_at, updated_at"
+
+func (r *WebhookRepository) FindAll(ctx context.Context) ([]*entity.Webhook, error) {
+	query := `SELECT ` + webhookSelectColumns + ` FROM webhooks ORDER BY id`
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	webhooks := make([]*entity.Webhook, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) FindByID(ctx context.Context, id int64) (*entity.Webhook, error) {
+	query := `SELECT ` + webhookSelectColumns + ` FROM webhooks WHERE id = ?`
+
+	webhook, err := scanWebhook(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainErrors.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return webhook, nil
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	query := `INSERT INTO webhooks (url, secret, enabled) VALUES (?, ?, ?)`
+
+	id, err := insertID(ctx, r.dialect(), r.SqlHandler, query, webhook.URL, webhook.Secret, webhook.Enabled)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// 送信の記録は外部キーで連鎖削除される
+func (r *WebhookRepository) Delete(ctx context.Context, id int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %w", domainErrors.ErrDatabaseError, err)
+	}
+	if rowsAffected == 0 {
+		return domainErrors.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) Enable(ctx context.Context, id int64) (*entity.Webhook, error) {
+	query := `UPDATE webhooks SET enabled = TRUE, failure_count = 0, updated_at = ` + r.dialect().now() + ` WHERE id = ?`
+
+	if _, err := r.Execute(ctx, query, id); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	// 存在しない場合はErrWebhookNotFoundを返す
+	return r.FindByID(ctx, id)
+}
+
+func (r *WebhookRepository) RecordResult(ctx context.Context, id int64, succeeded bool, maxFailures int) error {
+	query := `UPDATE webhooks SET failure_count = 0, updated_at = ` + r.dialect().now() + ` WHERE id = ?`
+	args := []interface{}{id}
+	if !succeeded {
+		// MySQLはSETの代入を左から順に評価するため、failure_countを増やす前の値で無効にするかを判定する
+		query = `
+        UPDATE webhooks
+        SET enabled = CASE WHEN failure_count + 1 >= ? THEN FALSE ELSE enabled END,
+            failure_count = failure_count + 1,
+            updated_at = ` + r.dialect().now() + `
+        WHERE id = ?
+    `
+		args = []interface{}{maxFailures, id}
+	}
+
+	if _, err := r.Execute(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	return inTx(ctx, r.SqlHandler, r.dialect(), func(tx Transaction) error {
+		// 配信中に削除されたWebhookの記録は保存しない。記録の途中で削除されないようロックする
+		var webhookID int64
+		err := tx.QueryRow(ctx, `SELECT id FROM webhooks WHERE id = ?`+r.dialect().forUpdate(), delivery.WebhookID).Scan(&webhookID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domainErrors.ErrWebhookNotFound
+			}
+			return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+
+		query := `
+        INSERT INTO webhook_deliveries (webhook_id, event_id, event, item_id, attempt, status_code, error, succeeded, duration_ms)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `
+		var statusCode sql.NullInt64
+		if delivery.StatusCode != nil {
+			statusCode = sql.NullInt64{Int64: int64(*delivery.StatusCode), Valid: true}
+		}
+		id, err := insertID(ctx, r.dialect(), tx, query,
+			delivery.WebhookID,
+			delivery.EventID,
+			delivery.Event,
+			delivery.ItemID,
+			delivery.Attempt,
+			statusCode,
+			delivery.Error,
+			delivery.Succeeded,
+			delivery.DurationMs,
+		)
+		if err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+
+		delivery.ID = id
+		return nil
+	})
+}
+
+func (r *WebhookRepository) FindDeliveries(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error) {
+	query := `
+        SELECT id, webhook_id, event_id, event, item_id, attempt, status_code, error, succeeded, duration_ms, created_at
+        FROM webhook_deliveries
+        WHERE webhook_id = ?
+        ORDER BY id DESC
+        LIMIT ?
+    `
+
+	rows, err := r.Query(ctx, query, webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]*entity.WebhookDelivery, 0)
+	for rows.Next() {
+		var d entity.WebhookDelivery
+		var statusCode sql.NullInt64
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.Event, &d.ItemID, &d.Attempt, &statusCode, &d.Error, &d.Succeeded, &d.DurationMs, &d.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+		}
+		if statusCode.Valid {
+			code := int(statusCode.Int64)
+			d.StatusCode = &code
+		}
+		deliveries = append(deliveries, &d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) DeleteDeliveriesBefore(ctx context.Context, createdBefore time.Time) (int64, error) {
+	result, err := r.Execute(ctx, `DELETE FROM webhook_deliveries WHERE created_at <= ?`, createdBefore)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return deleted, nil
+}
+
+func scanWebhook(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Webhook, error) {
+	var webhook entity.Webhook
+
+	if err := scanner.Scan(&webhook.ID, &webhook.URL, &webhook.Secret, &webhook.Enabled, &webhook.FailureCount, &webhook.CreatedAt, &webhook.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
@@ -0,0 +1,4 @@
+package graphql
+
+// generated.goとmodels_gen.goはschema.graphqlsとgqlgen.ymlから生成する。スキーマを変更した場合は go generate で再生成する
+//go:generate go run github.com/99designs/gqlgen@v0.17.70 generate
@@ -0,0 +1,206 @@
+// Package graphql はアイテムを読み取るためのGraphQLのエンドポイントを提供する。
+//
+// スキーマはschema.graphqlsに定義し、クエリの解析・検証・実行はgqlgenが生成したコード（generated.go）で行う。
+// 読み取り専用のクエリのみを扱い、ミューテーション・サブスクリプション・イントロスペクションは扱わない。
+// 実行の前にクエリの深さと複雑さを見積もり、上限を超えるクエリはデータベースに問い合わせずに拒否する
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/99designs/gqlgen/graphql/executor"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+)
+
+// 実行するリクエスト。OperationNameはクエリに複数の操作が含まれる場合のみ必須
+type Request struct {
+	Query         string          `json:"query"`
+	OperationName string          `json:"operationName,omitempty"`
+	Variables     json.RawMessage `json:"variables,omitempty"` // JSONのオブジェクト
+}
+
+// 実行結果。クエリの構文・検証の誤りや制限の超過で実行しなかった場合はDataを含まず、Errorsのみを返す
+type Response struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors gqlerror.List   `json:"errors,omitempty"`
+}
+
+// クエリの大きさの上限。0の場合は制限しない
+type Limits struct {
+	// フィールドの入れ子の深さ。ルートのフィールドを1とし、フラグメントは展開して数える
+	MaxDepth int
+	// フィールドごとのコストの合計。gqlgenのComplexityLimitで、スキーマのComplexityRootの見積もりから求める
+	MaxComplexity int
+}
+
+// 実行するスキーマ。Prepareはリクエストごとに、実行の前にデータローダーなどをctxに設定する
+type Schema struct {
+	Executable graphql.ExecutableSchema
+	Prepare    func(ctx context.Context) context.Context
+}
+
+// スキーマと上限を設定したgqlgenの実行器
+type runner struct {
+	schema *Schema
+	exec   *executor.Executor
+}
+
+// schemaがnilの場合も作成でき、実行すると内部エラーを返す
+func newRunner(schema *Schema, limits Limits) *runner {
+	r := &runner{schema: schema}
+	if schema == nil {
+		return r
+	}
+	r.exec = executor.New(schema.Executable)
+	if limits.MaxDepth > 0 {
+		r.exec.Use(&depthLimit{max: limits.MaxDepth})
+	}
+	if limits.MaxComplexity > 0 {
+		r.exec.Use(extension.FixedComplexityLimit(limits.MaxComplexity))
+	}
+	return r
+}
+
+// リクエストを検証し、問題がなければschemaで実行する
+func Execute(ctx context.Context, schema *Schema, req Request, limits Limits) *Response {
+	return newRunner(schema, limits).run(ctx, req)
+}
+
+func (r *runner) run(ctx context.Context, req Request) *Response {
+	if r.exec == nil {
+		return &Response{Errors: gqlerror.List{gqlerror.Errorf("schema is not configured")}}
+	}
+
+	variables, err := decodeVariables(req.Variables)
+	if err != nil {
+		return &Response{Errors: gqlerror.List{badRequestError(err.Error())}}
+	}
+
+	ctx = graphql.StartOperationTrace(ctx)
+	params := &graphql.RawParams{
+		Query:         req.Query,
+		OperationName: req.OperationName,
+		Variables:     variables,
+	}
+	opCtx, errs := r.exec.CreateOperationContext(ctx, params)
+	if errs != nil {
+		res := r.exec.DispatchError(graphql.WithOperationContext(ctx, opCtx), errs)
+		return &Response{Errors: res.Errors}
+	}
+
+	if r.schema.Prepare != nil {
+		ctx = r.schema.Prepare(ctx)
+	}
+	responses, ctx := r.exec.DispatchOperation(ctx, opCtx)
+	res := responses(ctx)
+	return &Response{Data: res.Data, Errors: res.Errors}
+}
+
+// 変数をJSONのオブジェクトとして読み込む。整数の変数を正確に扱うため、数値はjson.Numberとする
+func decodeVariables(raw json.RawMessage) (map[string]any, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+	var variables map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&variables); err != nil {
+		return nil, errors.New("variables must be a JSON object")
+	}
+	return variables, nil
+}
+
+// リクエストの形式の誤り
+func badRequestError(message string) *gqlerror.Error {
+	return &gqlerror.Error{Message: message, Extensions: map[string]any{"code": httperror.CodeBadRequest}}
+}
+
+// クエリの深さの上限を超えた場合のエラーコード。gqlgenのComplexityLimitのCOMPLEXITY_LIMIT_EXCEEDEDに合わせる
+const errDepthLimit = "DEPTH_LIMIT_EXCEEDED"
+
+// 実行する操作のフィールドの入れ子の深さを制限するgqlgenの拡張
+type depthLimit struct {
+	max int
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = &depthLimit{}
+
+func (d *depthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (d *depthLimit) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d *depthLimit) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	if depth := selectionDepth(opCtx.Operation.SelectionSet); depth > d.max {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.max)
+		errcode.Set(err, errDepthLimit)
+		return err
+	}
+	return nil
+}
+
+// フィールドの入れ子の深さ。フラグメントは展開して数える。検証済みの操作を対象とするため、フラグメントの循環はない
+func selectionDepth(set ast.SelectionSet) int {
+	depth := 0
+	for _, sel := range set {
+		var d int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			d = 1 + selectionDepth(sel.SelectionSet)
+		case *ast.InlineFragment:
+			d = selectionDepth(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			if sel.Definition != nil {
+				d = selectionDepth(sel.Definition.SelectionSet)
+			}
+		}
+		depth = max(depth, d)
+	}
+	return depth
+}
+
+// IDのスカラーとして、int64のIDを文字列で返す
+func MarshalInt64ID(id int64) graphql.Marshaler {
+	return graphql.WriterFunc(func(w io.Writer) {
+		_, _ = io.WriteString(w, strconv.Quote(strconv.FormatInt(id, 10)))
+	})
+}
+
+// IDのスカラーの値をint64のIDとして読み込む。文字列と整数を受け付ける
+func UnmarshalInt64ID(v any) (int64, error) {
+	switch v := v.(type) {
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case json.Number:
+		return strconv.ParseInt(string(v), 10, 64)
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	default:
+		return 0, fmt.Errorf("%T is not an ID", v)
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,46 @@
+package graphql
+
+import (
+	"net/http"
+
+	"Aicon-assignment/internal/interfaces/openapi"
+)
+
+// GraphQLのエンドポイントの操作。クエリの結果の形は選択したフィールドによるため、dataの中身は定めない
+func Operations() []openapi.Operation {
+	location := openapi.Object(map[string]*openapi.Schema{"line": openapi.Integer(), "column": openapi.Integer()})
+	gqlError := openapi.Object(map[string]*openapi.Schema{"message": openapi.String()}).WithOptional(map[string]*openapi.Schema{
+		"locations":  openapi.ArrayOf(location),
+		"path":       openapi.ArrayOf(&openapi.Schema{}).Describe("フィールドの応答名とリストの添字"),
+		"extensions": {Type: "object", Description: "codeに機械可読なエラーコード"},
+	})
+	response := (&openapi.Schema{Type: "object", AdditionalProperties: false}).WithOptional(map[string]*openapi.Schema{
+		"data":   {Type: "object", Nullable: true, Description: "クエリの結果。実行しなかった場合は含まない"},
+		"errors": openapi.ArrayOf(gqlError),
+	})
+
+	responses := openapi.Responses{
+		http.StatusOK:         openapi.JSON("クエリの結果（フィールドのエラーはerrorsで返す）", response),
+		http.StatusBadRequest: openapi.JSON("リクエストの形式・クエリの誤り、深さ・複雑さの上限の超過", response),
+	}
+	return []openapi.Operation{
+		{
+			Method: http.MethodGet, Path: "/graphql", ID: "queryGraphQL", Summary: "GraphQLのクエリの実行（読み取り専用）", Tags: []string{"graphql"},
+			Parameters: []*openapi.Parameter{
+				openapi.Query("query", openapi.String(), "GraphQLのクエリ"),
+				openapi.Query("variables", openapi.String(), "変数（JSONのオブジェクト）"),
+				openapi.Query("operationName", openapi.String(), "実行する操作の名前。クエリに複数の操作が含まれる場合は必須"),
+			},
+			Responses: responses,
+		},
+		{
+			Method: http.MethodPost, Path: "/graphql", ID: "postGraphQL", Summary: "GraphQLのクエリの実行（読み取り専用）", Tags: []string{"graphql"},
+			RequestBody: openapi.JSONBody(openapi.Object(map[string]*openapi.Schema{"query": openapi.String()}).WithOptional(map[string]*openapi.Schema{
+				"operationName": openapi.String(),
+				"variables":     {Type: "object", Description: "変数"},
+			})),
+			Responses: responses,
+			Errors:    []int{http.StatusRequestEntityTooLarge},
+		},
+	}
+}
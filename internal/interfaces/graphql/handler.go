@@ -0,0 +1,55 @@
+package graphql
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/interfaces/controller/request"
+)
+
+type Handler struct {
+	runner *runner
+}
+
+func NewHandler(schema *Schema, limits Limits) *Handler {
+	return &Handler{runner: newRunner(schema, limits)}
+}
+
+// GET /graphql?query=...&variables=...&operationName=...
+// POST /graphql
+//
+// 実行したクエリは、フィールドのエラーを含む場合も200で返す。
+// リクエストの形式の誤りと、クエリの構文・検証の誤り、深さ・複雑さの上限の超過は、実行せずに400で返す
+func (h *Handler) Query(c echo.Context) error {
+	var req Request
+	if c.Request().Method == http.MethodGet {
+		req.Query = c.QueryParam("query")
+		req.OperationName = c.QueryParam("operationName")
+		req.Variables = []byte(c.QueryParam("variables"))
+	} else if err := request.Decode(c, &req); err != nil {
+		if errors.Is(err, httperror.ErrBodyTooLarge) {
+			return httperror.Respond(c, err, "invalid request format")
+		}
+		return badRequest(c, err.Error())
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		return badRequest(c, "query is required")
+	}
+
+	res := h.runner.run(c.Request().Context(), req)
+	if res.Data == nil {
+		return c.JSON(http.StatusBadRequest, res)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// リクエストの形式の誤りを、クエリの誤りと同じerrorsの形式で400で返す
+func badRequest(c echo.Context, message string) error {
+	return c.JSON(http.StatusBadRequest, &Response{Errors: gqlerror.List{badRequestError(message)}})
+}
@@ -0,0 +1,160 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/openapi/openapitest"
+)
+
+func serveGraphQL(t *testing.T, req *http.Request, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	schema, _ := newTestItemSchema(t)
+	h := NewHandler(schema, Limits{MaxDepth: 8, MaxComplexity: 5000})
+	rec := httptest.NewRecorder()
+	require.NoError(t, h.Query(echo.New().NewContext(req, rec)))
+	openapitest.AssertExchange(t, openapitest.Document("", Operations()...), req.Method, "/graphql", body, rec)
+	return rec
+}
+
+func postGraphQL(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	return serveGraphQL(t, req, body)
+}
+
+func decodeResponse(t *testing.T, rec *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+
+	var res map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return res
+}
+
+func TestHandler_Query(t *testing.T) {
+	t.Run("正常系: POSTのクエリと変数", func(t *testing.T) {
+		rec := postGraphQL(t, `{"query": "query ($id: ID!) { item(id: $id) { name } }", "variables": {"id": 1}}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"data": {"item": {"name": "デイトナ"}}}`, rec.Body.String())
+	})
+
+	t.Run("正常系: GETのクエリと変数", func(t *testing.T) {
+		params := url.Values{
+			"query":     {`query ($id: ID!) { item(id: $id) { name } }`},
+			"variables": {`{"id": "2"}`},
+		}
+		rec := serveGraphQL(t, httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil), "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"data": {"item": {"name": "バーキン"}}}`, rec.Body.String())
+	})
+
+	t.Run("正常系: フィールドのエラーは200でdataとerrorsを返す", func(t *testing.T) {
+		rec := postGraphQL(t, `{"query": "{ item(id: 999) { name } summary { total } }"}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		res := decodeResponse(t, rec)
+		assert.Equal(t, map[string]interface{}{"item": nil, "summary": map[string]interface{}{"total": float64(3)}}, res["data"])
+		errs := res["errors"].([]interface{})
+		require.Len(t, errs, 1)
+		assert.Equal(t, "item_not_found", errs[0].(map[string]interface{})["extensions"].(map[string]interface{})["code"])
+	})
+
+	t.Run("異常系: イントロスペクションは扱わない", func(t *testing.T) {
+		rec := postGraphQL(t, `{"query": "{ __schema { types { name } } }"}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"data": {"__schema": null}, "errors": [{"message": "introspection disabled", "path": ["__schema"]}]}`, rec.Body.String())
+	})
+}
+
+func TestHandler_Query_BadRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		query        url.Values
+		expectedMsg  string
+		expectedCode string
+	}{
+		{
+			name:         "異常系: クエリがない",
+			method:       http.MethodPost,
+			body:         `{"variables": {}}`,
+			expectedMsg:  "query is required",
+			expectedCode: "bad_request",
+		},
+		{
+			name:         "異常系: JSONの形式の誤り",
+			method:       http.MethodPost,
+			body:         `{"query": `,
+			expectedCode: "bad_request",
+		},
+		{
+			name:         "異常系: 変数がJSONのオブジェクトでない",
+			method:       http.MethodGet,
+			query:        url.Values{"query": {"{ summary { total } }"}, "variables": {"[1]"}},
+			expectedMsg:  "variables must be a JSON object",
+			expectedCode: "bad_request",
+		},
+		{
+			name:         "異常系: 構文の誤り",
+			method:       http.MethodPost,
+			body:         `{"query": "{ items { total }"}`,
+			expectedMsg:  "Expected Name",
+			expectedCode: "GRAPHQL_PARSE_FAILED",
+		},
+		{
+			name:         "異常系: ミューテーション",
+			method:       http.MethodPost,
+			body:         `{"query": "mutation { deleteItem(id: 1) }"}`,
+			expectedMsg:  `Schema does not support operation type "mutation"`,
+			expectedCode: "GRAPHQL_VALIDATION_FAILED",
+		},
+
+		{
+			name:         "異常系: 複雑さの上限を超える",
+			method:       http.MethodPost,
+			body:         `{"query": "{ items(limit: 200) { items { id name brand category images { id url displayOrder createdAt } } } }"}`,
+			expectedMsg:  "exceeds the limit of 5000",
+			expectedCode: "COMPLEXITY_LIMIT_EXCEEDED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req *http.Request
+			if tt.method == http.MethodGet {
+				req = httptest.NewRequest(http.MethodGet, "/graphql?"+tt.query.Encode(), nil)
+			} else {
+				req = httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tt.body))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			}
+			rec := serveGraphQL(t, req, tt.body)
+
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			res := decodeResponse(t, rec)
+			// 実行しなかったクエリはdataを含まない
+			assert.NotContains(t, res, "data")
+			errs := res["errors"].([]interface{})
+			require.Len(t, errs, 1)
+			gqlErr := errs[0].(map[string]interface{})
+			assert.Contains(t, gqlErr["message"], tt.expectedMsg)
+			if tt.expectedCode != "" {
+				assert.Equal(t, tt.expectedCode, gqlErr["extensions"].(map[string]interface{})["code"])
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vikstrous/dataloadgen"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/usecase"
+)
+
+// アイテムの一覧・詳細・カテゴリーごとの集計を読み取るスキーマ（schema.graphqls）。
+// タグはアイテムと同じ問い合わせで読み込まれ、画像は一覧のアイテムの分をデータローダーでまとめて読み込む
+func NewItemSchema(itemUsecase usecase.ItemUsecase, imageUsecase usecase.ItemImageUsecase) *Schema {
+	cfg := Config{Resolvers: &Resolver{itemUsecase: itemUsecase}}
+
+	// 一覧はlimit件（省略時は既定の件数）のアイテムを返すものとして見積もる
+	cfg.Complexity.Query.Items = func(child int, filter *ItemFilter, sort *ItemSort, limit *int64, offset *int64, cursor *string) int {
+		n := int64(usecase.DefaultListLimit)
+		if limit != nil && *limit > 0 {
+			n = min(*limit, usecase.MaxListLimit)
+		}
+		return 1 + int(n)*child
+	}
+	cfg.Complexity.Item.Images = listComplexity(entity.MaxImagesPerItem)
+	cfg.Complexity.CategorySummary.Categories = listComplexity(summaryCategoryEstimate)
+	cfg.Complexity.CategoryStats.Conditions = listComplexity(len(entity.ValidConditions))
+
+	return &Schema{
+		Executable: NewExecutableSchema(cfg),
+		Prepare: func(ctx context.Context) context.Context {
+			return context.WithValue(ctx, loadersKey{}, &itemLoaders{
+				images: dataloadgen.NewMappedLoader(imageUsecase.ListImagesByItemIDs, dataloadgen.WithBatchCapacity(usecase.MaxListLimit)),
+			})
+		},
+	}
+}
+
+// 集計のカテゴリーの件数の見積もり。カテゴリーは管理者が登録するもので、通常はこの件数に収まる
+const summaryCategoryEstimate = 20
+
+// 件数がestimate件程度のリストを返すフィールドとして複雑さを見積もる
+func listComplexity(estimate int) func(child int) int {
+	return func(child int) int {
+		return 1 + estimate*child
+	}
+}
+
+// リクエストごとのデータローダー
+type itemLoaders struct {
+	images *dataloadgen.Loader[int64, []*entity.ItemImage]
+}
+
+type loadersKey struct{}
+
+// generated.goのResolverRootの実装。フィールドの値はユースケースから取得する
+type Resolver struct {
+	itemUsecase usecase.ItemUsecase
+}
+
+func (r *Resolver) Query() QueryResolver         { return &queryResolver{r} }
+func (r *Resolver) Item() ItemResolver           { return &itemResolver{r} }
+func (r *Resolver) ItemImage() ItemImageResolver { return &itemImageResolver{r} }
+func (r *Resolver) ItemList() ItemListResolver   { return &itemListResolver{r} }
+
+type queryResolver struct{ *Resolver }
+
+func (r *queryResolver) Items(ctx context.Context, filter *ItemFilter, sort *ItemSort, limit *int64, offset *int64, cursor *string) (*usecase.ItemList, error) {
+	input, err := listItemsInput(filter, sort, limit, offset, cursor)
+	if err != nil {
+		return nil, resolverError(ctx, err, "failed to retrieve items")
+	}
+	list, err := r.itemUsecase.GetAllItems(ctx, input)
+	if err != nil {
+		return nil, resolverError(ctx, err, "failed to retrieve items")
+	}
+	return list, nil
+}
+
+func (r *queryResolver) Item(ctx context.Context, id string) (*entity.Item, error) {
+	itemID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || itemID <= 0 {
+		return nil, resolverError(ctx, fmt.Errorf("%w: id must be a positive integer", domainErrors.ErrInvalidInput), "failed to retrieve item")
+	}
+	item, err := r.itemUsecase.GetItemByID(ctx, itemID)
+	if err != nil {
+		return nil, resolverError(ctx, err, "failed to retrieve item")
+	}
+	return item, nil
+}
+
+func (r *queryResolver) Summary(ctx context.Context) (*usecase.CategorySummary, error) {
+	summary, err := r.itemUsecase.GetCategorySummary(ctx)
+	if err != nil {
+		return nil, resolverError(ctx, err, "failed to retrieve summary")
+	}
+	return summary, nil
+}
+
+type itemResolver struct{ *Resolver }
+
+func (r *itemResolver) PurchaseDate(ctx context.Context, obj *entity.Item) (string, error) {
+	return obj.PurchaseDate.String(), nil
+}
+
+func (r *itemResolver) SoldDate(ctx context.Context, obj *entity.Item) (*string, error) {
+	if obj.SoldDate == nil {
+		return nil, nil
+	}
+	date := obj.SoldDate.String()
+	return &date, nil
+}
+
+func (r *itemResolver) CreatedAt(ctx context.Context, obj *entity.Item) (string, error) {
+	return formatTime(obj.CreatedAt), nil
+}
+
+func (r *itemResolver) UpdatedAt(ctx context.Context, obj *entity.Item) (string, error) {
+	return formatTime(obj.UpdatedAt), nil
+}
+
+func (r *itemResolver) Images(ctx context.Context, obj *entity.Item) ([]*entity.ItemImage, error) {
+	// 詳細の取得では画像も読み込み済み
+	if obj.Images != nil {
+		return obj.Images, nil
+	}
+	loaders, ok := ctx.Value(loadersKey{}).(*itemLoaders)
+	if !ok {
+		return nil, errors.New("item loaders are not prepared")
+	}
+	images, err := loaders.images.Load(ctx, obj.ID)
+	if err != nil && !errors.Is(err, dataloadgen.ErrNotFound) {
+		return nil, resolverError(ctx, err, "failed to retrieve item images")
+	}
+	// 画像のないアイテムは空のリストとする
+	if images == nil {
+		return []*entity.ItemImage{}, nil
+	}
+	return images, nil
+}
+
+type itemImageResolver struct{ *Resolver }
+
+func (r *itemImageResolver) CreatedAt(ctx context.Context, obj *entity.ItemImage) (string, error) {
+	return formatTime(obj.CreatedAt), nil
+}
+
+type itemListResolver struct{ *Resolver }
+
+func (r *itemListResolver) NextCursor(ctx context.Context, obj *usecase.ItemList) (*string, error) {
+	if obj.NextCursor == "" {
+		return nil, nil
+	}
+	return &obj.NextCursor, nil
+}
+
+// 引数を一覧取得の入力に変換する。値の正規化はREST APIのクエリパラメータと同じ
+func listItemsInput(filter *ItemFilter, sort *ItemSort, limit, offset *int64, cursor *string) (usecase.ListItemsInput, error) {
+	var input usecase.ListItemsInput
+	if limit != nil {
+		if *limit < 1 {
+			return input, fmt.Errorf("%w: limit must be 1 or greater", domainErrors.ErrInvalidInput)
+		}
+		input.Limit = int(min(*limit, usecase.MaxListLimit))
+	}
+	if offset != nil {
+		if *offset < 0 {
+			return input, fmt.Errorf("%w: offset must be 0 or greater", domainErrors.ErrInvalidInput)
+		}
+		input.Offset = int(*offset)
+	}
+	input.Cursor = value(cursor)
+
+	if sort != nil {
+		input.Sort.Field = strings.ToLower(sort.Field.String())
+		if sort.Order != nil {
+			input.Sort.Order = strings.ToLower(sort.Order.String())
+		}
+	}
+
+	if filter == nil {
+		return input, nil
+	}
+	input.Filter = entity.ItemFilter{
+		Category:       entity.NormalizeText(value(filter.Category)),
+		Condition:      strings.TrimSpace(value(filter.Condition)),
+		Status:         strings.ToLower(strings.TrimSpace(value(filter.Status))),
+		Brand:          entity.NormalizeBrandName(value(filter.Brand)),
+		Keyword:        value(filter.Keyword),
+		IncludeDeleted: value(filter.IncludeDeleted),
+	}
+	if location := entity.NormalizePurchaseLocation(value(filter.PurchaseLocation)); location != nil {
+		input.Filter.PurchaseLocation = *location
+	}
+	if filter.Tags != nil {
+		input.Filter.Tags = entity.NormalizeTags(filter.Tags)
+	}
+
+	var errs []string
+	if filter.MinPrice != nil && *filter.MinPrice < 0 {
+		errs = append(errs, "minPrice must be 0 or greater")
+	} else {
+		input.Filter.MinPrice = filter.MinPrice
+	}
+	if filter.MaxPrice != nil && *filter.MaxPrice < 0 {
+		errs = append(errs, "maxPrice must be 0 or greater")
+	} else {
+		input.Filter.MaxPrice = filter.MaxPrice
+	}
+	if input.Filter.MinPrice != nil && input.Filter.MaxPrice != nil && *input.Filter.MinPrice > *input.Filter.MaxPrice {
+		errs = append(errs, "minPrice must be less than or equal to maxPrice")
+	}
+	for _, d := range []struct {
+		name  string
+		value *string
+	}{{"purchasedFrom", filter.PurchasedFrom}, {"purchasedTo", filter.PurchasedTo}} {
+		s := strings.TrimSpace(value(d.value))
+		if s == "" {
+			continue
+		}
+		date, err := entity.ParsePurchaseDate(s)
+		if err != nil {
+			errs = append(errs, d.name+" must be in YYYY-MM-DD format")
+			continue
+		}
+		t := date.Time()
+		if d.name == "purchasedFrom" {
+			input.Filter.PurchasedFrom = &t
+		} else {
+			input.Filter.PurchasedTo = &t
+		}
+	}
+	if input.Filter.PurchasedFrom != nil && input.Filter.PurchasedTo != nil && input.Filter.PurchasedFrom.After(*input.Filter.PurchasedTo) {
+		errs = append(errs, "purchasedFrom must be on or before purchasedTo")
+	}
+	if len(errs) > 0 {
+		return input, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, strings.Join(errs, "; "))
+	}
+
+	return input, nil
+}
+
+// ユースケースのエラーをフィールドのエラーに変換する。分類はREST APIと共通のhttperror.Fromの対応を使い、
+// 機械可読なコードとバリデーションエラーはextensionsで返す。分類できないエラーは内部の詳細を返さずmessageを使う
+func resolverError(ctx context.Context, err error, message string) error {
+	status, res := httperror.From(err, message)
+	switch {
+	case status >= http.StatusInternalServerError:
+		slog.ErrorContext(ctx, "リクエストの処理に失敗しました", "error", err)
+	case errors.Is(err, domainErrors.ErrValidation):
+		slog.InfoContext(ctx, "入力値の検証に失敗しました", "error", err)
+	}
+
+	extensions := map[string]interface{}{"code": res.Code}
+	if len(res.Details) > 0 {
+		extensions["details"] = res.Details
+	}
+	if len(res.Errors) > 0 {
+		extensions["errors"] = res.Errors
+	}
+	return &gqlerror.Error{Message: res.Error, Extensions: extensions}
+}
+
+// 省略可能な引数の値。省略された場合はゼロ値
+func value[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+	return *v
+}
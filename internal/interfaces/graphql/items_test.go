@@ -0,0 +1,203 @@
+package graphql
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+	"Aicon-assignment/internal/usecase/usecasetest"
+)
+
+// 画像の一括読み込みの呼び出しを記録するテスト用のusecase.ItemImageUsecase。それ以外のメソッドは呼ぶとpanicする
+type fakeImageUsecase struct {
+	usecase.ItemImageUsecase
+
+	images map[int64][]*entity.ItemImage
+	mu     sync.Mutex
+	calls  [][]int64
+}
+
+func (u *fakeImageUsecase) ListImagesByItemIDs(ctx context.Context, itemIDs []int64) (map[int64][]*entity.ItemImage, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	// フィールドは並行に解決されるため、キーの順序は定まらない
+	keys := slices.Clone(itemIDs)
+	slices.Sort(keys)
+	u.calls = append(u.calls, keys)
+	found := make(map[int64][]*entity.ItemImage)
+	for _, id := range itemIDs {
+		if images, ok := u.images[id]; ok {
+			found[id] = images
+		}
+	}
+	return found, nil
+}
+
+func newTestItemSchema(t *testing.T) (*Schema, *fakeImageUsecase) {
+	t.Helper()
+
+	mustDate := func(s string) entity.PurchaseDate {
+		date, err := entity.ParsePurchaseDate(s)
+		require.NoError(t, err)
+		return date
+	}
+	condition := "中古A"
+	itemUsecase := usecasetest.NewItemUsecase(entity.NewCategorySet("時計", "バッグ"),
+		&entity.Item{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: mustDate("2023-01-15"), Condition: &condition, Tags: []string{"限定"}},
+		&entity.Item{Name: "バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2000000, PurchaseDate: mustDate("2023-02-20")},
+		&entity.Item{Name: "サブマリーナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1200000, PurchaseDate: mustDate("2023-03-01")},
+	)
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	imageUsecase := &fakeImageUsecase{images: map[int64][]*entity.ItemImage{
+		1: {
+			{ID: 1, ItemID: 1, URL: "/images/1.jpg", DisplayOrder: 0, CreatedAt: createdAt},
+			{ID: 2, ItemID: 1, URL: "/images/2.jpg", DisplayOrder: 1, CreatedAt: createdAt},
+		},
+		3: {{ID: 3, ItemID: 3, URL: "/images/3.jpg", DisplayOrder: 0, CreatedAt: createdAt}},
+	}}
+	return NewItemSchema(itemUsecase, imageUsecase), imageUsecase
+}
+
+func executeItems(t *testing.T, schema *Schema, req Request) (string, gqlerror.List) {
+	t.Helper()
+
+	res := Execute(context.Background(), schema, req, Limits{MaxDepth: 8, MaxComplexity: 5000})
+	return string(res.Data), res.Errors
+}
+
+func TestItemSchema_Items(t *testing.T) {
+	schema, imageUsecase := newTestItemSchema(t)
+
+	data, errs := executeItems(t, schema, Request{Query: `{
+		items(filter: { brand: "rolex" }, limit: 10) {
+			total
+			items { id name purchaseDate condition tags images { url displayOrder } }
+		}
+	}`})
+	require.Empty(t, errs)
+	assert.JSONEq(t, `{"items": {"total": 2, "items": [
+		{"id": "1", "name": "デイトナ", "purchaseDate": "2023-01-15", "condition": "中古A", "tags": ["限定"],
+		 "images": [{"url": "/images/1.jpg", "displayOrder": 0}, {"url": "/images/2.jpg", "displayOrder": 1}]},
+		{"id": "3", "name": "サブマリーナ", "purchaseDate": "2023-03-01", "condition": null, "tags": [],
+		 "images": [{"url": "/images/3.jpg", "displayOrder": 0}]}
+	]}}`, data)
+
+	// 一覧のアイテムの画像は1回の呼び出しでまとめて読み込む
+	assert.Equal(t, [][]int64{{1, 3}}, imageUsecase.calls)
+}
+
+func TestItemSchema_ItemsWithoutImages(t *testing.T) {
+	schema, imageUsecase := newTestItemSchema(t)
+
+	// 画像を選択しない場合は画像を読み込まない
+	_, errs := executeItems(t, schema, Request{Query: `{ items { items { name } } }`})
+	require.Empty(t, errs)
+	assert.Empty(t, imageUsecase.calls)
+
+	// 画像のないアイテムは空のリスト
+	data, errs := executeItems(t, schema, Request{Query: `{ item(id: 2) { name images { url } } }`})
+	require.Empty(t, errs)
+	assert.JSONEq(t, `{"item": {"name": "バーキン", "images": []}}`, data)
+}
+
+func TestItemSchema_AliasedItems(t *testing.T) {
+	schema, imageUsecase := newTestItemSchema(t)
+
+	data, errs := executeItems(t, schema, Request{Query: `
+		query ($a: ID!, $b: ID!) {
+			a: item(id: $a) { ...withImages }
+			b: item(id: $b) { ...withImages }
+		}
+		fragment withImages on Item { name images { id } }
+	`, Variables: []byte(`{"a": "1", "b": 3}`)})
+	require.Empty(t, errs)
+	assert.JSONEq(t, `{"a": {"name": "デイトナ", "images": [{"id": "1"}, {"id": "2"}]}, "b": {"name": "サブマリーナ", "images": [{"id": "3"}]}}`, data)
+
+	// 別のフィールドで取得したアイテムの画像もまとめて読み込む
+	assert.Equal(t, [][]int64{{1, 3}}, imageUsecase.calls)
+}
+
+func TestItemSchema_Summary(t *testing.T) {
+	schema, _ := newTestItemSchema(t)
+
+	data, errs := executeItems(t, schema, Request{Query: `{ summary { total totalPrice categories { category count } } }`})
+	require.Empty(t, errs)
+	assert.JSONEq(t, `{"summary": {"total": 3, "totalPrice": 4700000, "categories": [
+		{"category": "時計", "count": 2}, {"category": "バッグ", "count": 1}
+	]}}`, data)
+}
+
+func TestItemSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		expectedData string
+		expectedCode string
+	}{
+		{
+			name:         "異常系: 存在しないアイテム",
+			query:        `{ item(id: 999) { name } }`,
+			expectedData: `{"item": null}`,
+			expectedCode: "item_not_found",
+		},
+		{
+			name:         "異常系: IDの形式の誤り",
+			query:        `{ item(id: "abc") { name } }`,
+			expectedData: `{"item": null}`,
+			expectedCode: "validation_failed",
+		},
+		{
+			name:         "異常系: 件数の誤り",
+			query:        `{ items(limit: 0) { total } }`,
+			expectedData: `{"items": null}`,
+			expectedCode: "validation_failed",
+		},
+		{
+			name:         "異常系: 購入日の範囲の誤り",
+			query:        `{ items(filter: { purchasedFrom: "2023-03-01", purchasedTo: "2023-01-01" }) { total } }`,
+			expectedData: `{"items": null}`,
+			expectedCode: "validation_failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, _ := newTestItemSchema(t)
+
+			data, errs := executeItems(t, schema, Request{Query: tt.query})
+			assert.JSONEq(t, tt.expectedData, data)
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.expectedCode, errs[0].Extensions["code"])
+			assert.NotEmpty(t, errs[0].Path)
+		})
+	}
+}
+
+func TestItemSchema_Limits(t *testing.T) {
+	schema, imageUsecase := newTestItemSchema(t)
+
+	// 上限件数のアイテムの画像の一覧は複雑さの上限を超えるため、実行しない
+	data, errs := executeItems(t, schema, Request{Query: `{ items(limit: 200) { items { images { id url displayOrder createdAt } } } }`})
+	assert.Empty(t, data)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "COMPLEXITY_LIMIT_EXCEEDED", errs[0].Extensions["code"])
+	assert.Empty(t, imageUsecase.calls)
+
+	// 深さはフラグメントも展開して数える
+	res := Execute(context.Background(), schema, Request{Query: `
+		{ items { ...list } }
+		fragment list on ItemList { items { images { ... on ItemImage { id } } } }
+	`}, Limits{MaxDepth: 3})
+	assert.Empty(t, res.Data)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "DEPTH_LIMIT_EXCEEDED", res.Errors[0].Extensions["code"])
+	assert.Empty(t, imageUsecase.calls)
+}
@@ -0,0 +1,118 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package graphql
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type ItemFilter struct {
+	Category         *string  `json:"category,omitempty"`
+	Condition        *string  `json:"condition,omitempty"`
+	Status           *string  `json:"status,omitempty"`
+	Brand            *string  `json:"brand,omitempty"`
+	Keyword          *string  `json:"keyword,omitempty"`
+	MinPrice         *int64   `json:"minPrice,omitempty"`
+	MaxPrice         *int64   `json:"maxPrice,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
+	PurchaseLocation *string  `json:"purchaseLocation,omitempty"`
+	PurchasedFrom    *string  `json:"purchasedFrom,omitempty"`
+	PurchasedTo      *string  `json:"purchasedTo,omitempty"`
+	IncludeDeleted   *bool    `json:"includeDeleted,omitempty"`
+}
+
+type ItemSort struct {
+	Field ItemSortField `json:"field"`
+	Order *SortOrder    `json:"order,omitempty"`
+}
+
+type Query struct {
+}
+
+type ItemSortField string
+
+const (
+	ItemSortFieldPurchasePrice ItemSortField = "PURCHASE_PRICE"
+	ItemSortFieldPurchaseDate  ItemSortField = "PURCHASE_DATE"
+	ItemSortFieldName          ItemSortField = "NAME"
+	ItemSortFieldCreatedAt     ItemSortField = "CREATED_AT"
+)
+
+var AllItemSortField = []ItemSortField{
+	ItemSortFieldPurchasePrice,
+	ItemSortFieldPurchaseDate,
+	ItemSortFieldName,
+	ItemSortFieldCreatedAt,
+}
+
+func (e ItemSortField) IsValid() bool {
+	switch e {
+	case ItemSortFieldPurchasePrice, ItemSortFieldPurchaseDate, ItemSortFieldName, ItemSortFieldCreatedAt:
+		return true
+	}
+	return false
+}
+
+func (e ItemSortField) String() string {
+	return string(e)
+}
+
+func (e *ItemSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ItemSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ItemSortField", str)
+	}
+	return nil
+}
+
+func (e ItemSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "ASC"
+	SortOrderDesc SortOrder = "DESC"
+)
+
+var AllSortOrder = []SortOrder{
+	SortOrderAsc,
+	SortOrderDesc,
+}
+
+func (e SortOrder) IsValid() bool {
+	switch e {
+	case SortOrderAsc, SortOrderDesc:
+		return true
+	}
+	return false
+}
+
+func (e SortOrder) String() string {
+	return string(e)
+}
+
+func (e *SortOrder) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SortOrder(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SortOrder", str)
+	}
+	return nil
+}
+
+func (e SortOrder) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
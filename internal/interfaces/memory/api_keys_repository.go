@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// usecase.APIKeyRepositoryのメモリ上の実装
+type APIKeyRepository struct {
+	*Store
+}
+
+func (r *APIKeyRepository) FindAll(ctx context.Context) ([]*entity.APIKey, error) {
+	defer r.rlock(ctx)()
+
+	keys := make([]*entity.APIKey, 0, len(r.apiKeys))
+	for _, key := range r.apiKeys {
+		keys = append(keys, cloneAPIKey(key))
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	defer r.rlock(ctx)()
+
+	for _, key := range r.apiKeys {
+		if key.KeyHash == keyHash {
+			return cloneAPIKey(key), nil
+		}
+	}
+
+	return nil, domainErrors.ErrAPIKeyNotFound
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error) {
+	defer r.lock(ctx)()
+
+	// SQLの実装と同じく、ハッシュ値の一意制約を守る
+	for _, existing := range r.apiKeys {
+		if existing.KeyHash == key.KeyHash {
+			return nil, fmt.Errorf("%w: api key already exists", domainErrors.ErrDuplicateEntry)
+		}
+	}
+
+	r.lastAPIKeyID++
+	stored := cloneAPIKey(key)
+	stored.ID = r.lastAPIKeyID
+	stored.CreatedAt = entity.Now()
+	stored.RevokedAt = nil
+	r.apiKeys = append(r.apiKeys, stored)
+
+	return cloneAPIKey(stored), nil
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, id int64) (*entity.APIKey, error) {
+	defer r.lock(ctx)()
+
+	for _, key := range r.apiKeys {
+		if key.ID != id {
+			continue
+		}
+		if key.RevokedAt == nil {
+			now := entity.Now()
+			key.RevokedAt = &now
+		}
+		return cloneAPIKey(key), nil
+	}
+
+	return nil, domainErrors.ErrAPIKeyNotFound
+}
+
+func (r *APIKeyRepository) UpdateRole(ctx context.Context, id int64, role entity.UserRole) (*entity.APIKey, error) {
+	defer r.lock(ctx)()
+
+	for _, key := range r.apiKeys {
+		if key.ID == id {
+			key.Role = role
+			return cloneAPIKey(key), nil
+		}
+	}
+
+	return nil, domainErrors.ErrAPIKeyNotFound
+}
+
+func cloneAPIKey(key *entity.APIKey) *entity.APIKey {
+	clone := *key
+	if key.RevokedAt != nil {
+		revokedAt := *key.RevokedAt
+		clone.RevokedAt = &revokedAt
+	}
+	return &clone
+}
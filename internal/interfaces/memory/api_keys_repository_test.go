@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestAPIKeyRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := &APIKeyRepository{Store: NewStore()}
+
+	apiKey, _, err := entity.NewAPIKey("batch", entity.UserRoleUser)
+	require.NoError(t, err)
+	created, err := repo.Create(ctx, apiKey)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), created.ID)
+
+	// 同じハッシュ値は登録できない
+	_, err = repo.Create(ctx, apiKey)
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+
+	found, err := repo.FindByHash(ctx, apiKey.KeyHash)
+	require.NoError(t, err)
+	assert.Equal(t, "batch", found.Label)
+	assert.Equal(t, entity.UserRoleUser, found.Role)
+	_, err = repo.FindByHash(ctx, "unknown")
+	assert.ErrorIs(t, err, domainErrors.ErrAPIKeyNotFound)
+
+	revoked, err := repo.Revoke(ctx, created.ID)
+	require.NoError(t, err)
+	require.NotNil(t, revoked.RevokedAt)
+	// 失効済みの場合は失効した日時を変えない
+	again, err := repo.Revoke(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, *revoked.RevokedAt, *again.RevokedAt)
+	_, err = repo.Revoke(ctx, 99)
+	assert.ErrorIs(t, err, domainErrors.ErrAPIKeyNotFound)
+
+	// 失効済みのキーも権限を変更できる
+	updated, err := repo.UpdateRole(ctx, created.ID, entity.UserRoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserRoleAdmin, updated.Role)
+	_, err = repo.UpdateRole(ctx, 99, entity.UserRoleAdmin)
+	assert.ErrorIs(t, err, domainErrors.ErrAPIKeyNotFound)
+
+	keys, err := repo.FindAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.True(t, keys[0].Revoked())
+	assert.Equal(t, entity.UserRoleAdmin, keys[0].Role)
+}
@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// usecase.AuditLogRepositoryのメモリ上の実装
+type AuditLogRepository struct {
+	*Store
+}
+
+func (r *AuditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	defer r.lock(ctx)()
+
+	r.lastAuditLogID++
+	stored := *log
+	stored.ID = r.lastAuditLogID
+	r.auditLogs = append(r.auditLogs, &stored)
+	log.ID = stored.ID
+
+	return nil
+}
+
+// 監査ログを新しい順に取得する。SQLの実装と同じく、記録した時刻が同じ場合はIDの降順
+func (r *AuditLogRepository) FindAll(ctx context.Context, filter entity.AuditLogFilter, page entity.Pagination) ([]*entity.AuditLog, error) {
+	defer r.rlock(ctx)()
+
+	matched := r.matchingAuditLogs(filter)
+	sort.SliceStable(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+
+	logs := make([]*entity.AuditLog, 0)
+	for i := page.Offset; i < len(matched) && i < page.Offset+page.Limit; i++ {
+		log := *matched[i]
+		logs = append(logs, &log)
+	}
+
+	return logs, nil
+}
+
+func (r *AuditLogRepository) Count(ctx context.Context, filter entity.AuditLogFilter) (int, error) {
+	defer r.rlock(ctx)()
+
+	return len(r.matchingAuditLogs(filter)), nil
+}
+
+// 絞り込み条件に一致する監査ログ。呼び出し元はロックを取得しておく
+func (r *AuditLogRepository) matchingAuditLogs(filter entity.AuditLogFilter) []*entity.AuditLog {
+	var matched []*entity.AuditLog
+	for _, log := range r.auditLogs {
+		if filter.Actor != "" && log.Actor != filter.Actor {
+			continue
+		}
+		if filter.Action != "" && log.Action != filter.Action {
+			continue
+		}
+		if !filter.InRange(log.CreatedAt) {
+			continue
+		}
+		matched = append(matched, log)
+	}
+	return matched
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func TestAuditLogRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := &AuditLogRepository{Store: NewStore()}
+
+	jan := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+	logs := []*entity.AuditLog{
+		{Actor: "user:2", Action: entity.AuditActionCreate, ItemID: 1, CreatedAt: jan},
+		{Actor: "api_key:ci", Action: entity.AuditActionImport, ItemID: 2, CreatedAt: feb},
+		{Actor: "user:2", Action: entity.AuditActionUpdate, ItemID: 1, CreatedAt: feb},
+	}
+	for _, log := range logs {
+		require.NoError(t, repo.Create(ctx, log))
+	}
+	assert.Equal(t, int64(3), logs[2].ID)
+
+	// 新しい順。同じ時刻の場合はIDの降順
+	all, err := repo.FindAll(ctx, entity.AuditLogFilter{}, entity.Pagination{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, []int64{3, 2, 1}, []int64{all[0].ID, all[1].ID, all[2].ID})
+
+	page, err := repo.FindAll(ctx, entity.AuditLogFilter{}, entity.Pagination{Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, int64(2), page[0].ID)
+
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		filter   entity.AuditLogFilter
+		expected int
+	}{
+		{name: "操作者", filter: entity.AuditLogFilter{Actor: "user:2"}, expected: 2},
+		{name: "操作", filter: entity.AuditLogFilter{Action: entity.AuditActionImport}, expected: 1},
+		{name: "期間の開始", filter: entity.AuditLogFilter{From: &from}, expected: 2},
+		{name: "期間の終了は含まない", filter: entity.AuditLogFilter{To: &feb}, expected: 1},
+		{name: "組み合わせ", filter: entity.AuditLogFilter{Actor: "user:2", From: &from}, expected: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := repo.Count(ctx, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, count)
+
+			found, err := repo.FindAll(ctx, tt.filter, entity.Pagination{Limit: 10})
+			require.NoError(t, err)
+			assert.Len(t, found, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// usecase.BrandRepositoryのメモリ上の実装。名前と別名はMySQLの照合順序と同じく大文字小文字を区別せずに比較する
+type BrandRepository struct {
+	*Store
+}
+
+func (r *BrandRepository) Search(ctx context.Context, prefix string, limit int) ([]*entity.Brand, error) {
+	defer r.rlock(ctx)()
+
+	prefix = strings.ToLower(prefix)
+	var matched []*entity.Brand
+	for _, brand := range r.brands {
+		for _, name := range brandNames(brand) {
+			if strings.HasPrefix(strings.ToLower(name), prefix) {
+				matched = append(matched, brand)
+				break
+			}
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		a, b := strings.ToLower(matched[i].Name), strings.ToLower(matched[j].Name)
+		if a != b {
+			return a < b
+		}
+		return matched[i].ID < matched[j].ID
+	})
+
+	brands := make([]*entity.Brand, 0)
+	for i := 0; i < len(matched) && i < limit; i++ {
+		brands = append(brands, cloneBrand(matched[i]))
+	}
+
+	return brands, nil
+}
+
+func (r *BrandRepository) FindByID(ctx context.Context, id int64) (*entity.Brand, error) {
+	defer r.rlock(ctx)()
+
+	brand := r.findBrand(id)
+	if brand == nil {
+		return nil, domainErrors.ErrBrandNotFound
+	}
+
+	return cloneBrand(brand), nil
+}
+
+func (r *BrandRepository) FindByNameOrAlias(ctx context.Context, name string) (*entity.Brand, error) {
+	defer r.rlock(ctx)()
+
+	brand := r.findBrandByName(name)
+	if brand == nil {
+		return nil, domainErrors.ErrBrandNotFound
+	}
+
+	return cloneBrand(brand), nil
+}
+
+func (r *BrandRepository) Create(ctx context.Context, brand *entity.Brand) (*entity.Brand, error) {
+	defer r.lock(ctx)()
+
+	for _, name := range brandNames(brand) {
+		if r.findBrandByName(name) != nil {
+			return nil, fmt.Errorf("%w: brand name or alias is already registered", domainErrors.ErrDuplicateEntry)
+		}
+	}
+
+	r.lastBrandID++
+	now := entity.Now()
+	stored := cloneBrand(brand)
+	stored.ID = r.lastBrandID
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	r.brands = append(r.brands, stored)
+
+	return cloneBrand(stored), nil
+}
+
+// 統合元の名前と別名を使っているアイテム（論理削除済みを含む）を統合先の名前に書き換え、
+// 古いバージョンでの更新で元に戻らないようバージョンも進める
+func (r *BrandRepository) Merge(ctx context.Context, sourceID, targetID int64) (int64, error) {
+	defer r.lock(ctx)()
+
+	source := r.findBrand(sourceID)
+	target := r.findBrand(targetID)
+	if source == nil || target == nil {
+		return 0, domainErrors.ErrBrandNotFound
+	}
+
+	sourceNames := brandNames(source)
+	var updated int64
+	now := entity.Now()
+	for _, item := range r.items {
+		for _, name := range sourceNames {
+			if strings.EqualFold(item.Brand, name) {
+				item.Brand = target.Name
+				item.Version++
+				item.UpdatedAt = now
+				updated++
+				break
+			}
+		}
+	}
+
+	target.Aliases = append(target.Aliases, sourceNames...)
+	sort.Strings(target.Aliases)
+	for i, brand := range r.brands {
+		if brand.ID == sourceID {
+			r.brands = append(r.brands[:i], r.brands[i+1:]...)
+			break
+		}
+	}
+
+	return updated, nil
+}
+
+func (s *Store) findBrand(id int64) *entity.Brand {
+	for _, brand := range s.brands {
+		if brand.ID == id {
+			return brand
+		}
+	}
+	return nil
+}
+
+// 名前か別名が一致するブランドのうちIDが最も小さいもの。呼び出し元はロックを取得しておく
+func (s *Store) findBrandByName(name string) *entity.Brand {
+	for _, brand := range s.brands {
+		for _, n := range brandNames(brand) {
+			if strings.EqualFold(n, name) {
+				return brand
+			}
+		}
+	}
+	return nil
+}
+
+// ブランドの名前と別名
+func brandNames(brand *entity.Brand) []string {
+	return append([]string{brand.Name}, brand.Aliases...)
+}
+
+func cloneBrand(brand *entity.Brand) *entity.Brand {
+	clone := *brand
+	clone.Aliases = append(make([]string, 0, len(brand.Aliases)), brand.Aliases...)
+	return &clone
+}
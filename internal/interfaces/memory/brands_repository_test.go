@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestBrandRepository(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore(DefaultCategories()...)
+	repo := &BrandRepository{Store: s}
+	items := &ItemRepository{Store: s}
+
+	hermes, err := repo.Create(ctx, &entity.Brand{Name: "HERMÈS", Aliases: []string{"エルメス"}})
+	require.NoError(t, err)
+	rolex, err := repo.Create(ctx, &entity.Brand{Name: "ROLEX", Aliases: []string{"Rolex SA"}})
+	require.NoError(t, err)
+
+	t.Run("異常系: 名前や別名は大文字小文字を区別せずに重複とみなす", func(t *testing.T) {
+		_, err := repo.Create(ctx, &entity.Brand{Name: "rolex"})
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+	})
+
+	t.Run("正常系: 名前か別名の前方一致で検索する", func(t *testing.T) {
+		brands, err := repo.Search(ctx, "rol", 10)
+		require.NoError(t, err)
+		require.Len(t, brands, 1)
+		assert.Equal(t, rolex.ID, brands[0].ID)
+
+		brands, err = repo.Search(ctx, "", 10)
+		require.NoError(t, err)
+		assert.Len(t, brands, 2)
+
+		found, err := repo.FindByNameOrAlias(ctx, "エルメス")
+		require.NoError(t, err)
+		assert.Equal(t, hermes.ID, found.ID)
+	})
+
+	t.Run("正常系: 統合でアイテムのブランド名を書き換える", func(t *testing.T) {
+		item, err := items.Create(ctx, &entity.Item{Name: "デイトナ", Category: "時計", Brand: "rolex sa"})
+		require.NoError(t, err)
+
+		updated, err := repo.Merge(ctx, rolex.ID, hermes.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), updated)
+
+		found, err := items.FindByID(ctx, item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "HERMÈS", found.Brand)
+		assert.Equal(t, item.Version+1, found.Version)
+
+		merged, err := repo.FindByID(ctx, hermes.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ROLEX", "Rolex SA", "エルメス"}, merged.Aliases)
+
+		_, err = repo.FindByID(ctx, rolex.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrBrandNotFound)
+	})
+}
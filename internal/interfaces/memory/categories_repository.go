@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// usecase.CategoryRepositoryのメモリ上の実装
+type CategoryRepository struct {
+	*Store
+}
+
+func (r *CategoryRepository) FindAll(ctx context.Context) ([]*entity.Category, error) {
+	defer r.rlock(ctx)()
+
+	categories := make([]*entity.Category, 0, len(r.categories))
+	for _, category := range r.categories {
+		clone := *category
+		categories = append(categories, &clone)
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryRepository) FindByID(ctx context.Context, id int64) (*entity.Category, error) {
+	defer r.rlock(ctx)()
+
+	category := r.findCategory(id)
+	if category == nil {
+		return nil, domainErrors.ErrCategoryNotFound
+	}
+
+	clone := *category
+	return &clone, nil
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	defer r.lock(ctx)()
+
+	for _, c := range r.categories {
+		if c.Name == category.Name {
+			return nil, fmt.Errorf("%w: category %s already exists", domainErrors.ErrDuplicateEntry, category.Name)
+		}
+		if c.Slug == category.Slug {
+			return nil, fmt.Errorf("%w: category slug %s already exists", domainErrors.ErrDuplicateEntry, category.Slug)
+		}
+	}
+
+	clone := *r.insertCategory(category)
+	return &clone, nil
+}
+
+// カテゴリー名を変更し、そのカテゴリーのアイテム（論理削除済みを含む）も付け替える
+func (r *CategoryRepository) Rename(ctx context.Context, id int64, name string) (*entity.Category, error) {
+	defer r.lock(ctx)()
+
+	category := r.findCategory(id)
+	if category == nil {
+		return nil, domainErrors.ErrCategoryNotFound
+	}
+
+	if category.Name != name {
+		for _, c := range r.categories {
+			if c.ID != id && c.Name == name {
+				return nil, fmt.Errorf("%w: category %s already exists", domainErrors.ErrDuplicateEntry, name)
+			}
+		}
+
+		now := entity.Now()
+		for _, item := range r.items {
+			if item.Category == category.Name {
+				item.Category = name
+				item.UpdatedAt = now
+			}
+		}
+		category.Name = name
+		category.UpdatedAt = now
+	}
+
+	clone := *category
+	return &clone, nil
+}
+
+// アイテム（論理削除済みを含む）から参照されていない場合のみ削除する
+func (r *CategoryRepository) Delete(ctx context.Context, id int64) error {
+	defer r.lock(ctx)()
+
+	for i, category := range r.categories {
+		if category.ID != id {
+			continue
+		}
+		if r.countCategoryItems(category.Name) > 0 {
+			return domainErrors.ErrCategoryInUse
+		}
+		r.categories = append(r.categories[:i], r.categories[i+1:]...)
+		return nil
+	}
+
+	return domainErrors.ErrCategoryNotFound
+}
+
+func (r *CategoryRepository) CountItems(ctx context.Context, name string) (int, error) {
+	defer r.rlock(ctx)()
+
+	return r.countCategoryItems(name), nil
+}
+
+// カテゴリーを採番して保存する。呼び出し元はロックを取得しておく
+func (s *Store) insertCategory(category *entity.Category) *entity.Category {
+	s.lastCategoryID++
+	now := entity.Now()
+
+	stored := *category
+	stored.ID = s.lastCategoryID
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	s.categories = append(s.categories, &stored)
+	return &stored
+}
+
+func (s *Store) findCategory(id int64) *entity.Category {
+	for _, category := range s.categories {
+		if category.ID == id {
+			return category
+		}
+	}
+	return nil
+}
+
+func (s *Store) countCategoryItems(name string) int {
+	count := 0
+	for _, item := range s.items {
+		if item.Category == name {
+			count++
+		}
+	}
+	return count
+}
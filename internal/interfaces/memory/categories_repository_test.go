@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestCategoryRepository(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore(DefaultCategories()...)
+	repo := &CategoryRepository{Store: s}
+	items := &ItemRepository{Store: s}
+
+	t.Run("異常系: 名前かスラッグの重複", func(t *testing.T) {
+		_, err := repo.Create(ctx, &entity.Category{Slug: "clock", Name: "時計", NameEn: "Clock"})
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+		_, err = repo.Create(ctx, &entity.Category{Slug: "watch", Name: "腕時計", NameEn: "Watch"})
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+	})
+
+	t.Run("正常系: 名前の変更でアイテムのカテゴリーも付け替える", func(t *testing.T) {
+		item, err := items.Create(ctx, &entity.Item{Name: "デイトナ", Category: "時計", Brand: "ROLEX"})
+		require.NoError(t, err)
+
+		renamed, err := repo.Rename(ctx, 1, "腕時計")
+		require.NoError(t, err)
+		assert.Equal(t, "腕時計", renamed.Name)
+
+		found, err := items.FindByID(ctx, item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "腕時計", found.Category)
+		assert.Equal(t, "watch", found.CategorySlug)
+	})
+
+	t.Run("異常系: アイテムのあるカテゴリーは削除できない", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Delete(ctx, 1), domainErrors.ErrCategoryInUse)
+		assert.ErrorIs(t, repo.Delete(ctx, 100), domainErrors.ErrCategoryNotFound)
+	})
+
+	t.Run("正常系: アイテムのないカテゴリーを削除する", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, 2))
+		_, err := repo.FindByID(ctx, 2)
+		assert.ErrorIs(t, err, domainErrors.ErrCategoryNotFound)
+	})
+}
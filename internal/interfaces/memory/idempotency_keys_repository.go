@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+// 冪等キーはユーザーごとに一意
+type idempotencyKeyID struct {
+	userID int64
+	key    string
+}
+
+// アイテムの作成と冪等キーの登録をストアのロック中にまとめて行う。キーはアイテムの所有者のものとして登録する。
+// createdBefore以前に登録された期限切れのキーは置き換え、有効なキーが登録済みの場合はアイテムを作成せずにErrIdempotencyKeyExistsを返す
+func (r *ItemRepository) CreateWithIdempotencyKey(ctx context.Context, item *entity.Item, key *entity.IdempotencyKey, createdBefore time.Time) (*entity.Item, error) {
+	defer r.lock(ctx)()
+
+	ownerID := principal.OwnerID(ctx)
+	id := idempotencyKeyID{userID: ownerID, key: key.Key}
+	if existing, ok := r.idempotencyKeys[id]; ok {
+		if existing.CreatedAt.After(createdBefore) {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrIdempotencyKeyExists, key.Key)
+		}
+		delete(r.idempotencyKeys, id)
+	}
+
+	if err := r.ensureSerialNumberAvailable(ownerID, item.SerialNumber, 0); err != nil {
+		return nil, err
+	}
+
+	stored := r.insertItem(ctx, item)
+	r.idempotencyKeys[id] = &entity.IdempotencyKey{
+		UserID:      ownerID,
+		Key:         key.Key,
+		RequestHash: key.RequestHash,
+		ItemID:      stored.ID,
+		CreatedAt:   entity.Now(),
+	}
+
+	return r.snapshot(stored), nil
+}
+
+// 呼び出し元のユーザーがcreatedAfterより後に登録した冪等キーを取得する
+func (r *ItemRepository) FindIdempotencyKey(ctx context.Context, key string, createdAfter time.Time) (*entity.IdempotencyKey, error) {
+	defer r.rlock(ctx)()
+
+	k, ok := r.idempotencyKeys[idempotencyKeyID{userID: principal.OwnerID(ctx), key: key}]
+	if !ok || !k.CreatedAt.After(createdAfter) {
+		return nil, domainErrors.ErrIdempotencyKeyNotFound
+	}
+
+	clone := *k
+	return &clone, nil
+}
+
+// createdBefore以前に登録された冪等キーを削除し、削除した件数を返す
+func (r *ItemRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, createdBefore time.Time) (int64, error) {
+	defer r.lock(ctx)()
+
+	var deleted int64
+	for key, k := range r.idempotencyKeys {
+		if !k.CreatedAt.After(createdBefore) {
+			delete(r.idempotencyKeys, key)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
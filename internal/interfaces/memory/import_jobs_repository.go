@@ -0,0 +1,151 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+// usecase.ImportJobRepositoryのメモリ上の実装
+type ImportJobRepository struct {
+	*Store
+}
+
+func (r *ImportJobRepository) Create(ctx context.Context, job *entity.ImportJob) (*entity.ImportJob, error) {
+	defer r.lock(ctx)()
+
+	r.lastImportJobID++
+	stored := cloneImportJob(job)
+	stored.ID = r.lastImportJobID
+	stored.UserID = principal.OwnerID(ctx)
+	stored.CreatedAt = entity.Now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.importJobs = append(r.importJobs, stored)
+
+	return cloneImportJob(stored), nil
+}
+
+func (r *ImportJobRepository) FindByID(ctx context.Context, id int64) (*entity.ImportJob, error) {
+	defer r.rlock(ctx)()
+
+	// ほかのユーザーのジョブは見つからないものとする
+	job := r.findImportJob(id)
+	if job == nil {
+		return nil, domainErrors.ErrImportJobNotFound
+	}
+	if userID, ok := principal.OwnerScope(ctx); ok && job.UserID != userID {
+		return nil, domainErrors.ErrImportJobNotFound
+	}
+
+	return cloneImportJob(job), nil
+}
+
+func (r *ImportJobRepository) Start(ctx context.Context, id int64) error {
+	defer r.lock(ctx)()
+
+	job := r.findImportJob(id)
+	if job == nil || job.Status != entity.ImportJobStatusQueued {
+		return nil
+	}
+	now := entity.Now()
+	job.Status = entity.ImportJobStatusRunning
+	job.StartedAt = &now
+	job.UpdatedAt = now
+
+	return nil
+}
+
+func (r *ImportJobRepository) UpdateProgress(ctx context.Context, id int64, rowsProcessed int) error {
+	defer r.lock(ctx)()
+
+	job := r.findImportJob(id)
+	if job == nil || job.Status != entity.ImportJobStatusRunning {
+		return nil
+	}
+	job.RowsProcessed = rowsProcessed
+	job.UpdatedAt = entity.Now()
+
+	return nil
+}
+
+func (r *ImportJobRepository) Finish(ctx context.Context, job *entity.ImportJob) error {
+	defer r.lock(ctx)()
+
+	stored := r.findImportJob(job.ID)
+	if stored == nil || stored.Finished() {
+		return nil
+	}
+	now := entity.Now()
+	stored.Status = job.Status
+	stored.RowsProcessed = job.RowsProcessed
+	stored.Succeeded = job.Succeeded
+	stored.Failed = job.Failed
+	stored.Errors = append([]entity.ImportRowError{}, job.Errors...)
+	stored.Error = job.Error
+	stored.FinishedAt = &now
+	stored.UpdatedAt = now
+
+	return nil
+}
+
+func (r *ImportJobRepository) FailUnfinished(ctx context.Context, reason string) (int64, error) {
+	defer r.lock(ctx)()
+
+	var updated int64
+	now := entity.Now()
+	for _, job := range r.importJobs {
+		if job.Finished() {
+			continue
+		}
+		job.Fail(reason)
+		job.FinishedAt = &now
+		job.UpdatedAt = now
+		updated++
+	}
+
+	return updated, nil
+}
+
+func (r *ImportJobRepository) DeleteFinishedBefore(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	defer r.lock(ctx)()
+
+	var deleted int64
+	kept := r.importJobs[:0]
+	for _, job := range r.importJobs {
+		if job.FinishedAt != nil && !job.FinishedAt.After(finishedBefore) {
+			deleted++
+		} else {
+			kept = append(kept, job)
+		}
+	}
+	r.importJobs = kept
+
+	return deleted, nil
+}
+
+// IDのジョブ。ない場合はnil。呼び出し元はロックを取得しておく
+func (s *Store) findImportJob(id int64) *entity.ImportJob {
+	for _, job := range s.importJobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+func cloneImportJob(job *entity.ImportJob) *entity.ImportJob {
+	clone := *job
+	clone.Errors = append([]entity.ImportRowError{}, job.Errors...)
+	if job.StartedAt != nil {
+		startedAt := *job.StartedAt
+		clone.StartedAt = &startedAt
+	}
+	if job.FinishedAt != nil {
+		finishedAt := *job.FinishedAt
+		clone.FinishedAt = &finishedAt
+	}
+	return &clone
+}
@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+func TestImportJobRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := &ImportJobRepository{Store: NewStore()}
+
+	running, err := repo.Create(ctx, entity.NewImportJob(false, false))
+	require.NoError(t, err)
+	queued, err := repo.Create(ctx, entity.NewImportJob(false, true))
+	require.NoError(t, err)
+	done, err := repo.Create(ctx, entity.NewImportJob(true, false))
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Start(ctx, running.ID))
+	require.NoError(t, repo.UpdateProgress(ctx, running.ID, 200))
+	require.NoError(t, repo.Start(ctx, done.ID))
+	done.Status = entity.ImportJobStatusDone
+	done.Errors = []entity.ImportRowError{{Row: 2, Message: "name is required"}}
+	require.NoError(t, repo.Finish(ctx, done))
+	// 保存後に元の値を変更しても、保存したジョブは変わらない
+	done.Errors[0].Row = 99
+
+	failed, err := repo.FailUnfinished(ctx, "interrupted")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), failed)
+
+	found, err := repo.FindByID(ctx, running.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ImportJobStatusFailed, found.Status)
+	assert.Equal(t, 200, found.RowsProcessed)
+	assert.Equal(t, "interrupted", found.Error)
+	assert.NotNil(t, found.StartedAt)
+
+	found, err = repo.FindByID(ctx, queued.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ImportJobStatusFailed, found.Status)
+	assert.Nil(t, found.StartedAt)
+
+	found, err = repo.FindByID(ctx, done.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ImportJobStatusDone, found.Status)
+	assert.Equal(t, 2, found.Errors[0].Row)
+	assert.Empty(t, found.Error)
+
+	_, err = repo.FindByID(ctx, 100)
+	assert.ErrorIs(t, err, domainErrors.ErrImportJobNotFound)
+
+	// ほかのユーザーのジョブは見つからない
+	alice := principal.NewContext(ctx, principal.Principal{UserID: 2})
+	owned, err := repo.Create(alice, entity.NewImportJob(false, false))
+	require.NoError(t, err)
+	_, err = repo.FindByID(principal.NewContext(ctx, principal.Principal{UserID: 3}), owned.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrImportJobNotFound)
+	_, err = repo.FindByID(alice, owned.ID)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+// アイテムの変更履歴を新しい順に取得する
+func (r *ItemRepository) FindHistories(ctx context.Context, itemID int64, page entity.Pagination) ([]*entity.ItemHistory, error) {
+	defer r.rlock(ctx)()
+
+	var matched []*entity.ItemHistory
+	for i := len(r.histories) - 1; i >= 0 && r.historiesVisible(ctx, itemID); i-- {
+		if r.histories[i].ItemID == itemID {
+			matched = append(matched, r.histories[i])
+		}
+	}
+
+	histories := make([]*entity.ItemHistory, 0)
+	for i := page.Offset; i < len(matched) && i < page.Offset+page.Limit; i++ {
+		history := *matched[i]
+		histories = append(histories, &history)
+	}
+
+	return histories, nil
+}
+
+func (r *ItemRepository) CountHistories(ctx context.Context, itemID int64) (int, error) {
+	defer r.rlock(ctx)()
+
+	if !r.historiesVisible(ctx, itemID) {
+		return 0, nil
+	}
+
+	count := 0
+	for _, history := range r.histories {
+		if history.ItemID == itemID {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// ctxの呼び出し元がアイテムの履歴を参照できるかどうか。
+// database.historyOwnerConditionと同じく、ユーザーには物理削除したアイテムとほかのユーザーのアイテムの履歴を返さない
+func (r *ItemRepository) historiesVisible(ctx context.Context, itemID int64) bool {
+	if _, ok := principal.OwnerScope(ctx); !ok {
+		return true
+	}
+	item, ok := r.items[itemID]
+	return ok && owned(ctx, item)
+}
+
+// 変更前後のスナップショットを履歴として記録する
+func (r *ItemRepository) CreateHistory(ctx context.Context, itemID int64, action string, before, after *entity.Item) error {
+	defer r.lock(ctx)()
+
+	beforeJSON, err := snapshotJSON(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := snapshotJSON(after)
+	if err != nil {
+		return err
+	}
+
+	r.lastHistoryID++
+	r.histories = append(r.histories, &entity.ItemHistory{
+		ID:        r.lastHistoryID,
+		ItemID:    itemID,
+		Action:    action,
+		Before:    beforeJSON,
+		After:     afterJSON,
+		CreatedAt: entity.Now(),
+	})
+
+	return nil
+}
+
+// スナップショットをJSONに変換する。nilの場合はnullとして保存する
+func snapshotJSON(item *entity.Item) (json.RawMessage, error) {
+	if item == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to marshal item snapshot: %w", domainErrors.ErrDatabaseError, err)
+	}
+
+	return json.RawMessage(b), nil
+}
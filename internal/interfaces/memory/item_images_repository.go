@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// アイテムの画像を表示順に取得する
+func (r *ItemRepository) FindImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	defer r.rlock(ctx)()
+
+	images := make([]*entity.ItemImage, 0)
+	for _, image := range r.images {
+		if image.ItemID == itemID {
+			clone := *image
+			images = append(images, &clone)
+		}
+	}
+	sortImages(images)
+
+	return images, nil
+}
+
+// 複数のアイテムの画像をアイテムのIDごとに表示順で返す。画像のないアイテムは結果に含まれない
+func (r *ItemRepository) FindImagesByItemIDs(ctx context.Context, itemIDs []int64) (map[int64][]*entity.ItemImage, error) {
+	defer r.rlock(ctx)()
+
+	wanted := make(map[int64]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		wanted[id] = true
+	}
+
+	images := make(map[int64][]*entity.ItemImage)
+	for _, image := range r.images {
+		if wanted[image.ItemID] {
+			clone := *image
+			images[image.ItemID] = append(images[image.ItemID], &clone)
+		}
+	}
+	for _, list := range images {
+		sortImages(list)
+	}
+
+	return images, nil
+}
+
+// 画像を末尾に追加する。ストアのロック中に件数を確認するため、同時に追加されても上限を超えない
+func (r *ItemRepository) AddImage(ctx context.Context, itemID int64, url string, maxImages int) (*entity.ItemImage, error) {
+	defer r.lock(ctx)()
+
+	if item, ok := r.items[itemID]; !ok || item.DeletedAt != nil {
+		return nil, domainErrors.ErrItemNotFound
+	}
+
+	count, maxOrder := 0, 0
+	for _, image := range r.images {
+		if image.ItemID != itemID {
+			continue
+		}
+		count++
+		if image.DisplayOrder > maxOrder {
+			maxOrder = image.DisplayOrder
+		}
+	}
+	if count >= maxImages {
+		return nil, fmt.Errorf("%w: an item can have at most %d images", domainErrors.ErrImageLimitExceeded, maxImages)
+	}
+
+	r.lastImageID++
+	image := &entity.ItemImage{
+		ID:           r.lastImageID,
+		ItemID:       itemID,
+		URL:          url,
+		DisplayOrder: maxOrder + 1,
+		CreatedAt:    entity.Now(),
+	}
+	r.images = append(r.images, image)
+
+	clone := *image
+	return &clone, nil
+}
+
+func (r *ItemRepository) DeleteImage(ctx context.Context, itemID, imageID int64) error {
+	defer r.lock(ctx)()
+
+	for i, image := range r.images {
+		if image.ID == imageID && image.ItemID == itemID {
+			r.images = append(r.images[:i], r.images[i+1:]...)
+			return nil
+		}
+	}
+
+	return domainErrors.ErrImageNotFound
+}
+
+// imageIDsの順に表示順を振り直す。アイテムの画像と過不足なく一致しない場合はErrInvalidInputを返す
+func (r *ItemRepository) ReorderImages(ctx context.Context, itemID int64, imageIDs []int64) error {
+	defer r.lock(ctx)()
+
+	current := make(map[int64]*entity.ItemImage)
+	for _, image := range r.images {
+		if image.ItemID == itemID {
+			current[image.ID] = image
+		}
+	}
+
+	if len(imageIDs) != len(current) {
+		return fmt.Errorf("%w: image_ids must list all %d images of the item", domainErrors.ErrInvalidInput, len(current))
+	}
+	ordered := make([]*entity.ItemImage, 0, len(imageIDs))
+	for _, id := range imageIDs {
+		image, ok := current[id]
+		if !ok {
+			return fmt.Errorf("%w: image %d does not belong to the item or is listed twice", domainErrors.ErrInvalidInput, id)
+		}
+		delete(current, id)
+		ordered = append(ordered, image)
+	}
+
+	for i, image := range ordered {
+		image.DisplayOrder = i + 1
+	}
+
+	return nil
+}
+
+// 表示順、同じ場合はIDの順に並べる
+func sortImages(images []*entity.ItemImage) {
+	sort.SliceStable(images, func(i, j int) bool {
+		if images[i].DisplayOrder != images[j].DisplayOrder {
+			return images[i].DisplayOrder < images[j].DisplayOrder
+		}
+		return images[i].ID < images[j].ID
+	})
+}
@@ -0,0 +1,328 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) ([]*entity.CategoryCurrencyTotal, error) {
+	defer r.rlock(ctx)()
+
+	type key struct{ currency, condition, status string }
+
+	// カテゴリーの登録順に集計し、アイテムが0件のカテゴリーも0件の行として返す
+	items := r.sortedItems()
+	totals := make([]*entity.CategoryCurrencyTotal, 0)
+	for _, category := range r.categories {
+		groups := make(map[key]*entity.CategoryCurrencyTotal)
+		var keys []key
+		for _, item := range items {
+			if item.DeletedAt != nil || !owned(ctx, item) || item.Category != category.Name {
+				continue
+			}
+			k := key{currency: item.Currency, status: item.Status}
+			if item.Condition != nil {
+				k.condition = *item.Condition
+			}
+			total, ok := groups[k]
+			if !ok {
+				total = &entity.CategoryCurrencyTotal{Category: category.Name, Currency: k.currency, Condition: k.condition, Status: k.status}
+				groups[k] = total
+				keys = append(keys, k)
+			}
+			total.Count++
+			total.TotalPrice += item.PurchasePrice
+		}
+
+		if len(keys) == 0 {
+			totals = append(totals, &entity.CategoryCurrencyTotal{Category: category.Name})
+			continue
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].currency != keys[j].currency {
+				return keys[i].currency < keys[j].currency
+			}
+			if keys[i].condition != keys[j].condition {
+				return keys[i].condition < keys[j].condition
+			}
+			return keys[i].status < keys[j].status
+		})
+		for _, k := range keys {
+			totals = append(totals, groups[k])
+		}
+	}
+
+	return totals, nil
+}
+
+// 売却価格を記録した売却済みのアイテムを通貨ごとに集計する。yearが0でない場合はその年に売却したアイテムのみ集計する
+func (r *ItemRepository) GetProfitByCurrency(ctx context.Context, year int) ([]*entity.ProfitCurrencyTotal, error) {
+	defer r.rlock(ctx)()
+
+	groups := make(map[string]*entity.ProfitCurrencyTotal)
+	for _, item := range r.items {
+		if item.DeletedAt != nil || !owned(ctx, item) || item.Status != entity.ItemStatusSold || item.SellingPrice == nil {
+			continue
+		}
+		if year != 0 && (item.SoldDate == nil || item.SoldDate.Time().Year() != year) {
+			continue
+		}
+		total, ok := groups[item.Currency]
+		if !ok {
+			total = &entity.ProfitCurrencyTotal{Currency: item.Currency}
+			groups[item.Currency] = total
+		}
+		total.Count++
+		total.SellingPrice += *item.SellingPrice
+		total.PurchasePrice += item.PurchasePrice
+	}
+
+	totals := make([]*entity.ProfitCurrencyTotal, 0, len(groups))
+	for _, total := range groups {
+		totals = append(totals, total)
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].Currency < totals[j].Currency })
+
+	return totals, nil
+}
+
+// 論理削除されていないアイテムを購入店舗ごと・通貨ごとに集計する。売却済みのアイテムも含め、店舗が未設定の行を先頭にする
+func (r *ItemRepository) GetSpendByLocation(ctx context.Context) ([]*entity.LocationCurrencyTotal, error) {
+	defer r.rlock(ctx)()
+
+	type key struct {
+		hasLocation bool
+		location    string
+		currency    string
+	}
+
+	groups := make(map[key]*entity.LocationCurrencyTotal)
+	var keys []key
+	for _, item := range r.items {
+		if item.DeletedAt != nil || !owned(ctx, item) {
+			continue
+		}
+		k := key{currency: item.Currency}
+		if item.PurchaseLocation != nil {
+			k.hasLocation = true
+			k.location = *item.PurchaseLocation
+		}
+		total, ok := groups[k]
+		if !ok {
+			total = &entity.LocationCurrencyTotal{PurchaseLocation: cloneString(item.PurchaseLocation), Currency: item.Currency}
+			groups[k] = total
+			keys = append(keys, k)
+		}
+		total.Count++
+		total.TotalPrice += item.PurchasePrice
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].hasLocation != keys[j].hasLocation {
+			return !keys[i].hasLocation
+		}
+		if keys[i].location != keys[j].location {
+			return keys[i].location < keys[j].location
+		}
+		return keys[i].currency < keys[j].currency
+	})
+
+	totals := make([]*entity.LocationCurrencyTotal, 0, len(keys))
+	for _, k := range keys {
+		totals = append(totals, groups[k])
+	}
+
+	return totals, nil
+}
+
+// ブランドと通貨の組。ブランドは大文字小文字のみが異なるものをまとめるため小文字にする
+type brandCurrencyKey struct {
+	brand    string
+	currency string
+}
+
+func (k brandCurrencyKey) less(other brandCurrencyKey) bool {
+	if k.brand != other.brand {
+		return k.brand < other.brand
+	}
+	return k.currency < other.currency
+}
+
+// 所有中・出品中のアイテムをブランドごと・通貨ごとに集計する
+func (r *ItemRepository) GetSummaryByBrand(ctx context.Context) ([]*entity.BrandCurrencyTotal, error) {
+	defer r.rlock(ctx)()
+
+	groups := make(map[brandCurrencyKey]*entity.BrandCurrencyTotal)
+	var keys []brandCurrencyKey
+	for _, item := range r.items {
+		if item.DeletedAt != nil || !owned(ctx, item) || item.Status == entity.ItemStatusSold {
+			continue
+		}
+		k := brandCurrencyKey{brand: strings.ToLower(item.Brand), currency: item.Currency}
+		total, ok := groups[k]
+		if !ok {
+			total = &entity.BrandCurrencyTotal{BrandKey: k.brand, Brand: item.Brand, Currency: item.Currency}
+			groups[k] = total
+			keys = append(keys, k)
+		}
+		// 表示するブランド名はグループ内でバイナリ順が最小のものにして、結果を一定にする
+		if item.Brand < total.Brand {
+			total.Brand = item.Brand
+		}
+		total.Count++
+		total.TotalPrice += item.PurchasePrice
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].less(keys[j]) })
+
+	totals := make([]*entity.BrandCurrencyTotal, 0, len(keys))
+	for _, k := range keys {
+		totals = append(totals, groups[k])
+	}
+
+	return totals, nil
+}
+
+// 所有中・出品中のアイテムのうち、ブランドごと・通貨ごとに購入価格が最も高いアイテムを取得する
+func (r *ItemRepository) FindMostExpensiveByBrand(ctx context.Context) ([]*entity.ItemPrice, error) {
+	defer r.rlock(ctx)()
+
+	groups := make(map[brandCurrencyKey]*entity.ItemPrice)
+	var keys []brandCurrencyKey
+	for _, item := range r.sortedItems() {
+		if item.DeletedAt != nil || !owned(ctx, item) || item.Status == entity.ItemStatusSold {
+			continue
+		}
+		k := brandCurrencyKey{brand: strings.ToLower(item.Brand), currency: item.Currency}
+		current, ok := groups[k]
+		if !ok {
+			keys = append(keys, k)
+		}
+		// ID順に見ているため、同額の場合は先に見たIDの小さいアイテムが残る
+		if !ok || item.PurchasePrice > current.PurchasePrice {
+			groups[k] = &entity.ItemPrice{BrandKey: k.brand, ID: item.ID, Name: item.Name, PurchasePrice: item.PurchasePrice, Currency: item.Currency}
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].less(keys[j]) })
+
+	items := make([]*entity.ItemPrice, 0, len(keys))
+	for _, k := range keys {
+		items = append(items, groups[k])
+	}
+
+	return items, nil
+}
+
+// 期間の形式ごとのtime.Formatのレイアウト
+var spendPeriodLayouts = map[string]string{
+	entity.SpendGranularityMonth: "2006-01",
+	entity.SpendGranularityYear:  "2006",
+}
+
+// 論理削除されていないアイテムを購入日の期間ごと・通貨ごとに集計する。売却済みのアイテムも含める
+func (r *ItemRepository) GetSpendByPeriod(ctx context.Context, spendRange entity.SpendRange) ([]*entity.PeriodCurrencyTotal, error) {
+	defer r.rlock(ctx)()
+
+	layout, ok := spendPeriodLayouts[spendRange.Granularity]
+	if !ok {
+		layout = spendPeriodLayouts[entity.SpendGranularityMonth]
+	}
+	from := entity.NewPurchaseDate(spendRange.From)
+	end := entity.NewPurchaseDate(spendRange.End())
+
+	type key struct{ period, currency string }
+
+	groups := make(map[key]*entity.PeriodCurrencyTotal)
+	var keys []key
+	for _, item := range r.items {
+		if item.DeletedAt != nil || !owned(ctx, item) || item.PurchaseDate.Before(from) || !item.PurchaseDate.Before(end) {
+			continue
+		}
+		k := key{period: item.PurchaseDate.Time().Format(layout), currency: item.Currency}
+		total, ok := groups[k]
+		if !ok {
+			total = &entity.PeriodCurrencyTotal{Period: k.period, Currency: k.currency}
+			groups[k] = total
+			keys = append(keys, k)
+		}
+		total.Count++
+		total.TotalPrice += item.PurchasePrice
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].period != keys[j].period {
+			return keys[i].period < keys[j].period
+		}
+		return keys[i].currency < keys[j].currency
+	})
+
+	totals := make([]*entity.PeriodCurrencyTotal, 0, len(keys))
+	for _, k := range keys {
+		totals = append(totals, groups[k])
+	}
+
+	return totals, nil
+}
+
+// 絞り込み条件に一致するアイテムをカテゴリーごと・通貨ごとに集計する
+func (r *ItemRepository) GetStatsByCategory(ctx context.Context, filter entity.ItemFilter) ([]*entity.CategoryCurrencyStats, error) {
+	defer r.rlock(ctx)()
+
+	type key struct{ category, currency string }
+
+	groups := make(map[key]*entity.CategoryCurrencyStats)
+	var keys []key
+	for _, item := range r.filterItems(ctx, filter) {
+		k := key{category: item.Category, currency: item.Currency}
+		s, ok := groups[k]
+		if !ok {
+			s = &entity.CategoryCurrencyStats{Category: k.category, Currency: k.currency}
+			groups[k] = s
+			keys = append(keys, k)
+		}
+		s.Count++
+		s.TotalPrice += item.PurchasePrice
+		if item.PurchaseDate.After(s.LatestPurchaseDate) {
+			s.LatestPurchaseDate = item.PurchaseDate
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].category != keys[j].category {
+			return keys[i].category < keys[j].category
+		}
+		return keys[i].currency < keys[j].currency
+	})
+
+	stats := make([]*entity.CategoryCurrencyStats, 0, len(keys))
+	for _, k := range keys {
+		stats = append(stats, groups[k])
+	}
+
+	return stats, nil
+}
+
+// 絞り込み条件に一致するアイテムのうち、通貨ごとに購入価格が最も高いアイテムを取得する。同額の場合はIDの小さいアイテム
+func (r *ItemRepository) FindMostExpensiveByCurrency(ctx context.Context, filter entity.ItemFilter) ([]*entity.ItemPrice, error) {
+	defer r.rlock(ctx)()
+
+	groups := make(map[string]*entity.ItemPrice)
+	for _, item := range r.filterItems(ctx, filter) {
+		if current, ok := groups[item.Currency]; ok && item.PurchasePrice <= current.PurchasePrice {
+			continue
+		}
+		groups[item.Currency] = &entity.ItemPrice{ID: item.ID, Name: item.Name, PurchasePrice: item.PurchasePrice, Currency: item.Currency}
+	}
+
+	items := make([]*entity.ItemPrice, 0, len(groups))
+	for _, item := range groups {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Currency < items[j].Currency })
+
+	return items, nil
+}
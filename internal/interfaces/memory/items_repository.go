@@ -0,0 +1,502 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+// usecase.ItemRepositoryのメモリ上の実装。取得・集計の結果はdatabase.ItemRepositoryと同じになるようにする
+type ItemRepository struct {
+	*Store
+}
+
+func (r *ItemRepository) FindAll(ctx context.Context, filter entity.ItemFilter, sort entity.ItemSort, page entity.Pagination) ([]*entity.Item, error) {
+	defer r.rlock(ctx)()
+
+	matched := r.filterItems(ctx, filter)
+	sortItems(matched, sort)
+	if page.After != nil {
+		matched = itemsAfter(matched, sort, *page.After)
+	}
+
+	// 範囲外のoffsetでもエラーにせず空のスライスを返す
+	items := make([]*entity.Item, 0)
+	for i := page.Offset; i < len(matched) && i < page.Offset+page.Limit; i++ {
+		items = append(items, r.snapshot(matched[i]))
+	}
+
+	return items, nil
+}
+
+// 一致するアイテムはすでにメモリ上にあるため、複製してからロックを解放し、fnの間は書き込みを妨げない
+func (r *ItemRepository) EachItem(ctx context.Context, filter entity.ItemFilter, sort entity.ItemSort, fn func(*entity.Item) error) error {
+	items := r.matchingSnapshots(ctx, filter, sort)
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ItemRepository) matchingSnapshots(ctx context.Context, filter entity.ItemFilter, sort entity.ItemSort) []*entity.Item {
+	defer r.rlock(ctx)()
+
+	matched := r.filterItems(ctx, filter)
+	sortItems(matched, sort)
+	items := make([]*entity.Item, len(matched))
+	for i, item := range matched {
+		items[i] = r.snapshot(item)
+	}
+	return items
+}
+
+func (r *ItemRepository) Count(ctx context.Context, filter entity.ItemFilter) (int, error) {
+	defer r.rlock(ctx)()
+
+	return len(r.filterItems(ctx, filter)), nil
+}
+
+// database.ItemRepositoryと同じく、論理削除したアイテムも対象とする
+func (r *ItemRepository) MaxUpdatedAt(ctx context.Context, filter entity.ItemFilter) (*time.Time, error) {
+	defer r.rlock(ctx)()
+
+	filter.IncludeDeleted = true
+	var latest *time.Time
+	for _, item := range r.filterItems(ctx, filter) {
+		if latest == nil || item.UpdatedAt.After(*latest) {
+			updatedAt := item.UpdatedAt
+			latest = &updatedAt
+		}
+	}
+
+	return latest, nil
+}
+
+func (r *ItemRepository) FindUpdatedSince(ctx context.Context, since time.Time, limit int) ([]*entity.Item, error) {
+	defer r.rlock(ctx)()
+
+	var matched []*entity.Item
+	for _, item := range r.items {
+		if item.DeletedAt == nil && owned(ctx, item) && !item.UpdatedAt.Before(since) {
+			matched = append(matched, item)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		c := matched[i].UpdatedAt.Compare(matched[j].UpdatedAt)
+		if c == 0 {
+			c = compareInt64(matched[i].ID, matched[j].ID)
+		}
+		return c > 0
+	})
+
+	items := make([]*entity.Item, 0, min(len(matched), limit))
+	for _, item := range matched[:min(len(matched), limit)] {
+		items = append(items, r.snapshot(item))
+	}
+	return items, nil
+}
+
+func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	defer r.rlock(ctx)()
+
+	item, ok := r.items[id]
+	if !ok || item.DeletedAt != nil || !owned(ctx, item) {
+		return nil, domainErrors.ErrItemNotFound
+	}
+
+	return r.snapshot(item), nil
+}
+
+// トランザクション内ではWithinTxがStore全体をロックしているため、FindByIDと同じ
+func (r *ItemRepository) FindByIDForUpdate(ctx context.Context, id int64) (*entity.Item, error) {
+	return r.FindByID(ctx, id)
+}
+
+// シリアル番号が一致するアイテムを取得する。MySQLの照合順序に合わせて大文字小文字を区別しない
+func (r *ItemRepository) FindBySerialNumber(ctx context.Context, serialNumber string) (*entity.Item, error) {
+	defer r.rlock(ctx)()
+
+	for _, item := range r.sortedItems() {
+		if item.DeletedAt == nil && owned(ctx, item) && item.SerialNumber != nil && strings.EqualFold(*item.SerialNumber, serialNumber) {
+			return r.snapshot(item), nil
+		}
+	}
+
+	return nil, domainErrors.ErrItemNotFound
+}
+
+func (r *ItemRepository) FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	defer r.rlock(ctx)()
+
+	items := make([]*entity.Item, 0, len(ids))
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		item, ok := r.items[id]
+		if !ok || item.DeletedAt != nil || !owned(ctx, item) || seen[id] {
+			continue
+		}
+		seen[id] = true
+		items = append(items, r.snapshot(item))
+	}
+
+	return items, nil
+}
+
+func (r *ItemRepository) FindByPurchaseDate(ctx context.Context, purchaseDate entity.PurchaseDate) ([]*entity.Item, error) {
+	defer r.rlock(ctx)()
+
+	items := make([]*entity.Item, 0)
+	for _, item := range r.sortedItems() {
+		if item.DeletedAt == nil && owned(ctx, item) && item.PurchaseDate.Equal(purchaseDate) {
+			items = append(items, r.snapshot(item))
+		}
+	}
+
+	return items, nil
+}
+
+func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	defer r.lock(ctx)()
+
+	if err := r.ensureSerialNumberAvailable(principal.OwnerID(ctx), item.SerialNumber, 0); err != nil {
+		return nil, err
+	}
+
+	return r.snapshot(r.insertItem(ctx, item)), nil
+}
+
+// すべてのアイテムのシリアル番号を確認してから作成し、途中で失敗した場合は1件も作成しない
+func (r *ItemRepository) CreateMany(ctx context.Context, items []*entity.Item) ([]int64, error) {
+	defer r.lock(ctx)()
+
+	serialNumbers := make(map[string]bool, len(items))
+	for _, item := range items {
+		if err := r.ensureSerialNumberAvailable(principal.OwnerID(ctx), item.SerialNumber, 0); err != nil {
+			return nil, err
+		}
+		if item.SerialNumber != nil {
+			key := strings.ToLower(*item.SerialNumber)
+			if serialNumbers[key] {
+				return nil, fmt.Errorf("%w: serial number %s is duplicated", domainErrors.ErrDuplicateSerialNumber, *item.SerialNumber)
+			}
+			serialNumbers[key] = true
+		}
+	}
+
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = r.insertItem(ctx, item).ID
+	}
+
+	return ids, nil
+}
+
+func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	defer r.lock(ctx)()
+
+	stored, ok := r.items[item.ID]
+	if !ok || stored.DeletedAt != nil || !owned(ctx, stored) {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	// 読み込んだ後に別のリクエストで更新されていれば上書きしない
+	if stored.Version != item.Version {
+		return nil, domainErrors.NewVersionConflictError(stored.Version)
+	}
+	if err := r.ensureSerialNumberAvailable(stored.UserID, item.SerialNumber, item.ID); err != nil {
+		return nil, err
+	}
+
+	updated := cloneItem(item)
+	updated.UserID = stored.UserID
+	updated.CategorySlug = ""
+	updated.Tags = sortedTags(item.Tags)
+	updated.Version = stored.Version + 1
+	updated.CreatedAt = stored.CreatedAt
+	updated.UpdatedAt = entity.Now()
+	updated.DeletedAt = nil
+	r.items[item.ID] = updated
+
+	return r.snapshot(updated), nil
+}
+
+// 論理削除。DeletedAtを設定し、以降の取得・集計の対象から外す
+func (r *ItemRepository) Delete(ctx context.Context, id int64) (*entity.ItemChange, error) {
+	defer r.lock(ctx)()
+
+	stored, ok := r.items[id]
+	if !ok || stored.DeletedAt != nil || !owned(ctx, stored) {
+		return nil, domainErrors.ErrItemNotFound
+	}
+
+	before := r.snapshot(stored)
+	now := entity.Now()
+	stored.DeletedAt = &now
+	stored.UpdatedAt = now
+
+	return &entity.ItemChange{Before: before, After: r.snapshot(stored)}, nil
+}
+
+func (r *ItemRepository) Restore(ctx context.Context, id int64) (*entity.ItemChange, error) {
+	defer r.lock(ctx)()
+
+	stored, ok := r.items[id]
+	if !ok || stored.DeletedAt == nil || !owned(ctx, stored) {
+		return nil, domainErrors.ErrItemNotFound
+	}
+
+	before := r.snapshot(stored)
+	stored.DeletedAt = nil
+	stored.UpdatedAt = entity.Now()
+
+	return &entity.ItemChange{Before: before, After: r.snapshot(stored)}, nil
+}
+
+// 物理削除。論理削除済みのアイテムも対象とし、アイテムの画像も削除する。履歴は残す
+func (r *ItemRepository) HardDelete(ctx context.Context, id int64) (*entity.ItemChange, error) {
+	defer r.lock(ctx)()
+
+	stored, ok := r.items[id]
+	if !ok || !owned(ctx, stored) {
+		return nil, domainErrors.ErrItemNotFound
+	}
+
+	before := r.snapshot(stored)
+	delete(r.items, id)
+
+	images := r.images[:0]
+	for _, image := range r.images {
+		if image.ItemID != id {
+			images = append(images, image)
+		}
+	}
+	r.images = images
+
+	return &entity.ItemChange{Before: before}, nil
+}
+
+// アイテムを採番し、ctxの呼び出し元のユーザーのアイテムとして保存する。
+// 列のデフォルト値と同じく、バージョンは1、通貨と所有状況は未設定であればJPYとownedにする。呼び出し元はロックを取得しておく
+func (r *ItemRepository) insertItem(ctx context.Context, item *entity.Item) *entity.Item {
+	r.lastItemID++
+	now := entity.Now()
+
+	stored := cloneItem(item)
+	stored.ID = r.lastItemID
+	stored.UserID = principal.OwnerID(ctx)
+	stored.CategorySlug = ""
+	stored.Tags = sortedTags(item.Tags)
+	if stored.Currency == "" {
+		stored.Currency = entity.DefaultCurrency
+	}
+	if stored.Status == "" {
+		stored.Status = entity.ItemStatusOwned
+	}
+	stored.SellingPrice = nil
+	stored.SoldDate = nil
+	stored.Version = 1
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	stored.DeletedAt = nil
+
+	r.items[stored.ID] = stored
+	return stored
+}
+
+// userIDのほかのアイテム（論理削除済みを含む）が同じシリアル番号を使っている場合はErrDuplicateSerialNumberを返す。
+// excludeIDのアイテムは除く。シリアル番号はユーザーごとに一意にする
+func (r *ItemRepository) ensureSerialNumberAvailable(userID int64, serialNumber *string, excludeID int64) error {
+	if serialNumber == nil {
+		return nil
+	}
+	for _, item := range r.items {
+		if item.ID != excludeID && item.UserID == userID && item.SerialNumber != nil && strings.EqualFold(*item.SerialNumber, *serialNumber) {
+			return fmt.Errorf("%w: serial number %s is already registered", domainErrors.ErrDuplicateSerialNumber, *serialNumber)
+		}
+	}
+	return nil
+}
+
+// 保存済みのアイテムをID順に返す
+func (r *ItemRepository) sortedItems() []*entity.Item {
+	items := make([]*entity.Item, 0, len(r.items))
+	for _, item := range r.items {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return items
+}
+
+// 絞り込み条件に一致するctxの所有者のアイテムをID順に返す
+func (r *ItemRepository) filterItems(ctx context.Context, filter entity.ItemFilter) []*entity.Item {
+	var items []*entity.Item
+	for _, item := range r.sortedItems() {
+		if owned(ctx, item) && matchesFilter(item, filter) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ctxの呼び出し元がユーザーの場合に、そのユーザーのアイテムかどうか。
+// database.ownerConditionと同じく、ほかのユーザーのアイテムは存在しないものとして扱う
+func owned(ctx context.Context, item *entity.Item) bool {
+	userID, ok := principal.OwnerScope(ctx)
+	return !ok || item.UserID == userID
+}
+
+// database.buildItemFilterと同じ条件でアイテムを判定する
+func matchesFilter(item *entity.Item, filter entity.ItemFilter) bool {
+	if !filter.IncludeDeleted && item.DeletedAt != nil {
+		return false
+	}
+	if filter.OwnerID != nil && item.UserID != *filter.OwnerID {
+		return false
+	}
+	if filter.Category != "" && item.Category != filter.Category {
+		return false
+	}
+	if filter.Condition != "" && (item.Condition == nil || *item.Condition != filter.Condition) {
+		return false
+	}
+	if filter.Status != "" && item.Status != filter.Status {
+		return false
+	}
+	for _, tag := range filter.Tags {
+		if !containsString(item.Tags, tag) {
+			return false
+		}
+	}
+	if filter.PurchaseLocation != "" && (item.PurchaseLocation == nil || *item.PurchaseLocation != filter.PurchaseLocation) {
+		return false
+	}
+	if filter.Brand != "" && !strings.Contains(strings.ToLower(item.Brand), strings.ToLower(filter.Brand)) {
+		return false
+	}
+	if filter.Keyword != "" {
+		keyword := strings.ToLower(filter.Keyword)
+		if !strings.Contains(strings.ToLower(item.Name), keyword) && !strings.Contains(strings.ToLower(item.Brand), keyword) {
+			return false
+		}
+	}
+	if filter.MinPrice != nil && item.PurchasePrice < *filter.MinPrice {
+		return false
+	}
+	if filter.MaxPrice != nil && item.PurchasePrice > *filter.MaxPrice {
+		return false
+	}
+	if filter.PurchasedFrom != nil && item.PurchaseDate.Before(entity.NewPurchaseDate(*filter.PurchasedFrom)) {
+		return false
+	}
+	if filter.PurchasedTo != nil && item.PurchaseDate.After(entity.NewPurchaseDate(*filter.PurchasedTo)) {
+		return false
+	}
+	return true
+}
+
+// 並び替え指定の順に並べる。同値の場合はIDで順序を確定させる
+func sortItems(items []*entity.Item, itemSort entity.ItemSort) {
+	itemSort = itemSort.WithDefaults()
+	desc := itemSort.Order == entity.SortOrderDesc
+
+	sort.SliceStable(items, func(i, j int) bool {
+		c := compareItems(items[i], items[j], itemSort.Field)
+		if c == 0 {
+			c = compareInt64(items[i].ID, items[j].ID)
+		}
+		if desc {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// 並び替え済みのitemsのうち、afterより後のアイテム
+func itemsAfter(items []*entity.Item, itemSort entity.ItemSort, after entity.ItemCursor) []*entity.Item {
+	itemSort = itemSort.WithDefaults()
+	pivot := cursorItem(after)
+
+	for i, item := range items {
+		c := compareItems(item, pivot, itemSort.Field)
+		if c == 0 {
+			c = compareInt64(item.ID, pivot.ID)
+		}
+		if itemSort.Order == entity.SortOrderDesc {
+			c = -c
+		}
+		if c > 0 {
+			return items[i:]
+		}
+	}
+	return nil
+}
+
+// カーソルの位置にあるアイテムとして、IDと並び替えの項目のみを設定したアイテム
+func cursorItem(cursor entity.ItemCursor) *entity.Item {
+	item := &entity.Item{ID: cursor.ID}
+	switch value := cursor.Value.(type) {
+	case int64:
+		item.PurchasePrice = value
+	case entity.PurchaseDate:
+		item.PurchaseDate = value
+	case string:
+		item.Name = value
+	case time.Time:
+		item.CreatedAt = value
+	}
+	return item
+}
+
+func compareItems(a, b *entity.Item, field string) int {
+	switch field {
+	case entity.SortByPurchasePrice:
+		return compareInt64(a.PurchasePrice, b.PurchasePrice)
+	case entity.SortByPurchaseDate:
+		return a.PurchaseDate.Time().Compare(b.PurchaseDate.Time())
+	case entity.SortByName:
+		// MySQLの照合順序に合わせて大文字小文字を区別しない
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	default:
+		return a.CreatedAt.Compare(b.CreatedAt)
+	}
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// 名前順に並べたタグの複製。タグがない場合はnil
+func sortedTags(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	sorted := append([]string(nil), tags...)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+	"Aicon-assignment/internal/usecase/repositorytest"
+)
+
+var (
+	_ usecase.ItemRepository     = (*ItemRepository)(nil)
+	_ usecase.CategoryRepository = (*CategoryRepository)(nil)
+	_ usecase.TagRepository      = (*TagRepository)(nil)
+	_ usecase.BrandRepository    = (*BrandRepository)(nil)
+)
+
+func TestItemRepository_Conformance(t *testing.T) {
+	repositorytest.RunItemRepositoryTests(t, func(t *testing.T) usecase.ItemRepository {
+		return &ItemRepository{Store: NewStore(DefaultCategories()...)}
+	})
+}
+
+func TestItemRepository_ReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := &ItemRepository{Store: NewStore(DefaultCategories()...)}
+
+	created, err := repo.Create(ctx, &entity.Item{Name: "デイトナ", Category: "時計", Brand: "ROLEX", Tags: []string{"限定"}})
+	require.NoError(t, err)
+
+	// 返されたアイテムを書き換えても保存済みの値は変わらない
+	created.Name = "変更"
+	created.Tags[0] = "変更"
+
+	found, err := repo.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "デイトナ", found.Name)
+	assert.Equal(t, []string{"限定"}, found.Tags)
+}
+
+func TestItemRepository_ConcurrentCreate(t *testing.T) {
+	ctx := context.Background()
+	repo := &ItemRepository{Store: NewStore(DefaultCategories()...)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.Create(ctx, &entity.Item{Name: "デイトナ", Category: "時計", Brand: "ROLEX"})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	count, err := repo.Count(ctx, entity.ItemFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 50, count)
+
+	items, err := repo.FindAll(ctx, entity.ItemFilter{}, entity.ItemSort{Field: entity.SortByCreatedAt, Order: entity.SortOrderAsc}, entity.Pagination{Limit: 100})
+	require.NoError(t, err)
+	seen := make(map[int64]bool)
+	for _, item := range items {
+		seen[item.ID] = true
+	}
+	assert.Len(t, seen, 50)
+}
+
+func TestNewSeededStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewSeededStore()
+
+	categories, err := (&CategoryRepository{Store: s}).FindAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, categories, 5)
+
+	items, err := (&ItemRepository{Store: s}).FindAll(ctx, entity.ItemFilter{}, entity.ItemSort{}, entity.Pagination{Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, items, 5)
+}
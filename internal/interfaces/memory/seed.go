@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// MySQLのマイグレーションと同じ初期カテゴリー
+func DefaultCategories() []*entity.Category {
+	return []*entity.Category{
+		{Slug: "watch", Name: "時計", NameEn: "Watch"},
+		{Slug: "bag", Name: "バッグ", NameEn: "Bag"},
+		{Slug: "jewelry", Name: "ジュエリー", NameEn: "Jewelry"},
+		{Slug: "shoes", Name: "靴", NameEn: "Shoes"},
+		{Slug: "other", Name: "その他", NameEn: "Other"},
+	}
+}
+
+// MySQLのマイグレーションと同じ初期カテゴリーとサンプルのアイテムを登録したストアを作成する
+func NewSeededStore() *Store {
+	s := NewStore(DefaultCategories()...)
+
+	items := &ItemRepository{Store: s}
+	for _, item := range []*entity.Item{
+		{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: entity.MustParsePurchaseDate("2023-01-15")},
+		{Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2000000, PurchaseDate: entity.MustParsePurchaseDate("2023-02-20")},
+		{Name: "ティファニー ネックレス", Category: "ジュエリー", Brand: "Tiffany & Co.", PurchasePrice: 300000, PurchaseDate: entity.MustParsePurchaseDate("2023-03-10")},
+		{Name: "ルブタン パンプス", Category: "靴", Brand: "Christian Louboutin", PurchasePrice: 150000, PurchaseDate: entity.MustParsePurchaseDate("2023-04-05")},
+		{Name: "アップルウォッチ", Category: "その他", Brand: "Apple", PurchasePrice: 50000, PurchaseDate: entity.MustParsePurchaseDate("2023-05-12")},
+	} {
+		items.insertItem(context.Background(), item)
+	}
+
+	return s
+}
@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"sync"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// リポジトリが共有するメモリ上のデータ。MySQLを使わずに開発やテストでアプリケーションを動かすために使い、
+// 各リポジトリはRWMutexで読み書きを排他制御する。プロセスを終了するとデータは失われる
+type Store struct {
+	mu sync.RWMutex
+
+	items           map[int64]*entity.Item
+	histories       []*entity.ItemHistory
+	images          []*entity.ItemImage
+	idempotencyKeys map[idempotencyKeyID]*entity.IdempotencyKey
+	categories      []*entity.Category
+	brands          []*entity.Brand
+	webhooks        []*entity.Webhook
+	deliveries      []*entity.WebhookDelivery
+	importJobs      []*entity.ImportJob
+	apiKeys         []*entity.APIKey
+	users           []*entity.User
+	refreshTokens   []*entity.RefreshToken
+	auditLogs       []*entity.AuditLog
+
+	// テーブルのAUTO_INCREMENTと同じく、削除されたIDは再利用しない
+	lastItemID         int64
+	lastHistoryID      int64
+	lastImageID        int64
+	lastCategoryID     int64
+	lastBrandID        int64
+	lastWebhookID      int64
+	lastDeliveryID     int64
+	lastImportJobID    int64
+	lastAPIKeyID       int64
+	lastUserID         int64
+	lastRefreshTokenID int64
+	lastAuditLogID     int64
+}
+
+// 空のストアを作成する。categoriesは登録順にIDを振って登録する。
+// マイグレーションと同じく、パスワードを設定していない既定のユーザーを登録しておく
+func NewStore(categories ...*entity.Category) *Store {
+	now := entity.Now()
+	s := &Store{
+		items:           make(map[int64]*entity.Item),
+		idempotencyKeys: make(map[idempotencyKeyID]*entity.IdempotencyKey),
+		users:           []*entity.User{{ID: entity.DefaultUserID, Email: entity.DefaultUserEmail, Role: entity.UserRoleAdmin, CreatedAt: now, UpdatedAt: now}},
+		lastUserID:      entity.DefaultUserID,
+	}
+	for _, category := range categories {
+		s.insertCategory(category)
+	}
+	return s
+}
+
+// 保存済みのアイテムを返却用に複製し、カテゴリーのスラッグを設定する。呼び出し元はロックを取得しておく
+func (s *Store) snapshot(item *entity.Item) *entity.Item {
+	clone := cloneItem(item)
+	clone.CategorySlug = ""
+	for _, category := range s.categories {
+		if category.Name == item.Category {
+			clone.CategorySlug = category.Slug
+			break
+		}
+	}
+	return clone
+}
+
+// ポインタのフィールドとタグも含めてアイテムを複製する。画像は単一アイテムの取得時にユースケースで設定するため複製しない
+func cloneItem(item *entity.Item) *entity.Item {
+	clone := *item
+	clone.SerialNumber = cloneString(item.SerialNumber)
+	clone.Condition = cloneString(item.Condition)
+	clone.PurchaseLocation = cloneString(item.PurchaseLocation)
+	if item.SellingPrice != nil {
+		price := *item.SellingPrice
+		clone.SellingPrice = &price
+	}
+	if item.SoldDate != nil {
+		date := *item.SoldDate
+		clone.SoldDate = &date
+	}
+	if item.DeletedAt != nil {
+		deletedAt := *item.DeletedAt
+		clone.DeletedAt = &deletedAt
+	}
+	// タグがない場合はSQLの実装と同じくnilにする
+	clone.Tags = nil
+	if len(item.Tags) > 0 {
+		clone.Tags = append([]string(nil), item.Tags...)
+	}
+	clone.Images = nil
+	return &clone
+}
+
+func cloneString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
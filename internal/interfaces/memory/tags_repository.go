@@ -0,0 +1,37 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// usecase.TagRepositoryのメモリ上の実装。タグはアイテムのTagsから集計する
+type TagRepository struct {
+	*Store
+}
+
+// タグを名前順に、論理削除されていないアイテムの件数とともに取得する。
+// database.TagRepositoryと同じく、呼び出し元がユーザーの場合はそのユーザーのアイテムのみを数える
+func (r *TagRepository) FindAllWithCounts(ctx context.Context) ([]*entity.TagCount, error) {
+	defer r.rlock(ctx)()
+
+	counts := make(map[string]int)
+	for _, item := range r.items {
+		if item.DeletedAt != nil || !owned(ctx, item) {
+			continue
+		}
+		for _, tag := range item.Tags {
+			counts[tag]++
+		}
+	}
+
+	tags := make([]*entity.TagCount, 0, len(counts))
+	for name, count := range counts {
+		tags = append(tags, &entity.TagCount{Name: name, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+
+	return tags, nil
+}
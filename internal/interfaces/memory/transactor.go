@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// コンテキストに保持する実行中のトランザクションのキー。値はロックを取得したStore
+type txKey struct{}
+
+// usecase.Transactorの実装。トランザクションの間はStoreの書き込みロックを取得し続け、
+// fnがエラーを返した場合は開始時の内容に戻す
+type Transactor struct {
+	*Store
+}
+
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	// 実行中のトランザクションがある場合はそのトランザクションに含める
+	if t.inTx(ctx) {
+		return fn(ctx)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	saved := t.copyData()
+	committed := false
+	defer func() {
+		if !committed {
+			t.restore(saved)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, t.Store)); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+// ctxでこのStoreのトランザクションを実行中かどうか
+func (s *Store) inTx(ctx context.Context) bool {
+	store, ok := ctx.Value(txKey{}).(*Store)
+	return ok && store == s
+}
+
+// 書き込みロックを取得し、解放する関数を返す。トランザクション内ではWithinTxがロックを取得済みのため何もしない
+func (s *Store) lock(ctx context.Context) func() {
+	if s.inTx(ctx) {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+// 読み込みロックを取得し、解放する関数を返す。トランザクション内ではWithinTxがロックを取得済みのため何もしない
+func (s *Store) rlock(ctx context.Context) func() {
+	if s.inTx(ctx) {
+		return func() {}
+	}
+	s.mu.RLock()
+	return s.mu.RUnlock
+}
+
+// ロールバック用に複製したStoreの内容。呼び出し元はロックを取得しておく
+func (s *Store) copyData() *Store {
+	saved := &Store{
+		items:              make(map[int64]*entity.Item, len(s.items)),
+		histories:          make([]*entity.ItemHistory, 0, len(s.histories)),
+		images:             make([]*entity.ItemImage, 0, len(s.images)),
+		idempotencyKeys:    make(map[idempotencyKeyID]*entity.IdempotencyKey, len(s.idempotencyKeys)),
+		categories:         make([]*entity.Category, 0, len(s.categories)),
+		brands:             make([]*entity.Brand, 0, len(s.brands)),
+		webhooks:           make([]*entity.Webhook, 0, len(s.webhooks)),
+		deliveries:         make([]*entity.WebhookDelivery, 0, len(s.deliveries)),
+		importJobs:         make([]*entity.ImportJob, 0, len(s.importJobs)),
+		apiKeys:            make([]*entity.APIKey, 0, len(s.apiKeys)),
+		users:              make([]*entity.User, 0, len(s.users)),
+		refreshTokens:      make([]*entity.RefreshToken, 0, len(s.refreshTokens)),
+		lastItemID:         s.lastItemID,
+		lastHistoryID:      s.lastHistoryID,
+		lastImageID:        s.lastImageID,
+		lastCategoryID:     s.lastCategoryID,
+		lastBrandID:        s.lastBrandID,
+		lastWebhookID:      s.lastWebhookID,
+		lastDeliveryID:     s.lastDeliveryID,
+		lastImportJobID:    s.lastImportJobID,
+		lastAPIKeyID:       s.lastAPIKeyID,
+		lastUserID:         s.lastUserID,
+		lastRefreshTokenID: s.lastRefreshTokenID,
+	}
+	for id, item := range s.items {
+		saved.items[id] = cloneItem(item)
+	}
+	for _, history := range s.histories {
+		clone := *history
+		saved.histories = append(saved.histories, &clone)
+	}
+	for _, image := range s.images {
+		clone := *image
+		saved.images = append(saved.images, &clone)
+	}
+	for key, idempotencyKey := range s.idempotencyKeys {
+		clone := *idempotencyKey
+		saved.idempotencyKeys[key] = &clone
+	}
+	for _, category := range s.categories {
+		clone := *category
+		saved.categories = append(saved.categories, &clone)
+	}
+	for _, brand := range s.brands {
+		saved.brands = append(saved.brands, cloneBrand(brand))
+	}
+	for _, webhook := range s.webhooks {
+		clone := *webhook
+		saved.webhooks = append(saved.webhooks, &clone)
+	}
+	for _, delivery := range s.deliveries {
+		saved.deliveries = append(saved.deliveries, cloneDelivery(delivery))
+	}
+	for _, job := range s.importJobs {
+		saved.importJobs = append(saved.importJobs, cloneImportJob(job))
+	}
+	for _, key := range s.apiKeys {
+		saved.apiKeys = append(saved.apiKeys, cloneAPIKey(key))
+	}
+	for _, user := range s.users {
+		clone := *user
+		saved.users = append(saved.users, &clone)
+	}
+	for _, token := range s.refreshTokens {
+		saved.refreshTokens = append(saved.refreshTokens, cloneRefreshToken(token))
+	}
+	return saved
+}
+
+// copyDataで複製した内容に戻す。呼び出し元はロックを取得しておく
+func (s *Store) restore(saved *Store) {
+	s.items = saved.items
+	s.histories = saved.histories
+	s.images = saved.images
+	s.idempotencyKeys = saved.idempotencyKeys
+	s.categories = saved.categories
+	s.brands = saved.brands
+	s.webhooks = saved.webhooks
+	s.deliveries = saved.deliveries
+	s.importJobs = saved.importJobs
+	s.apiKeys = saved.apiKeys
+	s.users = saved.users
+	s.refreshTokens = saved.refreshTokens
+	s.lastItemID = saved.lastItemID
+	s.lastHistoryID = saved.lastHistoryID
+	s.lastImageID = saved.lastImageID
+	s.lastCategoryID = saved.lastCategoryID
+	s.lastBrandID = saved.lastBrandID
+	s.lastWebhookID = saved.lastWebhookID
+	s.lastDeliveryID = saved.lastDeliveryID
+	s.lastImportJobID = saved.lastImportJobID
+	s.lastAPIKeyID = saved.lastAPIKeyID
+	s.lastUserID = saved.lastUserID
+	s.lastRefreshTokenID = saved.lastRefreshTokenID
+}
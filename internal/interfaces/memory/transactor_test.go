@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+var _ usecase.Transactor = (*Transactor)(nil)
+
+func TestTransactor_WithinTx(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore(DefaultCategories()...)
+	transactor := &Transactor{Store: store}
+	items := &ItemRepository{Store: store}
+	categories := &CategoryRepository{Store: store}
+
+	kept, err := items.Create(ctx, &entity.Item{Name: "デイトナ", Category: "時計", Brand: "ROLEX"})
+	require.NoError(t, err)
+
+	t.Run("異常系: 途中で失敗した場合はすべての書き込みを取り消す", func(t *testing.T) {
+		historiesBefore, err := items.CountHistories(ctx, kept.ID)
+		require.NoError(t, err)
+
+		failure := errors.New("failure")
+		err = transactor.WithinTx(ctx, func(ctx context.Context) error {
+			_, err := items.CreateMany(ctx, []*entity.Item{
+				{Name: "バーキン", Category: "バッグ", Brand: "HERMÈS"},
+				{Name: "ケリー", Category: "バッグ", Brand: "HERMÈS"},
+			})
+			require.NoError(t, err)
+			change, err := items.Delete(ctx, kept.ID)
+			require.NoError(t, err)
+			require.NoError(t, items.CreateHistory(ctx, kept.ID, entity.HistoryActionDelete, change.Before, change.After))
+			_, err = categories.Create(ctx, &entity.Category{Name: "楽器", Slug: "instruments"})
+			require.NoError(t, err)
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+
+		count, err := items.Count(ctx, entity.ItemFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		histories, err := items.CountHistories(ctx, kept.ID)
+		require.NoError(t, err)
+		assert.Equal(t, historiesBefore, histories)
+
+		all, err := categories.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, len(DefaultCategories()))
+	})
+
+	t.Run("正常系: エラーがなければ書き込みを残す", func(t *testing.T) {
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return transactor.WithinTx(ctx, func(ctx context.Context) error {
+				_, err := items.Delete(ctx, kept.ID)
+				return err
+			})
+		})
+		require.NoError(t, err)
+
+		_, err = items.FindByID(ctx, kept.ID)
+		assert.Error(t, err)
+	})
+}
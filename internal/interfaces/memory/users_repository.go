@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// usecase.UserRepositoryのメモリ上の実装
+type UserRepository struct {
+	*Store
+}
+
+func (r *UserRepository) FindAll(ctx context.Context) ([]*entity.User, error) {
+	defer r.rlock(ctx)()
+
+	users := make([]*entity.User, 0, len(r.users))
+	for _, user := range r.users {
+		clone := *user
+		users = append(users, &clone)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	defer r.rlock(ctx)()
+
+	for _, user := range r.users {
+		if user.Email == email {
+			clone := *user
+			return &clone, nil
+		}
+	}
+
+	return nil, domainErrors.ErrUserNotFound
+}
+
+func (r *UserRepository) FindByID(ctx context.Context, id int64) (*entity.User, error) {
+	defer r.rlock(ctx)()
+
+	for _, user := range r.users {
+		if user.ID == id {
+			clone := *user
+			return &clone, nil
+		}
+	}
+
+	return nil, domainErrors.ErrUserNotFound
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
+	defer r.lock(ctx)()
+
+	// SQLの実装と同じく、メールアドレスの一意制約を守る
+	for _, existing := range r.users {
+		if existing.Email == user.Email {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDuplicateEmail, user.Email)
+		}
+	}
+
+	r.lastUserID++
+	now := entity.Now()
+	stored := *user
+	stored.ID = r.lastUserID
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	r.users = append(r.users, &stored)
+
+	clone := stored
+	return &clone, nil
+}
+
+func (r *UserRepository) UpdateRole(ctx context.Context, id int64, role entity.UserRole) (*entity.User, error) {
+	defer r.lock(ctx)()
+
+	for _, user := range r.users {
+		if user.ID == id {
+			user.Role = role
+			user.UpdatedAt = entity.Now()
+			clone := *user
+			return &clone, nil
+		}
+	}
+
+	return nil, domainErrors.ErrUserNotFound
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) (*entity.User, error) {
+	defer r.lock(ctx)()
+
+	for _, user := range r.users {
+		if user.ID == id {
+			user.PasswordHash = passwordHash
+			user.UpdatedAt = entity.Now()
+			clone := *user
+			return &clone, nil
+		}
+	}
+
+	return nil, domainErrors.ErrUserNotFound
+}
+
+func (r *UserRepository) CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error) {
+	defer r.lock(ctx)()
+
+	for _, existing := range r.refreshTokens {
+		if existing.TokenHash == token.TokenHash {
+			return nil, fmt.Errorf("%w: refresh token already exists", domainErrors.ErrDuplicateEntry)
+		}
+	}
+
+	r.lastRefreshTokenID++
+	stored := cloneRefreshToken(token)
+	stored.ID = r.lastRefreshTokenID
+	stored.CreatedAt = entity.Now()
+	stored.RevokedAt = nil
+	r.refreshTokens = append(r.refreshTokens, stored)
+
+	return cloneRefreshToken(stored), nil
+}
+
+func (r *UserRepository) FindRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	defer r.rlock(ctx)()
+
+	for _, token := range r.refreshTokens {
+		if token.TokenHash == tokenHash {
+			return cloneRefreshToken(token), nil
+		}
+	}
+
+	return nil, domainErrors.ErrRefreshTokenNotFound
+}
+
+func (r *UserRepository) RevokeRefreshToken(ctx context.Context, id int64) (bool, error) {
+	defer r.lock(ctx)()
+
+	for _, token := range r.refreshTokens {
+		if token.ID != id || token.RevokedAt != nil {
+			continue
+		}
+		now := entity.Now()
+		token.RevokedAt = &now
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func cloneRefreshToken(token *entity.RefreshToken) *entity.RefreshToken {
+	clone := *token
+	if token.RevokedAt != nil {
+		revokedAt := *token.RevokedAt
+		clone.RevokedAt = &revokedAt
+	}
+	return &clone
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestUserRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := &UserRepository{Store: NewStore()}
+
+	// 既定のユーザーはパスワードなしの管理者として登録済み
+	owner, err := repo.FindByEmail(ctx, entity.DefaultUserEmail)
+	require.NoError(t, err)
+	assert.Equal(t, entity.DefaultUserID, owner.ID)
+	assert.Equal(t, entity.UserRoleAdmin, owner.Role)
+	assert.Empty(t, owner.PasswordHash)
+
+	user, err := entity.NewUser("alice@example.com", "password")
+	require.NoError(t, err)
+	created, err := repo.Create(ctx, user)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), created.ID)
+
+	_, err = repo.Create(ctx, user)
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateEmail)
+	_, err = repo.FindByEmail(ctx, "bob@example.com")
+	assert.ErrorIs(t, err, domainErrors.ErrUserNotFound)
+
+	require.NoError(t, owner.SetPassword("new password"))
+	updated, err := repo.UpdatePassword(ctx, owner.ID, owner.PasswordHash)
+	require.NoError(t, err)
+	assert.True(t, updated.CheckPassword("new password"))
+	_, err = repo.UpdatePassword(ctx, 99, "hash")
+	assert.ErrorIs(t, err, domainErrors.ErrUserNotFound)
+
+	promoted, err := repo.UpdateRole(ctx, created.ID, entity.UserRoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserRoleAdmin, promoted.Role)
+	found, err := repo.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserRoleAdmin, found.Role)
+	_, err = repo.FindByID(ctx, 99)
+	assert.ErrorIs(t, err, domainErrors.ErrUserNotFound)
+
+	users, err := repo.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+}
+
+func TestUserRepository_RefreshTokens(t *testing.T) {
+	ctx := context.Background()
+	repo := &UserRepository{Store: NewStore()}
+
+	refreshToken, token, err := entity.NewRefreshToken(entity.DefaultUserID, time.Hour)
+	require.NoError(t, err)
+	created, err := repo.CreateRefreshToken(ctx, refreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), created.ID)
+
+	found, err := repo.FindRefreshToken(ctx, entity.HashRefreshToken(token))
+	require.NoError(t, err)
+	assert.Nil(t, found.RevokedAt)
+	_, err = repo.FindRefreshToken(ctx, "unknown")
+	assert.ErrorIs(t, err, domainErrors.ErrRefreshTokenNotFound)
+
+	revoked, err := repo.RevokeRefreshToken(ctx, created.ID)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	// 失効済みのトークンは二度失効できない
+	revoked, err = repo.RevokeRefreshToken(ctx, created.ID)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	found, err = repo.FindRefreshToken(ctx, entity.HashRefreshToken(token))
+	require.NoError(t, err)
+	assert.NotNil(t, found.RevokedAt)
+}
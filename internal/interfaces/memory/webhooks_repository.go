@@ -0,0 +1,186 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// usecase.WebhookRepositoryのメモリ上の実装
+type WebhookRepository struct {
+	*Store
+}
+
+func (r *WebhookRepository) FindAll(ctx context.Context) ([]*entity.Webhook, error) {
+	defer r.rlock(ctx)()
+
+	webhooks := make([]*entity.Webhook, 0, len(r.webhooks))
+	for _, webhook := range r.webhooks {
+		clone := *webhook
+		webhooks = append(webhooks, &clone)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) FindByID(ctx context.Context, id int64) (*entity.Webhook, error) {
+	defer r.rlock(ctx)()
+
+	webhook := r.findWebhook(id)
+	if webhook == nil {
+		return nil, domainErrors.ErrWebhookNotFound
+	}
+
+	clone := *webhook
+	return &clone, nil
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	defer r.lock(ctx)()
+
+	r.lastWebhookID++
+	stored := *webhook
+	stored.ID = r.lastWebhookID
+	stored.CreatedAt = entity.Now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.webhooks = append(r.webhooks, &stored)
+
+	clone := stored
+	return &clone, nil
+}
+
+// テーブルの外部キーと同じく、Webhookの送信の記録も削除する
+func (r *WebhookRepository) Delete(ctx context.Context, id int64) error {
+	defer r.lock(ctx)()
+
+	index := -1
+	for i, webhook := range r.webhooks {
+		if webhook.ID == id {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return domainErrors.ErrWebhookNotFound
+	}
+	r.webhooks = append(r.webhooks[:index], r.webhooks[index+1:]...)
+
+	kept := r.deliveries[:0]
+	for _, delivery := range r.deliveries {
+		if delivery.WebhookID != id {
+			kept = append(kept, delivery)
+		}
+	}
+	r.deliveries = kept
+
+	return nil
+}
+
+func (r *WebhookRepository) Enable(ctx context.Context, id int64) (*entity.Webhook, error) {
+	defer r.lock(ctx)()
+
+	webhook := r.findWebhook(id)
+	if webhook == nil {
+		return nil, domainErrors.ErrWebhookNotFound
+	}
+	webhook.Enabled = true
+	webhook.FailureCount = 0
+	webhook.UpdatedAt = entity.Now()
+
+	clone := *webhook
+	return &clone, nil
+}
+
+func (r *WebhookRepository) RecordResult(ctx context.Context, id int64, succeeded bool, maxFailures int) error {
+	defer r.lock(ctx)()
+
+	webhook := r.findWebhook(id)
+	if webhook == nil {
+		return nil
+	}
+	if succeeded {
+		webhook.FailureCount = 0
+	} else {
+		webhook.FailureCount++
+		if webhook.FailureCount >= maxFailures {
+			webhook.Enabled = false
+		}
+	}
+	webhook.UpdatedAt = entity.Now()
+
+	return nil
+}
+
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	defer r.lock(ctx)()
+
+	// 配信中に削除されたWebhookの記録は、外部キーの制約と同じく保存しない
+	if r.findWebhook(delivery.WebhookID) == nil {
+		return domainErrors.ErrWebhookNotFound
+	}
+
+	r.lastDeliveryID++
+	stored := cloneDelivery(delivery)
+	stored.ID = r.lastDeliveryID
+	stored.CreatedAt = entity.Now()
+	r.deliveries = append(r.deliveries, stored)
+	delivery.ID = stored.ID
+
+	return nil
+}
+
+func (r *WebhookRepository) FindDeliveries(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error) {
+	defer r.rlock(ctx)()
+
+	deliveries := make([]*entity.WebhookDelivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, cloneDelivery(delivery))
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID > deliveries[j].ID })
+	if len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) DeleteDeliveriesBefore(ctx context.Context, createdBefore time.Time) (int64, error) {
+	defer r.lock(ctx)()
+
+	var deleted int64
+	kept := r.deliveries[:0]
+	for _, delivery := range r.deliveries {
+		if delivery.CreatedAt.After(createdBefore) {
+			kept = append(kept, delivery)
+		} else {
+			deleted++
+		}
+	}
+	r.deliveries = kept
+
+	return deleted, nil
+}
+
+// IDのWebhook。ない場合はnil。呼び出し元はロックを取得しておく
+func (s *Store) findWebhook(id int64) *entity.Webhook {
+	for _, webhook := range s.webhooks {
+		if webhook.ID == id {
+			return webhook
+		}
+	}
+	return nil
+}
+
+func cloneDelivery(delivery *entity.WebhookDelivery) *entity.WebhookDelivery {
+	clone := *delivery
+	if delivery.StatusCode != nil {
+		code := *delivery.StatusCode
+		clone.StatusCode = &code
+	}
+	return &clone
+}
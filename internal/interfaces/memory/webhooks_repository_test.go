@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestWebhookRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := &WebhookRepository{Store: NewStore()}
+
+	webhook, err := repo.Create(ctx, &entity.Webhook{URL: "https://example.com/hooks", Secret: "0123456789abcdef", Enabled: true})
+	require.NoError(t, err)
+
+	t.Run("正常系: 失敗が上限に達すると無効になり、成功で回数を0に戻す", func(t *testing.T) {
+		require.NoError(t, repo.RecordResult(ctx, webhook.ID, false, 2))
+		found, err := repo.FindByID(ctx, webhook.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, found.FailureCount)
+		assert.True(t, found.Enabled)
+
+		require.NoError(t, repo.RecordResult(ctx, webhook.ID, false, 2))
+		found, err = repo.FindByID(ctx, webhook.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, found.FailureCount)
+		assert.False(t, found.Enabled)
+
+		enabled, err := repo.Enable(ctx, webhook.ID)
+		require.NoError(t, err)
+		assert.True(t, enabled.Enabled)
+		assert.Zero(t, enabled.FailureCount)
+
+		require.NoError(t, repo.RecordResult(ctx, webhook.ID, false, 2))
+		require.NoError(t, repo.RecordResult(ctx, webhook.ID, true, 2))
+		found, err = repo.FindByID(ctx, webhook.ID)
+		require.NoError(t, err)
+		assert.Zero(t, found.FailureCount)
+	})
+
+	t.Run("正常系: 送信の記録を新しい順に返し、期限切れの記録を削除する", func(t *testing.T) {
+		status := 500
+		for attempt := 1; attempt <= 3; attempt++ {
+			delivery := &entity.WebhookDelivery{WebhookID: webhook.ID, EventID: "e1", Event: entity.WebhookEventItemCreated, ItemID: 1, Attempt: attempt, StatusCode: &status}
+			require.NoError(t, repo.CreateDelivery(ctx, delivery))
+			assert.NotZero(t, delivery.ID)
+		}
+		// 保存後に元の値を変更しても、保存した記録は変わらない
+		status = 200
+
+		deliveries, err := repo.FindDeliveries(ctx, webhook.ID, 2)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		assert.Equal(t, 3, deliveries[0].Attempt)
+		assert.Equal(t, 500, *deliveries[0].StatusCode)
+
+		deleted, err := repo.DeleteDeliveriesBefore(ctx, entity.Now().Add(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), deleted)
+	})
+
+	t.Run("正常系: 削除したWebhookの送信の記録も削除する", func(t *testing.T) {
+		require.NoError(t, repo.CreateDelivery(ctx, &entity.WebhookDelivery{WebhookID: webhook.ID, EventID: "e2", Event: entity.WebhookEventItemDeleted, ItemID: 1, Attempt: 1}))
+		require.NoError(t, repo.Delete(ctx, webhook.ID))
+
+		deliveries, err := repo.FindDeliveries(ctx, webhook.ID, 10)
+		require.NoError(t, err)
+		assert.Empty(t, deliveries)
+	})
+
+	t.Run("異常系: 存在しないWebhook", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Delete(ctx, webhook.ID), domainErrors.ErrWebhookNotFound)
+		_, err := repo.Enable(ctx, webhook.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrWebhookNotFound)
+		assert.ErrorIs(t, repo.CreateDelivery(ctx, &entity.WebhookDelivery{WebhookID: webhook.ID}), domainErrors.ErrWebhookNotFound)
+		// 削除済みのWebhookの結果は無視する
+		assert.NoError(t, repo.RecordResult(ctx, webhook.ID, false, 1))
+	})
+}
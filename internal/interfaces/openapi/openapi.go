@@ -0,0 +1,433 @@
+// APIのOpenAPI 3.0の文書を、各コントローラーのパッケージが宣言する操作（Operation）から組み立てる。
+// スキーマは手で書かず、リクエストとレスポンスのGoの型から生成する
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+	"Aicon-assignment/internal/interfaces/controller/response"
+)
+
+// 対応するOpenAPIのバージョン
+const Version = "3.0.3"
+
+// OpenAPIの文書
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]*Endpoint `json:"paths"` // パス→小文字のメソッド→操作
+	Components Components                      `json:"components"`
+	Security   []map[string][]string           `json:"security"`
+
+	typeNames  map[reflect.Type]string // components.schemasに登録した型の名前
+	operations map[string]*Operation   // "メソッド パス"→追加した操作。テストの検証に使う
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	Responses       map[string]*Response       `json:"responses"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+	In     string `json:"in,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+// コントローラーが宣言する1つのルートの操作。パスのパラメーターは {id} の形式で書く
+type Operation struct {
+	Method      string
+	Path        string
+	ID          string
+	Summary     string
+	Description string
+	Tags        []string
+	Parameters  []*Parameter
+	RequestBody *RequestBody
+	Responses   Responses
+	// 成功以外に返すステータスコード。problem+jsonのエラーレスポンスとして追加する
+	Errors []int
+	// 認証せずに呼び出せるルート
+	Public bool
+}
+
+// ステータスコード→レスポンス
+type Responses map[int]*Response
+
+// 文書に出力する操作
+type Endpoint struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Ref         string                `json:"$ref,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// パスの整数のIDのパラメーター
+func PathID(name, description string) *Parameter {
+	return &Parameter{Name: name, In: "path", Description: description, Required: true, Schema: Integer()}
+}
+
+// クエリパラメーター（任意）
+func Query(name string, schema *Schema, description string) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+// リクエストヘッダー（任意）
+func RequestHeader(name, description string) *Parameter {
+	return &Parameter{Name: name, In: "header", Description: description, Schema: String()}
+}
+
+// JSONのリクエストボディ
+func JSONBody(schema *Schema) *RequestBody {
+	return &RequestBody{Required: true, Content: map[string]*MediaType{"application/json": {Schema: schema}}}
+}
+
+// multipart/form-dataでfieldのファイルを受け取るリクエストボディ
+func FileUpload(field, description string) *RequestBody {
+	return &RequestBody{Required: true, Content: map[string]*MediaType{
+		"multipart/form-data": {Schema: Object(map[string]*Schema{
+			field: {Type: "string", Format: "binary", Description: description},
+		})},
+	}}
+}
+
+// JSONのレスポンス
+func JSON(description string, schema *Schema) *Response {
+	return &Response{Description: description, Content: map[string]*MediaType{"application/json": {Schema: schema}}}
+}
+
+// mediaTypeのレスポンス。JSON以外の形式（CSV、xlsxなど）で返す場合に使う
+func Content(description, mediaType string, schema *Schema) *Response {
+	return &Response{Description: description, Content: map[string]*MediaType{mediaType: {Schema: schema}}}
+}
+
+// ボディのないレスポンス
+func NoContent(description string) *Response {
+	return &Response{Description: description}
+}
+
+// レスポンスヘッダーを加えたレスポンス
+func (r *Response) WithHeader(name, description string) *Response {
+	clone := *r
+	clone.Headers = make(map[string]*Header, len(r.Headers)+1)
+	for k, v := range r.Headers {
+		clone.Headers[k] = v
+	}
+	clone.Headers[name] = &Header{Description: description, Schema: String()}
+	return &clone
+}
+
+// v2のレスポンスの形式で、1件のリソースをitemで包んだスキーマ
+func Envelope(item *Schema) *Schema {
+	return Object(map[string]*Schema{"item": item}).WithOptional(map[string]*Schema{"meta": Of(response.Meta{})})
+}
+
+// v2のレスポンスの形式で、一覧をitemsとpaginationで包んだスキーマ
+func List(item *Schema) *Schema {
+	return Object(map[string]*Schema{
+		"items":      ArrayOf(item),
+		"pagination": Of(response.Pagination{}),
+	}).WithOptional(map[string]*Schema{"meta": Of(response.Meta{})})
+}
+
+func NewDocument(info Info) *Document {
+	d := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]map[string]*Endpoint),
+		Components: Components{
+			Schemas:   make(map[string]*Schema),
+			Responses: make(map[string]*Response),
+			SecuritySchemes: map[string]*SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer"},
+				"apiKey":     {Type: "apiKey", In: "header", Name: "X-API-Key"},
+			},
+		},
+		// APIキーかアクセストークンのいずれかで認証する
+		Security:   []map[string][]string{{"bearerAuth": {}}, {"apiKey": {}}},
+		typeNames:  make(map[reflect.Type]string),
+		operations: make(map[string]*Operation),
+	}
+	d.addErrorComponents()
+	return d
+}
+
+// すべてのエラーレスポンスで共通のスキーマ。problem+jsonと、Accept: application/jsonで求められた場合の旧形式
+func (d *Document) addErrorComponents() {
+	d.schemaFor(reflect.TypeOf(domainErrors.FieldError{}))
+	d.schemaFor(reflect.TypeOf(response.Pagination{}))
+	d.schemaFor(reflect.TypeOf(httperror.ErrorResponse{}))
+	d.Components.Schemas["Problem"] = problemSchema(nil)
+	d.Components.Responses["Problem"] = ProblemResponse("エラー（RFC 7807）。Accept: application/jsonの場合は旧形式で返す", nil, &Schema{Ref: "#/components/schemas/ErrorResponse"})
+	d.Components.Responses["Problem"].Content[httperror.MIMEApplicationProblemJSON].Schema = &Schema{Ref: "#/components/schemas/Problem"}
+}
+
+// problem+jsonのスキーマ。extensionsには、エラーごとの情報のプロパティを加えたり置き換えたりできる
+func problemSchema(extensions map[string]*Schema) *Schema {
+	properties := map[string]*Schema{
+		"request_id":       String().Describe("ログと照合するためのリクエストID"),
+		"errors":           ArrayOf(&Schema{Ref: "#/components/schemas/ValidationError"}).Describe("項目ごとのバリデーションエラー"),
+		"current_version":  Integer().Describe("バージョンの競合時のみ、サーバー上の現在のバージョン"),
+		"existing_item_id": Integer().Describe("重複登録時のみ、登録済みのアイテムのID"),
+		"current_status":   String(),
+		"requested_status": String(),
+	}
+	for name, schema := range extensions {
+		properties[name] = schema
+	}
+	return Object(map[string]*Schema{
+		"type":   String().Describe("問題の種類を表すURI（/problems/<code>）"),
+		"title":  String(),
+		"status": Integer(),
+		"extensions": Object(map[string]*Schema{
+			"code": String().Describe("エラーコード"),
+		}).WithOptional(properties),
+	}).WithOptional(map[string]*Schema{"detail": String()})
+}
+
+// problem+jsonと旧形式（legacy）のエラーレスポンス。
+// 一括登録のようにextensionsに独自の情報を含めるエラーは、宣言するステータスコードのレスポンスにこれを使う
+func ProblemResponse(description string, extensions map[string]*Schema, legacy *Schema) *Response {
+	return &Response{
+		Description: description,
+		Content: map[string]*MediaType{
+			httperror.MIMEApplicationProblemJSON: {Schema: problemSchema(extensions)},
+			"application/json":                   {Schema: legacy},
+		},
+	}
+}
+
+// prefixを付けたパスで操作を追加する。同じメソッドとパスの操作を追加した場合はpanicする
+func (d *Document) Add(prefix string, operations ...Operation) {
+	for _, op := range operations {
+		path := prefix + op.Path
+		key := op.Method + " " + path
+		if _, ok := d.operations[key]; ok {
+			panic("openapi: duplicate operation " + key)
+		}
+		added := op
+		d.operations[key] = &added
+
+		endpoint := &Endpoint{
+			OperationID: op.ID,
+			Summary:     op.Summary,
+			Description: op.Description,
+			Tags:        op.Tags,
+			Responses:   make(map[string]*Response),
+		}
+		for _, p := range op.Parameters {
+			resolved := *p
+			resolved.Schema = d.resolve(p.Schema)
+			endpoint.Parameters = append(endpoint.Parameters, &resolved)
+		}
+		if op.RequestBody != nil {
+			endpoint.RequestBody = &RequestBody{Required: op.RequestBody.Required, Content: d.resolveContent(op.RequestBody.Content)}
+		}
+		for status, res := range op.Responses {
+			resolved := *res
+			resolved.Content = d.resolveContent(res.Content)
+			endpoint.Responses[strconv.Itoa(status)] = &resolved
+		}
+		for _, status := range op.Errors {
+			endpoint.Responses[strconv.Itoa(status)] = &Response{Ref: "#/components/responses/Problem"}
+		}
+		// 宣言していないエラー（401、429、500など）もproblem+jsonで返す
+		endpoint.Responses["default"] = &Response{Ref: "#/components/responses/Problem"}
+		if op.Public {
+			endpoint.Security = []map[string][]string{{}}
+		}
+
+		if d.Paths[path] == nil {
+			d.Paths[path] = make(map[string]*Endpoint)
+		}
+		d.Paths[path][strings.ToLower(op.Method)] = endpoint
+	}
+}
+
+func (d *Document) resolveContent(content map[string]*MediaType) map[string]*MediaType {
+	if content == nil {
+		return nil
+	}
+	resolved := make(map[string]*MediaType, len(content))
+	for mediaType, media := range content {
+		resolved[mediaType] = &MediaType{Schema: d.resolve(media.Schema)}
+	}
+	return resolved
+}
+
+// 追加した操作の"メソッド パス"の一覧
+func (d *Document) Routes() []string {
+	routes := make([]string, 0, len(d.operations))
+	for key := range d.operations {
+		routes = append(routes, key)
+	}
+	slices.Sort(routes)
+	return routes
+}
+
+// リクエストボディが文書どおりかを確かめる。pathは文書のパス（/api/v1/items/{id} など）
+func (d *Document) ValidateRequest(method, path, contentType string, body []byte) error {
+	op, err := d.operation(method, path)
+	if err != nil {
+		return err
+	}
+	endpoint := d.Paths[path][strings.ToLower(method)]
+	if endpoint.RequestBody == nil {
+		if len(body) > 0 {
+			return fmt.Errorf("%s %s: request body is not documented", method, path)
+		}
+		return nil
+	}
+	media, err := lookupContent(endpoint.RequestBody.Content, contentType)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", op.Method, path, err)
+	}
+	return d.validateBody(media, contentType, body, fmt.Sprintf("%s %s request", method, path))
+}
+
+// レスポンスが文書どおりかを確かめる。宣言していない成功のステータスコードはエラーとし、
+// 宣言していないエラーのステータスコードはdefaultのエラーレスポンスとして確かめる
+func (d *Document) ValidateResponse(method, path string, status int, contentType string, body []byte) error {
+	if _, err := d.operation(method, path); err != nil {
+		return err
+	}
+	endpoint := d.Paths[path][strings.ToLower(method)]
+	res, ok := endpoint.Responses[strconv.Itoa(status)]
+	if !ok {
+		if status < http.StatusBadRequest {
+			return fmt.Errorf("%s %s: status %d is not documented", method, path, status)
+		}
+		res = endpoint.Responses["default"]
+	}
+	if res.Ref != "" {
+		res = d.Components.Responses[strings.TrimPrefix(res.Ref, "#/components/responses/")]
+	}
+
+	if len(res.Content) == 0 {
+		if len(body) > 0 {
+			return fmt.Errorf("%s %s: status %d must not have a body", method, path, status)
+		}
+		return nil
+	}
+	media, err := lookupContent(res.Content, contentType)
+	if err != nil {
+		return fmt.Errorf("%s %s: status %d: %w", method, path, status, err)
+	}
+	return d.validateBody(media, contentType, body, fmt.Sprintf("%s %s %d response", method, path, status))
+}
+
+func (d *Document) operation(method, path string) (*Operation, error) {
+	op, ok := d.operations[method+" "+path]
+	if !ok {
+		return nil, fmt.Errorf("%s %s is not documented", method, path)
+	}
+	return op, nil
+}
+
+// Content-Typeに対応するメディアタイプ。charsetなどのパラメーターは比較しない
+func lookupContent(content map[string]*MediaType, contentType string) (*MediaType, error) {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	if media, ok := content[mediaType]; ok {
+		return media, nil
+	}
+	return nil, fmt.Errorf("content type %q is not documented", contentType)
+}
+
+// JSONのボディをスキーマで確かめる。NDJSONは1行ずつ確かめ、それ以外の形式は確かめない
+func (d *Document) validateBody(media *MediaType, contentType string, body []byte, name string) error {
+	if media.Schema == nil {
+		return nil
+	}
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	switch {
+	case mediaType == "application/x-ndjson":
+		for i, line := range strings.Split(strings.TrimSuffix(string(body), "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			if err := d.validateJSON(media.Schema, []byte(line)); err != nil {
+				return fmt.Errorf("%s line %d: %w", name, i+1, err)
+			}
+		}
+		return nil
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		if err := d.validateJSON(media.Schema, body); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (d *Document) validateJSON(schema *Schema, body []byte) error {
+	value, err := decodeJSON(body)
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return d.Validate(schema, value)
+}
+
+// Validateで確かめる形式（数値はjson.Number）でJSONを読み込む
+func decodeJSON(body []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
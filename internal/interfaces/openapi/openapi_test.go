@@ -0,0 +1,183 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+)
+
+type testChild struct {
+	Name string `json:"name"`
+}
+
+type testEmbedded struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testResource struct {
+	testEmbedded
+	ID       int64             `json:"id"`
+	Note     *string           `json:"note"`
+	Child    *testChild        `json:"child"`
+	Children []testChild       `json:"children,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+func newTestDocument() *Document {
+	doc := NewDocument(Info{Title: "test", Version: "test"})
+	doc.Add("/api", Operation{
+		Method: http.MethodPost, Path: "/resources", ID: "createResource",
+		RequestBody: JSONBody(Object(map[string]*Schema{"name": String()})),
+		Responses:   Responses{http.StatusCreated: JSON("created", Of(testResource{}))},
+		Errors:      []int{http.StatusBadRequest},
+	}, Operation{
+		Method: http.MethodDelete, Path: "/resources/{id}", ID: "deleteResource",
+		Parameters: []*Parameter{PathID("id", "ID")},
+		Responses:  Responses{http.StatusNoContent: NoContent("deleted")},
+	})
+	return doc
+}
+
+func TestSchemaOf_Struct(t *testing.T) {
+	doc := newTestDocument()
+
+	schema := doc.Components.Schemas["TestResource"]
+	require.NotNil(t, schema)
+	assert.Equal(t, []string{"child", "created_at", "id", "note"}, schema.Required)
+	assert.ElementsMatch(t, []string{"created_at", "id", "note", "child", "children", "labels"}, keys(schema.Properties))
+	assert.Equal(t, "date-time", schema.Properties["created_at"].Format)
+	assert.True(t, schema.Properties["note"].Nullable)
+	// $refのnullはallOfで包む
+	assert.True(t, schema.Properties["child"].Nullable)
+	assert.Equal(t, "#/components/schemas/TestChild", schema.Properties["child"].AllOf[0].Ref)
+	assert.Equal(t, "#/components/schemas/TestChild", schema.Properties["children"].Items.Ref)
+	assert.Equal(t, false, schema.AdditionalProperties)
+}
+
+func TestDocument_Add_Duplicate(t *testing.T) {
+	doc := newTestDocument()
+	assert.Panics(t, func() {
+		doc.Add("/api", Operation{Method: http.MethodDelete, Path: "/resources/{id}"})
+	})
+}
+
+func TestDocument_ValidateResponse(t *testing.T) {
+	doc := newTestDocument()
+	const path = "/api/resources"
+
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		wantErr     string
+	}{
+		{
+			name: "正常系: 必須のフィールドのみ", status: http.StatusCreated, contentType: "application/json",
+			body: `{"id":1,"note":null,"child":null,"created_at":"2024-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "正常系: 任意のフィールドを含む", status: http.StatusCreated, contentType: "application/json; charset=UTF-8",
+			body: `{"id":1,"note":"n","child":{"name":"c"},"children":[{"name":"d"}],"labels":{"a":"b"},"created_at":"2024-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "正常系: 宣言したエラー", status: http.StatusBadRequest, contentType: httperror.MIMEApplicationProblemJSON,
+			body: `{"type":"/problems/VALIDATION_ERROR","title":"validation failed","status":400,"extensions":{"code":"VALIDATION_ERROR","errors":[{"field":"name","code":"REQUIRED","message":"name is required"}]}}`,
+		},
+		{
+			name: "正常系: 宣言していないエラーはdefaultで照合", status: http.StatusInternalServerError, contentType: "application/json",
+			body: `{"error":"internal server error","code":"INTERNAL_ERROR"}`,
+		},
+		{
+			name: "異常系: 必須のフィールドがない", status: http.StatusCreated, contentType: "application/json",
+			body:    `{"id":1,"note":null,"child":null}`,
+			wantErr: `missing required property "created_at"`,
+		},
+		{
+			name: "異常系: 文書にないフィールド", status: http.StatusCreated, contentType: "application/json",
+			body:    `{"id":1,"note":null,"child":null,"created_at":"2024-01-01T00:00:00Z","extra":true}`,
+			wantErr: "$.extra: property is not documented",
+		},
+		{
+			name: "異常系: 型が異なる", status: http.StatusCreated, contentType: "application/json",
+			body:    `{"id":"1","note":null,"child":{"name":1},"created_at":"2024-01-01"}`,
+			wantErr: "$.id: must be an integer",
+		},
+		{
+			name: "異常系: nullを許さないフィールド", status: http.StatusCreated, contentType: "application/json",
+			body:    `{"id":1,"note":null,"child":null,"children":null,"created_at":"2024-01-01T00:00:00Z"}`,
+			wantErr: "$.children: must not be null",
+		},
+		{
+			name: "異常系: 宣言していない成功のステータスコード", status: http.StatusOK, contentType: "application/json",
+			body:    `{}`,
+			wantErr: "200",
+		},
+		{
+			name: "異常系: 宣言していないメディアタイプ", status: http.StatusCreated, contentType: "text/csv",
+			body:    "id\n1\n",
+			wantErr: "text/csv",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := doc.ValidateResponse(http.MethodPost, path, tt.status, tt.contentType, []byte(tt.body))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestDocument_ValidateResponse_NoContent(t *testing.T) {
+	doc := newTestDocument()
+
+	assert.NoError(t, doc.ValidateResponse(http.MethodDelete, "/api/resources/{id}", http.StatusNoContent, "", nil))
+	assert.Error(t, doc.ValidateResponse(http.MethodDelete, "/api/resources/{id}", http.StatusNoContent, "application/json", []byte(`{}`)))
+	assert.Error(t, doc.ValidateResponse(http.MethodGet, "/api/resources/{id}", http.StatusOK, "", nil))
+}
+
+func TestDocument_ValidateRequest(t *testing.T) {
+	doc := newTestDocument()
+
+	assert.NoError(t, doc.ValidateRequest(http.MethodPost, "/api/resources", "application/json", []byte(`{"name":"a"}`)))
+	assert.Error(t, doc.ValidateRequest(http.MethodPost, "/api/resources", "application/json", []byte(`{"name":"a","extra":1}`)))
+	assert.Error(t, doc.ValidateRequest(http.MethodPost, "/api/resources", "application/json", []byte(`{}`)))
+	assert.Error(t, doc.ValidateRequest(http.MethodPost, "/api/resources", "application/json", []byte(`not json`)))
+}
+
+func TestValidate_OneOf(t *testing.T) {
+	doc := NewDocument(Info{})
+	schema := &Schema{OneOf: []*Schema{Integer(), {Type: "string", Pattern: `^\d+$`}}}
+
+	var errs []error
+	for _, body := range []string{`1`, `"2"`} {
+		value, err := decodeJSON([]byte(body))
+		require.NoError(t, err)
+		errs = append(errs, doc.Validate(schema, value))
+	}
+	assert.Equal(t, []error{nil, nil}, errs)
+
+	value, err := decodeJSON([]byte(`"a"`))
+	require.NoError(t, err)
+	assert.Error(t, doc.Validate(schema, value))
+}
+
+func keys(m map[string]*Schema) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	return names
+}
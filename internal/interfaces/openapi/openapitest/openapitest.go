@@ -0,0 +1,31 @@
+// ハンドラーのテストのリクエストとレスポンスを、OpenAPIの文書と照合する補助関数
+package openapitest
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/interfaces/openapi"
+)
+
+// prefixを付けたパスでoperationsを追加した文書
+func Document(prefix string, operations ...openapi.Operation) *openapi.Document {
+	doc := openapi.NewDocument(openapi.Info{Title: "test", Version: "test"})
+	doc.Add(prefix, operations...)
+	return doc
+}
+
+// ハンドラーが返したレスポンスが文書どおりかを確かめる。
+// レスポンスが成功（2xx）の場合は、送ったJSONのリクエストボディ（reqBody、空の場合はボディなし）も確かめる。
+// 失敗したリクエストは、文書にないリクエストを送って400や422になることを確かめるテストのため確かめない
+func AssertExchange(t testing.TB, doc *openapi.Document, method, path, reqBody string, rec *httptest.ResponseRecorder) {
+	t.Helper()
+
+	if rec.Code < 300 && reqBody != "" {
+		assert.NoError(t, doc.ValidateRequest(method, path, echo.MIMEApplicationJSON, []byte(reqBody)))
+	}
+	assert.NoError(t, doc.ValidateResponse(method, path, rec.Code, rec.Header().Get(echo.HeaderContentType), rec.Body.Bytes()))
+}
@@ -0,0 +1,271 @@
+package openapi
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// OpenAPI 3.0のスキーマ。Ofで作ったスキーマはDocumentに追加するときにGoの型から生成し、
+// 名前のある構造体はcomponents.schemasに登録して$refで参照する
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"` // *Schemaまたはfalse
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+
+	goType reflect.Type // Ofで指定した型。Documentに追加するときにスキーマに置き換える
+}
+
+// vの型のJSONのスキーマ。構造体のフィールドはjsonタグの名前で、omitemptyのないフィールドを必須とする
+func Of(v interface{}) *Schema {
+	return &Schema{goType: reflect.TypeOf(v)}
+}
+
+// 要素がitemsの配列
+func ArrayOf(items *Schema) *Schema {
+	return &Schema{Type: "array", Items: items}
+}
+
+// propertiesのすべてを必須とするオブジェクト。未知のフィールドは許さない
+func Object(properties map[string]*Schema) *Schema {
+	required := make([]string, 0, len(properties))
+	for name := range properties {
+		required = append(required, name)
+	}
+	return &Schema{Type: "object", Properties: properties, Required: sorted(required), AdditionalProperties: false}
+}
+
+func sorted(values []string) []string {
+	slices.Sort(values)
+	return values
+}
+
+// 必須でないプロパティを加えたスキーマ
+func (s *Schema) WithOptional(properties map[string]*Schema) *Schema {
+	clone := *s
+	clone.Properties = make(map[string]*Schema, len(s.Properties)+len(properties))
+	for name, property := range s.Properties {
+		clone.Properties[name] = property
+	}
+	for name, property := range properties {
+		clone.Properties[name] = property
+	}
+	return &clone
+}
+
+// 説明を付けたスキーマ
+func (s *Schema) Describe(description string) *Schema {
+	clone := *s
+	clone.Description = description
+	return &clone
+}
+
+func String() *Schema  { return &Schema{Type: "string"} }
+func Integer() *Schema { return &Schema{Type: "integer", Format: "int64"} }
+func Boolean() *Schema { return &Schema{Type: "boolean"} }
+
+// valuesのいずれかの文字列
+func Enum(values ...string) *Schema {
+	enum := make([]interface{}, len(values))
+	for i, v := range values {
+		enum[i] = v
+	}
+	return &Schema{Type: "string", Enum: enum}
+}
+
+// 独自のJSONの形式に変換する型のスキーマ
+var typeSchemas = map[reflect.Type]func() *Schema{
+	reflect.TypeOf(time.Time{}): func() *Schema { return &Schema{Type: "string", Format: "date-time"} },
+	// 未設定の場合はnullとする
+	reflect.TypeOf(entity.PurchaseDate{}): func() *Schema { return &Schema{Type: "string", Format: "date", Nullable: true} },
+	reflect.TypeOf(entity.Money{}):        func() *Schema { return &Schema{Type: "integer", Format: "int64"} },
+	reflect.TypeOf(usecase.PurchasePriceInput(0)): func() *Schema {
+		return &Schema{
+			Description: "整数、または数字の文字列（\"128000\"、\"128,000\"）",
+			OneOf:       []*Schema{{Type: "integer", Format: "int64"}, {Type: "string", Pattern: `^-?(\d+|\d{1,3}(,\d{3})+)$`}},
+		}
+	},
+}
+
+// MarshalJSONでフィールドを加える型の、加えるプロパティ（必須でない）
+var extraProperties = map[reflect.Type]map[string]*Schema{
+	reflect.TypeOf(entity.Item{}): {
+		"profit": Integer().Describe("売却による利益（selling_price - purchase_price）。売却済みの場合のみ"),
+	},
+}
+
+// components.schemasに登録する名前。型の名前と異なる名前で公開する型
+var componentNames = map[reflect.Type]string{
+	reflect.TypeOf(domainErrors.FieldError{}): "ValidationError",
+}
+
+// Goの型からスキーマを生成する。名前のある構造体はcomponents.schemasに登録して$refを返す
+func (d *Document) schemaFor(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		schema := d.schemaFor(t.Elem())
+		if schema.Ref != "" {
+			// $refと並べたnullableは無視されるため、allOfで包む
+			return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+		}
+		nullable := *schema
+		nullable.Nullable = true
+		return &nullable
+	}
+	if newSchema, ok := typeSchemas[t]; ok {
+		return newSchema()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Boolean()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Integer()
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return String()
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byteはbase64の文字列になる
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return ArrayOf(d.schemaFor(t.Elem()))
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schemaFor(t.Elem())}
+	case reflect.Interface:
+		// 任意の値
+		return &Schema{}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return d.structSchema(t)
+		}
+		return d.component(t)
+	default:
+		panic("openapi: unsupported type " + t.String())
+	}
+}
+
+// 名前のある構造体をcomponents.schemasに登録し、参照を返す
+func (d *Document) component(t reflect.Type) *Schema {
+	name, ok := d.typeNames[t]
+	if !ok {
+		name = componentNames[t]
+		if name == "" {
+			// 非公開の型（credentialsRequestなど）も公開する名前にする
+			name = strings.ToUpper(t.Name()[:1]) + t.Name()[1:]
+		}
+		// 別のパッケージの同じ名前の型は、パッケージ名を付けて区別する
+		if _, taken := d.Components.Schemas[name]; taken {
+			name = strings.ToUpper(pkgName(t)[:1]) + pkgName(t)[1:] + name
+		}
+		d.typeNames[t] = name
+		// 自身を参照する型のため、先に登録してからプロパティを生成する
+		d.Components.Schemas[name] = &Schema{}
+		*d.Components.Schemas[name] = *d.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func pkgName(t reflect.Type) string {
+	path := t.PkgPath()
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// 構造体のフィールドをプロパティにする。埋め込んだ構造体のフィールドは同じ階層に展開する
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema), AdditionalProperties: false}
+	d.addFields(schema, t)
+	for name, property := range extraProperties[t] {
+		schema.Properties[name] = property
+	}
+	slices.Sort(schema.Required)
+	return schema
+}
+
+func (d *Document) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				d.addFields(schema, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = d.schemaFor(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// ドキュメントに追加するスキーマの、Ofで指定した型をスキーマに置き換える
+func (d *Document) resolve(s *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+	if s.goType != nil {
+		resolved := d.schemaFor(s.goType)
+		if s.Description != "" {
+			resolved = resolved.Describe(s.Description)
+		}
+		return resolved
+	}
+
+	resolved := *s
+	resolved.Items = d.resolve(s.Items)
+	if s.Properties != nil {
+		resolved.Properties = make(map[string]*Schema, len(s.Properties))
+		for name, property := range s.Properties {
+			resolved.Properties[name] = d.resolve(property)
+		}
+	}
+	if additional, ok := s.AdditionalProperties.(*Schema); ok {
+		resolved.AdditionalProperties = d.resolve(additional)
+	}
+	resolved.AllOf = d.resolveAll(s.AllOf)
+	resolved.OneOf = d.resolveAll(s.OneOf)
+	return &resolved
+}
+
+func (d *Document) resolveAll(schemas []*Schema) []*Schema {
+	if schemas == nil {
+		return nil
+	}
+	resolved := make([]*Schema, len(schemas))
+	for i, s := range schemas {
+		resolved[i] = d.resolve(s)
+	}
+	return resolved
+}
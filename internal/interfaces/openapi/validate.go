@@ -0,0 +1,147 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// JSONをinterface{}に読み込んだ値（数値はjson.Number）がスキーマどおりかを確かめる。
+// 対応していないキーワード（minLengthなど）は確かめない
+func (d *Document) Validate(schema *Schema, value interface{}) error {
+	var errs []error
+	d.validate(schema, value, "$", &errs)
+	return errors.Join(errs...)
+}
+
+func (d *Document) validate(schema *Schema, value interface{}, path string, errs *[]error) {
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		referenced, ok := d.Components.Schemas[name]
+		if !ok {
+			*errs = append(*errs, fmt.Errorf("%s: unknown schema %s", path, schema.Ref))
+			return
+		}
+		d.validate(referenced, value, path, errs)
+		return
+	}
+	if value == nil {
+		if !schema.Nullable && (schema.Type != "" || len(schema.AllOf) > 0 || len(schema.OneOf) > 0) {
+			*errs = append(*errs, fmt.Errorf("%s: must not be null", path))
+		}
+		return
+	}
+
+	for _, s := range schema.AllOf {
+		d.validate(s, value, path, errs)
+	}
+	if len(schema.OneOf) > 0 {
+		matched := 0
+		for _, s := range schema.OneOf {
+			if d.Validate(s, value) == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			*errs = append(*errs, fmt.Errorf("%s: must match exactly one schema in oneOf, matched %d", path, matched))
+		}
+	}
+
+	switch schema.Type {
+	case "":
+		// 任意の値
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			*errs = append(*errs, fmt.Errorf("%s: must be an object", path))
+			return
+		}
+		d.validateObject(schema, object, path, errs)
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			*errs = append(*errs, fmt.Errorf("%s: must be an array", path))
+			return
+		}
+		for i, element := range array {
+			d.validate(schema.Items, element, fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			*errs = append(*errs, fmt.Errorf("%s: must be a string", path))
+			return
+		}
+		if err := validateString(schema, s); err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: %w", path, err))
+		}
+	case "integer":
+		n, ok := value.(json.Number)
+		if _, err := n.Int64(); !ok || err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: must be an integer", path))
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			*errs = append(*errs, fmt.Errorf("%s: must be a number", path))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			*errs = append(*errs, fmt.Errorf("%s: must be a boolean", path))
+		}
+	default:
+		*errs = append(*errs, fmt.Errorf("%s: unknown type %s", path, schema.Type))
+	}
+}
+
+func (d *Document) validateObject(schema *Schema, object map[string]interface{}, path string, errs *[]error) {
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			*errs = append(*errs, fmt.Errorf("%s: missing required property %q", path, name))
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		propertyPath := path + "." + name
+		if property, ok := schema.Properties[name]; ok {
+			d.validate(property, object[name], propertyPath, errs)
+			continue
+		}
+		switch additional := schema.AdditionalProperties.(type) {
+		case bool:
+			if !additional {
+				*errs = append(*errs, fmt.Errorf("%s: property is not documented", propertyPath))
+			}
+		case *Schema:
+			d.validate(additional, object[name], propertyPath, errs)
+		}
+	}
+}
+
+func validateString(schema *Schema, s string) error {
+	if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, interface{}(s)) {
+		return fmt.Errorf("must be one of %v", schema.Enum)
+	}
+	if schema.Pattern != "" && !regexp.MustCompile(schema.Pattern).MatchString(s) {
+		return fmt.Errorf("must match %s", schema.Pattern)
+	}
+	switch schema.Format {
+	case "date":
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			return fmt.Errorf("must be a date (YYYY-MM-DD)")
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+			return fmt.Errorf("must be a date-time (RFC 3339)")
+		}
+	}
+	return nil
+}
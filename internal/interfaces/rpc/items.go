@@ -0,0 +1,201 @@
+// REST APIと同じユースケースを呼び出すgRPCのサービスの実装。
+// 入力値の検証はユースケースで行い、ここではメッセージの変換とエラーのステータスへの変換のみを行う
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/rpc/itemspb"
+	"Aicon-assignment/internal/usecase"
+)
+
+// itemspb.ItemServiceServerの実装
+type ItemServer struct {
+	itemspb.UnimplementedItemServiceServer
+
+	itemUsecase usecase.ItemUsecase
+}
+
+func NewItemServer(itemUsecase usecase.ItemUsecase) *ItemServer {
+	return &ItemServer{itemUsecase: itemUsecase}
+}
+
+func (s *ItemServer) CreateItem(ctx context.Context, req *itemspb.CreateItemRequest) (*itemspb.Item, error) {
+	item, err := s.itemUsecase.CreateItem(ctx, usecase.CreateItemInput{
+		Name:             req.GetName(),
+		Category:         req.GetCategory(),
+		Brand:            req.GetBrand(),
+		PurchasePrice:    usecase.PurchasePriceInput(req.GetPurchasePrice()),
+		Currency:         req.GetCurrency(),
+		PurchaseDate:     req.GetPurchaseDate(),
+		SerialNumber:     req.GetSerialNumber(),
+		Condition:        req.GetCondition(),
+		Notes:            req.GetNotes(),
+		PurchaseLocation: req.GetPurchaseLocation(),
+		Tags:             req.GetTags(),
+		Force:            req.GetForce(),
+	})
+	if err != nil {
+		return nil, Error(ctx, err, "failed to create item")
+	}
+	return toItem(item), nil
+}
+
+func (s *ItemServer) GetItem(ctx context.Context, req *itemspb.GetItemRequest) (*itemspb.Item, error) {
+	item, err := s.itemUsecase.GetItemByID(ctx, req.GetId())
+	if err != nil {
+		return nil, Error(ctx, err, "failed to retrieve item")
+	}
+	return toItem(item), nil
+}
+
+func (s *ItemServer) ListItems(ctx context.Context, req *itemspb.ListItemsRequest) (*itemspb.ListItemsResponse, error) {
+	input := usecase.ListItemsInput{
+		Filter: entity.ItemFilter{
+			Category:       entity.NormalizeText(req.GetCategory()),
+			Condition:      strings.TrimSpace(req.GetCondition()),
+			Status:         strings.ToLower(strings.TrimSpace(req.GetStatus())),
+			Brand:          entity.NormalizeBrandName(req.GetBrand()),
+			Keyword:        req.GetKeyword(),
+			Tags:           entity.NormalizeTags(req.GetTags()),
+			IncludeDeleted: req.GetIncludeDeleted(),
+		},
+		Sort: entity.ItemSort{
+			Field: strings.ToLower(strings.TrimSpace(req.GetSort())),
+			Order: strings.ToLower(strings.TrimSpace(req.GetOrder())),
+		},
+		Limit:  int(req.GetLimit()),
+		Offset: int(req.GetOffset()),
+		Cursor: req.GetCursor(),
+	}
+	if location := entity.NormalizePurchaseLocation(req.GetPurchaseLocation()); location != nil {
+		input.Filter.PurchaseLocation = *location
+	}
+
+	list, err := s.itemUsecase.GetAllItems(ctx, input)
+	if err != nil {
+		return nil, Error(ctx, err, "failed to retrieve items")
+	}
+
+	res := &itemspb.ListItemsResponse{
+		Items:      make([]*itemspb.Item, len(list.Items)),
+		Total:      int32(list.Total),
+		Limit:      int32(list.Limit),
+		Offset:     int32(list.Offset),
+		NextCursor: list.NextCursor,
+	}
+	for i, item := range list.Items {
+		res.Items[i] = toItem(item)
+	}
+	return res, nil
+}
+
+func (s *ItemServer) UpdateItem(ctx context.Context, req *itemspb.UpdateItemRequest) (*itemspb.Item, error) {
+	input := usecase.UpdateItemInput{
+		Name:             req.Name,
+		Category:         req.Category,
+		Brand:            req.Brand,
+		Currency:         req.Currency,
+		PurchaseDate:     req.PurchaseDate,
+		SerialNumber:     req.SerialNumber,
+		Condition:        req.Condition,
+		Notes:            req.Notes,
+		PurchaseLocation: req.PurchaseLocation,
+	}
+	if req.PurchasePrice != nil {
+		price := usecase.PurchasePriceInput(*req.PurchasePrice)
+		input.PurchasePrice = &price
+	}
+	if req.Tags != nil {
+		tags := req.Tags.GetValues()
+		if tags == nil {
+			tags = []string{}
+		}
+		input.Tags = &tags
+	}
+	// 0はproto3で未指定と区別できないため、バージョンの指定なしとしてユースケースで検証する
+	if req.GetVersion() != 0 {
+		version := req.GetVersion()
+		input.Version = &version
+	}
+
+	item, err := s.itemUsecase.UpdateItem(ctx, req.GetId(), input)
+	if err != nil {
+		return nil, Error(ctx, err, "failed to update item")
+	}
+	return toItem(item), nil
+}
+
+func (s *ItemServer) DeleteItem(ctx context.Context, req *itemspb.DeleteItemRequest) (*itemspb.DeleteItemResponse, error) {
+	if err := s.itemUsecase.DeleteItem(ctx, req.GetId(), req.Version); err != nil {
+		return nil, Error(ctx, err, "failed to delete item")
+	}
+	return &itemspb.DeleteItemResponse{}, nil
+}
+
+func (s *ItemServer) GetSummary(ctx context.Context, req *itemspb.GetSummaryRequest) (*itemspb.Summary, error) {
+	summary, err := s.itemUsecase.GetCategorySummary(ctx)
+	if err != nil {
+		return nil, Error(ctx, err, "failed to retrieve category summary")
+	}
+
+	res := &itemspb.Summary{
+		Categories:   make([]*itemspb.CategoryStats, len(summary.Categories)),
+		Currency:     summary.Currency,
+		Total:        int32(summary.Total),
+		TotalPrice:   summary.TotalPrice,
+		AveragePrice: summary.AveragePrice,
+		Sold:         &itemspb.SoldStats{Count: int32(summary.Sold.Count), TotalPrice: summary.Sold.TotalPrice},
+	}
+	for i, stats := range summary.Categories {
+		category := &itemspb.CategoryStats{
+			Category:     stats.Category,
+			Count:        int32(stats.Count),
+			TotalPrice:   stats.TotalPrice,
+			AveragePrice: stats.AveragePrice,
+			Conditions:   make([]*itemspb.ConditionStats, len(stats.Conditions)),
+		}
+		for j, condition := range stats.Conditions {
+			category.Conditions[j] = &itemspb.ConditionStats{
+				Condition:  condition.Condition,
+				Count:      int32(condition.Count),
+				TotalPrice: condition.TotalPrice,
+			}
+		}
+		res.Categories[i] = category
+	}
+	return res, nil
+}
+
+// アイテムをメッセージに変換する。日付はREST APIと同じくYYYY-MM-DD形式の文字列で返す
+func toItem(item *entity.Item) *itemspb.Item {
+	res := &itemspb.Item{
+		Id:               item.ID,
+		UserId:           item.UserID,
+		Name:             item.Name,
+		Category:         item.Category,
+		CategorySlug:     item.CategorySlug,
+		Brand:            item.Brand,
+		PurchasePrice:    item.PurchasePrice,
+		Currency:         item.Currency,
+		PurchaseDate:     item.PurchaseDate.String(),
+		SerialNumber:     item.SerialNumber,
+		Condition:        item.Condition,
+		Notes:            item.Notes,
+		PurchaseLocation: item.PurchaseLocation,
+		Status:           item.Status,
+		SellingPrice:     item.SellingPrice,
+		Version:          item.Version,
+		CreatedAt:        timestamppb.New(item.CreatedAt),
+		UpdatedAt:        timestamppb.New(item.UpdatedAt),
+		Tags:             item.Tags,
+	}
+	if item.SoldDate != nil {
+		res.SoldDate = item.SoldDate.String()
+	}
+	return res
+}
@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/rpc/itemspb"
+	"Aicon-assignment/internal/usecase"
+	"Aicon-assignment/internal/usecase/usecasetest"
+)
+
+// itemUsecaseを呼び出すサーバーにメモリ上の接続でつないだクライアント
+func newTestClient(t *testing.T, itemUsecase usecase.ItemUsecase) itemspb.ItemServiceClient {
+	t.Helper()
+
+	ln := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	itemspb.RegisterItemServiceServer(srv, NewItemServer(itemUsecase))
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return itemspb.NewItemServiceClient(conn)
+}
+
+func TestItemServer_CRUD(t *testing.T) {
+	client := newTestClient(t, usecasetest.NewItemUsecase(entity.NewCategorySet("時計", "バッグ")))
+	ctx := context.Background()
+
+	created, err := client.CreateItem(ctx, &itemspb.CreateItemRequest{
+		Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15",
+		Condition: "中古A", Tags: []string{"限定"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "デイトナ", created.GetName())
+	assert.Equal(t, "2023-01-15", created.GetPurchaseDate())
+	assert.Equal(t, "中古A", created.GetCondition())
+	// 未設定の任意のフィールドはnil
+	assert.Nil(t, created.SerialNumber)
+	assert.Equal(t, []string{"限定"}, created.GetTags())
+
+	updated, err := client.UpdateItem(ctx, &itemspb.UpdateItemRequest{
+		Id: created.GetId(), Version: created.GetVersion(),
+		PurchasePrice: proto.Int64(1600000),
+		Tags:          &itemspb.Tags{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1600000), updated.GetPurchasePrice())
+	assert.Equal(t, created.GetVersion()+1, updated.GetVersion())
+	// 空のTagsはすべてのタグを外す
+	assert.Empty(t, updated.GetTags())
+	// 指定しないフィールドは変更しない
+	assert.Equal(t, "デイトナ", updated.GetName())
+
+	got, err := client.GetItem(ctx, &itemspb.GetItemRequest{Id: created.GetId()})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(updated, got))
+
+	list, err := client.ListItems(ctx, &itemspb.ListItemsRequest{Brand: "rolex"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), list.GetTotal())
+	require.Len(t, list.GetItems(), 1)
+	assert.Equal(t, created.GetId(), list.GetItems()[0].GetId())
+
+	summary, err := client.GetSummary(ctx, &itemspb.GetSummaryRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), summary.GetTotal())
+	require.Len(t, summary.GetCategories(), 2)
+	assert.Equal(t, "時計", summary.GetCategories()[0].GetCategory())
+	assert.Equal(t, int64(1600000), summary.GetCategories()[0].GetTotalPrice())
+
+	// 古いバージョンでは削除しない
+	_, err = client.DeleteItem(ctx, &itemspb.DeleteItemRequest{Id: created.GetId(), Version: proto.Int64(created.GetVersion())})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = client.DeleteItem(ctx, &itemspb.DeleteItemRequest{Id: created.GetId()})
+	require.NoError(t, err)
+	_, err = client.GetItem(ctx, &itemspb.GetItemRequest{Id: created.GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestItemServer_ValidationError(t *testing.T) {
+	client := newTestClient(t, usecasetest.NewItemUsecase(entity.NewCategorySet("時計")))
+
+	_, err := client.CreateItem(context.Background(), &itemspb.CreateItemRequest{Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-15"})
+	st := status.Convert(err)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	var reason string
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			violations = d.GetFieldViolations()
+		case *errdetails.ErrorInfo:
+			reason = d.GetReason()
+		}
+	}
+	assert.Equal(t, "validation_failed", reason)
+	require.NotEmpty(t, violations)
+	assert.Equal(t, "name", violations[0].GetField())
+	assert.Equal(t, "required", violations[0].GetReason())
+}
+
+func TestItemServer_UpdateRequiresVersion(t *testing.T) {
+	client := newTestClient(t, usecasetest.NewItemUsecase(entity.NewCategorySet("時計"), &entity.Item{Name: "デイトナ", Category: "時計", Brand: "ROLEX", Version: 1}))
+
+	_, err := client.UpdateItem(context.Background(), &itemspb.UpdateItemRequest{Id: 1, Name: proto.String("サブマリーナ")})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,5 @@
+// Package itemspb はitems.protoから生成したgRPCのメッセージとサービス。
+// items.protoを変更した場合は go generate で再生成する
+package itemspb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative items.proto
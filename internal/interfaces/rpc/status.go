@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/httperror"
+)
+
+// エラーの詳細（ErrorInfo）のドメイン
+const ErrorDomain = "items.v1"
+
+// エラーをgRPCのステータスに変換する。分類はREST APIと共通のhttperror.Fromの対応を使い、
+// HTTPのステータスコードをgRPCのコードに読み替える。機械可読なコードはErrorInfoのReasonで、
+// フィールドごとのバリデーションエラーはBadRequestで返す。分類できないエラーはInternalとし、内部の詳細は返さずmessageを使う
+func Error(ctx context.Context, err error, message string) error {
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, "request canceled")
+	}
+
+	httpStatus, res := httperror.From(err, message)
+	code := Code(err, httpStatus)
+	logError(ctx, code, err)
+
+	st := status.New(code, res.Error)
+	// REST APIのエラーレスポンスの追加のフィールドは、ErrorInfoのメタデータで返す
+	meta := map[string]string{}
+	if res.CurrentVersion != 0 {
+		meta["current_version"] = strconv.FormatInt(res.CurrentVersion, 10)
+	}
+	if res.ExistingItemID != 0 {
+		meta["existing_item_id"] = strconv.FormatInt(res.ExistingItemID, 10)
+	}
+	if res.CurrentStatus != "" {
+		meta["current_status"] = res.CurrentStatus
+		meta["requested_status"] = res.RequestedStatus
+	}
+	info := &errdetails.ErrorInfo{Reason: res.Code, Domain: ErrorDomain}
+	if len(meta) > 0 {
+		info.Metadata = meta
+	}
+	details := []protoadapt.MessageV1{info}
+	if len(res.Errors) > 0 {
+		details = append(details, badRequest(ctx, res.Errors))
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// エラーとhttperror.Fromが決めたHTTPのステータスコードに対応するgRPCのコード。
+// 409のうち重複登録はAlreadyExists、バージョンの競合は読み込みからやり直せるためAbortedとする
+func Code(err error, httpStatus int) codes.Code {
+	switch {
+	case errors.Is(err, domainErrors.ErrDuplicateEntry):
+		return codes.AlreadyExists
+	case errors.Is(err, domainErrors.ErrVersionConflict):
+		return codes.Aborted
+	}
+
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusRequestEntityTooLarge:
+		return codes.InvalidArgument
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict, http.StatusPreconditionFailed, http.StatusPreconditionRequired:
+		return codes.FailedPrecondition
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+// フィールドごとのバリデーションエラー。メタデータのaccept-languageで言語が指定された場合は、その言語のメッセージも付ける
+func badRequest(ctx context.Context, errs domainErrors.ValidationErrors) *errdetails.BadRequest {
+	var lang string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("accept-language"); len(values) > 0 {
+			lang = httperror.Language(values[0])
+		}
+	}
+	localized := httperror.Localize(errs, lang)
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(errs))
+	for i, fieldErr := range errs {
+		violations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       fieldErr.Field,
+			Description: fieldErr.Message,
+			Reason:      fieldErr.Code,
+		}
+		if lang != "" {
+			violations[i].LocalizedMessage = &errdetails.LocalizedMessage{Locale: lang, Message: localized[i].Message}
+		}
+	}
+	return &errdetails.BadRequest{FieldViolations: violations}
+}
+
+// 予期しないエラー（Internal）はラップされた原因をたどれるようerrorで、入力値の検証の失敗はinfoでログに出す
+func logError(ctx context.Context, code codes.Code, err error) {
+	switch {
+	case code == codes.Internal:
+		slog.ErrorContext(ctx, "リクエストの処理に失敗しました", "code", code.String(), "error", err)
+	case errors.Is(err, domainErrors.ErrValidation):
+		slog.InfoContext(ctx, "入力値の検証に失敗しました", "code", code.String(), "error", err)
+	}
+}
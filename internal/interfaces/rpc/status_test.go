@@ -0,0 +1,74 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestError_Codes(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"存在しない", domainErrors.ErrItemNotFound, codes.NotFound},
+		{"バリデーション", domainErrors.NewValidationErrors(domainErrors.Required("name")), codes.InvalidArgument},
+		{"不正なカーソル", domainErrors.ErrInvalidCursor, codes.InvalidArgument},
+		{"シリアル番号の重複", fmt.Errorf("failed to create item: %w", domainErrors.ErrDuplicateSerialNumber), codes.AlreadyExists},
+		{"アイテムの重複", domainErrors.NewDuplicateItemError(3), codes.AlreadyExists},
+		{"バージョンの競合", domainErrors.NewVersionConflictError(2), codes.Aborted},
+		{"所有状況の遷移", domainErrors.NewStatusTransitionError("sold", "owned"), codes.FailedPrecondition},
+		{"認証", domainErrors.ErrUnauthenticated, codes.Unauthenticated},
+		{"権限", domainErrors.ErrForbidden, codes.PermissionDenied},
+		{"制限時間", context.DeadlineExceeded, codes.DeadlineExceeded},
+		{"中断", context.Canceled, codes.Canceled},
+		{"分類できない", errors.New("connection refused"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, status.Code(Error(context.Background(), tt.err, "failed")))
+		})
+	}
+}
+
+func TestError_Details(t *testing.T) {
+	st := status.Convert(Error(context.Background(), domainErrors.NewVersionConflictError(5), "failed"))
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, "version_conflict", info.GetReason())
+	assert.Equal(t, ErrorDomain, info.GetDomain())
+	assert.Equal(t, map[string]string{"current_version": "5"}, info.GetMetadata())
+
+	// 分類できないエラーは内部の詳細を返さない
+	st = status.Convert(Error(context.Background(), errors.New("connection refused"), "failed to create item"))
+	assert.Equal(t, "failed to create item", st.Message())
+}
+
+func TestError_LocalizedViolations(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "ja"))
+	st := status.Convert(Error(ctx, domainErrors.NewValidationErrors(domainErrors.Required("name")), "failed"))
+
+	var badRequest *errdetails.BadRequest
+	for _, detail := range st.Details() {
+		if d, ok := detail.(*errdetails.BadRequest); ok {
+			badRequest = d
+		}
+	}
+	require.NotNil(t, badRequest)
+	violation := badRequest.GetFieldViolations()[0]
+	assert.Equal(t, "name is required", violation.GetDescription())
+	assert.Equal(t, "ja", violation.GetLocalizedMessage().GetLocale())
+	assert.NotEqual(t, violation.GetDescription(), violation.GetLocalizedMessage().GetMessage())
+}
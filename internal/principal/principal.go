@@ -0,0 +1,63 @@
+// 認証したクライアントをctxで受け渡す。HTTPに依存しないため、ユースケースやリポジトリからも参照できる
+package principal
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 認証したクライアント。APIキーとユーザーのいずれか一方で認証する。サーバー内のバックグラウンドの処理はSystemを使う
+type Principal struct {
+	APIKeyID    int64           // 認証に使ったAPIキーのID
+	APIKeyLabel string          // 認証に使ったAPIキーのラベル。ログと監査に記録する
+	UserID      int64           // アクセストークンで認証したユーザーのID。APIキーで認証した場合は0
+	Role        entity.UserRole // 認証したユーザーかAPIキーの権限
+	System      bool            // サーバー内のバックグラウンドの処理かどうか
+}
+
+// 期限切れのデータの削除やWebhookの送信など、リクエストによらずサーバー内で実行する処理の呼び出し元。管理者として扱う
+var System = Principal{Role: entity.UserRoleAdmin, System: true}
+
+// 管理者として扱うかどうか。APIキーもユーザーと同じく、管理者の権限を与えたもののみ管理者として扱う
+func (p Principal) IsAdmin() bool {
+	return p.Role == entity.UserRoleAdmin
+}
+
+type contextKey struct{}
+
+// pを認証したクライアントとして持つctxを返す
+func NewContext(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// ctxの認証したクライアント。認証していない場合はfalseを返す
+func From(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(Principal)
+	return p, ok
+}
+
+// ctxの呼び出し元が管理者用の操作をできるかどうか。認証していない場合（認証を無効にした場合）は管理者として扱わない
+func IsAdmin(ctx context.Context) bool {
+	p, ok := From(ctx)
+	return ok && p.IsAdmin()
+}
+
+// アイテムの参照・変更を限定する所有者のID。管理者でないユーザー・APIキーとして認証した場合のみtrueを返し、
+// APIキーは登録するアイテムと同じく既定のユーザーのアイテムに限定する。
+// 管理者・バックグラウンドの処理と、認証していない場合（認証を無効にした場合）はすべてのユーザーのアイテムを扱う
+func OwnerScope(ctx context.Context) (int64, bool) {
+	p, ok := From(ctx)
+	if !ok || p.IsAdmin() {
+		return 0, false
+	}
+	return OwnerID(ctx), true
+}
+
+// 登録するアイテムの所有者のID。ユーザーとして認証していない場合は既定のユーザー。管理者が登録したアイテムは管理者のものになる
+func OwnerID(ctx context.Context) int64 {
+	if p, ok := From(ctx); ok && p.UserID != 0 {
+		return p.UserID
+	}
+	return entity.DefaultUserID
+}
@@ -0,0 +1,72 @@
+package principal
+
+import (
+	"context"
+	"testing"
+
+	"Aicon-assignment/internal/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrom(t *testing.T) {
+	_, ok := From(context.Background())
+	assert.False(t, ok)
+
+	p, ok := From(NewContext(context.Background(), Principal{APIKeyID: 1, APIKeyLabel: "batch"}))
+	assert.True(t, ok)
+	assert.Equal(t, Principal{APIKeyID: 1, APIKeyLabel: "batch"}, p)
+}
+
+func TestOwnerScope(t *testing.T) {
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantScope bool
+		wantOwner int64
+	}{
+		{name: "認証なし", ctx: context.Background(), wantOwner: entity.DefaultUserID},
+		// 一般の権限のAPIキーは、登録するアイテムと同じく既定のユーザーのアイテムに限定する
+		{name: "APIキー", ctx: NewContext(context.Background(), Principal{APIKeyID: 1, APIKeyLabel: "batch", Role: entity.UserRoleUser}), wantScope: true, wantOwner: entity.DefaultUserID},
+		{name: "管理者のAPIキー", ctx: NewContext(context.Background(), Principal{APIKeyID: 1, APIKeyLabel: "ops", Role: entity.UserRoleAdmin}), wantOwner: entity.DefaultUserID},
+		{name: "バックグラウンドの処理", ctx: NewContext(context.Background(), System), wantOwner: entity.DefaultUserID},
+		{name: "ユーザー", ctx: NewContext(context.Background(), Principal{UserID: 7, Role: entity.UserRoleUser}), wantScope: true, wantOwner: 7},
+		// 管理者はすべてのユーザーのアイテムを扱い、登録したアイテムは自分のものになる
+		{name: "管理者", ctx: NewContext(context.Background(), Principal{UserID: 7, Role: entity.UserRoleAdmin}), wantOwner: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, ok := OwnerScope(tt.ctx)
+			assert.Equal(t, tt.wantScope, ok)
+			if ok {
+				assert.Equal(t, tt.wantOwner, userID)
+			}
+			assert.Equal(t, tt.wantOwner, OwnerID(tt.ctx))
+		})
+	}
+}
+
+func TestIsAdmin(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		// 認証を無効にした場合は呼び出し元を区別できないため、管理者として扱わない
+		{name: "認証なし", ctx: context.Background(), want: false},
+		{name: "APIキー", ctx: NewContext(context.Background(), Principal{APIKeyID: 1, APIKeyLabel: "batch", Role: entity.UserRoleUser}), want: false},
+		{name: "権限のないAPIキー", ctx: NewContext(context.Background(), Principal{APIKeyID: 1, APIKeyLabel: "batch"}), want: false},
+		{name: "管理者のAPIキー", ctx: NewContext(context.Background(), Principal{APIKeyID: 1, APIKeyLabel: "ops", Role: entity.UserRoleAdmin}), want: true},
+		{name: "バックグラウンドの処理", ctx: NewContext(context.Background(), System), want: true},
+		{name: "ユーザー", ctx: NewContext(context.Background(), Principal{UserID: 7, Role: entity.UserRoleUser}), want: false},
+		{name: "権限のないトークン", ctx: NewContext(context.Background(), Principal{UserID: 7}), want: false},
+		{name: "管理者", ctx: NewContext(context.Background(), Principal{UserID: 7, Role: entity.UserRoleAdmin}), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsAdmin(tt.ctx))
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// リクエストを識別するIDをctxで受け渡す。HTTPに依存しないため、ユースケースやリポジトリからも参照できる
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// リクエストIDを受け取り、返し、外部への呼び出しに転送するヘッダー
+const Header = "X-Request-ID"
+
+// クライアントから受け取るリクエストIDの最大文字数。超える場合は新しく生成する
+const MaxLength = 128
+
+type contextKey struct{}
+
+// 新しいリクエストID（UUIDv4）
+func New() string {
+	return uuid.NewString()
+}
+
+// idをリクエストIDとして持つctxを返す
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// ctxのリクエストID。リクエストの処理中でない場合は空文字列
+func From(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// クライアントから受け取ったidをそのまま使えるかどうか。
+// ログやヘッダーに書き込むため、長さを制限し英数字と「-_.:」のみを許可する
+func Valid(id string) bool {
+	if id == "" || len(id) > MaxLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
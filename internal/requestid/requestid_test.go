@@ -0,0 +1,47 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	id := New()
+	parsed, err := uuid.Parse(id)
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(4), parsed.Version())
+	assert.True(t, Valid(id))
+}
+
+func TestFrom(t *testing.T) {
+	assert.Empty(t, From(context.Background()))
+	assert.Equal(t, "abc", From(NewContext(context.Background(), "abc")))
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want bool
+	}{
+		{"正常系: UUID", "3f2504e0-4f89-41d3-9a0c-0305e82c3301", true},
+		{"正常系: 記号を含むID", "lb:req_01.A", true},
+		{"正常系: 上限の長さ", strings.Repeat("a", MaxLength), true},
+		{"異常系: 空", "", false},
+		{"異常系: 上限を超える長さ", strings.Repeat("a", MaxLength+1), false},
+		{"異常系: 改行を含む", "abc\nlevel=ERROR", false},
+		{"異常系: 空白を含む", "abc def", false},
+		{"異常系: ASCII以外を含む", "リクエスト", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Valid(tt.id))
+		})
+	}
+}
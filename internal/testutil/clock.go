@@ -0,0 +1,37 @@
+// テストで共通して使う補助関数と型
+package testutil
+
+import (
+	"sync"
+	"time"
+)
+
+// 常に設定した時刻を返すClock。entity.SetClockに渡して使う
+type FixedClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func NewFixedClock(now time.Time) *FixedClock {
+	return &FixedClock{now: now}
+}
+
+func (c *FixedClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// 返す時刻を変更する
+func (c *FixedClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// 返す時刻をdだけ進める
+func (c *FixedClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type APIKeyUsecase interface {
+	// roleの権限を持つAPIキーを発行し、保存したキーとキーそのものを返す。キーそのものは保存しないため、この戻り値でしか得られない
+	CreateAPIKey(ctx context.Context, label string, role entity.UserRole) (*entity.APIKey, string, error)
+	ListAPIKeys(ctx context.Context) ([]*entity.APIKey, error)
+	// APIキーを失効させる。失効済みの場合はそのまま返す
+	RevokeAPIKey(ctx context.Context, id int64) (*entity.APIKey, error)
+	// APIキーの権限を変更する
+	SetAPIKeyRole(ctx context.Context, id int64, role entity.UserRole) (*entity.APIKey, error)
+	// リクエストで受け取ったキーを照合する。未登録・失効済みの場合はErrUnauthenticatedを返す
+	AuthenticateAPIKey(ctx context.Context, key string) (*entity.APIKey, error)
+}
+
+type apiKeyUsecase struct {
+	apiKeyRepo APIKeyRepository
+}
+
+func NewAPIKeyUsecase(apiKeyRepo APIKeyRepository) APIKeyUsecase {
+	return &apiKeyUsecase{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+func (u *apiKeyUsecase) CreateAPIKey(ctx context.Context, label string, role entity.UserRole) (*entity.APIKey, string, error) {
+	apiKey, key, err := entity.NewAPIKey(label, role)
+	if err != nil {
+		return nil, "", err
+	}
+
+	created, err := u.apiKeyRepo.Create(ctx, apiKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return created, key, nil
+}
+
+func (u *apiKeyUsecase) ListAPIKeys(ctx context.Context) ([]*entity.APIKey, error) {
+	keys, err := u.apiKeyRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (u *apiKeyUsecase) RevokeAPIKey(ctx context.Context, id int64) (*entity.APIKey, error) {
+	revoked, err := u.apiKeyRepo.Revoke(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return revoked, nil
+}
+
+func (u *apiKeyUsecase) SetAPIKeyRole(ctx context.Context, id int64, role entity.UserRole) (*entity.APIKey, error) {
+	if !role.Valid() {
+		return nil, fmt.Errorf("%w: unknown role %q", domainErrors.ErrInvalidInput, role)
+	}
+
+	updated, err := u.apiKeyRepo.UpdateRole(ctx, id, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update api key role: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (u *apiKeyUsecase) AuthenticateAPIKey(ctx context.Context, key string) (*entity.APIKey, error) {
+	if key == "" {
+		return nil, domainErrors.ErrUnauthenticated
+	}
+
+	keyHash := entity.HashAPIKey(key)
+	apiKey, err := u.apiKeyRepo.FindByHash(ctx, keyHash)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrAPIKeyNotFound) {
+			return nil, domainErrors.ErrUnauthenticated
+		}
+		return nil, fmt.Errorf("failed to authenticate api key: %w", err)
+	}
+
+	// 保存先の検索の実装によらず照合にかかる時間が一致した文字数に依存しないよう、ハッシュ値を定数時間で比較する
+	if subtle.ConstantTimeCompare([]byte(keyHash), []byte(apiKey.KeyHash)) != 1 || apiKey.Revoked() {
+		return nil, domainErrors.ErrUnauthenticated
+	}
+
+	return apiKey, nil
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ハッシュ値で保存するAPIKeyRepository
+type stubAPIKeyRepository struct {
+	APIKeyRepository
+	keys []*entity.APIKey
+}
+
+func (r *stubAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	for _, key := range r.keys {
+		if key.KeyHash == keyHash {
+			return key, nil
+		}
+	}
+	return nil, domainErrors.ErrAPIKeyNotFound
+}
+
+func (r *stubAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error) {
+	key.ID = int64(len(r.keys) + 1)
+	r.keys = append(r.keys, key)
+	return key, nil
+}
+
+func (r *stubAPIKeyRepository) UpdateRole(ctx context.Context, id int64, role entity.UserRole) (*entity.APIKey, error) {
+	for _, key := range r.keys {
+		if key.ID == id {
+			key.Role = role
+			return key, nil
+		}
+	}
+	return nil, domainErrors.ErrAPIKeyNotFound
+}
+
+func TestAPIKeyUsecase_AuthenticateAPIKey(t *testing.T) {
+	ctx := context.Background()
+	repo := &stubAPIKeyRepository{}
+	u := NewAPIKeyUsecase(repo)
+
+	created, key, err := u.CreateAPIKey(ctx, "batch", entity.UserRoleUser)
+	require.NoError(t, err)
+	// キーそのものは保存しない
+	assert.NotEqual(t, key, repo.keys[0].KeyHash)
+
+	authenticated, err := u.AuthenticateAPIKey(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, authenticated.ID)
+	assert.Equal(t, "batch", authenticated.Label)
+	assert.Equal(t, entity.UserRoleUser, authenticated.Role)
+
+	_, err = u.AuthenticateAPIKey(ctx, key+"x")
+	assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+	_, err = u.AuthenticateAPIKey(ctx, "")
+	assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+
+	// 失効したキーは認証しない
+	revokedAt := time.Now()
+	repo.keys[0].RevokedAt = &revokedAt
+	_, err = u.AuthenticateAPIKey(ctx, key)
+	assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+}
+
+func TestAPIKeyUsecase_CreateAPIKey_InvalidLabel(t *testing.T) {
+	repo := &stubAPIKeyRepository{}
+
+	_, _, err := NewAPIKeyUsecase(repo).CreateAPIKey(context.Background(), " ", entity.UserRoleUser)
+
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	assert.Empty(t, repo.keys)
+}
+
+func TestAPIKeyUsecase_SetAPIKeyRole(t *testing.T) {
+	ctx := context.Background()
+	repo := &stubAPIKeyRepository{}
+	u := NewAPIKeyUsecase(repo)
+	created, _, err := u.CreateAPIKey(ctx, "batch", entity.UserRoleUser)
+	require.NoError(t, err)
+
+	updated, err := u.SetAPIKeyRole(ctx, created.ID, entity.UserRoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserRoleAdmin, updated.Role)
+
+	_, err = u.SetAPIKeyRole(ctx, created.ID, "owner")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	_, err = u.SetAPIKeyRole(ctx, 99, entity.UserRoleUser)
+	assert.ErrorIs(t, err, domainErrors.ErrAPIKeyNotFound)
+}
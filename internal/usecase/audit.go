@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"Aicon-assignment/internal/clientip"
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+// AuditObserver counts the audit log entries that could not be recorded
+type AuditObserver interface {
+	// ObserveAuditFailure records that an entry of the audit log could not be recorded
+	ObserveAuditFailure()
+}
+
+type AuditUsecase interface {
+	// 絞り込み条件に一致する監査ログを新しい順に取得する
+	ListAuditLogs(ctx context.Context, filter entity.AuditLogFilter, limit, offset int) (*AuditLogList, error)
+}
+
+type AuditLogList struct {
+	AuditLogs []*entity.AuditLog `json:"audit_logs"`
+	Total     int                `json:"total"`
+	Limit     int                `json:"limit"`
+	Offset    int                `json:"offset"`
+}
+
+type auditUsecase struct {
+	auditRepo AuditLogRepository
+}
+
+func NewAuditUsecase(auditRepo AuditLogRepository) AuditUsecase {
+	return &auditUsecase{
+		auditRepo: auditRepo,
+	}
+}
+
+func (u *auditUsecase) ListAuditLogs(ctx context.Context, filter entity.AuditLogFilter, limit, offset int) (*AuditLogList, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	page, err := normalizePagination(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := u.auditRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	logs, err := u.auditRepo.FindAll(ctx, filter, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve audit logs: %w", err)
+	}
+
+	return &AuditLogList{
+		AuditLogs: logs,
+		Total:     total,
+		Limit:     page.Limit,
+		Offset:    page.Offset,
+	}, nil
+}
+
+// イベントの種類ごとの監査ログの操作。登録はwithAuditActionで上書きできる
+var auditActions = map[entity.EventType]string{
+	entity.EventItemCreated:     entity.AuditActionCreate,
+	entity.EventItemUpdated:     entity.AuditActionUpdate,
+	entity.EventItemDeleted:     entity.AuditActionDelete,
+	entity.EventItemRestored:    entity.AuditActionRestore,
+	entity.EventItemHardDeleted: entity.AuditActionHardDelete,
+}
+
+// コンテキストに保持する、登録のイベントを監査ログに記録する操作のキー
+type auditActionKey struct{}
+
+// ctxで登録したアイテムを、登録ではなくactionとして監査ログに記録するctxを返す
+func withAuditAction(ctx context.Context, action string) context.Context {
+	return context.WithValue(ctx, auditActionKey{}, action)
+}
+
+type auditHandler struct {
+	auditRepo AuditLogRepository
+	observer  AuditObserver
+}
+
+// 操作者・送信元とともにアイテムの変更を監査ログに記録するEventHandler。
+// 取り消した変更を記録しないよう、AfterCommitで包んで登録する。
+// 記録に失敗してもリクエストは失敗させず、ログに出力してobserverに数える
+func NewAuditHandler(auditRepo AuditLogRepository, observer AuditObserver) EventHandler {
+	return &auditHandler{auditRepo: auditRepo, observer: observer}
+}
+
+func (h *auditHandler) HandleEvent(ctx context.Context, event entity.Event) error {
+	action, ok := auditActions[event.Type]
+	if !ok {
+		return nil
+	}
+	if override, ok := ctx.Value(auditActionKey{}).(string); ok && event.Type == entity.EventItemCreated {
+		action = override
+	}
+
+	log := &entity.AuditLog{
+		Actor:     auditActor(ctx),
+		Action:    action,
+		ItemID:    event.ItemID,
+		SourceIP:  clientip.From(ctx),
+		CreatedAt: event.OccurredAt,
+	}
+	// 変更はコミット済みのため、レスポンスを返した後にクライアントが切断しても記録する
+	if err := h.auditRepo.Create(context.WithoutCancel(ctx), log); err != nil {
+		slog.ErrorContext(ctx, "監査ログの記録に失敗しました", "action", action, "item_id", event.ItemID, "actor", log.Actor, "error", err)
+		h.observer.ObserveAuditFailure()
+	}
+	return nil
+}
+
+// ctxの呼び出し元の監査ログでの表記
+func auditActor(ctx context.Context) string {
+	p, ok := principal.From(ctx)
+	switch {
+	case !ok:
+		return entity.AuditActorAnonymous
+	case p.System:
+		return entity.AuditActorSystem
+	case p.UserID != 0:
+		return entity.AuditActorUser(p.UserID)
+	default:
+		return entity.AuditActorAPIKey(p.APIKeyLabel)
+	}
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/clientip"
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+// 記録した監査ログを保持し、errを返すAuditLogRepository
+type stubAuditLogRepository struct {
+	logs []*entity.AuditLog
+	err  error
+
+	filter entity.AuditLogFilter
+	page   entity.Pagination
+}
+
+func (r *stubAuditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *stubAuditLogRepository) FindAll(ctx context.Context, filter entity.AuditLogFilter, page entity.Pagination) ([]*entity.AuditLog, error) {
+	r.filter, r.page = filter, page
+	return r.logs, r.err
+}
+
+func (r *stubAuditLogRepository) Count(ctx context.Context, filter entity.AuditLogFilter) (int, error) {
+	return len(r.logs), r.err
+}
+
+type countingAuditObserver struct {
+	failures int
+}
+
+func (o *countingAuditObserver) ObserveAuditFailure() { o.failures++ }
+
+// 発行したイベントをhandlerにそのまま渡すEventPublisher
+type handlerPublisher struct {
+	handler EventHandler
+}
+
+func (p handlerPublisher) Publish(ctx context.Context, event entity.Event) error {
+	return p.handler.HandleEvent(ctx, event)
+}
+
+func TestAuditUsecase_ListAuditLogs(t *testing.T) {
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: 既定の件数で取得する", func(t *testing.T) {
+		repo := &stubAuditLogRepository{logs: []*entity.AuditLog{{ID: 1}}}
+
+		result, err := NewAuditUsecase(repo).ListAuditLogs(context.Background(), entity.AuditLogFilter{Action: entity.AuditActionImport}, 0, 5)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Total)
+		assert.Equal(t, DefaultListLimit, result.Limit)
+		assert.Equal(t, 5, result.Offset)
+		assert.Equal(t, entity.Pagination{Limit: DefaultListLimit, Offset: 5}, repo.page)
+		assert.Equal(t, entity.AuditActionImport, repo.filter.Action)
+	})
+
+	t.Run("異常系: 不正な条件", func(t *testing.T) {
+		for _, filter := range []entity.AuditLogFilter{{Action: "read"}, {From: &from, To: &to}} {
+			_, err := NewAuditUsecase(&stubAuditLogRepository{}).ListAuditLogs(context.Background(), filter, 0, 0)
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		}
+	})
+}
+
+func TestAuditHandler_HandleEvent(t *testing.T) {
+	event := entity.NewItemEvent(entity.EventItemUpdated, 7, entity.ItemChange{})
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		expectedActor string
+	}{
+		{name: "正常系: ユーザー", ctx: principal.NewContext(context.Background(), principal.Principal{UserID: 2, Role: entity.UserRoleUser}), expectedActor: "user:2"},
+		{name: "正常系: APIキー", ctx: principal.NewContext(context.Background(), principal.Principal{APIKeyID: 1, APIKeyLabel: "ci"}), expectedActor: "api_key:ci"},
+		{name: "正常系: 認証なし", ctx: context.Background(), expectedActor: entity.AuditActorAnonymous},
+		{name: "正常系: バックグラウンドの処理", ctx: principal.NewContext(context.Background(), principal.System), expectedActor: entity.AuditActorSystem},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubAuditLogRepository{}
+
+			err := NewAuditHandler(repo, &countingAuditObserver{}).HandleEvent(clientip.NewContext(tt.ctx, "203.0.113.7"), event)
+
+			require.NoError(t, err)
+			require.Len(t, repo.logs, 1)
+			assert.Equal(t, &entity.AuditLog{Actor: tt.expectedActor, Action: entity.AuditActionUpdate, ItemID: 7, SourceIP: "203.0.113.7", CreatedAt: event.OccurredAt}, repo.logs[0])
+		})
+	}
+
+	t.Run("異常系: 記録に失敗してもエラーを返さずに数える", func(t *testing.T) {
+		observer := &countingAuditObserver{}
+
+		err := NewAuditHandler(&stubAuditLogRepository{err: domainErrors.ErrDatabaseError}, observer).HandleEvent(context.Background(), event)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, observer.failures)
+	})
+}
+
+func TestItemUsecase_Audit(t *testing.T) {
+	t.Run("正常系: インポートで登録したアイテムはimportとして記録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return([]int64{1, 2}, nil)
+		repo := &stubAuditLogRepository{}
+		publisher := handlerPublisher{handler: AfterCommit(NewAuditHandler(repo, &countingAuditObserver{}))}
+		usecase := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), &stubTransactor{}, publisher)
+
+		csv := importHeader +
+			"ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n" +
+			"エルメス バーキン,バッグ,HERMÈS,2000000,2023-02-20\n"
+		_, err := usecase.ImportItems(context.Background(), strings.NewReader(csv), ImportOptions{})
+
+		require.NoError(t, err)
+		require.Len(t, repo.logs, 2)
+		for _, log := range repo.logs {
+			assert.Equal(t, entity.AuditActionImport, log.Action)
+		}
+	})
+
+	t.Run("正常系: 取り消した変更は記録しない", func(t *testing.T) {
+		before := newEventTestItem(1)
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(before, nil)
+		mockRepo.On("Delete", mock.Anything, int64(1)).Return(&entity.ItemChange{Before: before, After: before}, nil)
+		repo := &stubAuditLogRepository{}
+		// 監査ログの後に登録したハンドラーのエラーで、トランザクションを取り消す
+		publisher := multiPublisher{
+			handlerPublisher{handler: AfterCommit(NewAuditHandler(repo, &countingAuditObserver{}))},
+			&recordingHandler{err: domainErrors.ErrDatabaseError},
+		}
+		usecase := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), &stubTransactor{}, publisher)
+
+		err := usecase.DeleteItem(context.Background(), 1, nil)
+
+		require.Error(t, err)
+		assert.Empty(t, repo.logs)
+	})
+}
+
+// 順にPublishし、最初のエラーを返すEventPublisher
+type multiPublisher []EventPublisher
+
+func (m multiPublisher) Publish(ctx context.Context, event entity.Event) error {
+	for _, p := range m {
+		if err := p.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// アクセストークンに含める内容
+type AccessTokenClaims struct {
+	UserID    int64
+	Role      entity.UserRole // 発行時のユーザーの権限。変更はトークンを再発行するまで反映されない
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// アクセストークンの署名と検証。署名の方式（JWTなど）はインフラストラクチャ層で実装する
+type AccessTokenSigner interface {
+	Sign(claims AccessTokenClaims) (string, error)
+	// 署名と有効期限を検証する。検証できない場合はErrUnauthenticatedを返す
+	Verify(token string) (*AccessTokenClaims, error)
+}
+
+// ログインや再発行で発行したトークンの組。リフレッシュトークンはハッシュ値のみを保存するため、この戻り値でしか得られない
+type AuthTokens struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+type AuthConfig struct {
+	AccessTokenTTL  time.Duration // アクセストークンの有効期間
+	RefreshTokenTTL time.Duration // リフレッシュトークンの有効期間
+}
+
+type AuthUsecase interface {
+	// ユーザーを登録し、そのユーザーとしてログインしたトークンを返す
+	Register(ctx context.Context, email, password string) (*entity.User, *AuthTokens, error)
+	// メールアドレスとパスワードを照合してトークンを発行する。一致しない場合はErrUnauthenticatedを返す
+	Login(ctx context.Context, email, password string) (*AuthTokens, error)
+	// リフレッシュトークンを失効させ、新しいトークンの組を発行する。未登録・失効済み・期限切れの場合はErrUnauthenticatedを返す
+	Refresh(ctx context.Context, refreshToken string) (*AuthTokens, error)
+	// リクエストで受け取ったアクセストークンを検証する。検証できない場合はErrUnauthenticatedを返す
+	AuthenticateAccessToken(ctx context.Context, token string) (*AccessTokenClaims, error)
+	ListUsers(ctx context.Context) ([]*entity.User, error)
+	// メールアドレスのユーザーのパスワードを設定する。移行時に作成した既定のユーザーはこれでログインできるようになる
+	SetPassword(ctx context.Context, email, password string) (*entity.User, error)
+	// メールアドレスのユーザーの権限を変更する。発行済みのアクセストークンには、期限が切れて再発行するまで反映されない
+	SetRole(ctx context.Context, email string, role entity.UserRole) (*entity.User, error)
+}
+
+type authUsecase struct {
+	userRepo UserRepository
+	signer   AccessTokenSigner
+	config   AuthConfig
+}
+
+func NewAuthUsecase(userRepo UserRepository, signer AccessTokenSigner, config AuthConfig) AuthUsecase {
+	return &authUsecase{
+		userRepo: userRepo,
+		signer:   signer,
+		config:   config,
+	}
+}
+
+// 未登録のメールアドレスでも登録済みの場合と同じだけ照合に時間をかけ、応答時間から登録の有無がわからないようにする
+var dummyUser = sync.OnceValue(func() *entity.User {
+	user := &entity.User{}
+	if err := user.SetPassword("dummy password"); err != nil {
+		panic(err)
+	}
+	return user
+})
+
+func (u *authUsecase) Register(ctx context.Context, email, password string) (*entity.User, *AuthTokens, error) {
+	user, err := entity.NewUser(email, password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created, err := u.userRepo.Create(ctx, user)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	tokens, err := u.issueTokens(ctx, created)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return created, tokens, nil
+}
+
+func (u *authUsecase) Login(ctx context.Context, email, password string) (*AuthTokens, error) {
+	user, err := u.userRepo.FindByEmail(ctx, entity.NormalizeEmail(email))
+	if err != nil {
+		if !errors.Is(err, domainErrors.ErrUserNotFound) {
+			return nil, fmt.Errorf("failed to retrieve user: %w", err)
+		}
+		dummyUser().CheckPassword(password)
+		return nil, domainErrors.ErrUnauthenticated
+	}
+
+	if !user.CheckPassword(password) {
+		return nil, domainErrors.ErrUnauthenticated
+	}
+
+	return u.issueTokens(ctx, user)
+}
+
+func (u *authUsecase) Refresh(ctx context.Context, refreshToken string) (*AuthTokens, error) {
+	if refreshToken == "" {
+		return nil, domainErrors.ErrUnauthenticated
+	}
+
+	token, err := u.userRepo.FindRefreshToken(ctx, entity.HashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrRefreshTokenNotFound) {
+			return nil, domainErrors.ErrUnauthenticated
+		}
+		return nil, fmt.Errorf("failed to retrieve refresh token: %w", err)
+	}
+	if !token.Usable(entity.Now()) {
+		return nil, domainErrors.ErrUnauthenticated
+	}
+
+	// 同じトークンで同時に再発行した場合は、先に失効させた一方のみに発行する
+	revoked, err := u.userRepo.RevokeRefreshToken(ctx, token.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	if !revoked {
+		return nil, domainErrors.ErrUnauthenticated
+	}
+
+	// 権限の変更を反映するため、発行時のトークンではなく現在のユーザーの権限で発行する
+	user, err := u.userRepo.FindByID(ctx, token.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	return u.issueTokens(ctx, user)
+}
+
+func (u *authUsecase) AuthenticateAccessToken(ctx context.Context, token string) (*AccessTokenClaims, error) {
+	if token == "" {
+		return nil, domainErrors.ErrUnauthenticated
+	}
+
+	return u.signer.Verify(token)
+}
+
+func (u *authUsecase) ListUsers(ctx context.Context) ([]*entity.User, error) {
+	users, err := u.userRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (u *authUsecase) SetPassword(ctx context.Context, email, password string) (*entity.User, error) {
+	user, err := u.userRepo.FindByEmail(ctx, entity.NormalizeEmail(email))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	if err := user.SetPassword(password); err != nil {
+		return nil, err
+	}
+
+	updated, err := u.userRepo.UpdatePassword(ctx, user.ID, user.PasswordHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (u *authUsecase) SetRole(ctx context.Context, email string, role entity.UserRole) (*entity.User, error) {
+	if !role.Valid() {
+		return nil, fmt.Errorf("%w: unknown role %q", domainErrors.ErrInvalidInput, role)
+	}
+
+	user, err := u.userRepo.FindByEmail(ctx, entity.NormalizeEmail(email))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	updated, err := u.userRepo.UpdateRole(ctx, user.ID, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	return updated, nil
+}
+
+// ユーザーのアクセストークンとリフレッシュトークンを発行する
+func (u *authUsecase) issueTokens(ctx context.Context, user *entity.User) (*AuthTokens, error) {
+	now := entity.Now()
+	claims := AccessTokenClaims{UserID: user.ID, Role: user.Role, IssuedAt: now, ExpiresAt: now.Add(u.config.AccessTokenTTL)}
+	accessToken, err := u.signer.Sign(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, token, err := entity.NewRefreshToken(user.ID, u.config.RefreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.userRepo.CreateRefreshToken(ctx, refreshToken); err != nil {
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return &AuthTokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  claims.ExpiresAt,
+		RefreshToken:          token,
+		RefreshTokenExpiresAt: refreshToken.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/testutil"
+)
+
+// ユーザーとリフレッシュトークンをスライスに保存するUserRepository
+type stubUserRepository struct {
+	UserRepository
+	users  []*entity.User
+	tokens []*entity.RefreshToken
+}
+
+func (r *stubUserRepository) FindByID(ctx context.Context, id int64) (*entity.User, error) {
+	for _, user := range r.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, domainErrors.ErrUserNotFound
+}
+
+func (r *stubUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, domainErrors.ErrUserNotFound
+}
+
+func (r *stubUserRepository) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
+	if _, err := r.FindByEmail(ctx, user.Email); err == nil {
+		return nil, domainErrors.ErrDuplicateEmail
+	}
+	user.ID = int64(len(r.users) + 1)
+	r.users = append(r.users, user)
+	return user, nil
+}
+
+func (r *stubUserRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) (*entity.User, error) {
+	for _, user := range r.users {
+		if user.ID == id {
+			user.PasswordHash = passwordHash
+			return user, nil
+		}
+	}
+	return nil, domainErrors.ErrUserNotFound
+}
+
+func (r *stubUserRepository) UpdateRole(ctx context.Context, id int64, role entity.UserRole) (*entity.User, error) {
+	user, err := r.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	user.Role = role
+	return user, nil
+}
+
+func (r *stubUserRepository) CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error) {
+	token.ID = int64(len(r.tokens) + 1)
+	r.tokens = append(r.tokens, token)
+	return token, nil
+}
+
+func (r *stubUserRepository) FindRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			clone := *token
+			return &clone, nil
+		}
+	}
+	return nil, domainErrors.ErrRefreshTokenNotFound
+}
+
+func (r *stubUserRepository) RevokeRefreshToken(ctx context.Context, id int64) (bool, error) {
+	for _, token := range r.tokens {
+		if token.ID == id && token.RevokedAt == nil {
+			now := entity.Now()
+			token.RevokedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// 「<権限>:<ユーザーのID>」をトークンにするAccessTokenSigner
+type stubSigner struct{}
+
+func (stubSigner) Sign(claims AccessTokenClaims) (string, error) {
+	return string(claims.Role) + ":" + strconv.FormatInt(claims.UserID, 10), nil
+}
+
+func (stubSigner) Verify(token string) (*AccessTokenClaims, error) {
+	role, idStr, _ := strings.Cut(token, ":")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return nil, domainErrors.ErrUnauthenticated
+	}
+	return &AccessTokenClaims{UserID: id, Role: entity.UserRole(role)}, nil
+}
+
+func newTestAuthUsecase() (AuthUsecase, *stubUserRepository) {
+	repo := &stubUserRepository{}
+	return NewAuthUsecase(repo, stubSigner{}, AuthConfig{AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour}), repo
+}
+
+func TestAuthUsecase_RegisterAndLogin(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t.Cleanup(entity.SetClock(testutil.NewFixedClock(now)))
+	ctx := context.Background()
+	u, _ := newTestAuthUsecase()
+
+	user, tokens, err := u.Register(ctx, "Alice@example.com", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", user.Email)
+	assert.Equal(t, "user:1", tokens.AccessToken)
+	assert.Equal(t, now.Add(time.Minute), tokens.AccessTokenExpiresAt)
+	assert.Equal(t, now.Add(time.Hour), tokens.RefreshTokenExpiresAt)
+
+	_, _, err = u.Register(ctx, "alice@example.com", "password")
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateEmail)
+	_, _, err = u.Register(ctx, "bob@example.com", "short")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+
+	// メールアドレスの大文字・小文字は区別しない
+	tokens, err = u.Login(ctx, "ALICE@example.com", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "user:1", tokens.AccessToken)
+
+	// パスワードの誤りと未登録のメールアドレスは区別しない
+	_, err = u.Login(ctx, "alice@example.com", "wrong password")
+	assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+	_, err = u.Login(ctx, "bob@example.com", "password")
+	assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+
+	claims, err := u.AuthenticateAccessToken(ctx, tokens.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), claims.UserID)
+	_, err = u.AuthenticateAccessToken(ctx, "")
+	assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+}
+
+func TestAuthUsecase_Refresh(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := testutil.NewFixedClock(now)
+	t.Cleanup(entity.SetClock(clock))
+	ctx := context.Background()
+	u, _ := newTestAuthUsecase()
+
+	_, tokens, err := u.Register(ctx, "alice@example.com", "password")
+	require.NoError(t, err)
+
+	refreshed, err := u.Refresh(ctx, tokens.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user:1", refreshed.AccessToken)
+	assert.NotEqual(t, tokens.RefreshToken, refreshed.RefreshToken)
+
+	// 使用済みのトークンは再発行に使えない
+	_, err = u.Refresh(ctx, tokens.RefreshToken)
+	assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+	_, err = u.Refresh(ctx, "unknown")
+	assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+
+	// 期限切れのトークンは再発行に使えない
+	clock.Advance(time.Hour)
+	_, err = u.Refresh(ctx, refreshed.RefreshToken)
+	assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+}
+
+func TestAuthUsecase_SetPassword(t *testing.T) {
+	ctx := context.Background()
+	u, repo := newTestAuthUsecase()
+	repo.users = []*entity.User{{ID: entity.DefaultUserID, Email: entity.DefaultUserEmail}}
+
+	// パスワードを設定するまで既定のユーザーはログインできない
+	_, err := u.Login(ctx, entity.DefaultUserEmail, "")
+	assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+
+	_, err = u.SetPassword(ctx, entity.DefaultUserEmail, "new password")
+	require.NoError(t, err)
+	_, err = u.Login(ctx, entity.DefaultUserEmail, "new password")
+	assert.NoError(t, err)
+
+	_, err = u.SetPassword(ctx, "bob@example.com", "new password")
+	assert.ErrorIs(t, err, domainErrors.ErrUserNotFound)
+}
+
+func TestAuthUsecase_SetRole(t *testing.T) {
+	ctx := context.Background()
+	u, _ := newTestAuthUsecase()
+
+	_, tokens, err := u.Register(ctx, "alice@example.com", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "user:1", tokens.AccessToken)
+
+	user, err := u.SetRole(ctx, "Alice@example.com", entity.UserRoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserRoleAdmin, user.Role)
+
+	// 変更前に発行したリフレッシュトークンでも、再発行したアクセストークンには現在の権限を含める
+	refreshed, err := u.Refresh(ctx, tokens.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, "admin:1", refreshed.AccessToken)
+
+	_, err = u.SetRole(ctx, "alice@example.com", "root")
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	_, err = u.SetRole(ctx, "bob@example.com", entity.UserRoleAdmin)
+	assert.ErrorIs(t, err, domainErrors.ErrUserNotFound)
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// IDを指定して一度に取得できる最大件数
+const MaxBatchGetItems = 100
+
+type ItemBatch struct {
+	Items      []*entity.Item `json:"items"`       // 指定した順序で、見つかったアイテムのみ
+	MissingIDs []int64        `json:"missing_ids"` // 存在しないか論理削除されたアイテムのID
+}
+
+// 指定したIDのアイテムを1回のクエリで取得し、指定した順序で返す。重複したIDは最初の1件のみ扱う
+func (u *itemUsecase) GetItemsByIDs(ctx context.Context, ids []int64) (*ItemBatch, error) {
+	unique, err := uniqueIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(unique) > MaxBatchGetItems {
+		return nil, fmt.Errorf("%w: at most %d ids can be requested at once", domainErrors.ErrInvalidInput, MaxBatchGetItems)
+	}
+
+	found, err := u.itemRepo.FindByIDs(ctx, unique)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	byID := make(map[int64]*entity.Item, len(found))
+	for _, item := range found {
+		byID[item.ID] = item
+	}
+
+	batch := &ItemBatch{Items: make([]*entity.Item, 0, len(found)), MissingIDs: make([]int64, 0)}
+	for _, id := range unique {
+		if item, ok := byID[id]; ok {
+			batch.Items = append(batch.Items, item)
+		} else {
+			batch.MissingIDs = append(batch.MissingIDs, id)
+		}
+	}
+	return batch, nil
+}
+
+// 重複を除いたID。順序は最初に現れた位置のままとする。0以下のIDを含む場合と空の場合はエラー
+func uniqueIDs(ids []int64) ([]int64, error) {
+	unique := make([]int64, 0, len(ids))
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: ids must be positive integers", domainErrors.ErrInvalidInput)
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("%w: at least one id is required", domainErrors.ErrInvalidInput)
+	}
+	return unique, nil
+}
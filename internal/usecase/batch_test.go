@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func itemIDs(items []*entity.Item) []int64 {
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func TestItemUsecase_GetItemsByIDs(t *testing.T) {
+	newItem := func(id int64, name string) *entity.Item {
+		item, err := entity.NewItem(entity.NewItemInput{Name: name, Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), Categories: testCategories})
+		require.NoError(t, err)
+		item.ID = id
+		return item
+	}
+
+	t.Run("正常系: 指定した順序で返し、見つからないIDはmissing_idsに含める", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		// 重複したIDは1回だけ問い合わせる
+		mockRepo.On("FindByIDs", mock.Anything, []int64{3, 1, 2, 99}).
+			Return([]*entity.Item{newItem(1, "デイトナ"), newItem(2, "サブマリーナ"), newItem(3, "GMTマスター")}, nil)
+		uc := NewItemUsecase(mockRepo, newMockCategoryRepository(), nil, newTestExchangeRates(), nil, nil)
+
+		batch, err := uc.GetItemsByIDs(context.Background(), []int64{3, 1, 3, 2, 99, 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 1, 2}, itemIDs(batch.Items))
+		assert.Equal(t, []int64{99}, batch.MissingIDs)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 1件も見つからない場合は空のitems", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByIDs", mock.Anything, []int64{5}).Return([]*entity.Item{}, nil)
+		uc := NewItemUsecase(mockRepo, newMockCategoryRepository(), nil, newTestExchangeRates(), nil, nil)
+
+		batch, err := uc.GetItemsByIDs(context.Background(), []int64{5})
+
+		require.NoError(t, err)
+		assert.Empty(t, batch.Items)
+		assert.NotNil(t, batch.Items)
+		assert.Equal(t, []int64{5}, batch.MissingIDs)
+	})
+
+	tooMany := make([]int64, MaxBatchGetItems+1)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 1)
+	}
+	invalid := []struct {
+		name string
+		ids  []int64
+	}{
+		{"異常系: IDが空", nil},
+		{"異常系: 0以下のID", []int64{1, 0}},
+		{"異常系: 上限を超える件数", tooMany},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			uc := NewItemUsecase(mockRepo, newMockCategoryRepository(), nil, newTestExchangeRates(), nil, nil)
+
+			batch, err := uc.GetItemsByIDs(context.Background(), tt.ids)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			assert.Nil(t, batch)
+			mockRepo.AssertNotCalled(t, "FindByIDs", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("正常系: 重複を除いて上限以内であれば取得する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByIDs", mock.Anything, tooMany[:MaxBatchGetItems]).Return([]*entity.Item{}, nil)
+		uc := NewItemUsecase(mockRepo, newMockCategoryRepository(), nil, newTestExchangeRates(), nil, nil)
+
+		_, err := uc.GetItemsByIDs(context.Background(), append(tooMany[:MaxBatchGetItems:MaxBatchGetItems], 1))
+		require.NoError(t, err)
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByIDs", mock.Anything, []int64{1}).Return(nil, domainErrors.ErrDatabaseError)
+		uc := NewItemUsecase(mockRepo, newMockCategoryRepository(), nil, newTestExchangeRates(), nil, nil)
+
+		_, err := uc.GetItemsByIDs(context.Background(), []int64{1})
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+}
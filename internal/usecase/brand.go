@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ブランド検索の件数のデフォルト値と上限
+const (
+	DefaultBrandSearchLimit = 10
+	MaxBrandSearchLimit     = 100
+)
+
+type BrandUsecase interface {
+	SearchBrands(ctx context.Context, prefix string, limit int) ([]*entity.Brand, error)
+	CreateBrand(ctx context.Context, input CreateBrandInput) (*entity.Brand, error)
+	MergeBrands(ctx context.Context, sourceID, targetID int64) (*BrandMergeResult, error)
+	ResolveItemBrand(ctx context.Context, brand string) (*BrandResolution, error)
+}
+
+type CreateBrandInput struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+}
+
+// ブランドの統合の結果。Brandは統合先のブランドで、ItemsUpdatedは名前を書き換えたアイテムの件数
+type BrandMergeResult struct {
+	Brand        *entity.Brand `json:"brand"`
+	ItemsUpdated int64         `json:"items_updated"`
+}
+
+// アイテムに保存するブランド名の解決結果。
+// 登録済みの名前か別名であればBrandは正式な名前になり、未登録でも保存できる場合はWarningに理由を設定する
+type BrandResolution struct {
+	Brand   string
+	Warning string
+}
+
+type brandUsecase struct {
+	brandRepo BrandRepository
+	// アイテムの登録・更新時のブランドの検証モード（entity.BrandValidationOff, Soft, Strict）
+	validation string
+}
+
+func NewBrandUsecase(brandRepo BrandRepository, validation string) BrandUsecase {
+	return &brandUsecase{
+		brandRepo:  brandRepo,
+		validation: validation,
+	}
+}
+
+// 名前か別名が前方一致するブランドを名前順に取得する。limitが0の場合はデフォルト値を使い、上限を超える場合は上限に丸める
+func (u *brandUsecase) SearchBrands(ctx context.Context, prefix string, limit int) ([]*entity.Brand, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("%w: limit must be 0 or greater", domainErrors.ErrInvalidInput)
+	}
+	if limit == 0 {
+		limit = DefaultBrandSearchLimit
+	}
+	if limit > MaxBrandSearchLimit {
+		limit = MaxBrandSearchLimit
+	}
+
+	brands, err := u.brandRepo.Search(ctx, entity.NormalizeBrandName(prefix), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search brands: %w", err)
+	}
+
+	return brands, nil
+}
+
+func (u *brandUsecase) CreateBrand(ctx context.Context, input CreateBrandInput) (*entity.Brand, error) {
+	brand, err := entity.NewBrand(input.Name, input.Aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := u.brandRepo.Create(ctx, brand)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrDuplicateEntry) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create brand: %w", err)
+	}
+
+	return created, nil
+}
+
+// 統合元のブランドを統合先にまとめ、アイテムのブランド名も統合先の名前に書き換える
+func (u *brandUsecase) MergeBrands(ctx context.Context, sourceID, targetID int64) (*BrandMergeResult, error) {
+	if sourceID <= 0 || targetID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if sourceID == targetID {
+		return nil, fmt.Errorf("%w: a brand cannot be merged into itself", domainErrors.ErrInvalidInput)
+	}
+
+	updated, err := u.brandRepo.Merge(ctx, sourceID, targetID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrBrandNotFound
+		}
+		return nil, fmt.Errorf("failed to merge brands: %w", err)
+	}
+
+	brand, err := u.brandRepo.FindByID(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve brand: %w", err)
+	}
+
+	return &BrandMergeResult{Brand: brand, ItemsUpdated: updated}, nil
+}
+
+// アイテムに保存するブランド名を検証モードに従って解決する。
+// 登録済みの名前か別名は正式な名前に置き換え、未登録の場合はstrictでは検証エラー、softでは警告付きでそのまま保存する
+func (u *brandUsecase) ResolveItemBrand(ctx context.Context, brand string) (*BrandResolution, error) {
+	brand = entity.NormalizeBrandName(brand)
+	if brand == "" || (u.validation != entity.BrandValidationSoft && u.validation != entity.BrandValidationStrict) {
+		return &BrandResolution{Brand: brand}, nil
+	}
+
+	known, err := u.brandRepo.FindByNameOrAlias(ctx, brand)
+	if err == nil {
+		return &BrandResolution{Brand: known.Name}, nil
+	}
+	if !domainErrors.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to find brand: %w", err)
+	}
+
+	if u.validation == entity.BrandValidationStrict {
+		return nil, domainErrors.NewValidationErrors(domainErrors.NewRuleError("brand", domainErrors.CodeNotRegistered, "brand must be a registered brand name or alias", nil))
+	}
+	return &BrandResolution{Brand: brand, Warning: "brand is not registered"}, nil
+}
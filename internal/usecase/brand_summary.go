@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ブランドごとの集計。金額は基準通貨に換算した値
+type BrandStats struct {
+	Brand         string             `json:"brand"`
+	Count         int                `json:"count"`
+	TotalPrice    int64              `json:"total_price"`
+	AveragePrice  float64            `json:"average_price"`
+	MostExpensive *MostExpensiveItem `json:"most_expensive"`
+}
+
+// 現在のコレクション（売却済みを除く）のブランドごとの集計。合計の多い順に並べる
+type BrandSummary struct {
+	Currency string        `json:"currency"`
+	Brands   []*BrandStats `json:"brands"`
+}
+
+// 大文字小文字のみが異なるブランドをまとめて、ブランドごとの件数・合計・平均価格・最高額のアイテムを集計する。
+// limitが0の場合はすべてのブランドを返す
+func (u *itemUsecase) GetBrandSummary(ctx context.Context, limit int) (*BrandSummary, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("%w: limit must be 0 or greater", domainErrors.ErrInvalidInput)
+	}
+
+	totals, err := u.itemRepo.GetSummaryByBrand(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get brand summary: %w", err)
+	}
+
+	summary := &BrandSummary{
+		Currency: u.exchangeRates.BaseCurrency(),
+		Brands:   make([]*BrandStats, 0),
+	}
+
+	// 通貨ごとの行をブランドごとにまとめる。表示名は通貨をまたいでもバイナリ順で最小のものにそろえる
+	byKey := make(map[string]*BrandStats)
+	for _, total := range totals {
+		price, err := u.toBaseCurrency(ctx, total.Total())
+		if err != nil {
+			return nil, err
+		}
+
+		stats, ok := byKey[total.BrandKey]
+		if !ok {
+			stats = &BrandStats{Brand: total.Brand}
+			byKey[total.BrandKey] = stats
+			summary.Brands = append(summary.Brands, stats)
+		} else if total.Brand < stats.Brand {
+			stats.Brand = total.Brand
+		}
+
+		stats.Count += total.Count
+		stats.TotalPrice += price.Amount()
+	}
+
+	sort.SliceStable(summary.Brands, func(i, j int) bool {
+		if summary.Brands[i].TotalPrice != summary.Brands[j].TotalPrice {
+			return summary.Brands[i].TotalPrice > summary.Brands[j].TotalPrice
+		}
+		return summary.Brands[i].Brand < summary.Brands[j].Brand
+	})
+	if limit > 0 && len(summary.Brands) > limit {
+		summary.Brands = summary.Brands[:limit]
+	}
+	if len(summary.Brands) == 0 {
+		return summary, nil
+	}
+
+	candidates, err := u.itemRepo.FindMostExpensiveByBrand(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find most expensive items: %w", err)
+	}
+
+	candidatesByKey := make(map[string][]*entity.ItemPrice)
+	for _, candidate := range candidates {
+		candidatesByKey[candidate.BrandKey] = append(candidatesByKey[candidate.BrandKey], candidate)
+	}
+	for key, stats := range byKey {
+		stats.AveragePrice = averagePrice(stats.TotalPrice, stats.Count)
+		if stats.MostExpensive, err = u.mostExpensive(ctx, candidatesByKey[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestItemUsecase_GetBrandSummary(t *testing.T) {
+	brandTotals := []*entity.BrandCurrencyTotal{
+		{BrandKey: "hermes", Brand: "Hermes", Currency: "JPY", Count: 1, TotalPrice: 1000000},
+		{BrandKey: "hermes", Brand: "HERMES", Currency: "USD", Count: 2, TotalPrice: 20000},
+		{BrandKey: "omega", Brand: "OMEGA", Currency: "JPY", Count: 1, TotalPrice: 500000},
+		{BrandKey: "rolex", Brand: "ROLEX", Currency: "JPY", Count: 2, TotalPrice: 5000000},
+	}
+	candidates := []*entity.ItemPrice{
+		{BrandKey: "hermes", ID: 1, Name: "ケリー", PurchasePrice: 1000000, Currency: "JPY"},
+		{BrandKey: "hermes", ID: 2, Name: "バーキン", PurchasePrice: 15000, Currency: "USD"},
+		{BrandKey: "omega", ID: 3, Name: "スピードマスター", PurchasePrice: 500000, Currency: "JPY"},
+		{BrandKey: "rolex", ID: 4, Name: "デイトナ", PurchasePrice: 3000000, Currency: "JPY"},
+	}
+
+	tests := []struct {
+		name            string
+		limit           int
+		setupMock       func(*MockItemRepository)
+		expectedSummary *BrandSummary
+		expectedErr     error
+	}{
+		{
+			name:  "正常系: 大文字小文字の違うブランドをまとめ、合計の多い順に並べる",
+			limit: 0,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSummaryByBrand", mock.Anything).Return(brandTotals, nil)
+				mockRepo.On("FindMostExpensiveByBrand", mock.Anything).Return(candidates, nil)
+			},
+			expectedSummary: &BrandSummary{
+				Currency: "JPY",
+				Brands: []*BrandStats{
+					{Brand: "ROLEX", Count: 2, TotalPrice: 5000000, AveragePrice: 2500000,
+						MostExpensive: &MostExpensiveItem{ID: 4, Name: "デイトナ", PurchasePrice: 3000000, Currency: "JPY"}},
+					{Brand: "HERMES", Count: 3, TotalPrice: 4000000, AveragePrice: 1333333.33,
+						MostExpensive: &MostExpensiveItem{ID: 2, Name: "バーキン", PurchasePrice: 15000, Currency: "USD"}},
+					{Brand: "OMEGA", Count: 1, TotalPrice: 500000, AveragePrice: 500000,
+						MostExpensive: &MostExpensiveItem{ID: 3, Name: "スピードマスター", PurchasePrice: 500000, Currency: "JPY"}},
+				},
+			},
+		},
+		{
+			name:  "正常系: 上位N件",
+			limit: 1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSummaryByBrand", mock.Anything).Return(brandTotals, nil)
+				mockRepo.On("FindMostExpensiveByBrand", mock.Anything).Return(candidates, nil)
+			},
+			expectedSummary: &BrandSummary{
+				Currency: "JPY",
+				Brands: []*BrandStats{
+					{Brand: "ROLEX", Count: 2, TotalPrice: 5000000, AveragePrice: 2500000,
+						MostExpensive: &MostExpensiveItem{ID: 4, Name: "デイトナ", PurchasePrice: 3000000, Currency: "JPY"}},
+				},
+			},
+		},
+		{
+			name:  "正常系: アイテムがない",
+			limit: 0,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSummaryByBrand", mock.Anything).Return([]*entity.BrandCurrencyTotal{}, nil)
+				// FindMostExpensiveByBrandは呼ばれない
+			},
+			expectedSummary: &BrandSummary{Currency: "JPY", Brands: []*BrandStats{}},
+		},
+		{
+			name:  "異常系: 負のlimit",
+			limit: -1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				// リポジトリは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			limit: 0,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSummaryByBrand", mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil)
+
+			summary, err := usecase.GetBrandSummary(context.Background(), tt.limit)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, summary)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedSummary, summary)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
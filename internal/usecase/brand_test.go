@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockBrandRepository struct {
+	mock.Mock
+}
+
+func (m *MockBrandRepository) Search(ctx context.Context, prefix string, limit int) ([]*entity.Brand, error) {
+	args := m.Called(ctx, prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Brand), args.Error(1)
+}
+
+func (m *MockBrandRepository) FindByID(ctx context.Context, id int64) (*entity.Brand, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Brand), args.Error(1)
+}
+
+func (m *MockBrandRepository) FindByNameOrAlias(ctx context.Context, name string) (*entity.Brand, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Brand), args.Error(1)
+}
+
+func (m *MockBrandRepository) Create(ctx context.Context, brand *entity.Brand) (*entity.Brand, error) {
+	args := m.Called(ctx, brand)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Brand), args.Error(1)
+}
+
+func (m *MockBrandRepository) Merge(ctx context.Context, sourceID, targetID int64) (int64, error) {
+	args := m.Called(ctx, sourceID, targetID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestBrandUsecase_SearchBrands(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit         int
+		expectedLimit int
+		expectedErr   error
+	}{
+		{name: "正常系: 未指定の場合はデフォルトの件数", limit: 0, expectedLimit: DefaultBrandSearchLimit},
+		{name: "正常系: 指定した件数", limit: 5, expectedLimit: 5},
+		{name: "正常系: 上限を超える場合は上限に丸める", limit: 1000, expectedLimit: MaxBrandSearchLimit},
+		{name: "異常系: 負の件数", limit: -1, expectedErr: domainErrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			brandRepo := new(MockBrandRepository)
+			if tt.expectedErr == nil {
+				brandRepo.On("Search", mock.Anything, "ro", tt.expectedLimit).Return([]*entity.Brand{{ID: 1, Name: "ROLEX"}}, nil)
+			}
+			usecase := NewBrandUsecase(brandRepo, entity.BrandValidationOff)
+
+			brands, err := usecase.SearchBrands(context.Background(), " ro ", tt.limit)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, brands)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, brands, 1)
+			}
+
+			brandRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBrandUsecase_MergeBrands(t *testing.T) {
+	t.Run("正常系: 統合先のブランドと書き換えた件数を返す", func(t *testing.T) {
+		brandRepo := new(MockBrandRepository)
+		brandRepo.On("Merge", mock.Anything, int64(2), int64(1)).Return(int64(4), nil)
+		brandRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Brand{ID: 1, Name: "ROLEX", Aliases: []string{"Rolex Japan"}}, nil)
+		usecase := NewBrandUsecase(brandRepo, entity.BrandValidationOff)
+
+		result, err := usecase.MergeBrands(context.Background(), 2, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, "ROLEX", result.Brand.Name)
+		assert.Equal(t, int64(4), result.ItemsUpdated)
+		brandRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 自分自身には統合できない", func(t *testing.T) {
+		brandRepo := new(MockBrandRepository)
+		usecase := NewBrandUsecase(brandRepo, entity.BrandValidationOff)
+
+		result, err := usecase.MergeBrands(context.Background(), 1, 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Nil(t, result)
+		brandRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: ブランドが存在しない", func(t *testing.T) {
+		brandRepo := new(MockBrandRepository)
+		brandRepo.On("Merge", mock.Anything, int64(2), int64(99)).Return(int64(0), domainErrors.ErrBrandNotFound)
+		usecase := NewBrandUsecase(brandRepo, entity.BrandValidationOff)
+
+		result, err := usecase.MergeBrands(context.Background(), 2, 99)
+
+		assert.ErrorIs(t, err, domainErrors.ErrBrandNotFound)
+		assert.Nil(t, result)
+		brandRepo.AssertExpectations(t)
+	})
+}
+
+func TestBrandUsecase_ResolveItemBrand(t *testing.T) {
+	rolex := &entity.Brand{ID: 1, Name: "ROLEX", Aliases: []string{"ロレックス"}}
+
+	tests := []struct {
+		name            string
+		validation      string
+		brand           string
+		setupMock       func(*MockBrandRepository)
+		expectedBrand   string
+		expectedWarning string
+		expectedErr     error
+	}{
+		{
+			name:          "正常系: offでは照合しない",
+			validation:    entity.BrandValidationOff,
+			brand:         "ロレックス",
+			setupMock:     func(brandRepo *MockBrandRepository) {},
+			expectedBrand: "ロレックス",
+		},
+		{
+			name:       "正常系: 別名を正式な名前に置き換える",
+			validation: entity.BrandValidationStrict,
+			brand:      "ロレックス",
+			setupMock: func(brandRepo *MockBrandRepository) {
+				brandRepo.On("FindByNameOrAlias", mock.Anything, "ロレックス").Return(rolex, nil)
+			},
+			expectedBrand: "ROLEX",
+		},
+		{
+			name:       "正常系: softでは未登録のブランドを警告付きで保存する",
+			validation: entity.BrandValidationSoft,
+			brand:      "UNKNOWN",
+			setupMock: func(brandRepo *MockBrandRepository) {
+				brandRepo.On("FindByNameOrAlias", mock.Anything, "UNKNOWN").Return(nil, domainErrors.ErrBrandNotFound)
+			},
+			expectedBrand:   "UNKNOWN",
+			expectedWarning: "brand is not registered",
+		},
+		{
+			name:       "異常系: strictでは未登録のブランドは検証エラー",
+			validation: entity.BrandValidationStrict,
+			brand:      "UNKNOWN",
+			setupMock: func(brandRepo *MockBrandRepository) {
+				brandRepo.On("FindByNameOrAlias", mock.Anything, "UNKNOWN").Return(nil, domainErrors.ErrBrandNotFound)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:       "異常系: データベースエラー",
+			validation: entity.BrandValidationSoft,
+			brand:      "ROLEX",
+			setupMock: func(brandRepo *MockBrandRepository) {
+				brandRepo.On("FindByNameOrAlias", mock.Anything, "ROLEX").Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			brandRepo := new(MockBrandRepository)
+			tt.setupMock(brandRepo)
+			usecase := NewBrandUsecase(brandRepo, tt.validation)
+
+			resolution, err := usecase.ResolveItemBrand(context.Background(), tt.brand)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, resolution)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedBrand, resolution.Brand)
+				assert.Equal(t, tt.expectedWarning, resolution.Warning)
+			}
+
+			brandRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 一括登録で受け付ける最大件数
+const MaxBulkCreateItems = 500
+
+type BulkItemError struct {
+	Index   int                           `json:"index"` // リクエスト配列内の位置（0始まり）
+	Message string                        `json:"message"`
+	Errors  domainErrors.ValidationErrors `json:"errors,omitempty"` // フィールド単位のエラー
+}
+
+// 一括登録で1件以上の要素がバリデーションに失敗した場合のエラー
+type BulkValidationError struct {
+	Errors []BulkItemError
+}
+
+func (e *BulkValidationError) Error() string {
+	return fmt.Sprintf("%s: %d of the items failed validation", domainErrors.ErrInvalidInput.Error(), len(e.Errors))
+}
+
+func (e *BulkValidationError) Unwrap() error {
+	return domainErrors.ErrInvalidInput
+}
+
+// 全要素を検証したうえで1つのトランザクションで登録し、リクエストと同じ順序で返す
+func (u *itemUsecase) BulkCreateItems(ctx context.Context, inputs []CreateItemInput) ([]*entity.Item, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("%w: at least one item is required", domainErrors.ErrInvalidInput)
+	}
+	if len(inputs) > MaxBulkCreateItems {
+		return nil, fmt.Errorf("%w: at most %d items can be created at once", domainErrors.ErrInvalidInput, MaxBulkCreateItems)
+	}
+
+	categories, err := u.categories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*entity.Item, 0, len(inputs))
+	var itemErrors []BulkItemError
+	for i, input := range inputs {
+		item, err := newItemFromInput(input, categories)
+		if err != nil {
+			itemError := BulkItemError{Index: i, Message: err.Error()}
+			errors.As(err, &itemError.Errors)
+			itemErrors = append(itemErrors, itemError)
+			continue
+		}
+		items = append(items, item)
+	}
+
+	if len(itemErrors) > 0 {
+		return nil, &BulkValidationError{Errors: itemErrors}
+	}
+
+	// 登録した全件を取得できなかった場合は登録も取り消す
+	var ordered []*entity.Item
+	err = u.withinTx(ctx, func(ctx context.Context) error {
+		ids, err := u.itemRepo.CreateMany(ctx, items)
+		if err != nil {
+			return fmt.Errorf("failed to create items: %w", err)
+		}
+
+		created, err := u.itemRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve created items: %w", err)
+		}
+
+		byID := make(map[int64]*entity.Item, len(created))
+		for _, item := range created {
+			byID[item.ID] = item
+		}
+
+		ordered = make([]*entity.Item, 0, len(ids))
+		for _, id := range ids {
+			item, ok := byID[id]
+			if !ok {
+				return fmt.Errorf("%w: created item %d not found", domainErrors.ErrDatabaseError, id)
+			}
+			ordered = append(ordered, item)
+			if err := u.publish(ctx, entity.EventItemCreated, id, entity.ItemChange{After: item}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ordered, nil
+}
+
+// 一括削除で受け付ける最大件数
+const MaxBulkDeleteItems = 100
+
+// 一括削除のIDごとの結果
+const (
+	BulkDeleteStatusDeleted  = "deleted"
+	BulkDeleteStatusNotFound = "not_found"
+	BulkDeleteStatusError    = "error"
+)
+
+type BulkDeleteResult struct {
+	ID      int64  `json:"id"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"` // statusがerrorの場合のみ
+}
+
+// 厳密モードの一括削除で、存在しないか論理削除済みのIDがあったため全件を取り消した場合のエラー
+type BulkDeleteError struct {
+	Results []BulkDeleteResult // 削除できなかったIDの結果
+}
+
+func (e *BulkDeleteError) Error() string {
+	return fmt.Sprintf("%s: %d of the items were not found", domainErrors.ErrItemNotFound.Error(), len(e.Results))
+}
+
+func (e *BulkDeleteError) Unwrap() error {
+	return domainErrors.ErrItemNotFound
+}
+
+// 指定したIDのアイテムを論理削除し、重複を除いた指定順でIDごとの結果を返す。
+// 厳密モードでは1つのトランザクションで削除し、1件でも削除できない場合は全件を取り消す。
+// bestEffortの場合は1件ずつ削除し、削除できなかったIDは結果に含めて残りの削除を続ける
+func (u *itemUsecase) BulkDeleteItems(ctx context.Context, ids []int64, bestEffort bool) ([]BulkDeleteResult, error) {
+	unique, err := uniqueIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(unique) > MaxBulkDeleteItems {
+		return nil, fmt.Errorf("%w: at most %d items can be deleted at once", domainErrors.ErrInvalidInput, MaxBulkDeleteItems)
+	}
+
+	if bestEffort {
+		results := make([]BulkDeleteResult, 0, len(unique))
+		for _, id := range unique {
+			err := u.DeleteItem(ctx, id, nil)
+			switch {
+			case err == nil:
+				results = append(results, BulkDeleteResult{ID: id, Status: BulkDeleteStatusDeleted})
+			case errors.Is(err, domainErrors.ErrItemNotFound):
+				results = append(results, BulkDeleteResult{ID: id, Status: BulkDeleteStatusNotFound})
+			case ctx.Err() != nil:
+				// 中断した場合は残りのIDも削除できないため、結果を返さずに終了する
+				return nil, err
+			default:
+				results = append(results, BulkDeleteResult{ID: id, Status: BulkDeleteStatusError, Message: "failed to delete item"})
+			}
+		}
+		return results, nil
+	}
+
+	// 一時的なエラーでfnからやり直す場合があるため、結果はfnの中で作り直す
+	var results []BulkDeleteResult
+	err = u.withinTx(ctx, func(ctx context.Context) error {
+		results = make([]BulkDeleteResult, 0, len(unique))
+		var notFound []BulkDeleteResult
+		for _, id := range unique {
+			if err := u.DeleteItem(ctx, id, nil); err != nil {
+				if !errors.Is(err, domainErrors.ErrItemNotFound) {
+					return err
+				}
+				notFound = append(notFound, BulkDeleteResult{ID: id, Status: BulkDeleteStatusNotFound})
+				continue
+			}
+			results = append(results, BulkDeleteResult{ID: id, Status: BulkDeleteStatusDeleted})
+		}
+		if len(notFound) > 0 {
+			return &BulkDeleteError{Results: notFound}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_BulkCreateItems(t *testing.T) {
+	validInputs := []CreateItemInput{
+		{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"},
+		{Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2000000, PurchaseDate: "2023-02-20"},
+	}
+
+	t.Run("正常系: リクエストと同じ順序で返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		item1, _ := entity.NewItem(entity.NewItemInput{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), Categories: testCategories})
+		item1.ID = 10
+		item2, _ := entity.NewItem(entity.NewItemInput{Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2000000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-02-20"), Categories: testCategories})
+		item2.ID = 11
+
+		mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+			return len(items) == 2
+		})).Return([]int64{10, 11}, nil)
+		// FindByIDsは順序を保証しない
+		mockRepo.On("FindByIDs", mock.Anything, []int64{10, 11}).Return([]*entity.Item{item2, item1}, nil)
+
+		items, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil).BulkCreateItems(context.Background(), validInputs)
+
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, int64(10), items[0].ID)
+		assert.Equal(t, int64(11), items[1].ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 失敗した全要素の位置を返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		inputs := []CreateItemInput{
+			{Name: "", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"},
+			validInputs[0],
+			{Name: "アイテム", Category: "衣服", Brand: "ブランド", PurchasePrice: 100, PurchaseDate: "2023-01-15"},
+		}
+
+		items, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil).BulkCreateItems(context.Background(), inputs)
+
+		assert.Nil(t, items)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		var bulkErr *BulkValidationError
+		require.True(t, errors.As(err, &bulkErr))
+		require.Len(t, bulkErr.Errors, 2)
+		assert.Equal(t, 0, bulkErr.Errors[0].Index)
+		assert.Contains(t, bulkErr.Errors[0].Message, "name is required")
+		assert.Equal(t, "name", bulkErr.Errors[0].Errors[0].Field)
+		assert.Equal(t, 2, bulkErr.Errors[1].Index)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 空の配列", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil).BulkCreateItems(context.Background(), []CreateItemInput{})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 上限件数を超過", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		inputs := make([]CreateItemInput, MaxBulkCreateItems+1)
+
+		_, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil).BulkCreateItems(context.Background(), inputs)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		var bulkErr *BulkValidationError
+		assert.False(t, errors.As(err, &bulkErr))
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+
+		_, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil).BulkCreateItems(context.Background(), validInputs)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_BulkDeleteItems(t *testing.T) {
+	newItem := func(id int64) *entity.Item {
+		item, _ := entity.NewItem(entity.NewItemInput{Name: "時計", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
+		item.ID = id
+		return item
+	}
+
+	t.Run("正常系: 1つのトランザクションで削除し、重複を除いた指定順で結果を返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		for _, id := range []int64{2, 1} {
+			mockRepo.On("FindByID", mock.MatchedBy(inStubTx), id).Return(newItem(id), nil).Once()
+			mockRepo.On("Delete", mock.MatchedBy(inStubTx), id).Return(&entity.ItemChange{}, nil).Once()
+		}
+
+		transactor := &stubTransactor{}
+		results, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), transactor, nil).BulkDeleteItems(context.Background(), []int64{2, 1, 2}, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, []BulkDeleteResult{{ID: 2, Status: BulkDeleteStatusDeleted}, {ID: 1, Status: BulkDeleteStatusDeleted}}, results)
+		assert.Equal(t, 1, transactor.calls)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 厳密モードで見つからないIDがある場合は、見つからないIDをすべて返して取り消す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(1), nil)
+		mockRepo.On("Delete", mock.Anything, int64(1)).Return(&entity.ItemChange{}, nil)
+		mockRepo.On("FindByID", mock.Anything, int64(8)).Return(nil, domainErrors.ErrItemNotFound)
+		mockRepo.On("FindByID", mock.Anything, int64(9)).Return(nil, domainErrors.ErrItemNotFound)
+
+		results, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), &stubTransactor{}, nil).BulkDeleteItems(context.Background(), []int64{8, 1, 9}, false)
+
+		assert.Nil(t, results)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		var bulkErr *BulkDeleteError
+		require.True(t, errors.As(err, &bulkErr))
+		assert.Equal(t, []BulkDeleteResult{{ID: 8, Status: BulkDeleteStatusNotFound}, {ID: 9, Status: BulkDeleteStatusNotFound}}, bulkErr.Results)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 厳密モードでデータベースエラーの場合は残りを削除せずに返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(1), nil)
+		mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil, domainErrors.ErrDatabaseError)
+
+		_, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), &stubTransactor{}, nil).BulkDeleteItems(context.Background(), []int64{1, 2}, false)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		var bulkErr *BulkDeleteError
+		assert.False(t, errors.As(err, &bulkErr))
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, int64(2))
+	})
+
+	t.Run("正常系: best_effortでは削除できないIDがあっても残りを削除する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(1), nil)
+		mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil, domainErrors.ErrDatabaseError)
+		mockRepo.On("FindByID", mock.Anything, int64(2)).Return(nil, domainErrors.ErrItemNotFound)
+		mockRepo.On("FindByID", mock.Anything, int64(3)).Return(newItem(3), nil)
+		mockRepo.On("Delete", mock.Anything, int64(3)).Return(&entity.ItemChange{}, nil)
+
+		transactor := &stubTransactor{}
+		results, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), transactor, nil).BulkDeleteItems(context.Background(), []int64{1, 2, 3}, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, []BulkDeleteResult{
+			{ID: 1, Status: BulkDeleteStatusError, Message: "failed to delete item"},
+			{ID: 2, Status: BulkDeleteStatusNotFound},
+			{ID: 3, Status: BulkDeleteStatusDeleted},
+		}, results)
+		// 1件ずつ別のトランザクションで削除する
+		assert.Equal(t, 3, transactor.calls)
+		mockRepo.AssertExpectations(t)
+	})
+
+	tooMany := make([]int64, MaxBulkDeleteItems+1)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 1)
+	}
+	invalid := []struct {
+		name string
+		ids  []int64
+	}{
+		{"異常系: 空のID", nil},
+		{"異常系: 0以下のID", []int64{1, 0}},
+		{"異常系: 上限件数を超過", tooMany},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+
+			_, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil).BulkDeleteItems(context.Background(), tt.ids, false)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
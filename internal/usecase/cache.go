@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+// Cache stores values under string keys for a limited time. Instances that share the stored values also share the generations
+type Cache interface {
+	// Get returns the value stored under key. The second result is false when there is none or it has expired
+	Get(ctx context.Context, key string) ([]byte, bool)
+
+	// Set stores value under key until ttl elapses, replacing any existing value
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration)
+
+	// Delete removes the values stored under keys. Missing keys are ignored
+	Delete(ctx context.Context, keys ...string)
+
+	// Generation returns the current generation of name. Callers include it in keys so that Invalidate makes older values unused
+	Generation(ctx context.Context, name string) int64
+
+	// Invalidate removes the values stored under keys and advances the generations of names, on every instance sharing the cache
+	Invalidate(ctx context.Context, names []string, keys ...string)
+}
+
+// CacheObserver counts the item reads served from the cache and the reads that missed it
+type CacheObserver interface {
+	// ObserveCacheHit records that a read of kind (e.g. "find_all") was served from the cache
+	ObserveCacheHit(kind string)
+
+	// ObserveCacheMiss records that a read of kind was loaded from the repository
+	ObserveCacheMiss(kind string)
+}
+
+// キャッシュする読み込みの種類
+const (
+	cacheFindAll  = "find_all"
+	cacheCount    = "count"
+	cacheFindByID = "find_by_id"
+	cacheSummary  = "summary"
+)
+
+// キーに含める世代の名前。一覧・件数・集計の世代はアイテムのいずれかを変更すると進め、
+// 単一のアイテムの世代はカテゴリーの変更など、複数のアイテムが変わる場合に進める
+const (
+	listGeneration = "items:list"
+	itemGeneration = "items:item"
+)
+
+// アイテムの一覧・件数・単一のアイテム・カテゴリーごとの集計をキャッシュし、変更時に破棄する。
+// ItemRepositoryのほか、アイテムの内容や集計を変えるカテゴリーとブランドの変更もデコレーターで捕捉する。
+// 値はJSONで保持するため、呼び出し元が返されたアイテムを変更してもキャッシュには影響しない
+type ItemCache struct {
+	cache    Cache
+	ttl      time.Duration
+	observer CacheObserver
+
+	// 読み込みの間に変更があった場合に古い値を保存しないよう、このプロセスでの破棄と保存を排他制御する。
+	// 保存は並行して行えるよう読み込みのロックを使う
+	mu sync.RWMutex
+}
+
+// 読み込みの種類ごとのヒット・ミスをobserverに数える。observerがnilの場合は数えない
+func NewItemCache(cache Cache, ttl time.Duration, observer CacheObserver) *ItemCache {
+	return &ItemCache{cache: cache, ttl: ttl, observer: observer}
+}
+
+// repoの読み込みをキャッシュし、書き込みでキャッシュを破棄するItemRepositoryを返す
+func (c *ItemCache) ItemRepository(repo ItemRepository) ItemRepository {
+	return &cachedItemRepository{ItemRepository: repo, cache: c}
+}
+
+// カテゴリーの登録・名前の変更・削除でキャッシュを破棄するCategoryRepositoryを返す。
+// アイテムのカテゴリーのスラッグと、空のカテゴリーを含む集計が変わるため
+func (c *ItemCache) CategoryRepository(repo CategoryRepository) CategoryRepository {
+	return &cachedCategoryRepository{CategoryRepository: repo, cache: c}
+}
+
+// ブランドの統合でキャッシュを破棄するBrandRepositoryを返す。統合するとアイテムのブランドが書き換わるため
+func (c *ItemCache) BrandRepository(repo BrandRepository) BrandRepository {
+	return &cachedBrandRepository{BrandRepository: repo, cache: c}
+}
+
+// 世代と引数からキーを作る。引数はJSONにしたもののハッシュにし、キーの長さを一定にする
+func (c *ItemCache) key(kind string, generation int64, args ...interface{}) string {
+	b, err := json.Marshal(args)
+	if err != nil {
+		// 引数はすべてJSONにできる型のため、ここには来ない
+		panic(fmt.Sprintf("failed to marshal cache key: %v", err))
+	}
+	sum := sha256.Sum256(b)
+	return fmt.Sprintf("items:%s:%d:%s", kind, generation, hex.EncodeToString(sum[:]))
+}
+
+// 単一のアイテムのキー。アイテムの変更時に個別に破棄できるよう、IDをそのまま含める
+func (c *ItemCache) itemKey(generation, id int64) string {
+	return fmt.Sprintf("items:%s:%d:%d", cacheFindByID, generation, id)
+}
+
+// keyOfで作ったキーの値を返し、ない場合はloadの結果を保存して返す。
+// トランザクションの中ではコミット前の内容を保存しないよう、キャッシュを使わずにloadを呼ぶ
+func cached[T any](ctx context.Context, c *ItemCache, kind string, keyOf func(list, item int64) string, load func() (T, error)) (T, error) {
+	if inTx(ctx) {
+		return load()
+	}
+
+	generations := func() (int64, int64) {
+		return c.cache.Generation(ctx, listGeneration), c.cache.Generation(ctx, itemGeneration)
+	}
+	list, item := generations()
+	key := keyOf(list, item)
+
+	if b, ok := c.cache.Get(ctx, key); ok {
+		var value T
+		if err := json.Unmarshal(b, &value); err == nil {
+			if c.observer != nil {
+				c.observer.ObserveCacheHit(kind)
+			}
+			return value, nil
+		}
+		slog.WarnContext(ctx, "⚠️  キャッシュの値を読み込めないため破棄します", "key", key)
+		c.cache.Delete(ctx, key)
+	}
+	if c.observer != nil {
+		c.observer.ObserveCacheMiss(kind)
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return value, nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	// 読み込みの間にアイテムが変更された場合は、変更前の内容の可能性があるため保存しない
+	if newList, newItem := generations(); newList == list && newItem == item {
+		c.cache.Set(ctx, key, b, c.ttl)
+	}
+	return value, nil
+}
+
+// アイテムを変更した後にキャッシュを破棄する。idsのアイテムと、一覧・件数・集計を破棄し、
+// allがtrueの場合はすべてのアイテムを破棄する。トランザクションの中ではコミットした後に破棄する。
+// キャッシュを共有する他のプロセスの変更との間では、読み込みの間の変更を検出できないことがある
+func (c *ItemCache) invalidate(ctx context.Context, all bool, ids ...int64) {
+	if pending, ok := ctx.Value(afterCommitKey{}).(*[]func(context.Context)); ok {
+		*pending = append(*pending, func(ctx context.Context) { c.invalidate(ctx, all, ids...) })
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	generation := c.cache.Generation(ctx, itemGeneration)
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = c.itemKey(generation, id)
+	}
+	names := []string{listGeneration}
+	if all {
+		names = append(names, itemGeneration)
+	}
+	c.cache.Invalidate(ctx, names, keys...)
+}
+
+// 一覧・件数・集計のキーに含める所有者。ユーザーごとに結果が異なるため、所有者で限定しない場合は0
+func ownerKey(ctx context.Context) int64 {
+	userID, _ := principal.OwnerScope(ctx)
+	return userID
+}
+
+// ctxで実行中のトランザクションがあるかどうか。itemUsecase.withinTxで開始したもののみ判定できる
+func inTx(ctx context.Context) bool {
+	_, ok := ctx.Value(afterCommitKey{}).(*[]func(context.Context))
+	return ok
+}
+
+// 読み込みはItemCacheのキャッシュを使い、書き込みはキャッシュを破棄する。
+// それ以外のメソッドは埋め込んだリポジトリをそのまま呼ぶため、アイテムを変更するメソッドを追加した場合はここで破棄する
+type cachedItemRepository struct {
+	ItemRepository
+	cache *ItemCache
+}
+
+func (r *cachedItemRepository) FindAll(ctx context.Context, filter entity.ItemFilter, sort entity.ItemSort, page entity.Pagination) ([]*entity.Item, error) {
+	keyOf := func(list, _ int64) string {
+		return r.cache.key(cacheFindAll, list, ownerKey(ctx), filter, sort, page)
+	}
+	return cached(ctx, r.cache, cacheFindAll, keyOf, func() ([]*entity.Item, error) {
+		return r.ItemRepository.FindAll(ctx, filter, sort, page)
+	})
+}
+
+func (r *cachedItemRepository) Count(ctx context.Context, filter entity.ItemFilter) (int, error) {
+	keyOf := func(list, _ int64) string {
+		return r.cache.key(cacheCount, list, ownerKey(ctx), filter)
+	}
+	return cached(ctx, r.cache, cacheCount, keyOf, func() (int, error) {
+		return r.ItemRepository.Count(ctx, filter)
+	})
+}
+
+func (r *cachedItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	keyOf := func(_, item int64) string {
+		return r.cache.itemKey(item, id)
+	}
+	item, err := cached(ctx, r.cache, cacheFindByID, keyOf, func() (*entity.Item, error) {
+		return r.ItemRepository.FindByID(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// キーはIDのみで所有者によらず共有するため、他のユーザーのアイテムは見つからないものとする
+	if userID, ok := principal.OwnerScope(ctx); ok && item.UserID != userID {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	return item, nil
+}
+
+func (r *cachedItemRepository) GetSummaryByCategory(ctx context.Context) ([]*entity.CategoryCurrencyTotal, error) {
+	keyOf := func(list, _ int64) string {
+		return r.cache.key(cacheSummary, list, ownerKey(ctx))
+	}
+	return cached(ctx, r.cache, cacheSummary, keyOf, func() ([]*entity.CategoryCurrencyTotal, error) {
+		return r.ItemRepository.GetSummaryByCategory(ctx)
+	})
+}
+
+// 書き込みはエラーの場合も破棄する。制限時間の超過などでは、変更が保存されたかどうか分からないため
+
+func (r *cachedItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	defer r.cache.invalidate(ctx, false)
+	return r.ItemRepository.Create(ctx, item)
+}
+
+func (r *cachedItemRepository) CreateMany(ctx context.Context, items []*entity.Item) ([]int64, error) {
+	defer r.cache.invalidate(ctx, false)
+	return r.ItemRepository.CreateMany(ctx, items)
+}
+
+func (r *cachedItemRepository) CreateWithIdempotencyKey(ctx context.Context, item *entity.Item, key *entity.IdempotencyKey, createdBefore time.Time) (*entity.Item, error) {
+	defer r.cache.invalidate(ctx, false)
+	return r.ItemRepository.CreateWithIdempotencyKey(ctx, item, key, createdBefore)
+}
+
+func (r *cachedItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	defer r.cache.invalidate(ctx, false, item.ID)
+	return r.ItemRepository.Update(ctx, item)
+}
+
+func (r *cachedItemRepository) Delete(ctx context.Context, id int64) (*entity.ItemChange, error) {
+	defer r.cache.invalidate(ctx, false, id)
+	return r.ItemRepository.Delete(ctx, id)
+}
+
+func (r *cachedItemRepository) Restore(ctx context.Context, id int64) (*entity.ItemChange, error) {
+	defer r.cache.invalidate(ctx, false, id)
+	return r.ItemRepository.Restore(ctx, id)
+}
+
+func (r *cachedItemRepository) HardDelete(ctx context.Context, id int64) (*entity.ItemChange, error) {
+	defer r.cache.invalidate(ctx, false, id)
+	return r.ItemRepository.HardDelete(ctx, id)
+}
+
+type cachedCategoryRepository struct {
+	CategoryRepository
+	cache *ItemCache
+}
+
+func (r *cachedCategoryRepository) Create(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	defer r.cache.invalidate(ctx, true)
+	return r.CategoryRepository.Create(ctx, category)
+}
+
+func (r *cachedCategoryRepository) Rename(ctx context.Context, id int64, name string) (*entity.Category, error) {
+	defer r.cache.invalidate(ctx, true)
+	return r.CategoryRepository.Rename(ctx, id, name)
+}
+
+func (r *cachedCategoryRepository) Delete(ctx context.Context, id int64) error {
+	defer r.cache.invalidate(ctx, true)
+	return r.CategoryRepository.Delete(ctx, id)
+}
+
+type cachedBrandRepository struct {
+	BrandRepository
+	cache *ItemCache
+}
+
+func (r *cachedBrandRepository) Merge(ctx context.Context, sourceID, targetID int64) (int64, error) {
+	defer r.cache.invalidate(ctx, true)
+	return r.BrandRepository.Merge(ctx, sourceID, targetID)
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+// 期限を考慮しないmapのCache
+type mapCache struct {
+	mu          sync.Mutex
+	values      map[string][]byte
+	generations map[string]int64
+}
+
+func newMapCache() *mapCache {
+	return &mapCache{values: make(map[string][]byte), generations: make(map[string]int64)}
+}
+
+func (c *mapCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.values[key]
+	return value, ok
+}
+
+func (c *mapCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+}
+
+func (c *mapCache) Delete(ctx context.Context, keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.values, key)
+	}
+}
+
+func (c *mapCache) Generation(ctx context.Context, name string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generations[name]
+}
+
+func (c *mapCache) Invalidate(ctx context.Context, names []string, keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, name := range names {
+		c.generations[name]++
+	}
+	for _, key := range keys {
+		delete(c.values, key)
+	}
+}
+
+// 読み込みの種類ごとのヒット・ミスの件数を数えるCacheObserver
+type countingCacheObserver struct {
+	mu     sync.Mutex
+	hits   map[string]int
+	misses map[string]int
+}
+
+func newCountingCacheObserver() *countingCacheObserver {
+	return &countingCacheObserver{hits: make(map[string]int), misses: make(map[string]int)}
+}
+
+func (o *countingCacheObserver) ObserveCacheHit(kind string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.hits[kind]++
+}
+
+func (o *countingCacheObserver) ObserveCacheMiss(kind string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.misses[kind]++
+}
+
+func TestItemCache_FindByID(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "ロレックス デイトナ", Tags: []string{}}, nil).Once()
+	observer := newCountingCacheObserver()
+	repo := NewItemCache(newMapCache(), time.Minute, observer).ItemRepository(mockRepo)
+
+	item, err := repo.FindByID(context.Background(), 1)
+	require.NoError(t, err)
+	// 返したアイテムを変更してもキャッシュには影響しない
+	item.Name = "変更"
+
+	cachedItem, err := repo.FindByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "ロレックス デイトナ", cachedItem.Name)
+	mockRepo.AssertExpectations(t)
+
+	assert.Equal(t, map[string]int{cacheFindByID: 1}, observer.hits)
+	assert.Equal(t, map[string]int{cacheFindByID: 1}, observer.misses)
+}
+
+func TestItemCache_UpdateInvalidates(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	filter := entity.ItemFilter{Category: "時計"}
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "変更前"}, nil).Once()
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "変更後"}, nil).Once()
+	mockRepo.On("Count", mock.Anything, filter).Return(1, nil).Twice()
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1, Name: "変更後"}, nil)
+	repo := NewItemCache(newMapCache(), time.Minute, nil).ItemRepository(mockRepo)
+	ctx := context.Background()
+
+	_, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	_, err = repo.Count(ctx, filter)
+	require.NoError(t, err)
+
+	_, err = repo.Update(ctx, &entity.Item{ID: 1, Name: "変更後"})
+	require.NoError(t, err)
+
+	item, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "変更後", item.Name)
+	_, err = repo.Count(ctx, filter)
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemCache_KeysDependOnArguments(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	page := entity.Pagination{Limit: 10}
+	mockRepo.On("FindAll", mock.Anything, entity.ItemFilter{}, entity.ItemSort{}, page).Return([]*entity.Item{{ID: 1}}, nil).Once()
+	mockRepo.On("FindAll", mock.Anything, entity.ItemFilter{Brand: "ROLEX"}, entity.ItemSort{}, page).Return([]*entity.Item{}, nil).Once()
+	repo := NewItemCache(newMapCache(), time.Minute, nil).ItemRepository(mockRepo)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		items, err := repo.FindAll(ctx, entity.ItemFilter{}, entity.ItemSort{}, page)
+		require.NoError(t, err)
+		assert.Len(t, items, 1)
+		items, err = repo.FindAll(ctx, entity.ItemFilter{Brand: "ROLEX"}, entity.ItemSort{}, page)
+		require.NoError(t, err)
+		assert.Empty(t, items)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemCache_OwnerScope(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, UserID: 2}, nil).Once()
+	mockRepo.On("Count", mock.Anything, entity.ItemFilter{}).Return(1, nil).Once()
+	mockRepo.On("Count", mock.Anything, entity.ItemFilter{}).Return(0, nil).Once()
+	repo := NewItemCache(newMapCache(), time.Minute, nil).ItemRepository(mockRepo)
+	owner := principal.NewContext(context.Background(), principal.Principal{UserID: 2})
+	other := principal.NewContext(context.Background(), principal.Principal{UserID: 3})
+
+	_, err := repo.FindByID(owner, 1)
+	require.NoError(t, err)
+	// 所有者がキャッシュしたアイテムも、他のユーザーには見つからないものとする
+	_, err = repo.FindByID(other, 1)
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+
+	// 件数はユーザーごとにキャッシュする
+	count, err := repo.Count(owner, entity.ItemFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	count, err = repo.Count(other, entity.ItemFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemCache_Transaction(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "変更前"}, nil).Times(3)
+	mockRepo.On("Delete", mock.Anything, int64(1)).Return(&entity.ItemChange{}, nil)
+	repo := NewItemCache(newMapCache(), time.Minute, nil).ItemRepository(mockRepo)
+	ctx := context.Background()
+
+	_, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+
+	var pending []func(context.Context)
+	txCtx := context.WithValue(ctx, afterCommitKey{}, &pending)
+	// トランザクションの中ではキャッシュを使わない
+	_, err = repo.FindByID(txCtx, 1)
+	require.NoError(t, err)
+	_, err = repo.Delete(txCtx, 1)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	// コミットするまでは変更前の値を返す
+	_, err = repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "FindByID", 2)
+
+	pending[0](ctx)
+	_, err = repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestItemCache_SkipStaleValue(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	itemCache := NewItemCache(newMapCache(), time.Minute, nil)
+	// 読み込みの間に他のリクエストがアイテムを変更する
+	mockRepo.On("GetSummaryByCategory", mock.Anything).
+		Run(func(args mock.Arguments) { itemCache.invalidate(context.Background(), false, 1) }).
+		Return([]*entity.CategoryCurrencyTotal{}, nil).Once()
+	mockRepo.On("GetSummaryByCategory", mock.Anything).Return([]*entity.CategoryCurrencyTotal{}, nil).Twice()
+	repo := itemCache.ItemRepository(mockRepo)
+
+	for i := 0; i < 3; i++ {
+		_, err := repo.GetSummaryByCategory(context.Background())
+		require.NoError(t, err)
+	}
+	// 1回目の値は保存せず、2回目の値を3回目に使う
+	mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 2)
+}
+
+func TestItemCache_CategoryRenameInvalidatesItems(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockCategoryRepo := new(MockCategoryRepository)
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Category: "時計"}, nil).Once()
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Category: "腕時計"}, nil).Once()
+	mockCategoryRepo.On("Rename", mock.Anything, int64(1), "腕時計").Return(&entity.Category{ID: 1, Name: "腕時計"}, nil)
+	itemCache := NewItemCache(newMapCache(), time.Minute, nil)
+	repo := itemCache.ItemRepository(mockRepo)
+	ctx := context.Background()
+
+	_, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	_, err = itemCache.CategoryRepository(mockCategoryRepo).Rename(ctx, 1, "腕時計")
+	require.NoError(t, err)
+
+	item, err := repo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "腕時計", item.Category)
+	mockRepo.AssertExpectations(t)
+}
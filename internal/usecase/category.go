@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type CategoryUsecase interface {
+	ListCategories(ctx context.Context) ([]*entity.Category, error)
+	GetCategory(ctx context.Context, id int64) (*entity.Category, error)
+	CreateCategory(ctx context.Context, input CreateCategoryInput) (*entity.Category, error)
+	UpdateCategory(ctx context.Context, id int64, name string) (*entity.Category, error)
+	DeleteCategory(ctx context.Context, id int64) error
+}
+
+type CreateCategoryInput struct {
+	Name   string `json:"name"`
+	Slug   string `json:"slug"`
+	NameEn string `json:"name_en"`
+}
+
+// アイテムから参照されているカテゴリーを削除しようとした場合のエラー
+type CategoryInUseError struct {
+	ItemCount int
+}
+
+func (e *CategoryInUseError) Error() string {
+	return fmt.Sprintf("%s: %d items belong to the category", domainErrors.ErrCategoryInUse.Error(), e.ItemCount)
+}
+
+func (e *CategoryInUseError) Unwrap() error {
+	return domainErrors.ErrCategoryInUse
+}
+
+type categoryUsecase struct {
+	categoryRepo CategoryRepository
+}
+
+func NewCategoryUsecase(categoryRepo CategoryRepository) CategoryUsecase {
+	return &categoryUsecase{
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (u *categoryUsecase) ListCategories(ctx context.Context) ([]*entity.Category, error) {
+	categories, err := u.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (u *categoryUsecase) GetCategory(ctx context.Context, id int64) (*entity.Category, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	category, err := u.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve category: %w", err)
+	}
+
+	return category, nil
+}
+
+func (u *categoryUsecase) CreateCategory(ctx context.Context, input CreateCategoryInput) (*entity.Category, error) {
+	category, err := entity.NewCategory(input.Name, input.Slug, input.NameEn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	created, err := u.categoryRepo.Create(ctx, category)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrDuplicateEntry) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return created, nil
+}
+
+// カテゴリー名を変更する。そのカテゴリーのアイテムも新しい名前に付け替わる。スラッグは変更しない
+func (u *categoryUsecase) UpdateCategory(ctx context.Context, id int64, name string) (*entity.Category, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	name = strings.TrimSpace(name)
+	if err := entity.ValidateCategoryName(name); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	updated, err := u.categoryRepo.Rename(ctx, id, name)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrCategoryNotFound
+		}
+		if errors.Is(err, domainErrors.ErrDuplicateEntry) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return updated, nil
+}
+
+// アイテム（論理削除済みを含む）が1件でもあるカテゴリーは削除できない
+func (u *categoryUsecase) DeleteCategory(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	err := u.categoryRepo.Delete(ctx, id)
+	if err == nil {
+		return nil
+	}
+	if domainErrors.IsNotFoundError(err) {
+		return domainErrors.ErrCategoryNotFound
+	}
+	if !errors.Is(err, domainErrors.ErrCategoryInUse) {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	category, err := u.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve category: %w", err)
+	}
+	count, err := u.categoryRepo.CountItems(ctx, category.Name)
+	if err != nil {
+		return fmt.Errorf("failed to count category items: %w", err)
+	}
+
+	return &CategoryInUseError{ItemCount: count}
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestCategoryUsecase_CreateCategory(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       CreateCategoryInput
+		setupMock   func(*MockCategoryRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: カテゴリーを登録",
+			input: CreateCategoryInput{Name: " アクセサリー ", Slug: " Accessory ", NameEn: "Accessory"},
+			setupMock: func(categoryRepo *MockCategoryRepository) {
+				categoryRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *entity.Category) bool {
+					return c.Name == "アクセサリー" && c.Slug == "accessory" && c.NameEn == "Accessory"
+				})).Return(&entity.Category{ID: 6, Slug: "accessory", Name: "アクセサリー", NameEn: "Accessory"}, nil)
+			},
+		},
+		{
+			name:  "異常系: 登録済みの名前",
+			input: CreateCategoryInput{Name: "時計", Slug: "watch", NameEn: "Watch"},
+			setupMock: func(categoryRepo *MockCategoryRepository) {
+				categoryRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDuplicateEntry)
+			},
+			expectedErr: domainErrors.ErrDuplicateEntry,
+		},
+		{
+			name:        "異常系: 名前が空",
+			input:       CreateCategoryInput{Slug: "accessory", NameEn: "Accessory"},
+			setupMock:   func(categoryRepo *MockCategoryRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categoryRepo := new(MockCategoryRepository)
+			tt.setupMock(categoryRepo)
+			usecase := NewCategoryUsecase(categoryRepo)
+
+			category, err := usecase.CreateCategory(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, category)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "アクセサリー", category.Name)
+			}
+
+			categoryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCategoryUsecase_UpdateCategory(t *testing.T) {
+	t.Run("正常系: 名前を変更", func(t *testing.T) {
+		categoryRepo := new(MockCategoryRepository)
+		categoryRepo.On("Rename", mock.Anything, int64(1), "腕時計").Return(&entity.Category{ID: 1, Name: "腕時計"}, nil)
+		usecase := NewCategoryUsecase(categoryRepo)
+
+		category, err := usecase.UpdateCategory(context.Background(), 1, "腕時計")
+
+		require.NoError(t, err)
+		assert.Equal(t, "腕時計", category.Name)
+		categoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 存在しないカテゴリー", func(t *testing.T) {
+		categoryRepo := new(MockCategoryRepository)
+		categoryRepo.On("Rename", mock.Anything, int64(999), "腕時計").Return(nil, domainErrors.ErrCategoryNotFound)
+		usecase := NewCategoryUsecase(categoryRepo)
+
+		category, err := usecase.UpdateCategory(context.Background(), 999, "腕時計")
+
+		assert.ErrorIs(t, err, domainErrors.ErrCategoryNotFound)
+		assert.Nil(t, category)
+		categoryRepo.AssertExpectations(t)
+	})
+}
+
+func TestCategoryUsecase_DeleteCategory(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            int64
+		setupMock     func(*MockCategoryRepository)
+		expectedErr   error
+		expectedCount int
+	}{
+		{
+			name: "正常系: アイテムのないカテゴリーを削除",
+			id:   6,
+			setupMock: func(categoryRepo *MockCategoryRepository) {
+				categoryRepo.On("Delete", mock.Anything, int64(6)).Return(nil)
+			},
+		},
+		{
+			name: "異常系: アイテムがあるカテゴリーは件数とともに拒否",
+			id:   1,
+			setupMock: func(categoryRepo *MockCategoryRepository) {
+				categoryRepo.On("Delete", mock.Anything, int64(1)).Return(domainErrors.ErrCategoryInUse)
+				categoryRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Category{ID: 1, Name: "時計"}, nil)
+				categoryRepo.On("CountItems", mock.Anything, "時計").Return(3, nil)
+			},
+			expectedErr:   domainErrors.ErrCategoryInUse,
+			expectedCount: 3,
+		},
+		{
+			name: "異常系: 存在しないカテゴリー",
+			id:   999,
+			setupMock: func(categoryRepo *MockCategoryRepository) {
+				categoryRepo.On("Delete", mock.Anything, int64(999)).Return(domainErrors.ErrCategoryNotFound)
+			},
+			expectedErr: domainErrors.ErrCategoryNotFound,
+		},
+		{
+			name:        "異常系: 無効なID（0以下）",
+			id:          0,
+			setupMock:   func(categoryRepo *MockCategoryRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categoryRepo := new(MockCategoryRepository)
+			tt.setupMock(categoryRepo)
+			usecase := NewCategoryUsecase(categoryRepo)
+
+			err := usecase.DeleteCategory(context.Background(), tt.id)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.expectedCount > 0 {
+				var inUseErr *CategoryInUseError
+				require.True(t, errors.As(err, &inUseErr))
+				assert.Equal(t, tt.expectedCount, inUseErr.ItemCount)
+			}
+
+			categoryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_CreateItem_RegisteredCategory(t *testing.T) {
+	categoryRepo := new(MockCategoryRepository)
+	categoryRepo.On("FindAll", mock.Anything).Return([]*entity.Category{{ID: 6, Name: "アクセサリー"}}, nil)
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("FindByPurchaseDate", mock.Anything, mock.Anything).Return([]*entity.Item{}, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(&entity.Item{ID: 1, Category: "アクセサリー"}, nil)
+	usecase := NewItemUsecase(mockRepo, categoryRepo, new(MockImageStorage), newTestExchangeRates(), nil, nil)
+
+	item, err := usecase.CreateItem(context.Background(), CreateItemInput{
+		Name: "ブレスレット", Category: "アクセサリー", Brand: "ブランド", PurchasePrice: 10000, PurchaseDate: "2023-01-01",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "アクセサリー", item.Category)
+
+	_, err = usecase.CreateItem(context.Background(), CreateItemInput{
+		Name: "時計", Category: "時計", Brand: "ブランド", PurchasePrice: 10000, PurchaseDate: "2023-01-01",
+	})
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "category must be one of: アクセサリー")
+
+	mockRepo.AssertExpectations(t)
+}
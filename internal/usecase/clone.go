@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 複製時に元のアイテムの値を置き換えるフィールド。nilのフィールドは元のアイテムの値（複製しないフィールドは未設定）とする
+type CloneItemInput struct {
+	Name             *string             `json:"name,omitempty"`
+	Category         *string             `json:"category,omitempty"`
+	Brand            *string             `json:"brand,omitempty"`
+	PurchasePrice    *PurchasePriceInput `json:"purchase_price,omitempty"`
+	Currency         *string             `json:"currency,omitempty"`
+	PurchaseDate     *string             `json:"purchase_date,omitempty"` // YYYY-MM-DD 形式
+	SerialNumber     *string             `json:"serial_number,omitempty"` // 元のアイテムのシリアル番号は複製しない
+	Condition        *string             `json:"condition,omitempty"`
+	Notes            *string             `json:"notes,omitempty"`
+	PurchaseLocation *string             `json:"purchase_location,omitempty"`
+	Tags             *[]string           `json:"tags,omitempty"`
+}
+
+// 既存のアイテムの名前・カテゴリー・ブランド・購入価格（通貨を含む）・購入日を引き継いで新しいアイテムを登録する。
+// inputで指定したフィールドは置き換え、組み合わせた値を登録と同じくバリデーションする。
+// 同じモデルを複数所持するための操作のため、重複の確認は行わない
+func (u *itemUsecase) CloneItem(ctx context.Context, id int64, input CloneItemInput) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	source, err := u.itemRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to find item: %w", err)
+	}
+
+	created, err := u.CreateItem(ctx, input.merge(source))
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// 元のアイテムの値にinputで指定したフィールドを重ねた登録の入力
+func (in CloneItemInput) merge(source *entity.Item) CreateItemInput {
+	merged := CreateItemInput{
+		Name:          source.Name,
+		Category:      source.Category,
+		Brand:         source.Brand,
+		PurchasePrice: PurchasePriceInput(source.PurchasePrice),
+		Currency:      source.Currency,
+		PurchaseDate:  source.PurchaseDate.String(),
+		Force:         true,
+	}
+	if in.Name != nil {
+		merged.Name = *in.Name
+	}
+	if in.Category != nil {
+		merged.Category = *in.Category
+	}
+	if in.Brand != nil {
+		merged.Brand = *in.Brand
+	}
+	if in.PurchasePrice != nil {
+		merged.PurchasePrice = *in.PurchasePrice
+	}
+	if in.Currency != nil {
+		merged.Currency = *in.Currency
+	}
+	if in.PurchaseDate != nil {
+		merged.PurchaseDate = *in.PurchaseDate
+	}
+	if in.SerialNumber != nil {
+		merged.SerialNumber = *in.SerialNumber
+	}
+	if in.Condition != nil {
+		merged.Condition = *in.Condition
+	}
+	if in.Notes != nil {
+		merged.Notes = *in.Notes
+	}
+	if in.PurchaseLocation != nil {
+		merged.PurchaseLocation = *in.PurchaseLocation
+	}
+	if in.Tags != nil {
+		merged.Tags = *in.Tags
+	}
+	return merged
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_CloneItem(t *testing.T) {
+	newSource := func() *entity.Item {
+		source, err := entity.NewItem(entity.NewItemInput{
+			Name:             "ロレックス デイトナ",
+			Category:         "時計",
+			Brand:            "ROLEX",
+			PurchasePrice:    1500000,
+			Currency:         "JPY",
+			PurchaseDate:     entity.MustParsePurchaseDate("2023-01-15"),
+			SerialNumber:     "ABC123",
+			Condition:        entity.ConditionUsedA,
+			Notes:            "付属品あり",
+			PurchaseLocation: "銀座店",
+			Tags:             []string{"限定"},
+			Categories:       testCategories,
+		})
+		require.NoError(t, err)
+		source.ID = 1
+		source.Version = 3
+		return source
+	}
+
+	t.Run("正常系: 名前・カテゴリー・ブランド・購入価格を引き継ぎ、指定したフィールドで置き換える", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		source := newSource()
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(source, nil)
+		// 登録するアイテム
+		var clone *entity.Item
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			clone = item
+			return true
+		})).Return(&entity.Item{ID: 2}, nil)
+
+		created, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil).
+			CloneItem(context.Background(), 1, CloneItemInput{PurchaseDate: stringPtr("2024-03-01"), Notes: stringPtr("2本目")})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), created.ID)
+		require.NotNil(t, clone)
+		assert.Zero(t, clone.ID)
+		assert.Equal(t, source.Name, clone.Name)
+		assert.Equal(t, source.Category, clone.Category)
+		assert.Equal(t, source.Brand, clone.Brand)
+		assert.Equal(t, source.PurchasePrice, clone.PurchasePrice)
+		assert.Equal(t, source.Currency, clone.Currency)
+		assert.Equal(t, "2024-03-01", clone.PurchaseDate.String())
+		assert.Equal(t, "2本目", clone.Notes)
+		// シリアル番号など個体ごとの値は引き継がない
+		assert.Nil(t, clone.SerialNumber)
+		assert.Nil(t, clone.Condition)
+		assert.Nil(t, clone.PurchaseLocation)
+		assert.Empty(t, clone.Tags)
+		assert.Equal(t, entity.InitialItemVersion, clone.Version)
+		assert.False(t, clone.CreatedAt.Before(source.CreatedAt))
+		// 同じモデルを複数所持するための操作のため、重複を確認しない
+		mockRepo.AssertNotCalled(t, "FindByPurchaseDate", mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 購入日を指定しない場合は元のアイテムの購入日を引き継ぐ", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newSource(), nil)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.PurchaseDate.String() == "2023-01-15" && item.SerialNumber != nil && *item.SerialNumber == "XYZ789"
+		})).Return(newSource(), nil)
+
+		_, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil).
+			CloneItem(context.Background(), 1, CloneItemInput{SerialNumber: stringPtr("XYZ789")})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 置き換えた値もバリデーションする", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newSource(), nil)
+
+		_, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil).
+			CloneItem(context.Background(), 1, CloneItemInput{Name: stringPtr("")})
+
+		assert.ErrorIs(t, err, domainErrors.ErrValidation)
+		var validationErrors domainErrors.ValidationErrors
+		require.True(t, errors.As(err, &validationErrors))
+		assert.Equal(t, "name", validationErrors[0].Field)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 存在しないか論理削除されたアイテム", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(9)).Return(nil, domainErrors.ErrNotFound)
+
+		_, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil).
+			CloneItem(context.Background(), 9, CloneItemInput{})
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 0以下のID", func(t *testing.T) {
+		_, err := NewItemUsecase(new(MockItemRepository), newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil).
+			CloneItem(context.Background(), 0, CloneItemInput{})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 一覧のカーソルの内容。並び替えの指定と、前のページの最後のアイテムの並び替えの項目の値とID
+type itemCursorToken struct {
+	Sort  string          `json:"s"`
+	Order string          `json:"o"`
+	Value json.RawMessage `json:"v"`
+	ID    int64           `json:"id"`
+}
+
+// itemの位置を表すカーソルを、JSONをbase64（URLセーフ）で符号化した文字列にする
+func encodeItemCursor(sort entity.ItemSort, item *entity.Item) (string, error) {
+	sort = sort.WithDefaults()
+	cursor := sort.CursorOf(item)
+
+	value, err := json.Marshal(cursor.Value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	data, err := json.Marshal(itemCursorToken{Sort: sort.Field, Order: sort.Order, Value: value, ID: cursor.ID})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// encodeItemCursorで作成したカーソルを解析する。
+// 解析できない場合や、カーソルを作成したときと並び替えの指定が異なる場合はErrInvalidCursorを返す
+func decodeItemCursor(s string, sort entity.ItemSort) (*entity.ItemCursor, error) {
+	sort = sort.WithDefaults()
+	invalid := fmt.Errorf("%w: cursor cannot be decoded", domainErrors.ErrInvalidCursor)
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, invalid
+	}
+	var token itemCursorToken
+	if err := json.Unmarshal(data, &token); err != nil || token.ID <= 0 || len(token.Value) == 0 {
+		return nil, invalid
+	}
+	if token.Sort != sort.Field || token.Order != sort.Order {
+		return nil, fmt.Errorf("%w: cursor was issued for a different sort or order", domainErrors.ErrInvalidCursor)
+	}
+
+	var value interface{}
+	switch sort.Field {
+	case entity.SortByPurchasePrice:
+		var price int64
+		err = json.Unmarshal(token.Value, &price)
+		value = price
+	case entity.SortByPurchaseDate:
+		var date entity.PurchaseDate
+		err = json.Unmarshal(token.Value, &date)
+		value = date
+	case entity.SortByName:
+		var name string
+		err = json.Unmarshal(token.Value, &name)
+		value = name
+	default:
+		var createdAt time.Time
+		err = json.Unmarshal(token.Value, &createdAt)
+		value = createdAt
+	}
+	if err != nil {
+		return nil, invalid
+	}
+
+	return &entity.ItemCursor{Value: value, ID: token.ID}, nil
+}
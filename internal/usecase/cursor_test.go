@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func newCursorTestItem(t *testing.T) *entity.Item {
+	t.Helper()
+
+	item, err := entity.NewItem(entity.NewItemInput{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), Categories: testCategories})
+	require.NoError(t, err)
+	item.ID = 12
+	item.CreatedAt = time.Date(2023, 1, 15, 10, 0, 0, 123456000, time.UTC)
+	return item
+}
+
+func TestItemCursor_RoundTrip(t *testing.T) {
+	item := newCursorTestItem(t)
+
+	tests := []struct {
+		sort     entity.ItemSort
+		expected interface{}
+	}{
+		{entity.ItemSort{Field: entity.SortByPurchasePrice, Order: entity.SortOrderAsc}, int64(1500000)},
+		{entity.ItemSort{Field: entity.SortByPurchaseDate, Order: entity.SortOrderDesc}, entity.MustParsePurchaseDate("2023-01-15")},
+		{entity.ItemSort{Field: entity.SortByName}, "ロレックス デイトナ"},
+		{entity.ItemSort{}, item.CreatedAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort.WithDefaults().Field+" "+tt.sort.WithDefaults().Order, func(t *testing.T) {
+			encoded, err := encodeItemCursor(tt.sort, item)
+			require.NoError(t, err)
+
+			cursor, err := decodeItemCursor(encoded, tt.sort)
+			require.NoError(t, err)
+			assert.Equal(t, int64(12), cursor.ID)
+			if createdAt, ok := tt.expected.(time.Time); ok {
+				assert.True(t, createdAt.Equal(cursor.Value.(time.Time)))
+				return
+			}
+			assert.Equal(t, tt.expected, cursor.Value)
+		})
+	}
+}
+
+// 不正なカーソルは解析せずにErrInvalidCursorを返す
+func TestDecodeItemCursor_Invalid(t *testing.T) {
+	byPrice := entity.ItemSort{Field: entity.SortByPurchasePrice, Order: entity.SortOrderAsc}
+	encode := func(json string) string { return base64.RawURLEncoding.EncodeToString([]byte(json)) }
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"base64でない", "!!!"},
+		{"JSONでない", encode("not json")},
+		{"IDがない", encode(`{"s":"purchase_price","o":"asc","v":1500000}`)},
+		{"値がない", encode(`{"s":"purchase_price","o":"asc","id":12}`)},
+		{"値の型が並び替えの項目と異なる", encode(`{"s":"purchase_price","o":"asc","v":"1500000","id":12}`)},
+		{"並び替えの項目が異なる", encode(`{"s":"name","o":"asc","v":"デイトナ","id":12}`)},
+		{"並び順が異なる", encode(`{"s":"purchase_price","o":"desc","v":1500000,"id":12}`)},
+		{"JSONのnull", encode(`null`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := decodeItemCursor(tt.cursor, byPrice)
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidCursor)
+			assert.Nil(t, cursor)
+		})
+	}
+}
+
+func TestItemUsecase_GetAllItems_Cursor(t *testing.T) {
+	defaultSort := entity.ItemSort{Field: entity.SortByCreatedAt, Order: entity.SortOrderDesc}
+	item := newCursorTestItem(t)
+
+	t.Run("正常系: limit件ちょうどの場合は続きのカーソルを返し、カーソルの位置から取得する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, entity.ItemFilter{}, defaultSort, entity.Pagination{Limit: 1}).Return([]*entity.Item{item}, nil)
+		mockRepo.On("Count", mock.Anything, entity.ItemFilter{}).Return(2, nil)
+		mockRepo.On("FindAll", mock.Anything, entity.ItemFilter{}, defaultSort, mock.MatchedBy(func(page entity.Pagination) bool {
+			return page.Limit == 1 && page.Offset == 0 && page.After != nil && page.After.ID == item.ID && item.CreatedAt.Equal(page.After.Value.(time.Time))
+		})).Return([]*entity.Item{}, nil)
+		uc := NewItemUsecase(mockRepo, newMockCategoryRepository(), nil, newTestExchangeRates(), nil, nil)
+
+		first, err := uc.GetAllItems(context.Background(), ListItemsInput{Limit: 1})
+		require.NoError(t, err)
+		require.NotEmpty(t, first.NextCursor)
+
+		second, err := uc.GetAllItems(context.Background(), ListItemsInput{Limit: 1, Cursor: first.NextCursor})
+		require.NoError(t, err)
+		assert.Empty(t, second.Items)
+		assert.Empty(t, second.NextCursor)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: カーソルとoffsetは同時に指定できない", func(t *testing.T) {
+		cursor, err := encodeItemCursor(defaultSort, item)
+		require.NoError(t, err)
+		uc := NewItemUsecase(new(MockItemRepository), newMockCategoryRepository(), nil, newTestExchangeRates(), nil, nil)
+
+		list, err := uc.GetAllItems(context.Background(), ListItemsInput{Cursor: cursor, Offset: 10})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidCursor)
+		assert.Nil(t, list)
+	})
+
+	t.Run("異常系: 別の並び替えで発行したカーソル", func(t *testing.T) {
+		cursor, err := encodeItemCursor(defaultSort, item)
+		require.NoError(t, err)
+		uc := NewItemUsecase(new(MockItemRepository), newMockCategoryRepository(), nil, newTestExchangeRates(), nil, nil)
+
+		list, err := uc.GetAllItems(context.Background(), ListItemsInput{Cursor: cursor, Sort: entity.ItemSort{Field: entity.SortByName}})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidCursor)
+		assert.Nil(t, list)
+	})
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 保存したアイテムの変更の発行先。エラーを返した場合は変更を取り消す
+type EventPublisher interface {
+	Publish(ctx context.Context, event entity.Event) error
+}
+
+// 発行されたイベントの処理。同期的に呼び出すため、変更と同じトランザクションの中で実行する
+type EventHandler interface {
+	HandleEvent(ctx context.Context, event entity.Event) error
+}
+
+// 発行先を設定しない場合のEventPublisher
+type noPublisher struct{}
+
+func (noPublisher) Publish(ctx context.Context, event entity.Event) error { return nil }
+
+// コンテキストに保持する、実行中のトランザクションのコミット後に実行する処理のキー
+type afterCommitKey struct{}
+
+// transactor.WithinTxでfnを実行し、コミットした場合のみfnの中でAfterCommitに渡したイベントを処理する。
+// 入れ子の呼び出しでは、外側のトランザクションのコミットまで処理を遅らせる
+func (u *itemUsecase) withinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(afterCommitKey{}).(*[]func(context.Context)); ok {
+		return u.transactor.WithinTx(ctx, fn)
+	}
+
+	var pending []func(context.Context)
+	err := u.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		// 一時的なエラーでやり直した場合は、取り消した試行のイベントを処理しない
+		pending = nil
+		return fn(context.WithValue(ctx, afterCommitKey{}, &pending))
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, p := range pending {
+		p(ctx)
+	}
+	return nil
+}
+
+// 保存したアイテムの変更を発行する。withinTxの中で呼び出し、エラーの場合はトランザクションを取り消す
+func (u *itemUsecase) publish(ctx context.Context, eventType entity.EventType, itemID int64, change entity.ItemChange) error {
+	return u.publisher.Publish(ctx, entity.NewItemEvent(eventType, itemID, change))
+}
+
+type afterCommitHandler struct {
+	handler EventHandler
+}
+
+// コミットした後にhandlerを呼び出すEventHandler。Webhookの送信など、取り消せない処理に使う。
+// コミット後のエラーは変更を取り消せないため、ログに出力するだけにする
+func AfterCommit(handler EventHandler) EventHandler {
+	return afterCommitHandler{handler: handler}
+}
+
+func (h afterCommitHandler) HandleEvent(ctx context.Context, event entity.Event) error {
+	if pending, ok := ctx.Value(afterCommitKey{}).(*[]func(context.Context)); ok {
+		*pending = append(*pending, func(ctx context.Context) { h.handle(ctx, event) })
+		return nil
+	}
+	h.handle(ctx, event)
+	return nil
+}
+
+func (h afterCommitHandler) handle(ctx context.Context, event entity.Event) {
+	if err := h.handler.HandleEvent(ctx, event); err != nil {
+		slog.WarnContext(ctx, "⚠️  イベントの処理に失敗しました", "event", event.Type, "item_id", event.ItemID, "error", err)
+	}
+}
@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 受け取ったイベントと、受け取った時点でトランザクションの中だったかどうかを記録し、errを返すEventPublisher兼EventHandler
+type recordingHandler struct {
+	events []entity.Event
+	inTx   []bool
+	err    error
+}
+
+func (h *recordingHandler) Publish(ctx context.Context, event entity.Event) error {
+	return h.HandleEvent(ctx, event)
+}
+
+func (h *recordingHandler) HandleEvent(ctx context.Context, event entity.Event) error {
+	h.events = append(h.events, event)
+	h.inTx = append(h.inTx, inStubTx(ctx))
+	return h.err
+}
+
+func (h *recordingHandler) types() []entity.EventType {
+	types := make([]entity.EventType, len(h.events))
+	for i, event := range h.events {
+		types[i] = event.Type
+	}
+	return types
+}
+
+// 一時的なエラーを模して、fnを1回実行して取り消してからやり直すTransactor
+type retryingTransactor struct {
+	stubTransactor
+}
+
+func (r *retryingTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := r.stubTransactor.WithinTx(ctx, fn); err != nil {
+		return err
+	}
+	return r.stubTransactor.WithinTx(ctx, fn)
+}
+
+func newEventTestItem(id int64) *entity.Item {
+	item, _ := entity.NewItem(entity.NewItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
+	item.ID = id
+	return item
+}
+
+func TestItemUsecase_Publish(t *testing.T) {
+	t.Run("正常系: 削除は変更前後のアイテムをトランザクションの中で発行する", func(t *testing.T) {
+		before := newEventTestItem(1)
+		after := newEventTestItem(1)
+		now := entity.Now()
+		after.DeletedAt = &now
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(before, nil)
+		mockRepo.On("Delete", mock.Anything, int64(1)).Return(&entity.ItemChange{Before: before, After: after}, nil)
+		publisher := &recordingHandler{}
+
+		err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), &stubTransactor{}, publisher).DeleteItem(context.Background(), 1, nil)
+
+		require.NoError(t, err)
+		require.Len(t, publisher.events, 1)
+		assert.Equal(t, entity.EventItemDeleted, publisher.events[0].Type)
+		assert.Equal(t, int64(1), publisher.events[0].ItemID)
+		assert.Same(t, before, publisher.events[0].Before)
+		assert.Same(t, after, publisher.events[0].After)
+		assert.Equal(t, []bool{true}, publisher.inTx)
+	})
+
+	t.Run("正常系: 更新は変更前の内容を残して発行する", func(t *testing.T) {
+		existing := newEventTestItem(1)
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByIDForUpdate", mock.Anything, int64(1)).Return(existing, nil)
+		updated := newEventTestItem(1)
+		updated.Name = "時計2"
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(updated, nil)
+		publisher := &recordingHandler{}
+		name := "時計2"
+		version := existing.Version
+
+		_, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), &stubTransactor{}, publisher).UpdateItem(context.Background(), 1, UpdateItemInput{Name: &name, Version: &version})
+
+		require.NoError(t, err)
+		require.Len(t, publisher.events, 1)
+		assert.Equal(t, entity.EventItemUpdated, publisher.events[0].Type)
+		assert.Equal(t, "時計1", publisher.events[0].Before.Name)
+		assert.Equal(t, "時計2", publisher.events[0].After.Name)
+	})
+
+	t.Run("異常系: 保存に失敗した変更は発行しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newEventTestItem(1), nil)
+		mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil, domainErrors.ErrDatabaseError)
+		publisher := &recordingHandler{}
+
+		err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), &stubTransactor{}, publisher).DeleteItem(context.Background(), 1, nil)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Empty(t, publisher.events)
+	})
+
+	t.Run("異常系: 発行に失敗した場合は変更を取り消す", func(t *testing.T) {
+		item := newEventTestItem(1)
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindImages", mock.Anything, int64(1)).Return([]*entity.ItemImage{{ID: 1, ItemID: 1, URL: "/images/1.jpg"}}, nil)
+		mockRepo.On("HardDelete", mock.Anything, int64(1)).Return(&entity.ItemChange{Before: item}, nil)
+		imageStorage := new(MockImageStorage)
+		failure := errors.New("failure")
+
+		err := NewItemUsecase(mockRepo, newMockCategoryRepository(), imageStorage, newTestExchangeRates(), &stubTransactor{}, &recordingHandler{err: failure}).HardDeleteItem(context.Background(), 1)
+
+		assert.ErrorIs(t, err, failure)
+		// 取り消した場合は画像ファイルも削除しない
+		imageStorage.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAfterCommit(t *testing.T) {
+	t.Run("正常系: やり直したトランザクションでは最後の試行のイベントのみをコミット後に処理する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return([]int64{10, 11}, nil)
+		mockRepo.On("FindByIDs", mock.Anything, []int64{10, 11}).Return([]*entity.Item{newEventTestItem(10), newEventTestItem(11)}, nil)
+		handler := &recordingHandler{}
+		inputs := []CreateItemInput{
+			{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"},
+			{Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2000000, PurchaseDate: "2023-02-20"},
+		}
+
+		_, err := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), &retryingTransactor{}, newAfterCommitPublisher(handler)).BulkCreateItems(context.Background(), inputs)
+
+		require.NoError(t, err)
+		assert.Equal(t, []entity.EventType{entity.EventItemCreated, entity.EventItemCreated}, handler.types())
+		assert.Equal(t, int64(10), handler.events[0].ItemID)
+		assert.Equal(t, int64(11), handler.events[1].ItemID)
+		assert.Equal(t, []bool{false, false}, handler.inTx)
+	})
+
+	t.Run("異常系: 取り消したトランザクションのイベントは処理しない", func(t *testing.T) {
+		item := newEventTestItem(1)
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		mockRepo.On("Delete", mock.Anything, int64(1)).Return(&entity.ItemChange{Before: item, After: item}, nil)
+		handler := &recordingHandler{}
+		failure := errors.New("failure")
+		uc := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), &stubTransactor{}, newAfterCommitPublisher(handler))
+
+		// 一括削除の途中で失敗した場合は、削除できた分も取り消す
+		mockRepo.On("FindByID", mock.Anything, int64(2)).Return(nil, failure)
+		_, err := uc.BulkDeleteItems(context.Background(), []int64{1, 2}, false)
+
+		assert.ErrorIs(t, err, failure)
+		assert.Empty(t, handler.events)
+	})
+
+	t.Run("正常系: トランザクションの外ではすぐに処理し、エラーは返さない", func(t *testing.T) {
+		handler := &recordingHandler{err: errors.New("failure")}
+
+		err := AfterCommit(handler).HandleEvent(context.Background(), entity.Event{Type: entity.EventItemCreated, ItemID: 1})
+
+		assert.NoError(t, err)
+		assert.Len(t, handler.events, 1)
+	})
+}
+
+// 1つのEventHandlerをAfterCommitで包んで発行するEventPublisher
+type afterCommitPublisher struct {
+	handler EventHandler
+}
+
+func newAfterCommitPublisher(handler EventHandler) EventPublisher {
+	return afterCommitPublisher{handler: AfterCommit(handler)}
+}
+
+func (p afterCommitPublisher) Publish(ctx context.Context, event entity.Event) error {
+	return p.handler.HandleEvent(ctx, event)
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 通貨の換算レート。集計時に購入価格を基準通貨に換算するために使う
+type ExchangeRateProvider interface {
+	// BaseCurrency returns the ISO 4217 code of the currency amounts are converted into
+	BaseCurrency() string
+
+	// Rate returns the value of one unit of the currency in the base currency
+	Rate(ctx context.Context, currency string) (float64, error)
+}
+
+// 金額を基準通貨に換算する。端数は四捨五入する
+func (u *itemUsecase) toBaseCurrency(ctx context.Context, amount entity.Money) (entity.Money, error) {
+	rate, err := u.exchangeRates.Rate(ctx, amount.Currency())
+	if err != nil {
+		return entity.Money{}, fmt.Errorf("failed to convert %s to %s: %w", amount.Currency(), u.exchangeRates.BaseCurrency(), err)
+	}
+	return amount.Convert(u.exchangeRates.BaseCurrency(), rate), nil
+}
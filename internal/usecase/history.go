@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ItemHistoryList struct {
+	Histories []*entity.ItemHistory `json:"histories"`
+	Total     int                   `json:"total"`
+	Limit     int                   `json:"limit"`
+	Offset    int                   `json:"offset"`
+}
+
+// アイテムの変更履歴を新しい順に取得する。
+// 物理削除されたアイテムでも履歴が残っていれば返す
+func (u *itemUsecase) GetItemHistory(ctx context.Context, id int64, limit, offset int) (*ItemHistoryList, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	page, err := normalizePagination(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := u.itemRepo.CountHistories(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count item histories: %w", err)
+	}
+
+	// 履歴がない場合は、アイテム自体が存在するかで404を判定する
+	if total == 0 {
+		if _, err := u.itemRepo.FindByID(ctx, id); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return nil, domainErrors.ErrItemNotFound
+			}
+			return nil, fmt.Errorf("failed to retrieve item: %w", err)
+		}
+	}
+
+	histories, err := u.itemRepo.FindHistories(ctx, id, page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve item histories: %w", err)
+	}
+
+	return &ItemHistoryList{
+		Histories: histories,
+		Total:     total,
+		Limit:     page.Limit,
+		Offset:    page.Offset,
+	}, nil
+}
+
+// イベントの種類ごとの履歴の操作。登録は履歴に記録しない
+var historyActions = map[entity.EventType]string{
+	entity.EventItemUpdated:     entity.HistoryActionUpdate,
+	entity.EventItemDeleted:     entity.HistoryActionDelete,
+	entity.EventItemRestored:    entity.HistoryActionRestore,
+	entity.EventItemHardDeleted: entity.HistoryActionHardDelete,
+}
+
+type historyHandler struct {
+	itemRepo ItemRepository
+}
+
+// 変更前後のスナップショットを履歴に記録するEventHandler。
+// 変更と同じトランザクションで記録するため、AfterCommitで包まずに登録する
+func NewHistoryHandler(itemRepo ItemRepository) EventHandler {
+	return &historyHandler{itemRepo: itemRepo}
+}
+
+func (h *historyHandler) HandleEvent(ctx context.Context, event entity.Event) error {
+	action, ok := historyActions[event.Type]
+	if !ok {
+		return nil
+	}
+
+	if err := h.itemRepo.CreateHistory(ctx, event.ItemID, action, event.Before, event.After); err != nil {
+		return fmt.Errorf("failed to record item history: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_GetItemHistory(t *testing.T) {
+	histories := []*entity.ItemHistory{
+		{ID: 2, ItemID: 1, Action: entity.HistoryActionDelete, Before: json.RawMessage(`{"id":1}`), After: json.RawMessage(`{"id":1}`)},
+		{ID: 1, ItemID: 1, Action: entity.HistoryActionUpdate, Before: json.RawMessage(`{"id":1}`), After: json.RawMessage(`{"id":1}`)},
+	}
+
+	tests := []struct {
+		name          string
+		id            int64
+		limit         int
+		offset        int
+		setupMock     func(*MockItemRepository)
+		expectedErr   error
+		expectedCount int
+		expectedLimit int
+	}{
+		{
+			name: "正常系: 履歴をデフォルトのlimitで取得",
+			id:   1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CountHistories", mock.Anything, int64(1)).Return(2, nil)
+				mockRepo.On("FindHistories", mock.Anything, int64(1), entity.Pagination{Limit: DefaultListLimit}).Return(histories, nil)
+			},
+			expectedCount: 2,
+			expectedLimit: DefaultListLimit,
+		},
+		{
+			name:   "正常系: limitが上限を超える場合は上限に丸める",
+			id:     1,
+			limit:  1000,
+			offset: 1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CountHistories", mock.Anything, int64(1)).Return(2, nil)
+				mockRepo.On("FindHistories", mock.Anything, int64(1), entity.Pagination{Limit: MaxListLimit, Offset: 1}).Return(histories[1:], nil)
+			},
+			expectedCount: 1,
+			expectedLimit: MaxListLimit,
+		},
+		{
+			name: "正常系: 履歴がないが存在するアイテム",
+			id:   3,
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem(entity.NewItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
+				item.ID = 3
+				mockRepo.On("CountHistories", mock.Anything, int64(3)).Return(0, nil)
+				mockRepo.On("FindByID", mock.Anything, int64(3)).Return(item, nil)
+				mockRepo.On("FindHistories", mock.Anything, int64(3), entity.Pagination{Limit: DefaultListLimit}).Return([]*entity.ItemHistory{}, nil)
+			},
+			expectedCount: 0,
+			expectedLimit: DefaultListLimit,
+		},
+		{
+			name: "異常系: 履歴もアイテムも存在しない",
+			id:   999,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CountHistories", mock.Anything, int64(999)).Return(0, nil)
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:        "異常系: 無効なID（0以下）",
+			id:          0,
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: 負のoffset",
+			id:          1,
+			offset:      -1,
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: データベースエラー",
+			id:   1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CountHistories", mock.Anything, int64(1)).Return(0, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil)
+
+			result, err := usecase.GetItemHistory(context.Background(), tt.id, tt.limit, tt.offset)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.Histories, tt.expectedCount)
+				assert.Equal(t, tt.expectedLimit, result.Limit)
+				assert.Equal(t, tt.offset, result.Offset)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHistoryHandler_HandleEvent(t *testing.T) {
+	before := &entity.Item{ID: 1, Name: "時計1"}
+	after := &entity.Item{ID: 1, Name: "時計2"}
+
+	tests := []struct {
+		name           string
+		event          entity.Event
+		expectedAction string // 空の場合は記録しない
+		repoErr        error
+	}{
+		{name: "正常系: 登録は記録しない", event: entity.NewItemEvent(entity.EventItemCreated, 1, entity.ItemChange{After: after})},
+		{name: "正常系: 更新", event: entity.NewItemEvent(entity.EventItemUpdated, 1, entity.ItemChange{Before: before, After: after}), expectedAction: entity.HistoryActionUpdate},
+		{name: "正常系: 論理削除", event: entity.NewItemEvent(entity.EventItemDeleted, 1, entity.ItemChange{Before: before, After: after}), expectedAction: entity.HistoryActionDelete},
+		{name: "正常系: 復元", event: entity.NewItemEvent(entity.EventItemRestored, 1, entity.ItemChange{Before: before, After: after}), expectedAction: entity.HistoryActionRestore},
+		{name: "正常系: 物理削除", event: entity.NewItemEvent(entity.EventItemHardDeleted, 1, entity.ItemChange{Before: before}), expectedAction: entity.HistoryActionHardDelete},
+		{name: "異常系: データベースエラー", event: entity.NewItemEvent(entity.EventItemUpdated, 1, entity.ItemChange{Before: before, After: after}), expectedAction: entity.HistoryActionUpdate, repoErr: domainErrors.ErrDatabaseError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			if tt.expectedAction != "" {
+				mockRepo.On("CreateHistory", mock.Anything, int64(1), tt.expectedAction, tt.event.Before, tt.event.After).Return(tt.repoErr)
+			}
+
+			err := NewHistoryHandler(mockRepo).HandleEvent(context.Background(), tt.event)
+
+			if tt.repoErr != nil {
+				assert.ErrorIs(t, err, tt.repoErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 冪等キーを指定してアイテムを登録する。
+// 有効期間内に同じキーで登録済みの場合は新たに作成せず、最初に作成したアイテムをreplayed=trueで返す。
+// 登録済みのキーとリクエスト内容が異なる場合はErrIdempotencyKeyMismatchを返す
+func (u *itemUsecase) CreateItemWithIdempotencyKey(ctx context.Context, key string, input CreateItemInput) (item *entity.Item, replayed bool, err error) {
+	requestHash, err := hashCreateItemInput(input)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to hash request: %w", err)
+	}
+	createdAfter := entity.Now().Add(-entity.IdempotencyKeyTTL)
+
+	// 再送の大半はここで見つかるため、カテゴリーの取得やバリデーションより先に確認する
+	stored, err := u.itemRepo.FindIdempotencyKey(ctx, key, createdAfter)
+	if err == nil {
+		return u.replayCreatedItem(ctx, stored, requestHash)
+	}
+	if !errors.Is(err, domainErrors.ErrIdempotencyKeyNotFound) {
+		return nil, false, fmt.Errorf("failed to retrieve idempotency key: %w", err)
+	}
+
+	categories, err := u.categories(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	newItem, err := newItemFromInput(input, categories)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !input.Force {
+		if err := u.checkDuplicateItem(ctx, newItem); err != nil {
+			return nil, false, err
+		}
+	}
+
+	idempotencyKey := &entity.IdempotencyKey{Key: key, RequestHash: requestHash}
+	var createdItem *entity.Item
+	err = u.withinTx(ctx, func(ctx context.Context) error {
+		created, err := u.itemRepo.CreateWithIdempotencyKey(ctx, newItem, idempotencyKey, createdAfter)
+		if err != nil {
+			return err
+		}
+		createdItem = created
+		return u.publish(ctx, entity.EventItemCreated, created.ID, entity.ItemChange{After: created})
+	})
+	if err == nil {
+		return createdItem, false, nil
+	}
+	if !errors.Is(err, domainErrors.ErrIdempotencyKeyExists) {
+		return nil, false, fmt.Errorf("failed to create item: %w", err)
+	}
+
+	// 同じキーの同時リクエストに先を越された場合は、そちらの結果を返す
+	stored, err = u.itemRepo.FindIdempotencyKey(ctx, key, createdAfter)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to retrieve idempotency key: %w", err)
+	}
+	return u.replayCreatedItem(ctx, stored, requestHash)
+}
+
+// 登録済みの冪等キーで作成したアイテムを返す
+func (u *itemUsecase) replayCreatedItem(ctx context.Context, stored *entity.IdempotencyKey, requestHash string) (*entity.Item, bool, error) {
+	if stored.RequestHash != requestHash {
+		return nil, false, domainErrors.ErrIdempotencyKeyMismatch
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, stored.ItemID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return item, true, nil
+}
+
+// 有効期間を過ぎた冪等キーを削除し、削除した件数を返す
+func (u *itemUsecase) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	deleted, err := u.itemRepo.DeleteExpiredIdempotencyKeys(ctx, entity.Now().Add(-entity.IdempotencyKeyTTL))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// 登録の入力のハッシュ。同じキーで異なる内容が送られたことを検出するために使う
+func hashCreateItemInput(input CreateItemInput) (string, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
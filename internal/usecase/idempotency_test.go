@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/testutil"
+)
+
+func TestItemUsecase_CreateItemWithIdempotencyKey(t *testing.T) {
+	fixedNow := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	createdAfter := fixedNow.Add(-entity.IdempotencyKeyTTL)
+
+	input := CreateItemInput{
+		Name:          "ロレックス デイトナ",
+		Category:      "時計",
+		Brand:         "ROLEX",
+		PurchasePrice: 1500000,
+		PurchaseDate:  "2023-01-15",
+	}
+	requestHash, err := hashCreateItemInput(input)
+	assert.NoError(t, err)
+
+	createdItem, _ := entity.NewItem(entity.NewItemInput{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15"), Categories: testCategories})
+	createdItem.ID = 1
+
+	storedKey := &entity.IdempotencyKey{Key: "key-1", RequestHash: requestHash, ItemID: 1, CreatedAt: fixedNow.Add(-time.Hour)}
+
+	tests := []struct {
+		name             string
+		input            CreateItemInput
+		setupMock        func(*MockItemRepository)
+		expectedErr      error
+		expectedReplayed bool
+	}{
+		{
+			name:  "正常系: 新しいキーでアイテムを作成",
+			input: input,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindIdempotencyKey", mock.Anything, "key-1", createdAfter).Return(nil, domainErrors.ErrIdempotencyKeyNotFound)
+				mockRepo.On("FindByPurchaseDate", mock.Anything, mock.Anything).Return([]*entity.Item{}, nil)
+				mockRepo.On("CreateWithIdempotencyKey", mock.Anything, mock.AnythingOfType("*entity.Item"), &entity.IdempotencyKey{Key: "key-1", RequestHash: requestHash}, createdAfter).Return(createdItem, nil)
+			},
+		},
+		{
+			name:  "正常系: 登録済みのキーでは最初に作成したアイテムを返す",
+			input: input,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindIdempotencyKey", mock.Anything, "key-1", createdAfter).Return(storedKey, nil)
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(createdItem, nil)
+			},
+			expectedReplayed: true,
+		},
+		{
+			name:  "正常系: 同時リクエストに先を越された場合はそちらのアイテムを返す",
+			input: input,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindIdempotencyKey", mock.Anything, "key-1", createdAfter).Return(nil, domainErrors.ErrIdempotencyKeyNotFound).Once()
+				mockRepo.On("FindByPurchaseDate", mock.Anything, mock.Anything).Return([]*entity.Item{}, nil)
+				mockRepo.On("CreateWithIdempotencyKey", mock.Anything, mock.AnythingOfType("*entity.Item"), mock.Anything, createdAfter).Return(nil, domainErrors.ErrIdempotencyKeyExists)
+				mockRepo.On("FindIdempotencyKey", mock.Anything, "key-1", createdAfter).Return(storedKey, nil).Once()
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(createdItem, nil)
+			},
+			expectedReplayed: true,
+		},
+		{
+			name: "異常系: 登録済みのキーで内容が異なる",
+			input: CreateItemInput{
+				Name:          "ロレックス サブマリーナ",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: 1500000,
+				PurchaseDate:  "2023-01-15",
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindIdempotencyKey", mock.Anything, "key-1", createdAfter).Return(storedKey, nil)
+			},
+			expectedErr: domainErrors.ErrIdempotencyKeyMismatch,
+		},
+		{
+			name: "異常系: 無効な入力ではキーを登録しない",
+			input: CreateItemInput{
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: 1500000,
+				PurchaseDate:  "2023-01-15",
+			},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindIdempotencyKey", mock.Anything, "key-1", createdAfter).Return(nil, domainErrors.ErrIdempotencyKeyNotFound)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			input: input,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindIdempotencyKey", mock.Anything, "key-1", createdAfter).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer entity.SetClock(testutil.NewFixedClock(fixedNow))()
+
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil)
+
+			item, replayed, err := usecase.CreateItemWithIdempotencyKey(context.Background(), "key-1", tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, item)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, createdItem, item)
+			}
+			assert.Equal(t, tt.expectedReplayed, replayed)
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_DeleteExpiredIdempotencyKeys(t *testing.T) {
+	fixedNow := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	defer entity.SetClock(testutil.NewFixedClock(fixedNow))()
+
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("DeleteExpiredIdempotencyKeys", mock.Anything, fixedNow.Add(-24*time.Hour)).Return(int64(3), nil)
+	usecase := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil)
+
+	deleted, err := usecase.DeleteExpiredIdempotencyKeys(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// アップロードできる画像の形式と保存時の拡張子
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+type ItemImageUsecase interface {
+	ListItemImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error)
+	AddItemImage(ctx context.Context, itemID int64, r io.Reader) (*entity.ItemImage, error)
+	DeleteItemImage(ctx context.Context, itemID, imageID int64) error
+	ReorderItemImages(ctx context.Context, itemID int64, imageIDs []int64) ([]*entity.ItemImage, error)
+	ListImagesByItemIDs(ctx context.Context, itemIDs []int64) (map[int64][]*entity.ItemImage, error)
+}
+
+type itemImageUsecase struct {
+	itemRepo     ItemRepository
+	imageStorage ImageStorage
+	maxSize      int64 // 画像の最大サイズ（バイト）
+}
+
+func NewItemImageUsecase(itemRepo ItemRepository, imageStorage ImageStorage, maxSize int64) ItemImageUsecase {
+	return &itemImageUsecase{
+		itemRepo:     itemRepo,
+		imageStorage: imageStorage,
+		maxSize:      maxSize,
+	}
+}
+
+func (u *itemImageUsecase) ListItemImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	if err := u.ensureItemExists(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	images, err := u.itemRepo.FindImages(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve item images: %w", err)
+	}
+
+	return images, nil
+}
+
+// 複数のアイテムの画像をアイテムのIDごとに返す。一覧の各アイテムの画像をまとめて読み込むために使い、
+// アイテムごとに問い合わせる代わりに、参照できるアイテムの確認と画像の取得をそれぞれ1回のクエリで行う。
+// 存在しないか参照できないアイテムと、画像のないアイテムは結果に含まれない
+func (u *itemImageUsecase) ListImagesByItemIDs(ctx context.Context, itemIDs []int64) (map[int64][]*entity.ItemImage, error) {
+	unique, err := uniqueIDs(itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(unique) > MaxListLimit {
+		return nil, fmt.Errorf("%w: at most %d ids can be requested at once", domainErrors.ErrInvalidInput, MaxListLimit)
+	}
+
+	items, err := u.itemRepo.FindByIDs(ctx, unique)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	if len(items) == 0 {
+		return map[int64][]*entity.ItemImage{}, nil
+	}
+	visible := make([]int64, len(items))
+	for i, item := range items {
+		visible[i] = item.ID
+	}
+
+	images, err := u.itemRepo.FindImagesByItemIDs(ctx, visible)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve item images: %w", err)
+	}
+
+	return images, nil
+}
+
+// 画像を保存し、アイテムの画像の末尾に追加する
+func (u *itemImageUsecase) AddItemImage(ctx context.Context, itemID int64, r io.Reader) (*entity.ItemImage, error) {
+	if err := u.ensureItemExists(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	// 上限を1バイト超えて読めた場合はサイズ超過とみなす
+	data, err := io.ReadAll(io.LimitReader(r, u.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read image: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	if int64(len(data)) > u.maxSize {
+		return nil, fmt.Errorf("%w: image must be %d bytes or smaller", domainErrors.ErrFileTooLarge, u.maxSize)
+	}
+
+	ext, ok := imageExtensions[http.DetectContentType(data)]
+	if !ok {
+		return nil, fmt.Errorf("%w: image must be JPEG or PNG", domainErrors.ErrInvalidInput)
+	}
+
+	// 同じアイテムの画像同士で衝突しないよう、アップロードごとに別名で保存する
+	name := fmt.Sprintf("item_%d_%d%s", itemID, time.Now().UnixNano(), ext)
+	url, err := u.imageStorage.Save(ctx, name, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save image: %w", err)
+	}
+
+	image, err := u.itemRepo.AddImage(ctx, itemID, url, entity.MaxImagesPerItem)
+	if err != nil {
+		// 参照されないファイルが残らないよう保存した画像を削除する
+		removeImageFile(ctx, u.imageStorage, url)
+		if domainErrors.IsNotFoundError(err) || domainErrors.IsValidationError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to add item image: %w", err)
+	}
+
+	return image, nil
+}
+
+func (u *itemImageUsecase) DeleteItemImage(ctx context.Context, itemID, imageID int64) error {
+	if imageID <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+	if err := u.ensureItemExists(ctx, itemID); err != nil {
+		return err
+	}
+
+	images, err := u.itemRepo.FindImages(ctx, itemID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve item images: %w", err)
+	}
+	var target *entity.ItemImage
+	for _, image := range images {
+		if image.ID == imageID {
+			target = image
+			break
+		}
+	}
+	if target == nil {
+		return domainErrors.ErrImageNotFound
+	}
+
+	if err := u.itemRepo.DeleteImage(ctx, itemID, imageID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrImageNotFound
+		}
+		return fmt.Errorf("failed to delete item image: %w", err)
+	}
+
+	removeImageFile(ctx, u.imageStorage, target.URL)
+
+	return nil
+}
+
+// imageIDsの順に画像を並べ替え、並べ替え後の画像を返す
+func (u *itemImageUsecase) ReorderItemImages(ctx context.Context, itemID int64, imageIDs []int64) ([]*entity.ItemImage, error) {
+	if err := u.ensureItemExists(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	if err := u.itemRepo.ReorderImages(ctx, itemID, imageIDs); err != nil {
+		if domainErrors.IsValidationError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to reorder item images: %w", err)
+	}
+
+	images, err := u.itemRepo.FindImages(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve item images: %w", err)
+	}
+
+	return images, nil
+}
+
+func (u *itemImageUsecase) ensureItemExists(ctx context.Context, itemID int64) error {
+	if itemID <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	if _, err := u.itemRepo.FindByID(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemNotFound
+		}
+		return fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return nil
+}
+
+// データベースの更新後に行うファイル削除。失敗してもリクエスト自体は成功として扱う
+func removeImageFile(ctx context.Context, imageStorage ImageStorage, url string) {
+	if err := imageStorage.Delete(ctx, url); err != nil {
+		slog.WarnContext(ctx, "failed to delete image file", "url", url, "error", err)
+	}
+}
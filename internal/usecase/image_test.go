@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockImageStorage struct {
+	mock.Mock
+}
+
+func (m *MockImageStorage) Save(ctx context.Context, name string, r io.Reader) (string, error) {
+	args := m.Called(ctx, name, r)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockImageStorage) Delete(ctx context.Context, url string) error {
+	args := m.Called(ctx, url)
+	return args.Error(0)
+}
+
+// PNGのシグネチャを先頭に持つデータ
+var pngData = append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 16)...)
+
+func newImageTestItem() *entity.Item {
+	item, _ := entity.NewItem(entity.NewItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
+	item.ID = 1
+	return item
+}
+
+func TestItemImageUsecase_AddItemImage(t *testing.T) {
+	url := "/images/item_1_new.png"
+	pngName := mock.MatchedBy(func(name string) bool {
+		return strings.HasPrefix(name, "item_1_") && strings.HasSuffix(name, ".png")
+	})
+
+	tests := []struct {
+		name        string
+		id          int64
+		data        []byte
+		setupMock   func(*MockItemRepository, *MockImageStorage)
+		expectedErr error
+	}{
+		{
+			name: "正常系: 画像を追加",
+			id:   1,
+			data: pngData,
+			setupMock: func(mockRepo *MockItemRepository, mockStorage *MockImageStorage) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+				mockStorage.On("Save", mock.Anything, pngName, mock.Anything).Return(url, nil)
+				mockRepo.On("AddImage", mock.Anything, int64(1), url, entity.MaxImagesPerItem).
+					Return(&entity.ItemImage{ID: 1, ItemID: 1, URL: url, DisplayOrder: 1}, nil)
+			},
+		},
+		{
+			name: "異常系: 上限を超える場合は保存したファイルを削除",
+			id:   1,
+			data: pngData,
+			setupMock: func(mockRepo *MockItemRepository, mockStorage *MockImageStorage) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+				mockStorage.On("Save", mock.Anything, pngName, mock.Anything).Return(url, nil)
+				mockRepo.On("AddImage", mock.Anything, int64(1), url, entity.MaxImagesPerItem).Return(nil, domainErrors.ErrImageLimitExceeded)
+				mockStorage.On("Delete", mock.Anything, url).Return(nil)
+			},
+			expectedErr: domainErrors.ErrImageLimitExceeded,
+		},
+		{
+			name: "異常系: 存在しないアイテム",
+			id:   999,
+			data: pngData,
+			setupMock: func(mockRepo *MockItemRepository, mockStorage *MockImageStorage) {
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name: "異常系: JPEG/PNG以外の形式",
+			id:   1,
+			data: []byte("GIF89a..."),
+			setupMock: func(mockRepo *MockItemRepository, mockStorage *MockImageStorage) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: サイズ上限を超える",
+			id:   1,
+			data: append(pngData, make([]byte, 100)...),
+			setupMock: func(mockRepo *MockItemRepository, mockStorage *MockImageStorage) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+			},
+			expectedErr: domainErrors.ErrFileTooLarge,
+		},
+		{
+			name:        "異常系: 無効なID（0以下）",
+			id:          0,
+			data:        pngData,
+			setupMock:   func(mockRepo *MockItemRepository, mockStorage *MockImageStorage) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			mockStorage := new(MockImageStorage)
+			tt.setupMock(mockRepo, mockStorage)
+			usecase := NewItemImageUsecase(mockRepo, mockStorage, 64)
+
+			image, err := usecase.AddItemImage(context.Background(), tt.id, bytes.NewReader(tt.data))
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, image)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, url, image.URL)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockStorage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemImageUsecase_DeleteItemImage(t *testing.T) {
+	images := []*entity.ItemImage{
+		{ID: 1, ItemID: 1, URL: "/images/item_1_1.jpg", DisplayOrder: 1},
+		{ID: 2, ItemID: 1, URL: "/images/item_1_2.jpg", DisplayOrder: 2},
+	}
+
+	tests := []struct {
+		name        string
+		imageID     int64
+		setupMock   func(*MockItemRepository, *MockImageStorage)
+		expectedErr error
+	}{
+		{
+			name:    "正常系: 画像を削除",
+			imageID: 2,
+			setupMock: func(mockRepo *MockItemRepository, mockStorage *MockImageStorage) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+				mockRepo.On("FindImages", mock.Anything, int64(1)).Return(images, nil)
+				mockRepo.On("DeleteImage", mock.Anything, int64(1), int64(2)).Return(nil)
+				mockStorage.On("Delete", mock.Anything, "/images/item_1_2.jpg").Return(nil)
+			},
+		},
+		{
+			name:    "正常系: ファイル削除に失敗しても成功として扱う",
+			imageID: 1,
+			setupMock: func(mockRepo *MockItemRepository, mockStorage *MockImageStorage) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+				mockRepo.On("FindImages", mock.Anything, int64(1)).Return(images, nil)
+				mockRepo.On("DeleteImage", mock.Anything, int64(1), int64(1)).Return(nil)
+				mockStorage.On("Delete", mock.Anything, "/images/item_1_1.jpg").Return(errors.New("permission denied"))
+			},
+		},
+		{
+			name:    "異常系: 他のアイテムの画像",
+			imageID: 99,
+			setupMock: func(mockRepo *MockItemRepository, mockStorage *MockImageStorage) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+				mockRepo.On("FindImages", mock.Anything, int64(1)).Return(images, nil)
+			},
+			expectedErr: domainErrors.ErrImageNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			mockStorage := new(MockImageStorage)
+			tt.setupMock(mockRepo, mockStorage)
+			usecase := NewItemImageUsecase(mockRepo, mockStorage, 64)
+
+			err := usecase.DeleteItemImage(context.Background(), 1, tt.imageID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockStorage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemImageUsecase_ReorderItemImages(t *testing.T) {
+	t.Run("正常系: 並べ替え後の画像を返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		mockRepo.On("ReorderImages", mock.Anything, int64(1), []int64{2, 1}).Return(nil)
+		mockRepo.On("FindImages", mock.Anything, int64(1)).Return([]*entity.ItemImage{
+			{ID: 2, ItemID: 1, DisplayOrder: 1},
+			{ID: 1, ItemID: 1, DisplayOrder: 2},
+		}, nil)
+		usecase := NewItemImageUsecase(mockRepo, new(MockImageStorage), 64)
+
+		images, err := usecase.ReorderItemImages(context.Background(), 1, []int64{2, 1})
+
+		require.NoError(t, err)
+		require.Len(t, images, 2)
+		assert.Equal(t, int64(2), images[0].ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 画像の指定に過不足がある", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		mockRepo.On("ReorderImages", mock.Anything, int64(1), []int64{2}).Return(domainErrors.ErrInvalidInput)
+		usecase := NewItemImageUsecase(mockRepo, new(MockImageStorage), 64)
+
+		images, err := usecase.ReorderItemImages(context.Background(), 1, []int64{2})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Nil(t, images)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemImageUsecase_ListImagesByItemIDs(t *testing.T) {
+	t.Run("正常系: 参照できるアイテムの画像のみまとめて取得する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		// アイテム2は存在しないか他のユーザーのアイテム
+		mockRepo.On("FindByIDs", mock.Anything, []int64{1, 2}).Return([]*entity.Item{newImageTestItem()}, nil)
+		mockRepo.On("FindImagesByItemIDs", mock.Anything, []int64{1}).Return(map[int64][]*entity.ItemImage{
+			1: {{ID: 1, ItemID: 1, DisplayOrder: 1}},
+		}, nil)
+		usecase := NewItemImageUsecase(mockRepo, new(MockImageStorage), 64)
+
+		images, err := usecase.ListImagesByItemIDs(context.Background(), []int64{1, 2, 1})
+
+		require.NoError(t, err)
+		assert.Len(t, images, 1)
+		assert.Len(t, images[1], 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 参照できるアイテムがない場合は画像を問い合わせない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByIDs", mock.Anything, []int64{2}).Return([]*entity.Item{}, nil)
+		usecase := NewItemImageUsecase(mockRepo, new(MockImageStorage), 64)
+
+		images, err := usecase.ListImagesByItemIDs(context.Background(), []int64{2})
+
+		require.NoError(t, err)
+		assert.Empty(t, images)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 不正なID", func(t *testing.T) {
+		usecase := NewItemImageUsecase(new(MockItemRepository), new(MockImageStorage), 64)
+
+		_, err := usecase.ListImagesByItemIDs(context.Background(), []int64{0})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
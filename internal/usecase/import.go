@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// CSVインポートで必須となる列。currency列は任意で、ない場合や空の場合はJPYとする。serial_number列、condition列、notes列、purchase_location列も任意
+var importColumns = []string{"name", "category", "brand", "purchase_price", "purchase_date"}
+
+type ImportOptions struct {
+	// trueの場合は有効な行のみ登録し、falseの場合は1行でもエラーがあれば何も登録しない
+	BestEffort bool
+	// trueの場合は検証のみ行い、データベースには書き込まない
+	DryRun bool
+	// 解析したデータ行の件数を一定の行数ごとに受け取る。nilの場合は通知しない
+	OnProgress func(rows int)
+}
+
+// 解析の進捗を通知する間隔（行数）
+const importProgressInterval = 100
+
+type ImportResult struct {
+	DryRun    bool                    `json:"dry_run"`
+	Rows      int                     `json:"rows"`      // 解析したデータ行の件数
+	Succeeded int                     `json:"succeeded"` // ドライランの場合は登録される予定の件数
+	Failed    int                     `json:"failed"`
+	Errors    []entity.ImportRowError `json:"errors"`
+}
+
+func (u *itemUsecase) ImportItems(ctx context.Context, r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	categories, err := u.categories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, rowErrors, err := parseImportCSV(r, categories, opts.OnProgress)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		DryRun: opts.DryRun,
+		Rows:   len(items) + len(rowErrors),
+		Failed: len(rowErrors),
+		Errors: rowErrors,
+	}
+
+	if len(rowErrors) > 0 && !opts.BestEffort {
+		return result, nil
+	}
+
+	if len(items) > 0 && !opts.DryRun {
+		// 監査ログには登録ではなくインポートとして記録する
+		err := u.withinTx(withAuditAction(ctx, entity.AuditActionImport), func(ctx context.Context) error {
+			ids, err := u.itemRepo.CreateMany(ctx, items)
+			if err != nil {
+				return fmt.Errorf("failed to import items: %w", err)
+			}
+			// 件数が多い場合があるため、登録したアイテムを取得し直さずに入力の内容で発行する
+			for i, item := range items {
+				item.ID = ids[i]
+				if err := u.publish(ctx, entity.EventItemCreated, item.ID, entity.ItemChange{After: item}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	result.Succeeded = len(items)
+
+	return result, nil
+}
+
+// CSVを解析し、有効な行のエンティティと無効な行のエラーを返す。
+// ファイル自体が不正な場合（空、ヘッダー不足など）はエラーを返す。progressがnilでない場合は一定の行数ごとに解析済みの行数を渡す
+func parseImportCSV(r io.Reader, categories entity.CategoryLookup, progress func(rows int)) ([]*entity.Item, []entity.ImportRowError, error) {
+	reader := csv.NewReader(r)
+	// 列数の不一致は行ごとのエラーとして扱う
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("%w: file is empty", domainErrors.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to read header row: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	columnIndex, err := parseImportHeader(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]*entity.Item, 0)
+	rowErrors := make([]entity.ImportRowError, 0)
+	// ファイル内で重複する行の検出用（キー: 正規化した内容, 値: 最初に出現した行番号）
+	seen := make(map[string]int)
+	// シリアル番号が重複する行の検出用（キー: 小文字にしたシリアル番号）
+	seenSerialNumbers := make(map[string]int)
+	for rowNum := 2; ; rowNum++ {
+		if rows := rowNum - 2; progress != nil && rows > 0 && rows%importProgressInterval == 0 {
+			progress(rows)
+		}
+
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, entity.ImportRowError{Row: rowNum, Message: err.Error()})
+			continue
+		}
+
+		if len(record) != len(header) {
+			rowErrors = append(rowErrors, entity.ImportRowError{
+				Row:     rowNum,
+				Message: fmt.Sprintf("expected %d columns but got %d", len(header), len(record)),
+			})
+			continue
+		}
+
+		item, err := parseImportRecord(record, columnIndex, categories)
+		if err != nil {
+			rowErrors = append(rowErrors, entity.ImportRowError{Row: rowNum, Message: err.Error()})
+			continue
+		}
+
+		key := importDuplicateKey(item)
+		if firstRow, ok := seen[key]; ok {
+			rowErrors = append(rowErrors, entity.ImportRowError{
+				Row:     rowNum,
+				Message: fmt.Sprintf("duplicate of row %d", firstRow),
+			})
+			continue
+		}
+
+		if item.SerialNumber != nil {
+			serialKey := strings.ToLower(*item.SerialNumber)
+			if firstRow, ok := seenSerialNumbers[serialKey]; ok {
+				rowErrors = append(rowErrors, entity.ImportRowError{
+					Row:     rowNum,
+					Message: fmt.Sprintf("serial_number duplicates row %d", firstRow),
+				})
+				continue
+			}
+			seenSerialNumbers[serialKey] = rowNum
+		}
+		seen[key] = rowNum
+
+		items = append(items, item)
+	}
+
+	return items, rowErrors, nil
+}
+
+// ヘッダー行から列名と位置の対応を作る。列の順序は問わない
+func parseImportHeader(header []string) (map[string]int, error) {
+	columnIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		name = entity.NormalizeText(name)
+		if i == 0 {
+			// エクスポート時に付与したBOMを取り除く
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columnIndex[strings.ToLower(name)] = i
+	}
+
+	var missing []string
+	for _, column := range importColumns {
+		if _, ok := columnIndex[column]; !ok {
+			missing = append(missing, column)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: header row is missing columns: %s", domainErrors.ErrInvalidInput, strings.Join(missing, ", "))
+	}
+
+	return columnIndex, nil
+}
+
+// 名前・ブランド・購入日が同じ行を重複とみなす
+func importDuplicateKey(item *entity.Item) string {
+	return strings.ToLower(item.Name) + "\x00" + strings.ToLower(item.Brand) + "\x00" + item.PurchaseDate.String()
+}
+
+func parseImportRecord(record []string, columnIndex map[string]int, categories entity.CategoryLookup) (*entity.Item, error) {
+	priceStr := strings.TrimSpace(record[columnIndex["purchase_price"]])
+	price, err := strconv.ParseInt(priceStr, 10, 64)
+	if err != nil {
+		return nil, errors.New("purchase_price must be an integer")
+	}
+
+	var currency string
+	if i, ok := columnIndex["currency"]; ok {
+		currency = record[i]
+	}
+
+	var serialNumber, condition, notes, purchaseLocation string
+	if i, ok := columnIndex["serial_number"]; ok {
+		serialNumber = record[i]
+	}
+	if i, ok := columnIndex["condition"]; ok {
+		condition = record[i]
+	}
+	if i, ok := columnIndex["notes"]; ok {
+		notes = record[i]
+	}
+	if i, ok := columnIndex["purchase_location"]; ok {
+		purchaseLocation = record[i]
+	}
+
+	purchaseDate, err := parseInputPurchaseDate(record[columnIndex["purchase_date"]])
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewItem(entity.NewItemInput{
+		Name:             record[columnIndex["name"]],
+		Category:         record[columnIndex["category"]],
+		Brand:            record[columnIndex["brand"]],
+		PurchasePrice:    price,
+		Currency:         currency,
+		PurchaseDate:     purchaseDate,
+		SerialNumber:     serialNumber,
+		Condition:        condition,
+		Notes:            notes,
+		PurchaseLocation: purchaseLocation,
+		Categories:       categories,
+	})
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"Aicon-assignment/internal/clientip"
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+// サーバーの終了で中断したジョブに記録する理由
+const importInterruptedReason = "the import was interrupted because the server stopped"
+
+// 全件モードで無効な行があったジョブに記録する理由
+const importRowErrorsReason = "no items were imported because some rows have errors"
+
+type ImportJobUsecase interface {
+	// ファイルを一時ファイルに書き出してジョブを登録し、キューに入れてすぐに戻る
+	StartImport(ctx context.Context, r io.Reader, opts ImportOptions) (*entity.ImportJob, error)
+	GetImportJob(ctx context.Context, id int64) (*entity.ImportJob, error)
+	// キューから取り出したジョブを実行し、結果を記録する。ワーカーから呼び出す
+	RunImportJob(ctx context.Context, task ImportTask)
+	// 終了していないジョブを失敗にする。サーバーの起動時と終了時に呼び出す
+	FailUnfinishedImportJobs(ctx context.Context) (int64, error)
+	DeleteExpiredImportJobs(ctx context.Context) (int64, error)
+}
+
+// ワーカーに渡すインポートのジョブ。アップロードされたファイルはメモリ上に保持せず、一時ファイルに書き出す
+type ImportTask struct {
+	JobID   int64
+	File    string // アップロードされたファイルを書き出した一時ファイルのパス。ジョブの実行後に削除する
+	Options ImportOptions
+	// ジョブを登録したクライアント。ワーカーはリクエストのctxを引き継がないため、インポートするアイテムの所有者を決めるのに使う。認証していない場合はnil
+	Principal *principal.Principal
+	// ジョブを登録したクライアントのIPアドレス。監査ログに記録する
+	ClientIP string
+}
+
+// ジョブの一時ファイルを削除する。実行しなかったジョブの一時ファイルも残らないよう、キューから捨てるときにも呼び出す
+func (t ImportTask) Discard() {
+	if t.File == "" {
+		return
+	}
+	if err := os.Remove(t.File); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.Warn("⚠️  インポートの一時ファイルの削除に失敗しました", "job_id", t.JobID, "file", t.File, "error", err)
+	}
+}
+
+// インポートのジョブの実行待ちのキュー。Enqueueはすぐに戻り、キューが一杯の場合はErrImportQueueFullを返す
+type ImportJobQueue interface {
+	Enqueue(task ImportTask) error
+}
+
+type importJobUsecase struct {
+	jobRepo     ImportJobRepository
+	itemUsecase ItemUsecase
+	queue       ImportJobQueue
+	maxSize     int64 // アップロードできるファイルの最大サイズ（バイト）
+}
+
+func NewImportJobUsecase(jobRepo ImportJobRepository, itemUsecase ItemUsecase, queue ImportJobQueue, maxSize int64) ImportJobUsecase {
+	return &importJobUsecase{
+		jobRepo:     jobRepo,
+		itemUsecase: itemUsecase,
+		queue:       queue,
+		maxSize:     maxSize,
+	}
+}
+
+func (u *importJobUsecase) StartImport(ctx context.Context, r io.Reader, opts ImportOptions) (*entity.ImportJob, error) {
+	file, err := u.saveUpload(r)
+	if err != nil {
+		return nil, err
+	}
+	task := ImportTask{File: file, Options: opts, ClientIP: clientip.From(ctx)}
+
+	job, err := u.jobRepo.Create(ctx, entity.NewImportJob(opts.BestEffort, opts.DryRun))
+	if err != nil {
+		task.Discard()
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	task.JobID = job.ID
+	if p, ok := principal.From(ctx); ok {
+		task.Principal = &p
+	}
+	if err := u.queue.Enqueue(task); err != nil {
+		task.Discard()
+		// 実行されないジョブが処理待ちのまま残らないよう失敗にしておく
+		job.Fail(err.Error())
+		if finishErr := u.jobRepo.Finish(ctx, job); finishErr != nil {
+			slog.WarnContext(ctx, "⚠️  インポートのジョブの失敗の記録に失敗しました", "job_id", job.ID, "error", finishErr)
+		}
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// アップロードされたファイルを読み込みながら一時ファイルに書き出し、そのパスを返す。
+// ファイル全体をメモリ上に保持しないため、大きなファイルでもメモリを使い切らない
+func (u *importJobUsecase) saveUpload(r io.Reader) (string, error) {
+	f, err := os.CreateTemp("", "import-*.csv")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	remove := func() {
+		if err := os.Remove(f.Name()); err != nil {
+			slog.Warn("⚠️  インポートの一時ファイルの削除に失敗しました", "file", f.Name(), "error", err)
+		}
+	}
+
+	// 上限を1バイト超えて読めた場合はサイズ超過とみなす
+	n, copyErr := io.Copy(f, io.LimitReader(r, u.maxSize+1))
+	if err := f.Close(); err != nil && copyErr == nil {
+		remove()
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	switch {
+	case errors.Is(copyErr, domainErrors.ErrFileTooLarge):
+		// リクエストボディの上限を超えた
+		remove()
+		return "", copyErr
+	case copyErr != nil:
+		remove()
+		return "", fmt.Errorf("%w: failed to read file: %s", domainErrors.ErrInvalidInput, copyErr.Error())
+	case n > u.maxSize:
+		remove()
+		return "", fmt.Errorf("%w: file must be %d bytes or smaller", domainErrors.ErrFileTooLarge, u.maxSize)
+	}
+	return f.Name(), nil
+}
+
+func (u *importJobUsecase) GetImportJob(ctx context.Context, id int64) (*entity.ImportJob, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	job, err := u.jobRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrImportJobNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve import job: %w", err)
+	}
+
+	return job, nil
+}
+
+func (u *importJobUsecase) RunImportJob(ctx context.Context, task ImportTask) {
+	defer task.Discard()
+	job := &entity.ImportJob{ID: task.JobID, Errors: []entity.ImportRowError{}}
+	defer u.finish(ctx, job)
+
+	if err := u.jobRepo.Start(ctx, task.JobID); err != nil {
+		slog.WarnContext(ctx, "⚠️  インポートのジョブを開始できませんでした", "job_id", task.JobID, "error", err)
+		job.Fail("failed to start the import")
+		return
+	}
+
+	// アイテムはジョブを登録したユーザーのものとして登録し、監査ログにはジョブを登録したクライアントを記録する
+	importCtx := ctx
+	if task.ClientIP != "" {
+		importCtx = clientip.NewContext(importCtx, task.ClientIP)
+	}
+	if task.Principal != nil {
+		importCtx = principal.NewContext(importCtx, *task.Principal)
+	}
+
+	opts := task.Options
+	opts.OnProgress = func(rows int) {
+		if err := u.jobRepo.UpdateProgress(ctx, task.JobID, rows); err != nil {
+			slog.WarnContext(ctx, "⚠️  インポートのジョブの進捗の記録に失敗しました", "job_id", task.JobID, "error", err)
+		}
+	}
+
+	file, err := os.Open(task.File)
+	if err != nil {
+		slog.WarnContext(ctx, "⚠️  インポートの一時ファイルを開けませんでした", "job_id", task.JobID, "error", err)
+		job.Fail("failed to read the uploaded file")
+		return
+	}
+	defer file.Close()
+
+	result, err := u.itemUsecase.ImportItems(importCtx, file, opts)
+	if err != nil {
+		switch {
+		case ctx.Err() != nil:
+			job.Fail(importInterruptedReason)
+		case errors.Is(err, domainErrors.ErrValidation):
+			// ファイル自体の不正（空、ヘッダー不足など）は理由をそのまま返す
+			job.Fail(err.Error())
+		default:
+			slog.WarnContext(ctx, "⚠️  インポートのジョブが失敗しました", "job_id", task.JobID, "error", err)
+			job.Fail("failed to import items")
+		}
+		return
+	}
+
+	job.Status = entity.ImportJobStatusDone
+	job.RowsProcessed = result.Rows
+	job.Succeeded = result.Succeeded
+	job.Failed = result.Failed
+	job.Errors = result.Errors
+	if result.Failed > 0 && !opts.BestEffort {
+		job.Fail(importRowErrorsReason)
+	}
+}
+
+// 実行したジョブの結果を記録する。サーバーの終了でctxがキャンセルされていても、登録済みの結果は記録する
+func (u *importJobUsecase) finish(ctx context.Context, job *entity.ImportJob) {
+	if err := u.jobRepo.Finish(context.WithoutCancel(ctx), job); err != nil {
+		slog.WarnContext(ctx, "⚠️  インポートのジョブの結果の記録に失敗しました", "job_id", job.ID, "error", err)
+	}
+}
+
+func (u *importJobUsecase) FailUnfinishedImportJobs(ctx context.Context) (int64, error) {
+	failed, err := u.jobRepo.FailUnfinished(ctx, importInterruptedReason)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail unfinished import jobs: %w", err)
+	}
+
+	return failed, nil
+}
+
+func (u *importJobUsecase) DeleteExpiredImportJobs(ctx context.Context) (int64, error) {
+	deleted, err := u.jobRepo.DeleteFinishedBefore(ctx, entity.Now().Add(-entity.ImportJobRetention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired import jobs: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/clientip"
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+)
+
+// 使わないメソッドは埋め込んだインターフェースに委ねる（呼ぶとpanicする）
+type stubImportJobRepository struct {
+	ImportJobRepository
+	jobs     map[int64]*entity.ImportJob
+	progress []int
+}
+
+func newStubImportJobRepository() *stubImportJobRepository {
+	return &stubImportJobRepository{jobs: make(map[int64]*entity.ImportJob)}
+}
+
+func (r *stubImportJobRepository) Create(ctx context.Context, job *entity.ImportJob) (*entity.ImportJob, error) {
+	stored := *job
+	stored.ID = int64(len(r.jobs) + 1)
+	r.jobs[stored.ID] = &stored
+	clone := stored
+	return &clone, nil
+}
+
+func (r *stubImportJobRepository) FindByID(ctx context.Context, id int64) (*entity.ImportJob, error) {
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, domainErrors.ErrImportJobNotFound
+	}
+	return job, nil
+}
+
+func (r *stubImportJobRepository) Start(ctx context.Context, id int64) error {
+	r.jobs[id].Status = entity.ImportJobStatusRunning
+	return nil
+}
+
+func (r *stubImportJobRepository) UpdateProgress(ctx context.Context, id int64, rowsProcessed int) error {
+	r.progress = append(r.progress, rowsProcessed)
+	return nil
+}
+
+func (r *stubImportJobRepository) Finish(ctx context.Context, job *entity.ImportJob) error {
+	// サーバーの終了でキャンセルされても結果は記録する
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	stored := *job
+	r.jobs[job.ID] = &stored
+	return nil
+}
+
+// キューに入れたジョブを記録する。errを設定した場合は入れずにerrを返す
+type stubImportJobQueue struct {
+	tasks []ImportTask
+	err   error
+}
+
+func (q *stubImportJobQueue) Enqueue(task ImportTask) error {
+	if q.err != nil {
+		return q.err
+	}
+	q.tasks = append(q.tasks, task)
+	return nil
+}
+
+// ImportItemsのみを実装し、進捗を1回通知して設定した結果を返す
+type stubImportItemUsecase struct {
+	ItemUsecase
+	result   *ImportResult
+	err      error
+	ownerID  int64  // インポートしたアイテムの所有者
+	clientIP string // 監査ログに記録する送信元
+}
+
+func (u *stubImportItemUsecase) ImportItems(ctx context.Context, r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	u.ownerID = principal.OwnerID(ctx)
+	u.clientIP = clientip.From(ctx)
+	opts.OnProgress(100)
+	return u.result, u.err
+}
+
+// インポートするCSVを一時ファイルに書き出す
+func writeImportFile(t *testing.T, csv string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "items.csv")
+	require.NoError(t, os.WriteFile(file, []byte(csv), 0o600))
+	return file
+}
+
+func TestImportJobUsecase_StartImport(t *testing.T) {
+	tests := []struct {
+		name        string
+		csv         string
+		queueErr    error
+		expectedErr error
+		jobStatus   string
+	}{
+		{name: "正常系: ジョブを登録してキューに入れる", csv: importHeader, jobStatus: entity.ImportJobStatusQueued},
+		{name: "異常系: ファイルが大きすぎる", csv: importHeader + strings.Repeat("a", 100), expectedErr: domainErrors.ErrFileTooLarge},
+		{name: "異常系: キューが一杯の場合はジョブを失敗にする", csv: importHeader, queueErr: domainErrors.ErrImportQueueFull, expectedErr: domainErrors.ErrImportQueueFull, jobStatus: entity.ImportJobStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newStubImportJobRepository()
+			queue := &stubImportJobQueue{err: tt.queueErr}
+			u := NewImportJobUsecase(repo, nil, queue, 100)
+
+			job, err := u.StartImport(context.Background(), strings.NewReader(tt.csv), ImportOptions{BestEffort: true})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, job)
+				assert.Empty(t, queue.tasks)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, entity.ImportJobStatusQueued, job.Status)
+				assert.True(t, job.BestEffort)
+				require.Len(t, queue.tasks, 1)
+				assert.Equal(t, job.ID, queue.tasks[0].JobID)
+				// アップロードされたファイルは一時ファイルに書き出してワーカーに渡す
+				data, err := os.ReadFile(queue.tasks[0].File)
+				require.NoError(t, err)
+				assert.Equal(t, importHeader, string(data))
+				queue.tasks[0].Discard()
+			}
+			if tt.jobStatus != "" {
+				require.Len(t, repo.jobs, 1)
+				assert.Equal(t, tt.jobStatus, repo.jobs[1].Status)
+			} else {
+				assert.Empty(t, repo.jobs)
+			}
+		})
+	}
+}
+
+func TestImportJobUsecase_RunImportJob(t *testing.T) {
+	rowErrors := []entity.ImportRowError{{Row: 3, Message: "purchase_price must be an integer"}}
+
+	tests := []struct {
+		name           string
+		opts           ImportOptions
+		result         *ImportResult
+		err            error
+		cancel         bool
+		expectedStatus string
+		expectedError  string
+	}{
+		{
+			name:           "正常系: 有効な行のみ登録した結果を記録する",
+			opts:           ImportOptions{BestEffort: true},
+			result:         &ImportResult{Rows: 2, Succeeded: 1, Failed: 1, Errors: rowErrors},
+			expectedStatus: entity.ImportJobStatusDone,
+		},
+		{
+			name:           "異常系: 全件モードで無効な行がある",
+			result:         &ImportResult{Rows: 2, Failed: 1, Errors: rowErrors},
+			expectedStatus: entity.ImportJobStatusFailed,
+			expectedError:  importRowErrorsReason,
+		},
+		{
+			name:           "異常系: 分類できないエラーは詳細を記録しない",
+			err:            errors.New("connection refused"),
+			expectedStatus: entity.ImportJobStatusFailed,
+			expectedError:  "failed to import items",
+		},
+		{
+			name:           "異常系: ファイル自体の不正は理由をそのまま記録する",
+			err:            domainErrors.ErrInvalidInput,
+			expectedStatus: entity.ImportJobStatusFailed,
+			expectedError:  "invalid input",
+		},
+		{
+			name:           "異常系: サーバーの終了で中断した",
+			err:            context.Canceled,
+			cancel:         true,
+			expectedStatus: entity.ImportJobStatusFailed,
+			expectedError:  importInterruptedReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newStubImportJobRepository()
+			job, err := repo.Create(context.Background(), entity.NewImportJob(tt.opts.BestEffort, false))
+			require.NoError(t, err)
+			u := NewImportJobUsecase(repo, &stubImportItemUsecase{result: tt.result, err: tt.err}, &stubImportJobQueue{}, 100)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.cancel {
+				cancel()
+			}
+			defer cancel()
+			file := writeImportFile(t, importHeader)
+			u.RunImportJob(ctx, ImportTask{JobID: job.ID, File: file, Options: tt.opts})
+			// 実行したジョブの一時ファイルは削除する
+			assert.NoFileExists(t, file)
+
+			finished := repo.jobs[job.ID]
+			assert.Equal(t, tt.expectedStatus, finished.Status)
+			assert.Equal(t, tt.expectedError, finished.Error)
+			assert.Equal(t, []int{100}, repo.progress)
+			if tt.result != nil {
+				assert.Equal(t, tt.result.Rows, finished.RowsProcessed)
+				assert.Equal(t, rowErrors, finished.Errors)
+			}
+		})
+	}
+}
+
+func TestImportJobUsecase_ImportsAsRequester(t *testing.T) {
+	repo := newStubImportJobRepository()
+	queue := &stubImportJobQueue{}
+	itemUsecase := &stubImportItemUsecase{result: &ImportResult{}}
+	u := NewImportJobUsecase(repo, itemUsecase, queue, 100)
+
+	ctx := clientip.NewContext(principal.NewContext(context.Background(), principal.Principal{UserID: 2}), "203.0.113.7")
+	_, err := u.StartImport(ctx, strings.NewReader(importHeader), ImportOptions{})
+	require.NoError(t, err)
+	require.Len(t, queue.tasks, 1)
+
+	// ワーカーはリクエストのctxを引き継がないが、ジョブを登録したユーザーのアイテムとして登録し、送信元を監査ログに記録する
+	u.RunImportJob(context.Background(), queue.tasks[0])
+	assert.Equal(t, int64(2), itemUsecase.ownerID)
+	assert.Equal(t, "203.0.113.7", itemUsecase.clientIP)
+}
+
+func TestImportJobUsecase_GetImportJob(t *testing.T) {
+	repo := newStubImportJobRepository()
+	job, err := repo.Create(context.Background(), entity.NewImportJob(false, false))
+	require.NoError(t, err)
+	u := NewImportJobUsecase(repo, nil, &stubImportJobQueue{}, 100)
+
+	found, err := u.GetImportJob(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, job.ID, found.ID)
+
+	_, err = u.GetImportJob(context.Background(), 2)
+	assert.ErrorIs(t, err, domainErrors.ErrImportJobNotFound)
+	_, err = u.GetImportJob(context.Background(), 0)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+}
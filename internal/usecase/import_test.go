@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const importHeader = "name,category,brand,purchase_price,purchase_date\n"
+
+func TestItemUsecase_ImportItems(t *testing.T) {
+	tests := []struct {
+		name              string
+		csv               string
+		opts              ImportOptions
+		setupMock         func(*MockItemRepository)
+		expectedSucceeded int
+		expectedFailed    int
+		expectedErrorRows []int
+		expectedErr       error
+	}{
+		{
+			name: "正常系: 全行が有効",
+			csv: importHeader +
+				"ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n" +
+				"エルメス バーキン,バッグ,HERMÈS,2000000,2023-02-20\n",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+					return len(items) == 2
+				})).Return([]int64{1, 2}, nil)
+			},
+			expectedSucceeded: 2,
+		},
+		{
+			name: "正常系: 列の順序が異なりBOM付きのヘッダー",
+			csv: "\ufeffpurchase_date,purchase_price,brand,category,name\n" +
+				"2023-01-15,1500000,ROLEX,時計,ロレックス デイトナ\n",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+					return len(items) == 1 && items[0].Name == "ロレックス デイトナ" && items[0].PurchasePrice == 1500000
+				})).Return([]int64{1}, nil)
+			},
+			expectedSucceeded: 1,
+		},
+		{
+			name: "正常系: currency列がある場合は通貨も登録",
+			csv: "name,category,brand,purchase_price,currency,purchase_date\n" +
+				"ロレックス デイトナ,時計,ROLEX,12000,eur,2023-01-15\n" +
+				"エルメス バーキン,バッグ,HERMÈS,2000000,,2023-02-20\n",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+					return len(items) == 2 && items[0].Currency == "EUR" && items[1].Currency == "JPY"
+				})).Return([]int64{1, 2}, nil)
+			},
+			expectedSucceeded: 2,
+		},
+		{
+			name: "正常系: 全件モードでエラー行がある場合は何も登録しない",
+			csv: importHeader +
+				"ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n" +
+				",時計,ROLEX,1500000,2023-01-15\n" +
+				"アイテム,衣服,ブランド,abc,2023-01-15\n",
+			setupMock: func(mockRepo *MockItemRepository) {
+				// CreateManyは呼ばれない
+			},
+			expectedSucceeded: 0,
+			expectedFailed:    2,
+			expectedErrorRows: []int{3, 4},
+		},
+		{
+			name: "正常系: ベストエフォートモードでは有効な行のみ登録",
+			csv: importHeader +
+				"ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n" +
+				"列が足りない,時計\n",
+			opts: ImportOptions{BestEffort: true},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+					return len(items) == 1
+				})).Return([]int64{1}, nil)
+			},
+			expectedSucceeded: 1,
+			expectedFailed:    1,
+			expectedErrorRows: []int{3},
+		},
+		{
+			name: "正常系: ファイル内の重複行はエラー",
+			csv: importHeader +
+				"ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n" +
+				"ロレックス デイトナ,時計,rolex,1600000,2023-01-15\n",
+			opts: ImportOptions{BestEffort: true},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+					return len(items) == 1
+				})).Return([]int64{1}, nil)
+			},
+			expectedSucceeded: 1,
+			expectedFailed:    1,
+			expectedErrorRows: []int{3},
+		},
+		{
+			name: "正常系: ファイル内でシリアル番号が重複する行はエラー",
+			csv: "name,category,brand,purchase_price,purchase_date,serial_number\n" +
+				"ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15,SN-001\n" +
+				"ロレックス サブマリーナ,時計,ROLEX,1200000,2023-02-20,sn-001\n" +
+				"エルメス バーキン,バッグ,HERMÈS,2000000,2023-02-20,\n",
+			opts: ImportOptions{BestEffort: true},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+					return len(items) == 2 && *items[0].SerialNumber == "SN-001" && items[1].SerialNumber == nil
+				})).Return([]int64{1, 2}, nil)
+			},
+			expectedSucceeded: 2,
+			expectedFailed:    1,
+			expectedErrorRows: []int{3},
+		},
+		{
+			name: "正常系: ドライランでは登録予定の件数を返し、書き込まない",
+			csv: importHeader +
+				"ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n" +
+				"エルメス バーキン,バッグ,HERMÈS,2000000,2023-02-20\n",
+			opts: ImportOptions{DryRun: true},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// CreateManyは呼ばれない
+			},
+			expectedSucceeded: 2,
+		},
+		{
+			name: "正常系: ドライランでも行ごとのエラーを返す",
+			csv: importHeader +
+				"ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n" +
+				"エルメス バーキン,バッグ,HERMÈS,-1,2023-02-20\n",
+			opts: ImportOptions{DryRun: true, BestEffort: true},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// CreateManyは呼ばれない
+			},
+			expectedSucceeded: 1,
+			expectedFailed:    1,
+			expectedErrorRows: []int{3},
+		},
+		{
+			name: "正常系: ヘッダーのみ",
+			csv:  importHeader,
+			setupMock: func(mockRepo *MockItemRepository) {
+				// CreateManyは呼ばれない
+			},
+		},
+		{
+			name: "異常系: 空のファイル",
+			csv:  "",
+			setupMock: func(mockRepo *MockItemRepository) {
+				// CreateManyは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: ヘッダー行に必須列がない",
+			csv:  "ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n",
+			setupMock: func(mockRepo *MockItemRepository) {
+				// CreateManyは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: データベースエラー",
+			csv:  importHeader + "ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil)
+
+			result, err := usecase.ImportItems(context.Background(), strings.NewReader(tt.csv), tt.opts)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.opts.DryRun, result.DryRun)
+			assert.Equal(t, tt.expectedSucceeded, result.Succeeded)
+			assert.Equal(t, tt.expectedFailed, result.Failed)
+
+			var rows []int
+			for _, rowErr := range result.Errors {
+				rows = append(rows, rowErr.Row)
+				assert.NotEmpty(t, rowErr.Message)
+			}
+			assert.Equal(t, tt.expectedErrorRows, rows)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_ImportItems_Progress(t *testing.T) {
+	// 無効な行も解析済みの行として数える
+	csv := importHeader + strings.Repeat("ロレックス デイトナ,時計,ROLEX,abc,2023-01-15\n", 250)
+	var progress []int
+	opts := ImportOptions{OnProgress: func(rows int) { progress = append(progress, rows) }}
+	usecase := NewItemUsecase(new(MockItemRepository), newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil)
+
+	result, err := usecase.ImportItems(context.Background(), strings.NewReader(csv), opts)
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{100, 200}, progress)
+	assert.Equal(t, 250, result.Rows)
+	assert.Equal(t, 250, result.Failed)
+}
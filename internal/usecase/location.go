@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// 購入店舗ごとの件数と購入価格の合計。PurchaseLocationがnilの場合は店舗が未設定のアイテム
+type LocationSpend struct {
+	PurchaseLocation *string `json:"purchase_location"`
+	Count            int     `json:"count"`
+	TotalPrice       int64   `json:"total_price"`
+}
+
+// 購入店舗ごとの支出の集計。金額はCurrency（基準通貨）に換算した値
+type LocationReport struct {
+	Currency   string           `json:"currency"`
+	Locations  []*LocationSpend `json:"locations"`
+	Total      int              `json:"total"`
+	TotalPrice int64            `json:"total_price"`
+}
+
+// 購入店舗ごとの購入価格の合計を基準通貨に換算して集計する。
+// 合計の多い順に並べ、店舗が未設定のアイテムは最後にまとめる
+func (u *itemUsecase) GetLocationReport(ctx context.Context) (*LocationReport, error) {
+	totals, err := u.itemRepo.GetSpendByLocation(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spend by location: %w", err)
+	}
+
+	report := &LocationReport{
+		Currency:  u.exchangeRates.BaseCurrency(),
+		Locations: make([]*LocationSpend, 0),
+	}
+
+	// 通貨ごとの行を店舗ごとにまとめる
+	byLocation := make(map[string]*LocationSpend)
+	var unknown *LocationSpend
+	for _, total := range totals {
+		price, err := u.toBaseCurrency(ctx, total.Total())
+		if err != nil {
+			return nil, err
+		}
+
+		var spend *LocationSpend
+		switch {
+		case total.PurchaseLocation == nil:
+			if unknown == nil {
+				unknown = &LocationSpend{}
+			}
+			spend = unknown
+		case byLocation[*total.PurchaseLocation] != nil:
+			spend = byLocation[*total.PurchaseLocation]
+		default:
+			spend = &LocationSpend{PurchaseLocation: total.PurchaseLocation}
+			byLocation[*total.PurchaseLocation] = spend
+			report.Locations = append(report.Locations, spend)
+		}
+
+		spend.Count += total.Count
+		spend.TotalPrice += price.Amount()
+		report.Total += total.Count
+		report.TotalPrice += price.Amount()
+	}
+
+	sort.SliceStable(report.Locations, func(i, j int) bool {
+		return report.Locations[i].TotalPrice > report.Locations[j].TotalPrice
+	})
+	if unknown != nil {
+		report.Locations = append(report.Locations, unknown)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestItemUsecase_GetLocationReport(t *testing.T) {
+	ginza := "銀座本店"
+	online := "公式オンライン"
+
+	tests := []struct {
+		name           string
+		setupMock      func(*MockItemRepository)
+		expectedReport *LocationReport
+		expectedErr    error
+	}{
+		{
+			name: "正常系: 通貨をまとめて合計の多い順に並べ、未設定は最後",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSpendByLocation", mock.Anything).Return([]*entity.LocationCurrencyTotal{
+					{Currency: "JPY", Count: 1, TotalPrice: 5000000},
+					{PurchaseLocation: &online, Currency: "JPY", Count: 1, TotalPrice: 300000},
+					{PurchaseLocation: &ginza, Currency: "JPY", Count: 2, TotalPrice: 200000},
+					{PurchaseLocation: &ginza, Currency: "USD", Count: 1, TotalPrice: 1000},
+				}, nil)
+			},
+			expectedReport: &LocationReport{
+				Currency: "JPY",
+				Locations: []*LocationSpend{
+					{PurchaseLocation: &ginza, Count: 3, TotalPrice: 350000},
+					{PurchaseLocation: &online, Count: 1, TotalPrice: 300000},
+					{Count: 1, TotalPrice: 5000000},
+				},
+				Total:      5,
+				TotalPrice: 5650000,
+			},
+		},
+		{
+			name: "正常系: アイテムがない",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSpendByLocation", mock.Anything).Return([]*entity.LocationCurrencyTotal{}, nil)
+			},
+			expectedReport: &LocationReport{Currency: "JPY", Locations: []*LocationSpend{}},
+		},
+		{
+			name: "異常系: データベースエラー",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSpendByLocation", mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil)
+
+			report, err := usecase.GetLocationReport(context.Background())
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, report)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedReport, report)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,540 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// QueryObserver records how long each repository call took
+type QueryObserver interface {
+	// ObserveQuery records that method of repository (e.g. "ItemRepository" and "FindAll") returned after d
+	ObserveQuery(repository, method string, d time.Duration)
+}
+
+// ItemRepositoryWithMetrics はrepoの各メソッドの所要時間をobserverに記録するItemRepositoryを返す
+func ItemRepositoryWithMetrics(repo ItemRepository, observer QueryObserver) ItemRepository {
+	return &observedItemRepository{repo: repo, observer: observer}
+}
+
+type observedItemRepository struct {
+	repo     ItemRepository
+	observer QueryObserver
+}
+
+func (o *observedItemRepository) observe(method string, start time.Time) {
+	o.observer.ObserveQuery("ItemRepository", method, time.Since(start))
+}
+
+func (o *observedItemRepository) FindAll(ctx context.Context, filter entity.ItemFilter, sort entity.ItemSort, page entity.Pagination) ([]*entity.Item, error) {
+	defer o.observe("FindAll", time.Now())
+	return o.repo.FindAll(ctx, filter, sort, page)
+}
+
+// クライアントが読み込む速さに左右されるため記録しない
+func (o *observedItemRepository) EachItem(ctx context.Context, filter entity.ItemFilter, sort entity.ItemSort, fn func(*entity.Item) error) error {
+	return o.repo.EachItem(ctx, filter, sort, fn)
+}
+
+func (o *observedItemRepository) Count(ctx context.Context, filter entity.ItemFilter) (int, error) {
+	defer o.observe("Count", time.Now())
+	return o.repo.Count(ctx, filter)
+}
+
+func (o *observedItemRepository) MaxUpdatedAt(ctx context.Context, filter entity.ItemFilter) (*time.Time, error) {
+	defer o.observe("MaxUpdatedAt", time.Now())
+	return o.repo.MaxUpdatedAt(ctx, filter)
+}
+
+func (o *observedItemRepository) FindUpdatedSince(ctx context.Context, since time.Time, limit int) ([]*entity.Item, error) {
+	defer o.observe("FindUpdatedSince", time.Now())
+	return o.repo.FindUpdatedSince(ctx, since, limit)
+}
+
+func (o *observedItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	defer o.observe("FindByID", time.Now())
+	return o.repo.FindByID(ctx, id)
+}
+
+func (o *observedItemRepository) FindByIDForUpdate(ctx context.Context, id int64) (*entity.Item, error) {
+	defer o.observe("FindByIDForUpdate", time.Now())
+	return o.repo.FindByIDForUpdate(ctx, id)
+}
+
+func (o *observedItemRepository) FindBySerialNumber(ctx context.Context, serialNumber string) (*entity.Item, error) {
+	defer o.observe("FindBySerialNumber", time.Now())
+	return o.repo.FindBySerialNumber(ctx, serialNumber)
+}
+
+func (o *observedItemRepository) FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	defer o.observe("FindByIDs", time.Now())
+	return o.repo.FindByIDs(ctx, ids)
+}
+
+func (o *observedItemRepository) FindByPurchaseDate(ctx context.Context, purchaseDate entity.PurchaseDate) ([]*entity.Item, error) {
+	defer o.observe("FindByPurchaseDate", time.Now())
+	return o.repo.FindByPurchaseDate(ctx, purchaseDate)
+}
+
+func (o *observedItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	defer o.observe("Create", time.Now())
+	return o.repo.Create(ctx, item)
+}
+
+func (o *observedItemRepository) CreateMany(ctx context.Context, items []*entity.Item) ([]int64, error) {
+	defer o.observe("CreateMany", time.Now())
+	return o.repo.CreateMany(ctx, items)
+}
+
+func (o *observedItemRepository) Delete(ctx context.Context, id int64) (*entity.ItemChange, error) {
+	defer o.observe("Delete", time.Now())
+	return o.repo.Delete(ctx, id)
+}
+
+func (o *observedItemRepository) Restore(ctx context.Context, id int64) (*entity.ItemChange, error) {
+	defer o.observe("Restore", time.Now())
+	return o.repo.Restore(ctx, id)
+}
+
+func (o *observedItemRepository) HardDelete(ctx context.Context, id int64) (*entity.ItemChange, error) {
+	defer o.observe("HardDelete", time.Now())
+	return o.repo.HardDelete(ctx, id)
+}
+
+func (o *observedItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	defer o.observe("Update", time.Now())
+	return o.repo.Update(ctx, item)
+}
+
+func (o *observedItemRepository) CreateWithIdempotencyKey(ctx context.Context, item *entity.Item, key *entity.IdempotencyKey, createdBefore time.Time) (*entity.Item, error) {
+	defer o.observe("CreateWithIdempotencyKey", time.Now())
+	return o.repo.CreateWithIdempotencyKey(ctx, item, key, createdBefore)
+}
+
+func (o *observedItemRepository) FindIdempotencyKey(ctx context.Context, key string, createdAfter time.Time) (*entity.IdempotencyKey, error) {
+	defer o.observe("FindIdempotencyKey", time.Now())
+	return o.repo.FindIdempotencyKey(ctx, key, createdAfter)
+}
+
+func (o *observedItemRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, createdBefore time.Time) (int64, error) {
+	defer o.observe("DeleteExpiredIdempotencyKeys", time.Now())
+	return o.repo.DeleteExpiredIdempotencyKeys(ctx, createdBefore)
+}
+
+func (o *observedItemRepository) FindImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	defer o.observe("FindImages", time.Now())
+	return o.repo.FindImages(ctx, itemID)
+}
+
+func (o *observedItemRepository) FindImagesByItemIDs(ctx context.Context, itemIDs []int64) (map[int64][]*entity.ItemImage, error) {
+	defer o.observe("FindImagesByItemIDs", time.Now())
+	return o.repo.FindImagesByItemIDs(ctx, itemIDs)
+}
+
+func (o *observedItemRepository) AddImage(ctx context.Context, itemID int64, url string, maxImages int) (*entity.ItemImage, error) {
+	defer o.observe("AddImage", time.Now())
+	return o.repo.AddImage(ctx, itemID, url, maxImages)
+}
+
+func (o *observedItemRepository) DeleteImage(ctx context.Context, itemID, imageID int64) error {
+	defer o.observe("DeleteImage", time.Now())
+	return o.repo.DeleteImage(ctx, itemID, imageID)
+}
+
+func (o *observedItemRepository) ReorderImages(ctx context.Context, itemID int64, imageIDs []int64) error {
+	defer o.observe("ReorderImages", time.Now())
+	return o.repo.ReorderImages(ctx, itemID, imageIDs)
+}
+
+func (o *observedItemRepository) FindHistories(ctx context.Context, itemID int64, page entity.Pagination) ([]*entity.ItemHistory, error) {
+	defer o.observe("FindHistories", time.Now())
+	return o.repo.FindHistories(ctx, itemID, page)
+}
+
+func (o *observedItemRepository) CreateHistory(ctx context.Context, itemID int64, action string, before, after *entity.Item) error {
+	defer o.observe("CreateHistory", time.Now())
+	return o.repo.CreateHistory(ctx, itemID, action, before, after)
+}
+
+func (o *observedItemRepository) CountHistories(ctx context.Context, itemID int64) (int, error) {
+	defer o.observe("CountHistories", time.Now())
+	return o.repo.CountHistories(ctx, itemID)
+}
+
+func (o *observedItemRepository) GetSummaryByCategory(ctx context.Context) ([]*entity.CategoryCurrencyTotal, error) {
+	defer o.observe("GetSummaryByCategory", time.Now())
+	return o.repo.GetSummaryByCategory(ctx)
+}
+
+func (o *observedItemRepository) GetProfitByCurrency(ctx context.Context, year int) ([]*entity.ProfitCurrencyTotal, error) {
+	defer o.observe("GetProfitByCurrency", time.Now())
+	return o.repo.GetProfitByCurrency(ctx, year)
+}
+
+func (o *observedItemRepository) GetSpendByLocation(ctx context.Context) ([]*entity.LocationCurrencyTotal, error) {
+	defer o.observe("GetSpendByLocation", time.Now())
+	return o.repo.GetSpendByLocation(ctx)
+}
+
+func (o *observedItemRepository) GetSpendByPeriod(ctx context.Context, r entity.SpendRange) ([]*entity.PeriodCurrencyTotal, error) {
+	defer o.observe("GetSpendByPeriod", time.Now())
+	return o.repo.GetSpendByPeriod(ctx, r)
+}
+
+func (o *observedItemRepository) GetSummaryByBrand(ctx context.Context) ([]*entity.BrandCurrencyTotal, error) {
+	defer o.observe("GetSummaryByBrand", time.Now())
+	return o.repo.GetSummaryByBrand(ctx)
+}
+
+func (o *observedItemRepository) FindMostExpensiveByBrand(ctx context.Context) ([]*entity.ItemPrice, error) {
+	defer o.observe("FindMostExpensiveByBrand", time.Now())
+	return o.repo.FindMostExpensiveByBrand(ctx)
+}
+
+func (o *observedItemRepository) GetStatsByCategory(ctx context.Context, filter entity.ItemFilter) ([]*entity.CategoryCurrencyStats, error) {
+	defer o.observe("GetStatsByCategory", time.Now())
+	return o.repo.GetStatsByCategory(ctx, filter)
+}
+
+func (o *observedItemRepository) FindMostExpensiveByCurrency(ctx context.Context, filter entity.ItemFilter) ([]*entity.ItemPrice, error) {
+	defer o.observe("FindMostExpensiveByCurrency", time.Now())
+	return o.repo.FindMostExpensiveByCurrency(ctx, filter)
+}
+
+// CategoryRepositoryWithMetrics はrepoの各メソッドの所要時間をobserverに記録するCategoryRepositoryを返す
+func CategoryRepositoryWithMetrics(repo CategoryRepository, observer QueryObserver) CategoryRepository {
+	return &observedCategoryRepository{repo: repo, observer: observer}
+}
+
+type observedCategoryRepository struct {
+	repo     CategoryRepository
+	observer QueryObserver
+}
+
+func (o *observedCategoryRepository) observe(method string, start time.Time) {
+	o.observer.ObserveQuery("CategoryRepository", method, time.Since(start))
+}
+
+func (o *observedCategoryRepository) FindAll(ctx context.Context) ([]*entity.Category, error) {
+	defer o.observe("FindAll", time.Now())
+	return o.repo.FindAll(ctx)
+}
+
+func (o *observedCategoryRepository) FindByID(ctx context.Context, id int64) (*entity.Category, error) {
+	defer o.observe("FindByID", time.Now())
+	return o.repo.FindByID(ctx, id)
+}
+
+func (o *observedCategoryRepository) Create(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	defer o.observe("Create", time.Now())
+	return o.repo.Create(ctx, category)
+}
+
+func (o *observedCategoryRepository) Rename(ctx context.Context, id int64, name string) (*entity.Category, error) {
+	defer o.observe("Rename", time.Now())
+	return o.repo.Rename(ctx, id, name)
+}
+
+func (o *observedCategoryRepository) Delete(ctx context.Context, id int64) error {
+	defer o.observe("Delete", time.Now())
+	return o.repo.Delete(ctx, id)
+}
+
+func (o *observedCategoryRepository) CountItems(ctx context.Context, name string) (int, error) {
+	defer o.observe("CountItems", time.Now())
+	return o.repo.CountItems(ctx, name)
+}
+
+// TagRepositoryWithMetrics はrepoの各メソッドの所要時間をobserverに記録するTagRepositoryを返す
+func TagRepositoryWithMetrics(repo TagRepository, observer QueryObserver) TagRepository {
+	return &observedTagRepository{repo: repo, observer: observer}
+}
+
+type observedTagRepository struct {
+	repo     TagRepository
+	observer QueryObserver
+}
+
+func (o *observedTagRepository) observe(method string, start time.Time) {
+	o.observer.ObserveQuery("TagRepository", method, time.Since(start))
+}
+
+func (o *observedTagRepository) FindAllWithCounts(ctx context.Context) ([]*entity.TagCount, error) {
+	defer o.observe("FindAllWithCounts", time.Now())
+	return o.repo.FindAllWithCounts(ctx)
+}
+
+// BrandRepositoryWithMetrics はrepoの各メソッドの所要時間をobserverに記録するBrandRepositoryを返す
+func BrandRepositoryWithMetrics(repo BrandRepository, observer QueryObserver) BrandRepository {
+	return &observedBrandRepository{repo: repo, observer: observer}
+}
+
+type observedBrandRepository struct {
+	repo     BrandRepository
+	observer QueryObserver
+}
+
+func (o *observedBrandRepository) observe(method string, start time.Time) {
+	o.observer.ObserveQuery("BrandRepository", method, time.Since(start))
+}
+
+func (o *observedBrandRepository) Search(ctx context.Context, prefix string, limit int) ([]*entity.Brand, error) {
+	defer o.observe("Search", time.Now())
+	return o.repo.Search(ctx, prefix, limit)
+}
+
+func (o *observedBrandRepository) FindByID(ctx context.Context, id int64) (*entity.Brand, error) {
+	defer o.observe("FindByID", time.Now())
+	return o.repo.FindByID(ctx, id)
+}
+
+func (o *observedBrandRepository) FindByNameOrAlias(ctx context.Context, name string) (*entity.Brand, error) {
+	defer o.observe("FindByNameOrAlias", time.Now())
+	return o.repo.FindByNameOrAlias(ctx, name)
+}
+
+func (o *observedBrandRepository) Create(ctx context.Context, brand *entity.Brand) (*entity.Brand, error) {
+	defer o.observe("Create", time.Now())
+	return o.repo.Create(ctx, brand)
+}
+
+func (o *observedBrandRepository) Merge(ctx context.Context, sourceID, targetID int64) (int64, error) {
+	defer o.observe("Merge", time.Now())
+	return o.repo.Merge(ctx, sourceID, targetID)
+}
+
+// WebhookRepositoryWithMetrics はrepoの各メソッドの所要時間をobserverに記録するWebhookRepositoryを返す
+func WebhookRepositoryWithMetrics(repo WebhookRepository, observer QueryObserver) WebhookRepository {
+	return &observedWebhookRepository{repo: repo, observer: observer}
+}
+
+type observedWebhookRepository struct {
+	repo     WebhookRepository
+	observer QueryObserver
+}
+
+func (o *observedWebhookRepository) observe(method string, start time.Time) {
+	o.observer.ObserveQuery("WebhookRepository", method, time.Since(start))
+}
+
+func (o *observedWebhookRepository) FindAll(ctx context.Context) ([]*entity.Webhook, error) {
+	defer o.observe("FindAll", time.Now())
+	return o.repo.FindAll(ctx)
+}
+
+func (o *observedWebhookRepository) FindByID(ctx context.Context, id int64) (*entity.Webhook, error) {
+	defer o.observe("FindByID", time.Now())
+	return o.repo.FindByID(ctx, id)
+}
+
+func (o *observedWebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	defer o.observe("Create", time.Now())
+	return o.repo.Create(ctx, webhook)
+}
+
+func (o *observedWebhookRepository) Delete(ctx context.Context, id int64) error {
+	defer o.observe("Delete", time.Now())
+	return o.repo.Delete(ctx, id)
+}
+
+func (o *observedWebhookRepository) Enable(ctx context.Context, id int64) (*entity.Webhook, error) {
+	defer o.observe("Enable", time.Now())
+	return o.repo.Enable(ctx, id)
+}
+
+func (o *observedWebhookRepository) RecordResult(ctx context.Context, id int64, succeeded bool, maxFailures int) error {
+	defer o.observe("RecordResult", time.Now())
+	return o.repo.RecordResult(ctx, id, succeeded, maxFailures)
+}
+
+func (o *observedWebhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	defer o.observe("CreateDelivery", time.Now())
+	return o.repo.CreateDelivery(ctx, delivery)
+}
+
+func (o *observedWebhookRepository) FindDeliveries(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error) {
+	defer o.observe("FindDeliveries", time.Now())
+	return o.repo.FindDeliveries(ctx, webhookID, limit)
+}
+
+func (o *observedWebhookRepository) DeleteDeliveriesBefore(ctx context.Context, createdBefore time.Time) (int64, error) {
+	defer o.observe("DeleteDeliveriesBefore", time.Now())
+	return o.repo.DeleteDeliveriesBefore(ctx, createdBefore)
+}
+
+// ImportJobRepositoryWithMetrics はrepoの各メソッドの所要時間をobserverに記録するImportJobRepositoryを返す
+func ImportJobRepositoryWithMetrics(repo ImportJobRepository, observer QueryObserver) ImportJobRepository {
+	return &observedImportJobRepository{repo: repo, observer: observer}
+}
+
+type observedImportJobRepository struct {
+	repo     ImportJobRepository
+	observer QueryObserver
+}
+
+func (o *observedImportJobRepository) observe(method string, start time.Time) {
+	o.observer.ObserveQuery("ImportJobRepository", method, time.Since(start))
+}
+
+func (o *observedImportJobRepository) Create(ctx context.Context, job *entity.ImportJob) (*entity.ImportJob, error) {
+	defer o.observe("Create", time.Now())
+	return o.repo.Create(ctx, job)
+}
+
+func (o *observedImportJobRepository) FindByID(ctx context.Context, id int64) (*entity.ImportJob, error) {
+	defer o.observe("FindByID", time.Now())
+	return o.repo.FindByID(ctx, id)
+}
+
+func (o *observedImportJobRepository) Start(ctx context.Context, id int64) error {
+	defer o.observe("Start", time.Now())
+	return o.repo.Start(ctx, id)
+}
+
+func (o *observedImportJobRepository) UpdateProgress(ctx context.Context, id int64, rowsProcessed int) error {
+	defer o.observe("UpdateProgress", time.Now())
+	return o.repo.UpdateProgress(ctx, id, rowsProcessed)
+}
+
+func (o *observedImportJobRepository) Finish(ctx context.Context, job *entity.ImportJob) error {
+	defer o.observe("Finish", time.Now())
+	return o.repo.Finish(ctx, job)
+}
+
+func (o *observedImportJobRepository) FailUnfinished(ctx context.Context, reason string) (int64, error) {
+	defer o.observe("FailUnfinished", time.Now())
+	return o.repo.FailUnfinished(ctx, reason)
+}
+
+func (o *observedImportJobRepository) DeleteFinishedBefore(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	defer o.observe("DeleteFinishedBefore", time.Now())
+	return o.repo.DeleteFinishedBefore(ctx, finishedBefore)
+}
+
+// APIKeyRepositoryWithMetrics はrepoの各メソッドの所要時間をobserverに記録するAPIKeyRepositoryを返す
+func APIKeyRepositoryWithMetrics(repo APIKeyRepository, observer QueryObserver) APIKeyRepository {
+	return &observedAPIKeyRepository{repo: repo, observer: observer}
+}
+
+type observedAPIKeyRepository struct {
+	repo     APIKeyRepository
+	observer QueryObserver
+}
+
+func (o *observedAPIKeyRepository) observe(method string, start time.Time) {
+	o.observer.ObserveQuery("APIKeyRepository", method, time.Since(start))
+}
+
+func (o *observedAPIKeyRepository) FindAll(ctx context.Context) ([]*entity.APIKey, error) {
+	defer o.observe("FindAll", time.Now())
+	return o.repo.FindAll(ctx)
+}
+
+func (o *observedAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	defer o.observe("FindByHash", time.Now())
+	return o.repo.FindByHash(ctx, keyHash)
+}
+
+func (o *observedAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error) {
+	defer o.observe("Create", time.Now())
+	return o.repo.Create(ctx, key)
+}
+
+func (o *observedAPIKeyRepository) Revoke(ctx context.Context, id int64) (*entity.APIKey, error) {
+	defer o.observe("Revoke", time.Now())
+	return o.repo.Revoke(ctx, id)
+}
+
+func (o *observedAPIKeyRepository) UpdateRole(ctx context.Context, id int64, role entity.UserRole) (*entity.APIKey, error) {
+	defer o.observe("UpdateRole", time.Now())
+	return o.repo.UpdateRole(ctx, id, role)
+}
+
+// UserRepositoryWithMetrics はrepoの各メソッドの所要時間をobserverに記録するUserRepositoryを返す
+func UserRepositoryWithMetrics(repo UserRepository, observer QueryObserver) UserRepository {
+	return &observedUserRepository{repo: repo, observer: observer}
+}
+
+type observedUserRepository struct {
+	repo     UserRepository
+	observer QueryObserver
+}
+
+func (o *observedUserRepository) observe(method string, start time.Time) {
+	o.observer.ObserveQuery("UserRepository", method, time.Since(start))
+}
+
+func (o *observedUserRepository) FindAll(ctx context.Context) ([]*entity.User, error) {
+	defer o.observe("FindAll", time.Now())
+	return o.repo.FindAll(ctx)
+}
+
+func (o *observedUserRepository) FindByID(ctx context.Context, id int64) (*entity.User, error) {
+	defer o.observe("FindByID", time.Now())
+	return o.repo.FindByID(ctx, id)
+}
+
+func (o *observedUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	defer o.observe("FindByEmail", time.Now())
+	return o.repo.FindByEmail(ctx, email)
+}
+
+func (o *observedUserRepository) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
+	defer o.observe("Create", time.Now())
+	return o.repo.Create(ctx, user)
+}
+
+func (o *observedUserRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) (*entity.User, error) {
+	defer o.observe("UpdatePassword", time.Now())
+	return o.repo.UpdatePassword(ctx, id, passwordHash)
+}
+
+func (o *observedUserRepository) UpdateRole(ctx context.Context, id int64, role entity.UserRole) (*entity.User, error) {
+	defer o.observe("UpdateRole", time.Now())
+	return o.repo.UpdateRole(ctx, id, role)
+}
+
+func (o *observedUserRepository) CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error) {
+	defer o.observe("CreateRefreshToken", time.Now())
+	return o.repo.CreateRefreshToken(ctx, token)
+}
+
+func (o *observedUserRepository) FindRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	defer o.observe("FindRefreshToken", time.Now())
+	return o.repo.FindRefreshToken(ctx, tokenHash)
+}
+
+func (o *observedUserRepository) RevokeRefreshToken(ctx context.Context, id int64) (bool, error) {
+	defer o.observe("RevokeRefreshToken", time.Now())
+	return o.repo.RevokeRefreshToken(ctx, id)
+}
+
+// AuditLogRepositoryWithMetrics はrepoの各メソッドの所要時間をobserverに記録するAuditLogRepositoryを返す
+func AuditLogRepositoryWithMetrics(repo AuditLogRepository, observer QueryObserver) AuditLogRepository {
+	return &observedAuditLogRepository{repo: repo, observer: observer}
+}
+
+type observedAuditLogRepository struct {
+	repo     AuditLogRepository
+	observer QueryObserver
+}
+
+func (o *observedAuditLogRepository) observe(method string, start time.Time) {
+	o.observer.ObserveQuery("AuditLogRepository", method, time.Since(start))
+}
+
+func (o *observedAuditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	defer o.observe("Create", time.Now())
+	return o.repo.Create(ctx, log)
+}
+
+func (o *observedAuditLogRepository) FindAll(ctx context.Context, filter entity.AuditLogFilter, page entity.Pagination) ([]*entity.AuditLog, error) {
+	defer o.observe("FindAll", time.Now())
+	return o.repo.FindAll(ctx, filter, page)
+}
+
+func (o *observedAuditLogRepository) Count(ctx context.Context, filter entity.AuditLogFilter) (int, error) {
+	defer o.observe("Count", time.Now())
+	return o.repo.Count(ctx, filter)
+}
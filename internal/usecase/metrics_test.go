@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 記録されたリポジトリとメソッドの名前
+type recordingObserver struct {
+	calls []string
+}
+
+func (o *recordingObserver) ObserveQuery(repository, method string, d time.Duration) {
+	o.calls = append(o.calls, repository+"."+method)
+}
+
+func TestItemRepositoryWithMetrics(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("Count", mock.Anything, entity.ItemFilter{}).Return(3, nil)
+	mockRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, assert.AnError)
+	observer := &recordingObserver{}
+	repo := ItemRepositoryWithMetrics(mockRepo, observer)
+
+	count, err := repo.Count(context.Background(), entity.ItemFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	// エラーの場合も記録する
+	_, err = repo.FindByID(context.Background(), 1)
+	assert.ErrorIs(t, err, assert.AnError)
+
+	assert.Equal(t, []string{"ItemRepository.Count", "ItemRepository.FindByID"}, observer.calls)
+}
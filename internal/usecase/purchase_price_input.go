@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 数字の文字列の形式。3桁ごとのカンマ区切り（"128,000"）も受け付ける
+var priceStringPattern = regexp.MustCompile(`^-?(\d+|\d{1,3}(,\d{3})+)$`)
+
+// 購入価格の入力値。JSONの整数と、数字の文字列（"128000"、"128,000"）を受け付ける。
+// 小数や数字以外の値はpurchase_priceのバリデーションエラーとし、負の値はほかの項目と同じくバリデーションで拒否する
+type PurchasePriceInput int64
+
+func (p *PurchasePriceInput) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+
+	var value string
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return invalidPurchasePrice()
+		}
+		if s = strings.TrimSpace(s); priceStringPattern.MatchString(s) {
+			value = strings.ReplaceAll(s, ",", "")
+		}
+	} else {
+		value = string(data)
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return invalidPurchasePrice()
+	}
+	*p = PurchasePriceInput(parsed)
+	return nil
+}
+
+// 購入価格をint64のポインタで返す。未指定（nil）の場合はnil
+func (p *PurchasePriceInput) Int64() *int64 {
+	if p == nil {
+		return nil
+	}
+	v := int64(*p)
+	return &v
+}
+
+func invalidPurchasePrice() error {
+	return domainErrors.NewValidationErrors(domainErrors.NewRuleError("purchase_price", domainErrors.CodeInvalidType, "purchase_price must be an integer or a numeric string", map[string]interface{}{"type": "integer"}))
+}
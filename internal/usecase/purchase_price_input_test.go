@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestPurchasePriceInput_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		json        string
+		expected    int64
+		expectedErr bool
+	}{
+		{name: "正常系: 整数", json: `128000`, expected: 128000},
+		{name: "正常系: 数字の文字列", json: `"128000"`, expected: 128000},
+		{name: "正常系: カンマ区切りの文字列", json: `"1,280,000"`, expected: 1280000},
+		{name: "正常系: 前後に空白がある文字列", json: `" 128,000 "`, expected: 128000},
+		{name: "正常系: 0", json: `"0"`, expected: 0},
+		{name: "正常系: 負の値はバリデーションで拒否するため受け付ける", json: `-1`, expected: -1},
+		{name: "正常系: 負の値の文字列", json: `"-1,000"`, expected: -1000},
+		{name: "異常系: 小数", json: `128000.5`, expectedErr: true},
+		{name: "異常系: 指数表記", json: `1e5`, expectedErr: true},
+		{name: "異常系: 小数の文字列", json: `"128000.5"`, expectedErr: true},
+		{name: "異常系: カンマの位置が誤っている", json: `"12,80,000"`, expectedErr: true},
+		{name: "異常系: 数字以外の文字列", json: `"abc"`, expectedErr: true},
+		{name: "異常系: 空文字", json: `""`, expectedErr: true},
+		{name: "異常系: 範囲外の値", json: `"99999999999999999999"`, expectedErr: true},
+		{name: "異常系: 真偽値", json: `true`, expectedErr: true},
+		{name: "異常系: 配列", json: `[1]`, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var input CreateItemInput
+			err := json.Unmarshal([]byte(`{"purchase_price": `+tt.json+`}`), &input)
+
+			if tt.expectedErr {
+				var validationErrors domainErrors.ValidationErrors
+				require.True(t, errors.As(err, &validationErrors), "%v", err)
+				assert.Equal(t, domainErrors.NewValidationErrors(domainErrors.NewRuleError("purchase_price", domainErrors.CodeInvalidType, "purchase_price must be an integer or a numeric string", map[string]interface{}{"type": "integer"})), validationErrors)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, PurchasePriceInput(tt.expected), input.PurchasePrice)
+		})
+	}
+}
+
+func TestPurchasePriceInput_Int64(t *testing.T) {
+	var input UpdateItemInput
+	require.NoError(t, json.Unmarshal([]byte(`{"purchase_price": "2,000,000"}`), &input))
+	assert.Equal(t, int64Ptr(2000000), input.PurchasePrice.Int64())
+
+	input = UpdateItemInput{}
+	require.NoError(t, json.Unmarshal([]byte(`{"purchase_price": null}`), &input))
+	assert.Nil(t, input.PurchasePrice.Int64())
+}
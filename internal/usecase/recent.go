@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 最近のアイテムの件数のデフォルト値と上限
+const (
+	DefaultRecentItemsLimit = 20
+	MaxRecentItemsLimit     = 100
+)
+
+// 最近のアイテムと、それを含めた理由（entity.RecentEventCreated・entity.RecentEventUpdated）
+type RecentItem struct {
+	Event string       `json:"event"`
+	Item  *entity.Item `json:"item"`
+}
+
+type RecentItemList struct {
+	Items []*RecentItem `json:"items"` // 更新日時の新しい順
+	Since time.Time     `json:"since"` // 期間の始まり。これ以降に登録または更新したアイテムを返す
+}
+
+// 現在からwindowの期間内に登録または更新したアイテムを、更新日時の新しい順に最大limit件返す。
+// limitが0の場合はデフォルト値を使い、上限を超える場合は上限に丸める。論理削除したアイテムは含めない
+func (u *itemUsecase) GetRecentItems(ctx context.Context, window time.Duration, limit int) (*RecentItemList, error) {
+	if window <= 0 || window > entity.MaxRecentWindow {
+		return nil, fmt.Errorf("%w: window must be greater than 0 and at most 90 days", domainErrors.ErrInvalidInput)
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("%w: limit must be 0 or greater", domainErrors.ErrInvalidInput)
+	}
+	if limit == 0 {
+		limit = DefaultRecentItemsLimit
+	}
+	limit = min(limit, MaxRecentItemsLimit)
+
+	since := entity.Now().Add(-window)
+	items, err := u.itemRepo.FindUpdatedSince(ctx, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve recent items: %w", err)
+	}
+
+	list := &RecentItemList{Items: make([]*RecentItem, len(items)), Since: since}
+	for i, item := range items {
+		list.Items[i] = &RecentItem{Event: entity.RecentEventOf(item, since), Item: item}
+	}
+	return list, nil
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/testutil"
+)
+
+func TestItemUsecase_GetRecentItems(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	t.Cleanup(entity.SetClock(testutil.NewFixedClock(now)))
+	since := now.Add(-7 * 24 * time.Hour)
+
+	t.Run("正常系: 期間内に登録したアイテムはcreated、それより前に登録して期間内に更新したアイテムはupdated", func(t *testing.T) {
+		updated := &entity.Item{ID: 1, Name: "デイトナ", CreatedAt: since.Add(-time.Hour), UpdatedAt: now.Add(-time.Hour)}
+		created := &entity.Item{ID: 2, Name: "バーキン", CreatedAt: since.Add(time.Hour), UpdatedAt: since.Add(2 * time.Hour)}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindUpdatedSince", mock.Anything, since, DefaultRecentItemsLimit).Return([]*entity.Item{updated, created}, nil)
+		uc := NewItemUsecase(mockRepo, newMockCategoryRepository(), nil, newTestExchangeRates(), nil, nil)
+
+		list, err := uc.GetRecentItems(context.Background(), 7*24*time.Hour, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, since, list.Since)
+		require.Len(t, list.Items, 2)
+		assert.Equal(t, entity.RecentEventUpdated, list.Items[0].Event)
+		assert.Equal(t, int64(1), list.Items[0].Item.ID)
+		assert.Equal(t, entity.RecentEventCreated, list.Items[1].Event)
+		assert.Equal(t, int64(2), list.Items[1].Item.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 上限を超えるlimitは上限に丸める", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindUpdatedSince", mock.Anything, now.Add(-time.Hour), MaxRecentItemsLimit).Return([]*entity.Item{}, nil)
+		uc := NewItemUsecase(mockRepo, newMockCategoryRepository(), nil, newTestExchangeRates(), nil, nil)
+
+		list, err := uc.GetRecentItems(context.Background(), time.Hour, MaxRecentItemsLimit+1)
+
+		require.NoError(t, err)
+		assert.Empty(t, list.Items)
+		assert.NotNil(t, list.Items)
+		mockRepo.AssertExpectations(t)
+	})
+
+	for _, tt := range []struct {
+		name   string
+		window time.Duration
+		limit  int
+	}{
+		{name: "異常系: 期間が0", window: 0},
+		{name: "異常系: 期間が90日を超える", window: entity.MaxRecentWindow + time.Hour},
+		{name: "異常系: 負のlimit", window: time.Hour, limit: -1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			uc := NewItemUsecase(mockRepo, newMockCategoryRepository(), nil, newTestExchangeRates(), nil, nil)
+
+			_, err := uc.GetRecentItems(context.Background(), tt.window, tt.limit)
+
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			mockRepo.AssertNotCalled(t, "FindUpdatedSince", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("異常系: 取得に失敗", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindUpdatedSince", mock.Anything, since, 10).Return(nil, domainErrors.ErrDatabaseError)
+		uc := NewItemUsecase(mockRepo, newMockCategoryRepository(), nil, newTestExchangeRates(), nil, nil)
+
+		_, err := uc.GetRecentItems(context.Background(), 7*24*time.Hour, 10)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+}
@@ -2,27 +2,296 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"Aicon-assignment/internal/domain/entity"
 )
 
 // ItemRepository defines the interface for item data access
 type ItemRepository interface {
-	// FindAll retrieves all items
-	FindAll(ctx context.Context) ([]*entity.Item, error)
+	// FindAll retrieves items matching the filter in the given order within the given page
+	FindAll(ctx context.Context, filter entity.ItemFilter, sort entity.ItemSort, page entity.Pagination) ([]*entity.Item, error)
+
+	// EachItem passes the items matching the filter to fn one at a time in the given order, without loading them all into memory.
+	// Iteration stops at the first error returned by fn, and that error is returned
+	EachItem(ctx context.Context, filter entity.ItemFilter, sort entity.ItemSort, fn func(*entity.Item) error) error
+
+	// Count returns the number of items matching the filter
+	Count(ctx context.Context, filter entity.ItemFilter) (int, error)
+
+	// MaxUpdatedAt returns the latest updated_at of the items matching the filter, or nil if there are none.
+	// Soft-deleted items are always included regardless of filter.IncludeDeleted, so that a deletion also advances the result
+	MaxUpdatedAt(ctx context.Context, filter entity.ItemFilter) (*time.Time, error)
+
+	// FindUpdatedSince retrieves at most limit items whose updated_at is at or after since, newest updated_at first.
+	// Items created at or after since are included as well, because updated_at is set to created_at on creation
+	FindUpdatedSince(ctx context.Context, since time.Time, limit int) ([]*entity.Item, error)
 
 	// FindByID retrieves an item by ID
 	FindByID(ctx context.Context, id int64) (*entity.Item, error)
 
-	// Create creates a new item and returns it with the generated ID
+	// FindByIDForUpdate retrieves an item by ID and, inside Transactor.WithinTx, locks it until the transaction ends
+	FindByIDForUpdate(ctx context.Context, id int64) (*entity.Item, error)
+
+	// FindBySerialNumber retrieves an item by serial number. Returns ErrItemNotFound when there is none
+	FindBySerialNumber(ctx context.Context, serialNumber string) (*entity.Item, error)
+
+	// FindByIDs retrieves the items with the given IDs; missing IDs are skipped and order is not guaranteed
+	FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error)
+
+	// FindByPurchaseDate retrieves the items purchased on the given date in ID order
+	FindByPurchaseDate(ctx context.Context, purchaseDate entity.PurchaseDate) ([]*entity.Item, error)
+
+	// Create creates a new item and returns it with the generated ID.
+	// Returns ErrDuplicateSerialNumber when another item already has the same serial number
 	Create(ctx context.Context, item *entity.Item) (*entity.Item, error)
 
-	// Delete deletes an item by ID
-	Delete(ctx context.Context, id int64) error
+	// CreateMany creates items in a single transaction and returns their IDs in order
+	CreateMany(ctx context.Context, items []*entity.Item) ([]int64, error)
+
+	// Delete soft-deletes an item by ID and returns the item before and after the change
+	Delete(ctx context.Context, id int64) (*entity.ItemChange, error)
+
+	// Restore restores a soft-deleted item by ID and returns the item before and after the change
+	Restore(ctx context.Context, id int64) (*entity.ItemChange, error)
 
-	// Update updates an existing item and returns the updated item
+	// HardDelete permanently deletes an item by ID, including soft-deleted ones, and returns the item before the change
+	HardDelete(ctx context.Context, id int64) (*entity.ItemChange, error)
+
+	// Update updates an existing item and returns the updated item.
+	// The update only succeeds when item.Version matches the stored version, which is then incremented;
+	// otherwise a *VersionConflictError carrying the stored version is returned
 	Update(ctx context.Context, item *entity.Item) (*entity.Item, error)
 
-	// GetSummaryByCategory returns item counts grouped by category (bonus feature)
-	GetSummaryByCategory(ctx context.Context) (map[string]int, error)
+	// CreateWithIdempotencyKey creates a new item and registers the idempotency key for it in a single transaction.
+	// Keys are unique per owner of the item, so other users can use the same key independently.
+	// Keys created at or before createdBefore are treated as expired and replaced.
+	// Returns ErrIdempotencyKeyExists when the owner already registered the key, which is detected by a unique constraint
+	CreateWithIdempotencyKey(ctx context.Context, item *entity.Item, key *entity.IdempotencyKey, createdBefore time.Time) (*entity.Item, error)
+
+	// FindIdempotencyKey retrieves the caller's idempotency key created after createdAfter. Returns ErrIdempotencyKeyNotFound when there is none
+	FindIdempotencyKey(ctx context.Context, key string, createdAfter time.Time) (*entity.IdempotencyKey, error)
+
+	// DeleteExpiredIdempotencyKeys deletes idempotency keys created at or before createdBefore and returns the number of deleted keys
+	DeleteExpiredIdempotencyKeys(ctx context.Context, createdBefore time.Time) (int64, error)
+
+	// FindImages retrieves the images of an item in display order
+	FindImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error)
+
+	// FindImagesByItemIDs retrieves the images of the given items in a single query, grouped by item ID in display order.
+	// Items without images are not included in the result
+	FindImagesByItemIDs(ctx context.Context, itemIDs []int64) (map[int64][]*entity.ItemImage, error)
+
+	// AddImage appends an image to the end of the item's images. Returns ErrImageLimitExceeded when the item already has maxImages images
+	AddImage(ctx context.Context, itemID int64, url string, maxImages int) (*entity.ItemImage, error)
+
+	// DeleteImage deletes an image of an item
+	DeleteImage(ctx context.Context, itemID, imageID int64) error
+
+	// ReorderImages sets the display order to the order of imageIDs, which must list every image of the item exactly once
+	ReorderImages(ctx context.Context, itemID int64, imageIDs []int64) error
+
+	// FindHistories retrieves the change history of an item, newest first
+	FindHistories(ctx context.Context, itemID int64, page entity.Pagination) ([]*entity.ItemHistory, error)
+
+	// CreateHistory records the snapshots of an item before and after a change. A nil snapshot is stored as null
+	CreateHistory(ctx context.Context, itemID int64, action string, before, after *entity.Item) error
+
+	// CountHistories returns the number of history entries of an item
+	CountHistories(ctx context.Context, itemID int64) (int, error)
+
+	// GetSummaryByCategory returns the item count and price total of every category per currency, including empty categories (bonus feature)
+	GetSummaryByCategory(ctx context.Context) ([]*entity.CategoryCurrencyTotal, error)
+
+	// GetProfitByCurrency returns the count, selling price total and purchase price total of sold items with a recorded selling price per currency.
+	// When year is not 0, only items sold in that year are included
+	GetProfitByCurrency(ctx context.Context, year int) ([]*entity.ProfitCurrencyTotal, error)
+
+	// GetSpendByLocation returns the item count and purchase price total of items that are not soft-deleted per purchase location and currency.
+	// Items without a purchase location are grouped under a nil location
+	GetSpendByLocation(ctx context.Context) ([]*entity.LocationCurrencyTotal, error)
+
+	// GetSpendByPeriod returns the item count and purchase price total of items that are not soft-deleted per purchase period and currency
+	// within the range. Periods without purchases are not returned
+	GetSpendByPeriod(ctx context.Context, r entity.SpendRange) ([]*entity.PeriodCurrencyTotal, error)
+
+	// GetSummaryByBrand returns the item count and purchase price total of owned and listed items per brand and currency.
+	// Brands that differ only in letter case are grouped together
+	GetSummaryByBrand(ctx context.Context) ([]*entity.BrandCurrencyTotal, error)
+
+	// FindMostExpensiveByBrand returns the item with the highest purchase price of owned and listed items for each brand and currency.
+	// Ties are broken by the smaller ID
+	FindMostExpensiveByBrand(ctx context.Context) ([]*entity.ItemPrice, error)
+
+	// GetStatsByCategory returns the item count, price total and latest purchase date of items matching the filter per category and currency
+	GetStatsByCategory(ctx context.Context, filter entity.ItemFilter) ([]*entity.CategoryCurrencyStats, error)
+
+	// FindMostExpensiveByCurrency returns the item with the highest purchase price among items matching the filter for each currency.
+	// Ties are broken by the smaller ID
+	FindMostExpensiveByCurrency(ctx context.Context, filter entity.ItemFilter) ([]*entity.ItemPrice, error)
+}
+
+// CategoryRepository defines the interface for category data access
+type CategoryRepository interface {
+	// FindAll retrieves all categories in registration order
+	FindAll(ctx context.Context) ([]*entity.Category, error)
+
+	// FindByID retrieves a category by ID
+	FindByID(ctx context.Context, id int64) (*entity.Category, error)
+
+	// Create creates a new category. Returns ErrDuplicateEntry when the name is already registered
+	Create(ctx context.Context, category *entity.Category) (*entity.Category, error)
+
+	// Rename renames a category and the category of the items that reference it in the same transaction
+	Rename(ctx context.Context, id int64, name string) (*entity.Category, error)
+
+	// Delete deletes a category only when no item, including soft-deleted ones, references it
+	Delete(ctx context.Context, id int64) error
+
+	// CountItems returns the number of items, including soft-deleted ones, in the category
+	CountItems(ctx context.Context, name string) (int, error)
+}
+
+// TagRepository defines the interface for tag data access. Tags are attached to items through ItemRepository
+type TagRepository interface {
+	// FindAllWithCounts retrieves the tags attached to at least one item that is not soft-deleted, in name order with their item counts
+	FindAllWithCounts(ctx context.Context) ([]*entity.TagCount, error)
+}
+
+// BrandRepository defines the interface for canonical brand data access
+type BrandRepository interface {
+	// Search retrieves up to limit brands whose name or one of whose aliases starts with prefix, ignoring letter case, in name order.
+	// An empty prefix matches every brand
+	Search(ctx context.Context, prefix string, limit int) ([]*entity.Brand, error)
+
+	// FindByID retrieves a brand by ID
+	FindByID(ctx context.Context, id int64) (*entity.Brand, error)
+
+	// FindByNameOrAlias retrieves the brand whose name or one of whose aliases equals name, ignoring letter case
+	FindByNameOrAlias(ctx context.Context, name string) (*entity.Brand, error)
+
+	// Create registers a brand with its aliases. The name and aliases must not be used by another brand
+	Create(ctx context.Context, brand *entity.Brand) (*entity.Brand, error)
+
+	// Merge moves the source brand into the target brand in a transaction: items using the source name or aliases are rewritten
+	// to the target name, the source name and aliases become aliases of the target, and the source brand is deleted.
+	// It returns the number of rewritten items
+	Merge(ctx context.Context, sourceID, targetID int64) (int64, error)
+}
+
+// WebhookRepository defines the interface for webhook endpoints and the log of their delivery attempts
+type WebhookRepository interface {
+	// FindAll retrieves all webhooks, including disabled ones, in registration order
+	FindAll(ctx context.Context) ([]*entity.Webhook, error)
+
+	// FindByID retrieves a webhook by ID
+	FindByID(ctx context.Context, id int64) (*entity.Webhook, error)
+
+	// Create registers a webhook
+	Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error)
+
+	// Delete deletes a webhook together with its delivery attempts
+	Delete(ctx context.Context, id int64) error
+
+	// Enable re-enables a webhook and resets its failure count
+	Enable(ctx context.Context, id int64) (*entity.Webhook, error)
+
+	// RecordResult updates the failure count of a webhook after a delivery: a success resets it, and a failure increments it
+	// and disables the webhook once it reaches maxFailures. A webhook deleted in the meantime is ignored
+	RecordResult(ctx context.Context, id int64, succeeded bool, maxFailures int) error
+
+	// CreateDelivery records a delivery attempt
+	CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+
+	// FindDeliveries retrieves up to limit delivery attempts of a webhook, newest first
+	FindDeliveries(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error)
+
+	// DeleteDeliveriesBefore deletes the delivery attempts recorded at or before createdBefore and returns the number deleted
+	DeleteDeliveriesBefore(ctx context.Context, createdBefore time.Time) (int64, error)
+}
+
+// ImportJobRepository defines the interface for background CSV import jobs
+type ImportJobRepository interface {
+	// Create registers a queued job owned by the caller's user
+	Create(ctx context.Context, job *entity.ImportJob) (*entity.ImportJob, error)
+
+	// FindByID retrieves a job by ID. Jobs of other users are reported as not found
+	FindByID(ctx context.Context, id int64) (*entity.ImportJob, error)
+
+	// Start marks a queued job as running. Other jobs are left unchanged
+	Start(ctx context.Context, id int64) error
+
+	// UpdateProgress records the number of rows parsed so far by a running job. Other jobs are left unchanged
+	UpdateProgress(ctx context.Context, id int64, rowsProcessed int) error
+
+	// Finish records the result and the final status of a queued or running job. Other jobs are left unchanged
+	Finish(ctx context.Context, job *entity.ImportJob) error
+
+	// FailUnfinished marks all queued and running jobs as failed with reason and returns the number updated
+	FailUnfinished(ctx context.Context, reason string) (int64, error)
+
+	// DeleteFinishedBefore deletes the jobs finished at or before finishedBefore and returns the number deleted
+	DeleteFinishedBefore(ctx context.Context, finishedBefore time.Time) (int64, error)
+}
+
+// APIKeyRepository defines the interface for API keys. Keys are stored and looked up by their hash only
+type APIKeyRepository interface {
+	// FindAll retrieves all keys including revoked ones, ordered by ID
+	FindAll(ctx context.Context) ([]*entity.APIKey, error)
+
+	// FindByHash retrieves a key including a revoked one by the hash of the key
+	FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error)
+
+	// Create registers a key
+	Create(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error)
+
+	// Revoke sets the revocation time of a key that has not been revoked yet. Revoked keys are left unchanged
+	Revoke(ctx context.Context, id int64) (*entity.APIKey, error)
+
+	// UpdateRole changes the role of a key including a revoked one
+	UpdateRole(ctx context.Context, id int64, role entity.UserRole) (*entity.APIKey, error)
+}
+
+// UserRepository defines the interface for users and their refresh tokens. Tokens are stored and looked up by their hash only
+type UserRepository interface {
+	// FindAll retrieves all users ordered by ID
+	FindAll(ctx context.Context) ([]*entity.User, error)
+
+	// FindByID retrieves a user by ID. Returns ErrUserNotFound when there is none
+	FindByID(ctx context.Context, id int64) (*entity.User, error)
+
+	// FindByEmail retrieves a user by the normalized email address. Returns ErrUserNotFound when there is none
+	FindByEmail(ctx context.Context, email string) (*entity.User, error)
+
+	// Create registers a user. Returns ErrDuplicateEmail when the email address is already registered
+	Create(ctx context.Context, user *entity.User) (*entity.User, error)
+
+	// UpdatePassword replaces the password hash of a user
+	UpdatePassword(ctx context.Context, id int64, passwordHash string) (*entity.User, error)
+
+	// UpdateRole replaces the role of a user
+	UpdateRole(ctx context.Context, id int64, role entity.UserRole) (*entity.User, error)
+
+	// CreateRefreshToken registers a refresh token
+	CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error)
+
+	// FindRefreshToken retrieves a refresh token including a revoked or expired one by the hash of the token.
+	// Returns ErrRefreshTokenNotFound when there is none
+	FindRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+
+	// RevokeRefreshToken sets the revocation time of a token that has not been revoked yet.
+	// Returns false when the token was already revoked, so that concurrent refreshes with the same token succeed only once
+	RevokeRefreshToken(ctx context.Context, id int64) (bool, error)
+}
+
+// AuditLogRepository defines the interface for the audit log of changes to items
+type AuditLogRepository interface {
+	// Create records an entry. The ID is assigned by the repository
+	Create(ctx context.Context, log *entity.AuditLog) error
+
+	// FindAll retrieves the entries matching the filter, newest first
+	FindAll(ctx context.Context, filter entity.AuditLogFilter, page entity.Pagination) ([]*entity.AuditLog, error)
+
+	// Count returns the number of entries matching the filter
+	Count(ctx context.Context, filter entity.AuditLogFilter) (int, error)
 }
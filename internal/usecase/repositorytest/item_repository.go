@@ -0,0 +1,726 @@
+// usecase.ItemRepositoryの実装が満たすべき振る舞いを確かめる共通のテスト
+package repositorytest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
+	"Aicon-assignment/internal/testutil"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ItemRepositoryFactory はテストごとに空のリポジトリを返す。
+// カテゴリーにはマイグレーションで登録する初期カテゴリー（時計、バッグ、ジュエリー、靴、その他）を登録しておく
+type ItemRepositoryFactory func(t *testing.T) usecase.ItemRepository
+
+// RunItemRepositoryTests はnewRepositoryで作成したリポジトリに対して共通のテストを実行する
+func RunItemRepositoryTests(t *testing.T, newRepository ItemRepositoryFactory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo usecase.ItemRepository)
+	}{
+		{"正常系: 作成したアイテムを取得できる", testCreateAndFind},
+		{"異常系: シリアル番号の重複", testDuplicateSerialNumber},
+		{"正常系: 一覧の絞り込み・並び替え・ページネーション", testFindAll},
+		{"正常系: カーソルによるページネーション", testFindAllAfterCursor},
+		{"正常系: 並び替えの項目が同値のアイテムをオフセットで辿る", testFindAllPagesWithDuplicateKeys},
+		{"正常系: 1件ずつの読み込み", testEachItem},
+		{"正常系: 複数のアイテムを連続したIDで作成する", testCreateMany},
+		{"正常系: 更新でバージョンが進む", testUpdate},
+		{"正常系: 論理削除・復元・物理削除で変更前後のアイテムを返す", testDeleteRestoreHardDelete},
+		{"正常系: 論理削除したアイテムを含む最新の更新日時", testMaxUpdatedAt},
+		{"正常系: 指定した日時以降に登録・更新したアイテム", testFindUpdatedSince},
+		{"正常系: 履歴の記録", testCreateHistory},
+		{"正常系: 画像の追加・並び替え・削除", testImages},
+		{"正常系: 冪等キーの登録と期限切れの削除", testIdempotencyKeys},
+		{"正常系: 集計", testSummaries},
+		{"正常系: ユーザーごとにアイテムを分ける", testOwnerScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepository(t))
+		})
+	}
+}
+
+var ctx = context.Background()
+
+// 登録済みのカテゴリー名の一覧。NewItemの検証に使う
+var categories = entity.NewCategorySet("時計", "バッグ", "ジュエリー", "靴", "その他")
+
+func newItem(t *testing.T, input entity.NewItemInput) *entity.Item {
+	t.Helper()
+	if input.Category == "" {
+		input.Category = "時計"
+	}
+	if input.Brand == "" {
+		input.Brand = "ROLEX"
+	}
+	if input.PurchaseDate.IsZero() {
+		input.PurchaseDate = entity.MustParsePurchaseDate("2023-01-15")
+	}
+	input.Categories = categories
+
+	item, err := entity.NewItem(input)
+	require.NoError(t, err)
+	return item
+}
+
+func create(t *testing.T, repo usecase.ItemRepository, input entity.NewItemInput) *entity.Item {
+	t.Helper()
+	item, err := repo.Create(ctx, newItem(t, input))
+	require.NoError(t, err)
+	return item
+}
+
+func ids(items []*entity.Item) []int64 {
+	result := make([]int64, len(items))
+	for i, item := range items {
+		result[i] = item.ID
+	}
+	return result
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func testCreateAndFind(t *testing.T, repo usecase.ItemRepository) {
+	created := create(t, repo, entity.NewItemInput{
+		Name: "デイトナ", Brand: "ROLEX", PurchasePrice: 1500000, Currency: "USD",
+		SerialNumber: "SN-1", Condition: "中古A", PurchaseLocation: "銀座店", Tags: []string{"限定", "ギフト"},
+	})
+
+	assert.NotZero(t, created.ID)
+	assert.Equal(t, int64(1), created.Version)
+	assert.Equal(t, "watch", created.CategorySlug)
+	assert.Equal(t, []string{"ギフト", "限定"}, created.Tags)
+	assert.Equal(t, entity.ItemStatusOwned, created.Status)
+
+	found, err := repo.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "デイトナ", found.Name)
+	assert.Equal(t, int64(1500000), found.PurchasePrice)
+	assert.Equal(t, "USD", found.Currency)
+	assert.Equal(t, "2023-01-15", found.PurchaseDate.String())
+	require.NotNil(t, found.SerialNumber)
+	assert.Equal(t, "SN-1", *found.SerialNumber)
+	require.NotNil(t, found.Condition)
+	assert.Equal(t, "中古A", *found.Condition)
+	require.NotNil(t, found.PurchaseLocation)
+	assert.Equal(t, "銀座店", *found.PurchaseLocation)
+	assert.Equal(t, []string{"ギフト", "限定"}, found.Tags)
+	assert.Nil(t, found.DeletedAt)
+
+	bySerial, err := repo.FindBySerialNumber(ctx, "SN-1")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, bySerial.ID)
+
+	byDate, err := repo.FindByPurchaseDate(ctx, entity.MustParsePurchaseDate("2023-01-15"))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{created.ID}, ids(byDate))
+
+	byIDs, err := repo.FindByIDs(ctx, []int64{created.ID, created.ID + 1000})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{created.ID}, ids(byIDs))
+
+	_, err = repo.FindByID(ctx, created.ID+1000)
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	_, err = repo.FindBySerialNumber(ctx, "SN-2")
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+}
+
+func testDuplicateSerialNumber(t *testing.T, repo usecase.ItemRepository) {
+	create(t, repo, entity.NewItemInput{Name: "デイトナ", SerialNumber: "SN-1"})
+
+	_, err := repo.Create(ctx, newItem(t, entity.NewItemInput{Name: "サブマリーナ", SerialNumber: "SN-1"}))
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateSerialNumber)
+
+	// シリアル番号のないアイテムはいくつでも登録できる
+	create(t, repo, entity.NewItemInput{Name: "エクスプローラー"})
+	create(t, repo, entity.NewItemInput{Name: "ミルガウス"})
+}
+
+func testFindAll(t *testing.T, repo usecase.ItemRepository) {
+	cheap := create(t, repo, entity.NewItemInput{Name: "ネックレス", Category: "ジュエリー", Brand: "Tiffany & Co.", PurchasePrice: 300000, Tags: []string{"ギフト"}})
+	middle := create(t, repo, entity.NewItemInput{Name: "デイトナ", Brand: "ROLEX", PurchasePrice: 1500000, Tags: []string{"ギフト", "限定"}})
+	expensive := create(t, repo, entity.NewItemInput{Name: "サブマリーナ", Brand: "Rolex", PurchasePrice: 2000000, PurchaseDate: entity.MustParsePurchaseDate("2023-06-01")})
+
+	byPrice := entity.ItemSort{Field: entity.SortByPurchasePrice, Order: entity.SortOrderAsc}
+	all := entity.Pagination{Limit: 10}
+
+	items, err := repo.FindAll(ctx, entity.ItemFilter{}, byPrice, all)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{cheap.ID, middle.ID, expensive.ID}, ids(items))
+
+	items, err = repo.FindAll(ctx, entity.ItemFilter{}, entity.ItemSort{Field: entity.SortByPurchasePrice, Order: entity.SortOrderDesc}, entity.Pagination{Limit: 1, Offset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{middle.ID}, ids(items))
+
+	items, err = repo.FindAll(ctx, entity.ItemFilter{}, byPrice, entity.Pagination{Limit: 10, Offset: 10})
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	filters := []struct {
+		name     string
+		filter   entity.ItemFilter
+		expected []int64
+	}{
+		{"カテゴリー", entity.ItemFilter{Category: "時計"}, []int64{middle.ID, expensive.ID}},
+		{"ブランドは大文字小文字を区別しない部分一致", entity.ItemFilter{Brand: "rol"}, []int64{middle.ID, expensive.ID}},
+		{"キーワードは名前かブランド", entity.ItemFilter{Keyword: "tiffany"}, []int64{cheap.ID}},
+		{"タグはすべて一致", entity.ItemFilter{Tags: []string{"ギフト", "限定"}}, []int64{middle.ID}},
+		{"価格の範囲は境界値を含む", entity.ItemFilter{MinPrice: int64Ptr(300000), MaxPrice: int64Ptr(1500000)}, []int64{cheap.ID, middle.ID}},
+		{"購入日の範囲", entity.ItemFilter{PurchasedFrom: timePtr(time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC))}, []int64{expensive.ID}},
+	}
+	for _, f := range filters {
+		items, err := repo.FindAll(ctx, f.filter, byPrice, all)
+		require.NoError(t, err, f.name)
+		assert.Equal(t, f.expected, ids(items), f.name)
+
+		count, err := repo.Count(ctx, f.filter)
+		require.NoError(t, err, f.name)
+		assert.Equal(t, len(f.expected), count, f.name)
+	}
+}
+
+// 並び替えの項目が同値のアイテムを含めても、カーソルで辿ったページをつなげると一度に取得した一覧と一致する
+func testFindAllAfterCursor(t *testing.T, repo usecase.ItemRepository) {
+	create(t, repo, entity.NewItemInput{Name: "デイトナ", PurchasePrice: 1500000, PurchaseDate: entity.MustParsePurchaseDate("2023-01-15")})
+	create(t, repo, entity.NewItemInput{Name: "サブマリーナ", PurchasePrice: 1500000, PurchaseDate: entity.MustParsePurchaseDate("2023-01-15")})
+	create(t, repo, entity.NewItemInput{Name: "GMTマスター", PurchasePrice: 1200000, PurchaseDate: entity.MustParsePurchaseDate("2023-03-01")})
+	create(t, repo, entity.NewItemInput{Name: "エクスプローラー", PurchasePrice: 800000, PurchaseDate: entity.MustParsePurchaseDate("2023-01-15")})
+	deleted := create(t, repo, entity.NewItemInput{Name: "ヨットマスター", PurchasePrice: 1500000})
+	_, err := repo.Delete(ctx, deleted.ID)
+	require.NoError(t, err)
+	create(t, repo, entity.NewItemInput{Name: "ミルガウス", PurchasePrice: 1500000, PurchaseDate: entity.MustParsePurchaseDate("2022-12-24")})
+
+	for _, field := range entity.ValidSortFields {
+		for _, order := range []string{entity.SortOrderAsc, entity.SortOrderDesc} {
+			sort := entity.ItemSort{Field: field, Order: order}
+			all, err := repo.FindAll(ctx, entity.ItemFilter{}, sort, entity.Pagination{Limit: 100})
+			require.NoError(t, err)
+			require.Len(t, all, 5)
+
+			var paged []*entity.Item
+			page := entity.Pagination{Limit: 2}
+			for {
+				items, err := repo.FindAll(ctx, entity.ItemFilter{}, sort, page)
+				require.NoError(t, err)
+				paged = append(paged, items...)
+				if len(items) < page.Limit {
+					break
+				}
+				cursor := sort.CursorOf(items[len(items)-1])
+				page.After = &cursor
+			}
+			assert.Equal(t, ids(all), ids(paged), "%s %s", field, order)
+		}
+	}
+}
+
+// 並び替えの項目がほとんど同値でも、idで順序が確定するためオフセットで辿ったページに重複や欠落がない。
+// 並び替えを指定しない場合はcreated_atの降順、同値の場合はidの降順
+func testFindAllPagesWithDuplicateKeys(t *testing.T, repo usecase.ItemRepository) {
+	// 登録日時もすべて同じにする
+	t.Cleanup(entity.SetClock(testutil.NewFixedClock(time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC))))
+
+	names := []string{"デイトナ", "Daytona", "daytona"}
+	prices := []int64{1500000, 800000}
+	dates := []entity.PurchaseDate{entity.MustParsePurchaseDate("2023-01-15"), entity.MustParsePurchaseDate("2023-03-01")}
+	created := make(map[int64]bool)
+	for i := 0; i < 23; i++ {
+		item := create(t, repo, entity.NewItemInput{Name: names[i%len(names)], PurchasePrice: prices[i%len(prices)], PurchaseDate: dates[i%len(dates)]})
+		created[item.ID] = true
+	}
+
+	sorts := []entity.ItemSort{{}}
+	for _, field := range entity.ValidSortFields {
+		for _, order := range []string{entity.SortOrderAsc, entity.SortOrderDesc} {
+			sorts = append(sorts, entity.ItemSort{Field: field, Order: order})
+		}
+	}
+	for _, sort := range sorts {
+		seen := make(map[int64]bool)
+		var paged []int64
+		for page := (entity.Pagination{Limit: 4}); ; page.Offset += page.Limit {
+			items, err := repo.FindAll(ctx, entity.ItemFilter{}, sort, page)
+			require.NoError(t, err)
+			for _, item := range items {
+				assert.False(t, seen[item.ID], "%+v: id %d が複数のページにある", sort, item.ID)
+				seen[item.ID] = true
+			}
+			paged = append(paged, ids(items)...)
+			if len(items) < page.Limit {
+				break
+			}
+		}
+		assert.Equal(t, created, seen, "%+v", sort)
+
+		// 同じ条件で取得し直しても順序は変わらない
+		all, err := repo.FindAll(ctx, entity.ItemFilter{}, sort, entity.Pagination{Limit: 100})
+		require.NoError(t, err)
+		assert.Equal(t, ids(all), paged, "%+v", sort)
+	}
+
+	defaults, err := repo.FindAll(ctx, entity.ItemFilter{}, entity.ItemSort{}, entity.Pagination{Limit: 100})
+	require.NoError(t, err)
+	for i := 1; i < len(defaults); i++ {
+		assert.Greater(t, defaults[i-1].ID, defaults[i].ID)
+	}
+}
+
+// 絞り込み条件と並び順はFindAllと同じで、fnがエラーを返した時点で打ち切る
+func testEachItem(t *testing.T, repo usecase.ItemRepository) {
+	create(t, repo, entity.NewItemInput{Name: "ネックレス", Category: "ジュエリー", Brand: "Tiffany & Co.", PurchasePrice: 300000})
+	create(t, repo, entity.NewItemInput{Name: "デイトナ", Brand: "ROLEX", PurchasePrice: 1500000, Tags: []string{"限定"}})
+	create(t, repo, entity.NewItemInput{Name: "サブマリーナ", Brand: "Rolex", PurchasePrice: 2000000})
+	deleted := create(t, repo, entity.NewItemInput{Name: "ヨットマスター", Brand: "ROLEX", PurchasePrice: 1800000})
+	_, err := repo.Delete(ctx, deleted.ID)
+	require.NoError(t, err)
+
+	sort := entity.ItemSort{Field: entity.SortByPurchasePrice, Order: entity.SortOrderDesc}
+	for _, filter := range []entity.ItemFilter{{}, {Category: "時計"}, {Brand: "rol"}, {Tags: []string{"限定"}}} {
+		expected, err := repo.FindAll(ctx, filter, sort, entity.Pagination{Limit: 100})
+		require.NoError(t, err)
+
+		var items []*entity.Item
+		require.NoError(t, repo.EachItem(ctx, filter, sort, func(item *entity.Item) error {
+			items = append(items, item)
+			return nil
+		}))
+		assert.Equal(t, expected, items, "%+v", filter)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = repo.EachItem(ctx, entity.ItemFilter{}, sort, func(*entity.Item) error {
+		calls++
+		return stop
+	})
+	assert.Same(t, stop, err)
+	assert.Equal(t, 1, calls)
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func testCreateMany(t *testing.T, repo usecase.ItemRepository) {
+	created, err := repo.CreateMany(ctx, []*entity.Item{
+		newItem(t, entity.NewItemInput{Name: "デイトナ", Tags: []string{"限定"}}),
+		newItem(t, entity.NewItemInput{Name: "サブマリーナ"}),
+	})
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	assert.Equal(t, created[0]+1, created[1])
+
+	first, err := repo.FindByID(ctx, created[0])
+	require.NoError(t, err)
+	assert.Equal(t, "デイトナ", first.Name)
+	assert.Equal(t, []string{"限定"}, first.Tags)
+
+	// 1つのINSERTの上限を超える件数は分けて登録し、端数の行も含めて入力と同じ順序のIDを返す
+	many := make([]*entity.Item, 1201)
+	for i := range many {
+		many[i] = newItem(t, entity.NewItemInput{Name: fmt.Sprintf("デイトナ %d", i)})
+	}
+	ids, err := repo.CreateMany(ctx, many)
+	require.NoError(t, err)
+	require.Len(t, ids, len(many))
+	found, err := repo.FindByIDs(ctx, ids)
+	require.NoError(t, err)
+	names := make(map[int64]string, len(found))
+	for _, item := range found {
+		names[item.ID] = item.Name
+	}
+	for i, id := range ids {
+		assert.Equal(t, many[i].Name, names[id])
+	}
+
+	empty, err := repo.CreateMany(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func testUpdate(t *testing.T, repo usecase.ItemRepository) {
+	created := create(t, repo, entity.NewItemInput{Name: "デイトナ", PurchasePrice: 1500000, Tags: []string{"限定"}})
+
+	item := *created
+	item.Name = "デイトナ 116500LN"
+	item.Tags = []string{"ギフト"}
+	updated, err := repo.Update(ctx, &item)
+	require.NoError(t, err)
+	assert.Equal(t, "デイトナ 116500LN", updated.Name)
+	assert.Equal(t, int64(2), updated.Version)
+	assert.Equal(t, []string{"ギフト"}, updated.Tags)
+
+	// 古いバージョンでの更新は保存済みのバージョンを返して失敗する
+	_, err = repo.Update(ctx, &item)
+	var conflict *domainErrors.VersionConflictError
+	require.True(t, errors.As(err, &conflict))
+	assert.Equal(t, int64(2), conflict.CurrentVersion)
+
+	// 履歴はusecaseのイベントの処理で記録するため、リポジトリでは記録しない
+	count, err := repo.CountHistories(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func testDeleteRestoreHardDelete(t *testing.T, repo usecase.ItemRepository) {
+	item := create(t, repo, entity.NewItemInput{Name: "デイトナ"})
+
+	change, err := repo.Delete(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Nil(t, change.Before.DeletedAt)
+	require.NotNil(t, change.After.DeletedAt)
+	_, err = repo.FindByID(ctx, item.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	_, err = repo.Delete(ctx, item.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+
+	count, err := repo.Count(ctx, entity.ItemFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	count, err = repo.Count(ctx, entity.ItemFilter{IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	change, err = repo.Restore(ctx, item.ID)
+	require.NoError(t, err)
+	require.NotNil(t, change.Before.DeletedAt)
+	assert.Nil(t, change.After.DeletedAt)
+	_, err = repo.Restore(ctx, item.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	_, err = repo.FindByID(ctx, item.ID)
+	require.NoError(t, err)
+
+	change, err = repo.HardDelete(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "デイトナ", change.Before.Name)
+	assert.Nil(t, change.After)
+	_, err = repo.HardDelete(ctx, item.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+}
+
+func testMaxUpdatedAt(t *testing.T, repo usecase.ItemRepository) {
+	latest, err := repo.MaxUpdatedAt(ctx, entity.ItemFilter{})
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	create(t, repo, entity.NewItemInput{Name: "デイトナ", Category: "時計"})
+	bag := create(t, repo, entity.NewItemInput{Name: "バーキン", Category: "バッグ"})
+
+	latest, err = repo.MaxUpdatedAt(ctx, entity.ItemFilter{Category: "バッグ"})
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	latest, err = repo.MaxUpdatedAt(ctx, entity.ItemFilter{Category: "靴"})
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	// 論理削除したアイテムは一覧から外れるが、削除した日時として結果に残る
+	change, err := repo.Delete(ctx, bag.ID)
+	require.NoError(t, err)
+	assert.False(t, change.After.UpdatedAt.Before(change.Before.UpdatedAt))
+	latest, err = repo.MaxUpdatedAt(ctx, entity.ItemFilter{Category: "バッグ"})
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.True(t, latest.Equal(change.After.UpdatedAt), "got %v, want %v", latest, change.After.UpdatedAt)
+
+	// ほかのユーザーのアイテムは対象としない
+	latest, err = repo.MaxUpdatedAt(principal.NewContext(ctx, principal.Principal{UserID: 3}), entity.ItemFilter{})
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+}
+
+func testFindUpdatedSince(t *testing.T, repo usecase.ItemRepository) {
+	daytona := create(t, repo, entity.NewItemInput{Name: "デイトナ"})
+	create(t, repo, entity.NewItemInput{Name: "バーキン", Category: "バッグ"})
+	deleted := create(t, repo, entity.NewItemInput{Name: "サブマリーナ"})
+	_, err := repo.Delete(ctx, deleted.ID)
+	require.NoError(t, err)
+
+	// 登録時の更新日時から後のアイテムを更新日時の新しい順に返し、論理削除したアイテムは含めない
+	items, err := repo.FindUpdatedSince(ctx, daytona.UpdatedAt, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.ElementsMatch(t, []string{"デイトナ", "バーキン"}, []string{items[0].Name, items[1].Name})
+	assert.False(t, items[0].UpdatedAt.Before(items[1].UpdatedAt))
+	if items[0].UpdatedAt.Equal(items[1].UpdatedAt) {
+		assert.Greater(t, items[0].ID, items[1].ID)
+	}
+
+	items, err = repo.FindUpdatedSince(ctx, daytona.UpdatedAt, 1)
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	items, err = repo.FindUpdatedSince(ctx, daytona.UpdatedAt.Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+	assert.NotNil(t, items)
+
+	// ほかのユーザーのアイテムは対象としない
+	items, err = repo.FindUpdatedSince(principal.NewContext(ctx, principal.Principal{UserID: 3}), daytona.UpdatedAt, 10)
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+// 物理削除後も履歴は残り、新しい順に並ぶ
+func testCreateHistory(t *testing.T, repo usecase.ItemRepository) {
+	item := create(t, repo, entity.NewItemInput{Name: "デイトナ"})
+	updated := *item
+	updated.Name = "デイトナ 116500LN"
+
+	require.NoError(t, repo.CreateHistory(ctx, item.ID, entity.HistoryActionUpdate, item, &updated))
+	_, err := repo.HardDelete(ctx, item.ID)
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateHistory(ctx, item.ID, entity.HistoryActionHardDelete, &updated, nil))
+
+	count, err := repo.CountHistories(ctx, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	histories, err := repo.FindHistories(ctx, item.ID, entity.Pagination{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, histories, 2)
+	assert.Equal(t, entity.HistoryActionHardDelete, histories[0].Action)
+	assert.Nil(t, histories[0].After)
+	assert.Equal(t, entity.HistoryActionUpdate, histories[1].Action)
+
+	var before, after entity.Item
+	require.NoError(t, json.Unmarshal(histories[1].Before, &before))
+	require.NoError(t, json.Unmarshal(histories[1].After, &after))
+	assert.Equal(t, "デイトナ", before.Name)
+	assert.Equal(t, "デイトナ 116500LN", after.Name)
+}
+
+func testImages(t *testing.T, repo usecase.ItemRepository) {
+	item := create(t, repo, entity.NewItemInput{Name: "デイトナ"})
+
+	first, err := repo.AddImage(ctx, item.ID, "/images/1.jpg", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.DisplayOrder)
+	second, err := repo.AddImage(ctx, item.ID, "/images/2.jpg", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.DisplayOrder)
+
+	_, err = repo.AddImage(ctx, item.ID, "/images/3.jpg", 2)
+	assert.ErrorIs(t, err, domainErrors.ErrImageLimitExceeded)
+	_, err = repo.AddImage(ctx, item.ID+1000, "/images/3.jpg", 2)
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+
+	assert.ErrorIs(t, repo.ReorderImages(ctx, item.ID, []int64{second.ID}), domainErrors.ErrInvalidInput)
+	require.NoError(t, repo.ReorderImages(ctx, item.ID, []int64{second.ID, first.ID}))
+
+	images, err := repo.FindImages(ctx, item.ID)
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+	assert.Equal(t, []string{"/images/2.jpg", "/images/1.jpg"}, []string{images[0].URL, images[1].URL})
+
+	// 複数のアイテムの画像をまとめて取得する。画像のないアイテムは含まれない
+	other, err := repo.Create(ctx, newItem(t, entity.NewItemInput{Name: "サブマリーナ"}))
+	require.NoError(t, err)
+	byItem, err := repo.FindImagesByItemIDs(ctx, []int64{item.ID, other.ID})
+	require.NoError(t, err)
+	assert.Len(t, byItem, 1)
+	require.Len(t, byItem[item.ID], 2)
+	assert.Equal(t, "/images/2.jpg", byItem[item.ID][0].URL)
+
+	require.NoError(t, repo.DeleteImage(ctx, item.ID, first.ID))
+	assert.ErrorIs(t, repo.DeleteImage(ctx, item.ID, first.ID), domainErrors.ErrImageNotFound)
+}
+
+func testIdempotencyKeys(t *testing.T, repo usecase.ItemRepository) {
+	key := &entity.IdempotencyKey{Key: "key-1", RequestHash: "hash"}
+	expiredBefore := time.Now().Add(-time.Hour)
+
+	item, err := repo.CreateWithIdempotencyKey(ctx, newItem(t, entity.NewItemInput{Name: "デイトナ"}), key, expiredBefore)
+	require.NoError(t, err)
+
+	_, err = repo.CreateWithIdempotencyKey(ctx, newItem(t, entity.NewItemInput{Name: "デイトナ"}), key, expiredBefore)
+	assert.ErrorIs(t, err, domainErrors.ErrIdempotencyKeyExists)
+
+	found, err := repo.FindIdempotencyKey(ctx, "key-1", expiredBefore)
+	require.NoError(t, err)
+	assert.Equal(t, item.ID, found.ItemID)
+	assert.Equal(t, "hash", found.RequestHash)
+
+	_, err = repo.FindIdempotencyKey(ctx, "key-2", expiredBefore)
+	assert.ErrorIs(t, err, domainErrors.ErrIdempotencyKeyNotFound)
+
+	// キーはユーザーごとに一意で、ほかのユーザーのキーは見つからず、同じキーを別に使える
+	alice := principal.NewContext(ctx, principal.Principal{UserID: 2})
+	bob := principal.NewContext(ctx, principal.Principal{UserID: 3})
+	_, err = repo.FindIdempotencyKey(alice, "key-1", expiredBefore)
+	assert.ErrorIs(t, err, domainErrors.ErrIdempotencyKeyNotFound)
+	aliceItem, err := repo.CreateWithIdempotencyKey(alice, newItem(t, entity.NewItemInput{Name: "サブマリーナ"}), key, expiredBefore)
+	require.NoError(t, err)
+	_, err = repo.FindIdempotencyKey(bob, "key-1", expiredBefore)
+	assert.ErrorIs(t, err, domainErrors.ErrIdempotencyKeyNotFound)
+	_, err = repo.CreateWithIdempotencyKey(bob, newItem(t, entity.NewItemInput{Name: "サブマリーナ"}), key, expiredBefore)
+	require.NoError(t, err)
+	_, err = repo.CreateWithIdempotencyKey(alice, newItem(t, entity.NewItemInput{Name: "サブマリーナ"}), key, expiredBefore)
+	assert.ErrorIs(t, err, domainErrors.ErrIdempotencyKeyExists)
+	found, err = repo.FindIdempotencyKey(alice, "key-1", expiredBefore)
+	require.NoError(t, err)
+	assert.Equal(t, aliceItem.ID, found.ItemID)
+
+	deleted, err := repo.DeleteExpiredIdempotencyKeys(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	_, err = repo.FindIdempotencyKey(ctx, "key-1", expiredBefore)
+	assert.ErrorIs(t, err, domainErrors.ErrIdempotencyKeyNotFound)
+}
+
+func testSummaries(t *testing.T, repo usecase.ItemRepository) {
+	daytona := create(t, repo, entity.NewItemInput{Name: "デイトナ", Brand: "ROLEX", PurchasePrice: 1500000, Condition: "新品", PurchaseLocation: "銀座店", PurchaseDate: entity.MustParsePurchaseDate("2023-01-15")})
+	submariner := create(t, repo, entity.NewItemInput{Name: "サブマリーナ", Brand: "Rolex", PurchasePrice: 1500000, PurchaseDate: entity.MustParsePurchaseDate("2023-02-10")})
+	create(t, repo, entity.NewItemInput{Name: "バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 10000, Currency: "USD", PurchaseLocation: "銀座店", PurchaseDate: entity.MustParsePurchaseDate("2023-02-20")})
+
+	// 売却済みのアイテム
+	sold := *submariner
+	sold.Status = entity.ItemStatusListed
+	require.NoError(t, sold.MarkSold(1800000, entity.MustParsePurchaseDate("2024-03-01")))
+	_, err := repo.Update(ctx, &sold)
+	require.NoError(t, err)
+
+	t.Run("カテゴリーごとの集計はアイテムのないカテゴリーも含む", func(t *testing.T) {
+		totals, err := repo.GetSummaryByCategory(ctx)
+		require.NoError(t, err)
+		counts := make(map[string]int)
+		for _, total := range totals {
+			counts[total.Category] += total.Count
+		}
+		assert.Equal(t, map[string]int{"時計": 2, "バッグ": 1, "ジュエリー": 0, "靴": 0, "その他": 0}, counts)
+	})
+
+	t.Run("売却済みのアイテムの利益", func(t *testing.T) {
+		totals, err := repo.GetProfitByCurrency(ctx, 0)
+		require.NoError(t, err)
+		require.Len(t, totals, 1)
+		assert.Equal(t, entity.ProfitCurrencyTotal{Currency: "JPY", Count: 1, SellingPrice: 1800000, PurchasePrice: 1500000}, *totals[0])
+
+		totals, err = repo.GetProfitByCurrency(ctx, 2023)
+		require.NoError(t, err)
+		assert.Empty(t, totals)
+	})
+
+	t.Run("購入店舗ごとの支出は店舗の未設定を先頭にする", func(t *testing.T) {
+		totals, err := repo.GetSpendByLocation(ctx)
+		require.NoError(t, err)
+		require.Len(t, totals, 3)
+		assert.Nil(t, totals[0].PurchaseLocation)
+		assert.Equal(t, "銀座店", *totals[1].PurchaseLocation)
+		assert.Equal(t, "JPY", totals[1].Currency)
+		assert.Equal(t, "USD", totals[2].Currency)
+	})
+
+	t.Run("ブランドごとの集計は大文字小文字をまとめ、売却済みを含めない", func(t *testing.T) {
+		totals, err := repo.GetSummaryByBrand(ctx)
+		require.NoError(t, err)
+		require.Len(t, totals, 2)
+		assert.Equal(t, entity.BrandCurrencyTotal{BrandKey: "hermès", Brand: "HERMÈS", Currency: "USD", Count: 1, TotalPrice: 10000}, *totals[0])
+		assert.Equal(t, entity.BrandCurrencyTotal{BrandKey: "rolex", Brand: "ROLEX", Currency: "JPY", Count: 1, TotalPrice: 1500000}, *totals[1])
+
+		items, err := repo.FindMostExpensiveByBrand(ctx)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, daytona.ID, items[1].ID)
+		assert.Equal(t, "rolex", items[1].BrandKey)
+	})
+
+	t.Run("購入月ごとの支出", func(t *testing.T) {
+		r, err := entity.ParseSpendRange(entity.SpendGranularityMonth, "2023-02", "2023-03")
+		require.NoError(t, err)
+		totals, err := repo.GetSpendByPeriod(ctx, r)
+		require.NoError(t, err)
+		require.Len(t, totals, 2)
+		assert.Equal(t, entity.PeriodCurrencyTotal{Period: "2023-02", Currency: "JPY", Count: 1, TotalPrice: 1500000}, *totals[0])
+		assert.Equal(t, entity.PeriodCurrencyTotal{Period: "2023-02", Currency: "USD", Count: 1, TotalPrice: 10000}, *totals[1])
+	})
+
+	t.Run("絞り込み条件での統計と最高額のアイテム", func(t *testing.T) {
+		filter := entity.ItemFilter{Category: "時計"}
+		stats, err := repo.GetStatsByCategory(ctx, filter)
+		require.NoError(t, err)
+		require.Len(t, stats, 1)
+		assert.Equal(t, 2, stats[0].Count)
+		assert.Equal(t, int64(3000000), stats[0].TotalPrice)
+		assert.Equal(t, "2023-02-10", stats[0].LatestPurchaseDate.String())
+
+		// 同額の場合はIDの小さいアイテム
+		items, err := repo.FindMostExpensiveByCurrency(ctx, filter)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, daytona.ID, items[0].ID)
+	})
+}
+
+// ユーザーとして認証したctxでは自分のアイテムだけを扱い、ほかのユーザーのアイテムは存在しないものとして扱う
+func testOwnerScope(t *testing.T, repo usecase.ItemRepository) {
+	alice := principal.NewContext(ctx, principal.Principal{UserID: 2})
+	bob := principal.NewContext(ctx, principal.Principal{UserID: 3})
+
+	item, err := repo.Create(alice, newItem(t, entity.NewItemInput{Name: "デイトナ", SerialNumber: "SN-1"}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), item.UserID)
+
+	_, err = repo.FindByID(bob, item.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	_, err = repo.FindBySerialNumber(bob, "SN-1")
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	found, err := repo.FindByID(alice, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), found.UserID)
+
+	// シリアル番号はユーザーごとに一意
+	_, err = repo.Create(alice, newItem(t, entity.NewItemInput{Name: "サブマリーナ", SerialNumber: "SN-1"}))
+	assert.ErrorIs(t, err, domainErrors.ErrDuplicateSerialNumber)
+	other, err := repo.Create(bob, newItem(t, entity.NewItemInput{Name: "サブマリーナ", SerialNumber: "SN-1"}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), other.UserID)
+
+	items, err := repo.FindAll(bob, entity.ItemFilter{}, entity.ItemSort{Field: entity.SortByPurchasePrice, Order: entity.SortOrderAsc}, entity.Pagination{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{other.ID}, ids(items))
+	count, err := repo.Count(alice, entity.ItemFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// ほかのユーザーのアイテムは更新・削除できない
+	update := *item
+	update.Name = "デイトナ 116500LN"
+	_, err = repo.Update(bob, &update)
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	_, err = repo.Delete(bob, item.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	_, err = repo.FindByID(alice, item.ID)
+	require.NoError(t, err)
+
+	// ユーザーとして認証していない場合はすべてのユーザーのアイテムを扱う
+	count, err = repo.Count(ctx, entity.ItemFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// 管理者はすべてのユーザーのアイテムを扱い、所有者で絞り込める
+	admin := principal.NewContext(ctx, principal.Principal{UserID: entity.DefaultUserID, Role: entity.UserRoleAdmin})
+	count, err = repo.Count(admin, entity.ItemFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	items, err = repo.FindAll(admin, entity.ItemFilter{OwnerID: int64Ptr(3)}, entity.ItemSort{Field: entity.SortByPurchasePrice, Order: entity.SortOrderAsc}, entity.Pagination{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{other.ID}, ids(items))
+	_, err = repo.FindByID(admin, item.ID)
+	require.NoError(t, err)
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 利益レポートで指定できる年の範囲
+const (
+	MinProfitReportYear = 1900
+	MaxProfitReportYear = 9999
+)
+
+// 売却の入力。Versionを指定した場合は、そのバージョンのときのみ売却済みにする
+type MarkItemSoldInput struct {
+	SellingPrice *int64 `json:"selling_price"`
+	SoldDate     string `json:"sold_date"` // YYYY-MM-DD 形式
+	Version      *int64 `json:"version,omitempty"`
+}
+
+// 実現した利益の集計。金額はCurrency（基準通貨）に換算した値
+type ProfitReport struct {
+	Currency           string `json:"currency"`
+	Year               *int   `json:"year,omitempty"` // 絞り込んだ売却年。未指定の場合は全期間
+	Count              int    `json:"count"`
+	TotalSellingPrice  int64  `json:"total_selling_price"`
+	TotalPurchasePrice int64  `json:"total_purchase_price"`
+	TotalProfit        int64  `json:"total_profit"`
+}
+
+// 出品中のアイテムを売却済みにし、売却価格と売却日を記録する
+func (u *itemUsecase) MarkItemSold(ctx context.Context, id int64, input MarkItemSoldInput) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to find item: %w", err)
+	}
+
+	if input.Version != nil {
+		if err := item.CheckVersion(*input.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	// 売却できないアイテムは、入力の内容にかかわらず競合として返す
+	if !entity.CanTransitionItemStatus(item.Status, entity.ItemStatusSold) {
+		return nil, domainErrors.NewStatusTransitionError(item.Status, entity.ItemStatusSold)
+	}
+
+	if input.SellingPrice == nil {
+		return nil, domainErrors.NewValidationErrors(domainErrors.Required("selling_price"))
+	}
+
+	var soldDate entity.PurchaseDate
+	if input.SoldDate != "" {
+		soldDate, err = entity.ParseSoldDate(input.SoldDate)
+		if err != nil {
+			return nil, domainErrors.FieldErrorFrom("sold_date", err)
+		}
+	}
+
+	before := item.Clone()
+	if err := item.MarkSold(*input.SellingPrice, soldDate); err != nil {
+		return nil, err
+	}
+
+	var updatedItem *entity.Item
+	err = u.withinTx(ctx, func(ctx context.Context) error {
+		updated, err := u.itemRepo.Update(ctx, item)
+		if err != nil {
+			return fmt.Errorf("failed to mark item as sold: %w", err)
+		}
+		updatedItem = updated
+		return u.publish(ctx, entity.EventItemUpdated, id, entity.ItemChange{Before: before, After: updated})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedItem, nil
+}
+
+// 売却済みのアイテムの利益を基準通貨に換算して集計する。yearが0の場合は全期間を集計する
+func (u *itemUsecase) GetProfitReport(ctx context.Context, year int) (*ProfitReport, error) {
+	if year != 0 && (year < MinProfitReportYear || year > MaxProfitReportYear) {
+		return nil, fmt.Errorf("%w: year must be between %d and %d", domainErrors.ErrInvalidInput, MinProfitReportYear, MaxProfitReportYear)
+	}
+
+	totals, err := u.itemRepo.GetProfitByCurrency(ctx, year)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profit: %w", err)
+	}
+
+	report := &ProfitReport{Currency: u.exchangeRates.BaseCurrency()}
+	if year != 0 {
+		report.Year = &year
+	}
+
+	selling, purchase := entity.ZeroMoney(report.Currency), entity.ZeroMoney(report.Currency)
+	for _, total := range totals {
+		totalSelling, err := u.toBaseCurrency(ctx, total.SellingTotal())
+		if err != nil {
+			return nil, err
+		}
+		totalPurchase, err := u.toBaseCurrency(ctx, total.PurchaseTotal())
+		if err != nil {
+			return nil, err
+		}
+
+		report.Count += total.Count
+		if selling, err = selling.Add(totalSelling); err != nil {
+			return nil, fmt.Errorf("failed to total selling prices: %w", err)
+		}
+		if purchase, err = purchase.Add(totalPurchase); err != nil {
+			return nil, fmt.Errorf("failed to total purchase prices: %w", err)
+		}
+	}
+
+	profit, err := selling.Sub(purchase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate profit: %w", err)
+	}
+	report.TotalSellingPrice = selling.Amount()
+	report.TotalPurchasePrice = purchase.Amount()
+	report.TotalProfit = profit.Amount()
+
+	return report, nil
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestItemUsecase_MarkItemSold(t *testing.T) {
+	newItem := func(status string) *entity.Item {
+		item, _ := entity.NewItem(entity.NewItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
+		item.ID = 1
+		item.Status = status
+		return item
+	}
+	soldItem := newItem(entity.ItemStatusListed)
+	_ = soldItem.MarkSold(1200000, entity.MustParsePurchaseDate("2023-06-01"))
+
+	tests := []struct {
+		name        string
+		id          int64
+		input       MarkItemSoldInput
+		setupMock   func(*MockItemRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 出品中のアイテムを売却",
+			id:    1,
+			input: MarkItemSoldInput{SellingPrice: int64Ptr(1200000), SoldDate: "2023-06-01"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusListed), nil)
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.Status == entity.ItemStatusSold &&
+						*item.SellingPrice == 1200000 &&
+						item.SoldDate.String() == "2023-06-01"
+				})).Return(soldItem, nil)
+			},
+		},
+		{
+			name:  "異常系: 所有中のアイテムは売却できない",
+			id:    1,
+			input: MarkItemSoldInput{SellingPrice: int64Ptr(1200000), SoldDate: "2023-06-01"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusOwned), nil)
+			},
+			expectedErr: domainErrors.ErrInvalidStatusTransition,
+		},
+		{
+			name:  "異常系: 売却済みのアイテムは入力が不正でも競合",
+			id:    1,
+			input: MarkItemSoldInput{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusSold), nil)
+			},
+			expectedErr: domainErrors.ErrInvalidStatusTransition,
+		},
+		{
+			name:  "異常系: 売却価格が未指定",
+			id:    1,
+			input: MarkItemSoldInput{SoldDate: "2023-06-01"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusListed), nil)
+			},
+			expectedErr: domainErrors.ErrValidation,
+		},
+		{
+			name:  "異常系: 売却日の形式が不正",
+			id:    1,
+			input: MarkItemSoldInput{SellingPrice: int64Ptr(1200000), SoldDate: "2023.06.01"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusListed), nil)
+			},
+			expectedErr: domainErrors.ErrValidation,
+		},
+		{
+			name:  "異常系: 売却日が購入日より前",
+			id:    1,
+			input: MarkItemSoldInput{SellingPrice: int64Ptr(1200000), SoldDate: "2022-12-31"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusListed), nil)
+			},
+			expectedErr: domainErrors.ErrValidation,
+		},
+		{
+			name:  "異常系: バージョンが古い",
+			id:    1,
+			input: MarkItemSoldInput{SellingPrice: int64Ptr(1200000), SoldDate: "2023-06-01", Version: int64Ptr(2)},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusListed), nil)
+			},
+			expectedErr: domainErrors.ErrVersionConflict,
+		},
+		{
+			name:  "異常系: アイテムが存在しない",
+			id:    999,
+			input: MarkItemSoldInput{SellingPrice: int64Ptr(1200000), SoldDate: "2023-06-01"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil)
+
+			item, err := usecase.MarkItemSold(context.Background(), tt.id, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, item)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64Ptr(200000), item.Profit())
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_GetProfitReport(t *testing.T) {
+	year := 2023
+
+	tests := []struct {
+		name           string
+		year           int
+		setupMock      func(*MockItemRepository)
+		expectedReport *ProfitReport
+		expectedErr    error
+	}{
+		{
+			name: "正常系: 通貨ごとの合計を基準通貨に換算して集計",
+			year: 2023,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetProfitByCurrency", mock.Anything, 2023).Return([]*entity.ProfitCurrencyTotal{
+					{Currency: "EUR", Count: 1, SellingPrice: 1000, PurchasePrice: 1500},
+					{Currency: "JPY", Count: 2, SellingPrice: 3000000, PurchasePrice: 2000000},
+				}, nil)
+			},
+			expectedReport: &ProfitReport{
+				Currency:           "JPY",
+				Year:               &year,
+				Count:              3,
+				TotalSellingPrice:  3160000,
+				TotalPurchasePrice: 2240000,
+				TotalProfit:        920000,
+			},
+		},
+		{
+			name: "正常系: 売却済みのアイテムがない",
+			year: 0,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetProfitByCurrency", mock.Anything, 0).Return([]*entity.ProfitCurrencyTotal{}, nil)
+			},
+			expectedReport: &ProfitReport{Currency: "JPY"},
+		},
+		{
+			name: "異常系: 範囲外の年",
+			year: 10000,
+			setupMock: func(mockRepo *MockItemRepository) {
+				// GetProfitByCurrencyは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: データベースエラー",
+			year: 2023,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetProfitByCurrency", mock.Anything, 2023).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil)
+
+			report, err := usecase.GetProfitReport(context.Background(), tt.year)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, report)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedReport, report)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -3,56 +3,321 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/principal"
 )
 
 type ItemUsecase interface {
-	GetAllItems(ctx context.Context) ([]*entity.Item, error)
+	GetAllItems(ctx context.Context, input ListItemsInput) (*ItemList, error)
+	GetItemsLastModified(ctx context.Context, filter entity.ItemFilter) (*time.Time, error)
 	GetItemByID(ctx context.Context, id int64) (*entity.Item, error)
+	GetItemBySerialNumber(ctx context.Context, serialNumber string) (*entity.Item, error)
+	GetItemsByIDs(ctx context.Context, ids []int64) (*ItemBatch, error)
+	GetRecentItems(ctx context.Context, window time.Duration, limit int) (*RecentItemList, error)
 	CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error)
+	CreateItemWithIdempotencyKey(ctx context.Context, key string, input CreateItemInput) (*entity.Item, bool, error)
+	CloneItem(ctx context.Context, id int64, input CloneItemInput) (*entity.Item, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+	BulkCreateItems(ctx context.Context, inputs []CreateItemInput) ([]*entity.Item, error)
 	UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error)
-	DeleteItem(ctx context.Context, id int64) error
+	ReplaceItem(ctx context.Context, id int64, input ReplaceItemInput) (*entity.Item, error)
+	ChangeItemStatus(ctx context.Context, id int64, input ChangeItemStatusInput) (*entity.Item, error)
+	MarkItemSold(ctx context.Context, id int64, input MarkItemSoldInput) (*entity.Item, error)
+	DeleteItem(ctx context.Context, id int64, expectedVersion *int64) error
+	BulkDeleteItems(ctx context.Context, ids []int64, bestEffort bool) ([]BulkDeleteResult, error)
+	RestoreItem(ctx context.Context, id int64) (*entity.Item, error)
+	HardDeleteItem(ctx context.Context, id int64) error
 	GetCategorySummary(ctx context.Context) (*CategorySummary, error)
+	GetBrandSummary(ctx context.Context, limit int) (*BrandSummary, error)
+	GetProfitReport(ctx context.Context, year int) (*ProfitReport, error)
+	GetLocationReport(ctx context.Context) (*LocationReport, error)
+	GetItemStats(ctx context.Context, filter entity.ItemFilter) (*ItemStats, error)
+	GetSpendReport(ctx context.Context, spendRange entity.SpendRange) (*SpendReport, error)
+	ExportItems(ctx context.Context, filter entity.ItemFilter, fn func(*entity.Item) error) error
+	ImportItems(ctx context.Context, r io.Reader, opts ImportOptions) (*ImportResult, error)
+	GetItemHistory(ctx context.Context, id int64, limit, offset int) (*ItemHistoryList, error)
+}
+
+// ページネーションのデフォルト値と上限
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
+// キーワード検索の最大文字数
+const MaxKeywordLength = 100
+
+// 一覧取得の入力。Limitが0の場合はデフォルト値を使用する
+type ListItemsInput struct {
+	Filter entity.ItemFilter
+	Sort   entity.ItemSort
+	Limit  int
+	Offset int
+	Cursor string // 前のページのNextCursor。指定した場合はOffsetの代わりにその続きから取得する
+}
+
+type ItemList struct {
+	Items  []*entity.Item `json:"items"`
+	Total  int            `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+	// 続きのページを取得するカーソル。ページがlimit件に満たない場合は空
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type CreateItemInput struct {
-	Name          string `json:"name"`
-	Category      string `json:"category"`
-	Brand         string `json:"brand"`
-	PurchasePrice int    `json:"purchase_price"`
-	PurchaseDate  string `json:"purchase_date"`
+	Name             string             `json:"name"`
+	Category         string             `json:"category"`
+	Brand            string             `json:"brand"`
+	PurchasePrice    PurchasePriceInput `json:"purchase_price"`
+	Currency         string             `json:"currency"` // 未指定の場合はJPY
+	PurchaseDate     string             `json:"purchase_date"`
+	SerialNumber     string             `json:"serial_number"`     // 任意。空の場合は未設定
+	Condition        string             `json:"condition"`         // 任意。空の場合は未設定
+	Notes            string             `json:"notes"`             // 任意
+	PurchaseLocation string             `json:"purchase_location"` // 任意。空の場合は未設定
+	Tags             []string           `json:"tags"`              // 任意。重複は取り除く
+
+	// trueの場合は、名前・ブランド・購入日が同じアイテムがあっても登録する
+	Force bool `json:"-"`
 }
 
+// nilのフィールドは変更しない。空文字は省略とは区別してバリデーションする
 type UpdateItemInput struct {
-	Name          *string `json:"name,omitempty"`
-	Brand         *string `json:"brand,omitempty"`
-	PurchasePrice *int    `json:"purchase_price,omitempty"`
+	Name             *string             `json:"name,omitempty"`
+	Category         *string             `json:"category,omitempty"`
+	Brand            *string             `json:"brand,omitempty"`
+	PurchasePrice    *PurchasePriceInput `json:"purchase_price,omitempty"`
+	Currency         *string             `json:"currency,omitempty"`
+	PurchaseDate     *string             `json:"purchase_date,omitempty"`     // YYYY-MM-DD 形式
+	SerialNumber     *string             `json:"serial_number,omitempty"`     // 空文字の場合はシリアル番号を削除する
+	Condition        *string             `json:"condition,omitempty"`         // 空文字の場合は状態を未設定に戻す
+	Notes            *string             `json:"notes,omitempty"`             // 空文字の場合はメモを削除する
+	PurchaseLocation *string             `json:"purchase_location,omitempty"` // 空文字の場合は購入店舗を未設定に戻す
+	Tags             *[]string           `json:"tags,omitempty"`              // 指定したタグで置き換える。空の配列の場合はすべてのタグを外す
+	Version          *int64              `json:"version,omitempty"`           // 取得時のバージョン。必須
+}
+
+// 全置換の入力。登録と同じフィールドをすべて置き換え、省略した任意のフィールドは未設定に戻す
+type ReplaceItemInput struct {
+	Name             string             `json:"name"`
+	Category         string             `json:"category"`
+	Brand            string             `json:"brand"`
+	PurchasePrice    PurchasePriceInput `json:"purchase_price"`
+	Currency         string             `json:"currency"` // 未指定の場合はJPY
+	PurchaseDate     string             `json:"purchase_date"`
+	SerialNumber     string             `json:"serial_number"`
+	Condition        string             `json:"condition"`
+	Notes            string             `json:"notes"`
+	PurchaseLocation string             `json:"purchase_location"`
+	Tags             []string           `json:"tags"`
+	Version          *int64             `json:"version,omitempty"` // 取得時のバージョン。必須
+}
+
+// 更新対象のフィールドが1つも指定されていないかどうか。versionは更新対象に含めない
+func (in UpdateItemInput) IsEmpty() bool {
+	return in.Name == nil && in.Category == nil && in.Brand == nil &&
+		in.PurchasePrice == nil && in.Currency == nil && in.PurchaseDate == nil &&
+		in.SerialNumber == nil && in.Condition == nil && in.Notes == nil &&
+		in.PurchaseLocation == nil && in.Tags == nil
 }
 
+// 金額はCurrency（基準通貨）に換算した値。CategoriesとTotal、TotalPrice、AveragePriceは
+// 現在のコレクション（売却済みを除く）の集計で、売却済みのアイテムはSoldに分けて集計する
 type CategorySummary struct {
-	Categories map[string]int `json:"categories"`
-	Total      int            `json:"total"`
+	Categories   []*entity.CategoryStats `json:"categories"`
+	Currency     string                  `json:"currency"`
+	Total        int                     `json:"total"`
+	TotalPrice   int64                   `json:"total_price"`
+	AveragePrice float64                 `json:"average_price"`
+	Sold         entity.SoldStats        `json:"sold"`
 }
 
 type itemUsecase struct {
-	itemRepo ItemRepository
+	itemRepo      ItemRepository
+	categoryRepo  CategoryRepository   // アイテムのカテゴリーの検証に使う
+	imageStorage  ImageStorage         // 物理削除時に画像ファイルを削除するために使う
+	exchangeRates ExchangeRateProvider // 集計時に購入価格を基準通貨に換算するために使う
+	transactor    Transactor           // 複数の書き込みや読み込みをまとめて実行するために使う
+	publisher     EventPublisher       // 保存したアイテムの変更を履歴やWebhookなどに伝えるために使う
 }
 
-func NewItemUsecase(itemRepo ItemRepository) ItemUsecase {
+// transactorがnilの場合はトランザクションを使わずに実行し、publisherがnilの場合は変更を発行しない
+func NewItemUsecase(itemRepo ItemRepository, categoryRepo CategoryRepository, imageStorage ImageStorage, exchangeRates ExchangeRateProvider, transactor Transactor, publisher EventPublisher) ItemUsecase {
+	if transactor == nil {
+		transactor = noTransactor{}
+	}
+	if publisher == nil {
+		publisher = noPublisher{}
+	}
 	return &itemUsecase{
-		itemRepo: itemRepo,
+		itemRepo:      itemRepo,
+		categoryRepo:  categoryRepo,
+		imageStorage:  imageStorage,
+		exchangeRates: exchangeRates,
+		transactor:    transactor,
+		publisher:     publisher,
+	}
+}
+
+// 登録済みのカテゴリーを取得する
+func (u *itemUsecase) categories(ctx context.Context) (*entity.CategorySet, error) {
+	categories, err := u.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve categories: %w", err)
 	}
+
+	return entity.NewCategorySetFrom(categories), nil
 }
 
-func (u *itemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
-	items, err := u.itemRepo.FindAll(ctx)
+func (u *itemUsecase) GetAllItems(ctx context.Context, input ListItemsInput) (*ItemList, error) {
+	page, err := normalizePagination(input.Limit, input.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := u.categories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := normalizeFilter(ctx, &input.Filter, categories); err != nil {
+		return nil, err
+	}
+
+	if err := input.Sort.Validate(); err != nil {
+		return nil, domainErrors.NewInvalidQueryError(err.Error())
+	}
+	sort := input.Sort.WithDefaults()
+
+	if input.Cursor != "" {
+		if page.Offset > 0 {
+			return nil, fmt.Errorf("%w: cursor and offset cannot be used together", domainErrors.ErrInvalidCursor)
+		}
+		if page.After, err = decodeItemCursor(input.Cursor, sort); err != nil {
+			return nil, err
+		}
+	}
+
+	items, err := u.itemRepo.FindAll(ctx, input.Filter, sort, page)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve items: %w", err)
 	}
 
-	return items, nil
+	total, err := u.itemRepo.Count(ctx, input.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count items: %w", err)
+	}
+
+	list := &ItemList{
+		Items:  items,
+		Total:  total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}
+	// limit件ちょうどの場合は続きがあるものとしてカーソルを返す
+	if len(items) == page.Limit {
+		if list.NextCursor, err = encodeItemCursor(sort, items[len(items)-1]); err != nil {
+			return nil, err
+		}
+	}
+
+	return list, nil
+}
+
+// 絞り込み条件に一致するアイテムの最新の更新日時。一覧のLast-Modifiedに使い、アイテムがない場合はnilを返す。
+// 論理削除したアイテムも含めるため、削除でも更新日時が進む。物理削除と、更新で絞り込み条件から外れたアイテムは反映されない
+func (u *itemUsecase) GetItemsLastModified(ctx context.Context, filter entity.ItemFilter) (*time.Time, error) {
+	categories, err := u.categories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := normalizeFilter(ctx, &filter, categories); err != nil {
+		return nil, err
+	}
+
+	latest, err := u.itemRepo.MaxUpdatedAt(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last modified time: %w", err)
+	}
+	return latest, nil
+}
+
+// limitが0の場合はデフォルト値を使い、上限を超える場合は上限に丸める
+func normalizePagination(limit, offset int) (entity.Pagination, error) {
+	if limit < 0 || offset < 0 {
+		return entity.Pagination{}, domainErrors.NewInvalidQueryError("limit and offset must be 0 or greater")
+	}
+
+	if limit == 0 {
+		limit = DefaultListLimit
+	}
+	if limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+
+	return entity.Pagination{Limit: limit, Offset: offset}, nil
+}
+
+// 絞り込み条件に一致する全アイテムを順に fn へ渡す。
+// 全件をメモリに載せないよう、リポジトリから1件ずつ受け取る
+func (u *itemUsecase) ExportItems(ctx context.Context, filter entity.ItemFilter, fn func(*entity.Item) error) error {
+	categories, err := u.categories(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := normalizeFilter(ctx, &filter, categories); err != nil {
+		return err
+	}
+
+	// fnのエラーはそのまま返し、取得のエラーと区別する
+	var fnErr error
+	err = u.itemRepo.EachItem(ctx, filter, entity.ItemSort{}.WithDefaults(), func(item *entity.Item) error {
+		fnErr = fn(item)
+		return fnErr
+	})
+	if err != nil {
+		if fnErr != nil {
+			return fnErr
+		}
+		return fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	return nil
+}
+
+// 絞り込み条件の正規化とバリデーション。所有者での絞り込みはすべてのユーザーのアイテムを扱える呼び出し元
+// （管理者と、認証を無効にした場合）のみ指定でき、それ以外はErrForbiddenを返す
+func normalizeFilter(ctx context.Context, filter *entity.ItemFilter, categories entity.CategoryLookup) error {
+	if _, scoped := principal.OwnerScope(ctx); filter.OwnerID != nil && scoped {
+		return fmt.Errorf("%w: owner_id can only be specified by admins", domainErrors.ErrForbidden)
+	}
+
+	filter.Keyword = entity.NormalizeText(filter.Keyword)
+	if utf8.RuneCountInString(filter.Keyword) > MaxKeywordLength {
+		return domainErrors.NewInvalidQueryError(fmt.Sprintf("q must be %d characters or less", MaxKeywordLength))
+	}
+
+	if err := filter.Validate(categories); err != nil {
+		return domainErrors.NewInvalidQueryError(err.Error())
+	}
+
+	// スラッグで指定された場合は、アイテムに保存されている表示名で絞り込む
+	if filter.Category != "" && categories != nil {
+		if category, ok := categories.Resolve(filter.Category); ok {
+			filter.Category = category.Name
+		}
+	}
+
+	return nil
 }
 
 func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
@@ -68,30 +333,119 @@ func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item,
 		return nil, fmt.Errorf("failed to retrieve item: %w", err)
 	}
 
+	images, err := u.itemRepo.FindImages(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve item images: %w", err)
+	}
+	item.Images = images
+
+	return item, nil
+}
+
+// シリアル番号でアイテムを取得する。前後の空白は無視する
+func (u *itemUsecase) GetItemBySerialNumber(ctx context.Context, serialNumber string) (*entity.Item, error) {
+	serialNumber = strings.TrimSpace(serialNumber)
+	if serialNumber == "" {
+		return nil, domainErrors.NewValidationErrors(domainErrors.Required("serial_number"))
+	}
+
+	item, err := u.itemRepo.FindBySerialNumber(ctx, serialNumber)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	images, err := u.itemRepo.FindImages(ctx, item.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve item images: %w", err)
+	}
+	item.Images = images
+
 	return item, nil
 }
 
 func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
-	// バリデーションして、新しいエンティティを作成
-	item, err := entity.NewItem(
-		input.Name,
-		input.Category,
-		input.Brand,
-		input.PurchasePrice,
-		input.PurchaseDate,
-	)
+	categories, err := u.categories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// バリデーションして、新しいエンティティを作成。エラーはフィールド単位のまま返す
+	item, err := newItemFromInput(input, categories)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+		return nil, err
 	}
 
-	createdItem, err := u.itemRepo.Create(ctx, item)
+	if !input.Force {
+		if err := u.checkDuplicateItem(ctx, item); err != nil {
+			return nil, err
+		}
+	}
+
+	var createdItem *entity.Item
+	err = u.withinTx(ctx, func(ctx context.Context) error {
+		created, err := u.itemRepo.Create(ctx, item)
+		if err != nil {
+			return fmt.Errorf("failed to create item: %w", err)
+		}
+		createdItem = created
+		return u.publish(ctx, entity.EventItemCreated, created.ID, entity.ItemChange{After: created})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create item: %w", err)
+		return nil, err
 	}
 
 	return createdItem, nil
 }
 
+// 名前・ブランド・購入日が同じアイテムが登録済みの場合は、そのIDを含むDuplicateItemErrorを返す
+func (u *itemUsecase) checkDuplicateItem(ctx context.Context, item *entity.Item) error {
+	candidates, err := u.itemRepo.FindByPurchaseDate(ctx, item.PurchaseDate)
+	if err != nil {
+		return fmt.Errorf("failed to check duplicate items: %w", err)
+	}
+
+	for _, existing := range candidates {
+		if item.IsDuplicateOf(existing) {
+			return domainErrors.NewDuplicateItemError(existing.ID)
+		}
+	}
+	return nil
+}
+
+// 登録の入力からエンティティを作成する
+func newItemFromInput(input CreateItemInput, categories entity.CategoryLookup) (*entity.Item, error) {
+	purchaseDate, err := parseInputPurchaseDate(input.PurchaseDate)
+	if err != nil {
+		return nil, domainErrors.FieldErrorFrom("purchase_date", err)
+	}
+
+	return entity.NewItem(entity.NewItemInput{
+		Name:             input.Name,
+		Category:         input.Category,
+		Brand:            input.Brand,
+		PurchasePrice:    int64(input.PurchasePrice),
+		Currency:         input.Currency,
+		PurchaseDate:     purchaseDate,
+		SerialNumber:     input.SerialNumber,
+		Condition:        input.Condition,
+		Notes:            input.Notes,
+		PurchaseLocation: input.PurchaseLocation,
+		Tags:             input.Tags,
+		Categories:       categories,
+	})
+}
+
+// 入力の購入日を解析する。空の場合はゼロ値を返し、必須チェックはエンティティのバリデーションに任せる
+func parseInputPurchaseDate(s string) (entity.PurchaseDate, error) {
+	if strings.TrimSpace(s) == "" {
+		return entity.PurchaseDate{}, nil
+	}
+	return entity.ParsePurchaseDate(s)
+}
+
 func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error) {
 	// IDバリデーション
 	if id <= 0 {
@@ -99,78 +453,255 @@ func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItem
 	}
 
 	// 更新対象フィールドが1つも指定されていない場合はエラー
-	if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil {
+	if input.IsEmpty() {
 		return nil, fmt.Errorf("%w: at least one field must be specified for update", domainErrors.ErrInvalidInput)
 	}
 
-	// 既存アイテムの取得
-	existingItem, err := u.itemRepo.FindByID(ctx, id)
-	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return nil, domainErrors.ErrItemNotFound
+	// 楽観的ロックのため、取得時のバージョンを必須とする
+	if input.Version == nil {
+		return nil, domainErrors.NewValidationErrors(domainErrors.Required("version"))
+	}
+
+	// 読み込みから書き込みまでを1つのトランザクションで実行し、行をロックして同時の更新を直列にする
+	var updatedItem *entity.Item
+	err := u.withinTx(ctx, func(ctx context.Context) error {
+		// 既存アイテムの取得
+		existingItem, err := u.itemRepo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to find item: %w", err)
+		}
+
+		categories, err := u.categories(ctx)
+		if err != nil {
+			return err
+		}
+
+		// UpdatePartialで変更する前の内容を履歴などのために残す
+		before := existingItem.Clone()
+
+		// UpdatePartialメソッドを使用して部分更新
+		err = existingItem.UpdatePartial(*input.Version, input.Name, input.Category, input.Brand, input.PurchasePrice.Int64(), input.Currency, input.PurchaseDate, input.SerialNumber, input.Condition, input.Notes, input.PurchaseLocation, input.Tags, categories)
+		if err != nil {
+			return err
 		}
-		return nil, fmt.Errorf("failed to find item: %w", err)
+
+		// データベースに更新を保存
+		updatedItem, err = u.itemRepo.Update(ctx, existingItem)
+		if err != nil {
+			return fmt.Errorf("failed to update item: %w", err)
+		}
+		return u.publish(ctx, entity.EventItemUpdated, id, entity.ItemChange{Before: before, After: updatedItem})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedItem, nil
+}
+
+// 登録と同じフィールドをすべて置き換える。UpdateItemと同じく、取得時のバージョンが現在のバージョンと異なる場合は更新しない
+func (u *itemUsecase) ReplaceItem(ctx context.Context, id int64, input ReplaceItemInput) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	// 楽観的ロックのため、取得時のバージョンを必須とする
+	if input.Version == nil {
+		return nil, domainErrors.NewValidationErrors(domainErrors.Required("version"))
 	}
 
-	// UpdatePartialメソッドを使用して部分更新
-	err = existingItem.UpdatePartial(input.Name, input.Brand, input.PurchasePrice)
+	purchaseDate, err := parseInputPurchaseDate(input.PurchaseDate)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+		return nil, domainErrors.FieldErrorFrom("purchase_date", err)
 	}
 
-	// データベースに更新を保存
-	updatedItem, err := u.itemRepo.Update(ctx, existingItem)
+	var updatedItem *entity.Item
+	err = u.withinTx(ctx, func(ctx context.Context) error {
+		existingItem, err := u.itemRepo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to find item: %w", err)
+		}
+
+		categories, err := u.categories(ctx)
+		if err != nil {
+			return err
+		}
+
+		before := existingItem.Clone()
+
+		err = existingItem.Update(*input.Version, input.Name, input.Category, input.Brand, int64(input.PurchasePrice), input.Currency, purchaseDate, input.SerialNumber, input.Condition, input.Notes, input.PurchaseLocation, input.Tags, categories)
+		if err != nil {
+			return err
+		}
+
+		updatedItem, err = u.itemRepo.Update(ctx, existingItem)
+		if err != nil {
+			return fmt.Errorf("failed to update item: %w", err)
+		}
+		return u.publish(ctx, entity.EventItemUpdated, id, entity.ItemChange{Before: before, After: updatedItem})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update item: %w", err)
+		return nil, err
 	}
 
 	return updatedItem, nil
 }
 
-func (u *itemUsecase) DeleteItem(ctx context.Context, id int64) error {
+// expectedVersionを指定した場合は、そのバージョンのときのみ削除する。
+// 存在とバージョンの確認、削除と履歴の記録は1つのトランザクションで実行する
+func (u *itemUsecase) DeleteItem(ctx context.Context, id int64, expectedVersion *int64) error {
 	if id <= 0 {
 		return domainErrors.ErrInvalidInput
 	}
 
-	_, err := u.itemRepo.FindByID(ctx, id)
-	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return domainErrors.ErrItemNotFound
+	return u.withinTx(ctx, func(ctx context.Context) error {
+		item, err := u.itemRepo.FindByID(ctx, id)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to check item existence: %w", err)
+		}
+
+		if expectedVersion != nil {
+			if err := item.CheckVersion(*expectedVersion); err != nil {
+				return err
+			}
+		}
+
+		change, err := u.itemRepo.Delete(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete item: %w", err)
 		}
-		return fmt.Errorf("failed to check item existence: %w", err)
+
+		return u.publish(ctx, entity.EventItemDeleted, id, *change)
+	})
+}
+
+// 論理削除したアイテムを元に戻す
+func (u *itemUsecase) RestoreItem(ctx context.Context, id int64) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
 	}
 
-	err = u.itemRepo.Delete(ctx, id)
+	var restoredItem *entity.Item
+	err := u.withinTx(ctx, func(ctx context.Context) error {
+		change, err := u.itemRepo.Restore(ctx, id)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to restore item: %w", err)
+		}
+		restoredItem = change.After
+		return u.publish(ctx, entity.EventItemRestored, id, *change)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete item: %w", err)
+		return nil, err
+	}
+
+	return restoredItem, nil
+}
+
+// 物理削除（管理者用）。論理削除済みのアイテムも削除できる。
+// 画像のレコードは外部キーで連鎖削除されるため、ここではファイルのみを削除する。
+// ファイルは削除を取り消せないため、コミットした後に削除する
+func (u *itemUsecase) HardDeleteItem(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	images, err := u.itemRepo.FindImages(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve item images: %w", err)
+	}
+
+	err = u.withinTx(ctx, func(ctx context.Context) error {
+		change, err := u.itemRepo.HardDelete(ctx, id)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return domainErrors.ErrItemNotFound
+			}
+			return fmt.Errorf("failed to hard delete item: %w", err)
+		}
+		return u.publish(ctx, entity.EventItemHardDeleted, id, *change)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, image := range images {
+		removeImageFile(ctx, u.imageStorage, image.URL)
 	}
 
 	return nil
 }
 
 func (u *itemUsecase) GetCategorySummary(ctx context.Context) (*CategorySummary, error) {
-	categoryCounts, err := u.itemRepo.GetSummaryByCategory(ctx)
+	totals, err := u.itemRepo.GetSummaryByCategory(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get category summary: %w", err)
 	}
 
-	// 合計計算
-	total := 0
-	for _, count := range categoryCounts {
-		total += count
+	// 通貨ごとの合計を基準通貨に換算し、カテゴリー単位と状態単位にまとめる
+	summary := &CategorySummary{
+		Categories: make([]*entity.CategoryStats, 0),
+		Currency:   u.exchangeRates.BaseCurrency(),
 	}
+	statsByCategory := make(map[string]*entity.CategoryStats)
+	for _, total := range totals {
+		stats, exists := statsByCategory[total.Category]
+		if !exists {
+			stats = &entity.CategoryStats{Category: total.Category, Conditions: entity.NewConditionStatsList()}
+			statsByCategory[total.Category] = stats
+			summary.Categories = append(summary.Categories, stats)
+		}
+		if total.Count == 0 {
+			continue
+		}
+
+		price, err := u.toBaseCurrency(ctx, total.Total())
+		if err != nil {
+			return nil, err
+		}
+		converted := price.Amount()
+
+		if total.Status == entity.ItemStatusSold {
+			summary.Sold.Count += total.Count
+			summary.Sold.TotalPrice += converted
+			continue
+		}
 
-	summary := make(map[string]int)
-	for _, category := range entity.GetValidCategories() {
-		if count, exists := categoryCounts[category]; exists {
-			summary[category] = count
-		} else {
-			summary[category] = 0
+		stats.Count += total.Count
+		stats.TotalPrice += converted
+		for _, conditionStats := range stats.Conditions {
+			if conditionStats.Condition == total.Condition {
+				conditionStats.Count += total.Count
+				conditionStats.TotalPrice += converted
+			}
 		}
+		summary.Total += total.Count
+		summary.TotalPrice += converted
 	}
 
-	return &CategorySummary{
-		Categories: summary,
-		Total:      total,
-	}, nil
+	for _, stats := range summary.Categories {
+		stats.AveragePrice = averagePrice(stats.TotalPrice, stats.Count)
+	}
+	summary.AveragePrice = averagePrice(summary.TotalPrice, summary.Total)
+
+	return summary, nil
+}
+
+// 平均価格を小数第2位までに丸めて返す。件数が0の場合は0
+func averagePrice(total int64, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return math.Round(float64(total)/float64(count)*100) / 100
 }
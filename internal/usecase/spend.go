@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 期間ごとの購入件数と購入価格の合計
+type SpendPeriod struct {
+	Period     string `json:"period"`
+	Count      int    `json:"count"`
+	TotalPrice int64  `json:"total_price"`
+}
+
+// 購入日の期間ごとの支出の集計。金額はCurrency（基準通貨）に換算した値で、
+// 購入がない期間も0件の期間として含める
+type SpendReport struct {
+	Currency    string         `json:"currency"`
+	Granularity string         `json:"granularity"`
+	Periods     []*SpendPeriod `json:"periods"`
+	Total       int            `json:"total"`
+	TotalPrice  int64          `json:"total_price"`
+}
+
+// 集計期間内の購入を期間ごとに基準通貨に換算して集計する
+func (u *itemUsecase) GetSpendReport(ctx context.Context, spendRange entity.SpendRange) (*SpendReport, error) {
+	totals, err := u.itemRepo.GetSpendByPeriod(ctx, spendRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spend by period: %w", err)
+	}
+
+	report := &SpendReport{
+		Currency:    u.exchangeRates.BaseCurrency(),
+		Granularity: spendRange.Granularity,
+		Periods:     make([]*SpendPeriod, 0),
+	}
+
+	// 購入がない期間も含めるため、先にすべての期間を0で用意する
+	byPeriod := make(map[string]*SpendPeriod)
+	for _, period := range spendRange.Periods() {
+		spend := &SpendPeriod{Period: period}
+		byPeriod[period] = spend
+		report.Periods = append(report.Periods, spend)
+	}
+
+	for _, total := range totals {
+		spend, ok := byPeriod[total.Period]
+		if !ok {
+			continue
+		}
+
+		price, err := u.toBaseCurrency(ctx, total.Total())
+		if err != nil {
+			return nil, err
+		}
+
+		spend.Count += total.Count
+		spend.TotalPrice += price.Amount()
+		report.Total += total.Count
+		report.TotalPrice += price.Amount()
+	}
+
+	return report, nil
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestItemUsecase_GetSpendReport(t *testing.T) {
+	spendRange, _ := entity.ParseSpendRange("month", "2023-11", "2024-02")
+
+	tests := []struct {
+		name           string
+		setupMock      func(*MockItemRepository)
+		expectedReport *SpendReport
+		expectedErr    error
+	}{
+		{
+			name: "正常系: 通貨を換算して期間ごとにまとめ、購入がない月は0",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSpendByPeriod", mock.Anything, spendRange).Return([]*entity.PeriodCurrencyTotal{
+					{Period: "2023-11", Currency: "JPY", Count: 2, TotalPrice: 300000},
+					{Period: "2023-11", Currency: "USD", Count: 1, TotalPrice: 1000},
+					{Period: "2024-02", Currency: "EUR", Count: 1, TotalPrice: 500},
+				}, nil)
+			},
+			expectedReport: &SpendReport{
+				Currency:    "JPY",
+				Granularity: "month",
+				Periods: []*SpendPeriod{
+					{Period: "2023-11", Count: 3, TotalPrice: 450000},
+					{Period: "2023-12"},
+					{Period: "2024-01"},
+					{Period: "2024-02", Count: 1, TotalPrice: 80000},
+				},
+				Total:      4,
+				TotalPrice: 530000,
+			},
+		},
+		{
+			name: "正常系: 購入がない場合はすべての期間が0",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSpendByPeriod", mock.Anything, spendRange).Return([]*entity.PeriodCurrencyTotal{}, nil)
+			},
+			expectedReport: &SpendReport{
+				Currency:    "JPY",
+				Granularity: "month",
+				Periods:     []*SpendPeriod{{Period: "2023-11"}, {Period: "2023-12"}, {Period: "2024-01"}, {Period: "2024-02"}},
+			},
+		},
+		{
+			name: "異常系: データベースエラー",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSpendByPeriod", mock.Anything, spendRange).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil)
+
+			report, err := usecase.GetSpendReport(context.Background(), spendRange)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, report)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedReport, report)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
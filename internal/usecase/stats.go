@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 絞り込み条件に一致するアイテムの統計。金額はCurrency（基準通貨）に換算した値で、
+// アイテムがない場合はMostExpensiveとLatestPurchaseDateがnilになる
+type ItemStats struct {
+	Currency           string               `json:"currency"`
+	Total              int                  `json:"total"`
+	TotalPrice         int64                `json:"total_price"`
+	AveragePrice       float64              `json:"average_price"`
+	MostExpensive      *MostExpensiveItem   `json:"most_expensive"`
+	LatestPurchaseDate *entity.PurchaseDate `json:"latest_purchase_date"`
+	Categories         []*CategoryCount     `json:"categories"`
+}
+
+// 購入価格が最も高いアイテム。PurchasePriceはCurrency（アイテムの通貨）の金額
+type MostExpensiveItem struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	PurchasePrice int64  `json:"purchase_price"`
+	Currency      string `json:"currency"`
+}
+
+// カテゴリーごとのアイテムの件数
+type CategoryCount struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+// 一覧と同じ絞り込み条件でアイテムの統計を集計する。
+// 集計はSQLで行い、通貨ごとの合計と最高額のアイテムを基準通貨に換算してまとめる
+func (u *itemUsecase) GetItemStats(ctx context.Context, filter entity.ItemFilter) (*ItemStats, error) {
+	categories, err := u.categories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := normalizeFilter(ctx, &filter, categories); err != nil {
+		return nil, err
+	}
+
+	totals, err := u.itemRepo.GetStatsByCategory(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item stats: %w", err)
+	}
+
+	stats := &ItemStats{
+		Currency:   u.exchangeRates.BaseCurrency(),
+		Categories: make([]*CategoryCount, 0),
+	}
+
+	var current *CategoryCount
+	for _, total := range totals {
+		price, err := u.toBaseCurrency(ctx, total.Total())
+		if err != nil {
+			return nil, err
+		}
+
+		// カテゴリー順に並んでいるため、直前の行と同じカテゴリーであればまとめる
+		if current == nil || current.Category != total.Category {
+			current = &CategoryCount{Category: total.Category}
+			stats.Categories = append(stats.Categories, current)
+		}
+		current.Count += total.Count
+
+		stats.Total += total.Count
+		stats.TotalPrice += price.Amount()
+		if stats.LatestPurchaseDate == nil || total.LatestPurchaseDate.After(*stats.LatestPurchaseDate) {
+			latest := total.LatestPurchaseDate
+			stats.LatestPurchaseDate = &latest
+		}
+	}
+	stats.AveragePrice = averagePrice(stats.TotalPrice, stats.Total)
+
+	if stats.Total == 0 {
+		return stats, nil
+	}
+
+	candidates, err := u.itemRepo.FindMostExpensiveByCurrency(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find most expensive item: %w", err)
+	}
+
+	stats.MostExpensive, err = u.mostExpensive(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// 基準通貨に換算した価格が最も高いアイテムを選ぶ。同額の場合はIDの小さいアイテムで、候補がない場合はnil
+func (u *itemUsecase) mostExpensive(ctx context.Context, candidates []*entity.ItemPrice) (*MostExpensiveItem, error) {
+	var result *MostExpensiveItem
+	var maxConverted float64
+	for _, candidate := range candidates {
+		rate, err := u.exchangeRates.Rate(ctx, candidate.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %s to %s: %w", candidate.Currency, u.exchangeRates.BaseCurrency(), err)
+		}
+
+		converted := float64(candidate.PurchasePrice) * rate
+		if result == nil || converted > maxConverted || (converted == maxConverted && candidate.ID < result.ID) {
+			result = &MostExpensiveItem{
+				ID:            candidate.ID,
+				Name:          candidate.Name,
+				PurchasePrice: candidate.PurchasePrice,
+				Currency:      candidate.Currency,
+			}
+			maxConverted = converted
+		}
+	}
+	return result, nil
+}
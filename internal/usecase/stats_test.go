@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestItemUsecase_GetItemStats(t *testing.T) {
+	latest := entity.MustParsePurchaseDate("2024-03-01")
+
+	tests := []struct {
+		name          string
+		filter        entity.ItemFilter
+		setupMock     func(*MockItemRepository)
+		expectedStats *ItemStats
+		expectedErr   error
+	}{
+		{
+			name:   "正常系: 通貨を換算して集計し、換算後の価格で最高額のアイテムを選ぶ",
+			filter: entity.ItemFilter{Brand: "ROLEX"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetStatsByCategory", mock.Anything, entity.ItemFilter{Brand: "ROLEX"}).Return([]*entity.CategoryCurrencyStats{
+					{Category: "ジュエリー", Currency: "JPY", Count: 1, TotalPrice: 500000, LatestPurchaseDate: entity.MustParsePurchaseDate("2023-05-01")},
+					{Category: "時計", Currency: "JPY", Count: 2, TotalPrice: 2000000, LatestPurchaseDate: latest},
+					{Category: "時計", Currency: "USD", Count: 1, TotalPrice: 10000, LatestPurchaseDate: entity.MustParsePurchaseDate("2022-01-01")},
+				}, nil)
+				mockRepo.On("FindMostExpensiveByCurrency", mock.Anything, entity.ItemFilter{Brand: "ROLEX"}).Return([]*entity.ItemPrice{
+					{ID: 2, Name: "デイトナ", PurchasePrice: 1500000, Currency: "JPY"},
+					{ID: 3, Name: "サブマリーナー", PurchasePrice: 10000, Currency: "USD"},
+				}, nil)
+			},
+			expectedStats: &ItemStats{
+				Currency:           "JPY",
+				Total:              4,
+				TotalPrice:         4000000,
+				AveragePrice:       1000000,
+				MostExpensive:      &MostExpensiveItem{ID: 2, Name: "デイトナ", PurchasePrice: 1500000, Currency: "JPY"},
+				LatestPurchaseDate: &latest,
+				Categories: []*CategoryCount{
+					{Category: "ジュエリー", Count: 1},
+					{Category: "時計", Count: 3},
+				},
+			},
+		},
+		{
+			name:   "正常系: 換算後の価格が同じ場合はIDの小さいアイテム",
+			filter: entity.ItemFilter{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetStatsByCategory", mock.Anything, entity.ItemFilter{}).Return([]*entity.CategoryCurrencyStats{
+					{Category: "時計", Currency: "JPY", Count: 1, TotalPrice: 1500000, LatestPurchaseDate: latest},
+					{Category: "時計", Currency: "USD", Count: 1, TotalPrice: 10000, LatestPurchaseDate: latest},
+				}, nil)
+				mockRepo.On("FindMostExpensiveByCurrency", mock.Anything, entity.ItemFilter{}).Return([]*entity.ItemPrice{
+					{ID: 5, Name: "デイトナ", PurchasePrice: 1500000, Currency: "JPY"},
+					{ID: 3, Name: "サブマリーナー", PurchasePrice: 10000, Currency: "USD"},
+				}, nil)
+			},
+			expectedStats: &ItemStats{
+				Currency:           "JPY",
+				Total:              2,
+				TotalPrice:         3000000,
+				AveragePrice:       1500000,
+				MostExpensive:      &MostExpensiveItem{ID: 3, Name: "サブマリーナー", PurchasePrice: 10000, Currency: "USD"},
+				LatestPurchaseDate: &latest,
+				Categories:         []*CategoryCount{{Category: "時計", Count: 2}},
+			},
+		},
+		{
+			name:   "正常系: アイテムがない場合は0とnull",
+			filter: entity.ItemFilter{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetStatsByCategory", mock.Anything, entity.ItemFilter{}).Return([]*entity.CategoryCurrencyStats{}, nil)
+				// FindMostExpensiveByCurrencyは呼ばれない
+			},
+			expectedStats: &ItemStats{Currency: "JPY", Categories: []*CategoryCount{}},
+		},
+		{
+			name:   "異常系: 無効なカテゴリーで絞り込み",
+			filter: entity.ItemFilter{Category: "家電"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// リポジトリは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:   "異常系: データベースエラー",
+			filter: entity.ItemFilter{},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetStatsByCategory", mock.Anything, entity.ItemFilter{}).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil)
+
+			stats, err := usecase.GetItemStats(context.Background(), tt.filter)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, stats)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStats, stats)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 所有状況の変更の入力。Versionを指定した場合は、そのバージョンのときのみ変更する
+type ChangeItemStatusInput struct {
+	Status  string `json:"status"`
+	Version *int64 `json:"version,omitempty"`
+}
+
+// アイテムの所有状況を変更する。遷移のルールはエンティティで判定する
+func (u *itemUsecase) ChangeItemStatus(ctx context.Context, id int64, input ChangeItemStatusInput) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to find item: %w", err)
+	}
+
+	if input.Version != nil {
+		if err := item.CheckVersion(*input.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	before := item.Clone()
+	if err := item.ChangeStatus(input.Status); err != nil {
+		return nil, err
+	}
+
+	var updatedItem *entity.Item
+	err = u.withinTx(ctx, func(ctx context.Context) error {
+		updated, err := u.itemRepo.Update(ctx, item)
+		if err != nil {
+			return fmt.Errorf("failed to update item status: %w", err)
+		}
+		updatedItem = updated
+		return u.publish(ctx, entity.EventItemUpdated, id, entity.ItemChange{Before: before, After: updated})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedItem, nil
+}
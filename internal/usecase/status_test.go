@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestItemUsecase_ChangeItemStatus(t *testing.T) {
+	newItem := func(status string) *entity.Item {
+		item, _ := entity.NewItem(entity.NewItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2023-01-01"), Categories: testCategories})
+		item.ID = 1
+		item.Status = status
+		return item
+	}
+
+	tests := []struct {
+		name        string
+		id          int64
+		input       ChangeItemStatusInput
+		setupMock   func(*MockItemRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 所有中から出品中に変更",
+			id:    1,
+			input: ChangeItemStatusInput{Status: "listed"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusOwned), nil)
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+					return item.Status == entity.ItemStatusListed
+				})).Return(newItem(entity.ItemStatusListed), nil)
+			},
+		},
+		{
+			name:  "異常系: 売却済みからは変更できない",
+			id:    1,
+			input: ChangeItemStatusInput{Status: "listed"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusSold), nil)
+			},
+			expectedErr: domainErrors.ErrInvalidStatusTransition,
+		},
+		{
+			name:  "異常系: バージョンが古い",
+			id:    1,
+			input: ChangeItemStatusInput{Status: "listed", Version: int64Ptr(2)},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusOwned), nil)
+			},
+			expectedErr: domainErrors.ErrVersionConflict,
+		},
+		{
+			name:  "異常系: 無効な所有状況",
+			id:    1,
+			input: ChangeItemStatusInput{Status: "lost"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusOwned), nil)
+			},
+			expectedErr: domainErrors.ErrValidation,
+		},
+		{
+			name:  "異常系: アイテムが存在しない",
+			id:    999,
+			input: ChangeItemStatusInput{Status: "listed"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name:  "異常系: 無効なID（0以下）",
+			id:    0,
+			input: ChangeItemStatusInput{Status: "listed"},
+			setupMock: func(mockRepo *MockItemRepository) {
+				// FindByIDは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo, newMockCategoryRepository(), new(MockImageStorage), newTestExchangeRates(), nil, nil)
+
+			item, err := usecase.ChangeItemStatus(context.Background(), tt.id, tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, item)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.ItemStatusListed, item.Status)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
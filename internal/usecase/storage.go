@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"context"
+	"io"
+)
+
+// 画像ファイルの保存先
+type ImageStorage interface {
+	// Save stores the content under the given name and returns its public URL
+	Save(ctx context.Context, name string, r io.Reader) (string, error)
+
+	// Delete removes the file referenced by the URL returned from Save
+	Delete(ctx context.Context, url string) error
+}
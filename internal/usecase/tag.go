@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+type TagUsecase interface {
+	ListTags(ctx context.Context) ([]*entity.TagCount, error)
+}
+
+type tagUsecase struct {
+	tagRepo TagRepository
+}
+
+func NewTagUsecase(tagRepo TagRepository) TagUsecase {
+	return &tagUsecase{
+		tagRepo: tagRepo,
+	}
+}
+
+// アイテムに付いているタグを名前順に、アイテムの件数とともに取得する
+func (u *tagUsecase) ListTags(ctx context.Context) ([]*entity.TagCount, error) {
+	tags, err := u.tagRepo.FindAllWithCounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tags: %w", err)
+	}
+
+	return tags, nil
+}
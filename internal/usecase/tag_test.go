@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) FindAllWithCounts(ctx context.Context) ([]*entity.TagCount, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.TagCount), args.Error(1)
+}
+
+func TestTagUsecase_ListTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(*MockTagRepository)
+		expected    []*entity.TagCount
+		expectedErr error
+	}{
+		{
+			name: "正常系: タグと件数を取得",
+			setupMock: func(mockRepo *MockTagRepository) {
+				mockRepo.On("FindAllWithCounts", mock.Anything).Return([]*entity.TagCount{{Name: "プレゼント", Count: 1}, {Name: "限定品", Count: 3}}, nil)
+			},
+			expected: []*entity.TagCount{{Name: "プレゼント", Count: 1}, {Name: "限定品", Count: 3}},
+		},
+		{
+			name: "異常系: データベースエラー",
+			setupMock: func(mockRepo *MockTagRepository) {
+				mockRepo.On("FindAllWithCounts", mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTagRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewTagUsecase(mockRepo)
+
+			tags, err := usecase.ListTags(context.Background())
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, tags)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, tags)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,613 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 制限時間を設定したコンテキストでリポジトリを呼び出す。
+// 制限時間を過ぎた場合はcontext.DeadlineExceededを含むエラーを返す
+type queryTimeout time.Duration
+
+func (t queryTimeout) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, time.Duration(t))
+}
+
+// ItemRepositoryWithTimeout はrepoの各メソッドをtimeoutの制限時間で呼び出すItemRepositoryを返す。timeoutが0の場合はrepoをそのまま返す
+func ItemRepositoryWithTimeout(repo ItemRepository, timeout time.Duration) ItemRepository {
+	if timeout <= 0 {
+		return repo
+	}
+	return &timeoutItemRepository{repo: repo, timeout: queryTimeout(timeout)}
+}
+
+type timeoutItemRepository struct {
+	repo    ItemRepository
+	timeout queryTimeout
+}
+
+func (t *timeoutItemRepository) FindAll(ctx context.Context, filter entity.ItemFilter, sort entity.ItemSort, page entity.Pagination) ([]*entity.Item, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindAll(ctx, filter, sort, page)
+}
+
+// 件数に応じて時間がかかり、クライアントが読み込む速さにも左右されるため、制限時間を設定しない。
+// クライアントが切断した場合はctxの終了でクエリを中断する
+func (t *timeoutItemRepository) EachItem(ctx context.Context, filter entity.ItemFilter, sort entity.ItemSort, fn func(*entity.Item) error) error {
+	return t.repo.EachItem(ctx, filter, sort, fn)
+}
+
+func (t *timeoutItemRepository) Count(ctx context.Context, filter entity.ItemFilter) (int, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Count(ctx, filter)
+}
+
+func (t *timeoutItemRepository) MaxUpdatedAt(ctx context.Context, filter entity.ItemFilter) (*time.Time, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.MaxUpdatedAt(ctx, filter)
+}
+
+func (t *timeoutItemRepository) FindUpdatedSince(ctx context.Context, since time.Time, limit int) ([]*entity.Item, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindUpdatedSince(ctx, since, limit)
+}
+
+func (t *timeoutItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindByID(ctx, id)
+}
+
+func (t *timeoutItemRepository) FindByIDForUpdate(ctx context.Context, id int64) (*entity.Item, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindByIDForUpdate(ctx, id)
+}
+
+func (t *timeoutItemRepository) FindBySerialNumber(ctx context.Context, serialNumber string) (*entity.Item, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindBySerialNumber(ctx, serialNumber)
+}
+
+func (t *timeoutItemRepository) FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindByIDs(ctx, ids)
+}
+
+func (t *timeoutItemRepository) FindByPurchaseDate(ctx context.Context, purchaseDate entity.PurchaseDate) ([]*entity.Item, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindByPurchaseDate(ctx, purchaseDate)
+}
+
+func (t *timeoutItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Create(ctx, item)
+}
+
+func (t *timeoutItemRepository) CreateMany(ctx context.Context, items []*entity.Item) ([]int64, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.CreateMany(ctx, items)
+}
+
+func (t *timeoutItemRepository) Delete(ctx context.Context, id int64) (*entity.ItemChange, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Delete(ctx, id)
+}
+
+func (t *timeoutItemRepository) Restore(ctx context.Context, id int64) (*entity.ItemChange, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Restore(ctx, id)
+}
+
+func (t *timeoutItemRepository) HardDelete(ctx context.Context, id int64) (*entity.ItemChange, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.HardDelete(ctx, id)
+}
+
+func (t *timeoutItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Update(ctx, item)
+}
+
+func (t *timeoutItemRepository) CreateWithIdempotencyKey(ctx context.Context, item *entity.Item, key *entity.IdempotencyKey, createdBefore time.Time) (*entity.Item, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.CreateWithIdempotencyKey(ctx, item, key, createdBefore)
+}
+
+func (t *timeoutItemRepository) FindIdempotencyKey(ctx context.Context, key string, createdAfter time.Time) (*entity.IdempotencyKey, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindIdempotencyKey(ctx, key, createdAfter)
+}
+
+func (t *timeoutItemRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, createdBefore time.Time) (int64, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.DeleteExpiredIdempotencyKeys(ctx, createdBefore)
+}
+
+func (t *timeoutItemRepository) FindImages(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindImages(ctx, itemID)
+}
+
+func (t *timeoutItemRepository) FindImagesByItemIDs(ctx context.Context, itemIDs []int64) (map[int64][]*entity.ItemImage, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindImagesByItemIDs(ctx, itemIDs)
+}
+
+func (t *timeoutItemRepository) AddImage(ctx context.Context, itemID int64, url string, maxImages int) (*entity.ItemImage, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.AddImage(ctx, itemID, url, maxImages)
+}
+
+func (t *timeoutItemRepository) DeleteImage(ctx context.Context, itemID, imageID int64) error {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.DeleteImage(ctx, itemID, imageID)
+}
+
+func (t *timeoutItemRepository) ReorderImages(ctx context.Context, itemID int64, imageIDs []int64) error {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.ReorderImages(ctx, itemID, imageIDs)
+}
+
+func (t *timeoutItemRepository) FindHistories(ctx context.Context, itemID int64, page entity.Pagination) ([]*entity.ItemHistory, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindHistories(ctx, itemID, page)
+}
+
+func (t *timeoutItemRepository) CreateHistory(ctx context.Context, itemID int64, action string, before, after *entity.Item) error {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.CreateHistory(ctx, itemID, action, before, after)
+}
+
+func (t *timeoutItemRepository) CountHistories(ctx context.Context, itemID int64) (int, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.CountHistories(ctx, itemID)
+}
+
+func (t *timeoutItemRepository) GetSummaryByCategory(ctx context.Context) ([]*entity.CategoryCurrencyTotal, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.GetSummaryByCategory(ctx)
+}
+
+func (t *timeoutItemRepository) GetProfitByCurrency(ctx context.Context, year int) ([]*entity.ProfitCurrencyTotal, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.GetProfitByCurrency(ctx, year)
+}
+
+func (t *timeoutItemRepository) GetSpendByLocation(ctx context.Context) ([]*entity.LocationCurrencyTotal, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.GetSpendByLocation(ctx)
+}
+
+func (t *timeoutItemRepository) GetSpendByPeriod(ctx context.Context, r entity.SpendRange) ([]*entity.PeriodCurrencyTotal, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.GetSpendByPeriod(ctx, r)
+}
+
+func (t *timeoutItemRepository) GetSummaryByBrand(ctx context.Context) ([]*entity.BrandCurrencyTotal, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.GetSummaryByBrand(ctx)
+}
+
+func (t *timeoutItemRepository) FindMostExpensiveByBrand(ctx context.Context) ([]*entity.ItemPrice, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindMostExpensiveByBrand(ctx)
+}
+
+func (t *timeoutItemRepository) GetStatsByCategory(ctx context.Context, filter entity.ItemFilter) ([]*entity.CategoryCurrencyStats, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.GetStatsByCategory(ctx, filter)
+}
+
+func (t *timeoutItemRepository) FindMostExpensiveByCurrency(ctx context.Context, filter entity.ItemFilter) ([]*entity.ItemPrice, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindMostExpensiveByCurrency(ctx, filter)
+}
+
+// CategoryRepositoryWithTimeout はrepoの各メソッドをtimeoutの制限時間で呼び出すCategoryRepositoryを返す。timeoutが0の場合はrepoをそのまま返す
+func CategoryRepositoryWithTimeout(repo CategoryRepository, timeout time.Duration) CategoryRepository {
+	if timeout <= 0 {
+		return repo
+	}
+	return &timeoutCategoryRepository{repo: repo, timeout: queryTimeout(timeout)}
+}
+
+type timeoutCategoryRepository struct {
+	repo    CategoryRepository
+	timeout queryTimeout
+}
+
+func (t *timeoutCategoryRepository) FindAll(ctx context.Context) ([]*entity.Category, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindAll(ctx)
+}
+
+func (t *timeoutCategoryRepository) FindByID(ctx context.Context, id int64) (*entity.Category, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindByID(ctx, id)
+}
+
+func (t *timeoutCategoryRepository) Create(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Create(ctx, category)
+}
+
+func (t *timeoutCategoryRepository) Rename(ctx context.Context, id int64, name string) (*entity.Category, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Rename(ctx, id, name)
+}
+
+func (t *timeoutCategoryRepository) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Delete(ctx, id)
+}
+
+func (t *timeoutCategoryRepository) CountItems(ctx context.Context, name string) (int, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.CountItems(ctx, name)
+}
+
+// TagRepositoryWithTimeout はrepoの各メソッドをtimeoutの制限時間で呼び出すTagRepositoryを返す。timeoutが0の場合はrepoをそのまま返す
+func TagRepositoryWithTimeout(repo TagRepository, timeout time.Duration) TagRepository {
+	if timeout <= 0 {
+		return repo
+	}
+	return &timeoutTagRepository{repo: repo, timeout: queryTimeout(timeout)}
+}
+
+type timeoutTagRepository struct {
+	repo    TagRepository
+	timeout queryTimeout
+}
+
+func (t *timeoutTagRepository) FindAllWithCounts(ctx context.Context) ([]*entity.TagCount, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindAllWithCounts(ctx)
+}
+
+// BrandRepositoryWithTimeout はrepoの各メソッドをtimeoutの制限時間で呼び出すBrandRepositoryを返す。timeoutが0の場合はrepoをそのまま返す
+func BrandRepositoryWithTimeout(repo BrandRepository, timeout time.Duration) BrandRepository {
+	if timeout <= 0 {
+		return repo
+	}
+	return &timeoutBrandRepository{repo: repo, timeout: queryTimeout(timeout)}
+}
+
+type timeoutBrandRepository struct {
+	repo    BrandRepository
+	timeout queryTimeout
+}
+
+func (t *timeoutBrandRepository) Search(ctx context.Context, prefix string, limit int) ([]*entity.Brand, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Search(ctx, prefix, limit)
+}
+
+func (t *timeoutBrandRepository) FindByID(ctx context.Context, id int64) (*entity.Brand, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindByID(ctx, id)
+}
+
+func (t *timeoutBrandRepository) FindByNameOrAlias(ctx context.Context, name string) (*entity.Brand, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindByNameOrAlias(ctx, name)
+}
+
+func (t *timeoutBrandRepository) Create(ctx context.Context, brand *entity.Brand) (*entity.Brand, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Create(ctx, brand)
+}
+
+func (t *timeoutBrandRepository) Merge(ctx context.Context, sourceID, targetID int64) (int64, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Merge(ctx, sourceID, targetID)
+}
+
+// WebhookRepositoryWithTimeout はrepoの各メソッドをtimeoutの制限時間で呼び出すWebhookRepositoryを返す。timeoutが0の場合はrepoをそのまま返す
+func WebhookRepositoryWithTimeout(repo WebhookRepository, timeout time.Duration) WebhookRepository {
+	if timeout <= 0 {
+		return repo
+	}
+	return &timeoutWebhookRepository{repo: repo, timeout: queryTimeout(timeout)}
+}
+
+type timeoutWebhookRepository struct {
+	repo    WebhookRepository
+	timeout queryTimeout
+}
+
+func (t *timeoutWebhookRepository) FindAll(ctx context.Context) ([]*entity.Webhook, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindAll(ctx)
+}
+
+func (t *timeoutWebhookRepository) FindByID(ctx context.Context, id int64) (*entity.Webhook, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindByID(ctx, id)
+}
+
+func (t *timeoutWebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Create(ctx, webhook)
+}
+
+func (t *timeoutWebhookRepository) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Delete(ctx, id)
+}
+
+func (t *timeoutWebhookRepository) Enable(ctx context.Context, id int64) (*entity.Webhook, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Enable(ctx, id)
+}
+
+func (t *timeoutWebhookRepository) RecordResult(ctx context.Context, id int64, succeeded bool, maxFailures int) error {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.RecordResult(ctx, id, succeeded, maxFailures)
+}
+
+func (t *timeoutWebhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.CreateDelivery(ctx, delivery)
+}
+
+func (t *timeoutWebhookRepository) FindDeliveries(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindDeliveries(ctx, webhookID, limit)
+}
+
+func (t *timeoutWebhookRepository) DeleteDeliveriesBefore(ctx context.Context, createdBefore time.Time) (int64, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.DeleteDeliveriesBefore(ctx, createdBefore)
+}
+
+// ImportJobRepositoryWithTimeout はrepoの各メソッドをtimeoutの制限時間で呼び出すImportJobRepositoryを返す。timeoutが0の場合はrepoをそのまま返す
+func ImportJobRepositoryWithTimeout(repo ImportJobRepository, timeout time.Duration) ImportJobRepository {
+	if timeout <= 0 {
+		return repo
+	}
+	return &timeoutImportJobRepository{repo: repo, timeout: queryTimeout(timeout)}
+}
+
+type timeoutImportJobRepository struct {
+	repo    ImportJobRepository
+	timeout queryTimeout
+}
+
+func (t *timeoutImportJobRepository) Create(ctx context.Context, job *entity.ImportJob) (*entity.ImportJob, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Create(ctx, job)
+}
+
+func (t *timeoutImportJobRepository) FindByID(ctx context.Context, id int64) (*entity.ImportJob, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindByID(ctx, id)
+}
+
+func (t *timeoutImportJobRepository) Start(ctx context.Context, id int64) error {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Start(ctx, id)
+}
+
+func (t *timeoutImportJobRepository) UpdateProgress(ctx context.Context, id int64, rowsProcessed int) error {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.UpdateProgress(ctx, id, rowsProcessed)
+}
+
+func (t *timeoutImportJobRepository) Finish(ctx context.Context, job *entity.ImportJob) error {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Finish(ctx, job)
+}
+
+func (t *timeoutImportJobRepository) FailUnfinished(ctx context.Context, reason string) (int64, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FailUnfinished(ctx, reason)
+}
+
+func (t *timeoutImportJobRepository) DeleteFinishedBefore(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.DeleteFinishedBefore(ctx, finishedBefore)
+}
+
+// APIKeyRepositoryWithTimeout はrepoの各メソッドをtimeoutの制限時間で呼び出すAPIKeyRepositoryを返す。timeoutが0の場合はrepoをそのまま返す
+func APIKeyRepositoryWithTimeout(repo APIKeyRepository, timeout time.Duration) APIKeyRepository {
+	if timeout <= 0 {
+		return repo
+	}
+	return &timeoutAPIKeyRepository{repo: repo, timeout: queryTimeout(timeout)}
+}
+
+type timeoutAPIKeyRepository struct {
+	repo    APIKeyRepository
+	timeout queryTimeout
+}
+
+func (t *timeoutAPIKeyRepository) FindAll(ctx context.Context) ([]*entity.APIKey, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindAll(ctx)
+}
+
+func (t *timeoutAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindByHash(ctx, keyHash)
+}
+
+func (t *timeoutAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Create(ctx, key)
+}
+
+func (t *timeoutAPIKeyRepository) Revoke(ctx context.Context, id int64) (*entity.APIKey, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Revoke(ctx, id)
+}
+
+func (t *timeoutAPIKeyRepository) UpdateRole(ctx context.Context, id int64, role entity.UserRole) (*entity.APIKey, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.UpdateRole(ctx, id, role)
+}
+
+// UserRepositoryWithTimeout はrepoの各メソッドをtimeoutの制限時間で呼び出すUserRepositoryを返す。timeoutが0の場合はrepoをそのまま返す
+func UserRepositoryWithTimeout(repo UserRepository, timeout time.Duration) UserRepository {
+	if timeout <= 0 {
+		return repo
+	}
+	return &timeoutUserRepository{repo: repo, timeout: queryTimeout(timeout)}
+}
+
+type timeoutUserRepository struct {
+	repo    UserRepository
+	timeout queryTimeout
+}
+
+func (t *timeoutUserRepository) FindAll(ctx context.Context) ([]*entity.User, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindAll(ctx)
+}
+
+func (t *timeoutUserRepository) FindByID(ctx context.Context, id int64) (*entity.User, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindByID(ctx, id)
+}
+
+func (t *timeoutUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindByEmail(ctx, email)
+}
+
+func (t *timeoutUserRepository) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Create(ctx, user)
+}
+
+func (t *timeoutUserRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) (*entity.User, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.UpdatePassword(ctx, id, passwordHash)
+}
+
+func (t *timeoutUserRepository) UpdateRole(ctx context.Context, id int64, role entity.UserRole) (*entity.User, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.UpdateRole(ctx, id, role)
+}
+
+func (t *timeoutUserRepository) CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) (*entity.RefreshToken, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.CreateRefreshToken(ctx, token)
+}
+
+func (t *timeoutUserRepository) FindRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindRefreshToken(ctx, tokenHash)
+}
+
+func (t *timeoutUserRepository) RevokeRefreshToken(ctx context.Context, id int64) (bool, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.RevokeRefreshToken(ctx, id)
+}
+
+// AuditLogRepositoryWithTimeout はrepoの各メソッドをtimeoutの制限時間で呼び出すAuditLogRepositoryを返す。timeoutが0の場合はrepoをそのまま返す
+func AuditLogRepositoryWithTimeout(repo AuditLogRepository, timeout time.Duration) AuditLogRepository {
+	if timeout <= 0 {
+		return repo
+	}
+	return &timeoutAuditLogRepository{repo: repo, timeout: queryTimeout(timeout)}
+}
+
+type timeoutAuditLogRepository struct {
+	repo    AuditLogRepository
+	timeout queryTimeout
+}
+
+func (t *timeoutAuditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Create(ctx, log)
+}
+
+func (t *timeoutAuditLogRepository) FindAll(ctx context.Context, filter entity.AuditLogFilter, page entity.Pagination) ([]*entity.AuditLog, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.FindAll(ctx, filter, page)
+}
+
+func (t *timeoutAuditLogRepository) Count(ctx context.Context, filter entity.AuditLogFilter) (int, error) {
+	ctx, cancel := t.timeout.context(ctx)
+	defer cancel()
+	return t.repo.Count(ctx, filter)
+}
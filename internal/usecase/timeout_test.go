@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func TestItemRepositoryWithTimeout(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	// コンテキストの終了まで待つ遅いクエリ
+	mockRepo.On("FindByID", mock.Anything, int64(1)).
+		Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() }).
+		Return(nil, context.DeadlineExceeded)
+
+	repo := ItemRepositoryWithTimeout(mockRepo, 50*time.Millisecond)
+
+	start := time.Now()
+	_, err := repo.FindByID(context.Background(), 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	ctx := mockRepo.Calls[0].Arguments.Get(0).(context.Context)
+	_, ok := ctx.Deadline()
+	assert.True(t, ok)
+}
+
+func TestItemRepositoryWithTimeout_KeepsParentCancellation(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("Count", mock.Anything, entity.ItemFilter{}).Return(0, nil)
+
+	parent, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ItemRepositoryWithTimeout(mockRepo, time.Minute).Count(parent, entity.ItemFilter{})
+	require.NoError(t, err)
+
+	ctx := mockRepo.Calls[0].Arguments.Get(0).(context.Context)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
+func TestItemRepositoryWithTimeout_Zero(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	assert.Same(t, mockRepo, ItemRepositoryWithTimeout(mockRepo, 0))
+}
@@ -0,0 +1,1091 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// Tracer starts the spans that surround usecase methods and repository calls
+type Tracer interface {
+	// StartUsecase starts a span for method of usecase (e.g. "ItemUsecase" and "GetItemByID") as a child of the span in ctx.
+	// The returned function ends the span and records err on it when it is not nil
+	StartUsecase(ctx context.Context, usecase, method string) (context.Context, func(err error))
+	// StartQuery starts a span for method of repository (e.g. "ItemRepository" and "FindAll"), which names the statement the repository runs.
+	// The returned function ends the span and records err on it when it is not nil
+	StartQuery(ctx context.Context, repository, method string) (context.Context, func(err error))
+}
+
+// ItemRepositoryWithTracing はrepoの各メソッドの呼び出しをtracerのスパンで囲むItemRepositoryを返す
+func ItemRepositoryWithTracing(repo ItemRepository, tracer Tracer) ItemRepository {
+	return &tracedItemRepository{repo: repo, tracer: tracer}
+}
+
+type tracedItemRepository struct {
+	repo   ItemRepository
+	tracer Tracer
+}
+
+func (t *tracedItemRepository) FindAll(ctx context.Context, filter entity.ItemFilter, sort entity.ItemSort, page entity.Pagination) (_ []*entity.Item, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "FindAll")
+	defer func() { end(err) }()
+	return t.repo.FindAll(ctx, filter, sort, page)
+}
+
+func (t *tracedItemRepository) EachItem(ctx context.Context, filter entity.ItemFilter, sort entity.ItemSort, fn func(*entity.Item) error) (err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "EachItem")
+	defer func() { end(err) }()
+	return t.repo.EachItem(ctx, filter, sort, fn)
+}
+
+func (t *tracedItemRepository) Count(ctx context.Context, filter entity.ItemFilter) (_ int, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "Count")
+	defer func() { end(err) }()
+	return t.repo.Count(ctx, filter)
+}
+
+func (t *tracedItemRepository) MaxUpdatedAt(ctx context.Context, filter entity.ItemFilter) (_ *time.Time, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "MaxUpdatedAt")
+	defer func() { end(err) }()
+	return t.repo.MaxUpdatedAt(ctx, filter)
+}
+
+func (t *tracedItemRepository) FindUpdatedSince(ctx context.Context, since time.Time, limit int) (_ []*entity.Item, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "FindUpdatedSince")
+	defer func() { end(err) }()
+	return t.repo.FindUpdatedSince(ctx, since, limit)
+}
+
+func (t *tracedItemRepository) FindByID(ctx context.Context, id int64) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "FindByID")
+	defer func() { end(err) }()
+	return t.repo.FindByID(ctx, id)
+}
+
+func (t *tracedItemRepository) FindByIDForUpdate(ctx context.Context, id int64) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "FindByIDForUpdate")
+	defer func() { end(err) }()
+	return t.repo.FindByIDForUpdate(ctx, id)
+}
+
+func (t *tracedItemRepository) FindBySerialNumber(ctx context.Context, serialNumber string) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "FindBySerialNumber")
+	defer func() { end(err) }()
+	return t.repo.FindBySerialNumber(ctx, serialNumber)
+}
+
+func (t *tracedItemRepository) FindByIDs(ctx context.Context, ids []int64) (_ []*entity.Item, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "FindByIDs")
+	defer func() { end(err) }()
+	return t.repo.FindByIDs(ctx, ids)
+}
+
+func (t *tracedItemRepository) FindByPurchaseDate(ctx context.Context, purchaseDate entity.PurchaseDate) (_ []*entity.Item, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "FindByPurchaseDate")
+	defer func() { end(err) }()
+	return t.repo.FindByPurchaseDate(ctx, purchaseDate)
+}
+
+func (t *tracedItemRepository) Create(ctx context.Context, item *entity.Item) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "Create")
+	defer func() { end(err) }()
+	return t.repo.Create(ctx, item)
+}
+
+func (t *tracedItemRepository) CreateMany(ctx context.Context, items []*entity.Item) (_ []int64, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "CreateMany")
+	defer func() { end(err) }()
+	return t.repo.CreateMany(ctx, items)
+}
+
+func (t *tracedItemRepository) Delete(ctx context.Context, id int64) (_ *entity.ItemChange, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "Delete")
+	defer func() { end(err) }()
+	return t.repo.Delete(ctx, id)
+}
+
+func (t *tracedItemRepository) Restore(ctx context.Context, id int64) (_ *entity.ItemChange, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "Restore")
+	defer func() { end(err) }()
+	return t.repo.Restore(ctx, id)
+}
+
+func (t *tracedItemRepository) HardDelete(ctx context.Context, id int64) (_ *entity.ItemChange, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "HardDelete")
+	defer func() { end(err) }()
+	return t.repo.HardDelete(ctx, id)
+}
+
+func (t *tracedItemRepository) Update(ctx context.Context, item *entity.Item) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "Update")
+	defer func() { end(err) }()
+	return t.repo.Update(ctx, item)
+}
+
+func (t *tracedItemRepository) CreateWithIdempotencyKey(ctx context.Context, item *entity.Item, key *entity.IdempotencyKey, createdBefore time.Time) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "CreateWithIdempotencyKey")
+	defer func() { end(err) }()
+	return t.repo.CreateWithIdempotencyKey(ctx, item, key, createdBefore)
+}
+
+func (t *tracedItemRepository) FindIdempotencyKey(ctx context.Context, key string, createdAfter time.Time) (_ *entity.IdempotencyKey, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "FindIdempotencyKey")
+	defer func() { end(err) }()
+	return t.repo.FindIdempotencyKey(ctx, key, createdAfter)
+}
+
+func (t *tracedItemRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, createdBefore time.Time) (_ int64, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "DeleteExpiredIdempotencyKeys")
+	defer func() { end(err) }()
+	return t.repo.DeleteExpiredIdempotencyKeys(ctx, createdBefore)
+}
+
+func (t *tracedItemRepository) FindImages(ctx context.Context, itemID int64) (_ []*entity.ItemImage, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "FindImages")
+	defer func() { end(err) }()
+	return t.repo.FindImages(ctx, itemID)
+}
+
+func (t *tracedItemRepository) FindImagesByItemIDs(ctx context.Context, itemIDs []int64) (_ map[int64][]*entity.ItemImage, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "FindImagesByItemIDs")
+	defer func() { end(err) }()
+	return t.repo.FindImagesByItemIDs(ctx, itemIDs)
+}
+
+func (t *tracedItemRepository) AddImage(ctx context.Context, itemID int64, url string, maxImages int) (_ *entity.ItemImage, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "AddImage")
+	defer func() { end(err) }()
+	return t.repo.AddImage(ctx, itemID, url, maxImages)
+}
+
+func (t *tracedItemRepository) DeleteImage(ctx context.Context, itemID, imageID int64) (err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "DeleteImage")
+	defer func() { end(err) }()
+	return t.repo.DeleteImage(ctx, itemID, imageID)
+}
+
+func (t *tracedItemRepository) ReorderImages(ctx context.Context, itemID int64, imageIDs []int64) (err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "ReorderImages")
+	defer func() { end(err) }()
+	return t.repo.ReorderImages(ctx, itemID, imageIDs)
+}
+
+func (t *tracedItemRepository) FindHistories(ctx context.Context, itemID int64, page entity.Pagination) (_ []*entity.ItemHistory, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "FindHistories")
+	defer func() { end(err) }()
+	return t.repo.FindHistories(ctx, itemID, page)
+}
+
+func (t *tracedItemRepository) CreateHistory(ctx context.Context, itemID int64, action string, before, after *entity.Item) (err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "CreateHistory")
+	defer func() { end(err) }()
+	return t.repo.CreateHistory(ctx, itemID, action, before, after)
+}
+
+func (t *tracedItemRepository) CountHistories(ctx context.Context, itemID int64) (_ int, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "CountHistories")
+	defer func() { end(err) }()
+	return t.repo.CountHistories(ctx, itemID)
+}
+
+func (t *tracedItemRepository) GetSummaryByCategory(ctx context.Context) (_ []*entity.CategoryCurrencyTotal, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "GetSummaryByCategory")
+	defer func() { end(err) }()
+	return t.repo.GetSummaryByCategory(ctx)
+}
+
+func (t *tracedItemRepository) GetProfitByCurrency(ctx context.Context, year int) (_ []*entity.ProfitCurrencyTotal, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "GetProfitByCurrency")
+	defer func() { end(err) }()
+	return t.repo.GetProfitByCurrency(ctx, year)
+}
+
+func (t *tracedItemRepository) GetSpendByLocation(ctx context.Context) (_ []*entity.LocationCurrencyTotal, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "GetSpendByLocation")
+	defer func() { end(err) }()
+	return t.repo.GetSpendByLocation(ctx)
+}
+
+func (t *tracedItemRepository) GetSpendByPeriod(ctx context.Context, r entity.SpendRange) (_ []*entity.PeriodCurrencyTotal, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "GetSpendByPeriod")
+	defer func() { end(err) }()
+	return t.repo.GetSpendByPeriod(ctx, r)
+}
+
+func (t *tracedItemRepository) GetSummaryByBrand(ctx context.Context) (_ []*entity.BrandCurrencyTotal, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "GetSummaryByBrand")
+	defer func() { end(err) }()
+	return t.repo.GetSummaryByBrand(ctx)
+}
+
+func (t *tracedItemRepository) FindMostExpensiveByBrand(ctx context.Context) (_ []*entity.ItemPrice, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "FindMostExpensiveByBrand")
+	defer func() { end(err) }()
+	return t.repo.FindMostExpensiveByBrand(ctx)
+}
+
+func (t *tracedItemRepository) GetStatsByCategory(ctx context.Context, filter entity.ItemFilter) (_ []*entity.CategoryCurrencyStats, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "GetStatsByCategory")
+	defer func() { end(err) }()
+	return t.repo.GetStatsByCategory(ctx, filter)
+}
+
+func (t *tracedItemRepository) FindMostExpensiveByCurrency(ctx context.Context, filter entity.ItemFilter) (_ []*entity.ItemPrice, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ItemRepository", "FindMostExpensiveByCurrency")
+	defer func() { end(err) }()
+	return t.repo.FindMostExpensiveByCurrency(ctx, filter)
+}
+
+// CategoryRepositoryWithTracing はrepoの各メソッドの呼び出しをtracerのスパンで囲むCategoryRepositoryを返す
+func CategoryRepositoryWithTracing(repo CategoryRepository, tracer Tracer) CategoryRepository {
+	return &tracedCategoryRepository{repo: repo, tracer: tracer}
+}
+
+type tracedCategoryRepository struct {
+	repo   CategoryRepository
+	tracer Tracer
+}
+
+func (t *tracedCategoryRepository) FindAll(ctx context.Context) (_ []*entity.Category, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "CategoryRepository", "FindAll")
+	defer func() { end(err) }()
+	return t.repo.FindAll(ctx)
+}
+
+func (t *tracedCategoryRepository) FindByID(ctx context.Context, id int64) (_ *entity.Category, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "CategoryRepository", "FindByID")
+	defer func() { end(err) }()
+	return t.repo.FindByID(ctx, id)
+}
+
+func (t *tracedCategoryRepository) Create(ctx context.Context, category *entity.Category) (_ *entity.Category, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "CategoryRepository", "Create")
+	defer func() { end(err) }()
+	return t.repo.Create(ctx, category)
+}
+
+func (t *tracedCategoryRepository) Rename(ctx context.Context, id int64, name string) (_ *entity.Category, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "CategoryRepository", "Rename")
+	defer func() { end(err) }()
+	return t.repo.Rename(ctx, id, name)
+}
+
+func (t *tracedCategoryRepository) Delete(ctx context.Context, id int64) (err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "CategoryRepository", "Delete")
+	defer func() { end(err) }()
+	return t.repo.Delete(ctx, id)
+}
+
+func (t *tracedCategoryRepository) CountItems(ctx context.Context, name string) (_ int, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "CategoryRepository", "CountItems")
+	defer func() { end(err) }()
+	return t.repo.CountItems(ctx, name)
+}
+
+// TagRepositoryWithTracing はrepoの各メソッドの呼び出しをtracerのスパンで囲むTagRepositoryを返す
+func TagRepositoryWithTracing(repo TagRepository, tracer Tracer) TagRepository {
+	return &tracedTagRepository{repo: repo, tracer: tracer}
+}
+
+type tracedTagRepository struct {
+	repo   TagRepository
+	tracer Tracer
+}
+
+func (t *tracedTagRepository) FindAllWithCounts(ctx context.Context) (_ []*entity.TagCount, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "TagRepository", "FindAllWithCounts")
+	defer func() { end(err) }()
+	return t.repo.FindAllWithCounts(ctx)
+}
+
+// BrandRepositoryWithTracing はrepoの各メソッドの呼び出しをtracerのスパンで囲むBrandRepositoryを返す
+func BrandRepositoryWithTracing(repo BrandRepository, tracer Tracer) BrandRepository {
+	return &tracedBrandRepository{repo: repo, tracer: tracer}
+}
+
+type tracedBrandRepository struct {
+	repo   BrandRepository
+	tracer Tracer
+}
+
+func (t *tracedBrandRepository) Search(ctx context.Context, prefix string, limit int) (_ []*entity.Brand, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "BrandRepository", "Search")
+	defer func() { end(err) }()
+	return t.repo.Search(ctx, prefix, limit)
+}
+
+func (t *tracedBrandRepository) FindByID(ctx context.Context, id int64) (_ *entity.Brand, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "BrandRepository", "FindByID")
+	defer func() { end(err) }()
+	return t.repo.FindByID(ctx, id)
+}
+
+func (t *tracedBrandRepository) FindByNameOrAlias(ctx context.Context, name string) (_ *entity.Brand, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "BrandRepository", "FindByNameOrAlias")
+	defer func() { end(err) }()
+	return t.repo.FindByNameOrAlias(ctx, name)
+}
+
+func (t *tracedBrandRepository) Create(ctx context.Context, brand *entity.Brand) (_ *entity.Brand, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "BrandRepository", "Create")
+	defer func() { end(err) }()
+	return t.repo.Create(ctx, brand)
+}
+
+func (t *tracedBrandRepository) Merge(ctx context.Context, sourceID, targetID int64) (_ int64, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "BrandRepository", "Merge")
+	defer func() { end(err) }()
+	return t.repo.Merge(ctx, sourceID, targetID)
+}
+
+// WebhookRepositoryWithTracing はrepoの各メソッドの呼び出しをtracerのスパンで囲むWebhookRepositoryを返す
+func WebhookRepositoryWithTracing(repo WebhookRepository, tracer Tracer) WebhookRepository {
+	return &tracedWebhookRepository{repo: repo, tracer: tracer}
+}
+
+type tracedWebhookRepository struct {
+	repo   WebhookRepository
+	tracer Tracer
+}
+
+func (t *tracedWebhookRepository) FindAll(ctx context.Context) (_ []*entity.Webhook, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "WebhookRepository", "FindAll")
+	defer func() { end(err) }()
+	return t.repo.FindAll(ctx)
+}
+
+func (t *tracedWebhookRepository) FindByID(ctx context.Context, id int64) (_ *entity.Webhook, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "WebhookRepository", "FindByID")
+	defer func() { end(err) }()
+	return t.repo.FindByID(ctx, id)
+}
+
+func (t *tracedWebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) (_ *entity.Webhook, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "WebhookRepository", "Create")
+	defer func() { end(err) }()
+	return t.repo.Create(ctx, webhook)
+}
+
+func (t *tracedWebhookRepository) Delete(ctx context.Context, id int64) (err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "WebhookRepository", "Delete")
+	defer func() { end(err) }()
+	return t.repo.Delete(ctx, id)
+}
+
+func (t *tracedWebhookRepository) Enable(ctx context.Context, id int64) (_ *entity.Webhook, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "WebhookRepository", "Enable")
+	defer func() { end(err) }()
+	return t.repo.Enable(ctx, id)
+}
+
+func (t *tracedWebhookRepository) RecordResult(ctx context.Context, id int64, succeeded bool, maxFailures int) (err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "WebhookRepository", "RecordResult")
+	defer func() { end(err) }()
+	return t.repo.RecordResult(ctx, id, succeeded, maxFailures)
+}
+
+func (t *tracedWebhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) (err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "WebhookRepository", "CreateDelivery")
+	defer func() { end(err) }()
+	return t.repo.CreateDelivery(ctx, delivery)
+}
+
+func (t *tracedWebhookRepository) FindDeliveries(ctx context.Context, webhookID int64, limit int) (_ []*entity.WebhookDelivery, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "WebhookRepository", "FindDeliveries")
+	defer func() { end(err) }()
+	return t.repo.FindDeliveries(ctx, webhookID, limit)
+}
+
+func (t *tracedWebhookRepository) DeleteDeliveriesBefore(ctx context.Context, createdBefore time.Time) (_ int64, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "WebhookRepository", "DeleteDeliveriesBefore")
+	defer func() { end(err) }()
+	return t.repo.DeleteDeliveriesBefore(ctx, createdBefore)
+}
+
+// ImportJobRepositoryWithTracing はrepoの各メソッドの呼び出しをtracerのスパンで囲むImportJobRepositoryを返す
+func ImportJobRepositoryWithTracing(repo ImportJobRepository, tracer Tracer) ImportJobRepository {
+	return &tracedImportJobRepository{repo: repo, tracer: tracer}
+}
+
+type tracedImportJobRepository struct {
+	repo   ImportJobRepository
+	tracer Tracer
+}
+
+func (t *tracedImportJobRepository) Create(ctx context.Context, job *entity.ImportJob) (_ *entity.ImportJob, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ImportJobRepository", "Create")
+	defer func() { end(err) }()
+	return t.repo.Create(ctx, job)
+}
+
+func (t *tracedImportJobRepository) FindByID(ctx context.Context, id int64) (_ *entity.ImportJob, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ImportJobRepository", "FindByID")
+	defer func() { end(err) }()
+	return t.repo.FindByID(ctx, id)
+}
+
+func (t *tracedImportJobRepository) Start(ctx context.Context, id int64) (err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ImportJobRepository", "Start")
+	defer func() { end(err) }()
+	return t.repo.Start(ctx, id)
+}
+
+func (t *tracedImportJobRepository) UpdateProgress(ctx context.Context, id int64, rowsProcessed int) (err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ImportJobRepository", "UpdateProgress")
+	defer func() { end(err) }()
+	return t.repo.UpdateProgress(ctx, id, rowsProcessed)
+}
+
+func (t *tracedImportJobRepository) Finish(ctx context.Context, job *entity.ImportJob) (err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ImportJobRepository", "Finish")
+	defer func() { end(err) }()
+	return t.repo.Finish(ctx, job)
+}
+
+func (t *tracedImportJobRepository) FailUnfinished(ctx context.Context, reason string) (_ int64, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ImportJobRepository", "FailUnfinished")
+	defer func() { end(err) }()
+	return t.repo.FailUnfinished(ctx, reason)
+}
+
+func (t *tracedImportJobRepository) DeleteFinishedBefore(ctx context.Context, finishedBefore time.Time) (_ int64, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "ImportJobRepository", "DeleteFinishedBefore")
+	defer func() { end(err) }()
+	return t.repo.DeleteFinishedBefore(ctx, finishedBefore)
+}
+
+// APIKeyRepositoryWithTracing はrepoの各メソッドの呼び出しをtracerのスパンで囲むAPIKeyRepositoryを返す
+func APIKeyRepositoryWithTracing(repo APIKeyRepository, tracer Tracer) APIKeyRepository {
+	return &tracedAPIKeyRepository{repo: repo, tracer: tracer}
+}
+
+type tracedAPIKeyRepository struct {
+	repo   APIKeyRepository
+	tracer Tracer
+}
+
+func (t *tracedAPIKeyRepository) FindAll(ctx context.Context) (_ []*entity.APIKey, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "APIKeyRepository", "FindAll")
+	defer func() { end(err) }()
+	return t.repo.FindAll(ctx)
+}
+
+func (t *tracedAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (_ *entity.APIKey, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "APIKeyRepository", "FindByHash")
+	defer func() { end(err) }()
+	return t.repo.FindByHash(ctx, keyHash)
+}
+
+func (t *tracedAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (_ *entity.APIKey, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "APIKeyRepository", "Create")
+	defer func() { end(err) }()
+	return t.repo.Create(ctx, key)
+}
+
+func (t *tracedAPIKeyRepository) Revoke(ctx context.Context, id int64) (_ *entity.APIKey, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "APIKeyRepository", "Revoke")
+	defer func() { end(err) }()
+	return t.repo.Revoke(ctx, id)
+}
+
+func (t *tracedAPIKeyRepository) UpdateRole(ctx context.Context, id int64, role entity.UserRole) (_ *entity.APIKey, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "APIKeyRepository", "UpdateRole")
+	defer func() { end(err) }()
+	return t.repo.UpdateRole(ctx, id, role)
+}
+
+// UserRepositoryWithTracing はrepoの各メソッドの呼び出しをtracerのスパンで囲むUserRepositoryを返す
+func UserRepositoryWithTracing(repo UserRepository, tracer Tracer) UserRepository {
+	return &tracedUserRepository{repo: repo, tracer: tracer}
+}
+
+type tracedUserRepository struct {
+	repo   UserRepository
+	tracer Tracer
+}
+
+func (t *tracedUserRepository) FindAll(ctx context.Context) (_ []*entity.User, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "UserRepository", "FindAll")
+	defer func() { end(err) }()
+	return t.repo.FindAll(ctx)
+}
+
+func (t *tracedUserRepository) FindByID(ctx context.Context, id int64) (_ *entity.User, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "UserRepository", "FindByID")
+	defer func() { end(err) }()
+	return t.repo.FindByID(ctx, id)
+}
+
+func (t *tracedUserRepository) FindByEmail(ctx context.Context, email string) (_ *entity.User, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "UserRepository", "FindByEmail")
+	defer func() { end(err) }()
+	return t.repo.FindByEmail(ctx, email)
+}
+
+func (t *tracedUserRepository) Create(ctx context.Context, user *entity.User) (_ *entity.User, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "UserRepository", "Create")
+	defer func() { end(err) }()
+	return t.repo.Create(ctx, user)
+}
+
+func (t *tracedUserRepository) UpdatePassword(ctx context.Context, id int64, passwordHash string) (_ *entity.User, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "UserRepository", "UpdatePassword")
+	defer func() { end(err) }()
+	return t.repo.UpdatePassword(ctx, id, passwordHash)
+}
+
+func (t *tracedUserRepository) UpdateRole(ctx context.Context, id int64, role entity.UserRole) (_ *entity.User, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "UserRepository", "UpdateRole")
+	defer func() { end(err) }()
+	return t.repo.UpdateRole(ctx, id, role)
+}
+
+func (t *tracedUserRepository) CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) (_ *entity.RefreshToken, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "UserRepository", "CreateRefreshToken")
+	defer func() { end(err) }()
+	return t.repo.CreateRefreshToken(ctx, token)
+}
+
+func (t *tracedUserRepository) FindRefreshToken(ctx context.Context, tokenHash string) (_ *entity.RefreshToken, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "UserRepository", "FindRefreshToken")
+	defer func() { end(err) }()
+	return t.repo.FindRefreshToken(ctx, tokenHash)
+}
+
+func (t *tracedUserRepository) RevokeRefreshToken(ctx context.Context, id int64) (_ bool, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "UserRepository", "RevokeRefreshToken")
+	defer func() { end(err) }()
+	return t.repo.RevokeRefreshToken(ctx, id)
+}
+
+// AuditLogRepositoryWithTracing はrepoの各メソッドの呼び出しをtracerのスパンで囲むAuditLogRepositoryを返す
+func AuditLogRepositoryWithTracing(repo AuditLogRepository, tracer Tracer) AuditLogRepository {
+	return &tracedAuditLogRepository{repo: repo, tracer: tracer}
+}
+
+type tracedAuditLogRepository struct {
+	repo   AuditLogRepository
+	tracer Tracer
+}
+
+func (t *tracedAuditLogRepository) Create(ctx context.Context, log *entity.AuditLog) (err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "AuditLogRepository", "Create")
+	defer func() { end(err) }()
+	return t.repo.Create(ctx, log)
+}
+
+func (t *tracedAuditLogRepository) FindAll(ctx context.Context, filter entity.AuditLogFilter, page entity.Pagination) (_ []*entity.AuditLog, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "AuditLogRepository", "FindAll")
+	defer func() { end(err) }()
+	return t.repo.FindAll(ctx, filter, page)
+}
+
+func (t *tracedAuditLogRepository) Count(ctx context.Context, filter entity.AuditLogFilter) (_ int, err error) {
+	ctx, end := t.tracer.StartQuery(ctx, "AuditLogRepository", "Count")
+	defer func() { end(err) }()
+	return t.repo.Count(ctx, filter)
+}
+
+// ItemUsecaseWithTracing はusecaseの各メソッドの呼び出しをtracerのスパンで囲むItemUsecaseを返す
+func ItemUsecaseWithTracing(usecase ItemUsecase, tracer Tracer) ItemUsecase {
+	return &tracedItemUsecase{usecase: usecase, tracer: tracer}
+}
+
+type tracedItemUsecase struct {
+	usecase ItemUsecase
+	tracer  Tracer
+}
+
+func (t *tracedItemUsecase) GetAllItems(ctx context.Context, input ListItemsInput) (_ *ItemList, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "GetAllItems")
+	defer func() { end(err) }()
+	return t.usecase.GetAllItems(ctx, input)
+}
+
+func (t *tracedItemUsecase) GetItemsLastModified(ctx context.Context, filter entity.ItemFilter) (_ *time.Time, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "GetItemsLastModified")
+	defer func() { end(err) }()
+	return t.usecase.GetItemsLastModified(ctx, filter)
+}
+
+func (t *tracedItemUsecase) GetItemByID(ctx context.Context, id int64) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "GetItemByID")
+	defer func() { end(err) }()
+	return t.usecase.GetItemByID(ctx, id)
+}
+
+func (t *tracedItemUsecase) GetItemBySerialNumber(ctx context.Context, serialNumber string) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "GetItemBySerialNumber")
+	defer func() { end(err) }()
+	return t.usecase.GetItemBySerialNumber(ctx, serialNumber)
+}
+
+func (t *tracedItemUsecase) GetItemsByIDs(ctx context.Context, ids []int64) (_ *ItemBatch, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "GetItemsByIDs")
+	defer func() { end(err) }()
+	return t.usecase.GetItemsByIDs(ctx, ids)
+}
+
+func (t *tracedItemUsecase) GetRecentItems(ctx context.Context, window time.Duration, limit int) (_ *RecentItemList, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "GetRecentItems")
+	defer func() { end(err) }()
+	return t.usecase.GetRecentItems(ctx, window, limit)
+}
+
+func (t *tracedItemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "CreateItem")
+	defer func() { end(err) }()
+	return t.usecase.CreateItem(ctx, input)
+}
+
+func (t *tracedItemUsecase) CreateItemWithIdempotencyKey(ctx context.Context, key string, input CreateItemInput) (_ *entity.Item, _ bool, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "CreateItemWithIdempotencyKey")
+	defer func() { end(err) }()
+	return t.usecase.CreateItemWithIdempotencyKey(ctx, key, input)
+}
+
+func (t *tracedItemUsecase) CloneItem(ctx context.Context, id int64, input CloneItemInput) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "CloneItem")
+	defer func() { end(err) }()
+	return t.usecase.CloneItem(ctx, id, input)
+}
+
+func (t *tracedItemUsecase) DeleteExpiredIdempotencyKeys(ctx context.Context) (_ int64, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "DeleteExpiredIdempotencyKeys")
+	defer func() { end(err) }()
+	return t.usecase.DeleteExpiredIdempotencyKeys(ctx)
+}
+
+func (t *tracedItemUsecase) BulkCreateItems(ctx context.Context, inputs []CreateItemInput) (_ []*entity.Item, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "BulkCreateItems")
+	defer func() { end(err) }()
+	return t.usecase.BulkCreateItems(ctx, inputs)
+}
+
+func (t *tracedItemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "UpdateItem")
+	defer func() { end(err) }()
+	return t.usecase.UpdateItem(ctx, id, input)
+}
+
+func (t *tracedItemUsecase) ReplaceItem(ctx context.Context, id int64, input ReplaceItemInput) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "ReplaceItem")
+	defer func() { end(err) }()
+	return t.usecase.ReplaceItem(ctx, id, input)
+}
+
+func (t *tracedItemUsecase) ChangeItemStatus(ctx context.Context, id int64, input ChangeItemStatusInput) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "ChangeItemStatus")
+	defer func() { end(err) }()
+	return t.usecase.ChangeItemStatus(ctx, id, input)
+}
+
+func (t *tracedItemUsecase) MarkItemSold(ctx context.Context, id int64, input MarkItemSoldInput) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "MarkItemSold")
+	defer func() { end(err) }()
+	return t.usecase.MarkItemSold(ctx, id, input)
+}
+
+func (t *tracedItemUsecase) DeleteItem(ctx context.Context, id int64, expectedVersion *int64) (err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "DeleteItem")
+	defer func() { end(err) }()
+	return t.usecase.DeleteItem(ctx, id, expectedVersion)
+}
+
+func (t *tracedItemUsecase) BulkDeleteItems(ctx context.Context, ids []int64, bestEffort bool) (_ []BulkDeleteResult, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "BulkDeleteItems")
+	defer func() { end(err) }()
+	return t.usecase.BulkDeleteItems(ctx, ids, bestEffort)
+}
+
+func (t *tracedItemUsecase) RestoreItem(ctx context.Context, id int64) (_ *entity.Item, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "RestoreItem")
+	defer func() { end(err) }()
+	return t.usecase.RestoreItem(ctx, id)
+}
+
+func (t *tracedItemUsecase) HardDeleteItem(ctx context.Context, id int64) (err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "HardDeleteItem")
+	defer func() { end(err) }()
+	return t.usecase.HardDeleteItem(ctx, id)
+}
+
+func (t *tracedItemUsecase) GetCategorySummary(ctx context.Context) (_ *CategorySummary, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "GetCategorySummary")
+	defer func() { end(err) }()
+	return t.usecase.GetCategorySummary(ctx)
+}
+
+func (t *tracedItemUsecase) GetBrandSummary(ctx context.Context, limit int) (_ *BrandSummary, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "GetBrandSummary")
+	defer func() { end(err) }()
+	return t.usecase.GetBrandSummary(ctx, limit)
+}
+
+func (t *tracedItemUsecase) GetProfitReport(ctx context.Context, year int) (_ *ProfitReport, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "GetProfitReport")
+	defer func() { end(err) }()
+	return t.usecase.GetProfitReport(ctx, year)
+}
+
+func (t *tracedItemUsecase) GetLocationReport(ctx context.Context) (_ *LocationReport, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "GetLocationReport")
+	defer func() { end(err) }()
+	return t.usecase.GetLocationReport(ctx)
+}
+
+func (t *tracedItemUsecase) GetItemStats(ctx context.Context, filter entity.ItemFilter) (_ *ItemStats, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "GetItemStats")
+	defer func() { end(err) }()
+	return t.usecase.GetItemStats(ctx, filter)
+}
+
+func (t *tracedItemUsecase) GetSpendReport(ctx context.Context, spendRange entity.SpendRange) (_ *SpendReport, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "GetSpendReport")
+	defer func() { end(err) }()
+	return t.usecase.GetSpendReport(ctx, spendRange)
+}
+
+func (t *tracedItemUsecase) ExportItems(ctx context.Context, filter entity.ItemFilter, fn func(*entity.Item) error) (err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "ExportItems")
+	defer func() { end(err) }()
+	return t.usecase.ExportItems(ctx, filter, fn)
+}
+
+func (t *tracedItemUsecase) ImportItems(ctx context.Context, r io.Reader, opts ImportOptions) (_ *ImportResult, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "ImportItems")
+	defer func() { end(err) }()
+	return t.usecase.ImportItems(ctx, r, opts)
+}
+
+func (t *tracedItemUsecase) GetItemHistory(ctx context.Context, id int64, limit, offset int) (_ *ItemHistoryList, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemUsecase", "GetItemHistory")
+	defer func() { end(err) }()
+	return t.usecase.GetItemHistory(ctx, id, limit, offset)
+}
+
+// CategoryUsecaseWithTracing はusecaseの各メソッドの呼び出しをtracerのスパンで囲むCategoryUsecaseを返す
+func CategoryUsecaseWithTracing(usecase CategoryUsecase, tracer Tracer) CategoryUsecase {
+	return &tracedCategoryUsecase{usecase: usecase, tracer: tracer}
+}
+
+type tracedCategoryUsecase struct {
+	usecase CategoryUsecase
+	tracer  Tracer
+}
+
+func (t *tracedCategoryUsecase) ListCategories(ctx context.Context) (_ []*entity.Category, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "CategoryUsecase", "ListCategories")
+	defer func() { end(err) }()
+	return t.usecase.ListCategories(ctx)
+}
+
+func (t *tracedCategoryUsecase) GetCategory(ctx context.Context, id int64) (_ *entity.Category, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "CategoryUsecase", "GetCategory")
+	defer func() { end(err) }()
+	return t.usecase.GetCategory(ctx, id)
+}
+
+func (t *tracedCategoryUsecase) CreateCategory(ctx context.Context, input CreateCategoryInput) (_ *entity.Category, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "CategoryUsecase", "CreateCategory")
+	defer func() { end(err) }()
+	return t.usecase.CreateCategory(ctx, input)
+}
+
+func (t *tracedCategoryUsecase) UpdateCategory(ctx context.Context, id int64, name string) (_ *entity.Category, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "CategoryUsecase", "UpdateCategory")
+	defer func() { end(err) }()
+	return t.usecase.UpdateCategory(ctx, id, name)
+}
+
+func (t *tracedCategoryUsecase) DeleteCategory(ctx context.Context, id int64) (err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "CategoryUsecase", "DeleteCategory")
+	defer func() { end(err) }()
+	return t.usecase.DeleteCategory(ctx, id)
+}
+
+// TagUsecaseWithTracing はusecaseの各メソッドの呼び出しをtracerのスパンで囲むTagUsecaseを返す
+func TagUsecaseWithTracing(usecase TagUsecase, tracer Tracer) TagUsecase {
+	return &tracedTagUsecase{usecase: usecase, tracer: tracer}
+}
+
+type tracedTagUsecase struct {
+	usecase TagUsecase
+	tracer  Tracer
+}
+
+func (t *tracedTagUsecase) ListTags(ctx context.Context) (_ []*entity.TagCount, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "TagUsecase", "ListTags")
+	defer func() { end(err) }()
+	return t.usecase.ListTags(ctx)
+}
+
+// BrandUsecaseWithTracing はusecaseの各メソッドの呼び出しをtracerのスパンで囲むBrandUsecaseを返す
+func BrandUsecaseWithTracing(usecase BrandUsecase, tracer Tracer) BrandUsecase {
+	return &tracedBrandUsecase{usecase: usecase, tracer: tracer}
+}
+
+type tracedBrandUsecase struct {
+	usecase BrandUsecase
+	tracer  Tracer
+}
+
+func (t *tracedBrandUsecase) SearchBrands(ctx context.Context, prefix string, limit int) (_ []*entity.Brand, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "BrandUsecase", "SearchBrands")
+	defer func() { end(err) }()
+	return t.usecase.SearchBrands(ctx, prefix, limit)
+}
+
+func (t *tracedBrandUsecase) CreateBrand(ctx context.Context, input CreateBrandInput) (_ *entity.Brand, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "BrandUsecase", "CreateBrand")
+	defer func() { end(err) }()
+	return t.usecase.CreateBrand(ctx, input)
+}
+
+func (t *tracedBrandUsecase) MergeBrands(ctx context.Context, sourceID, targetID int64) (_ *BrandMergeResult, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "BrandUsecase", "MergeBrands")
+	defer func() { end(err) }()
+	return t.usecase.MergeBrands(ctx, sourceID, targetID)
+}
+
+func (t *tracedBrandUsecase) ResolveItemBrand(ctx context.Context, brand string) (_ *BrandResolution, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "BrandUsecase", "ResolveItemBrand")
+	defer func() { end(err) }()
+	return t.usecase.ResolveItemBrand(ctx, brand)
+}
+
+// ItemImageUsecaseWithTracing はusecaseの各メソッドの呼び出しをtracerのスパンで囲むItemImageUsecaseを返す
+func ItemImageUsecaseWithTracing(usecase ItemImageUsecase, tracer Tracer) ItemImageUsecase {
+	return &tracedItemImageUsecase{usecase: usecase, tracer: tracer}
+}
+
+type tracedItemImageUsecase struct {
+	usecase ItemImageUsecase
+	tracer  Tracer
+}
+
+func (t *tracedItemImageUsecase) ListItemImages(ctx context.Context, itemID int64) (_ []*entity.ItemImage, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemImageUsecase", "ListItemImages")
+	defer func() { end(err) }()
+	return t.usecase.ListItemImages(ctx, itemID)
+}
+
+func (t *tracedItemImageUsecase) AddItemImage(ctx context.Context, itemID int64, r io.Reader) (_ *entity.ItemImage, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemImageUsecase", "AddItemImage")
+	defer func() { end(err) }()
+	return t.usecase.AddItemImage(ctx, itemID, r)
+}
+
+func (t *tracedItemImageUsecase) DeleteItemImage(ctx context.Context, itemID, imageID int64) (err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemImageUsecase", "DeleteItemImage")
+	defer func() { end(err) }()
+	return t.usecase.DeleteItemImage(ctx, itemID, imageID)
+}
+
+func (t *tracedItemImageUsecase) ReorderItemImages(ctx context.Context, itemID int64, imageIDs []int64) (_ []*entity.ItemImage, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemImageUsecase", "ReorderItemImages")
+	defer func() { end(err) }()
+	return t.usecase.ReorderItemImages(ctx, itemID, imageIDs)
+}
+
+func (t *tracedItemImageUsecase) ListImagesByItemIDs(ctx context.Context, itemIDs []int64) (_ map[int64][]*entity.ItemImage, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ItemImageUsecase", "ListImagesByItemIDs")
+	defer func() { end(err) }()
+	return t.usecase.ListImagesByItemIDs(ctx, itemIDs)
+}
+
+// WebhookUsecaseWithTracing はusecaseの各メソッドの呼び出しをtracerのスパンで囲むWebhookUsecaseを返す
+func WebhookUsecaseWithTracing(usecase WebhookUsecase, tracer Tracer) WebhookUsecase {
+	return &tracedWebhookUsecase{usecase: usecase, tracer: tracer}
+}
+
+type tracedWebhookUsecase struct {
+	usecase WebhookUsecase
+	tracer  Tracer
+}
+
+func (t *tracedWebhookUsecase) ListWebhooks(ctx context.Context) (_ []*entity.Webhook, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "WebhookUsecase", "ListWebhooks")
+	defer func() { end(err) }()
+	return t.usecase.ListWebhooks(ctx)
+}
+
+func (t *tracedWebhookUsecase) CreateWebhook(ctx context.Context, input CreateWebhookInput) (_ *entity.Webhook, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "WebhookUsecase", "CreateWebhook")
+	defer func() { end(err) }()
+	return t.usecase.CreateWebhook(ctx, input)
+}
+
+func (t *tracedWebhookUsecase) DeleteWebhook(ctx context.Context, id int64) (err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "WebhookUsecase", "DeleteWebhook")
+	defer func() { end(err) }()
+	return t.usecase.DeleteWebhook(ctx, id)
+}
+
+func (t *tracedWebhookUsecase) EnableWebhook(ctx context.Context, id int64) (_ *entity.Webhook, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "WebhookUsecase", "EnableWebhook")
+	defer func() { end(err) }()
+	return t.usecase.EnableWebhook(ctx, id)
+}
+
+func (t *tracedWebhookUsecase) ListDeliveries(ctx context.Context, id int64, limit int) (_ []*entity.WebhookDelivery, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "WebhookUsecase", "ListDeliveries")
+	defer func() { end(err) }()
+	return t.usecase.ListDeliveries(ctx, id, limit)
+}
+
+func (t *tracedWebhookUsecase) DeleteExpiredDeliveries(ctx context.Context) (_ int64, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "WebhookUsecase", "DeleteExpiredDeliveries")
+	defer func() { end(err) }()
+	return t.usecase.DeleteExpiredDeliveries(ctx)
+}
+
+// ImportJobUsecaseWithTracing はusecaseの各メソッドの呼び出しをtracerのスパンで囲むImportJobUsecaseを返す
+func ImportJobUsecaseWithTracing(usecase ImportJobUsecase, tracer Tracer) ImportJobUsecase {
+	return &tracedImportJobUsecase{usecase: usecase, tracer: tracer}
+}
+
+type tracedImportJobUsecase struct {
+	usecase ImportJobUsecase
+	tracer  Tracer
+}
+
+func (t *tracedImportJobUsecase) StartImport(ctx context.Context, r io.Reader, opts ImportOptions) (_ *entity.ImportJob, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ImportJobUsecase", "StartImport")
+	defer func() { end(err) }()
+	return t.usecase.StartImport(ctx, r, opts)
+}
+
+func (t *tracedImportJobUsecase) GetImportJob(ctx context.Context, id int64) (_ *entity.ImportJob, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ImportJobUsecase", "GetImportJob")
+	defer func() { end(err) }()
+	return t.usecase.GetImportJob(ctx, id)
+}
+
+func (t *tracedImportJobUsecase) RunImportJob(ctx context.Context, task ImportTask) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ImportJobUsecase", "RunImportJob")
+	defer end(nil)
+	t.usecase.RunImportJob(ctx, task)
+}
+
+func (t *tracedImportJobUsecase) FailUnfinishedImportJobs(ctx context.Context) (_ int64, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ImportJobUsecase", "FailUnfinishedImportJobs")
+	defer func() { end(err) }()
+	return t.usecase.FailUnfinishedImportJobs(ctx)
+}
+
+func (t *tracedImportJobUsecase) DeleteExpiredImportJobs(ctx context.Context) (_ int64, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "ImportJobUsecase", "DeleteExpiredImportJobs")
+	defer func() { end(err) }()
+	return t.usecase.DeleteExpiredImportJobs(ctx)
+}
+
+// APIKeyUsecaseWithTracing はusecaseの各メソッドの呼び出しをtracerのスパンで囲むAPIKeyUsecaseを返す
+func APIKeyUsecaseWithTracing(usecase APIKeyUsecase, tracer Tracer) APIKeyUsecase {
+	return &tracedAPIKeyUsecase{usecase: usecase, tracer: tracer}
+}
+
+type tracedAPIKeyUsecase struct {
+	usecase APIKeyUsecase
+	tracer  Tracer
+}
+
+func (t *tracedAPIKeyUsecase) CreateAPIKey(ctx context.Context, label string, role entity.UserRole) (_ *entity.APIKey, _ string, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "APIKeyUsecase", "CreateAPIKey")
+	defer func() { end(err) }()
+	return t.usecase.CreateAPIKey(ctx, label, role)
+}
+
+func (t *tracedAPIKeyUsecase) ListAPIKeys(ctx context.Context) (_ []*entity.APIKey, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "APIKeyUsecase", "ListAPIKeys")
+	defer func() { end(err) }()
+	return t.usecase.ListAPIKeys(ctx)
+}
+
+func (t *tracedAPIKeyUsecase) RevokeAPIKey(ctx context.Context, id int64) (_ *entity.APIKey, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "APIKeyUsecase", "RevokeAPIKey")
+	defer func() { end(err) }()
+	return t.usecase.RevokeAPIKey(ctx, id)
+}
+
+func (t *tracedAPIKeyUsecase) SetAPIKeyRole(ctx context.Context, id int64, role entity.UserRole) (_ *entity.APIKey, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "APIKeyUsecase", "SetAPIKeyRole")
+	defer func() { end(err) }()
+	return t.usecase.SetAPIKeyRole(ctx, id, role)
+}
+
+func (t *tracedAPIKeyUsecase) AuthenticateAPIKey(ctx context.Context, key string) (_ *entity.APIKey, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "APIKeyUsecase", "AuthenticateAPIKey")
+	defer func() { end(err) }()
+	return t.usecase.AuthenticateAPIKey(ctx, key)
+}
+
+// AuthUsecaseWithTracing はusecaseの各メソッドの呼び出しをtracerのスパンで囲むAuthUsecaseを返す
+func AuthUsecaseWithTracing(usecase AuthUsecase, tracer Tracer) AuthUsecase {
+	return &tracedAuthUsecase{usecase: usecase, tracer: tracer}
+}
+
+type tracedAuthUsecase struct {
+	usecase AuthUsecase
+	tracer  Tracer
+}
+
+func (t *tracedAuthUsecase) Register(ctx context.Context, email, password string) (_ *entity.User, _ *AuthTokens, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "AuthUsecase", "Register")
+	defer func() { end(err) }()
+	return t.usecase.Register(ctx, email, password)
+}
+
+func (t *tracedAuthUsecase) Login(ctx context.Context, email, password string) (_ *AuthTokens, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "AuthUsecase", "Login")
+	defer func() { end(err) }()
+	return t.usecase.Login(ctx, email, password)
+}
+
+func (t *tracedAuthUsecase) Refresh(ctx context.Context, refreshToken string) (_ *AuthTokens, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "AuthUsecase", "Refresh")
+	defer func() { end(err) }()
+	return t.usecase.Refresh(ctx, refreshToken)
+}
+
+func (t *tracedAuthUsecase) AuthenticateAccessToken(ctx context.Context, token string) (_ *AccessTokenClaims, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "AuthUsecase", "AuthenticateAccessToken")
+	defer func() { end(err) }()
+	return t.usecase.AuthenticateAccessToken(ctx, token)
+}
+
+func (t *tracedAuthUsecase) ListUsers(ctx context.Context) (_ []*entity.User, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "AuthUsecase", "ListUsers")
+	defer func() { end(err) }()
+	return t.usecase.ListUsers(ctx)
+}
+
+func (t *tracedAuthUsecase) SetPassword(ctx context.Context, email, password string) (_ *entity.User, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "AuthUsecase", "SetPassword")
+	defer func() { end(err) }()
+	return t.usecase.SetPassword(ctx, email, password)
+}
+
+func (t *tracedAuthUsecase) SetRole(ctx context.Context, email string, role entity.UserRole) (_ *entity.User, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "AuthUsecase", "SetRole")
+	defer func() { end(err) }()
+	return t.usecase.SetRole(ctx, email, role)
+}
+
+// AuditUsecaseWithTracing はusecaseの各メソッドの呼び出しをtracerのスパンで囲むAuditUsecaseを返す
+func AuditUsecaseWithTracing(usecase AuditUsecase, tracer Tracer) AuditUsecase {
+	return &tracedAuditUsecase{usecase: usecase, tracer: tracer}
+}
+
+type tracedAuditUsecase struct {
+	usecase AuditUsecase
+	tracer  Tracer
+}
+
+func (t *tracedAuditUsecase) ListAuditLogs(ctx context.Context, filter entity.AuditLogFilter, limit, offset int) (_ *AuditLogList, err error) {
+	ctx, end := t.tracer.StartUsecase(ctx, "AuditUsecase", "ListAuditLogs")
+	defer func() { end(err) }()
+	return t.usecase.ListAuditLogs(ctx, filter, limit, offset)
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+type spanKey struct{}
+
+// 開始したスパンの名前と、終了時に受け取ったエラー
+type recordingTracer struct {
+	spans []string
+	errs  []error
+}
+
+func (r *recordingTracer) StartUsecase(ctx context.Context, usecase, method string) (context.Context, func(error)) {
+	return r.start(ctx, usecase+"."+method)
+}
+
+func (r *recordingTracer) StartQuery(ctx context.Context, repository, method string) (context.Context, func(error)) {
+	return r.start(ctx, repository+"."+method)
+}
+
+func (r *recordingTracer) start(ctx context.Context, name string) (context.Context, func(error)) {
+	r.spans = append(r.spans, name)
+	return context.WithValue(ctx, spanKey{}, name), func(err error) { r.errs = append(r.errs, err) }
+}
+
+// ctxが指定した名前のスパンの中かどうか
+func inSpan(name string) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(spanKey{}) == name })
+}
+
+func TestItemRepositoryWithTracing(t *testing.T) {
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("Count", inSpan("ItemRepository.Count"), entity.ItemFilter{}).Return(3, nil)
+	mockRepo.On("FindByID", inSpan("ItemRepository.FindByID"), int64(1)).Return(nil, assert.AnError)
+	tracer := &recordingTracer{}
+	repo := ItemRepositoryWithTracing(mockRepo, tracer)
+
+	count, err := repo.Count(context.Background(), entity.ItemFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	_, err = repo.FindByID(context.Background(), 1)
+	assert.ErrorIs(t, err, assert.AnError)
+
+	assert.Equal(t, []string{"ItemRepository.Count", "ItemRepository.FindByID"}, tracer.spans)
+	// 返したエラーをスパンに記録する
+	assert.Equal(t, []error{nil, assert.AnError}, tracer.errs)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCategoryUsecaseWithTracing(t *testing.T) {
+	mockRepo := new(MockCategoryRepository)
+	tracer := &recordingTracer{}
+	// ユースケースのスパンを開始してからリポジトリのスパンを開始する
+	mockRepo.On("FindByID", inSpan("CategoryRepository.FindByID"), int64(1)).Return(&entity.Category{ID: 1, Name: "時計"}, nil)
+	categoryUsecase := CategoryUsecaseWithTracing(NewCategoryUsecase(CategoryRepositoryWithTracing(mockRepo, tracer)), tracer)
+
+	category, err := categoryUsecase.GetCategory(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "時計", category.Name)
+
+	assert.Equal(t, []string{"CategoryUsecase.GetCategory", "CategoryRepository.FindByID"}, tracer.spans)
+	mockRepo.AssertExpectations(t)
+}
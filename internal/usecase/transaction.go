@@ -0,0 +1,17 @@
+package usecase
+
+import "context"
+
+// 複数のリポジトリの呼び出しを1つのトランザクションで実行する
+type Transactor interface {
+	// WithinTx runs fn in a transaction and commits it when fn returns nil; otherwise every write made
+	// through repositories called with fn's context is rolled back. Nested calls join the outer transaction
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// トランザクションを使わない場合のTransactor。fnをそのまま実行する
+type noTransactor struct{}
+
+func (noTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
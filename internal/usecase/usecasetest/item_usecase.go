@@ -0,0 +1,323 @@
+// ハンドラーなどのテストでデータベースの代わりに使うユースケースの実装
+package usecasetest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ItemUsecase はアイテムをメモリ上で保持するテスト用のusecase.ItemUsecase。
+// 一覧（最新の更新日時を含む）・取得（IDの一覧による取得を含む）・登録・更新・削除（一括削除を含む）・カテゴリー集計を実装し、それ以外のメソッドは埋め込んだインターフェースに委ねる（呼ぶとpanicする）。
+// 集計の金額は換算せず、アイテムの通貨の金額をそのまま合計する
+type ItemUsecase struct {
+	usecase.ItemUsecase
+
+	mu         sync.Mutex
+	items      map[int64]*entity.Item
+	nextID     int64
+	categories *entity.CategorySet
+}
+
+var _ usecase.ItemUsecase = (*ItemUsecase)(nil)
+
+// categoriesには登録済みのカテゴリーを渡す。itemsはIDが0の場合に採番して登録する
+func NewItemUsecase(categories *entity.CategorySet, items ...*entity.Item) *ItemUsecase {
+	u := &ItemUsecase{
+		items:      make(map[int64]*entity.Item),
+		nextID:     1,
+		categories: categories,
+	}
+	for _, item := range items {
+		u.store(item)
+	}
+	return u
+}
+
+func (u *ItemUsecase) store(item *entity.Item) *entity.Item {
+	stored := *item
+	if stored.ID == 0 {
+		stored.ID = u.nextID
+	}
+	if stored.ID >= u.nextID {
+		u.nextID = stored.ID + 1
+	}
+	u.items[stored.ID] = &stored
+	return copyItem(&stored)
+}
+
+func copyItem(item *entity.Item) *entity.Item {
+	copied := *item
+	return &copied
+}
+
+// 削除されていないアイテムをID順に返す。絞り込みはCategory（完全一致）とBrand（大文字小文字を区別しない部分一致）のみに対応する
+func (u *ItemUsecase) GetAllItems(ctx context.Context, input usecase.ListItemsInput) (*usecase.ItemList, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	limit := input.Limit
+	if limit == 0 {
+		limit = usecase.DefaultListLimit
+	}
+
+	matched := make([]*entity.Item, 0)
+	for _, item := range u.items {
+		if item.DeletedAt != nil || !matchesFilter(item, input.Filter) {
+			continue
+		}
+		matched = append(matched, copyItem(item))
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+
+	list := &usecase.ItemList{Items: []*entity.Item{}, Total: len(matched), Limit: limit, Offset: input.Offset}
+	if input.Offset < len(matched) {
+		end := input.Offset + limit
+		if end > len(matched) {
+			end = len(matched)
+		}
+		list.Items = matched[input.Offset:end]
+	}
+	return list, nil
+}
+
+// 絞り込みはGetAllItemsと同じ条件に対応し、削除したアイテムも含める
+func (u *ItemUsecase) GetItemsLastModified(ctx context.Context, filter entity.ItemFilter) (*time.Time, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	var latest *time.Time
+	for _, item := range u.items {
+		if matchesFilter(item, filter) && (latest == nil || item.UpdatedAt.After(*latest)) {
+			updatedAt := item.UpdatedAt
+			latest = &updatedAt
+		}
+	}
+	return latest, nil
+}
+
+// Category（完全一致）とBrand（大文字小文字を区別しない部分一致）の絞り込み
+func matchesFilter(item *entity.Item, filter entity.ItemFilter) bool {
+	return (filter.Category == "" || item.Category == filter.Category) &&
+		(filter.Brand == "" || strings.Contains(strings.ToLower(item.Brand), strings.ToLower(filter.Brand)))
+}
+
+func (u *ItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	item, ok := u.items[id]
+	if !ok || item.DeletedAt != nil {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	return copyItem(item), nil
+}
+
+// 削除されていないアイテムを指定した順序で返す。IDの件数の上限は確認しない
+func (u *ItemUsecase) GetItemsByIDs(ctx context.Context, ids []int64) (*usecase.ItemBatch, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	batch := &usecase.ItemBatch{Items: []*entity.Item{}, MissingIDs: []int64{}}
+	for _, id := range ids {
+		if item, ok := u.items[id]; ok && item.DeletedAt == nil {
+			batch.Items = append(batch.Items, copyItem(item))
+		} else {
+			batch.MissingIDs = append(batch.MissingIDs, id)
+		}
+	}
+	return batch, nil
+}
+
+func (u *ItemUsecase) CreateItem(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+	var purchaseDate entity.PurchaseDate
+	if strings.TrimSpace(input.PurchaseDate) != "" {
+		d, err := entity.ParsePurchaseDate(input.PurchaseDate)
+		if err != nil {
+			return nil, err
+		}
+		purchaseDate = d
+	}
+
+	item, err := entity.NewItem(entity.NewItemInput{
+		Name:             input.Name,
+		Category:         input.Category,
+		Brand:            input.Brand,
+		PurchasePrice:    int64(input.PurchasePrice),
+		Currency:         input.Currency,
+		PurchaseDate:     purchaseDate,
+		SerialNumber:     input.SerialNumber,
+		Condition:        input.Condition,
+		Notes:            input.Notes,
+		PurchaseLocation: input.PurchaseLocation,
+		Tags:             input.Tags,
+		Categories:       u.categories,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.store(item), nil
+}
+
+// 指定されたフィールドのみを更新し、バージョンを1つ進める
+func (u *ItemUsecase) UpdateItem(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+	if input.IsEmpty() {
+		return nil, fmt.Errorf("%w: at least one field must be specified for update", domainErrors.ErrInvalidInput)
+	}
+	if input.Version == nil {
+		return nil, domainErrors.NewValidationErrors(domainErrors.Required("version"))
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	stored, ok := u.items[id]
+	if !ok || stored.DeletedAt != nil {
+		return nil, domainErrors.ErrItemNotFound
+	}
+
+	item := copyItem(stored)
+	if err := item.UpdatePartial(*input.Version, input.Name, input.Category, input.Brand, input.PurchasePrice.Int64(), input.Currency, input.PurchaseDate, input.SerialNumber, input.Condition, input.Notes, input.PurchaseLocation, input.Tags, u.categories); err != nil {
+		return nil, err
+	}
+	item.Version++
+	return u.store(item), nil
+}
+
+// 登録と同じフィールドをすべて置き換え、バージョンを1つ進める
+func (u *ItemUsecase) ReplaceItem(ctx context.Context, id int64, input usecase.ReplaceItemInput) (*entity.Item, error) {
+	if input.Version == nil {
+		return nil, domainErrors.NewValidationErrors(domainErrors.Required("version"))
+	}
+	purchaseDate, err := entity.ParsePurchaseDate(input.PurchaseDate)
+	if err != nil {
+		return nil, domainErrors.FieldErrorFrom("purchase_date", err)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	stored, ok := u.items[id]
+	if !ok || stored.DeletedAt != nil {
+		return nil, domainErrors.ErrItemNotFound
+	}
+
+	item := copyItem(stored)
+	if err := item.Update(*input.Version, input.Name, input.Category, input.Brand, int64(input.PurchasePrice), input.Currency, purchaseDate, input.SerialNumber, input.Condition, input.Notes, input.PurchaseLocation, input.Tags, u.categories); err != nil {
+		return nil, err
+	}
+	item.Version++
+	return u.store(item), nil
+}
+
+// 論理削除する。expectedVersionを指定した場合は、そのバージョンのときのみ削除する
+func (u *ItemUsecase) DeleteItem(ctx context.Context, id int64, expectedVersion *int64) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	item, ok := u.items[id]
+	if !ok || item.DeletedAt != nil {
+		return domainErrors.ErrItemNotFound
+	}
+	if expectedVersion != nil {
+		if err := item.CheckVersion(*expectedVersion); err != nil {
+			return err
+		}
+	}
+
+	deletedAt := entity.Now()
+	item.DeletedAt = &deletedAt
+	item.UpdatedAt = deletedAt
+	return nil
+}
+
+// 論理削除してIDごとの結果を返す。bestEffortでない場合は、1件でも見つからなければどのアイテムも削除しない。
+// IDの重複と件数の上限は確認しない
+func (u *ItemUsecase) BulkDeleteItems(ctx context.Context, ids []int64, bestEffort bool) ([]usecase.BulkDeleteResult, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	results := make([]usecase.BulkDeleteResult, 0, len(ids))
+	var notFound []usecase.BulkDeleteResult
+	for _, id := range ids {
+		if item, ok := u.items[id]; !ok || item.DeletedAt != nil {
+			notFound = append(notFound, usecase.BulkDeleteResult{ID: id, Status: usecase.BulkDeleteStatusNotFound})
+			results = append(results, notFound[len(notFound)-1])
+			continue
+		}
+		results = append(results, usecase.BulkDeleteResult{ID: id, Status: usecase.BulkDeleteStatusDeleted})
+	}
+	if len(notFound) > 0 && !bestEffort {
+		return nil, &usecase.BulkDeleteError{Results: notFound}
+	}
+
+	deletedAt := entity.Now()
+	for _, result := range results {
+		if result.Status == usecase.BulkDeleteStatusDeleted {
+			u.items[result.ID].DeletedAt = &deletedAt
+			u.items[result.ID].UpdatedAt = deletedAt
+		}
+	}
+	return results, nil
+}
+
+// 登録済みのカテゴリーの順に、削除されていないアイテムを集計する
+func (u *ItemUsecase) GetCategorySummary(ctx context.Context) (*usecase.CategorySummary, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	summary := &usecase.CategorySummary{Categories: make([]*entity.CategoryStats, 0), Currency: entity.DefaultCurrency}
+	byCategory := make(map[string]*entity.CategoryStats)
+	for _, name := range u.categories.Names() {
+		stats := &entity.CategoryStats{Category: name, Conditions: entity.NewConditionStatsList()}
+		byCategory[name] = stats
+		summary.Categories = append(summary.Categories, stats)
+	}
+
+	for _, item := range u.items {
+		if item.DeletedAt != nil {
+			continue
+		}
+		if item.Status == entity.ItemStatusSold {
+			summary.Sold.Count++
+			summary.Sold.TotalPrice += item.PurchasePrice
+			continue
+		}
+
+		stats, ok := byCategory[item.Category]
+		if !ok {
+			continue
+		}
+		stats.Count++
+		stats.TotalPrice += item.PurchasePrice
+		for _, conditionStats := range stats.Conditions {
+			if item.Condition != nil && conditionStats.Condition == *item.Condition {
+				conditionStats.Count++
+				conditionStats.TotalPrice += item.PurchasePrice
+			}
+		}
+		summary.Total++
+		summary.TotalPrice += item.PurchasePrice
+	}
+
+	for _, stats := range summary.Categories {
+		if stats.Count > 0 {
+			stats.AveragePrice = float64(stats.TotalPrice) / float64(stats.Count)
+		}
+	}
+	if summary.Total > 0 {
+		summary.AveragePrice = float64(summary.TotalPrice) / float64(summary.Total)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,84 @@
+package usecasetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+var testCategories = entity.NewCategorySet("時計", "バッグ")
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestItemUsecase(t *testing.T) {
+	ctx := context.Background()
+	u := NewItemUsecase(testCategories, &entity.Item{ID: 10, Name: "バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2000000, Currency: "JPY", PurchaseDate: entity.MustParsePurchaseDate("2022-05-01"), Version: entity.InitialItemVersion})
+
+	t.Run("正常系: 登録すると既存のIDの次を採番する", func(t *testing.T) {
+		item, err := u.CreateItem(ctx, usecase.CreateItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(11), item.ID)
+
+		got, err := u.GetItemByID(ctx, 11)
+		require.NoError(t, err)
+		assert.Equal(t, "デイトナ", got.Name)
+	})
+
+	t.Run("異常系: 登録時のバリデーションはエンティティと同じ", func(t *testing.T) {
+		_, err := u.CreateItem(ctx, usecase.CreateItemInput{Name: "デイトナ", Category: "家電", Brand: "ROLEX", PurchaseDate: "2023-01-15"})
+		assert.ErrorIs(t, err, domainErrors.ErrValidation)
+	})
+
+	t.Run("正常系: 一覧は絞り込み・ページングできる", func(t *testing.T) {
+		list, err := u.GetAllItems(ctx, usecase.ListItemsInput{Filter: entity.ItemFilter{Brand: "rol"}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, list.Total)
+		assert.Equal(t, usecase.DefaultListLimit, list.Limit)
+
+		list, err = u.GetAllItems(ctx, usecase.ListItemsInput{Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 2, list.Total)
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, int64(11), list.Items[0].ID)
+	})
+
+	t.Run("正常系: 更新するとバージョンが進み、古いバージョンでは競合する", func(t *testing.T) {
+		item, err := u.UpdateItem(ctx, 10, usecase.UpdateItemInput{Name: stringPtr("バーキン 30"), Version: int64Ptr(1)})
+		require.NoError(t, err)
+		assert.Equal(t, "バーキン 30", item.Name)
+		assert.Equal(t, int64(2), item.Version)
+
+		_, err = u.UpdateItem(ctx, 10, usecase.UpdateItemInput{Name: stringPtr("バーキン 35"), Version: int64Ptr(1)})
+		assert.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+	})
+
+	t.Run("正常系: カテゴリー集計は登録済みのカテゴリーの順", func(t *testing.T) {
+		summary, err := u.GetCategorySummary(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, summary.Total)
+		assert.Equal(t, int64(3500000), summary.TotalPrice)
+		require.Len(t, summary.Categories, 2)
+		assert.Equal(t, "時計", summary.Categories[0].Category)
+		assert.Equal(t, 1, summary.Categories[0].Count)
+	})
+
+	t.Run("正常系: 削除したアイテムは取得できない", func(t *testing.T) {
+		require.NoError(t, u.DeleteItem(ctx, 11, nil))
+
+		_, err := u.GetItemByID(ctx, 11)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.ErrorIs(t, u.DeleteItem(ctx, 11, nil), domainErrors.ErrItemNotFound)
+	})
+}
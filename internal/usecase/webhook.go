@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 配信の記録の件数のデフォルト値と上限
+const (
+	DefaultWebhookDeliveryLimit = 50
+	MaxWebhookDeliveryLimit     = 200
+)
+
+type WebhookUsecase interface {
+	ListWebhooks(ctx context.Context) ([]*entity.Webhook, error)
+	CreateWebhook(ctx context.Context, input CreateWebhookInput) (*entity.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	EnableWebhook(ctx context.Context, id int64) (*entity.Webhook, error)
+	ListDeliveries(ctx context.Context, id int64, limit int) ([]*entity.WebhookDelivery, error)
+	DeleteExpiredDeliveries(ctx context.Context) (int64, error)
+}
+
+type CreateWebhookInput struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+type webhookUsecase struct {
+	webhookRepo WebhookRepository
+}
+
+func NewWebhookUsecase(webhookRepo WebhookRepository) WebhookUsecase {
+	return &webhookUsecase{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func (u *webhookUsecase) ListWebhooks(ctx context.Context) ([]*entity.Webhook, error) {
+	webhooks, err := u.webhookRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (u *webhookUsecase) CreateWebhook(ctx context.Context, input CreateWebhookInput) (*entity.Webhook, error) {
+	webhook, err := entity.NewWebhook(input.URL, input.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := u.webhookRepo.Create(ctx, webhook)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *webhookUsecase) DeleteWebhook(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	if err := u.webhookRepo.Delete(ctx, id); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrWebhookNotFound
+		}
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+// 失敗が続いて無効になったWebhookを有効に戻し、失敗の回数を0にする
+func (u *webhookUsecase) EnableWebhook(ctx context.Context, id int64) (*entity.Webhook, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	webhook, err := u.webhookRepo.Enable(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to enable webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// Webhookへの送信の記録を新しい順に取得する。limitが0の場合はデフォルト値を使い、上限を超える場合は上限に丸める
+func (u *webhookUsecase) ListDeliveries(ctx context.Context, id int64, limit int) ([]*entity.WebhookDelivery, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("%w: limit must be 0 or greater", domainErrors.ErrInvalidInput)
+	}
+	if limit == 0 {
+		limit = DefaultWebhookDeliveryLimit
+	}
+	if limit > MaxWebhookDeliveryLimit {
+		limit = MaxWebhookDeliveryLimit
+	}
+
+	if _, err := u.webhookRepo.FindByID(ctx, id); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve webhook: %w", err)
+	}
+
+	deliveries, err := u.webhookRepo.FindDeliveries(ctx, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// 保持期間を過ぎた送信の記録を削除し、削除した件数を返す
+func (u *webhookUsecase) DeleteExpiredDeliveries(ctx context.Context) (int64, error) {
+	deleted, err := u.webhookRepo.DeleteDeliveriesBefore(ctx, entity.Now().Add(-entity.WebhookDeliveryRetention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired webhook deliveries: %w", err)
+	}
+
+	return deleted, nil
+}
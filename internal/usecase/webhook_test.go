@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 使わないメソッドは埋め込んだインターフェースに委ねる（呼ぶとpanicする）
+type stubWebhookRepository struct {
+	WebhookRepository
+	webhooks  map[int64]*entity.Webhook
+	created   *entity.Webhook
+	lastLimit int
+}
+
+func (r *stubWebhookRepository) FindByID(ctx context.Context, id int64) (*entity.Webhook, error) {
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, domainErrors.ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+func (r *stubWebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) (*entity.Webhook, error) {
+	r.created = webhook
+	return webhook, nil
+}
+
+func (r *stubWebhookRepository) FindDeliveries(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error) {
+	r.lastLimit = limit
+	return []*entity.WebhookDelivery{}, nil
+}
+
+func TestWebhookUsecase_CreateWebhook(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       CreateWebhookInput
+		expectedErr error
+		field       string
+	}{
+		{name: "正常系: 前後の空白を除いて登録する", input: CreateWebhookInput{URL: " https://example.com/hooks ", Secret: "0123456789abcdef"}},
+		{name: "異常系: 相対URL", input: CreateWebhookInput{URL: "/hooks", Secret: "0123456789abcdef"}, expectedErr: domainErrors.ErrInvalidInput, field: "url"},
+		{name: "異常系: http・https以外のURL", input: CreateWebhookInput{URL: "ftp://example.com/hooks", Secret: "0123456789abcdef"}, expectedErr: domainErrors.ErrInvalidInput, field: "url"},
+		{name: "異常系: 短すぎるシークレット", input: CreateWebhookInput{URL: "https://example.com/hooks", Secret: "short"}, expectedErr: domainErrors.ErrInvalidInput, field: "secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubWebhookRepository{}
+
+			webhook, err := NewWebhookUsecase(repo).CreateWebhook(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				var errs domainErrors.ValidationErrors
+				require.True(t, errors.As(err, &errs))
+				assert.Equal(t, tt.field, errs[0].Field)
+				assert.Nil(t, repo.created)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "https://example.com/hooks", webhook.URL)
+			assert.True(t, webhook.Enabled)
+		})
+	}
+}
+
+func TestWebhookUsecase_ListDeliveries(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            int64
+		limit         int
+		expectedLimit int
+		expectedErr   error
+	}{
+		{name: "正常系: 0はデフォルト値", id: 1, limit: 0, expectedLimit: DefaultWebhookDeliveryLimit},
+		{name: "正常系: 上限を超える場合は上限に丸める", id: 1, limit: MaxWebhookDeliveryLimit + 1, expectedLimit: MaxWebhookDeliveryLimit},
+		{name: "異常系: 存在しないWebhook", id: 2, expectedErr: domainErrors.ErrWebhookNotFound},
+		{name: "異常系: 無効なID", id: 0, expectedErr: domainErrors.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &stubWebhookRepository{webhooks: map[int64]*entity.Webhook{1: {ID: 1}}}
+
+			_, err := NewWebhookUsecase(repo).ListDeliveries(context.Background(), tt.id, tt.limit)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLimit, repo.lastLimit)
+		})
+	}
+}